@@ -0,0 +1,20 @@
+package main
+
+// highContrast reports whether ui.accessibility.high_contrast is set: the dashboard then
+// uses useHighContrastTheme and marks in text what it would otherwise show only in color
+func (m Model) highContrast() bool {
+	return m.config != nil && m.config.UI.Accessibility.HighContrast
+}
+
+// reducedMotion reports whether ui.accessibility.reduced_motion is set: nothing on the
+// dashboard then moves or flashes by itself
+func (m Model) reducedMotion() bool {
+	return m.config != nil && m.config.UI.Accessibility.ReducedMotion
+}
+
+// fetchingMarker stands in for the spinner in a tile's title with reduced motion
+const fetchingMarker = "…"
+
+// staleMarker starts a stale tile's refresh status in high contrast, which otherwise
+// tells it apart only by color
+const staleMarker = "! "
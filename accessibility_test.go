@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestHighContrastTheme(t *testing.T) {
+	saved := []lipgloss.AdaptiveColor{colorTitle, colorTitleBar, colorBar, colorMuted, colorWarn, colorAlert, colorAccent, colorOnAccent, colorBorder}
+	defer func() {
+		colorTitle, colorTitleBar, colorBar, colorMuted, colorWarn = saved[0], saved[1], saved[2], saved[3], saved[4]
+		colorAlert, colorAccent, colorOnAccent, colorBorder = saved[5], saved[6], saved[7], saved[8]
+	}()
+
+	useHighContrastTheme()
+	if colorTitle.Dark != "231" || colorAccent.Dark != "226" || colorOnAccent.Dark != "16" || colorAccent.Light != "18" {
+		t.Errorf("Expected white titles and a yellow or navy focus, got %v, %v and %v", colorTitle, colorAccent, colorOnAccent)
+	}
+}
+
+func TestHighContrastMarkers(t *testing.T) {
+	m := benchmarkModel(120, 40)
+	m.widgets = m.widgets[:4]
+	title := m.widgets[0].title
+	if view := m.View(); strings.Contains(view, "▶ "+title+" item 1") {
+		t.Errorf("Expected no selection marker by default, got:\n%s", view)
+	}
+
+	m.config = &Config{}
+	m.config.UI.Accessibility.HighContrast = true
+	if view := m.View(); !strings.Contains(view, "▶ "+title+" item 1") || strings.Contains(view, "▶ "+title+" item 2") {
+		t.Errorf("Expected a marker on the selected item only, got:\n%s", view)
+	}
+
+	tile := WidgetTile{width: 40, status: "updated 2h ago", stale: true}
+	if footer := tile.footer(0, 0, 0); strings.Contains(footer, staleMarker) {
+		t.Errorf("Expected a stale status in color only by default, got %q", footer)
+	}
+	tile.markers = true
+	if footer := tile.footer(0, 0, 0); !strings.Contains(footer, staleMarker+"updated 2h ago") {
+		t.Errorf("Expected a stale status marked in text, got %q", footer)
+	}
+}
+
+func TestReducedMotion(t *testing.T) {
+	m := benchmarkModel(120, 40)
+	m.config = &Config{}
+	m.config.UI.Accessibility.ReducedMotion = true
+	if cmd := m.startFetch("news", 1); cmd != nil {
+		t.Error("Expected no spinner ticks with reduced motion")
+	}
+	if frame := m.fetchSpinner("news"); frame != fetchingMarker {
+		t.Errorf("Expected %q in place of the spinner, got %q", fetchingMarker, frame)
+	}
+	m.finishFetch("news", 1)
+	if frame := m.fetchSpinner("news"); frame != "" {
+		t.Errorf("Expected no marker once the fetch is done, got %q", frame)
+	}
+}
@@ -2,7 +2,11 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
 )
 
 // TestSpecificAddresses tests the traffic plugin with real Bangalore addresses
@@ -83,8 +87,8 @@ func TestDifferentAddressFormats(t *testing.T) {
 	}
 }
 
-// ExampleAddressConfigurations shows example configurations
-func ExampleAddressConfigurations() {
+// Example_addressConfigurations shows example configurations
+func Example_addressConfigurations() {
 	fmt.Println("Example Traffic Widget Configurations:")
 	fmt.Println()
 
@@ -127,3 +131,65 @@ func ExampleAddressConfigurations() {
 		fmt.Println()
 	}
 }
+
+func FuzzParseLocationConfig(f *testing.F) {
+	for _, seed := range []string{
+		`origin: "Manyata Tech Park, Bengaluru"`,
+		`origin: {latitude: 12.9716, longitude: 77.5946, name: Home}`,
+		`origin: {latitude: 12, longitude: 77}`,
+		`origin: {address: "ITPL", latitude: "12.9"}`,
+		`origin: ""`,
+		`origin: ~`,
+		`origin: [a, b]`,
+		`origin: {name: 5}`,
+		`destination: 560066`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		var config map[string]interface{}
+		if err := yaml.Unmarshal([]byte(data), &config); err != nil {
+			return
+		}
+		plugin := NewOSRMTrafficPlugin()
+		var location LocationConfig
+		if err := parseLocationConfig("origin", config, &location); err != nil {
+			return
+		}
+		hasCoords := location.Latitude != 0 && location.Longitude != 0
+		if strings.TrimSpace(location.Address) == "" && !hasCoords {
+			t.Errorf("Expected an address or coordinates from %q, got %+v", data, location)
+		}
+		if plugin.getLocationDisplayName(location) == "" {
+			t.Errorf("Expected a display name for %+v", location)
+		}
+	})
+}
+
+func FuzzGetLocationShortName(f *testing.F) {
+	for _, seed := range []string{
+		"Manyata Tech Park, Thanisandra Main Road, Bengaluru, Karnataka 560045, India",
+		"Outer Ring Road, Marathahalli, Bengaluru, Karnataka",
+		"ಮಾನ್ಯತಾ ಟೆಕ್ ಪಾರ್ಕ್, ಬೆಂಗಳೂರು",
+		"Straße des 17. Juni, Berlin",
+		", , Whitefield",
+		",",
+		"",
+		"\xff\xfe, Koramangala",
+	} {
+		f.Add(seed)
+	}
+	plugin := NewOSRMTrafficPlugin()
+	f.Fuzz(func(t *testing.T, address string) {
+		name := plugin.getLocationShortName(address)
+		if strings.Trim(address, ", \t\n\v\f\r\u0085 ") != "" && strings.TrimSpace(name) == "" {
+			t.Errorf("Expected a name from %q", address)
+		}
+		if !strings.Contains(address, name) {
+			t.Errorf("Expected %q to be part of %q", name, address)
+		}
+		if utf8.ValidString(address) && !utf8.ValidString(name) {
+			t.Errorf("Expected valid UTF-8 from %q, got %q", address, name)
+		}
+	})
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// AirQuality is the latest air quality at a location on the US EPA AQI scale
+type AirQuality struct {
+	Location string
+	AQI      int
+	PM25     float64 // µg/m³; zero when the provider only reports the index
+	PM10     float64 // µg/m³; zero when the provider only reports the index
+	Dominant string  // main pollutant, e.g. pm25
+	Updated  time.Time
+	URL      string
+}
+
+// aqiLevel is a band of the US EPA AQI scale
+type aqiLevel struct {
+	max    int
+	name   string
+	status string
+	advice string
+}
+
+// aqiLevels are the US EPA AQI bands, lowest first
+var aqiLevels = []aqiLevel{
+	{50, "Good", "🟢", "Air quality is satisfactory"},
+	{100, "Moderate", "🟡", "Unusually sensitive people should limit long outdoor exertion"},
+	{150, "Unhealthy for Sensitive Groups", "🟠", "Sensitive groups should reduce long or heavy outdoor exertion"},
+	{200, "Unhealthy", "🔴", "Reduce long or heavy outdoor exertion; sensitive groups avoid it"},
+	{300, "Very Unhealthy", "🟣", "Avoid long or heavy outdoor exertion"},
+	{1 << 30, "Hazardous", "🟤", "Avoid all outdoor physical activity"},
+}
+
+// levelForAQI returns the band an AQI value falls in
+func levelForAQI(aqi int) aqiLevel {
+	for _, level := range aqiLevels {
+		if aqi <= level.max {
+			return level
+		}
+	}
+	return aqiLevels[len(aqiLevels)-1]
+}
+
+// pm25AQI converts a 24-hour PM2.5 concentration in µg/m³ to the US EPA AQI, using the
+// breakpoints revised in 2024
+func pm25AQI(concentration float64) int {
+	breakpoints := []struct{ cLow, cHigh, iLow, iHigh float64 }{
+		{0, 9.0, 0, 50},
+		{9.1, 35.4, 51, 100},
+		{35.5, 55.4, 101, 150},
+		{55.5, 125.4, 151, 200},
+		{125.5, 225.4, 201, 300},
+		{225.5, 325.4, 301, 500},
+	}
+	// Concentrations are truncated to one decimal, as the EPA specifies
+	c := math.Floor(concentration*10+1e-9) / 10
+	for _, bp := range breakpoints {
+		if c <= bp.cHigh {
+			return int((bp.iHigh-bp.iLow)/(bp.cHigh-bp.cLow)*(c-bp.cLow) + bp.iLow + 0.5)
+		}
+	}
+	return 500
+}
+
+// cityName returns the city of a location such as "Bengaluru,IN"
+func cityName(location string) string {
+	city, _, _ := strings.Cut(location, ",")
+	return strings.TrimSpace(city)
+}
+
+// WAQIAirQualityPlugin fetches the air quality of a city or station from the World Air
+// Quality Index project
+type WAQIAirQualityPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	token       string
+	station     string
+	apiURL      string
+	client      *http.Client
+	lastData    *AirQuality
+}
+
+// NewWAQIAirQualityPlugin creates a new WAQI air quality plugin
+func NewWAQIAirQualityPlugin() *WAQIAirQualityPlugin {
+	return &WAQIAirQualityPlugin{
+		id:          "waqi",
+		pluginType:  "air-quality",
+		name:        "World Air Quality Index",
+		version:     "1.0.0",
+		description: "Shows the AQI of a city or station from aqicn.org",
+		author:      "GoDay Team",
+		token:       os.Getenv("WAQI_TOKEN"),
+		apiURL:      "https://api.waqi.info",
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// GetID returns the plugin ID
+func (wp *WAQIAirQualityPlugin) GetID() string {
+	return wp.id
+}
+
+// GetType returns the plugin type
+func (wp *WAQIAirQualityPlugin) GetType() string {
+	return wp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (wp *WAQIAirQualityPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        wp.name,
+		Version:     wp.version,
+		Description: wp.description,
+		Author:      wp.author,
+		Type:        wp.pluginType,
+		Config: map[string]string{
+			"has_token": fmt.Sprintf("%t", wp.token != ""),
+			"station":   wp.station,
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (wp *WAQIAirQualityPlugin) Initialize(config map[string]interface{}) error {
+	if token, ok := config["token"].(string); ok && token != "" {
+		wp.token = token
+	}
+	if station, ok := config["station"].(string); ok && station != "" {
+		wp.station = station
+	} else if city, ok := config["city"].(string); ok {
+		wp.station = strings.ToLower(cityName(city))
+	}
+	return nil
+}
+
+// Fetch retrieves the station's latest AQI
+func (wp *WAQIAirQualityPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if wp.token == "" {
+		return wp.lastData, fmt.Errorf("WAQI token not configured (widgets.air_quality.token or WAQI_TOKEN)")
+	}
+
+	var feed struct {
+		Status string          `json:"status"`
+		Data   json.RawMessage `json:"data"`
+	}
+	endpoint := fmt.Sprintf("%s/feed/%s/?token=%s", wp.apiURL, url.PathEscape(wp.station), url.QueryEscape(wp.token))
+	if err := fetchJSON(ctx, wp.client, "WAQI", endpoint, &feed); err != nil {
+		return wp.lastData, err
+	}
+	// Errors come back with status 200 and the message as data
+	if feed.Status != "ok" {
+		var message string
+		json.Unmarshal(feed.Data, &message)
+		return wp.lastData, fmt.Errorf("WAQI: %s", message)
+	}
+
+	var data struct {
+		AQI      json.Number `json:"aqi"` // "-" when the station has no reading
+		Dominant string      `json:"dominentpol"`
+		City     struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"city"`
+		Time struct {
+			ISO string `json:"iso"`
+		} `json:"time"`
+	}
+	if err := json.Unmarshal(feed.Data, &data); err != nil {
+		return wp.lastData, err
+	}
+	aqi, err := data.AQI.Int64()
+	if err != nil {
+		return wp.lastData, fmt.Errorf("WAQI station %s has no current reading", wp.station)
+	}
+	updated, _ := time.Parse(time.RFC3339, data.Time.ISO)
+
+	quality := &AirQuality{
+		Location: data.City.Name,
+		AQI:      int(aqi),
+		Dominant: data.Dominant,
+		Updated:  updated,
+		URL:      data.City.URL,
+	}
+	wp.lastData = quality
+	return quality, nil
+}
+
+// Cleanup performs cleanup
+func (wp *WAQIAirQualityPlugin) Cleanup() error {
+	return nil
+}
+
+// OWMAirQualityPlugin fetches air pollution from OpenWeatherMap with the weather API key
+// and rates it on the US EPA AQI scale
+type OWMAirQualityPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	apiKey      string
+	city        string
+	apiURL      string
+	client      *http.Client
+	lastData    *AirQuality
+	latitude    float64
+	longitude   float64
+	located     string // city the coordinates were looked up for
+}
+
+// NewOWMAirQualityPlugin creates a new OpenWeatherMap air quality plugin
+func NewOWMAirQualityPlugin() *OWMAirQualityPlugin {
+	return &OWMAirQualityPlugin{
+		id:          "owm-air-quality",
+		pluginType:  "air-quality",
+		name:        "OpenWeatherMap Air Pollution",
+		version:     "1.0.0",
+		description: "Shows PM2.5 and the AQI from the OpenWeatherMap Air Pollution API",
+		author:      "GoDay Team",
+		apiURL:      "http://api.openweathermap.org",
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// GetID returns the plugin ID
+func (op *OWMAirQualityPlugin) GetID() string {
+	return op.id
+}
+
+// GetType returns the plugin type
+func (op *OWMAirQualityPlugin) GetType() string {
+	return op.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (op *OWMAirQualityPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        op.name,
+		Version:     op.version,
+		Description: op.description,
+		Author:      op.author,
+		Type:        op.pluginType,
+		Config: map[string]string{
+			"has_api_key": fmt.Sprintf("%t", op.apiKey != ""),
+			"city":        op.city,
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (op *OWMAirQualityPlugin) Initialize(config map[string]interface{}) error {
+	if apiKey, ok := config["api_key"].(string); ok && apiKey != "YOUR_OWM_API_KEY" {
+		op.apiKey = apiKey
+	}
+	if city, ok := config["city"].(string); ok {
+		op.city = city
+	}
+	return nil
+}
+
+// Fetch looks up the city's coordinates once, then retrieves the current pollution
+func (op *OWMAirQualityPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if op.apiKey == "" {
+		return op.lastData, fmt.Errorf("OpenWeatherMap API key not configured (widgets.weather.api_key)")
+	}
+
+	if op.located != op.city {
+		var places []struct {
+			Name string  `json:"name"`
+			Lat  float64 `json:"lat"`
+			Lon  float64 `json:"lon"`
+		}
+		endpoint := fmt.Sprintf("%s/geo/1.0/direct?q=%s&limit=1&appid=%s", op.apiURL, url.QueryEscape(op.city), url.QueryEscape(op.apiKey))
+		if err := fetchJSON(ctx, op.client, "OpenWeatherMap", endpoint, &places); err != nil {
+			return op.lastData, err
+		}
+		if len(places) == 0 {
+			return op.lastData, fmt.Errorf("OpenWeatherMap does not know the location %q", op.city)
+		}
+		op.latitude, op.longitude = places[0].Lat, places[0].Lon
+		op.located = op.city
+	}
+
+	var pollution struct {
+		List []struct {
+			Dt         int64 `json:"dt"`
+			Components struct {
+				PM25 float64 `json:"pm2_5"`
+				PM10 float64 `json:"pm10"`
+			} `json:"components"`
+		} `json:"list"`
+	}
+	endpoint := fmt.Sprintf("%s/data/2.5/air_pollution?lat=%.4f&lon=%.4f&appid=%s", op.apiURL, op.latitude, op.longitude, url.QueryEscape(op.apiKey))
+	if err := fetchJSON(ctx, op.client, "OpenWeatherMap", endpoint, &pollution); err != nil {
+		return op.lastData, err
+	}
+	if len(pollution.List) == 0 {
+		return op.lastData, fmt.Errorf("OpenWeatherMap has no air pollution data for %q", op.city)
+	}
+
+	current := pollution.List[0]
+	quality := &AirQuality{
+		Location: cityName(op.city),
+		AQI:      pm25AQI(current.Components.PM25),
+		PM25:     current.Components.PM25,
+		PM10:     current.Components.PM10,
+		Dominant: "pm25",
+		Updated:  time.Unix(current.Dt, 0),
+		URL:      fmt.Sprintf("https://openweathermap.org/weathermap?basemap=map&cities=true&layer=pressure&lat=%.4f&lon=%.4f&zoom=10", op.latitude, op.longitude),
+	}
+	op.lastData = quality
+	return quality, nil
+}
+
+// Cleanup performs cleanup
+func (op *OWMAirQualityPlugin) Cleanup() error {
+	return nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWAQIAirQualityPluginFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("token") != "secret" {
+			fmt.Fprint(w, `{"status":"error","data":"Invalid key"}`)
+			return
+		}
+		switch r.URL.Path {
+		case "/feed/bengaluru/":
+			fmt.Fprint(w, `{"status":"ok","data":{"aqi":87,"dominentpol":"pm25",
+				"city":{"name":"BTM Layout, Bengaluru, India","url":"https://aqicn.org/city/india/bengaluru/btm"},
+				"iaqi":{"pm25":{"v":87},"pm10":{"v":54}},
+				"time":{"iso":"2026-10-16T14:00:00+05:30"}}}`)
+		case "/feed/@8190/":
+			fmt.Fprint(w, `{"status":"ok","data":{"aqi":"-","city":{"name":"Offline station"}}}`)
+		default:
+			fmt.Fprint(w, `{"status":"error","data":"Unknown station"}`)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("WAQI_TOKEN", "")
+	plugin := NewWAQIAirQualityPlugin()
+	plugin.apiURL = server.URL
+	plugin.Initialize(map[string]interface{}{"city": "Bengaluru,IN"})
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected an error without a token")
+	}
+
+	plugin.Initialize(map[string]interface{}{"city": "Bengaluru,IN", "token": "secret"})
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	quality := data.(*AirQuality)
+	if quality.AQI != 87 || quality.Dominant != "pm25" || quality.Location != "BTM Layout, Bengaluru, India" {
+		t.Errorf("Expected the station's AQI, got %+v", quality)
+	}
+	if quality.Updated.IsZero() || quality.URL == "" {
+		t.Errorf("Expected the update time and station page, got %+v", quality)
+	}
+
+	plugin.Initialize(map[string]interface{}{"station": "@8190"})
+	if data, err := plugin.Fetch(context.Background()); err == nil || data.(*AirQuality).AQI != 87 {
+		t.Errorf("Expected a station without a reading to fail with the last data, got %+v, %v", data, err)
+	}
+	plugin.Initialize(map[string]interface{}{"station": "atlantis"})
+	if _, err := plugin.Fetch(context.Background()); err == nil || err.Error() != "WAQI: Unknown station" {
+		t.Errorf("Expected WAQI's error message, got %v", err)
+	}
+}
+
+func TestOWMAirQualityPluginFetch(t *testing.T) {
+	geocodes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/geo/1.0/direct":
+			geocodes++
+			fmt.Fprint(w, `[{"name":"Bengaluru","lat":12.9762,"lon":77.6033}]`)
+		case "/data/2.5/air_pollution":
+			if query.Get("lat") != "12.9762" || query.Get("appid") != "key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"list":[{"dt":1792144800,"main":{"aqi":3},"components":{"pm2_5":42.17,"pm10":61.3}}]}`)
+		}
+	}))
+	defer server.Close()
+
+	plugin := NewOWMAirQualityPlugin()
+	plugin.apiURL = server.URL
+	plugin.Initialize(map[string]interface{}{"api_key": "YOUR_OWM_API_KEY", "city": "Bengaluru,IN"})
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected an error with the placeholder key")
+	}
+
+	plugin.Initialize(map[string]interface{}{"api_key": "key", "city": "Bengaluru,IN"})
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	quality := data.(*AirQuality)
+	if quality.AQI != 117 || quality.PM25 != 42.17 || quality.Location != "Bengaluru" {
+		t.Errorf("Expected the US AQI from PM2.5, got %+v", quality)
+	}
+
+	// The coordinates are looked up once per city
+	plugin.Fetch(context.Background())
+	if geocodes != 1 {
+		t.Errorf("Expected one geocoding request, got %d", geocodes)
+	}
+}
+
+func TestPM25AQI(t *testing.T) {
+	for _, tc := range []struct {
+		concentration float64
+		want          int
+	}{
+		{0, 0},
+		{9.0, 50},
+		{9.1, 51},
+		{35.49, 100},
+		{55.4, 150},
+		{125.5, 201},
+		{500, 500},
+	} {
+		if got := pm25AQI(tc.concentration); got != tc.want {
+			t.Errorf("Expected AQI %d for %.2f µg/m³, got %d", tc.want, tc.concentration, got)
+		}
+	}
+}
+
+func TestUpdateAQIWidget(t *testing.T) {
+	wm := NewWidgetManager()
+	wm.UpdateAQIWidget(&AirQuality{Location: "Bengaluru", AQI: 162, Dominant: "pm25"})
+	widget := wm.Widgets["aqi"]
+	if len(widget.Items) != 2 || widget.Count != 162 {
+		t.Fatalf("Expected the reading and advice, got %+v", widget)
+	}
+	if item := widget.Items[0]; item.Title != "AQI 162  Unhealthy" || item.Status != "🔴" || item.Subtitle != "mostly PM2.5 • Bengaluru" {
+		t.Errorf("Expected an unhealthy reading in red, got '%s' '%s' '%s'", item.Status, item.Title, item.Subtitle)
+	}
+
+	wm.UpdateAQIWidget(&AirQuality{AQI: 42, PM25: 10.2})
+	if item := wm.Widgets["aqi"].Items[0]; item.Status != "🟢" || item.Subtitle != "PM2.5 10.2 µg/m³" {
+		t.Errorf("Expected a good reading in green with PM2.5, got '%s' '%s'", item.Status, item.Subtitle)
+	}
+}
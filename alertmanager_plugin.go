@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AlertGroup is the firing alerts that share an alertname
+type AlertGroup struct {
+	Name     string
+	Count    int
+	Severity string // highest severity label among the alerts, if they have one
+	Summary  string // summary annotation of the longest-firing alert
+	Since    time.Time
+	URL      string // Alertmanager UI filtered to the group
+}
+
+// alertSeverityRank orders the common severity labels, most severe first
+var alertSeverityRank = map[string]int{"critical": 0, "error": 1, "high": 1, "warning": 2, "medium": 2, "info": 3, "low": 3, "none": 4}
+
+// severityRank returns the rank of a severity label; unknown severities sort last
+func severityRank(severity string) int {
+	if rank, ok := alertSeverityRank[strings.ToLower(severity)]; ok {
+		return rank
+	}
+	return len(alertSeverityRank)
+}
+
+// AlertmanagerPlugin lists the alerts currently firing in a Prometheus Alertmanager,
+// grouped by alertname, most severe first
+type AlertmanagerPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	apiURL      string
+	token       string
+	filters     []string
+	client      *http.Client
+	lastData    []AlertGroup
+}
+
+// NewAlertmanagerPlugin creates a new Alertmanager plugin
+func NewAlertmanagerPlugin() *AlertmanagerPlugin {
+	return &AlertmanagerPlugin{
+		id:          "alertmanager",
+		pluginType:  "alerts",
+		name:        "Alertmanager",
+		version:     "1.0.0",
+		description: "Shows firing Prometheus alerts grouped by alertname",
+		author:      "GoDay Team",
+		token:       os.Getenv("ALERTMANAGER_TOKEN"),
+		client:      &http.Client{Timeout: 10 * time.Second},
+		lastData:    []AlertGroup{},
+	}
+}
+
+// GetID returns the plugin ID
+func (ap *AlertmanagerPlugin) GetID() string {
+	return ap.id
+}
+
+// GetType returns the plugin type
+func (ap *AlertmanagerPlugin) GetType() string {
+	return ap.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (ap *AlertmanagerPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        ap.name,
+		Version:     ap.version,
+		Description: ap.description,
+		Author:      ap.author,
+		Type:        ap.pluginType,
+		Config: map[string]string{
+			"url":     ap.apiURL,
+			"filters": strings.Join(ap.filters, ","),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (ap *AlertmanagerPlugin) Initialize(config map[string]interface{}) error {
+	if apiURL, ok := config["url"].(string); ok {
+		ap.apiURL = strings.TrimRight(apiURL, "/")
+	}
+	if token, ok := config["token"].(string); ok && token != "" {
+		ap.token = token
+	}
+	ap.filters = configStringList(config["filters"])
+	return nil
+}
+
+// Fetch returns the firing alerts that are neither silenced nor inhibited, one group
+// per alertname
+func (ap *AlertmanagerPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if ap.apiURL == "" {
+		return ap.lastData, fmt.Errorf("Alertmanager URL not configured (widgets.alerts.url)")
+	}
+
+	query := url.Values{
+		"active":    {"true"},
+		"silenced":  {"false"},
+		"inhibited": {"false"},
+	}
+	for _, filter := range ap.filters {
+		query.Add("filter", filter)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", ap.apiURL+"/api/v2/alerts?"+query.Encode(), nil)
+	if err != nil {
+		return ap.lastData, err
+	}
+	if ap.token != "" {
+		req.Header.Set("Authorization", "Bearer "+ap.token)
+	}
+
+	resp, err := ap.client.Do(req)
+	if err != nil {
+		return ap.lastData, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ap.lastData, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		// Alertmanager explains rejected filters in a plain text or JSON string body
+		if message := strings.Trim(strings.TrimSpace(string(body)), `"`); message != "" && len(message) < 200 {
+			return ap.lastData, fmt.Errorf("Alertmanager returned status %d: %s", resp.StatusCode, message)
+		}
+		return ap.lastData, fmt.Errorf("Alertmanager returned status %d", resp.StatusCode)
+	}
+
+	var alerts []struct {
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+		StartsAt    time.Time         `json:"startsAt"`
+	}
+	if err := json.Unmarshal(body, &alerts); err != nil {
+		return ap.lastData, err
+	}
+
+	groups := make(map[string]*AlertGroup)
+	var order []*AlertGroup
+	for _, alert := range alerts {
+		name := alert.Labels["alertname"]
+		group, ok := groups[name]
+		if !ok {
+			group = &AlertGroup{Name: name, Severity: alert.Labels["severity"], URL: ap.groupURL(name)}
+			groups[name] = group
+			order = append(order, group)
+		}
+		group.Count++
+		if severity := alert.Labels["severity"]; severityRank(severity) < severityRank(group.Severity) {
+			group.Severity = severity
+		}
+		if group.Since.IsZero() || alert.StartsAt.Before(group.Since) {
+			group.Since = alert.StartsAt
+			if summary := alert.Annotations["summary"]; summary != "" {
+				group.Summary = summary
+			}
+		}
+		if group.Summary == "" {
+			group.Summary = alert.Annotations["summary"]
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if severityRank(a.Severity) != severityRank(b.Severity) {
+			return severityRank(a.Severity) < severityRank(b.Severity)
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Name < b.Name
+	})
+	result := make([]AlertGroup, 0, len(order))
+	for _, group := range order {
+		result = append(result, *group)
+	}
+
+	ap.lastData = result
+	return result, nil
+}
+
+// groupURL links to the Alertmanager UI showing the alerts of one alertname, with the
+// configured filters applied
+func (ap *AlertmanagerPlugin) groupURL(alertname string) string {
+	matchers := append([]string{fmt.Sprintf("alertname=%q", alertname)}, ap.filters...)
+	query := url.Values{
+		"silenced":  {"false"},
+		"inhibited": {"false"},
+		"active":    {"true"},
+		"filter":    {"{" + strings.Join(matchers, ",") + "}"},
+	}
+	return ap.apiURL + "/#/alerts?" + query.Encode()
+}
+
+// Cleanup performs cleanup
+func (ap *AlertmanagerPlugin) Cleanup() error {
+	return nil
+}
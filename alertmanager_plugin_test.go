@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAlertmanagerPluginFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/alerts" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query := r.URL.Query()
+		if query.Get("silenced") != "false" || query.Get("inhibited") != "false" {
+			t.Errorf("Expected silenced and inhibited alerts to be left out, got '%s'", r.URL.RawQuery)
+		}
+		if filters := query["filter"]; len(filters) != 1 || filters[0] != `team="payments"` {
+			t.Errorf("Expected the configured filter, got %v", filters)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Expected the bearer token, got '%s'", r.Header.Get("Authorization"))
+		}
+		fmt.Fprint(w, `[
+			{"labels":{"alertname":"DiskFull","severity":"warning","instance":"db1"},"startsAt":"2026-10-16T08:00:00Z"},
+			{"labels":{"alertname":"HighLatency","severity":"warning","instance":"api1"},"startsAt":"2026-10-16T09:00:00Z"},
+			{"labels":{"alertname":"HighLatency","severity":"critical","instance":"api2"},
+			 "annotations":{"summary":"p99 above 2s"},"startsAt":"2026-10-16T07:30:00Z"},
+			{"labels":{"alertname":"DiskFull","severity":"warning","instance":"db2"},"startsAt":"2026-10-16T08:30:00Z"},
+			{"labels":{"alertname":"Watchdog"},"startsAt":"2026-10-16T00:00:00Z"}]`)
+	}))
+	defer server.Close()
+
+	plugin := NewAlertmanagerPlugin()
+	err := plugin.Initialize(map[string]interface{}{
+		"url":     server.URL + "/",
+		"token":   "test-token",
+		"filters": []interface{}{`team="payments"`},
+	})
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	groups := data.([]AlertGroup)
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 alertnames, got %+v", groups)
+	}
+	if groups[0].Name != "HighLatency" || groups[0].Count != 2 || groups[0].Severity != "critical" {
+		t.Errorf("Expected the critical group first with both alerts, got %+v", groups[0])
+	}
+	if groups[0].Summary != "p99 above 2s" || !groups[0].Since.Equal(time.Date(2026, 10, 16, 7, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected the longest-firing alert's summary and start, got %+v", groups[0])
+	}
+	if groups[1].Name != "DiskFull" || groups[1].Count != 2 || groups[2].Name != "Watchdog" {
+		t.Errorf("Expected the warnings, then alerts without a severity, got %+v", groups)
+	}
+
+	link, err := url.Parse(groups[1].URL)
+	if err != nil || !strings.HasPrefix(groups[1].URL, server.URL+"/#/alerts?") {
+		t.Fatalf("Expected a link to the Alertmanager UI, got '%s'", groups[1].URL)
+	}
+	fragment, _ := url.ParseQuery(strings.TrimPrefix(link.Fragment, "/alerts?"))
+	if filter := fragment.Get("filter"); filter != `{alertname="DiskFull",team="payments"}` {
+		t.Errorf("Expected the UI filtered to the group, got '%s'", filter)
+	}
+}
+
+func TestAlertmanagerPluginErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `"bad matcher format: team"`)
+	}))
+	defer server.Close()
+
+	plugin := NewAlertmanagerPlugin()
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected a missing URL to fail")
+	}
+	plugin.Initialize(map[string]interface{}{"url": server.URL, "filters": []interface{}{"team"}})
+	if _, err := plugin.Fetch(context.Background()); err == nil || !strings.Contains(err.Error(), "bad matcher format") {
+		t.Errorf("Expected Alertmanager's reason in the error, got %v", err)
+	}
+
+	cfg := &Config{}
+	if cfg.ConfiguredWidgets()["alerts"] {
+		t.Error("Expected no alerts tile by default")
+	}
+	cfg.Widgets.Alerts.URL = server.URL
+	if !cfg.ConfiguredWidgets()["alerts"] {
+		t.Error("Expected the URL to show the tile")
+	}
+}
+
+func TestUpdateAlertsWidget(t *testing.T) {
+	wm := NewWidgetManager()
+	wm.UpdateAlertsWidget([]AlertGroup{
+		{Name: "HighLatency", Count: 3, Severity: "critical", Since: time.Now().Add(-45 * time.Minute), URL: "http://am/#/alerts"},
+		{Name: "Watchdog", Count: 1, Since: time.Now().Add(-2 * time.Hour)},
+	})
+
+	widget := wm.Widgets["alerts"]
+	if widget.Count != 4 || len(widget.Items) != 2 {
+		t.Fatalf("Expected 4 firing alerts in 2 groups, got %+v", widget)
+	}
+	if item := widget.Items[0]; item.Title != "HighLatency ×3" || item.Status != "🔴" || item.Subtitle != "critical • for 45m" || item.URL == "" {
+		t.Errorf("Expected the critical group with its link, got %+v", item)
+	}
+	if item := widget.Items[1]; item.Status != "⚪" || item.Subtitle != "for 2h" {
+		t.Errorf("Expected a group without a severity to be neutral, got %+v", item)
+	}
+
+	wm.UpdateAlertsWidget(nil)
+	if widget := wm.Widgets["alerts"]; widget.Count != 0 || widget.Items[0].Title != "No alerts firing" {
+		t.Errorf("Expected an all-clear item, got %+v", widget.Items)
+	}
+}
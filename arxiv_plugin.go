@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// defaultArxivCategories are used when widgets.news.arxiv.categories is not set
+var defaultArxivCategories = []string{"cs.LG", "cs.CR"}
+
+// ArxivPlugin shows the newest arXiv submissions in the configured categories.
+// Each category is a tag, so t narrows the list to one category.
+type ArxivPlugin struct {
+	*BaseNewsPlugin
+	apiURL     string
+	categories []string
+	maxResults int
+	feedParser *gofeed.Parser
+}
+
+// NewArxivPlugin creates a new arXiv plugin
+func NewArxivPlugin() *ArxivPlugin {
+	base := NewBaseNewsPlugin(
+		"arxiv",
+		"arXiv",
+		"1.0.0",
+		"Fetches new arXiv submissions in configured categories",
+		"GoDay Team",
+	)
+	base.supportedTags = append([]string{"all"}, defaultArxivCategories...)
+
+	return &ArxivPlugin{
+		BaseNewsPlugin: base,
+		apiURL:         "https://export.arxiv.org/api/query",
+		categories:     defaultArxivCategories,
+		maxResults:     20,
+		feedParser:     gofeed.NewParser(),
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (ap *ArxivPlugin) Initialize(config map[string]interface{}) error {
+	if tags := configStringList(config["tags"]); tags != nil {
+		ap.SetTags(tags)
+	}
+	if currentTag, ok := config["current_tag"].(string); ok {
+		ap.SetCurrentTag(currentTag)
+	}
+	if categories := configStringList(config["arxiv_categories"]); len(categories) > 0 {
+		for _, category := range categories {
+			// Categories look like cs.LG or math.PR; a few archives such as hep-th have no subject
+			if category == "" || strings.ContainsAny(category, " :\"") {
+				return fmt.Errorf("invalid arXiv category %q (expected e.g. cs.LG)", category)
+			}
+		}
+		ap.categories = categories
+	}
+	ap.supportedTags = append([]string{"all"}, ap.categories...)
+	return nil
+}
+
+// Fetch returns the newest submissions, newest first. The arXiv API asks clients to
+// keep to one request every few seconds, so all categories are fetched in one query.
+func (ap *ArxivPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	categories := ap.categories
+	if tag := ap.GetCurrentTag(); containsString(ap.categories, tag) {
+		categories = []string{tag}
+	}
+
+	var terms []string
+	for _, category := range categories {
+		terms = append(terms, "cat:"+category)
+	}
+	params := url.Values{}
+	params.Set("search_query", strings.Join(terms, " OR "))
+	params.Set("sortBy", "submittedDate")
+	params.Set("sortOrder", "descending")
+	params.Set("max_results", fmt.Sprintf("%d", ap.maxResults))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", ap.apiURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return ap.lastData, err
+	}
+	resp, err := ap.client.Do(req)
+	if err != nil {
+		return ap.lastData, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ap.lastData, fmt.Errorf("arXiv API returned status %d", resp.StatusCode)
+	}
+
+	parsed, err := ap.feedParser.Parse(resp.Body)
+	if err != nil {
+		return ap.lastData, err
+	}
+
+	var items []NewsItem
+	for _, entry := range parsed.Items {
+		// Errors come back as an entry titled Error
+		if entry.Title == "Error" {
+			return ap.lastData, fmt.Errorf("arXiv API error: %s", strings.TrimSpace(entry.Description))
+		}
+
+		var createdAt int64
+		if entry.PublishedParsed != nil {
+			createdAt = entry.PublishedParsed.Unix()
+		}
+
+		items = append(items, NewsItem{
+			Title:       strings.Join(strings.Fields(entry.Title), " "),
+			URL:         entry.Link,
+			Author:      arxivAuthors(entry.Authors),
+			CreatedAt:   createdAt,
+			Source:      "arxiv",
+			Description: strings.Join(strings.Fields(entry.Description), " "),
+			Tags:        entry.Categories,
+			Feed:        arxivPrimaryCategory(entry),
+		})
+	}
+
+	filtered := ap.filterByCurrentTag(items)
+	if len(filtered) > 12 {
+		filtered = filtered[:12]
+	}
+
+	ap.lastData = filtered
+	return filtered, nil
+}
+
+// arxivAuthors shortens long author lists to the first author
+func arxivAuthors(authors []*gofeed.Person) string {
+	switch len(authors) {
+	case 0:
+		return "arXiv"
+	case 1:
+		return authors[0].Name
+	case 2:
+		return authors[0].Name + ", " + authors[1].Name
+	}
+	return authors[0].Name + " et al."
+}
+
+// arxivPrimaryCategory returns the entry's arxiv:primary_category, or its first category
+func arxivPrimaryCategory(entry *gofeed.Item) string {
+	for _, primary := range entry.Extensions["arxiv"]["primary_category"] {
+		if term := primary.Attrs["term"]; term != "" {
+			return term
+		}
+	}
+	if len(entry.Categories) > 0 {
+		return entry.Categories[0]
+	}
+	return ""
+}
+
+// formatArxivItem shows the authors, category and age of a submission
+func formatArxivItem(news NewsItem) WidgetItem {
+	subtitle := news.Author
+	if news.Feed != "" {
+		subtitle = fmt.Sprintf("%s • %s • %s", news.Author, news.Feed, formatTimeAgo(time.Unix(news.CreatedAt, 0)))
+	}
+	return WidgetItem{Title: news.Title, Subtitle: subtitle, URL: news.URL}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const arxivTestFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:arxiv="http://arxiv.org/schemas/atom">
+  <title>ArXiv Query</title>
+  <entry>
+    <id>http://arxiv.org/abs/2610.01234v1</id>
+    <published>2026-10-15T17:59:58Z</published>
+    <title>Sparse Attention for
+      Long Contexts</title>
+    <summary>  We study sparse attention.
+    </summary>
+    <author><name>Ada Lovelace</name></author>
+    <author><name>Alan Turing</name></author>
+    <author><name>Grace Hopper</name></author>
+    <link href="http://arxiv.org/abs/2610.01234v1" rel="alternate" type="text/html"/>
+    <arxiv:primary_category term="cs.LG" scheme="http://arxiv.org/schemas/atom"/>
+    <category term="stat.ML" scheme="http://arxiv.org/schemas/atom"/>
+    <category term="cs.LG" scheme="http://arxiv.org/schemas/atom"/>
+  </entry>
+  <entry>
+    <id>http://arxiv.org/abs/2610.01000v1</id>
+    <published>2026-10-14T09:00:00Z</published>
+    <title>Fuzzing TLS Stacks</title>
+    <summary>We fuzz TLS.</summary>
+    <author><name>Eve</name></author>
+    <link href="http://arxiv.org/abs/2610.01000v1" rel="alternate" type="text/html"/>
+    <category term="cs.CR" scheme="http://arxiv.org/schemas/atom"/>
+  </entry>
+</feed>`
+
+func TestArxivPluginFetch(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("search_query"))
+		if r.URL.Query().Get("sortBy") != "submittedDate" {
+			t.Errorf("Expected the newest submissions, got sortBy '%s'", r.URL.Query().Get("sortBy"))
+		}
+		fmt.Fprint(w, arxivTestFeed)
+	}))
+	defer server.Close()
+
+	plugin := NewArxivPlugin()
+	plugin.apiURL = server.URL
+	if err := plugin.Initialize(map[string]interface{}{"arxiv_categories": []string{"cs.LG", "cs.CR"}, "current_tag": "all"}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if tags := plugin.GetSupportedTags(); strings.Join(tags, ",") != "all,cs.LG,cs.CR" {
+		t.Errorf("Expected a tag per category, got %v", tags)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	items := data.([]NewsItem)
+	if len(items) != 2 || queries[0] != "cat:cs.LG OR cat:cs.CR" {
+		t.Fatalf("Expected both categories in one query, got %v and %+v", queries, items)
+	}
+	if items[0].Title != "Sparse Attention for Long Contexts" || items[0].Author != "Ada Lovelace et al." || items[0].Feed != "cs.LG" {
+		t.Errorf("Expected a tidied title, shortened authors and the primary category, got %+v", items[0])
+	}
+	if items[1].Feed != "cs.CR" || items[1].Author != "Eve" {
+		t.Errorf("Expected the first category without a primary one, got %+v", items[1])
+	}
+
+	// Selecting a category with t queries just that category
+	plugin.SetCurrentTag("cs.CR")
+	data, _ = plugin.Fetch(context.Background())
+	if items := data.([]NewsItem); queries[1] != "cat:cs.CR" || len(items) != 1 || items[0].Title != "Fuzzing TLS Stacks" {
+		t.Errorf("Expected only cs.CR papers, got %v and %+v", queries, items)
+	}
+
+	if err := plugin.Initialize(map[string]interface{}{"arxiv_categories": []string{"cs.LG OR all:x"}}); err == nil {
+		t.Error("Expected an invalid category to fail")
+	}
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// attentionHighlightFor is how long a new item stays highlighted in its tile
+	attentionHighlightFor = 10 * time.Minute
+	// attentionBannerFor is how long the latest event stays in the status bar
+	attentionBannerFor = 2 * time.Minute
+	// attentionFlashFor is how long the status bar flashes after an event
+	attentionFlashFor = 10 * time.Second
+	// attentionFlashInterval is how often a flashing status bar toggles
+	attentionFlashInterval = 500 * time.Millisecond
+)
+
+// AttentionLevel is how strongly a new item asks for attention. Each level also
+// does everything the levels below it do.
+type AttentionLevel int
+
+const (
+	AttentionSilent AttentionLevel = iota
+	AttentionHighlight
+	AttentionFlash
+	AttentionBell
+	AttentionNotify
+)
+
+// attentionLevelNames are the config names of the levels, lowest first
+var attentionLevelNames = []string{"silent", "highlight", "flash", "bell", "notify"}
+
+func (l AttentionLevel) String() string {
+	return attentionLevelNames[l]
+}
+
+// ParseAttentionLevel parses a level name from the config
+func ParseAttentionLevel(name string) (AttentionLevel, error) {
+	for i, levelName := range attentionLevelNames {
+		if strings.EqualFold(name, levelName) {
+			return AttentionLevel(i), nil
+		}
+	}
+	return AttentionSilent, fmt.Errorf("unknown attention level %q (expected %s)", name, strings.Join(attentionLevelNames, ", "))
+}
+
+// AttentionRule sets the level of new items in a widget, optionally only those whose
+// text contains Match
+type AttentionRule struct {
+	Widget string
+	Match  string
+	Level  AttentionLevel
+}
+
+// AttentionEvent is an item that appeared in a tile since its last refresh
+type AttentionEvent struct {
+	Widget string
+	Title  string // the tile title
+	Item   WidgetListItem
+	Level  AttentionLevel
+	At     time.Time
+}
+
+// attentionFlashMsg toggles a flashing status bar
+type attentionFlashMsg struct{}
+
+// AttentionTracker notices new items in the tiles after each refresh and escalates
+// them by the configured rules
+type AttentionTracker struct {
+	rules        []AttentionRule
+	defaultLevel AttentionLevel
+	signatures   map[string]string          // tile key -> items at the last refresh
+	seen         map[string]map[string]bool // tile key -> item keys; set once the tile loaded
+	highlighted  map[string]time.Time       // tile key + item key -> when it appeared
+	banner       *AttentionEvent
+	flashing     bool // a flash toggle is scheduled
+	flashOn      bool
+	steady       bool // show the status bar without flashing
+	now          func() time.Time
+	bell         io.Writer
+	notify       func(title, body string) error // nil without a notifier; the bell still rings
+}
+
+// NewAttentionTracker creates a tracker for the attention rules in the config. Rules
+// with an unknown level are skipped; the config schema reports them on startup.
+func NewAttentionTracker(cfg *Config) *AttentionTracker {
+	tracker := &AttentionTracker{
+		signatures:  make(map[string]string),
+		seen:        make(map[string]map[string]bool),
+		highlighted: make(map[string]time.Time),
+		now:         time.Now,
+		bell:        os.Stdout,
+		notify:      desktopNotify,
+	}
+	if cfg == nil {
+		return tracker
+	}
+
+	if level, err := ParseAttentionLevel(cfg.Attention.Default); err == nil {
+		tracker.defaultLevel = level
+	}
+	for _, rule := range cfg.Attention.Rules {
+		level, err := ParseAttentionLevel(rule.Level)
+		if err != nil {
+			continue
+		}
+		tracker.rules = append(tracker.rules, AttentionRule{
+			Widget: strings.ToLower(rule.Widget),
+			Match:  strings.ToLower(rule.Match),
+			Level:  level,
+		})
+	}
+	return tracker
+}
+
+// attentionKey identifies an item across refreshes: by its link, or its title without one
+func attentionKey(item WidgetListItem) string {
+	if item.URL != "" {
+		return item.URL
+	}
+	return item.ItemTitle
+}
+
+// levelFor returns the level of the first rule matching the item, or the default level
+func (a *AttentionTracker) levelFor(widget string, item WidgetListItem) AttentionLevel {
+	text := strings.ToLower(item.ItemTitle + " " + item.Subtitle + " " + item.Status)
+	for _, rule := range a.rules {
+		if rule.Widget != "" && rule.Widget != widget {
+			continue
+		}
+		if rule.Match != "" && !strings.Contains(text, rule.Match) {
+			continue
+		}
+		return rule.Level
+	}
+	return a.defaultLevel
+}
+
+// Observe compares the tiles with the last refresh and returns the new items that are
+// not silent. A tile's first change is its initial load, which only records the items.
+// Tiles showing an error are skipped, so a recovering widget does not look all new.
+func (a *AttentionTracker) Observe(tiles []WidgetTile) []AttentionEvent {
+	var events []AttentionEvent
+	for _, tile := range tiles {
+		if tile.hasError {
+			continue
+		}
+
+		var items []WidgetListItem
+		var keys []string
+		for _, listItem := range tile.list.Items() {
+			if item, ok := listItem.(WidgetListItem); ok {
+				items = append(items, item)
+				keys = append(keys, attentionKey(item))
+			}
+		}
+		signature := strings.Join(keys, "\n")
+		previous, known := a.signatures[tile.key]
+		a.signatures[tile.key] = signature
+		if !known || previous == signature {
+			continue
+		}
+
+		seen, loaded := a.seen[tile.key]
+		current := make(map[string]bool, len(keys))
+		for i, item := range items {
+			current[keys[i]] = true
+			if !loaded || seen[keys[i]] {
+				continue
+			}
+			if level := a.levelFor(tile.key, item); level > AttentionSilent {
+				events = append(events, AttentionEvent{Widget: tile.key, Title: tile.title, Item: item, Level: level, At: a.now()})
+			}
+		}
+		a.seen[tile.key] = current
+	}
+	return events
+}
+
+// Escalate acts on new items by their level: it highlights them, shows the latest in a
+// flashing status bar, rings the terminal bell and sends a desktop notification
+func (a *AttentionTracker) Escalate(events []AttentionEvent) tea.Cmd {
+	var cmds []tea.Cmd
+	var notify []AttentionEvent
+	flash, bell := false, false
+	for i := range events {
+		event := events[i]
+		a.highlighted[event.Widget+"\x00"+attentionKey(event.Item)] = event.At
+		if event.Level >= AttentionFlash {
+			a.banner = &event
+			flash = true
+		}
+		bell = bell || event.Level >= AttentionBell
+		if event.Level >= AttentionNotify {
+			notify = append(notify, event)
+		}
+	}
+
+	if flash && !a.steady {
+		a.flashOn = true
+		if !a.flashing {
+			a.flashing = true
+			cmds = append(cmds, tickAttentionFlash())
+		}
+	}
+	if bell {
+		out := a.bell
+		cmds = append(cmds, func() tea.Msg {
+			fmt.Fprint(out, "\a")
+			return nil
+		})
+	}
+	if len(notify) > 0 && a.notify != nil {
+		title, body := notificationText(notify)
+		send := a.notify
+		cmds = append(cmds, func() tea.Msg {
+			// Notifications are best effort; the bell and banner still go off
+			send(title, body)
+			return nil
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// notificationText summarizes new items for one desktop notification
+func notificationText(events []AttentionEvent) (string, string) {
+	if len(events) == 1 {
+		body := events[0].Item.ItemTitle
+		if events[0].Item.Subtitle != "" {
+			body += " • " + events[0].Item.Subtitle
+		}
+		return "GoDay • " + events[0].Title, body
+	}
+
+	var titles []string
+	for _, event := range events {
+		titles = append(titles, event.Title+": "+event.Item.ItemTitle)
+	}
+	return fmt.Sprintf("GoDay • %d new items", len(events)), strings.Join(titles, "\n")
+}
+
+// tickAttentionFlash schedules the next toggle of a flashing status bar
+func tickAttentionFlash() tea.Cmd {
+	return tea.Tick(attentionFlashInterval, func(time.Time) tea.Msg { return attentionFlashMsg{} })
+}
+
+// Flash toggles the status bar and returns the next toggle while it is still flashing
+func (a *AttentionTracker) Flash() tea.Cmd {
+	if a.banner == nil || a.now().Sub(a.banner.At) >= attentionFlashFor {
+		a.flashing, a.flashOn = false, false
+		return nil
+	}
+	a.flashOn = !a.flashOn
+	return tickAttentionFlash()
+}
+
+// Highlighted returns the keys of the tile's items that are still highlighted
+func (a *AttentionTracker) Highlighted(tileKey string) map[string]bool {
+	keys := make(map[string]bool)
+	prefix := tileKey + "\x00"
+	for key, at := range a.highlighted {
+		if a.now().Sub(at) >= attentionHighlightFor {
+			delete(a.highlighted, key)
+			continue
+		}
+		if strings.HasPrefix(key, prefix) {
+			keys[strings.TrimPrefix(key, prefix)] = true
+		}
+	}
+	return keys
+}
+
+// Banner returns the status bar text for the latest flash-level event, if still shown,
+// and whether it is in the highlighted phase of a flash
+func (a *AttentionTracker) Banner() (string, bool) {
+	if a.banner == nil || a.now().Sub(a.banner.At) >= attentionBannerFor {
+		return "", false
+	}
+	text := fmt.Sprintf("🔔 %s: %s", a.banner.Title, a.banner.Item.ItemTitle)
+	if a.banner.Item.Subtitle != "" {
+		text += " • " + a.banner.Item.Subtitle
+	}
+	return text, a.flashOn
+}
+
+// desktopNotify shows a desktop notification with notify-send on Linux and BSD, or
+// osascript on macOS
+func desktopNotify(title, body string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", body, title)
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		return fmt.Errorf("desktop notifications are not supported on windows")
+	default:
+		cmd = exec.Command("notify-send", "--app-name=GoDay", title, body)
+	}
+	if err := cmd.Start(); errors.Is(err, exec.ErrNotFound) {
+		return missingBinaryError(cmd.Args[0])
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
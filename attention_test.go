@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAttentionLevels(t *testing.T) {
+	cfg := &Config{}
+	cfg.Attention.Default = "highlight"
+	cfg.Attention.Rules = []AttentionRuleConfig{
+		{Widget: "pagerduty", Match: "SEV1", Level: "notify"},
+		{Widget: "news", Level: "silent"},
+		{Widget: "prs", Level: "loud"},
+	}
+	tracker := NewAttentionTracker(cfg)
+
+	for _, tc := range []struct {
+		widget string
+		item   WidgetListItem
+		level  AttentionLevel
+	}{
+		{"pagerduty", WidgetListItem{ItemTitle: "Sev1: checkout down"}, AttentionNotify},
+		{"pagerduty", WidgetListItem{ItemTitle: "Sev3: slow search"}, AttentionHighlight},
+		{"news", WidgetListItem{ItemTitle: "Sev1 postmortems"}, AttentionSilent},
+		{"prs", WidgetListItem{ItemTitle: "#1"}, AttentionHighlight},
+	} {
+		if got := tracker.levelFor(tc.widget, tc.item); got != tc.level {
+			t.Errorf("Expected %s for %s '%s', got %s", tc.level, tc.widget, tc.item.ItemTitle, got)
+		}
+	}
+
+	if _, err := ParseAttentionLevel("loud"); err == nil {
+		t.Error("Expected an unknown level to fail")
+	}
+}
+
+func TestAttentionEscalation(t *testing.T) {
+	cfg := &Config{}
+	cfg.Attention.Rules = []AttentionRuleConfig{{Widget: "pagerduty", Match: "sev1", Level: "notify"}}
+	tracker := NewAttentionTracker(cfg)
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+	var bell bytes.Buffer
+	tracker.bell = &bell
+	var notified []string
+	tracker.notify = func(title, body string) error {
+		notified = append(notified, title+": "+body)
+		return nil
+	}
+
+	tile := NewWidgetTile("pagerduty", "PagerDuty", 40, 10)
+	tiles := []WidgetTile{tile}
+	update := func(items ...WidgetItem) []AttentionEvent {
+		tiles[0].UpdateItems(items)
+		return tracker.Observe(tiles)
+	}
+
+	// The placeholder, then the first load, are not new
+	tracker.Observe(tiles)
+	if events := update(WidgetItem{Title: "Sev2: queue backlog", URL: "https://pd/1"}); len(events) != 0 {
+		t.Fatalf("Expected the first load to be recorded silently, got %+v", events)
+	}
+
+	events := update(
+		WidgetItem{Title: "Sev2: queue backlog", URL: "https://pd/1"},
+		WidgetItem{Title: "Sev1: checkout down", Subtitle: "payments", URL: "https://pd/2"},
+		WidgetItem{Title: "Sev3: slow search", URL: "https://pd/3"},
+	)
+	if len(events) != 1 || events[0].Item.URL != "https://pd/2" || events[0].Level != AttentionNotify {
+		t.Fatalf("Expected only the new Sev1 item, got %+v", events)
+	}
+
+	immediateMsgs(tracker.Escalate(events))
+	if bell.String() != "\a" {
+		t.Errorf("Expected the terminal bell, got %q", bell.String())
+	}
+	if len(notified) != 1 || notified[0] != "GoDay • PagerDuty: Sev1: checkout down • payments" {
+		t.Errorf("Expected one desktop notification, got %v", notified)
+	}
+	if !tracker.Highlighted("pagerduty")["https://pd/2"] {
+		t.Error("Expected the new item to be highlighted")
+	}
+	if banner, flashOn := tracker.Banner(); !strings.Contains(banner, "Sev1: checkout down") || !flashOn {
+		t.Errorf("Expected a flashing status bar, got '%s' (%t)", banner, flashOn)
+	}
+
+	// Flashing stops, then the banner and highlight expire
+	now = now.Add(attentionFlashFor)
+	if cmd := tracker.Flash(); cmd != nil {
+		t.Error("Expected the flash to stop")
+	}
+	now = now.Add(attentionHighlightFor)
+	if banner, _ := tracker.Banner(); banner != "" || len(tracker.Highlighted("pagerduty")) != 0 {
+		t.Errorf("Expected the banner and highlight to expire, got '%s'", banner)
+	}
+
+	// In low power the status bar does not flash
+	tracker.steady = true
+	immediateMsgs(tracker.Escalate(update(
+		WidgetItem{Title: "Sev1: checkout down", URL: "https://pd/2"},
+		WidgetItem{Title: "Sev1: db down", URL: "https://pd/4"},
+	)))
+	if banner, flashOn := tracker.Banner(); !strings.Contains(banner, "Sev1: db down") || flashOn || tracker.flashing {
+		t.Errorf("Expected a steady status bar, got '%s' (%t)", banner, flashOn)
+	}
+	tracker.steady = false
+
+	// A widget showing an error, then recovering, is not all new
+	tiles[0].hasError = true
+	update(WidgetItem{Title: "PagerDuty unavailable"})
+	tiles[0].hasError = false
+	if events := update(WidgetItem{Title: "Sev1: checkout down", URL: "https://pd/2"}); len(events) != 0 {
+		t.Errorf("Expected no events after recovering, got %+v", events)
+	}
+}
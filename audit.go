@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Audited actions
+const (
+	auditPRApprove   = "pr.approve"
+	auditPRMerge     = "pr.merge"
+	auditIssueLabel  = "issue.label"
+	auditIssueAssign = "issue.assign"
+	auditIssueClose  = "issue.close"
+	auditTimerStart  = "timer.start"
+	auditTimerStop   = "timer.stop"
+)
+
+// AuditEntry is a write action performed from the dashboard
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"`           // e.g. pr.approve or issue.label
+	Target string    `json:"target"`           // e.g. octocat/api#12
+	Detail string    `json:"detail,omitempty"` // e.g. the label added
+	URL    string    `json:"url,omitempty"`
+}
+
+// AuditLog keeps a trail of what the dashboard changed on the user's behalf, one JSON
+// entry per line, next to the other state in ~/.goday
+type AuditLog struct {
+	path string
+	now  func() time.Time
+	mu   sync.Mutex
+}
+
+// AuditLogPath returns where the audit trail is kept: ~/.goday/audit.jsonl
+func AuditLogPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".goday", "audit.jsonl")
+}
+
+// NewAuditLog creates an audit log kept in path, or nil when path is empty
+func NewAuditLog(path string) *AuditLog {
+	if path == "" {
+		return nil
+	}
+	return &AuditLog{path: path, now: time.Now}
+}
+
+// Record appends an action to the trail
+func (a *AuditLog) Record(action, target, detail, url string) error {
+	if a == nil {
+		return nil
+	}
+	data, err := json.Marshal(AuditEntry{Time: a.now(), Action: action, Target: target, Detail: detail, URL: url})
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// Other goday processes, and goday sync, write to the same file
+	return withFileLock(a.path, func() error {
+		file, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = fmt.Fprintln(file, string(data))
+		return err
+	})
+}
+
+// RecordBulkAction records the PRs a bulk approve or merge went through for
+func (a *AuditLog) RecordBulkAction(msg bulkActionMsg) {
+	for _, result := range msg.results {
+		if result.Err != nil {
+			continue
+		}
+		pr := result.Update.PR
+		target := fmt.Sprintf("%s#%d", pr.Repository, pr.Number)
+		// A merge approves first, so both are on the trail
+		a.Record(auditPRApprove, target, pr.Title, pr.URL)
+		if msg.action == bulkMerge {
+			a.Record(auditPRMerge, target, pr.Title, pr.URL)
+		}
+	}
+}
+
+// RecordTriage records a triage action that went through
+func (a *AuditLog) RecordTriage(msg triageResultMsg) {
+	if msg.err != nil {
+		return
+	}
+	issue := msg.action.Issue
+	target := fmt.Sprintf("%s#%d", issue.Repository, issue.Number)
+	switch msg.action.Kind {
+	case triageLabel:
+		a.Record(auditIssueLabel, target, msg.action.Arg, issue.URL)
+	case triageAssign:
+		a.Record(auditIssueAssign, target, issue.Title, issue.URL)
+	case triageClose:
+		a.Record(auditIssueClose, target, msg.action.Arg, issue.URL)
+	}
+}
+
+// RecordTimer records a timer started or stopped with g or G
+func (a *AuditLog) RecordTimer(msg timerActionMsg) {
+	if msg.err != nil {
+		return
+	}
+	if msg.action == "stop" {
+		a.Record(auditTimerStop, msg.tracker, "", "")
+	} else {
+		a.Record(auditTimerStart, msg.tracker, "", "")
+	}
+}
+
+// ReadAuditLog returns the entries kept in path, oldest first. A missing file is an
+// empty trail; lines that cannot be read are skipped.
+func ReadAuditLog(path string) ([]AuditEntry, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil && entry.Action != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// runAudit prints the audit trail, newest first: goday audit [--limit N] [--json]
+func runAudit(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("audit", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	limit := fs.Int("limit", 50, "number of entries to show; 0 shows all")
+	asJSON := fs.Bool("json", false, "print JSON lines")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	entries, err := ReadAuditLog(AuditLogPath())
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		_, err := fmt.Fprintln(out, "No actions recorded yet.")
+		return err
+	}
+
+	var lines []string
+	for i := len(entries) - 1; i >= 0; i-- {
+		if *limit > 0 && len(lines) == *limit {
+			break
+		}
+		entry := entries[i]
+		if *asJSON {
+			data, _ := json.Marshal(entry)
+			lines = append(lines, string(data))
+			continue
+		}
+		line := fmt.Sprintf("%s  %-13s %s", entry.Time.Local().Format("2006-01-02 15:04"), entry.Action, entry.Target)
+		if entry.Detail != "" {
+			line += "  " + entry.Detail
+		}
+		lines = append(lines, line)
+	}
+	_, err = fmt.Fprintln(out, strings.Join(lines, "\n"))
+	return err
+}
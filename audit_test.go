@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditLogRecordsActions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	audit := NewAuditLog(AuditLogPath())
+	at := time.Date(2026, 10, 16, 9, 30, 0, 0, time.Local)
+	audit.now = func() time.Time { return at }
+
+	pr := func(number int) DependencyUpdate {
+		return DependencyUpdate{PR: GitPullRequest{Repository: "octocat/api", Number: number, Title: "Bump lib", URL: "https://github.com/octocat/api/pull/1"}}
+	}
+	audit.RecordBulkAction(bulkActionMsg{action: bulkMerge, results: []BulkResult{
+		{Update: pr(1)},
+		{Update: pr(2), Err: errors.New("merge conflict")},
+	}})
+	issue := TriageIssue{Repository: "octocat/web", Number: 7, Title: "Crash on start"}
+	audit.RecordTriage(triageResultMsg{action: triageAction{Issue: issue, Kind: triageLabel, Arg: "bug"}})
+	audit.RecordTriage(triageResultMsg{action: triageAction{Issue: issue, Kind: triageClose}, err: errors.New("status 403")})
+	audit.RecordTimer(timerActionMsg{action: "start", tracker: "Toggl Track"})
+	audit.RecordTimer(timerActionMsg{action: "stop", tracker: "Toggl Track", err: errors.New("no timer is running")})
+
+	entries, err := ReadAuditLog(AuditLogPath())
+	if err != nil {
+		t.Fatalf("ReadAuditLog failed: %v", err)
+	}
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Action+" "+entry.Target+" "+entry.Detail)
+	}
+	want := []string{"pr.approve octocat/api#1 Bump lib", "pr.merge octocat/api#1 Bump lib", "issue.label octocat/web#7 bug", "timer.start Toggl Track "}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected only the actions that went through, got:\n%s", strings.Join(got, "\n"))
+	}
+	if !entries[0].Time.Equal(at) {
+		t.Errorf("Expected the time of the action, got %v", entries[0].Time)
+	}
+
+	var out bytes.Buffer
+	if err := runAudit([]string{"--limit", "2"}, &out); err != nil {
+		t.Fatalf("runAudit failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "2026-10-16 09:30  timer.start") || !strings.Contains(lines[1], "issue.label") {
+		t.Errorf("Expected the two newest actions first, got:\n%s", out.String())
+	}
+}
+
+func TestAuditCommandWithoutActions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var out bytes.Buffer
+	if err := runAudit(nil, &out); err != nil || !strings.Contains(out.String(), "No actions") {
+		t.Errorf("Expected an empty trail, got '%s', %v", out.String(), err)
+	}
+	if err := runAudit([]string{"stray"}, &out); err == nil {
+		t.Error("Expected a stray argument to fail")
+	}
+
+	var off *AuditLog
+	if err := off.Record(auditPRApprove, "octocat/api#1", "", ""); err != nil {
+		t.Errorf("Expected a nil audit log to record nothing, got %v", err)
+	}
+}
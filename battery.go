@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// batteryLowPercent is the charge below which the header pill turns red, plugged in or not
+const batteryLowPercent = 15
+
+// BatteryStatus is the charge of the machine's battery and whether it is plugged in
+type BatteryStatus struct {
+	Present   bool // false on desktops and where the battery cannot be read
+	Percent   int
+	PluggedIn bool // charging, full or otherwise on mains power
+}
+
+// batteryMsg is a battery reading for the header
+type batteryMsg BatteryStatus
+
+// pmsetPercent matches the charge in pmset -g batt, e.g. "85%; discharging"
+var pmsetPercent = regexp.MustCompile(`(\d+)%;`)
+
+// readBattery reads the battery: from /sys/class/power_supply on Linux, pmset on macOS
+// and Win32_Battery on Windows
+func readBattery() BatteryStatus {
+	switch runtime.GOOS {
+	case "linux":
+		return linuxBattery("/sys/class/power_supply")
+	case "darwin":
+		out, err := exec.Command("pmset", "-g", "batt").Output()
+		if err != nil {
+			return BatteryStatus{}
+		}
+		return parsePmset(string(out))
+	case "windows":
+		out, err := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command",
+			`Get-CimInstance Win32_Battery | ForEach-Object { "$($_.EstimatedChargeRemaining) $($_.BatteryStatus)" }`).Output()
+		if err != nil {
+			return BatteryStatus{}
+		}
+		return parseWin32Battery(string(out))
+	}
+	return BatteryStatus{}
+}
+
+// linuxBattery reads the batteries under the sysfs power supply directory, averaging
+// the charge of machines with more than one. Only a discharging battery counts as
+// unplugged.
+func linuxBattery(dir string) BatteryStatus {
+	supplies, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		return BatteryStatus{}
+	}
+	status := BatteryStatus{PluggedIn: true}
+	total, batteries := 0, 0
+	for _, supply := range supplies {
+		kind, _ := os.ReadFile(filepath.Join(supply, "type"))
+		if strings.TrimSpace(string(kind)) != "Battery" {
+			continue
+		}
+		state, _ := os.ReadFile(filepath.Join(supply, "status"))
+		if strings.TrimSpace(string(state)) == "Discharging" {
+			status.PluggedIn = false
+		}
+		capacity, err := os.ReadFile(filepath.Join(supply, "capacity"))
+		if percent, convErr := strconv.Atoi(strings.TrimSpace(string(capacity))); err == nil && convErr == nil {
+			total += percent
+			batteries++
+		}
+		status.Present = true
+	}
+	if !status.Present {
+		return BatteryStatus{}
+	}
+	if batteries > 0 {
+		status.Percent = total / batteries
+	}
+	return status
+}
+
+// parsePmset reads pmset -g batt, which names the power source on its first line
+func parsePmset(out string) BatteryStatus {
+	match := pmsetPercent.FindStringSubmatch(out)
+	if match == nil {
+		return BatteryStatus{}
+	}
+	percent, _ := strconv.Atoi(match[1])
+	return BatteryStatus{
+		Present:   true,
+		Percent:   percent,
+		PluggedIn: !strings.Contains(out, "'Battery Power'"),
+	}
+}
+
+// parseWin32Battery reads "<charge> <status>" lines of Win32_Battery, where status 1 is
+// discharging and 4 and 5 are discharging while low
+func parseWin32Battery(out string) BatteryStatus {
+	fields := strings.Fields(out)
+	if len(fields) < 2 {
+		return BatteryStatus{}
+	}
+	percent, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return BatteryStatus{}
+	}
+	switch fields[1] {
+	case "1", "4", "5":
+		return BatteryStatus{Present: true, Percent: percent}
+	}
+	return BatteryStatus{Present: true, Percent: percent, PluggedIn: true}
+}
+
+// readBatteryCmd reads the battery off the UI goroutine, since macOS and Windows run a
+// command for it; it returns nil with ui.battery off
+func (m Model) readBatteryCmd() tea.Cmd {
+	if m.config != nil && m.config.UI.Battery != nil && !*m.config.UI.Battery {
+		return nil
+	}
+	return func() tea.Msg { return batteryMsg(readBattery()) }
+}
+
+// formatBatteryPill shows the charge, e.g. "🔋 85%", with 🔌 while plugged in. It is
+// empty without a battery.
+func formatBatteryPill(battery BatteryStatus) string {
+	if !battery.Present {
+		return ""
+	}
+	if battery.PluggedIn {
+		return fmt.Sprintf("🔌 %d%%", battery.Percent)
+	}
+	return fmt.Sprintf("🔋 %d%%", battery.Percent)
+}
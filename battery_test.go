@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLinuxBattery(t *testing.T) {
+	dir := t.TempDir()
+	supply := func(name string, files map[string]string) {
+		os.MkdirAll(filepath.Join(dir, name), 0755)
+		for file, content := range files {
+			os.WriteFile(filepath.Join(dir, name, file), []byte(content+"\n"), 0644)
+		}
+	}
+
+	if battery := linuxBattery(dir); battery.Present {
+		t.Errorf("Expected no battery on a desktop, got %+v", battery)
+	}
+	supply("AC", map[string]string{"type": "Mains", "online": "1"})
+	supply("BAT0", map[string]string{"type": "Battery", "status": "Full", "capacity": "100"})
+	supply("BAT1", map[string]string{"type": "Battery", "status": "Charging", "capacity": "60"})
+	if battery := linuxBattery(dir); !battery.Present || battery.Percent != 80 || !battery.PluggedIn {
+		t.Errorf("Expected two batteries at 80%% plugged in, got %+v", battery)
+	}
+	supply("BAT1", map[string]string{"status": "Discharging"})
+	if battery := linuxBattery(dir); battery.PluggedIn {
+		t.Errorf("Expected a discharging battery to count as unplugged, got %+v", battery)
+	}
+}
+
+func TestParsePmset(t *testing.T) {
+	battery := parsePmset("Now drawing from 'Battery Power'\n -InternalBattery-0 (id=4653155)\t12%; discharging; 0:41 remaining present: true\n")
+	if !battery.Present || battery.Percent != 12 || battery.PluggedIn {
+		t.Errorf("Expected 12%% on battery, got %+v", battery)
+	}
+	battery = parsePmset("Now drawing from 'AC Power'\n -InternalBattery-0 (id=4653155)\t95%; charging; 0:20 remaining present: true\n")
+	if !battery.Present || battery.Percent != 95 || !battery.PluggedIn {
+		t.Errorf("Expected 95%% plugged in, got %+v", battery)
+	}
+	if battery := parsePmset("Now drawing from 'AC Power'\n"); battery.Present {
+		t.Errorf("Expected no battery on a desktop Mac, got %+v", battery)
+	}
+}
+
+func TestParseWin32Battery(t *testing.T) {
+	if battery := parseWin32Battery("42 1\r\n"); !battery.Present || battery.Percent != 42 || battery.PluggedIn {
+		t.Errorf("Expected 42%% on battery, got %+v", battery)
+	}
+	if battery := parseWin32Battery("88 6\r\n"); !battery.PluggedIn {
+		t.Errorf("Expected a charging battery to be plugged in, got %+v", battery)
+	}
+	if battery := parseWin32Battery(""); battery.Present {
+		t.Errorf("Expected no battery without output, got %+v", battery)
+	}
+}
+
+func TestBatteryPill(t *testing.T) {
+	if pill := formatBatteryPill(BatteryStatus{}); pill != "" {
+		t.Errorf("Expected no pill without a battery, got %q", pill)
+	}
+	if pill := formatBatteryPill(BatteryStatus{Present: true, Percent: 9}); pill != "🔋 9%" {
+		t.Errorf("Expected %q, got %q", "🔋 9%", pill)
+	}
+	if pill := formatBatteryPill(BatteryStatus{Present: true, Percent: 70, PluggedIn: true}); pill != "🔌 70%" {
+		t.Errorf("Expected %q, got %q", "🔌 70%", pill)
+	}
+
+	off := false
+	m := Model{config: &Config{}}
+	m.config.UI.Battery = &off
+	if m.readBatteryCmd() != nil {
+		t.Error("Expected no battery reading with ui.battery off")
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+)
+
+// Capability is an optional program GoDay runs for some features
+type Capability struct {
+	Binary  string   // looked up in $PATH
+	Feature string   // what is turned off without it
+	Widgets []string // tiles that have nothing to show without it
+	Hint    string   // how to install it
+}
+
+// optionalCapabilities lists the programs GoDay can do without on goos. Programs every
+// system of that kind ships, such as osascript on macOS, are left out.
+func optionalCapabilities(goos string) []Capability {
+	capabilities := []Capability{{
+		Binary:  "git",
+		Feature: "the Commits tile and goday sync through a git repository",
+		Widgets: []string{"commits"},
+		Hint:    "install git from https://git-scm.com/downloads",
+	}, {
+		Binary:  "govulncheck",
+		Feature: "the Go Vulnerabilities tile",
+		Widgets: []string{"vulns"},
+		Hint:    "go install golang.org/x/vuln/cmd/govulncheck@latest",
+	}}
+	switch goos {
+	case "darwin", "windows":
+	default:
+		capabilities = append(capabilities, Capability{
+			Binary:  "notify-send",
+			Feature: "desktop notifications; the notify attention level rings the bell instead",
+			Hint:    "install libnotify, e.g. apt install libnotify-bin or dnf install libnotify",
+		})
+	}
+	return capabilities
+}
+
+// Capabilities records which optional programs are missing, found once at startup
+type Capabilities struct {
+	goos    string
+	missing map[string]Capability
+}
+
+// DetectCapabilities looks up each optional program for goos with lookPath
+func DetectCapabilities(goos string, lookPath func(string) (string, error)) *Capabilities {
+	c := &Capabilities{goos: goos, missing: make(map[string]Capability)}
+	for _, capability := range optionalCapabilities(goos) {
+		if _, err := lookPath(capability.Binary); err != nil {
+			c.missing[capability.Binary] = capability
+		}
+	}
+	return c
+}
+
+// Missing reports whether binary was not found; nil capabilities count as complete
+func (c *Capabilities) Missing(binary string) bool {
+	if c == nil {
+		return false
+	}
+	_, missing := c.missing[binary]
+	return missing
+}
+
+// MissingFor returns the missing program a widget needs, if any
+func (c *Capabilities) MissingFor(widget string) (Capability, bool) {
+	if c == nil {
+		return Capability{}, false
+	}
+	for _, capability := range c.missing {
+		for _, key := range capability.Widgets {
+			if key == widget {
+				return capability, true
+			}
+		}
+	}
+	return Capability{}, false
+}
+
+// Degrade replaces the placeholder of every widget whose program is missing with one
+// line saying so; those widgets are not fetched
+func (c *Capabilities) Degrade(wm *WidgetManager) {
+	if c == nil {
+		return
+	}
+	for _, capability := range c.missing {
+		for _, key := range capability.Widgets {
+			if widget, exists := wm.Widgets[key]; exists {
+				widget.Items = []WidgetItem{missingCapabilityItem(capability)}
+				widget.Count = 0
+				widget.HasError = false
+			}
+		}
+	}
+}
+
+// missingCapabilityItem is the one line a widget shows without its program
+func missingCapabilityItem(capability Capability) WidgetItem {
+	return WidgetItem{
+		Title:    capability.Binary + " not found",
+		Subtitle: "Run goday doctor for how to install it",
+		Status:   "⚠️",
+	}
+}
+
+// missingBinaryError explains a feature that cannot run without binary
+func missingBinaryError(binary string) error {
+	return fmt.Errorf("%s is not installed; run goday doctor for how to install it", binary)
+}
+
+// describe lists each optional program for goday doctor, with what is off without it
+func (c *Capabilities) describe() []string {
+	var lines []string
+	for _, capability := range optionalCapabilities(c.goos) {
+		if !c.Missing(capability.Binary) {
+			lines = append(lines, fmt.Sprintf("  %-12s found", capability.Binary+":"))
+			continue
+		}
+		lines = append(lines,
+			fmt.Sprintf("  %-12s missing, turning off %s", capability.Binary+":", capability.Feature),
+			"               To fix: "+capability.Hint)
+	}
+	return lines
+}
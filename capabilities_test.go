@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// lookPathWithout finds every program except the missing ones
+func lookPathWithout(missing ...string) func(string) (string, error) {
+	return func(binary string) (string, error) {
+		for _, name := range missing {
+			if name == binary {
+				return "", exec.ErrNotFound
+			}
+		}
+		return "/usr/bin/" + binary, nil
+	}
+}
+
+func TestDetectCapabilities(t *testing.T) {
+	caps := DetectCapabilities("linux", lookPathWithout("git", "notify-send"))
+	if !caps.Missing("git") || !caps.Missing("notify-send") {
+		t.Error("Expected git and notify-send to be missing")
+	}
+	if capability, missing := caps.MissingFor("commits"); !missing || capability.Binary != "git" {
+		t.Errorf("Expected the commits widget to need git, got %+v", capability)
+	}
+	if _, missing := caps.MissingFor("news"); missing {
+		t.Error("Expected the news widget to need no program")
+	}
+
+	// macOS always has osascript, so only git is looked for
+	if caps := DetectCapabilities("darwin", lookPathWithout("notify-send")); caps.Missing("notify-send") {
+		t.Error("Expected notify-send not to be looked for on macOS")
+	}
+	var none *Capabilities
+	if none.Missing("git") {
+		t.Error("Expected nil capabilities to miss nothing")
+	}
+}
+
+func TestCapabilitiesDegrade(t *testing.T) {
+	wm := NewWidgetManager()
+	wm.InitializeWidgets(nil)
+	DetectCapabilities("linux", lookPathWithout("git")).Degrade(wm)
+	items := wm.Widgets["commits"].Items
+	if len(items) != 1 || items[0].Title != "git not found" || !strings.Contains(items[0].Subtitle, "goday doctor") {
+		t.Errorf("Expected one line saying git is missing, got %+v", items)
+	}
+
+	m := Model{
+		capabilities: DetectCapabilities("linux", lookPathWithout("git")),
+		versions:     NewDataVersions(),
+		refresh:      NewRefreshProgress(),
+	}
+	if _, cmd := m.Update(fetchGitCommitsCmd{}); cmd != nil {
+		t.Error("Expected the commits fetch to stop without git")
+	}
+	_, cmd := m.Update(refreshNowMsg{fetch: fetchGitCommitsCmd{}})
+	if msgs := immediateMsgs(cmd); len(msgs) != 1 || msgs[0] != (refreshDoneMsg{widget: "commits"}) {
+		t.Errorf("Expected r not to fetch commits without git but to report them done, got %v", msgs)
+	}
+
+	// R leaves them out of the refresh
+	m.config = &Config{}
+	model, _ := m.Update(keyPress("R"))
+	if pending := model.(Model).refresh.pending; pending["commits"] || !pending["prs"] {
+		t.Errorf("Expected R to refresh the other widgets but not the commits, got %v", pending)
+	}
+}
+
+func TestCapabilitiesDescribe(t *testing.T) {
+	lines := strings.Join(DetectCapabilities("linux", lookPathWithout("notify-send")).describe(), "\n")
+	if !strings.Contains(lines, "git:") || !strings.Contains(lines, "found") {
+		t.Errorf("Expected git to be listed as found, got:\n%s", lines)
+	}
+	if !strings.Contains(lines, "notify-send: missing") || !strings.Contains(lines, "libnotify") {
+		t.Errorf("Expected notify-send to be listed as missing with a hint, got:\n%s", lines)
+	}
+
+	if err := missingBinaryError("git"); !strings.Contains(err.Error(), "goday doctor") || errors.Is(err, exec.ErrNotFound) {
+		t.Errorf("Expected a plain error pointing at goday doctor, got %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// certDefaultWarnDays turns a certificate yellow without widgets.certs.warn_days
+	certDefaultWarnDays = 30
+	// certDefaultCriticalDays turns a certificate red without widgets.certs.critical_days
+	certDefaultCriticalDays = 7
+	// certDialTimeout bounds the TLS handshake with one host
+	certDialTimeout = 10 * time.Second
+)
+
+// CertStatus is the certificate a host presents and when it expires
+type CertStatus struct {
+	Host         string    // as configured, e.g. example.com or example.com:8443
+	Expires      time.Time // when the first certificate in the chain expires
+	Issuer       string    // organization that issued the host's certificate
+	Intermediate string    // intermediate that expires before the host's own certificate, if any
+	Problem      string    // why the certificate does not verify, e.g. a name mismatch
+	Err          string    // why the host could not be reached
+	Level        string    // 🟢, or 🟡 and 🔴 by warn_days and critical_days
+}
+
+// DaysLeft returns the whole days until the certificate expires, negative once it has
+func (c CertStatus) DaysLeft(now time.Time) int {
+	return daysUntil(c.Expires, now)
+}
+
+// daysUntil returns the whole days from now until t, negative once t has passed
+func daysUntil(t, now time.Time) int {
+	return int(math.Floor(t.Sub(now).Hours() / 24))
+}
+
+// CertPlugin checks the TLS certificates of a list of hosts and how soon they expire
+type CertPlugin struct {
+	id           string
+	pluginType   string
+	name         string
+	version      string
+	description  string
+	author       string
+	hosts        []string
+	warnDays     int
+	criticalDays int
+	roots        *x509.CertPool // nil verifies against the system roots
+	lastData     []CertStatus
+}
+
+// NewCertPlugin creates a new certificate expiry plugin
+func NewCertPlugin() *CertPlugin {
+	return &CertPlugin{
+		id:           "certs",
+		pluginType:   "certs",
+		name:         "Certificates",
+		version:      "1.0.0",
+		description:  "Warns before the TLS certificates of your hosts expire",
+		author:       "GoDay Team",
+		warnDays:     certDefaultWarnDays,
+		criticalDays: certDefaultCriticalDays,
+		lastData:     []CertStatus{},
+	}
+}
+
+// GetID returns the plugin ID
+func (cp *CertPlugin) GetID() string {
+	return cp.id
+}
+
+// GetType returns the plugin type
+func (cp *CertPlugin) GetType() string {
+	return cp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (cp *CertPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        cp.name,
+		Version:     cp.version,
+		Description: cp.description,
+		Author:      cp.author,
+		Type:        cp.pluginType,
+		Config: map[string]string{
+			"hosts":         strings.Join(cp.hosts, ","),
+			"warn_days":     fmt.Sprintf("%d", cp.warnDays),
+			"critical_days": fmt.Sprintf("%d", cp.criticalDays),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (cp *CertPlugin) Initialize(config map[string]interface{}) error {
+	cp.hosts = configStringList(config["hosts"])
+	for _, host := range cp.hosts {
+		if strings.Contains(host, "://") || strings.Contains(host, "/") {
+			return fmt.Errorf("certificate host %q must be a hostname, optionally with :port", host)
+		}
+	}
+	if days, ok := config["warn_days"].(int); ok && days > 0 {
+		cp.warnDays = days
+	}
+	if days, ok := config["critical_days"].(int); ok && days > 0 {
+		cp.criticalDays = days
+	}
+	if cp.criticalDays > cp.warnDays {
+		return fmt.Errorf("critical_days (%d) must not be more than warn_days (%d)", cp.criticalDays, cp.warnDays)
+	}
+	return nil
+}
+
+// Fetch checks every host concurrently and returns them soonest expiry first, hosts that
+// could not be checked before all others
+func (cp *CertPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if len(cp.hosts) == 0 {
+		return cp.lastData, fmt.Errorf("no hosts configured (widgets.certs.hosts)")
+	}
+
+	results := make([]CertStatus, len(cp.hosts))
+	var wg sync.WaitGroup
+	for i, host := range cp.hosts {
+		wg.Add(1)
+		go func(i int, host string) {
+			defer wg.Done()
+			results[i] = cp.check(ctx, host)
+		}(i, host)
+	}
+	wg.Wait()
+
+	now := time.Now()
+	for i := range results {
+		results[i].Level = certLevel(results[i], now, cp.warnDays, cp.criticalDays)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if (results[i].Err != "") != (results[j].Err != "") {
+			return results[i].Err != ""
+		}
+		return results[i].Expires.Before(results[j].Expires)
+	})
+
+	cp.lastData = results
+	return results, nil
+}
+
+// check completes a TLS handshake with host and reads the chain it presents. The chain is
+// read even when it does not verify, so an expired or mismatched certificate still shows
+// its dates, with the problem alongside.
+func (cp *CertPlugin) check(ctx context.Context, host string) CertStatus {
+	status := CertStatus{Host: host}
+	serverName, port, err := net.SplitHostPort(host)
+	if err != nil {
+		serverName, port = host, "443"
+	}
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: certDialTimeout},
+		// Verified below, so that a bad certificate can still be described
+		Config: &tls.Config{ServerName: serverName, InsecureSkipVerify: true},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(serverName, port))
+	if err != nil {
+		status.Err = err.Error()
+		return status
+	}
+	defer conn.Close()
+
+	chain := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(chain) == 0 {
+		status.Err = "no certificate presented"
+		return status
+	}
+	// The chain breaks when its first certificate expires, which may be an intermediate
+	first := chain[0]
+	for _, cert := range chain[1:] {
+		if cert.NotAfter.Before(first.NotAfter) {
+			first = cert
+		}
+	}
+	status.Expires = first.NotAfter
+	if first != chain[0] {
+		status.Intermediate = first.Subject.CommonName
+	}
+	status.Issuer = chain[0].Issuer.CommonName
+	if len(chain[0].Issuer.Organization) > 0 {
+		status.Issuer = chain[0].Issuer.Organization[0]
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := chain[0].Verify(x509.VerifyOptions{
+		DNSName:       serverName,
+		Intermediates: intermediates,
+		Roots:         cp.roots,
+	}); err != nil {
+		status.Problem = certProblem(err)
+	}
+	return status
+}
+
+// certProblem shortens a verification error for the tile
+func certProblem(err error) string {
+	switch e := err.(type) {
+	case x509.HostnameError:
+		return "name mismatch"
+	case x509.UnknownAuthorityError:
+		return "untrusted issuer"
+	case x509.CertificateInvalidError:
+		if e.Reason == x509.Expired {
+			return "expired"
+		}
+	}
+	return err.Error()
+}
+
+// certLevel returns the status icon for a certificate: 🔴 once it expires within
+// criticalDays or does not verify, 🟡 within warnDays, otherwise 🟢
+func certLevel(cert CertStatus, now time.Time, warnDays, criticalDays int) string {
+	if cert.Err != "" || cert.Problem != "" {
+		return "🔴"
+	}
+	return expiryLevel(cert.DaysLeft(now), warnDays, criticalDays)
+}
+
+// expiryLevel returns 🔴 for days left below criticalDays, 🟡 below warnDays, otherwise 🟢
+func expiryLevel(days, warnDays, criticalDays int) string {
+	switch {
+	case days < criticalDays:
+		return "🔴"
+	case days < warnDays:
+		return "🟡"
+	}
+	return "🟢"
+}
+
+// formatDaysLeft describes how soon a certificate or domain expires
+func formatDaysLeft(days int) string {
+	switch {
+	case days == -1:
+		return "expired yesterday"
+	case days < 0:
+		return fmt.Sprintf("expired %d days ago", -days)
+	case days == 0:
+		return "expires today"
+	case days == 1:
+		return "expires tomorrow"
+	}
+	return fmt.Sprintf("expires in %d days", days)
+}
+
+// Cleanup performs cleanup
+func (cp *CertPlugin) Cleanup() error {
+	return nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCertPluginFetch(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	addr := strings.TrimPrefix(server.URL, "https://")
+	_, port, _ := net.SplitHostPort(addr)
+
+	// A port nobody listens on
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	closed := listener.Addr().String()
+	listener.Close()
+
+	plugin := NewCertPlugin()
+	if err := plugin.Initialize(map[string]interface{}{
+		"hosts": []string{addr, "localhost:" + port, closed},
+	}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	plugin.roots = x509.NewCertPool()
+	plugin.roots.AddCert(server.Certificate())
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	certs := data.([]CertStatus)
+	if len(certs) != 3 || certs[0].Host != closed || certs[0].Err == "" || certs[0].Level != "🔴" {
+		t.Fatalf("Expected the unreachable host first, got %+v", certs)
+	}
+	for _, cert := range certs[1:] {
+		if !cert.Expires.Equal(server.Certificate().NotAfter) {
+			t.Errorf("Expected the server certificate's expiry, got %v", cert.Expires)
+		}
+		switch cert.Host {
+		case addr:
+			if cert.Problem != "" || cert.Level != "🟢" || cert.Issuer != "Acme Co" {
+				t.Errorf("Expected a valid certificate from Acme Co, got %+v", cert)
+			}
+		default:
+			if cert.Problem != "name mismatch" || cert.Level != "🔴" {
+				t.Errorf("Expected a name mismatch for localhost, got %+v", cert)
+			}
+		}
+	}
+
+	// Without the test CA the certificate is not trusted
+	plugin.roots = nil
+	data, _ = plugin.Fetch(context.Background())
+	for _, cert := range data.([]CertStatus) {
+		if cert.Host == addr && cert.Problem != "untrusted issuer" {
+			t.Errorf("Expected an untrusted issuer, got %+v", cert)
+		}
+	}
+}
+
+func TestCertPluginInitialize(t *testing.T) {
+	if err := NewCertPlugin().Initialize(map[string]interface{}{"hosts": []string{"https://example.com"}}); err == nil {
+		t.Error("Expected a URL to be rejected as host")
+	}
+	if err := NewCertPlugin().Initialize(map[string]interface{}{"warn_days": 5}); err == nil {
+		t.Error("Expected warn_days below critical_days to be rejected")
+	}
+	if _, err := NewCertPlugin().Fetch(context.Background()); err == nil {
+		t.Error("Expected an error without hosts")
+	}
+}
+
+func TestUpdateCertsWidget(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	certs := []CertStatus{
+		{Host: "old.example.com", Expires: now.Add(-2 * day), Problem: "expired"},
+		{Host: "api.example.com", Expires: now.Add(5*day + time.Hour), Issuer: "Let's Encrypt"},
+		{Host: "www.example.com", Expires: now.Add(20 * day), Intermediate: "R3"},
+		{Host: "docs.example.com", Expires: now.Add(90 * day), Issuer: "Let's Encrypt"},
+	}
+	want := []string{"🔴", "🔴", "🟡", "🟢"}
+	for i := range certs {
+		if got := certLevel(certs[i], now, 30, 7); got != want[i] {
+			t.Errorf("Expected %s for %s, got %s", want[i], certs[i].Host, got)
+		}
+		certs[i].Level = want[i]
+	}
+
+	wm := NewWidgetManager()
+	wm.UpdateCertsWidget(certs, now)
+	widget := wm.Widgets["certs"]
+	if widget.Count != 3 {
+		t.Errorf("Expected 3 certificates needing attention, got %d", widget.Count)
+	}
+	if got := widget.Items[0].Subtitle; !strings.HasPrefix(got, "expired 2 days ago • ") || strings.Contains(got, "• expired") {
+		t.Errorf("Expected the expiry once, got '%s'", got)
+	}
+	if got := widget.Items[1].Subtitle; !strings.HasPrefix(got, "expires in 5 days • ") || !strings.HasSuffix(got, "Let's Encrypt") {
+		t.Errorf("Expected days left and issuer, got '%s'", got)
+	}
+	if got := widget.Items[2].Subtitle; !strings.HasSuffix(got, "intermediate R3") {
+		t.Errorf("Expected the intermediate that expires first, got '%s'", got)
+	}
+	if widget.Items[3].URL != "https://docs.example.com" {
+		t.Errorf("Expected a link to the host, got '%s'", widget.Items[3].URL)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toastDuration is how long a toast, such as "Copied", stays in place of the key legend
+const toastDuration = 2 * time.Second
+
+// copiedMsg reports that text was copied to the clipboard, or why it was not
+type copiedMsg struct {
+	what string // "link" or "title"
+	text string
+	err  error
+}
+
+// toastDoneMsg clears the toast it was sent for, unless a newer one replaced it
+type toastDoneMsg struct{ id int }
+
+// clipboardCommand returns the program that copies its stdin to the clipboard on goos:
+// pbcopy on macOS, clip on Windows and, elsewhere, wl-copy under Wayland or xclip or xsel
+func clipboardCommand(goos string, getenv func(string) string, lookPath func(string) (string, error)) ([]string, error) {
+	switch goos {
+	case "darwin":
+		return []string{"pbcopy"}, nil
+	case "windows":
+		return []string{"clip"}, nil
+	}
+	candidates := [][]string{
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	}
+	if getenv("WAYLAND_DISPLAY") != "" {
+		candidates = append([][]string{{"wl-copy"}}, candidates...)
+	}
+	for _, candidate := range candidates {
+		if _, err := lookPath(candidate[0]); err == nil {
+			return candidate, nil
+		}
+	}
+	return nil, errors.New("no clipboard program found; install wl-clipboard, xclip or xsel")
+}
+
+// copyToClipboard copies text to the system clipboard in the background
+func copyToClipboard(what, text string) tea.Cmd {
+	return func() tea.Msg {
+		args, err := clipboardCommand(runtime.GOOS, os.Getenv, exec.LookPath)
+		if err != nil {
+			return copiedMsg{what: what, text: text, err: err}
+		}
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		return copiedMsg{what: what, text: text, err: cmd.Run()}
+	}
+}
+
+// showToast shows text in place of the key legend for toastDuration
+func (m *Model) showToast(text string) tea.Cmd {
+	m.toastID++
+	m.toast = text
+	id := m.toastID
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg { return toastDoneMsg{id: id} })
+}
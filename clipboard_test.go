@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestClipboardCommand(t *testing.T) {
+	found := func(names ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, n := range names {
+				if n == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		}
+	}
+	env := func(wayland string) func(string) string {
+		return func(string) string { return wayland }
+	}
+
+	tests := []struct {
+		goos     string
+		wayland  string
+		programs []string
+		want     string
+	}{
+		{"darwin", "", nil, "pbcopy"},
+		{"windows", "", nil, "clip"},
+		{"linux", "wayland-0", []string{"wl-copy", "xclip"}, "wl-copy"},
+		{"linux", "", []string{"wl-copy", "xclip"}, "xclip -selection clipboard"},
+		{"freebsd", "", []string{"xsel"}, "xsel --clipboard --input"},
+	}
+	for _, test := range tests {
+		args, err := clipboardCommand(test.goos, env(test.wayland), found(test.programs...))
+		if err != nil || strings.Join(args, " ") != test.want {
+			t.Errorf("Expected %q on %s, got %q (%v)", test.want, test.goos, args, err)
+		}
+	}
+	if _, err := clipboardCommand("linux", env(""), found()); err == nil {
+		t.Error("Expected an error without a clipboard program")
+	}
+}
+
+func TestCopyToast(t *testing.T) {
+	m := benchmarkModel(120, 40)
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}); cmd == nil {
+		t.Error("Expected y to copy the selected item")
+	}
+
+	updated, _ := m.Update(copiedMsg{what: "link", text: "https://github.com/golang/go/pull/1"})
+	m = updated.(Model)
+	view := m.View()
+	if !strings.Contains(view, "Copied link: https://github.com/golang/go/pull/1") || strings.Contains(view, "all keys") {
+		t.Errorf("Expected the toast in place of the legend, got:\n%s", view)
+	}
+
+	// A newer toast is not cleared by the older one's timer
+	updated, _ = m.Update(copiedMsg{what: "title", text: "Standup", err: errors.New("no clipboard program found")})
+	m = updated.(Model)
+	updated, _ = m.Update(toastDoneMsg{id: m.toastID - 1})
+	m = updated.(Model)
+	if !strings.Contains(m.View(), "Could not copy: no clipboard program found") {
+		t.Error("Expected the newer toast to stay")
+	}
+	updated, _ = m.Update(toastDoneMsg{id: m.toastID})
+	m = updated.(Model)
+	if view := m.View(); strings.Contains(view, "Could not copy") || !strings.Contains(view, "all keys") {
+		t.Errorf("Expected the legend back once the toast is done, got:\n%s", view)
+	}
+}
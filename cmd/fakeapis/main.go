@@ -0,0 +1,52 @@
+// Command fakeapis serves the emulated upstream APIs from internal/fakeapis, for running
+// the dashboard against canned data:
+//
+//	go run ./cmd/fakeapis -addr :8089 -scenario normal
+//
+// Point the plugins' API URLs at it, e.g. widgets.jira.base_url: http://localhost:8089.
+// POST /scenario?name=500&service=github switches scenarios while it runs.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/bhanu/goday/internal/fakeapis"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8089", "address to listen on")
+	scenario := flag.String("scenario", string(fakeapis.Normal), "initial scenario for every service")
+	slow := flag.Duration("slow-delay", 2*time.Second, "how long the slow scenario holds responses")
+	flag.Parse()
+
+	initial, err := fakeapis.ParseScenario(*scenario)
+	if err != nil {
+		log.Fatal(err)
+	}
+	api := fakeapis.New()
+	api.SlowDelay = *slow
+	api.SetScenario(initial)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", api)
+	mux.HandleFunc("POST /scenario", func(w http.ResponseWriter, r *http.Request) {
+		scenario, err := fakeapis.ParseScenario(r.URL.Query().Get("name"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var services []fakeapis.Service
+		if service := r.URL.Query().Get("service"); service != "" {
+			services = append(services, fakeapis.Service(service))
+		}
+		api.SetScenario(scenario, services...)
+		fmt.Fprintln(w, scenario)
+	})
+
+	log.Printf("Serving fake GitHub, Jira, OpenWeatherMap, OSRM, news feed and daylight APIs on http://%s (%s)", *addr, initial)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
@@ -1,56 +1,514 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	User struct {
-		Name     string `yaml:"name"`
-		Location string `yaml:"location"`
+	Version int `yaml:"version" desc:"Config format version (managed by GoDay)"`
+	User    struct {
+		Name     string `yaml:"name" desc:"Name shown in the header"`
+		Location string `yaml:"location" desc:"Location for weather, e.g. \"Bengaluru,IN\""`
 	} `yaml:"user"`
 	UI struct {
-		Layout     string `yaml:"layout"`
-		MinWidth   int    `yaml:"min_width"`
-		TileHeight int    `yaml:"tile_height"`
+		Layout        string                `yaml:"layout" desc:"Dashboard layout"`
+		MinWidth      int                   `yaml:"min_width" desc:"Minimum terminal width"`
+		TileHeight    int                   `yaml:"tile_height" desc:"Height of each widget tile"`
+		Widgets       []string              `yaml:"widgets,omitempty" desc:"Visible widgets in display order (default: all)"`
+		StateFile     string                `yaml:"state_file,omitempty" desc:"JSON snapshot written after each refresh for status bars (default: ~/.goday/state.json; off disables)"`
+		Tiles         map[string]TileConfig `yaml:"tiles,omitempty" desc:"Tile titles and counts, keyed by widget, e.g. prs"`
+		Terminal      TerminalConfig        `yaml:"terminal,omitempty" desc:"What the terminal can render; detected on first run and by goday doctor"`
+		LastSession   string                `yaml:"last_session,omitempty" desc:"Directory the dashboard is saved to on quit, as JSON and text, and shown from on the next start until fresh data loads (default: ~/.goday/last-session; off disables)"`
+		Battery       *bool                 `yaml:"battery,omitempty" desc:"Show the battery charge next to the clock, red below 15%; only on machines with a battery (default: true)"`
+		Accessibility AccessibilityConfig   `yaml:"accessibility,omitempty" desc:"High-contrast colors and reduced motion"`
 	} `yaml:"ui"`
 	Widgets struct {
 		Weather struct {
-			TTL    string `yaml:"ttl"`
-			APIKey string `yaml:"api_key"`
+			TTL          string `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 600s"`
+			Provider     string `yaml:"provider" enum:"wttr,open-meteo,openweathermap,owm" desc:"Weather source; open-meteo adds an hourly forecast (default: openweathermap when api_key is set, otherwise wttr)"`
+			APIKey       string `yaml:"api_key" desc:"OpenWeatherMap API key; wttr needs none"`
+			DailyQuota   int    `yaml:"daily_quota,omitempty" desc:"OpenWeatherMap calls allowed per day (default: 1000, the free plan)"`
+			ShowForecast bool   `yaml:"show_forecast,omitempty" desc:"Show a Weather tile with the 5-day forecast; OpenWeatherMap then makes two calls per refresh"`
 		} `yaml:"weather"`
 		News struct {
-			TTL      string   `yaml:"ttl"`
-			Tags     []string `yaml:"tags"`
-			Provider string   `yaml:"provider"`
+			TTL      string     `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 600s"`
+			Tags     []string   `yaml:"tags" desc:"Tags cycled with the t key"`
+			Provider string     `yaml:"provider" enum:"aggregate,hn,devto,hackernoon,mastodon,rss,stackoverflow,producthunt,arxiv,security" desc:"News source (default: aggregate)"`
+			Sources  []string   `yaml:"sources,omitempty" desc:"Sources combined by the aggregate provider: hn, devto, hackernoon, rss, producthunt, arxiv, security (default: hackernoon, devto, rss)"`
+			Feeds    []NewsFeed `yaml:"feeds,omitempty" desc:"RSS/Atom feeds shown by the rss provider and added to aggregate"`
+			Mastodon struct {
+				Instance    string   `yaml:"instance" desc:"Mastodon server, e.g. fosstodon.org"`
+				AccessToken string   `yaml:"access_token" desc:"Access token with read scope, for mentions (default: $MASTODON_ACCESS_TOKEN)"`
+				Hashtags    []string `yaml:"hashtags" desc:"Hashtag timelines to show (default: the news tags)"`
+			} `yaml:"mastodon,omitempty"`
+			StackOverflow struct {
+				Site        string   `yaml:"site,omitempty" desc:"Stack Exchange site, e.g. serverfault (default: stackoverflow)"`
+				Tags        []string `yaml:"tags,omitempty" desc:"Question tags to show (default: the news tags)"`
+				Sort        string   `yaml:"sort,omitempty" enum:"newest,unanswered" desc:"Which questions to show (default: newest)"`
+				Key         string   `yaml:"key,omitempty" desc:"Stack Apps key; raises the daily quota and is required for the inbox"`
+				AccessToken string   `yaml:"access_token,omitempty" desc:"Access token with read_inbox scope, for your inbox and reputation (default: $STACKEXCHANGE_ACCESS_TOKEN)"`
+			} `yaml:"stackoverflow,omitempty"`
+			ProductHunt struct {
+				Token string `yaml:"token,omitempty" desc:"Product Hunt API developer token (default: $PRODUCTHUNT_TOKEN)"`
+			} `yaml:"producthunt,omitempty"`
+			Arxiv struct {
+				Categories []string `yaml:"categories,omitempty" desc:"arXiv categories to follow, e.g. cs.LG, cs.CR; each is a tag (default: cs.LG, cs.CR)"`
+			} `yaml:"arxiv,omitempty"`
+			Security struct {
+				Keywords  []string `yaml:"keywords,omitempty" desc:"Products to follow advisories for, e.g. kubernetes, openssl; each is a tag (default: every CISA KEV addition, and no NVD search)"`
+				NVDAPIKey string   `yaml:"nvd_api_key,omitempty" desc:"NVD API key; raises the rate limit from 5 to 50 requests in 30 seconds (default: $NVD_API_KEY)"`
+			} `yaml:"security,omitempty"`
+			Language struct {
+				Keep      []string `yaml:"keep,omitempty" desc:"Only show articles detected in these languages, as ISO 639-1 codes, e.g. [en]; articles whose language is unclear are kept (default: every language)"`
+				Translate string   `yaml:"translate,omitempty" desc:"Translates foreign-language RSS titles to English: a shell command reading the title on stdin with $GODAY_SOURCE_LANG set, e.g. trans -b :en, or the URL of a LibreTranslate-compatible /translate API"`
+				APIKey    string   `yaml:"api_key,omitempty" desc:"Key for the translation API (default: $LIBRETRANSLATE_API_KEY)"`
+			} `yaml:"language,omitempty"`
 		} `yaml:"news"`
+		PRs struct {
+			HideDrafts     bool     `yaml:"hide_drafts" desc:"Hide draft pull requests"`
+			HideWIP        bool     `yaml:"hide_wip" desc:"Hide PRs titled WIP or carrying a WIP label"`
+			WIPLabels      []string `yaml:"wip_labels,omitempty" desc:"Labels that mark a PR as WIP (default: wip, do-not-review, do not review)"`
+			Bots           string   `yaml:"bots,omitempty" enum:"show,hide,group" desc:"PRs opened by bots such as dependabot and renovate: show, hide, or group into a collapsible section (default: show)"`
+			DependencyBots []string `yaml:"dependency_bots,omitempty" desc:"Authors whose PRs are dependency updates for bulk approve/merge (default: app/dependabot, app/renovate)"`
+			MergeMethod    string   `yaml:"merge_method,omitempty" enum:"merge,squash,rebase" desc:"How bulk merge merges dependency updates (default: squash)"`
+		} `yaml:"prs,omitempty"`
+		Issues struct {
+			Repos        []string `yaml:"repos,omitempty" desc:"Repos to triage as owner/name (default: repos owned by you and the github-prs orgs)"`
+			Labels       []string `yaml:"labels,omitempty" desc:"Labels on the 1-9 keys of issue triage (default: bug, enhancement, question, duplicate)"`
+			CloseComment string   `yaml:"close_comment,omitempty" desc:"Comment prefilled when closing an issue from triage"`
+		} `yaml:"issues,omitempty"`
 		Slack struct {
-			TTL string `yaml:"ttl"`
+			TTL   string `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 20s"`
+			Token string `yaml:"token,omitempty" desc:"Slack user token with search:read, for the mentions tile (default: $SLACK_USER_TOKEN)"`
 		} `yaml:"slack"`
+		Teams struct {
+			TTL         string   `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 120s"`
+			Provider    string   `yaml:"provider" enum:"graph" desc:"Teams source (default: graph)"`
+			AccessToken string   `yaml:"access_token" desc:"Microsoft Graph access token (default: $MS_GRAPH_TOKEN)"`
+			Channels    []string `yaml:"channels" desc:"Channels to scan for mentions as teamID/channelID"`
+			Lookback    string   `yaml:"lookback" format:"duration" desc:"How far back to look for channel mentions (default: 24h)"`
+		} `yaml:"teams"`
+		Discord struct {
+			TTL      string   `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 60s"`
+			Provider string   `yaml:"provider" enum:"bot" desc:"Discord source (default: bot)"`
+			BotToken string   `yaml:"bot_token" desc:"Discord bot token (default: $DISCORD_BOT_TOKEN)"`
+			Channels []string `yaml:"channels" desc:"Channel IDs to show messages from"`
+			UserID   string   `yaml:"user_id" desc:"Your Discord user ID, to highlight mentions (default: the bot user)"`
+			Lookback string   `yaml:"lookback" format:"duration" desc:"How far back to show messages (default: 24h)"`
+		} `yaml:"discord"`
+		Discussions struct {
+			TTL      string   `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 600s"`
+			Provider string   `yaml:"provider" enum:"github" desc:"Discussions source (default: github)"`
+			Repos    []string `yaml:"repos" desc:"Repos whose Q&A discussions to watch, as owner/name; the tile is shown once set"`
+		} `yaml:"discussions"`
+		Mentions struct {
+			TTL        string   `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 120s"`
+			Provider   string   `yaml:"provider" enum:"unified" desc:"Mentions source (default: unified)"`
+			Sources    []string `yaml:"sources,omitempty" desc:"Systems to merge: github, jira, slack, email (default: every one with credentials); the tile is shown once set"`
+			GmailToken string   `yaml:"gmail_token,omitempty" desc:"Gmail API access token with gmail.readonly (default: $GMAIL_ACCESS_TOKEN)"`
+			EmailQuery string   `yaml:"email_query,omitempty" desc:"Gmail search for mail that needs you (default: is:unread to:me newer_than:7d)"`
+			JiraJQL    string   `yaml:"jira_jql,omitempty" desc:"JQL for Jira issues that mention you (default: comment ~ currentUser() AND updated >= -7d ORDER BY updated DESC)"`
+		} `yaml:"mentions,omitempty"`
+		Stocks struct {
+			TTL      string   `yaml:"ttl" format:"duration" desc:"Refresh interval during US market hours, e.g. 60s"`
+			Provider string   `yaml:"provider" enum:"finnhub" desc:"Quote source (default: finnhub)"`
+			APIKey   string   `yaml:"api_key,omitempty" desc:"Finnhub API key (default: $FINNHUB_API_KEY)"`
+			Symbols  []string `yaml:"symbols,omitempty" desc:"Ticker symbols to show, e.g. AAPL, MSFT; the tile is shown once set"`
+		} `yaml:"stocks,omitempty"`
+		Crypto struct {
+			TTL      string   `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 120s"`
+			Provider string   `yaml:"provider" enum:"coingecko" desc:"Price source (default: coingecko)"`
+			Coins    []string `yaml:"coins,omitempty" desc:"CoinGecko coin ids to show, e.g. bitcoin, ethereum; the tile is shown once set"`
+			Currency string   `yaml:"currency,omitempty" desc:"Currency prices are quoted in (default: usd)"`
+		} `yaml:"crypto,omitempty"`
+		FX struct {
+			TTL      string   `yaml:"ttl" format:"duration" desc:"Refresh interval; rates are published once per business day, e.g. 3600s"`
+			Provider string   `yaml:"provider" enum:"frankfurter" desc:"Rate source (default: frankfurter)"`
+			Pairs    []string `yaml:"pairs,omitempty" desc:"Currency pairs to show as BASE/QUOTE, e.g. USD/INR; the tile is shown once set"`
+		} `yaml:"fx,omitempty"`
+		AQI struct {
+			TTL      string `yaml:"ttl" format:"duration" desc:"Refresh interval; stations update hourly, e.g. 1800s"`
+			Provider string `yaml:"provider" enum:"waqi,openweathermap" desc:"Air quality source; openweathermap uses widgets.weather.api_key (default: waqi)"`
+			Token    string `yaml:"token,omitempty" desc:"WAQI API token from aqicn.org/data-platform/token; the tile is shown once set (default: $WAQI_TOKEN)"`
+			Station  string `yaml:"station,omitempty" desc:"WAQI city or station, e.g. bangalore, @8190 or geo:12.97;77.59 (default: the city of user.location)"`
+		} `yaml:"aqi,omitempty"`
+		Daylight struct {
+			TTL                string `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 3600s"`
+			Provider           string `yaml:"provider" enum:"sunrise-sunset" desc:"Sunrise and sunset source (default: sunrise-sunset)"`
+			GoldenHourReminder string `yaml:"golden_hour_reminder,omitempty" format:"duration" desc:"How long before the evening golden hour to point it out in the header and a desktop notification, e.g. 30m (default: off)"`
+		} `yaml:"daylight,omitempty"`
 		Confluence struct {
-			TTL string `yaml:"ttl"`
+			TTL string `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 300s"`
 		} `yaml:"confluence"`
+		PagerDuty struct {
+			TTL          string   `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 60s"`
+			Provider     string   `yaml:"provider" enum:"opsgenie,victorops" desc:"On-call and alerts backend; the tile is filled once this or the API key is set (default: opsgenie)"`
+			APIKey       string   `yaml:"api_key,omitempty" desc:"Opsgenie or Splunk On-Call API key with read access (default: $OPSGENIE_API_KEY or $VICTOROPS_API_KEY)"`
+			APIID        string   `yaml:"api_id,omitempty" desc:"Splunk On-Call API id that goes with api_key (default: $VICTOROPS_API_ID)"`
+			Organization string   `yaml:"organization,omitempty" desc:"Splunk On-Call organization slug, for links to incident timelines (default: $VICTOROPS_ORGANIZATION)"`
+			Region       string   `yaml:"region,omitempty" enum:"us,eu" desc:"Opsgenie instance your account is on (default: us)"`
+			Schedules    []string `yaml:"schedules,omitempty" desc:"Opsgenie schedule or Splunk On-Call team names or ids to show (default: every enabled schedule or team)"`
+		} `yaml:"pagerduty,omitempty"`
+		Alerts struct {
+			TTL      string   `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 60s"`
+			Provider string   `yaml:"provider" enum:"alertmanager" desc:"Alerts source (default: alertmanager)"`
+			URL      string   `yaml:"url,omitempty" desc:"Prometheus Alertmanager address, e.g. https://alertmanager.example.com; the tile is shown once set"`
+			Token    string   `yaml:"token,omitempty" desc:"Bearer token, when Alertmanager sits behind an authenticating proxy (default: $ALERTMANAGER_TOKEN)"`
+			Filters  []string `yaml:"filters,omitempty" desc:"Label matchers alerts must match, e.g. team=\"payments\" or severity=~\"critical|warning\" (default: every firing alert)"`
+		} `yaml:"alerts,omitempty"`
+		Status struct {
+			TTL      string             `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 120s"`
+			Provider string             `yaml:"provider" enum:"statuspage" desc:"Status source (default: statuspage)"`
+			Services []string           `yaml:"services,omitempty" desc:"Well-known status pages: aws, atlassian, cloudflare, discord, github, npm, slack, vercel; the tile is shown once these or pages are set"`
+			Pages    []StatusPageConfig `yaml:"pages,omitempty" desc:"Other status pages to watch"`
+		} `yaml:"status,omitempty"`
+		Uptime struct {
+			TTL      string        `yaml:"ttl" format:"duration" desc:"Interval between checks, e.g. 60s"`
+			Provider string        `yaml:"provider" enum:"http" desc:"Uptime source (default: http)"`
+			History  int           `yaml:"history,omitempty" desc:"Checks per endpoint kept for the history bar and uptime share (default: 10)"`
+			Checks   []UptimeCheck `yaml:"checks,omitempty" desc:"Endpoints to check; the tile is shown once set"`
+		} `yaml:"uptime,omitempty"`
+		Certs struct {
+			TTL          string   `yaml:"ttl" format:"duration" desc:"Interval between checks, e.g. 3600s"`
+			Provider     string   `yaml:"provider" enum:"tls" desc:"Certificate source (default: tls)"`
+			Hosts        []string `yaml:"hosts,omitempty" desc:"Hosts whose certificates to check, e.g. example.com or mail.example.com:993; the tile is shown once set"`
+			WarnDays     int      `yaml:"warn_days,omitempty" desc:"Days before expiry a certificate turns yellow (default: 30)"`
+			CriticalDays int      `yaml:"critical_days,omitempty" desc:"Days before expiry a certificate turns red (default: 7)"`
+		} `yaml:"certs,omitempty"`
+		Domains struct {
+			TTL          string   `yaml:"ttl" format:"duration" desc:"Interval between lookups, e.g. 86400s"`
+			Provider     string   `yaml:"provider" enum:"rdap" desc:"Domain source: RDAP, or WHOIS for top-level domains without RDAP (default: rdap)"`
+			Domains      []string `yaml:"domains,omitempty" desc:"Registered domains to watch, e.g. example.com; the tile is shown once set"`
+			WarnDays     int      `yaml:"warn_days,omitempty" desc:"Days before expiry a domain turns yellow (default: 30)"`
+			CriticalDays int      `yaml:"critical_days,omitempty" desc:"Days before expiry a domain turns red (default: 7)"`
+		} `yaml:"domains,omitempty"`
+		Trends struct {
+			TTL      string `yaml:"ttl" format:"duration" desc:"Interval between counts, e.g. 3600s"`
+			Provider string `yaml:"provider" enum:"github" desc:"Workload source (default: github)"`
+			Weeks    int    `yaml:"weeks,omitempty" desc:"Weeks the sparklines cover, at most 12; the tile is shown once set (default: 4)"`
+		} `yaml:"trends,omitempty"`
+		Releases struct {
+			TTL         string   `yaml:"ttl" format:"duration" desc:"Interval between checks, e.g. 3600s"`
+			Provider    string   `yaml:"provider" enum:"github" desc:"Release source (default: github)"`
+			Repos       []string `yaml:"repos,omitempty" desc:"Repositories to watch, as owner/name; the tile is shown once set"`
+			Prereleases bool     `yaml:"prereleases,omitempty" desc:"Count prereleases as new releases"`
+		} `yaml:"releases,omitempty"`
+		Vulns struct {
+			TTL      string   `yaml:"ttl" format:"duration" desc:"Interval between scans, e.g. 86400s"`
+			Provider string   `yaml:"provider" enum:"govulncheck" desc:"Vulnerability scanner (default: govulncheck)"`
+			Modules  []string `yaml:"modules,omitempty" desc:"Local Go module directories to scan; the tile is shown once set"`
+		} `yaml:"vulns,omitempty"`
+		System struct {
+			TTL             string   `yaml:"ttl" format:"duration" desc:"Interval between readings, e.g. 15s"`
+			Provider        string   `yaml:"provider" enum:"local" desc:"Resource source: /proc on Linux, sysctl and ps on macOS (default: local)"`
+			Disks           []string `yaml:"disks,omitempty" desc:"Paths whose filesystems to show; the tile is shown once set (default: the home directory)"`
+			Top             int      `yaml:"top,omitempty" desc:"Busiest processes to list; the tile is shown once set (default: 0, none)"`
+			WarnPercent     int      `yaml:"warn_percent,omitempty" desc:"Use from which CPU, load, memory and disks turn yellow (default: 75)"`
+			CriticalPercent int      `yaml:"critical_percent,omitempty" desc:"Use from which they turn red (default: 90)"`
+		} `yaml:"system,omitempty"`
+		Network struct {
+			TTL          string   `yaml:"ttl" format:"duration" desc:"Interval between latency measurements, e.g. 60s"`
+			Provider     string   `yaml:"provider" enum:"tcp" desc:"Latency source: time to open a TCP connection, as ping needs root (default: tcp)"`
+			Anchors      []string `yaml:"anchors,omitempty" desc:"Hosts to measure, optionally with :port (default port 443); gateway is the home router; the tile is shown once set (default: gateway, 1.1.1.1)"`
+			WarnMS       int      `yaml:"warn_ms,omitempty" desc:"Latency in milliseconds from which an anchor turns yellow (default: 100)"`
+			CriticalMS   int      `yaml:"critical_ms,omitempty" desc:"Latency in milliseconds from which an anchor turns red (default: 300)"`
+			SpeedTest    string   `yaml:"speed_test,omitempty" format:"duration" desc:"Interval between download speed tests, at least 1m, e.g. 1h; the tile is shown once set (default: off)"`
+			SpeedTestURL string   `yaml:"speed_test_url,omitempty" desc:"Large file downloaded for the speed test (default: a 25 MB Cloudflare download)"`
+		} `yaml:"network,omitempty"`
+		Pomodoro struct {
+			Work           string `yaml:"work,omitempty" format:"duration" desc:"Length of a focus session, at least 1m, e.g. 25m; the tile is shown once set"`
+			ShortBreak     string `yaml:"short_break,omitempty" format:"duration" desc:"Break after each session (default: 5m)"`
+			LongBreak      string `yaml:"long_break,omitempty" format:"duration" desc:"Break after every long_break_every sessions (default: 15m)"`
+			LongBreakEvery int    `yaml:"long_break_every,omitempty" desc:"Sessions before each long break (default: 4)"`
+			Notify         bool   `yaml:"notify,omitempty" desc:"Desktop notification when a session or break ends"`
+			Log            bool   `yaml:"log,omitempty" desc:"Keep completed sessions in ~/.goday/pomodoro.json"`
+		} `yaml:"pomodoro,omitempty"`
+		Toggl struct {
+			TTL         string `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 60s"`
+			Provider    string `yaml:"provider" enum:"toggl,harvest" desc:"Time tracker (default: toggl)"`
+			APIToken    string `yaml:"api_token,omitempty" desc:"Toggl Track API token, or Harvest personal access token; the tile is shown once set (default: $TOGGL_API_TOKEN or $HARVEST_ACCESS_TOKEN)"`
+			AccountID   string `yaml:"account_id,omitempty" desc:"Harvest account ID (default: $HARVEST_ACCOUNT_ID)"`
+			WorkspaceID int    `yaml:"workspace_id,omitempty" desc:"Toggl workspace g starts timers in without a project (default: your default workspace)"`
+			Project     string `yaml:"project,omitempty" desc:"Project g starts timers on, by name or ID; Harvest needs one (default: none)"`
+			Task        string `yaml:"task,omitempty" desc:"Harvest task of the project, by name or ID (default: the project's only task)"`
+			Description string `yaml:"description,omitempty" desc:"Description of timers started with g"`
+			Target      string `yaml:"target,omitempty" format:"duration" desc:"Time to track a day on Harvest, e.g. 7h30m (default: a fifth of your Harvest weekly capacity)"`
+		} `yaml:"toggl,omitempty"`
+		Notes struct {
+			Path string `yaml:"path,omitempty" desc:"Markdown file n adds quick notes to and N opens in $EDITOR; the tile is shown once set or once the default file exists (default: ~/.goday/notes.md)"`
+		} `yaml:"notes,omitempty"`
+		Reminders struct {
+			TTL      string `yaml:"ttl" format:"duration" desc:"Interval between rereading the file, e.g. 600s"`
+			Provider string `yaml:"provider" enum:"file" desc:"Reminder source (default: file)"`
+			Path     string `yaml:"path,omitempty" desc:"YAML or CSV file of dated reminders; the tile is shown once set or once the default file exists (default: ~/.goday/reminders.yaml)"`
+			Days     int    `yaml:"days,omitempty" desc:"Days ahead reminders are shown (default: 30)"`
+		} `yaml:"reminders,omitempty"`
 		Jira struct {
-			TTL     string `yaml:"ttl"`
-			LogWork bool   `yaml:"log_work"`
+			TTL      string `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 45s"`
+			LogWork  bool   `yaml:"log_work" desc:"Show [w] work-log shortcuts"`
+			BaseURL  string `yaml:"base_url" desc:"Jira site, e.g. https://yourcompany.atlassian.net"`
+			Email    string `yaml:"email" desc:"Jira Cloud account email (omit for a Server/Data Center personal access token)"`
+			APIToken string `yaml:"api_token" desc:"Jira API token (default: $JIRA_API_TOKEN)"`
 		} `yaml:"jira"`
 		Traffic struct {
-			TTL         string      `yaml:"ttl"`
-			Origin      interface{} `yaml:"origin"`      // Can be string or LocationConfig
-			Destination interface{} `yaml:"destination"` // Can be string or LocationConfig
+			TTL         string      `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 300s"`
+			Provider    string      `yaml:"provider" enum:"osrm,googlemaps" desc:"Routing source (default: osrm)"`
+			APIKey      string      `yaml:"api_key" desc:"Google Maps API key for the googlemaps provider (default: GOOGLE_MAPS_API_KEY)"`
+			Origin      interface{} `yaml:"origin" oneof:"location" desc:"Address string or {latitude, longitude, name}"`
+			Destination interface{} `yaml:"destination" oneof:"location" desc:"Address string or {latitude, longitude, name}"`
 		} `yaml:"traffic"`
 		Calendar struct {
-			TTL             string `yaml:"ttl"`
-			CredentialsFile string `yaml:"credentials_file"`
-			TokenFile       string `yaml:"token_file"`
-			MaxEvents       int    `yaml:"max_events"`
-			DaysAhead       int    `yaml:"days_ahead"`
+			TTL             string   `yaml:"ttl" format:"duration" desc:"Refresh interval, e.g. 300s"`
+			Provider        string   `yaml:"provider" enum:"google,ics" desc:"Calendar source (default: google)"`
+			ICS             []string `yaml:"ics" desc:"ICS URLs (http, https, webcal) or file paths used by the ics provider"`
+			Calendars       []string `yaml:"calendars" desc:"Google calendar IDs to merge (default: [primary])"`
+			CredentialsFile string   `yaml:"credentials_file" desc:"Google OAuth2 credentials JSON"`
+			TokenFile       string   `yaml:"token_file" desc:"Where the OAuth2 token is stored"`
+			MaxEvents       int      `yaml:"max_events" desc:"Maximum events to fetch"`
+			DaysAhead       int      `yaml:"days_ahead" desc:"Days ahead to fetch events"`
 		} `yaml:"calendar"`
 	} `yaml:"widgets"`
+	Plugins  map[string]map[string]interface{} `yaml:"plugins,omitempty" desc:"Settings passed verbatim to plugins, keyed by plugin ID"`
+	Searches map[string]SavedSearchConfig      `yaml:"searches,omitempty" desc:"Named Jira/GitHub queries run on demand with the s key"`
+	Schedule struct {
+		QuietHours string            `yaml:"quiet_hours,omitempty" desc:"Daily quiet time as HH:MM-HH:MM, e.g. 19:00-08:00; may cross midnight"`
+		Weekends   bool              `yaml:"weekends,omitempty" desc:"Saturday and Sunday are quiet all day"`
+		Widgets    []string          `yaml:"widgets,omitempty" desc:"Widgets not polled during quiet time, e.g. mentions, teams (default: every widget)"`
+		Alerts     string            `yaml:"alerts,omitempty" enum:"silent,highlight,flash,bell,notify" desc:"Highest attention level of new items during quiet time (default: highlight)"`
+		LowPower   string            `yaml:"low_power,omitempty" enum:"off,on,battery" desc:"Halve every poll frequency and stop the once-a-second timers (flashing status bar, fetch spinner, pomodoro seconds): on, or only while on battery; b toggles it (default: off)"`
+		Priorities map[string]string `yaml:"priorities,omitempty" desc:"Startup fetch order by widget: high fetches first, lazy once the dashboard is interactive, e.g. {calendar: high, news: normal} (default: calendar, pagerduty and alerts high; news, stocks, crypto, fx and domains lazy)"`
+	} `yaml:"schedule,omitempty"`
+	Preview struct {
+		From   string `yaml:"from,omitempty" desc:"Time of day tomorrow's preview card appears, as HH:MM (default: 17:00; off hides it)"`
+		Buffer string `yaml:"buffer,omitempty" format:"duration" desc:"Slack added to the expected commute for the leave-by time (default: 10m)"`
+	} `yaml:"preview,omitempty"`
+	Attention struct {
+		Default string                `yaml:"default,omitempty" enum:"silent,highlight,flash,bell,notify" desc:"Level of new items no rule matches (default: silent)"`
+		Rules   []AttentionRuleConfig `yaml:"rules,omitempty" desc:"Attention levels for new items; the first matching rule wins"`
+	} `yaml:"attention,omitempty"`
+	Sync struct {
+		Dir     string `yaml:"dir,omitempty" desc:"Folder shared between your machines, e.g. a Syncthing folder or a git checkout, that goday sync merges state through"`
+		Machine string `yaml:"machine,omitempty" desc:"Name of this machine in the sync folder (default: the hostname)"`
+	} `yaml:"sync,omitempty"`
+	Team struct {
+		Config string `yaml:"config,omitempty" desc:"Shared base config your team maintains, laid under this file: an https URL, a git repository (ending in .git, or git@/ssh://) or a file path; cached for offline starts"`
+		File   string `yaml:"file,omitempty" desc:"Path of the base config inside the team git repository (default: goday.yaml)"`
+	} `yaml:"team,omitempty"`
+	Telemetry bool `yaml:"telemetry" desc:"Send anonymous counts of the widgets shown and dashboard keys pressed, at most once a day, to help prioritize features; see goday telemetry status (default: false)"`
+	Safety    struct {
+		DryRun bool `yaml:"dry_run,omitempty" desc:"Log write actions, such as PR approvals, merges and issue triage, to ~/.goday/dry_run.log instead of sending them"`
+	} `yaml:"safety,omitempty"`
+	Keybindings map[string][]string `yaml:"keybindings,omitempty" desc:"Keys for dashboard actions, replacing their defaults, e.g. down: [down, h]; press ? for the actions and their keys"`
+	Features    map[string]bool     `yaml:"features,omitempty" desc:"Turn experimental or risky features on or off by name: write_actions (default: false), whois (default: false)"`
+}
+
+// NewsFeed is an RSS or Atom feed shown in the news widget
+type NewsFeed struct {
+	URL   string   `yaml:"url" desc:"Feed URL"`
+	Label string   `yaml:"label,omitempty" desc:"Name shown next to each article (default: the feed's title)"`
+	Tags  []string `yaml:"tags,omitempty" desc:"News tags given to every article of the feed, for the t filter"`
+}
+
+// StatusPageConfig is a status page watched by the service status widget
+type StatusPageConfig struct {
+	Name string `yaml:"name,omitempty" desc:"Name shown in the tile (default: the page's host)"`
+	URL  string `yaml:"url" desc:"Status page address, e.g. https://status.example.com, or the feed URL for kind feed"`
+	Kind string `yaml:"kind,omitempty" enum:"statuspage,instatus,feed" desc:"statuspage for Statuspage.io pages, instatus, or feed for an RSS/Atom incident feed (default: statuspage)"`
+}
+
+// UptimeCheck is an endpoint checked by the uptime widget
+type UptimeCheck struct {
+	Name    string `yaml:"name,omitempty" desc:"Name shown in the tile (default: the URL without its scheme)"`
+	URL     string `yaml:"url" desc:"Address to request with GET, e.g. https://api.example.com/healthz"`
+	Status  int    `yaml:"status,omitempty" desc:"Expected HTTP status; a 3xx status is checked without following the redirect (default: any 2xx or 3xx)"`
+	Keyword string `yaml:"keyword,omitempty" desc:"Text the response body must contain, case-sensitive"`
+	Timeout string `yaml:"timeout,omitempty" format:"duration" desc:"How long to wait before the endpoint counts as down (default: 10s)"`
+}
+
+// SavedSearchConfig is a named query across Jira and GitHub
+type SavedSearchConfig struct {
+	JQL    string `yaml:"jql" desc:"Jira JQL query"`
+	GitHub string `yaml:"github" desc:"GitHub issue/PR search query, e.g. is:open review-requested:@me"`
+}
+
+// AttentionRuleConfig sets how strongly new items in a widget ask for attention
+type AttentionRuleConfig struct {
+	Widget string `yaml:"widget,omitempty" desc:"Widget key, e.g. pagerduty (default: every widget)"`
+	Match  string `yaml:"match,omitempty" desc:"Only items whose title, subtitle or status contain this text, case-insensitive"`
+	Level  string `yaml:"level" enum:"silent,highlight,flash,bell,notify" desc:"silent, highlight the item, flash the status bar, ring the terminal bell, or send a desktop notification; each level includes the ones before it"`
+}
+
+// TileConfig renames a tile and sets what its title counts
+type TileConfig struct {
+	Title   string `yaml:"title,omitempty" desc:"Tile title, e.g. Reviews (default: the widget's own title)"`
+	Count   string `yaml:"count,omitempty" desc:"Number in the title: total (default), off, or an expression counting matching items, e.g. new, or status ~ \"🔴\" or subtitle ~ \"critical\""`
+	Columns int    `yaml:"columns,omitempty" desc:"Grid columns the tile spans, 1 to 3, e.g. 2 for a wide news tile (default: 1)"`
+	Rows    int    `yaml:"rows,omitempty" desc:"Grid rows the tile spans, e.g. 2 for a tall calendar (default: 1)"`
+	Enabled *bool  `yaml:"enabled,omitempty" desc:"false hides the tile and stops fetching its widget (default: true)"`
+}
+
+// AccessibilityConfig makes the dashboard easier to read and calmer to look at
+type AccessibilityConfig struct {
+	HighContrast  bool `yaml:"high_contrast,omitempty" desc:"High-contrast colors, and a text marker for each state otherwise shown only in color, such as the selected item (default: false)"`
+	ReducedMotion bool `yaml:"reduced_motion,omitempty" desc:"No spinners or flashing: tiles being fetched show … and alerts hold still in the status bar (default: false)"`
+}
+
+// TerminalConfig sets what the terminal can render; unset settings are guessed from $TERM
+type TerminalConfig struct {
+	Color      string `yaml:"color,omitempty" enum:"truecolor,256,16,none" desc:"Colors the terminal shows (default: from $COLORTERM and $TERM)"`
+	Emoji      *bool  `yaml:"emoji,omitempty" desc:"Whether emoji take two columns; false shows text symbols instead (default: true)"`
+	AltScreen  *bool  `yaml:"alt_screen,omitempty" desc:"Draw in the alternate screen, leaving the scrollback alone (default: true)"`
+	Mouse      *bool  `yaml:"mouse,omitempty" desc:"Click tiles and items and scroll with the mouse wheel; hold Shift to select text (default: false)"`
+	Background string `yaml:"background,omitempty" enum:"dark,light" desc:"The terminal's background, so colors stay legible on it (default: detected, or dark)"`
+}
+
+// SetWidgetTTL overrides the refresh interval of a configured widget
+func (c *Config) SetWidgetTTL(widget, ttl string) error {
+	if _, err := time.ParseDuration(ttl); err != nil {
+		return fmt.Errorf("invalid ttl %q for %s: %w", ttl, widget, err)
+	}
+
+	switch widget {
+	case "weather":
+		c.Widgets.Weather.TTL = ttl
+	case "news":
+		c.Widgets.News.TTL = ttl
+	case "slack":
+		c.Widgets.Slack.TTL = ttl
+	case "teams":
+		c.Widgets.Teams.TTL = ttl
+	case "discord":
+		c.Widgets.Discord.TTL = ttl
+	case "discussions":
+		c.Widgets.Discussions.TTL = ttl
+	case "mentions":
+		c.Widgets.Mentions.TTL = ttl
+	case "stocks":
+		c.Widgets.Stocks.TTL = ttl
+	case "crypto":
+		c.Widgets.Crypto.TTL = ttl
+	case "fx":
+		c.Widgets.FX.TTL = ttl
+	case "aqi":
+		c.Widgets.AQI.TTL = ttl
+	case "daylight":
+		c.Widgets.Daylight.TTL = ttl
+	case "confluence":
+		c.Widgets.Confluence.TTL = ttl
+	case "pagerduty":
+		c.Widgets.PagerDuty.TTL = ttl
+	case "alerts":
+		c.Widgets.Alerts.TTL = ttl
+	case "status":
+		c.Widgets.Status.TTL = ttl
+	case "uptime":
+		c.Widgets.Uptime.TTL = ttl
+	case "certs":
+		c.Widgets.Certs.TTL = ttl
+	case "domains":
+		c.Widgets.Domains.TTL = ttl
+	case "trends":
+		c.Widgets.Trends.TTL = ttl
+	case "releases":
+		c.Widgets.Releases.TTL = ttl
+	case "vulns":
+		c.Widgets.Vulns.TTL = ttl
+	case "system":
+		c.Widgets.System.TTL = ttl
+	case "network":
+		c.Widgets.Network.TTL = ttl
+	case "toggl":
+		c.Widgets.Toggl.TTL = ttl
+	case "reminders":
+		c.Widgets.Reminders.TTL = ttl
+	case "jira":
+		c.Widgets.Jira.TTL = ttl
+	case "traffic":
+		c.Widgets.Traffic.TTL = ttl
+	case "calendar":
+		c.Widgets.Calendar.TTL = ttl
+	default:
+		return fmt.Errorf("widget %q has no configurable ttl", widget)
+	}
+	return nil
+}
+
+// TileEnabled reports whether a widget's tile is shown, unless ui.tiles.<widget>.enabled
+// turns it off
+func (c *Config) TileEnabled(key string) bool {
+	if c == nil {
+		return true
+	}
+	enabled := c.UI.Tiles[key].Enabled
+	return enabled == nil || *enabled
+}
+
+// ConfiguredWidgets reports which optional widgets have been set up and should be
+// shown when ui.widgets is not set
+func (c *Config) ConfiguredWidgets() map[string]bool {
+	configured := map[string]bool{
+		"teams":     os.Getenv("MS_GRAPH_TOKEN") != "",
+		"mentions":  os.Getenv("SLACK_USER_TOKEN") != "" || os.Getenv("GMAIL_ACCESS_TOKEN") != "",
+		"aqi":       os.Getenv("WAQI_TOKEN") != "",
+		"pagerduty": os.Getenv("OPSGENIE_API_KEY") != "" || os.Getenv("VICTOROPS_API_KEY") != "",
+		"toggl":     os.Getenv("TOGGL_API_TOKEN") != "" || os.Getenv("HARVEST_ACCESS_TOKEN") != "",
+	}
+	if c == nil {
+		return configured
+	}
+	if c.Widgets.Teams.AccessToken != "" {
+		configured["teams"] = true
+	}
+	// Discord has nothing to show without channels to read
+	discordToken := c.Widgets.Discord.BotToken != "" || os.Getenv("DISCORD_BOT_TOKEN") != ""
+	configured["discord"] = discordToken && len(c.Widgets.Discord.Channels) > 0
+	configured["discussions"] = len(c.Widgets.Discussions.Repos) > 0
+	configured["stocks"] = len(c.Widgets.Stocks.Symbols) > 0
+	configured["crypto"] = len(c.Widgets.Crypto.Coins) > 0
+	configured["fx"] = len(c.Widgets.FX.Pairs) > 0
+	configured["alerts"] = c.Widgets.Alerts.URL != ""
+	configured["status"] = len(c.Widgets.Status.Services) > 0 || len(c.Widgets.Status.Pages) > 0
+	configured["uptime"] = len(c.Widgets.Uptime.Checks) > 0
+	configured["certs"] = len(c.Widgets.Certs.Hosts) > 0
+	configured["domains"] = len(c.Widgets.Domains.Domains) > 0
+	configured["trends"] = c.Widgets.Trends.Weeks > 0
+	configured["releases"] = len(c.Widgets.Releases.Repos) > 0
+	configured["vulns"] = len(c.Widgets.Vulns.Modules) > 0
+	configured["system"] = len(c.Widgets.System.Disks) > 0 || c.Widgets.System.Top > 0
+	configured["network"] = len(c.Widgets.Network.Anchors) > 0 || c.Widgets.Network.SpeedTest != ""
+	configured["pomodoro"] = c.Widgets.Pomodoro.Work != ""
+	if c.Widgets.Toggl.APIToken != "" {
+		configured["toggl"] = true
+	}
+	if _, err := os.Stat(RemindersPath(c)); err == nil || c.Widgets.Reminders.Path != "" {
+		configured["reminders"] = true
+	}
+	if _, err := os.Stat(NotesPath(c)); err == nil || c.Widgets.Notes.Path != "" {
+		configured["notes"] = true
+	}
+	configured["weather"] = c.Widgets.Weather.ShowForecast
+	if c.Widgets.PagerDuty.APIKey != "" || c.Widgets.PagerDuty.Provider != "" {
+		configured["pagerduty"] = true
+	}
+	if c.Widgets.AQI.Token != "" {
+		configured["aqi"] = true
+	}
+	// The OpenWeatherMap provider needs a real key, as it shares the weather one
+	if c.Widgets.AQI.Provider == "openweathermap" {
+		configured["aqi"] = c.Widgets.Weather.APIKey != "" && c.Widgets.Weather.APIKey != "YOUR_OWM_API_KEY"
+	}
+	// GitHub and Jira credentials are common, so only Slack, email or explicit sources show the tile
+	if len(c.Widgets.Mentions.Sources) > 0 || c.Widgets.Slack.Token != "" || c.Widgets.Mentions.GmailToken != "" {
+		configured["mentions"] = true
+	}
+	return configured
 }
 
 // GetConfigPath returns the path to the config file, checking multiple locations
@@ -80,16 +538,36 @@ func GetConfigPath() (string, error) {
 
 // LoadConfig loads configuration from the specified path
 func LoadConfig(path string) (*Config, error) {
-	f, err := os.Open(path)
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
+
+	// Validate against the same schema exported by `goday config schema`
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if err := ValidateConfigData(raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	// Lay the personal settings over the team's shared config, if there is one
+	if source, file := teamConfigSource(raw); source != "" {
+		data, err = applyTeamConfig(raw, source, file, filepath.Dir(path))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var cfg Config
-	dec := yaml.NewDecoder(f)
+	dec := yaml.NewDecoder(bytes.NewReader(data))
 	if err := dec.Decode(&cfg); err != nil {
 		return nil, err
 	}
+	if err := validateFeatures(cfg.Features); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
 	return &cfg, nil
 }
 
@@ -113,20 +591,88 @@ func LoadConfigFromDefaultPath() (*Config, error) {
 			return nil, fmt.Errorf("failed to create default config at %s: %w", configPath, err)
 		}
 
+		// Write the schema next to it so editors can validate and autocomplete
+		schemaPath := filepath.Join(configDir, "config.schema.json")
+		if err := WriteConfigSchema(schemaPath); err != nil {
+			fmt.Printf("Warning: could not write config schema: %v\n", err)
+		}
+
 		// Inform user that config was created
 		fmt.Printf("📁 Created config directory: %s\n", configDir)
 		fmt.Printf("📝 Created default config: %s\n", configPath)
 		fmt.Printf("💡 Edit the config file to customize your dashboard\n\n")
 	}
 
+	// Upgrade older config formats before loading
+	applied, err := MigrateConfigFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(applied) > 0 {
+		fmt.Printf("🔄 Migrated config to version %d (previous file backed up next to %s)\n", CurrentConfigVersion, configPath)
+		for _, description := range applied {
+			fmt.Printf("   • %s\n", description)
+		}
+	}
+
 	return LoadConfig(configPath)
 }
 
+// SaveNewsTags updates widgets.news.tags in the config file at path, keeping comments and layout
+func SaveNewsTags(path string, tags []string) error {
+	// Re-read the file under its lock, so edits saved meanwhile by another goday are kept
+	return withFileLock(path, func() error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			return fmt.Errorf("%s: expected a mapping at the top level", path)
+		}
+		news := ensureMapping(ensureMapping(doc.Content[0], "widgets"), "news")
+
+		list := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle}
+		for _, tag := range tags {
+			list.Content = append(list.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: tag})
+		}
+		if existing := mappingValue(news, "tags"); existing != nil {
+			// Keep block or flow style and any trailing comment of the current list
+			if existing.Kind == yaml.SequenceNode && len(tags) > 0 {
+				list.Style = existing.Style
+			}
+			list.LineComment = existing.LineComment
+			*existing = *list
+		} else {
+			news.Content = append(news.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "tags"}, list)
+		}
+
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(&doc); err != nil {
+			return err
+		}
+		if err := enc.Close(); err != nil {
+			return err
+		}
+		return writeFileAtomic(path, buf.Bytes(), 0644)
+	})
+}
+
 // CreateDefaultConfig creates a default configuration file
 func CreateDefaultConfig(path string) error {
-	defaultConfig := `# GoDay Dashboard Configuration
+	defaultConfig := `# yaml-language-server: $schema=config.schema.json
+# GoDay Dashboard Configuration
 # Config location: ~/.goday/config.yaml
 # Edit this file to customize your dashboard
+# Editor validation/autocompletion uses config.schema.json (regenerate with 'goday config schema')
+
+version: ` + strconv.Itoa(CurrentConfigVersion) + `  # Config format version (managed by GoDay)
 
 user:
   name: "Your Name"  # Change this to your name
@@ -136,27 +682,166 @@ ui:
   layout: at_a_glance
   min_width: 100
   tile_height: 7
+  # state_file: ~/.goday/state.json  # Snapshot for status bars (polybar, xbar); off disables
+  # battery: false  # Hide the laptop battery next to the clock
+  # last_session: off  # Don't save the dashboard on quit or show it on the next start
+  # tiles:  # Rename tiles and choose what their titles count
+  #   prs:
+  #     title: Reviews
+  #     count: new  # total (default), off, or an expression such as status ~ "🔴"
+  # terminal:  # Detected on first run; run 'goday doctor' to detect again
+  #   color: "256"  # truecolor, "256", "16" or none
+  #   emoji: true  # false shows text symbols where emoji would misalign
+  #   alt_screen: true
+  #   mouse: false  # true scrolls the focused tile with the wheel
 
 widgets:
   weather:
     ttl: 600s  # Refresh every 10 minutes
-    api_key: "YOUR_OWM_API_KEY"  # Get from openweathermap.org
+    # provider: wttr  # wttr (no key needed) or openweathermap/owm
+    api_key: "YOUR_OWM_API_KEY"  # Get from openweathermap.org; a real key selects OpenWeatherMap
   news:
     ttl: 600s
     tags: [golang, security, ai]  # Filter tech news by these tags
-    provider: hn  # hn (Hacker News) or devto (Dev.to)
+    provider: aggregate  # aggregate (Hackernoon + Dev.to + feeds), hn, devto, hackernoon, mastodon, rss, stackoverflow, producthunt, arxiv or security
+    # sources: [hackernoon, devto, rss, producthunt]  # What aggregate combines
+    # producthunt:
+    #   token: ""        # Product Hunt developer token; or set PRODUCTHUNT_TOKEN
+    # arxiv:
+    #   categories: [cs.LG, cs.CR]  # New papers in these arXiv categories
+    # security:
+    #   keywords: [kubernetes, openssl]  # CISA KEV and NVD advisories mentioning these
+    # feeds:             # Your own RSS/Atom feeds
+    #   - url: https://go.dev/blog/feed.atom
+    #     label: Go Blog
+    # language:
+    #   keep: [en]         # Drop articles detected in other languages
+    #   translate: "trans -b :en"  # Or a LibreTranslate URL; translates foreign RSS titles
+    #     tags: [golang]
+  prs:
+    hide_drafts: false
+    hide_wip: false   # Hide PRs titled WIP or labeled wip/do-not-review
+    # bots: group     # show, hide, or group dependabot/renovate PRs
+    # merge_method: squash  # How d (dependency updates) merges green PRs: merge, squash or rebase
+  # issues:           # Issue triage (i) for unlabeled issues in your repos
+  #   labels: [bug, enhancement, question]  # On the 1-9 keys
   slack:
     ttl: 20s
+    # token: ""         # Slack user token with search:read; or set SLACK_USER_TOKEN to show the Mentions tile
+  teams:
+    ttl: 120s
+    # access_token: ""  # Microsoft Graph token; or set MS_GRAPH_TOKEN to show the Teams tile
+    # channels: []      # teamID/channelID pairs to scan for @mentions
+  discord:
+    ttl: 60s
+    # bot_token: ""     # Discord bot token; or set DISCORD_BOT_TOKEN
+    # channels: []      # Channel IDs to show; the tile appears once these are set
+    # user_id: ""       # Your user ID, to highlight mentions of you
+  discussions:
+    ttl: 600s
+    # repos: []         # owner/name repos whose unanswered Q&A discussions to list; the tile appears once set
+  mentions:
+    ttl: 120s
+    # sources: [github, jira, slack, email]  # What the Mentions tile merges; the tile appears once set
+    # gmail_token: ""   # Gmail API token with gmail.readonly; or set GMAIL_ACCESS_TOKEN
+  stocks:
+    ttl: 60s            # During US market hours; closed markets are not polled
+    # api_key: ""       # Free key from finnhub.io; or set FINNHUB_API_KEY
+    # symbols: [AAPL, MSFT]  # The tile appears once these are set
+  crypto:
+    ttl: 120s           # CoinGecko's free API allows about 30 calls a minute
+    # coins: [bitcoin, ethereum]  # CoinGecko ids; the tile appears once these are set
+    # currency: usd
+  fx:
+    ttl: 3600s          # Reference rates are published once per business day
+    # pairs: [USD/INR, EUR/USD]  # The tile appears once these are set
+  aqi:
+    ttl: 1800s          # Stations report hourly
+    # token: ""         # Free token from aqicn.org/data-platform/token; or set WAQI_TOKEN to show the tile
+    # station: bangalore  # Defaults to the city of user.location
+    # provider: openweathermap  # Use the weather api_key instead of a WAQI token
+  daylight:
+    ttl: 3600s          # Sunrise and sunset next to the weather in the header
+    # golden_hour_reminder: 30m  # Point out the evening golden hour this long ahead
   confluence:
     ttl: 300s
+  pagerduty:
+    ttl: 60s
+    # provider: opsgenie  # Who is on call and open alerts; or victorops for Splunk On-Call
+    # api_key: ""       # Opsgenie API key; or set OPSGENIE_API_KEY
+    # region: us        # eu for accounts on app.eu.opsgenie.com
+    # api_id: ""        # Splunk On-Call only, with api_key; or set VICTOROPS_API_ID and VICTOROPS_API_KEY
+    # organization: ""  # Splunk On-Call org slug, for links to incident timelines
+    # schedules: []     # Schedule (or team) names to show; defaults to all of them
+  alerts:
+    ttl: 60s            # Firing Prometheus alerts, grouped by alertname
+    # url: https://alertmanager.example.com  # The tile appears once this is set
+    # token: ""         # When behind an authenticating proxy; or set ALERTMANAGER_TOKEN
+    # filters: ['team="payments"']  # Only alerts matching these label matchers
+  status:
+    ttl: 120s           # Degraded components and incidents on your dependencies' status pages
+    # services: [github, slack, npm, aws]  # The tile appears once these or pages are set
+    # pages:
+    #   - url: https://status.example.com  # Statuspage.io; kind: instatus or feed for others
+  uptime:
+    ttl: 60s            # Endpoints checked for status and latency, like a tiny uptime monitor
+    # history: 10       # Checks kept per endpoint
+    # checks:           # The tile appears once these are set
+    #   - name: API
+    #     url: https://api.example.com/healthz
+    #     keyword: ok   # Text the body must contain; status: 200 expects an exact status
+  certs:
+    ttl: 3600s          # TLS certificates checked for expiry
+    # hosts: [example.com, mail.example.com:993]  # The tile appears once these are set
+    # warn_days: 30     # Yellow from 30 days before expiry, red from critical_days (7)
+  domains:
+    ttl: 86400s         # Domain renewal dates, looked up once a day
+    # domains: [example.com, example.dev]  # The tile appears once these are set
+  trends:
+    ttl: 3600s          # PRs awaiting your review and issues assigned to you, charted
+    # weeks: 4          # The tile appears once this is set
+  releases:
+    ttl: 3600s          # New releases of the repositories you depend on
+    # repos: [charmbracelet/bubbletea, golang/go]  # The tile appears once these are set
+  vulns:
+    ttl: 86400s         # Known vulnerabilities in your Go modules, scanned daily with govulncheck
+    # modules: [~/src/myapp]  # The tile appears once these are set
+  system:
+    ttl: 15s            # CPU, load, memory and disk use of this machine; no network needed
+    # disks: [~/, /var]  # The tile appears once disks or top is set
+    # top: 3             # Busiest processes to list
+  network:
+    ttl: 60s            # Latency to your router and the internet
+    # anchors: [gateway, 1.1.1.1, vpn.example.com]  # The tile appears once anchors or speed_test is set
+    # speed_test: 1h    # Download speed test every hour; each one downloads up to 25 MB
+  toggl:
+    ttl: 60s            # Running Toggl Track timer and today's total; g starts a timer, G stops it
+    # api_token: ""     # Or set TOGGL_API_TOKEN; the tile appears once either is set
+    # project: Client work  # Project g starts timers on
+    # provider: harvest # Harvest instead; set account_id and task too
+  # pomodoro:
+  #   work: 25m         # Focus timer; f starts and pauses, F skips. The tile appears once this is set
+  #   short_break: 5m
+  #   long_break: 15m   # After every long_break_every (4) sessions
+  #   notify: true      # Desktop notification when a session or break ends
+  #   log: true         # Keep completed sessions in ~/.goday/pomodoro.json
+  # notes:
+  #   path: ~/.goday/notes.md  # n jots a note down, N opens the file in $EDITOR
+  # reminders:
+  #   path: ~/.goday/reminders.yaml  # Birthdays, renewals and other dates; the tile appears once this exists
+  #   days: 30          # How far ahead to show them
   jira:
     ttl: 45s
     log_work: true
   traffic:
     ttl: 300s  # Refresh every 5 minutes
+    # provider: googlemaps  # Live traffic from Google Maps; needs api_key or GOOGLE_MAPS_API_KEY
+    # api_key: "YOUR_GOOGLE_MAPS_API_KEY"
     # Option 1: Use addresses (geocoded automatically)
-    origin: "Electronic City Phase 1, Bengaluru, Karnataka, India"
-    destination: "Whitefield, Bengaluru, Karnataka, India"
+    origin:
+      address: "Electronic City Phase 1, Bengaluru, Karnataka, India"
+    destination:
+      address: "Whitefield, Bengaluru, Karnataka, India"
     
     # Option 2: Use precise coordinates (uncomment to use)
     # origin:
@@ -173,6 +858,11 @@ widgets:
     days_ahead: 7   # Days ahead to fetch events
     # credentials_file: ~/.goday/google_calendar_credentials.json  # Will be set automatically
     # token_file: ~/.goday/google_calendar_token.json             # Will be set automatically
+    # calendars: [primary, team@group.calendar.google.com]  # Google calendars to merge
+    # provider: ics  # Use .ics feeds/files instead of Google Calendar
+    # ics:
+    #   - https://calendar.example.com/team.ics
+    #   - ~/calendars/personal.ics
 
 # Calendar Setup:
 # 1. Go to https://console.cloud.google.com/
@@ -181,11 +871,85 @@ widgets:
 # 4. Download JSON and save as ~/.goday/google_calendar_credentials.json
 # 5. Restart GoDay and follow OAuth flow
 
+# Saved searches, run on demand with the s key:
+# searches:
+#   urgent:
+#     jql: "assignee = currentUser() AND priority in (Highest, High) AND resolution = Unresolved"
+#     github: "is:open is:pr review-requested:@me label:urgent"
+
+# Quiet time: paused widgets are not polled and alerts are held back to
+# highlight. Press o to override it when working late.
+# schedule:
+#   quiet_hours: "19:00-08:00"
+#   weekends: true
+#   widgets: [mentions, teams, discord, prs]
+#   alerts: highlight
+#   low_power: battery  # Halve polling on battery; on, off, or press b
+#   priorities:         # Startup fetch order: high first, lazy once interactive
+#     prs: high
+#     news: normal
+
+# How new items ask for attention: silent, highlight, flash (status bar),
+# bell (terminal bell) or notify (desktop notification). First match wins.
+# attention:
+#   default: silent
+#   rules:
+#     - widget: pagerduty
+#       match: sev1
+#       level: notify
+#     - widget: mentions
+#       level: bell
+#     - widget: news
+#       level: silent
+
+# Keep the audit trail and commute history the same on every machine: run
+# goday sync on each, e.g. from cron. A git checkout is pulled and pushed.
+# sync:
+#   dir: "~/Sync/goday"
+
+# Team dashboard: start from a config your team maintains; this file is laid
+# over it, and your ui.widgets are added after the team's tiles.
+# team:
+#   config: "git@github.com:acme/goday-team.git"  # Or an https URL or a file
+#   file: goday.yaml
+
+# Anonymous usage counts (widgets shown, dashboard keys pressed) to help
+# prioritize features. Off unless you turn it on; goday telemetry status
+# shows exactly what would be sent.
+telemetry: false
+
+# Dry run: write actions (PR approvals and merges, issue triage) are logged
+# to ~/.goday/dry_run.log instead of being sent. Also --dry-run.
+# safety:
+#   dry_run: true
+
+# Feature flags turn experimental or risky features on or off:
+# features:
+#   write_actions: true  # Merge, triage and start timers from d, i and g/G
+#   whois: true          # Look up domains without RDAP through WHOIS
+
+# From the evening on, a card previews tomorrow's first meeting, the commute
+# expected at that hour from the traffic history, and when to leave.
+# preview:
+#   from: "17:00"  # off hides it
+#   buffer: 10m
+
+# Plugin settings by plugin ID, passed to the plugin as-is. Use this for
+# plugins that have no section under widgets, e.g.:
+# plugins:
+#   local-git-commits:
+#     repositories: [~/code/goday, ~/code/api]
+#   github-prs:
+#     github_user: your-github-login
+#     involvement: involves  # author, involves, review-requested, assignee or mentions
+#     orgs: [your-org]
+#     max_results: 50
+
 # For more configuration examples, see:
 # - ADDRESS_CONFIGURATION_GUIDE.md (address formats)
 # - COORDINATE_EXAMPLES.md (coordinate examples)
 # - CONFIG_GUIDE.md (configuration guide)
 `
 
-	return os.WriteFile(path, []byte(defaultConfig), 0644)
+	return writeFileAtomic(path, []byte(defaultConfig), 0644)
 }
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentConfigVersion is the config format version written by this build of GoDay.
+// Files without a version key are treated as version 1.
+const CurrentConfigVersion = 3
+
+// configMigration upgrades a config document from version-1 to version
+type configMigration struct {
+	version     int
+	description string
+	apply       func(root *yaml.Node) error
+}
+
+// configMigrations must stay sorted by version; append new steps at the end
+var configMigrations = []configMigration{
+	{
+		version:     2,
+		description: "traffic origin/destination strings moved to {address: ...} objects",
+		apply:       migrateTrafficLocations,
+	},
+	{
+		version:     3,
+		description: "news.provider is now honoured; reset to aggregate to keep the existing feed",
+		apply:       migrateNewsProvider,
+	},
+}
+
+// MigrateConfigFile upgrades the config at path to CurrentConfigVersion in place.
+// The original file is kept as <path>.v<old version>.bak. It returns the descriptions
+// of the migrations that were applied, or nil if the file was already current.
+func MigrateConfigFile(path string) ([]string, error) {
+	// Another goday starting at the same time must not migrate the file twice
+	var applied []string
+	err := withFileLock(path, func() error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		migrated, fromVersion, migrations, err := migrateConfigData(data)
+		if err != nil {
+			return fmt.Errorf("failed to migrate %s: %w", path, err)
+		}
+		if len(migrations) == 0 {
+			return nil
+		}
+
+		// The backup holds the same tokens, so it is as private as the config
+		perm := os.FileMode(0644)
+		if info, err := os.Stat(path); err == nil {
+			perm = info.Mode().Perm()
+		}
+		backupPath := fmt.Sprintf("%s.v%d.bak", path, fromVersion)
+		if err := writeFileAtomic(backupPath, data, perm); err != nil {
+			return fmt.Errorf("failed to back up config to %s: %w", backupPath, err)
+		}
+		if err := writeFileAtomic(path, migrated, 0644); err != nil {
+			return fmt.Errorf("failed to write migrated config: %w", err)
+		}
+		applied = migrations
+		return nil
+	})
+	return applied, err
+}
+
+// migrateConfigData applies every pending migration to raw config YAML, keeping comments intact
+func migrateConfigData(data []byte) (migrated []byte, fromVersion int, applied []string, err error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, 0, nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		// Empty or non-mapping documents are left for LoadConfig to report
+		return data, 1, nil, nil
+	}
+	root := doc.Content[0]
+
+	fromVersion = 1
+	if versionNode := mappingValue(root, "version"); versionNode != nil {
+		fromVersion, err = strconv.Atoi(versionNode.Value)
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("invalid config version %q", versionNode.Value)
+		}
+	}
+	if fromVersion > CurrentConfigVersion {
+		return nil, 0, nil, fmt.Errorf("config version %d is newer than this GoDay supports (%d); please upgrade GoDay", fromVersion, CurrentConfigVersion)
+	}
+	if fromVersion == CurrentConfigVersion {
+		return data, fromVersion, nil, nil
+	}
+
+	for _, migration := range configMigrations {
+		if migration.version <= fromVersion {
+			continue
+		}
+		if err := migration.apply(root); err != nil {
+			return nil, 0, nil, fmt.Errorf("migration to version %d: %w", migration.version, err)
+		}
+		applied = append(applied, fmt.Sprintf("v%d: %s", migration.version, migration.description))
+	}
+	setConfigVersion(root, CurrentConfigVersion)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, 0, nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, 0, nil, err
+	}
+	return buf.Bytes(), fromVersion, applied, nil
+}
+
+// migrateTrafficLocations rewrites `origin: "addr"` as `origin: {address: "addr"}`
+func migrateTrafficLocations(root *yaml.Node) error {
+	traffic := mappingValue(mappingValue(root, "widgets"), "traffic")
+	if traffic == nil {
+		return nil
+	}
+
+	for _, key := range []string{"origin", "destination"} {
+		location := mappingValue(traffic, key)
+		if location == nil || location.Kind != yaml.ScalarNode || location.Tag != "!!str" {
+			continue
+		}
+
+		address := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: location.Value, Style: location.Style, LineComment: location.LineComment}
+		*location = yaml.Node{
+			Kind: yaml.MappingNode,
+			Tag:  "!!map",
+			Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: "address"},
+				address,
+			},
+		}
+	}
+	return nil
+}
+
+// migrateNewsProvider resets widgets.news.provider to aggregate. Before version 3 the
+// setting was ignored and the aggregate feed was always shown.
+func migrateNewsProvider(root *yaml.Node) error {
+	provider := mappingValue(mappingValue(mappingValue(root, "widgets"), "news"), "provider")
+	if provider == nil || provider.Kind != yaml.ScalarNode {
+		return nil
+	}
+	provider.Value = "aggregate"
+	provider.Tag = "!!str"
+	provider.LineComment = "# aggregate (Hackernoon + Dev.to), hn, devto or hackernoon"
+	return nil
+}
+
+// setConfigVersion sets the top-level version key, adding it first in the file if missing
+func setConfigVersion(root *yaml.Node, version int) {
+	value := strconv.Itoa(version)
+	if node := mappingValue(root, "version"); node != nil {
+		node.Value = value
+		return
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"}
+	val := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value, LineComment: "# Config format version (managed by GoDay)"}
+	// Keep the file's leading comment block above the new key
+	if len(root.Content) > 0 {
+		key.HeadComment = root.Content[0].HeadComment
+		root.Content[0].HeadComment = ""
+	}
+	root.Content = append([]*yaml.Node{key, val}, root.Content...)
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// ensureMapping returns the mapping value for key in node, appending an empty mapping if it is missing
+func ensureMapping(node *yaml.Node, key string) *yaml.Node {
+	if value := mappingValue(node, key); value != nil {
+		if value.Kind != yaml.MappingNode {
+			*value = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", LineComment: value.LineComment}
+		}
+		return value
+	}
+	value := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+	return value
+}
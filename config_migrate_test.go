@@ -0,0 +1,121 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const legacyConfig = `# My dashboard
+user:
+  name: "Test User"
+
+widgets:
+  news:
+    provider: hn  # hn or devto
+  traffic:
+    ttl: 300s
+    origin: "Electronic City, Bengaluru"  # home
+    destination:
+      latitude: 12.97
+      longitude: 77.75
+      name: "Office"
+`
+
+func TestMigrateConfigData(t *testing.T) {
+	migrated, fromVersion, applied, err := migrateConfigData([]byte(legacyConfig))
+	if err != nil {
+		t.Fatalf("migrateConfigData failed: %v", err)
+	}
+
+	if fromVersion != 1 {
+		t.Errorf("Expected unversioned config to be version 1, got %d", fromVersion)
+	}
+	if len(applied) != len(configMigrations) {
+		t.Errorf("Expected %d migrations applied, got %d", len(configMigrations), len(applied))
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(migrated, &cfg); err != nil {
+		t.Fatalf("Migrated config does not parse: %v", err)
+	}
+	if cfg.Version != CurrentConfigVersion {
+		t.Errorf("Expected version %d, got %d", CurrentConfigVersion, cfg.Version)
+	}
+
+	origin, ok := cfg.Widgets.Traffic.Origin.(map[string]interface{})
+	if !ok || origin["address"] != "Electronic City, Bengaluru" {
+		t.Errorf("Expected origin to become an address object, got %#v", cfg.Widgets.Traffic.Origin)
+	}
+	destination, ok := cfg.Widgets.Traffic.Destination.(map[string]interface{})
+	if !ok || destination["name"] != "Office" {
+		t.Errorf("Expected coordinate destination to be untouched, got %#v", cfg.Widgets.Traffic.Destination)
+	}
+
+	if cfg.Widgets.News.Provider != "aggregate" {
+		t.Errorf("Expected news provider to be reset to 'aggregate', got '%s'", cfg.Widgets.News.Provider)
+	}
+
+	// Comments survive the rewrite
+	text := string(migrated)
+	if !strings.Contains(text, "# My dashboard") || !strings.Contains(text, "# home") {
+		t.Errorf("Expected comments to be preserved, got:\n%s", text)
+	}
+
+	// Migrating again is a no-op
+	_, _, applied, err = migrateConfigData(migrated)
+	if err != nil || len(applied) != 0 {
+		t.Errorf("Expected current config to need no migrations, got %v (err %v)", applied, err)
+	}
+}
+
+func TestMigrateConfigDataRejectsNewerVersion(t *testing.T) {
+	if _, _, _, err := migrateConfigData([]byte("version: 99\n")); err == nil {
+		t.Error("Expected a config from a newer GoDay to be rejected")
+	}
+}
+
+func TestMigrateConfigFileKeepsBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(legacyConfig), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	applied, err := MigrateConfigFile(path)
+	if err != nil {
+		t.Fatalf("MigrateConfigFile failed: %v", err)
+	}
+	if len(applied) == 0 {
+		t.Fatal("Expected migrations to be applied")
+	}
+
+	backup, err := os.ReadFile(path + ".v1.bak")
+	if err != nil {
+		t.Fatalf("Expected backup file: %v", err)
+	}
+	if string(backup) != legacyConfig {
+		t.Error("Expected backup to contain the original config")
+	}
+
+	if _, err := LoadConfig(path); err != nil {
+		t.Errorf("Migrated config failed to load: %v", err)
+	}
+}
+
+func TestDefaultConfigIsCurrentVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := CreateDefaultConfig(path); err != nil {
+		t.Fatalf("CreateDefaultConfig failed: %v", err)
+	}
+
+	applied, err := MigrateConfigFile(path)
+	if err != nil {
+		t.Fatalf("MigrateConfigFile failed: %v", err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("Expected default config to need no migrations, got %v", applied)
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// durationPattern matches Go duration strings such as "600s", "5m" or "1h30m"
+const durationPattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+// JSONSchema is the subset of JSON Schema (draft-07) used to describe config.yaml
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	OneOf                []*JSONSchema          `json:"oneOf,omitempty"`
+}
+
+// schemaVariants maps `oneof` struct tags to the object form a field may take besides a string
+var schemaVariants = map[string]reflect.Type{
+	"location": reflect.TypeOf(LocationConfig{}),
+}
+
+// GenerateConfigSchema builds the JSON Schema for config.yaml from the Config struct
+func GenerateConfigSchema() *JSONSchema {
+	schema := schemaForType(reflect.TypeOf(Config{}))
+	schema.Schema = "http://json-schema.org/draft-07/schema#"
+	schema.Title = "GoDay configuration"
+	schema.Description = "Configuration for the GoDay terminal dashboard (~/.goday/config.yaml)"
+	return schema
+}
+
+// ConfigSchemaJSON returns the config schema as indented JSON
+func ConfigSchemaJSON() ([]byte, error) {
+	return json.MarshalIndent(GenerateConfigSchema(), "", "  ")
+}
+
+// WriteConfigSchema writes the config schema to path
+func WriteConfigSchema(path string) error {
+	data, err := ConfigSchemaJSON()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, append(data, '\n'), 0644)
+}
+
+// schemaForType derives a schema from a Go type, using yaml tags for property names
+func schemaForType(t reflect.Type) *JSONSchema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaForType(t.Elem())
+	case reflect.Struct:
+		schema := &JSONSchema{
+			Type:                 "object",
+			Properties:           make(map[string]*JSONSchema),
+			AdditionalProperties: false,
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := yamlFieldName(field)
+			if name == "" {
+				continue
+			}
+			schema.Properties[name] = schemaForField(field)
+		}
+		return schema
+	case reflect.Slice, reflect.Array:
+		return &JSONSchema{Type: "array", Items: schemaForType(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: schemaForType(t.Elem())}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	default:
+		// interface{} and anything else accepts any value
+		return &JSONSchema{}
+	}
+}
+
+// schemaForField applies the desc, enum, format and oneof struct tags to a field schema
+func schemaForField(field reflect.StructField) *JSONSchema {
+	var schema *JSONSchema
+	if variant, ok := schemaVariants[field.Tag.Get("oneof")]; ok {
+		schema = &JSONSchema{OneOf: []*JSONSchema{{Type: "string"}, schemaForType(variant)}}
+	} else {
+		schema = schemaForType(field.Type)
+	}
+
+	schema.Description = field.Tag.Get("desc")
+	if enum := field.Tag.Get("enum"); enum != "" {
+		schema.Enum = strings.Split(enum, ",")
+	}
+	if field.Tag.Get("format") == "duration" {
+		schema.Pattern = durationPattern
+	}
+	return schema
+}
+
+// yamlFieldName returns the yaml key for a struct field, or "" if it is not serialized
+func yamlFieldName(field reflect.StructField) string {
+	if field.PkgPath != "" {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+// Validate checks a decoded YAML value against the schema and returns every violation found
+func (s *JSONSchema) Validate(value interface{}) []string {
+	var problems []string
+	s.validate(value, "", &problems)
+	return problems
+}
+
+func (s *JSONSchema) validate(value interface{}, path string, problems *[]string) {
+	// Empty YAML values decode to nil and leave the field at its default
+	if value == nil {
+		return
+	}
+
+	report := func(format string, args ...interface{}) {
+		where := path
+		if where == "" {
+			where = "(root)"
+		}
+		*problems = append(*problems, fmt.Sprintf("%s: %s", where, fmt.Sprintf(format, args...)))
+	}
+
+	if len(s.OneOf) > 0 {
+		for _, option := range s.OneOf {
+			var optionProblems []string
+			option.validate(value, path, &optionProblems)
+			if len(optionProblems) == 0 {
+				return
+			}
+		}
+		report("does not match any allowed form")
+		return
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			report("expected an object, got %s", describeValue(value))
+			return
+		}
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := joinSchemaPath(path, key)
+			if prop, ok := s.Properties[key]; ok {
+				prop.validate(obj[key], child, problems)
+				continue
+			}
+			switch extra := s.AdditionalProperties.(type) {
+			case *JSONSchema:
+				extra.validate(obj[key], child, problems)
+			case bool:
+				if !extra {
+					*problems = append(*problems, fmt.Sprintf("%s: unknown key", child))
+				}
+			}
+		}
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			report("expected a list, got %s", describeValue(value))
+			return
+		}
+		if s.Items != nil {
+			for i, item := range arr {
+				s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i), problems)
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			report("expected a string, got %s", describeValue(value))
+			return
+		}
+		if len(s.Enum) > 0 && !containsString(s.Enum, str) {
+			report("%q is not one of %s", str, strings.Join(s.Enum, ", "))
+		}
+		if s.Pattern != "" && !regexp.MustCompile(s.Pattern).MatchString(str) {
+			if s.Pattern == durationPattern {
+				report("%q is not a duration (e.g. 300s, 5m)", str)
+			} else {
+				report("%q does not match %s", str, s.Pattern)
+			}
+		}
+	case "integer":
+		if _, ok := value.(int); !ok {
+			report("expected an integer, got %s", describeValue(value))
+		}
+	case "number":
+		switch value.(type) {
+		case int, float64:
+		default:
+			report("expected a number, got %s", describeValue(value))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			report("expected true or false, got %s", describeValue(value))
+		}
+	}
+}
+
+// ValidateConfigData validates raw config.yaml content that has been decoded into generic values
+func ValidateConfigData(raw interface{}) error {
+	problems := GenerateConfigSchema().Validate(raw)
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid config:\n  - %s", strings.Join(problems, "\n  - "))
+}
+
+func joinSchemaPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func describeValue(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "a list"
+	case string:
+		return fmt.Sprintf("string %q", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
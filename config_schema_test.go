@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestConfigSchemaMatchesStruct(t *testing.T) {
+	schema := GenerateConfigSchema()
+
+	widgets := schema.Properties["widgets"]
+	if widgets == nil {
+		t.Fatal("Expected schema to describe 'widgets'")
+	}
+
+	traffic := widgets.Properties["traffic"]
+	if traffic == nil || len(traffic.Properties["origin"].OneOf) != 2 {
+		t.Error("Expected traffic.origin to accept a string or a location object")
+	}
+
+	news := widgets.Properties["news"]
+	if news == nil || news.Properties["ttl"].Pattern != durationPattern {
+		t.Error("Expected news.ttl to be validated as a duration")
+	}
+
+	// The exported schema must be valid JSON
+	data, err := ConfigSchemaJSON()
+	if err != nil {
+		t.Fatalf("ConfigSchemaJSON failed: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Schema is not valid JSON: %v", err)
+	}
+}
+
+func TestDefaultConfigPassesValidation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := CreateDefaultConfig(path); err != nil {
+		t.Fatalf("CreateDefaultConfig failed: %v", err)
+	}
+
+	if _, err := LoadConfig(path); err != nil {
+		t.Errorf("Default config failed validation: %v", err)
+	}
+
+	// The sample config shipped with the repository must stay valid too
+	if _, err := LoadConfig("config.yaml"); err != nil {
+		t.Errorf("Sample config.yaml failed validation: %v", err)
+	}
+}
+
+func TestConfigSchemaValidation(t *testing.T) {
+	content := `
+user:
+  name: 42
+ui:
+  tile_height: tall
+widgets:
+  news:
+    ttl: often
+    provider: reddit
+  traffic:
+    origin:
+      latitude: 12.9
+      longitude: 77.6
+    destination: [1, 2]
+  unknown_widget:
+    ttl: 10s
+`
+	var raw interface{}
+	if err := yaml.Unmarshal([]byte(content), &raw); err != nil {
+		t.Fatalf("Failed to parse test YAML: %v", err)
+	}
+
+	problems := GenerateConfigSchema().Validate(raw)
+	expected := []string{
+		"user.name",
+		"ui.tile_height",
+		"widgets.news.ttl",
+		"widgets.news.provider",
+		"widgets.traffic.destination",
+		"widgets.unknown_widget",
+	}
+
+	joined := strings.Join(problems, "\n")
+	for _, path := range expected {
+		if !strings.Contains(joined, path+":") {
+			t.Errorf("Expected a validation problem for %s, got:\n%s", path, joined)
+		}
+	}
+	if strings.Contains(joined, "widgets.traffic.origin:") {
+		t.Errorf("Expected coordinate origin to be valid, got:\n%s", joined)
+	}
+	if len(problems) != len(expected) {
+		t.Errorf("Expected %d problems, got %d:\n%s", len(expected), len(problems), joined)
+	}
+}
+
+func TestLoadConfigRejectsInvalidConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("widgets:\n  weather:\n    ttl: never\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if _, err := LoadConfig(path); err == nil {
+		t.Error("Expected LoadConfig to reject an invalid ttl")
+	}
+}
+
+func TestConfigPluginsSection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+plugins:
+  local-git-commits:
+    repositories: [~/code/goday, ~/code/api]
+  my-plugin:
+    endpoint: https://example.com
+    retries: 3
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if cfg.Plugins["my-plugin"]["retries"] != 3 {
+		t.Errorf("Expected plugin settings to be kept verbatim, got %v", cfg.Plugins["my-plugin"])
+	}
+
+	plugin := NewLocalGitCommitsPlugin()
+	if err := plugin.Initialize(cfg.Plugins["local-git-commits"]); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if len(plugin.repositories) != 2 || plugin.repositories[1] != "~/code/api" {
+		t.Errorf("Expected repositories from plugins section, got %v", plugin.repositories)
+	}
+
+	merged := mergePluginConfig(map[string]interface{}{"max_events": 10, "days_ahead": 7}, map[string]interface{}{"days_ahead": 14})
+	if merged["max_events"] != 10 || merged["days_ahead"] != 14 {
+		t.Errorf("Expected plugins settings to override widget settings, got %v", merged)
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// cryptoSparklineWidth is how many points of the last day the sparkline shows
+const cryptoSparklineWidth = 12
+
+// sparkBlocks are the bar heights of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// CryptoQuote is the latest price of a coin and its price over the last day
+type CryptoQuote struct {
+	ID            string
+	Symbol        string
+	Name          string
+	Currency      string
+	Price         float64
+	ChangePercent float64
+	History       []float64
+	Updated       time.Time
+}
+
+// CryptoPlugin shows prices for configured coins from the CoinGecko API, which needs no key
+type CryptoPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	coins       []string
+	currency    string
+	apiURL      string
+	client      *http.Client
+	lastData    []CryptoQuote
+}
+
+// NewCryptoPlugin creates a new crypto plugin
+func NewCryptoPlugin() *CryptoPlugin {
+	return &CryptoPlugin{
+		id:          "crypto",
+		pluginType:  "finance",
+		name:        "Crypto",
+		version:     "1.0.0",
+		description: "Shows cryptocurrency prices and 24h changes from CoinGecko",
+		author:      "GoDay Team",
+		currency:    "usd",
+		apiURL:      "https://api.coingecko.com/api/v3",
+		client:      &http.Client{Timeout: 10 * time.Second},
+		lastData:    []CryptoQuote{},
+	}
+}
+
+// GetID returns the plugin ID
+func (cp *CryptoPlugin) GetID() string {
+	return cp.id
+}
+
+// GetType returns the plugin type
+func (cp *CryptoPlugin) GetType() string {
+	return cp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (cp *CryptoPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        cp.name,
+		Version:     cp.version,
+		Description: cp.description,
+		Author:      cp.author,
+		Type:        cp.pluginType,
+		Config: map[string]string{
+			"coins":    strings.Join(cp.coins, ","),
+			"currency": cp.currency,
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (cp *CryptoPlugin) Initialize(config map[string]interface{}) error {
+	cp.coins = nil
+	for _, coin := range configStringList(config["coins"]) {
+		cp.coins = append(cp.coins, strings.ToLower(strings.TrimSpace(coin)))
+	}
+	if currency, ok := config["currency"].(string); ok && currency != "" {
+		cp.currency = strings.ToLower(currency)
+	}
+	return nil
+}
+
+// Fetch returns a quote per coin, in configured order
+func (cp *CryptoPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if len(cp.coins) == 0 {
+		return cp.lastData, fmt.Errorf("no coins configured (widgets.crypto.coins)")
+	}
+
+	query := url.Values{}
+	query.Set("vs_currency", cp.currency)
+	query.Set("ids", strings.Join(cp.coins, ","))
+	query.Set("sparkline", "true")
+	query.Set("price_change_percentage", "24h")
+
+	var markets []struct {
+		ID            string  `json:"id"`
+		Symbol        string  `json:"symbol"`
+		Name          string  `json:"name"`
+		Price         float64 `json:"current_price"`
+		ChangePercent float64 `json:"price_change_percentage_24h"`
+		LastUpdated   string  `json:"last_updated"`
+		Sparkline     struct {
+			Price []float64 `json:"price"`
+		} `json:"sparkline_in_7d"`
+	}
+	if err := cp.get(ctx, "/coins/markets?"+query.Encode(), &markets); err != nil {
+		return cp.lastData, err
+	}
+
+	// CoinGecko orders by market cap and leaves out unknown ids
+	byID := make(map[string]int, len(markets))
+	for i, market := range markets {
+		byID[market.ID] = i
+	}
+	var quotes []CryptoQuote
+	for _, coin := range cp.coins {
+		i, ok := byID[coin]
+		if !ok {
+			return cp.lastData, fmt.Errorf("unknown coin %s (use CoinGecko ids such as bitcoin)", coin)
+		}
+		market := markets[i]
+		updated, _ := time.Parse(time.RFC3339, market.LastUpdated)
+
+		// The sparkline is hourly over 7 days; the tile shows the last day
+		history := market.Sparkline.Price
+		if len(history) > 24 {
+			history = history[len(history)-24:]
+		}
+
+		quotes = append(quotes, CryptoQuote{
+			ID:            market.ID,
+			Symbol:        strings.ToUpper(market.Symbol),
+			Name:          market.Name,
+			Currency:      strings.ToUpper(cp.currency),
+			Price:         market.Price,
+			ChangePercent: market.ChangePercent,
+			History:       history,
+			Updated:       updated,
+		})
+	}
+
+	cp.lastData = quotes
+	return quotes, nil
+}
+
+// get performs a CoinGecko API request and decodes the JSON response
+func (cp *CryptoPlugin) get(ctx context.Context, path string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", cp.apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := cp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("CoinGecko rate limit reached")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("CoinGecko API returned status %d", resp.StatusCode)
+	}
+	return json.Unmarshal(body, target)
+}
+
+// Cleanup performs cleanup
+func (cp *CryptoPlugin) Cleanup() error {
+	return nil
+}
+
+// sparkline draws values as block characters, resampled to width points, scaled
+// between their minimum and maximum
+func sparkline(values []float64, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+	if width == 1 {
+		values = values[len(values)-1:]
+	} else if len(values) > width {
+		// Keep the first and last values so the line ends at the current price
+		sampled := make([]float64, width)
+		for i := range sampled {
+			sampled[i] = values[i*(len(values)-1)/(width-1)]
+		}
+		values = sampled
+	}
+
+	low, high := values[0], values[0]
+	for _, v := range values {
+		low = math.Min(low, v)
+		high = math.Max(high, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		level := len(sparkBlocks) / 2
+		if high > low {
+			level = int(math.Round((v - low) / (high - low) * float64(len(sparkBlocks)-1)))
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCryptoPluginFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/coins/markets" || query.Get("vs_currency") != "eur" || query.Get("sparkline") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// Market cap order, not the configured order
+		fmt.Fprint(w, `[
+			{"id":"bitcoin","symbol":"btc","name":"Bitcoin","current_price":61234.5,"price_change_percentage_24h":2.345,
+			 "last_updated":"2026-10-16T09:00:00.000Z","sparkline_in_7d":{"price":[1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20,21,22,23,24,25,26,27,28,29,30]}},
+			{"id":"dogecoin","symbol":"doge","name":"Dogecoin","current_price":0.123456,"price_change_percentage_24h":-1.5,
+			 "last_updated":"2026-10-16T09:00:00.000Z","sparkline_in_7d":{"price":[]}}
+		]`)
+	}))
+	defer server.Close()
+
+	plugin := NewCryptoPlugin()
+	plugin.apiURL = server.URL
+	if err := plugin.Initialize(map[string]interface{}{"coins": []string{"Dogecoin", "bitcoin"}, "currency": "EUR"}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	quotes := data.([]CryptoQuote)
+	if len(quotes) != 2 || quotes[0].Symbol != "DOGE" || quotes[1].Price != 61234.5 || quotes[1].Currency != "EUR" {
+		t.Fatalf("Expected a quote per coin in configured order, got %+v", quotes)
+	}
+	if len(quotes[1].History) != 24 || quotes[1].History[0] != 7 {
+		t.Errorf("Expected the last day of the hourly sparkline, got %v", quotes[1].History)
+	}
+
+	wm := NewWidgetManager()
+	wm.UpdateCryptoWidget(quotes)
+	items := wm.Widgets["crypto"].Items
+	if items[0].Title != "DOGE  0.1235 EUR" || items[0].Subtitle != "-1.50% 24h" || items[0].Status != "🔴" {
+		t.Errorf("Expected small prices with significant digits, got %+v", items[0])
+	}
+	if items[1].Title != "BTC  61234.50 EUR" || items[1].Subtitle != "▁▂▂▃▃▄▅▅▆▆▇█ +2.35% 24h" || items[1].Status != "🟢" {
+		t.Errorf("Expected the price with a sparkline, got %+v", items[1])
+	}
+	if items[1].URL != "https://www.coingecko.com/en/coins/bitcoin" {
+		t.Errorf("Expected the CoinGecko page, got '%s'", items[1].URL)
+	}
+
+	plugin.Initialize(map[string]interface{}{"coins": []string{"bitcoin", "nope"}})
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected an unknown coin to fail")
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]float64{1, 5, 3}, 10); got != "▁█▅" {
+		t.Errorf("Expected values scaled between min and max, got '%s'", got)
+	}
+	if got := sparkline([]float64{2, 2}, 10); got != "▅▅" {
+		t.Errorf("Expected a flat line for equal values, got '%s'", got)
+	}
+	if got := sparkline([]float64{1, 2, 3, 4, 5}, 3); got != "▁▅█" {
+		t.Errorf("Expected the values resampled to the width, got '%s'", got)
+	}
+	if got := sparkline(nil, 10); got != "" {
+		t.Errorf("Expected no sparkline without values, got '%s'", got)
+	}
+}
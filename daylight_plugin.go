@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// goldenHourLength approximates the evening golden hour as the hour before sunset
+const goldenHourLength = time.Hour
+
+// Daylight is when the sun rises and sets at a location on one day
+type Daylight struct {
+	Sunrise time.Time
+	Sunset  time.Time
+}
+
+// GoldenHour returns when the evening golden hour starts
+func (d *Daylight) GoldenHour() time.Time {
+	return d.Sunset.Add(-goldenHourLength)
+}
+
+// SunriseSunsetPlugin fetches today's sunrise and sunset from sunrise-sunset.org, which
+// needs no API key
+type SunriseSunsetPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	city        string
+	apiURL      string
+	geocodeURL  string
+	client      *http.Client
+	lastData    *Daylight
+	latitude    float64
+	longitude   float64
+	located     string // city the coordinates were looked up for
+	now         func() time.Time
+}
+
+// NewSunriseSunsetPlugin creates a new sunrise-sunset.org daylight plugin
+func NewSunriseSunsetPlugin() *SunriseSunsetPlugin {
+	return &SunriseSunsetPlugin{
+		id:          "sunrise-sunset",
+		pluginType:  "daylight",
+		name:        "Sunrise Sunset",
+		version:     "1.0.0",
+		description: "Fetches today's sunrise and sunset from sunrise-sunset.org without an API key",
+		author:      "GoDay Team",
+		apiURL:      "https://api.sunrise-sunset.org",
+		geocodeURL:  "https://geocoding-api.open-meteo.com",
+		client:      &http.Client{Timeout: 10 * time.Second},
+		now:         time.Now,
+	}
+}
+
+// GetID returns the plugin ID
+func (sp *SunriseSunsetPlugin) GetID() string {
+	return sp.id
+}
+
+// GetType returns the plugin type
+func (sp *SunriseSunsetPlugin) GetType() string {
+	return sp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (sp *SunriseSunsetPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        sp.name,
+		Version:     sp.version,
+		Description: sp.description,
+		Author:      sp.author,
+		Type:        sp.pluginType,
+		Config: map[string]string{
+			"city": sp.city,
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (sp *SunriseSunsetPlugin) Initialize(config map[string]interface{}) error {
+	if city, ok := config["city"].(string); ok {
+		sp.city = city
+	}
+	return nil
+}
+
+// Fetch looks up the city's coordinates once, then retrieves today's sunrise and sunset
+func (sp *SunriseSunsetPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if sp.located != sp.city || sp.city == "" {
+		latitude, longitude, err := openMeteoGeocode(ctx, sp.client, sp.geocodeURL, sp.city)
+		if err != nil {
+			return sp.lastData, err
+		}
+		sp.latitude, sp.longitude = latitude, longitude
+		sp.located = sp.city
+	}
+
+	params := url.Values{}
+	params.Set("lat", fmt.Sprintf("%.4f", sp.latitude))
+	params.Set("lng", fmt.Sprintf("%.4f", sp.longitude))
+	params.Set("date", sp.now().Format("2006-01-02"))
+	params.Set("formatted", "0") // ISO 8601 times in UTC
+
+	var response struct {
+		Status  string          `json:"status"`
+		Results json.RawMessage `json:"results"` // "" when the request fails
+	}
+	if err := fetchJSON(ctx, sp.client, "sunrise-sunset.org", sp.apiURL+"/json?"+params.Encode(), &response); err != nil {
+		return sp.lastData, err
+	}
+	if response.Status != "OK" {
+		return sp.lastData, fmt.Errorf("sunrise-sunset.org: %s", response.Status)
+	}
+
+	var results struct {
+		Sunrise string `json:"sunrise"`
+		Sunset  string `json:"sunset"`
+	}
+	if err := json.Unmarshal(response.Results, &results); err != nil {
+		return sp.lastData, err
+	}
+	sunrise, err := time.Parse(time.RFC3339, results.Sunrise)
+	if err != nil {
+		return sp.lastData, err
+	}
+	sunset, err := time.Parse(time.RFC3339, results.Sunset)
+	if err != nil {
+		return sp.lastData, err
+	}
+
+	daylight := &Daylight{Sunrise: sunrise.Local(), Sunset: sunset.Local()}
+	sp.lastData = daylight
+	return daylight, nil
+}
+
+// Cleanup performs cleanup
+func (sp *SunriseSunsetPlugin) Cleanup() error {
+	return nil
+}
+
+// formatDaylightPill shows today's sunrise and sunset, and while the sun is up how much
+// daylight is left, e.g. "🌅 06:12  🌇 18:04 (3h20m left)". It is empty once the times
+// are from another day.
+func formatDaylightPill(d *Daylight, now time.Time) string {
+	if d == nil || d.Sunset.Format("2006-01-02") != now.Format("2006-01-02") {
+		return ""
+	}
+	pill := fmt.Sprintf("🌅 %s  🌇 %s", d.Sunrise.Format("15:04"), d.Sunset.Format("15:04"))
+	if now.After(d.Sunrise) && now.Before(d.Sunset) {
+		pill += fmt.Sprintf(" (%s left)", formatInterval(d.Sunset.Sub(now).Truncate(time.Minute)))
+	}
+	return pill
+}
+
+// GoldenHourReminder points out the evening golden hour some time ahead, for planning
+// an outdoor break
+type GoldenHourReminder struct {
+	lead     time.Duration
+	notified time.Time // golden hour the last notification was for
+}
+
+// NewGoldenHourReminder creates the reminder configured by widgets.daylight; it returns
+// nil when golden_hour_reminder is not set
+func NewGoldenHourReminder(cfg *Config) (*GoldenHourReminder, error) {
+	if cfg == nil || cfg.Widgets.Daylight.GoldenHourReminder == "" {
+		return nil, nil
+	}
+	lead, err := time.ParseDuration(cfg.Widgets.Daylight.GoldenHourReminder)
+	if err != nil || lead < 0 {
+		return nil, fmt.Errorf("invalid widgets.daylight.golden_hour_reminder %q", cfg.Widgets.Daylight.GoldenHourReminder)
+	}
+	return &GoldenHourReminder{lead: lead}, nil
+}
+
+// Pill describes the golden hour in the header from the reminder lead until sunset, or
+// returns "" outside that window
+func (g *GoldenHourReminder) Pill(d *Daylight, now time.Time) string {
+	if g == nil || d == nil {
+		return ""
+	}
+	golden := d.GoldenHour()
+	switch {
+	case now.Before(golden.Add(-g.lead)) || !now.Before(d.Sunset):
+		return ""
+	case now.Before(golden):
+		return "✨ Golden hour at " + golden.Format("15:04")
+	default:
+		return "✨ Golden hour until " + d.Sunset.Format("15:04")
+	}
+}
+
+// Due reports whether the reminder for this golden hour should be sent now, and records
+// it as sent so each golden hour is announced once
+func (g *GoldenHourReminder) Due(d *Daylight, now time.Time) bool {
+	if g.Pill(d, now) == "" || g.notified.Equal(d.GoldenHour()) {
+		return false
+	}
+	g.notified = d.GoldenHour()
+	return true
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSunriseSunsetPluginFetch(t *testing.T) {
+	geocodes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/v1/search":
+			geocodes++
+			fmt.Fprint(w, `{"results":[{"name":"Bengaluru","latitude":12.97194,"longitude":77.59369}]}`)
+		case "/json":
+			if query.Get("lat") != "12.9719" || query.Get("date") != "2026-10-16" || query.Get("formatted") != "0" {
+				fmt.Fprint(w, `{"results":"","status":"INVALID_REQUEST"}`)
+				return
+			}
+			fmt.Fprint(w, `{"results":{"sunrise":"2026-10-16T00:36:41+00:00","sunset":"2026-10-16T12:31:09+00:00",
+				"day_length":42868},"status":"OK"}`)
+		}
+	}))
+	defer server.Close()
+
+	plugin := NewSunriseSunsetPlugin()
+	plugin.apiURL = server.URL
+	plugin.geocodeURL = server.URL
+	plugin.now = func() time.Time { return time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local) }
+	plugin.Initialize(map[string]interface{}{"city": "Bengaluru,IN"})
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	daylight := data.(*Daylight)
+	if !daylight.Sunrise.Equal(time.Date(2026, 10, 16, 0, 36, 41, 0, time.UTC)) || daylight.Sunset.Location() != time.Local {
+		t.Errorf("Expected the sunrise in local time, got %v", daylight.Sunrise)
+	}
+	if got := daylight.GoldenHour(); !got.Equal(time.Date(2026, 10, 16, 11, 31, 9, 0, time.UTC)) {
+		t.Errorf("Expected the golden hour an hour before sunset, got %v", got)
+	}
+
+	// The coordinates are looked up once per city
+	plugin.Fetch(context.Background())
+	if geocodes != 1 {
+		t.Errorf("Expected one geocoding request, got %d", geocodes)
+	}
+	plugin.now = func() time.Time { return time.Date(2026, 10, 17, 9, 0, 0, 0, time.Local) }
+	if data, err := plugin.Fetch(context.Background()); err == nil || !data.(*Daylight).Sunrise.Equal(daylight.Sunrise) {
+		t.Errorf("Expected a rejected request to fail with the last data, got %+v, %v", data, err)
+	}
+}
+
+func TestFormatDaylightPill(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2026, 10, 16, h, m, 0, 0, time.Local) }
+	daylight := &Daylight{Sunrise: at(6, 12), Sunset: at(18, 4)}
+
+	for _, tc := range []struct {
+		now  time.Time
+		want string
+	}{
+		{at(5, 30), "🌅 06:12  🌇 18:04"},
+		{at(14, 43), "🌅 06:12  🌇 18:04 (3h21m left)"},
+		{at(19, 0), "🌅 06:12  🌇 18:04"},
+		{at(19, 0).AddDate(0, 0, 1), ""},
+	} {
+		if got := formatDaylightPill(daylight, tc.now); got != tc.want {
+			t.Errorf("Expected '%s' at %s, got '%s'", tc.want, tc.now.Format("Jan 2 15:04"), got)
+		}
+	}
+	if got := formatDaylightPill(nil, at(12, 0)); got != "" {
+		t.Errorf("Expected no pill before the first fetch, got '%s'", got)
+	}
+}
+
+func TestGoldenHourReminder(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2026, 10, 16, h, m, 0, 0, time.Local) }
+	daylight := &Daylight{Sunrise: at(6, 12), Sunset: at(18, 4)}
+
+	cfg := &Config{}
+	if reminder, err := NewGoldenHourReminder(cfg); reminder != nil || err != nil {
+		t.Errorf("Expected no reminder by default, got %+v, %v", reminder, err)
+	}
+	cfg.Widgets.Daylight.GoldenHourReminder = "soon"
+	if _, err := NewGoldenHourReminder(cfg); err == nil {
+		t.Error("Expected an invalid duration to fail")
+	}
+	cfg.Widgets.Daylight.GoldenHourReminder = "30m"
+	reminder, err := NewGoldenHourReminder(cfg)
+	if err != nil {
+		t.Fatalf("NewGoldenHourReminder failed: %v", err)
+	}
+
+	if reminder.Pill(daylight, at(16, 30)) != "" || reminder.Due(daylight, at(16, 30)) {
+		t.Error("Expected nothing before the reminder lead")
+	}
+	if got := reminder.Pill(daylight, at(16, 40)); got != "✨ Golden hour at 17:04" {
+		t.Errorf("Expected the coming golden hour, got '%s'", got)
+	}
+	if got := reminder.Pill(daylight, at(17, 30)); got != "✨ Golden hour until 18:04" {
+		t.Errorf("Expected the golden hour until sunset, got '%s'", got)
+	}
+	if reminder.Pill(daylight, at(18, 4)) != "" {
+		t.Error("Expected nothing after sunset")
+	}
+
+	// Each golden hour is announced once
+	if !reminder.Due(daylight, at(16, 40)) || reminder.Due(daylight, at(16, 41)) {
+		t.Error("Expected one notification for the golden hour")
+	}
+	tomorrow := &Daylight{Sunrise: daylight.Sunrise.AddDate(0, 0, 1), Sunset: daylight.Sunset.AddDate(0, 0, 1)}
+	if !reminder.Due(tomorrow, at(16, 40).AddDate(0, 0, 1)) {
+		t.Error("Expected tomorrow's golden hour to be announced again")
+	}
+
+	var off *GoldenHourReminder
+	if off.Pill(daylight, at(17, 30)) != "" || off.Due(daylight, at(17, 30)) {
+		t.Error("Expected no reminder when it is turned off")
+	}
+}
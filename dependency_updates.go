@@ -0,0 +1,446 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Check states of a dependency-update PR. Only green PRs are approved or merged.
+const (
+	checksGreen    = "green"
+	checksPending  = "pending"
+	checksFailing  = "failing"
+	checksConflict = "conflict"
+	checksNone     = "no checks"
+	checksMerged   = "merged"
+)
+
+// Bulk actions offered for green dependency-update PRs
+const (
+	bulkApprove = "approve"
+	bulkMerge   = "merge"
+)
+
+// defaultDependencyBots are the authors whose PRs count as dependency updates
+var defaultDependencyBots = []string{"app/dependabot", "app/renovate"}
+
+// DependencyUpdate is an open dependency-update PR and the state of its checks
+type DependencyUpdate struct {
+	PR      GitPullRequest
+	HeadSHA string
+	Checks  string
+}
+
+// Green reports whether the PR can be merged and all of its checks passed
+func (u DependencyUpdate) Green() bool {
+	return u.Checks == checksGreen
+}
+
+// BulkResult is the outcome of a bulk action on one PR
+type BulkResult struct {
+	Update DependencyUpdate
+	Err    error
+}
+
+// dependencyUpdatesMsg carries the dependency-update PRs found back to the TUI
+type dependencyUpdatesMsg struct {
+	updates []DependencyUpdate
+	err     error
+}
+
+// bulkActionMsg carries the outcome of a bulk approve or merge back to the TUI
+type bulkActionMsg struct {
+	action  string
+	results []BulkResult
+}
+
+// DependencyUpdater finds dependency-update PRs in the user's repos and approves or merges them
+type DependencyUpdater struct {
+	githubAPI
+	bots        []string
+	mergeMethod string
+}
+
+// NewDependencyUpdater creates an updater from config
+func NewDependencyUpdater(cfg *Config) *DependencyUpdater {
+	updater := &DependencyUpdater{
+		githubAPI:   newGitHubAPI(cfg),
+		bots:        defaultDependencyBots,
+		mergeMethod: "squash",
+	}
+	if cfg != nil {
+		if len(cfg.Widgets.PRs.DependencyBots) > 0 {
+			updater.bots = cfg.Widgets.PRs.DependencyBots
+		}
+		if cfg.Widgets.PRs.MergeMethod != "" {
+			updater.mergeMethod = cfg.Widgets.PRs.MergeMethod
+		}
+	}
+	return updater
+}
+
+// isDependencyUpdate reports whether a PR was opened by one of the dependency bots.
+// Bots match with or without the app/ prefix and [bot] suffix.
+func isDependencyUpdate(pr GitPullRequest, bots []string) bool {
+	normalize := func(login string) string {
+		login = strings.ToLower(strings.TrimSpace(login))
+		login = strings.TrimPrefix(login, "app/")
+		return strings.TrimSuffix(login, "[bot]")
+	}
+	author := normalize(pr.Author)
+	for _, bot := range bots {
+		if author == normalize(bot) {
+			return true
+		}
+	}
+	return false
+}
+
+// List finds open dependency-update PRs in repos owned by the user or the configured
+// orgs, and checks which of them are green
+func (u *DependencyUpdater) List(ctx context.Context) ([]DependencyUpdate, error) {
+	if u.token == "" {
+		return nil, fmt.Errorf("a GitHub token is required to approve and merge PRs")
+	}
+	owners, err := u.ownerQualifiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	qualifiers := []string{"type:pr", "is:open", "archived:false"}
+	for _, bot := range u.bots {
+		qualifiers = append(qualifiers, "author:"+bot)
+	}
+	qualifiers = append(qualifiers, owners...)
+	params := url.Values{}
+	params.Set("q", strings.Join(qualifiers, " "))
+	params.Set("per_page", "100")
+
+	var result struct {
+		Items []struct {
+			Number int    `json:"number"`
+			Title  string `json:"title"`
+			User   struct {
+				Login string `json:"login"`
+				Type  string `json:"type"`
+			} `json:"user"`
+			HTMLURL       string    `json:"html_url"`
+			RepositoryURL string    `json:"repository_url"`
+			UpdatedAt     time.Time `json:"updated_at"`
+		} `json:"items"`
+	}
+	if err := u.do(ctx, "GET", "/search/issues?"+params.Encode(), nil, &result); err != nil {
+		return nil, err
+	}
+
+	var updates []DependencyUpdate
+	for _, item := range result.Items {
+		pr := GitPullRequest{
+			Number:     item.Number,
+			Title:      item.Title,
+			State:      "open",
+			Author:     item.User.Login,
+			UpdatedAt:  item.UpdatedAt,
+			Repository: repositoryFromURL(item.RepositoryURL),
+			URL:        item.HTMLURL,
+			IsBot:      isBotAuthor(item.User.Login, item.User.Type),
+		}
+		if isDependencyUpdate(pr, u.bots) {
+			updates = append(updates, DependencyUpdate{PR: pr})
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := range updates {
+		wg.Add(1)
+		go func(update *DependencyUpdate) {
+			defer wg.Done()
+			update.HeadSHA, update.Checks = u.checkState(ctx, update.PR)
+		}(&updates[i])
+	}
+	wg.Wait()
+
+	sort.Slice(updates, func(i, j int) bool {
+		if updates[i].PR.Repository != updates[j].PR.Repository {
+			return updates[i].PR.Repository < updates[j].PR.Repository
+		}
+		return updates[i].PR.Number < updates[j].PR.Number
+	})
+	return updates, nil
+}
+
+// checkState reads a PR's head commit and combines its mergeability, check runs and
+// commit statuses into one state. Errors count as pending so the PR is left alone.
+func (u *DependencyUpdater) checkState(ctx context.Context, pr GitPullRequest) (string, string) {
+	var pull struct {
+		Mergeable *bool `json:"mergeable"`
+		Head      struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	}
+	if err := u.do(ctx, "GET", fmt.Sprintf("/repos/%s/pulls/%d", pr.Repository, pr.Number), nil, &pull); err != nil {
+		return "", checksPending
+	}
+	sha := pull.Head.SHA
+	if pull.Mergeable != nil && !*pull.Mergeable {
+		return sha, checksConflict
+	}
+
+	var runs struct {
+		TotalCount int `json:"total_count"`
+		CheckRuns  []struct {
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+		} `json:"check_runs"`
+	}
+	if err := u.do(ctx, "GET", fmt.Sprintf("/repos/%s/commits/%s/check-runs?per_page=100", pr.Repository, sha), nil, &runs); err != nil {
+		return sha, checksPending
+	}
+	var status struct {
+		State      string `json:"state"`
+		TotalCount int    `json:"total_count"`
+	}
+	if err := u.do(ctx, "GET", fmt.Sprintf("/repos/%s/commits/%s/status", pr.Repository, sha), nil, &status); err != nil {
+		return sha, checksPending
+	}
+
+	// The combined status is "pending" when there are no statuses at all
+	if runs.TotalCount == 0 && status.TotalCount == 0 {
+		return sha, checksNone
+	}
+	if status.State == "failure" || status.State == "error" {
+		return sha, checksFailing
+	}
+	pending := status.TotalCount > 0 && status.State == "pending"
+	for _, run := range runs.CheckRuns {
+		if run.Status != "completed" {
+			pending = true
+			continue
+		}
+		switch run.Conclusion {
+		case "success", "neutral", "skipped":
+		default:
+			return sha, checksFailing
+		}
+	}
+	if pending {
+		return sha, checksPending
+	}
+	return sha, checksGreen
+}
+
+// Apply approves, or approves and merges, every green update one at a time.
+// Merges are pinned to the checked head commit so newly pushed commits are not merged unchecked.
+func (u *DependencyUpdater) Apply(ctx context.Context, action string, updates []DependencyUpdate) []BulkResult {
+	var results []BulkResult
+	for _, update := range updates {
+		if !update.Green() {
+			continue
+		}
+		pr := update.PR
+		err := u.do(ctx, "POST", fmt.Sprintf("/repos/%s/pulls/%d/reviews", pr.Repository, pr.Number),
+			map[string]string{"event": "APPROVE"}, nil)
+		if err == nil && action == bulkMerge {
+			err = u.do(ctx, "PUT", fmt.Sprintf("/repos/%s/pulls/%d/merge", pr.Repository, pr.Number),
+				map[string]string{"merge_method": u.mergeMethod, "sha": update.HeadSHA}, nil)
+		}
+		results = append(results, BulkResult{Update: update, Err: err})
+	}
+	return results
+}
+
+// listDependencyUpdatesCmd finds dependency-update PRs in the background
+func listDependencyUpdatesCmd(updater *DependencyUpdater) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		updates, err := updater.List(ctx)
+		return dependencyUpdatesMsg{updates: updates, err: err}
+	}
+}
+
+// runBulkActionCmd approves or merges the green updates in the background
+func runBulkActionCmd(updater *DependencyUpdater, action string, updates []DependencyUpdate) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		return bulkActionMsg{action: action, results: updater.Apply(ctx, action, updates)}
+	}
+}
+
+// DependencyPanel is the overlay listing dependency-update PRs with bulk approve and merge
+type DependencyPanel struct {
+	updates     []DependencyUpdate
+	selected    int
+	loading     bool
+	running     bool
+	confirm     string // bulk action awaiting y/n
+	mergeMethod string
+	results     map[string]error // outcome per PR URL after a bulk action
+	action      string           // bulk action the results belong to
+	err         string
+}
+
+// NewDependencyPanel creates a panel that waits for its PRs to load
+func NewDependencyPanel(mergeMethod string) *DependencyPanel {
+	return &DependencyPanel{loading: true, mergeMethod: mergeMethod}
+}
+
+// Green returns the updates a bulk action would apply to
+func (p *DependencyPanel) Green() []DependencyUpdate {
+	var green []DependencyUpdate
+	for _, update := range p.updates {
+		if update.Green() {
+			green = append(green, update)
+		}
+	}
+	return green
+}
+
+// Update handles a key press. It reports whether the panel should close, the bulk
+// action to run once confirmed (if any) and a URL to open (if any).
+func (p *DependencyPanel) Update(msg tea.KeyMsg) (done bool, run string, open string) {
+	if p.confirm != "" {
+		switch msg.String() {
+		case "y", "Y":
+			run, p.confirm = p.confirm, ""
+			p.running, p.results, p.action = true, nil, run
+			return false, run, ""
+		case "n", "N", "esc", "q":
+			p.confirm = ""
+		}
+		return false, "", ""
+	}
+
+	switch msg.String() {
+	case "esc", "q", "d":
+		return true, "", ""
+	case "up", "k":
+		if p.selected > 0 {
+			p.selected--
+		}
+	case "down", "j":
+		if p.selected < len(p.updates)-1 {
+			p.selected++
+		}
+	case "enter":
+		if p.selected < len(p.updates) {
+			return false, "", p.updates[p.selected].PR.URL
+		}
+	case "a", "m":
+		if p.loading || p.running || len(p.Green()) == 0 {
+			return false, "", ""
+		}
+		p.confirm = bulkApprove
+		if msg.String() == "m" {
+			p.confirm = bulkMerge
+		}
+	}
+	return false, "", ""
+}
+
+// SetUpdates shows the PRs found
+func (p *DependencyPanel) SetUpdates(msg dependencyUpdatesMsg) {
+	p.loading = false
+	p.updates = msg.updates
+	p.selected = 0
+	if msg.err != nil {
+		p.err = msg.err.Error()
+	}
+}
+
+// SetResults records the outcome of a bulk action
+func (p *DependencyPanel) SetResults(msg bulkActionMsg) {
+	p.running = false
+	p.action = msg.action
+	p.results = make(map[string]error, len(msg.results))
+	for _, result := range msg.results {
+		p.results[result.Update.PR.URL] = result.Err
+	}
+	// Merged PRs are no longer green, so a second bulk merge skips them
+	for i, update := range p.updates {
+		if err, ok := p.results[update.PR.URL]; ok && err == nil && msg.action == bulkMerge {
+			p.updates[i].Checks = checksMerged
+		}
+	}
+}
+
+// View renders the panel as a bordered box
+func (p *DependencyPanel) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colorTitle)
+	selectedStyle := lipgloss.NewStyle().Foreground(colorAccent).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(colorMuted).Italic(true)
+	errorStyle := lipgloss.NewStyle().Foreground(colorAlert)
+	confirmStyle := lipgloss.NewStyle().Foreground(colorWarn).Bold(true)
+
+	icons := map[string]string{
+		checksGreen:    "✅",
+		checksPending:  "🔄",
+		checksFailing:  "❌",
+		checksConflict: "⚠️",
+		checksNone:     "⚪",
+		checksMerged:   "🟣",
+	}
+	done := map[string]string{bulkApprove: "approved", bulkMerge: "merged"}
+
+	lines := []string{titleStyle.Render("Dependency Updates"), ""}
+	switch {
+	case p.loading:
+		lines = append(lines, "🔄 Checking dependency PRs...")
+	case p.err != "":
+		lines = append(lines, errorStyle.Render("❌ "+p.err))
+	case len(p.updates) == 0:
+		lines = append(lines, dimStyle.Render("No open dependency updates"))
+	}
+
+	start := 0
+	if p.selected >= maxPaletteRows {
+		start = p.selected - maxPaletteRows + 1
+	}
+	for i := start; i < len(p.updates) && i < start+maxPaletteRows; i++ {
+		update := p.updates[i]
+		line := fmt.Sprintf("%s %s#%d %s  %s", icons[update.Checks], update.PR.Repository, update.PR.Number, update.PR.Title, dimStyle.Render(update.Checks))
+		if err, ok := p.results[update.PR.URL]; ok {
+			if err != nil {
+				line += errorStyle.Render(" → " + err.Error())
+			} else {
+				line += selectedStyle.Render(" → " + done[p.action])
+			}
+		}
+		if i == p.selected {
+			lines = append(lines, selectedStyle.Render("▶ ")+line)
+		} else {
+			lines = append(lines, "  "+line)
+		}
+	}
+
+	lines = append(lines, "")
+	green := len(p.Green())
+	switch {
+	case p.confirm == bulkApprove:
+		lines = append(lines, confirmStyle.Render(fmt.Sprintf("Approve %d green PR(s)? y/n", green)))
+	case p.confirm == bulkMerge:
+		lines = append(lines, confirmStyle.Render(fmt.Sprintf("Approve and %s-merge %d green PR(s)? y/n", p.mergeMethod, green)))
+	case p.running:
+		lines = append(lines, "🔄 Working...")
+	default:
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("%d green • a approve all • m merge all • Enter open • Esc close", green)))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Padding(1, 2).
+		Width(80).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDependencyUpdaterListAndMerge(t *testing.T) {
+	var mu sync.Mutex
+	var query string
+	var actions []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/user":
+			fmt.Fprint(w, `{"login":"octocat"}`)
+		case r.URL.Path == "/search/issues":
+			query = r.URL.Query().Get("q")
+			item := func(number int, repo, author string) string {
+				return fmt.Sprintf(`{"number":%d,"title":"Bump lib %d","user":{"login":%q,"type":"Bot"},"html_url":"https://github.com/%s/pull/%d","repository_url":"https://api.github.com/repos/%s"}`,
+					number, number, author, repo, number, repo)
+			}
+			fmt.Fprintf(w, `{"items":[%s,%s,%s,%s,%s]}`,
+				item(3, "octocat/web", "renovate[bot]"),
+				item(1, "octocat/api", "dependabot[bot]"),
+				item(2, "octocat/api", "dependabot[bot]"),
+				item(4, "octocat/api", "dependabot[bot]"),
+				item(5, "octocat/api", "github-actions[bot]"))
+		case strings.HasPrefix(r.URL.Path, "/repos/") && strings.Contains(r.URL.Path, "/pulls/") && r.Method == "GET":
+			number := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			mergeable := number != "4"
+			fmt.Fprintf(w, `{"mergeable":%t,"head":{"sha":"sha%s"}}`, mergeable, number)
+		case strings.HasSuffix(r.URL.Path, "/check-runs"):
+			conclusion := `"success"`
+			if strings.Contains(r.URL.Path, "sha2") {
+				conclusion = `"failure"`
+			}
+			fmt.Fprintf(w, `{"total_count":1,"check_runs":[{"status":"completed","conclusion":%s}]}`, conclusion)
+		case strings.HasSuffix(r.URL.Path, "/status"):
+			state := "success"
+			if strings.Contains(r.URL.Path, "sha3") {
+				state = "pending"
+			}
+			fmt.Fprintf(w, `{"state":%q,"total_count":1}`, state)
+		case strings.HasSuffix(r.URL.Path, "/reviews") || strings.HasSuffix(r.URL.Path, "/merge"):
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			actions = append(actions, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, body))
+			mu.Unlock()
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &Config{Plugins: map[string]map[string]interface{}{
+		"github-prs": {"github_token": "test-token", "orgs": []interface{}{"acme"}},
+	}, Features: map[string]bool{FeatureWriteActions: true}}
+	updater := NewDependencyUpdater(cfg)
+	updater.apiURL = server.URL
+
+	updates, err := updater.List(context.Background())
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	expectedQuery := "type:pr is:open archived:false author:app/dependabot author:app/renovate user:octocat org:acme"
+	if query != expectedQuery {
+		t.Errorf("Expected query '%s', got '%s'", expectedQuery, query)
+	}
+	if len(updates) != 4 {
+		t.Fatalf("Expected 4 dependency updates (github-actions excluded), got %d: %+v", len(updates), updates)
+	}
+	expected := []struct {
+		repo   string
+		number int
+		checks string
+	}{
+		{"octocat/api", 1, checksGreen},
+		{"octocat/api", 2, checksFailing},
+		{"octocat/api", 4, checksConflict},
+		{"octocat/web", 3, checksPending},
+	}
+	for i, e := range expected {
+		if updates[i].PR.Repository != e.repo || updates[i].PR.Number != e.number || updates[i].Checks != e.checks {
+			t.Errorf("Update %d: expected %s#%d %s, got %s#%d %s", i, e.repo, e.number, e.checks,
+				updates[i].PR.Repository, updates[i].PR.Number, updates[i].Checks)
+		}
+	}
+
+	results := updater.Apply(context.Background(), bulkMerge, updates)
+	if len(results) != 1 || results[0].Err != nil {
+		t.Fatalf("Expected only the green PR to be merged, got %+v", results)
+	}
+	expectedActions := []string{
+		"POST /repos/octocat/api/pulls/1/reviews map[event:APPROVE]",
+		"PUT /repos/octocat/api/pulls/1/merge map[merge_method:squash sha:sha1]",
+	}
+	if strings.Join(actions, "\n") != strings.Join(expectedActions, "\n") {
+		t.Errorf("Expected approve then squash merge pinned to the head commit, got:\n%s", strings.Join(actions, "\n"))
+	}
+}
+
+func TestDependencyUpdaterRequiresToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+	if _, err := NewDependencyUpdater(nil).List(context.Background()); err == nil {
+		t.Error("Expected listing without a token to fail")
+	}
+}
+
+func TestIsDependencyUpdate(t *testing.T) {
+	bots := []string{"app/dependabot", "Renovate-Bot"}
+	tests := map[string]bool{
+		"dependabot[bot]": true,
+		"dependabot":      true,
+		"renovate-bot":    true,
+		"renovate[bot]":   false,
+		"octocat":         false,
+	}
+	for author, expected := range tests {
+		if got := isDependencyUpdate(GitPullRequest{Author: author}, bots); got != expected {
+			t.Errorf("isDependencyUpdate(%q) = %t, expected %t", author, got, expected)
+		}
+	}
+}
+
+func TestDependencyPanelConfirmsBulkActions(t *testing.T) {
+	key := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	panel := NewDependencyPanel("squash")
+	if _, run, _ := panel.Update(key("m")); run != "" || panel.confirm != "" {
+		t.Error("Expected no bulk action while PRs are loading")
+	}
+
+	green := DependencyUpdate{PR: GitPullRequest{Repository: "octocat/api", Number: 1, URL: "https://github.com/octocat/api/pull/1"}, Checks: checksGreen}
+	failing := DependencyUpdate{PR: GitPullRequest{Repository: "octocat/api", Number: 2, URL: "https://github.com/octocat/api/pull/2"}, Checks: checksFailing}
+	panel.SetUpdates(dependencyUpdatesMsg{updates: []DependencyUpdate{green, failing}})
+
+	panel.Update(key("m"))
+	if !strings.Contains(panel.View(), "Approve and squash-merge 1 green PR(s)? y/n") {
+		t.Errorf("Expected a merge confirmation, got:\n%s", panel.View())
+	}
+	if _, run, _ := panel.Update(key("n")); run != "" || panel.confirm != "" {
+		t.Error("Expected n to cancel the confirmation")
+	}
+
+	panel.Update(key("m"))
+	done, run, _ := panel.Update(key("y"))
+	if done || run != bulkMerge {
+		t.Fatalf("Expected y to run the merge, got done=%t run='%s'", done, run)
+	}
+
+	panel.SetResults(bulkActionMsg{action: bulkMerge, results: []BulkResult{{Update: green}}})
+	if len(panel.Green()) != 0 {
+		t.Error("Expected merged PRs to no longer be green")
+	}
+	if view := panel.View(); !strings.Contains(view, "→ merged") {
+		t.Errorf("Expected the merged PR to be marked, got:\n%s", view)
+	}
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// DiscordPlugin shows recent messages and mentions from Discord channels using a bot token
+type DiscordPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	botToken    string
+	channels    []string // channel IDs the bot can read
+	userID      string   // whose mentions to surface; defaults to the bot user
+	lookback    time.Duration
+	apiURL      string
+	channelInfo map[string]discordChannel
+	client      *http.Client
+	lastData    []ChatActivity
+	rateLimitTracker
+}
+
+type discordChannel struct {
+	Name    string `json:"name"`
+	GuildID string `json:"guild_id"`
+}
+
+type discordMessage struct {
+	ID        string    `json:"id"`
+	Content   string    `json:"content"`
+	Timestamp time.Time `json:"timestamp"`
+	Author    struct {
+		ID         string `json:"id"`
+		Username   string `json:"username"`
+		GlobalName string `json:"global_name"`
+	} `json:"author"`
+	Mentions []struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"mentions"`
+	MentionEveryone bool `json:"mention_everyone"`
+}
+
+// NewDiscordPlugin creates a new Discord plugin
+func NewDiscordPlugin() *DiscordPlugin {
+	return &DiscordPlugin{
+		id:          "discord",
+		pluginType:  "chat",
+		name:        "Discord",
+		version:     "1.0.0",
+		description: "Shows recent messages and mentions from Discord channels",
+		author:      "GoDay Team",
+		botToken:    os.Getenv("DISCORD_BOT_TOKEN"),
+		lookback:    24 * time.Hour,
+		apiURL:      "https://discord.com/api/v10",
+		channelInfo: make(map[string]discordChannel),
+		client:      &http.Client{Timeout: 15 * time.Second},
+		lastData:    []ChatActivity{},
+	}
+}
+
+// GetID returns the plugin ID
+func (dp *DiscordPlugin) GetID() string {
+	return dp.id
+}
+
+// GetType returns the plugin type
+func (dp *DiscordPlugin) GetType() string {
+	return dp.pluginType
+}
+
+// Initialize sets up the plugin with configuration
+func (dp *DiscordPlugin) Initialize(config map[string]interface{}) error {
+	if token, ok := config["bot_token"].(string); ok && token != "" {
+		dp.botToken = token
+	}
+	if userID, ok := config["user_id"].(string); ok {
+		dp.userID = userID
+	}
+	dp.channels = configStringList(config["channels"])
+	if lookback, ok := config["lookback"].(string); ok && lookback != "" {
+		duration, err := time.ParseDuration(lookback)
+		if err != nil {
+			return fmt.Errorf("invalid lookback %q: %w", lookback, err)
+		}
+		dp.lookback = duration
+	}
+	return nil
+}
+
+// Fetch returns one entry per channel with messages inside the lookback window,
+// counting messages that mention the configured user
+func (dp *DiscordPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if dp.botToken == "" {
+		return dp.lastData, fmt.Errorf("Discord bot token not configured (widgets.discord.bot_token or DISCORD_BOT_TOKEN)")
+	}
+	if len(dp.channels) == 0 {
+		return dp.lastData, fmt.Errorf("no Discord channels configured")
+	}
+
+	if dp.userID == "" {
+		var me struct {
+			ID string `json:"id"`
+		}
+		if err := dp.get(ctx, "/users/@me", &me); err != nil {
+			return dp.lastData, err
+		}
+		dp.userID = me.ID
+	}
+
+	since := time.Now().Add(-dp.lookback)
+	var activity []ChatActivity
+	for _, channelID := range dp.channels {
+		channel, err := dp.channel(ctx, channelID)
+		if err != nil {
+			return dp.lastData, fmt.Errorf("channel %s: %w", channelID, err)
+		}
+
+		var messages []discordMessage
+		if err := dp.get(ctx, "/channels/"+url.PathEscape(channelID)+"/messages?limit=50", &messages); err != nil {
+			return dp.lastData, fmt.Errorf("channel %s: %w", channelID, err)
+		}
+
+		// Messages are returned newest first
+		entry := ChatActivity{Channel: "#" + channel.Name}
+		for _, message := range messages {
+			if message.Timestamp.Before(since) || message.Author.ID == dp.userID {
+				continue
+			}
+			entry.Unread++
+			if dp.mentionsUser(message) {
+				entry.Mentions++
+			}
+			if entry.Updated.IsZero() {
+				entry.Updated = message.Timestamp
+				entry.From = discordAuthor(message)
+				entry.Preview = entry.From + ": " + discordPreview(message)
+				entry.URL = fmt.Sprintf("https://discord.com/channels/%s/%s/%s", channel.GuildID, channelID, message.ID)
+			}
+		}
+		if entry.Unread > 0 {
+			activity = append(activity, entry)
+		}
+	}
+
+	sortChatActivity(activity)
+	dp.lastData = activity
+	return activity, nil
+}
+
+// channel returns a channel's name and guild, caching lookups
+func (dp *DiscordPlugin) channel(ctx context.Context, channelID string) (discordChannel, error) {
+	if channel, ok := dp.channelInfo[channelID]; ok {
+		return channel, nil
+	}
+	var channel discordChannel
+	if err := dp.get(ctx, "/channels/"+url.PathEscape(channelID), &channel); err != nil {
+		return channel, err
+	}
+	dp.channelInfo[channelID] = channel
+	return channel, nil
+}
+
+func (dp *DiscordPlugin) mentionsUser(message discordMessage) bool {
+	if message.MentionEveryone {
+		return true
+	}
+	for _, mention := range message.Mentions {
+		if mention.ID == dp.userID {
+			return true
+		}
+	}
+	return false
+}
+
+func discordAuthor(message discordMessage) string {
+	if message.Author.GlobalName != "" {
+		return message.Author.GlobalName
+	}
+	return message.Author.Username
+}
+
+// discordPreview flattens a message to one line and replaces <@id> mentions with @username
+func discordPreview(message discordMessage) string {
+	content := message.Content
+	for _, mention := range message.Mentions {
+		content = strings.ReplaceAll(content, "<@"+mention.ID+">", "@"+mention.Username)
+		content = strings.ReplaceAll(content, "<@!"+mention.ID+">", "@"+mention.Username)
+	}
+	content = strings.Join(strings.Fields(content), " ")
+	if content == "" {
+		return "(attachment)"
+	}
+	if runes := []rune(content); len(runes) > 60 {
+		content = string(runes[:59]) + "…"
+	}
+	return content
+}
+
+// get performs an authenticated Discord API request and decodes the JSON response
+func (dp *DiscordPlugin) get(ctx context.Context, path string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", dp.apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bot "+dp.botToken)
+	req.Header.Set("User-Agent", "DiscordBot (https://github.com/bhanu/goday, 1.0)")
+
+	resp, err := dp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	dp.observeRateLimit(resp)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return json.Unmarshal(body, target)
+	case http.StatusUnauthorized:
+		return fmt.Errorf("Discord bot token rejected")
+	case http.StatusForbidden:
+		return fmt.Errorf("bot is missing access")
+	default:
+		return fmt.Errorf("Discord API returned status %d", resp.StatusCode)
+	}
+}
+
+// GetMetadata returns plugin metadata
+func (dp *DiscordPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        dp.name,
+		Version:     dp.version,
+		Description: dp.description,
+		Author:      dp.author,
+		Type:        dp.pluginType,
+		Config: map[string]string{
+			"bot_token": "Discord bot token (default: $DISCORD_BOT_TOKEN)",
+			"channels":  "List of channel IDs to watch",
+			"user_id":   "User whose mentions are highlighted (default: the bot user)",
+			"lookback":  "How far back to show messages (default: 24h)",
+		},
+	}
+}
+
+// Cleanup performs cleanup
+func (dp *DiscordPlugin) Cleanup() error {
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiscordPluginFetch(t *testing.T) {
+	now := time.Now().UTC()
+	stamp := func(d time.Duration) string { return now.Add(d).Format(time.RFC3339) }
+
+	responses := map[string]string{
+		"/channels/100": `{"name":"general","guild_id":"9"}`,
+		"/channels/100/messages": fmt.Sprintf(`[
+			{"id":"3","content":"hey <@42>,\nready?","timestamp":%q,"author":{"id":"7","username":"alice","global_name":"Alice"},"mentions":[{"id":"42","username":"me"}]},
+			{"id":"2","content":"my own message","timestamp":%q,"author":{"id":"42","username":"me"},"mentions":[]},
+			{"id":"1","content":"","timestamp":%q,"author":{"id":"8","username":"bob"},"mentions":[]}
+		]`, stamp(-5*time.Minute), stamp(-10*time.Minute), stamp(-20*time.Minute)),
+		"/channels/200": `{"name":"random","guild_id":"9"}`,
+		"/channels/200/messages": fmt.Sprintf(`[
+			{"id":"6","content":"lunch?","timestamp":%q,"author":{"id":"8","username":"bob"},"mentions":[]},
+			{"id":"5","content":"old","timestamp":%q,"author":{"id":"8","username":"bob"},"mentions":[],"mention_everyone":true}
+		]`, stamp(-time.Minute), stamp(-48*time.Hour)),
+		"/channels/300":          `{"name":"quiet","guild_id":"9"}`,
+		"/channels/300/messages": `[]`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bot test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	plugin := NewDiscordPlugin()
+	plugin.apiURL = server.URL
+	err := plugin.Initialize(map[string]interface{}{
+		"bot_token": "test-token",
+		"channels":  []interface{}{"100", "200", "300"},
+		"user_id":   "42",
+	})
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	activity := data.([]ChatActivity)
+	if len(activity) != 2 {
+		t.Fatalf("Expected 2 channels with recent messages, got %d: %+v", len(activity), activity)
+	}
+
+	general, random := activity[0], activity[1]
+	if general.Channel != "#general" || general.Mentions != 1 || general.Unread != 2 {
+		t.Errorf("Expected 1 mention and 2 unread in #general, got %+v", general)
+	}
+	if general.Preview != "Alice: hey @me, ready?" {
+		t.Errorf("Expected preview 'Alice: hey @me, ready?', got '%s'", general.Preview)
+	}
+	if general.URL != "https://discord.com/channels/9/100/3" {
+		t.Errorf("Expected a link to the latest message, got '%s'", general.URL)
+	}
+	if random.Channel != "#random" || random.Mentions != 0 || random.Unread != 1 {
+		t.Errorf("Expected the old @everyone message to be outside the lookback, got %+v", random)
+	}
+}
+
+func TestDiscordPluginErrors(t *testing.T) {
+	t.Setenv("DISCORD_BOT_TOKEN", "")
+	plugin := NewDiscordPlugin()
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected Fetch to fail without a bot token")
+	}
+
+	plugin.Initialize(map[string]interface{}{"bot_token": "token"})
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected Fetch to fail without channels")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	plugin.apiURL = server.URL
+	plugin.Initialize(map[string]interface{}{"bot_token": "token", "channels": []string{"100"}, "user_id": "42"})
+	if _, err := plugin.Fetch(context.Background()); err == nil || !strings.Contains(err.Error(), "missing access") {
+		t.Errorf("Expected a missing access error, got %v", err)
+	}
+}
+
+func TestConfiguredWidgetsDiscordNeedsChannels(t *testing.T) {
+	t.Setenv("DISCORD_BOT_TOKEN", "token")
+	cfg := &Config{}
+	if cfg.ConfiguredWidgets()["discord"] {
+		t.Error("Expected Discord to stay hidden without channels")
+	}
+	cfg.Widgets.Discord.Channels = []string{"100"}
+	if !cfg.ConfiguredWidgets()["discord"] {
+		t.Error("Expected Discord to be configured with a token and channels")
+	}
+}
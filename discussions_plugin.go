@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Discussion is an unanswered question in a GitHub Discussions Q&A category
+type Discussion struct {
+	Repository string
+	Title      string
+	URL        string
+	Author     string
+	Category   string
+	Comments   int
+	CreatedAt  time.Time
+}
+
+// discussionsQuery searches discussions; only Q&A categories can be answered
+const discussionsQuery = `query($q: String!) {
+  search(type: DISCUSSION, query: $q, first: 50) {
+    nodes {
+      ... on Discussion {
+        title
+        url
+        createdAt
+        isAnswered
+        author { login }
+        repository { nameWithOwner }
+        category { name isAnswerable }
+        comments { totalCount }
+      }
+    }
+  }
+}`
+
+// GitHubDiscussionsPlugin lists unanswered Q&A discussions in the repos the user maintains
+type GitHubDiscussionsPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	githubToken string
+	repos       []string // owner/name; empty searches the user's repos and orgs
+	orgs        []string
+	maxResults  int
+	graphqlURL  string
+	client      *http.Client
+	lastData    []Discussion
+	rateLimitTracker
+}
+
+// NewGitHubDiscussionsPlugin creates a new GitHub Discussions plugin
+func NewGitHubDiscussionsPlugin() *GitHubDiscussionsPlugin {
+	githubToken := os.Getenv("GITHUB_TOKEN")
+	if githubToken == "" {
+		githubToken = os.Getenv("GH_TOKEN")
+	}
+
+	return &GitHubDiscussionsPlugin{
+		id:          "github-discussions",
+		pluginType:  "git",
+		name:        "GitHub Discussions",
+		version:     "1.0.0",
+		description: "Lists unanswered questions in GitHub Discussions",
+		author:      "GoDay Team",
+		githubToken: githubToken,
+		maxResults:  20,
+		graphqlURL:  "https://api.github.com/graphql",
+		client:      &http.Client{Timeout: 15 * time.Second},
+		lastData:    []Discussion{},
+	}
+}
+
+// GetID returns the plugin ID
+func (gd *GitHubDiscussionsPlugin) GetID() string {
+	return gd.id
+}
+
+// GetType returns the plugin type
+func (gd *GitHubDiscussionsPlugin) GetType() string {
+	return gd.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (gd *GitHubDiscussionsPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        gd.name,
+		Version:     gd.version,
+		Description: gd.description,
+		Author:      gd.author,
+		Type:        gd.pluginType,
+		Config: map[string]string{
+			"has_github_token": fmt.Sprintf("%t", gd.githubToken != ""),
+			"repos":            strings.Join(gd.repos, ","),
+			"orgs":             strings.Join(gd.orgs, ","),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (gd *GitHubDiscussionsPlugin) Initialize(config map[string]interface{}) error {
+	if token, ok := config["github_token"].(string); ok && token != "" {
+		gd.githubToken = token
+	}
+	gd.repos = configStringList(config["repos"])
+	for _, repo := range gd.repos {
+		if strings.Count(repo, "/") != 1 {
+			return fmt.Errorf("invalid repo %q (expected owner/name)", repo)
+		}
+	}
+	gd.orgs = configStringList(config["orgs"])
+	if maxResults, ok := config["max_results"].(int); ok && maxResults > 0 {
+		gd.maxResults = maxResults
+	}
+	return nil
+}
+
+// Fetch returns unanswered questions, the longest-waiting first
+func (gd *GitHubDiscussionsPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	// The GraphQL API, the only one that searches discussions, requires a token
+	if gd.githubToken == "" {
+		return gd.lastData, fmt.Errorf("GitHub token not configured")
+	}
+
+	qualifiers := []string{"is:open", "is:unanswered", "sort:created-desc"}
+	for _, repo := range gd.repos {
+		qualifiers = append(qualifiers, "repo:"+repo)
+	}
+	if len(gd.repos) == 0 {
+		var viewer struct {
+			Viewer struct {
+				Login string `json:"login"`
+			} `json:"viewer"`
+		}
+		if err := gd.query(ctx, `query { viewer { login } }`, nil, &viewer); err != nil {
+			return gd.lastData, err
+		}
+		qualifiers = append(qualifiers, "user:"+viewer.Viewer.Login)
+		for _, org := range gd.orgs {
+			qualifiers = append(qualifiers, "org:"+org)
+		}
+	}
+
+	var result struct {
+		Search struct {
+			Nodes []struct {
+				Title      string    `json:"title"`
+				URL        string    `json:"url"`
+				CreatedAt  time.Time `json:"createdAt"`
+				IsAnswered bool      `json:"isAnswered"`
+				Author     *struct {
+					Login string `json:"login"`
+				} `json:"author"`
+				Repository struct {
+					NameWithOwner string `json:"nameWithOwner"`
+				} `json:"repository"`
+				Category struct {
+					Name         string `json:"name"`
+					IsAnswerable bool   `json:"isAnswerable"`
+				} `json:"category"`
+				Comments struct {
+					TotalCount int `json:"totalCount"`
+				} `json:"comments"`
+			} `json:"nodes"`
+		} `json:"search"`
+	}
+	variables := map[string]interface{}{"q": strings.Join(qualifiers, " ")}
+	if err := gd.query(ctx, discussionsQuery, variables, &result); err != nil {
+		return gd.lastData, err
+	}
+
+	var discussions []Discussion
+	for _, node := range result.Search.Nodes {
+		if !node.Category.IsAnswerable || node.IsAnswered || node.URL == "" {
+			continue
+		}
+		author := "ghost" // deleted accounts have no author
+		if node.Author != nil {
+			author = node.Author.Login
+		}
+		discussions = append(discussions, Discussion{
+			Repository: node.Repository.NameWithOwner,
+			Title:      node.Title,
+			URL:        node.URL,
+			Author:     author,
+			Category:   node.Category.Name,
+			Comments:   node.Comments.TotalCount,
+			CreatedAt:  node.CreatedAt,
+		})
+	}
+
+	sort.SliceStable(discussions, func(i, j int) bool {
+		return discussions[i].CreatedAt.Before(discussions[j].CreatedAt)
+	})
+	if len(discussions) > gd.maxResults {
+		discussions = discussions[:gd.maxResults]
+	}
+
+	gd.lastData = discussions
+	return discussions, nil
+}
+
+// query runs a GraphQL query and decodes its data into target
+func (gd *GitHubDiscussionsPlugin) query(ctx context.Context, query string, variables map[string]interface{}, target interface{}) error {
+	payload, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", gd.graphqlURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "bearer "+gd.githubToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := gd.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	gd.observeRateLimit(resp)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GitHub GraphQL API returned status %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return err
+	}
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("GitHub GraphQL error: %s", response.Errors[0].Message)
+	}
+	return json.Unmarshal(response.Data, target)
+}
+
+// Cleanup performs cleanup
+func (gd *GitHubDiscussionsPlugin) Cleanup() error {
+	return nil
+}
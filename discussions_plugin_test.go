@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGitHubDiscussionsPluginFetch(t *testing.T) {
+	now := time.Now().UTC()
+	var searched string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body struct {
+			Query     string            `json:"query"`
+			Variables map[string]string `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if strings.Contains(body.Query, "viewer") {
+			fmt.Fprint(w, `{"data":{"viewer":{"login":"octocat"}}}`)
+			return
+		}
+		searched = body.Variables["q"]
+
+		node := func(title string, age time.Duration, answered, answerable bool, comments int) string {
+			return fmt.Sprintf(`{"title":%q,"url":"https://github.com/octocat/api/discussions/%d","createdAt":%q,"isAnswered":%t,`+
+				`"author":{"login":"alice"},"repository":{"nameWithOwner":"octocat/api"},"category":{"name":"Q&A","isAnswerable":%t},"comments":{"totalCount":%d}}`,
+				title, len(title), now.Add(-age).Format(time.RFC3339), answered, answerable, comments)
+		}
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		fmt.Fprintf(w, `{"data":{"search":{"nodes":[%s,%s,%s,%s,{}]}}}`,
+			node("Recent question", 2*time.Hour, false, true, 0),
+			node("Old question", 10*24*time.Hour, false, true, 2),
+			node("Answered question", time.Hour, true, true, 1),
+			node("Announcement", time.Hour, false, false, 0))
+	}))
+	defer server.Close()
+
+	plugin := NewGitHubDiscussionsPlugin()
+	plugin.graphqlURL = server.URL
+	if err := plugin.Initialize(map[string]interface{}{"github_token": "token", "orgs": []interface{}{"acme"}}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	discussions := data.([]Discussion)
+
+	if searched != "is:open is:unanswered sort:created-desc user:octocat org:acme" {
+		t.Errorf("Unexpected search query '%s'", searched)
+	}
+	if len(discussions) != 2 || discussions[0].Title != "Old question" || discussions[1].Title != "Recent question" {
+		t.Fatalf("Expected the 2 unanswered Q&A questions, oldest first, got %+v", discussions)
+	}
+	if rl, ok := plugin.RateLimit(); !ok || rl.Remaining != 4999 {
+		t.Errorf("Expected the GraphQL rate limit to be tracked, got %+v", rl)
+	}
+
+	wm := NewWidgetManager()
+	wm.UpdateDiscussionsWidget(discussions)
+	items := wm.Widgets["discussions"].Items
+	if items[0].Subtitle != "octocat/api • waiting 10d • 2 comments" || items[0].Status != "❓" {
+		t.Errorf("Expected the old question's age and comments, got %+v", items[0])
+	}
+	if items[1].Status != "🔴" {
+		t.Errorf("Expected a question without replies to be flagged, got %+v", items[1])
+	}
+}
+
+func TestGitHubDiscussionsPluginConfig(t *testing.T) {
+	plugin := NewGitHubDiscussionsPlugin()
+	plugin.githubToken = ""
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected Fetch to fail without a token")
+	}
+	if err := plugin.Initialize(map[string]interface{}{"repos": []interface{}{"octocat"}}); err == nil {
+		t.Error("Expected a repo without an owner to fail")
+	}
+
+	cfg := &Config{}
+	if cfg.ConfiguredWidgets()["discussions"] {
+		t.Error("Expected the discussions tile to stay hidden without repos")
+	}
+	cfg.Widgets.Discussions.Repos = []string{"octocat/api"}
+	if !cfg.ConfiguredWidgets()["discussions"] {
+		t.Error("Expected the discussions tile to be shown once repos are set")
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	now := time.Now()
+	for age, expected := range map[time.Duration]string{
+		45 * time.Minute:    "45m",
+		5 * time.Hour:       "5h",
+		12 * 24 * time.Hour: "12d",
+	} {
+		if got := formatAge(now.Add(-age), now); got != expected {
+			t.Errorf("Expected %v to format as '%s', got '%s'", age, expected, got)
+		}
+	}
+}
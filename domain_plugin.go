@@ -0,0 +1,417 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// rdapBootstrapURL lists the RDAP server of each top-level domain
+	rdapBootstrapURL = "https://data.iana.org/rdap/dns.json"
+	// whoisRootServer names the WHOIS server of top-level domains without RDAP
+	whoisRootServer = "whois.iana.org:43"
+	// domainDefaultWarnDays turns a domain yellow without widgets.domains.warn_days
+	domainDefaultWarnDays = 30
+	// domainDefaultCriticalDays turns a domain red without widgets.domains.critical_days
+	domainDefaultCriticalDays = 7
+)
+
+// DomainStatus is the registration of a domain and when it expires
+type DomainStatus struct {
+	Domain    string
+	Expires   time.Time
+	Registrar string
+	Source    string // rdap or whois
+	Problem   string // e.g. the domain is in its redemption period
+	Err       string // why the expiry date could not be looked up
+	Level     string // 🟢, or 🟡 and 🔴 by warn_days and critical_days
+}
+
+// DomainPlugin looks up when domains expire through RDAP, or WHOIS for top-level domains
+// without an RDAP server
+type DomainPlugin struct {
+	id           string
+	pluginType   string
+	name         string
+	version      string
+	description  string
+	author       string
+	domains      []string
+	warnDays     int
+	criticalDays int
+	bootstrapURL string
+	whoisServer  string
+	whois        bool              // features.whois; without it only RDAP is used
+	rdapServers  map[string]string // top-level domain -> RDAP base URL, once bootstrapped
+	mu           sync.Mutex
+	client       *http.Client
+	lastData     []DomainStatus
+}
+
+// NewDomainPlugin creates a new domain expiry plugin
+func NewDomainPlugin() *DomainPlugin {
+	return &DomainPlugin{
+		id:           "domains",
+		pluginType:   "domains",
+		name:         "Domains",
+		version:      "1.0.0",
+		description:  "Tracks when your domains expire through RDAP and WHOIS",
+		author:       "GoDay Team",
+		warnDays:     domainDefaultWarnDays,
+		criticalDays: domainDefaultCriticalDays,
+		bootstrapURL: rdapBootstrapURL,
+		whoisServer:  whoisRootServer,
+		client:       &http.Client{Timeout: 15 * time.Second},
+		lastData:     []DomainStatus{},
+	}
+}
+
+// GetID returns the plugin ID
+func (dp *DomainPlugin) GetID() string {
+	return dp.id
+}
+
+// GetType returns the plugin type
+func (dp *DomainPlugin) GetType() string {
+	return dp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (dp *DomainPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        dp.name,
+		Version:     dp.version,
+		Description: dp.description,
+		Author:      dp.author,
+		Type:        dp.pluginType,
+		Config: map[string]string{
+			"domains":       strings.Join(dp.domains, ","),
+			"warn_days":     fmt.Sprintf("%d", dp.warnDays),
+			"critical_days": fmt.Sprintf("%d", dp.criticalDays),
+			"whois":         fmt.Sprintf("%t", dp.whois),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (dp *DomainPlugin) Initialize(config map[string]interface{}) error {
+	dp.domains = nil
+	for _, domain := range configStringList(config["domains"]) {
+		domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+		if !strings.Contains(domain, ".") || strings.ContainsAny(domain, "/: ") {
+			return fmt.Errorf("invalid domain %q (expected a name such as example.com)", domain)
+		}
+		dp.domains = append(dp.domains, domain)
+	}
+	if days, ok := config["warn_days"].(int); ok && days > 0 {
+		dp.warnDays = days
+	}
+	if days, ok := config["critical_days"].(int); ok && days > 0 {
+		dp.criticalDays = days
+	}
+	dp.whois, _ = config["whois"].(bool)
+	if dp.criticalDays > dp.warnDays {
+		return fmt.Errorf("critical_days (%d) must not be more than warn_days (%d)", dp.criticalDays, dp.warnDays)
+	}
+	return nil
+}
+
+// Fetch looks up every domain concurrently and returns them soonest expiry first, domains
+// that could not be looked up before all others
+func (dp *DomainPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if len(dp.domains) == 0 {
+		return dp.lastData, fmt.Errorf("no domains configured (widgets.domains.domains)")
+	}
+
+	// Without the bootstrap file every domain needs WHOIS; it is tried again next time
+	servers, bootstrapErr := dp.rdapBootstrap(ctx)
+
+	results := make([]DomainStatus, len(dp.domains))
+	var wg sync.WaitGroup
+	for i, domain := range dp.domains {
+		wg.Add(1)
+		go func(i int, domain string) {
+			defer wg.Done()
+			results[i] = dp.lookup(ctx, domain, servers, bootstrapErr)
+		}(i, domain)
+	}
+	wg.Wait()
+
+	now := time.Now()
+	for i := range results {
+		results[i].Level = "🔴"
+		if results[i].Err == "" && results[i].Problem == "" {
+			results[i].Level = expiryLevel(daysUntil(results[i].Expires, now), dp.warnDays, dp.criticalDays)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if (results[i].Err != "") != (results[j].Err != "") {
+			return results[i].Err != ""
+		}
+		return results[i].Expires.Before(results[j].Expires)
+	})
+
+	dp.lastData = results
+	return results, nil
+}
+
+// lookup asks the domain's RDAP server, or WHOIS when its top-level domain has none and
+// WHOIS is enabled
+func (dp *DomainPlugin) lookup(ctx context.Context, domain string, servers map[string]string, bootstrapErr error) DomainStatus {
+	if base := rdapServerFor(domain, servers); base != "" {
+		status, err := dp.lookupRDAP(ctx, domain, base)
+		if err != nil {
+			return DomainStatus{Domain: domain, Source: "rdap", Err: err.Error()}
+		}
+		return status
+	}
+	if !dp.whois {
+		err := fmt.Sprintf("no RDAP server for this domain; WHOIS lookups are off (features.%s)", FeatureWHOIS)
+		if bootstrapErr != nil {
+			err = bootstrapErr.Error()
+		}
+		return DomainStatus{Domain: domain, Source: "rdap", Err: err}
+	}
+	status, err := dp.lookupWHOIS(ctx, domain)
+	if err != nil {
+		return DomainStatus{Domain: domain, Source: "whois", Err: err.Error()}
+	}
+	return status
+}
+
+// rdapBootstrap returns the RDAP server of each top-level domain, from IANA once per run
+func (dp *DomainPlugin) rdapBootstrap(ctx context.Context) (map[string]string, error) {
+	dp.mu.Lock()
+	defer dp.mu.Unlock()
+	if dp.rdapServers != nil {
+		return dp.rdapServers, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", dp.bootstrapURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := dp.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("RDAP bootstrap returned status %d", resp.StatusCode)
+	}
+	var bootstrap struct {
+		Services [][][]string `json:"services"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&bootstrap); err != nil {
+		return nil, fmt.Errorf("failed to parse RDAP bootstrap: %w", err)
+	}
+
+	servers := make(map[string]string)
+	for _, service := range bootstrap.Services {
+		if len(service) != 2 || len(service[1]) == 0 {
+			continue
+		}
+		// Prefer an https server when several are listed
+		base := service[1][0]
+		for _, url := range service[1] {
+			if strings.HasPrefix(url, "https://") {
+				base = url
+				break
+			}
+		}
+		for _, tld := range service[0] {
+			servers[strings.ToLower(tld)] = strings.TrimSuffix(base, "/") + "/"
+		}
+	}
+	dp.rdapServers = servers
+	return servers, nil
+}
+
+// rdapServerFor returns the RDAP base URL for domain, matching its longest listed suffix
+func rdapServerFor(domain string, servers map[string]string) string {
+	labels := strings.Split(domain, ".")
+	for i := 1; i < len(labels); i++ {
+		if base, ok := servers[strings.Join(labels[i:], ".")]; ok {
+			return base
+		}
+	}
+	return ""
+}
+
+// rdapDomain is the part of an RDAP domain response the tile shows
+type rdapDomain struct {
+	Status []string `json:"status"`
+	Events []struct {
+		Action string `json:"eventAction"`
+		Date   string `json:"eventDate"`
+	} `json:"events"`
+	Entities []struct {
+		Roles []string          `json:"roles"`
+		VCard []json.RawMessage `json:"vcardArray"`
+	} `json:"entities"`
+}
+
+// lookupRDAP reads the expiration event and registrar from an RDAP server
+func (dp *DomainPlugin) lookupRDAP(ctx context.Context, domain, base string) (DomainStatus, error) {
+	status := DomainStatus{Domain: domain, Source: "rdap"}
+	req, err := http.NewRequestWithContext(ctx, "GET", base+"domain/"+domain, nil)
+	if err != nil {
+		return status, err
+	}
+	req.Header.Set("Accept", "application/rdap+json")
+	resp, err := dp.client.Do(req)
+	if err != nil {
+		return status, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return status, fmt.Errorf("not registered")
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return status, fmt.Errorf("RDAP rate limit reached; try a longer ttl")
+	case resp.StatusCode != http.StatusOK:
+		return status, fmt.Errorf("RDAP returned status %d", resp.StatusCode)
+	}
+
+	var result rdapDomain
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return status, fmt.Errorf("failed to parse RDAP response: %w", err)
+	}
+	for _, event := range result.Events {
+		if event.Action == "expiration" {
+			status.Expires, _ = time.Parse(time.RFC3339, event.Date)
+		}
+	}
+	if status.Expires.IsZero() {
+		return status, fmt.Errorf("the registry publishes no expiry date")
+	}
+	for _, entity := range result.Entities {
+		if containsString(entity.Roles, "registrar") {
+			status.Registrar = vcardName(entity.VCard)
+		}
+	}
+	for _, state := range result.Status {
+		if state == "redemption period" || state == "pending delete" {
+			status.Problem = state
+		}
+	}
+	return status, nil
+}
+
+// vcardName returns the fn (formatted name) of a jCard, ["vcard", [[name, params, type, value], ...]]
+func vcardName(vcard []json.RawMessage) string {
+	if len(vcard) < 2 {
+		return ""
+	}
+	var properties [][]interface{}
+	if err := json.Unmarshal(vcard[1], &properties); err != nil {
+		return ""
+	}
+	for _, property := range properties {
+		if len(property) == 4 && property[0] == "fn" {
+			name, _ := property[3].(string)
+			return name
+		}
+	}
+	return ""
+}
+
+var (
+	whoisRefer     = regexp.MustCompile(`(?im)^\s*(?:refer|whois):\s*(\S+)`)
+	whoisExpiry    = regexp.MustCompile(`(?im)^\s*(?:registry expiry date|registrar registration expiration date|expiration date|expiry date|expire date|expires(?: on)?|paid-till|renewal date)\s*:\s*(.+?)\s*$`)
+	whoisRegistrar = regexp.MustCompile(`(?im)^\s*registrar(?: name)?\s*:\s*(.+?)\s*$`)
+)
+
+// whoisDateLayouts are the expiry date formats WHOIS servers use
+var whoisDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006.01.02",
+	"2006/01/02",
+	"02-Jan-2006",
+	"02.01.2006",
+	"January 2 2006",
+}
+
+// lookupWHOIS asks IANA's WHOIS server which server knows the top-level domain, and that
+// server for the expiry date
+func (dp *DomainPlugin) lookupWHOIS(ctx context.Context, domain string) (DomainStatus, error) {
+	status := DomainStatus{Domain: domain, Source: "whois"}
+	root, err := whoisQuery(ctx, dp.whoisServer, domain)
+	if err != nil {
+		return status, err
+	}
+	match := whoisRefer.FindStringSubmatch(root)
+	if match == nil {
+		return status, fmt.Errorf("no WHOIS server for this domain")
+	}
+	server := match[1]
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "43")
+	}
+	reply, err := whoisQuery(ctx, server, domain)
+	if err != nil {
+		return status, err
+	}
+
+	match = whoisExpiry.FindStringSubmatch(reply)
+	if match == nil {
+		if strings.Contains(strings.ToLower(reply), "no match") || strings.Contains(strings.ToLower(reply), "not found") {
+			return status, fmt.Errorf("not registered")
+		}
+		return status, fmt.Errorf("the registry publishes no expiry date")
+	}
+	for _, layout := range whoisDateLayouts {
+		if expires, err := time.Parse(layout, match[1]); err == nil {
+			status.Expires = expires
+			break
+		}
+	}
+	if status.Expires.IsZero() {
+		return status, fmt.Errorf("unknown expiry date %q", match[1])
+	}
+	if match := whoisRegistrar.FindStringSubmatch(reply); match != nil {
+		status.Registrar = match[1]
+	}
+	return status, nil
+}
+
+// whoisQuery sends a query to a WHOIS server and returns its whole reply
+func whoisQuery(ctx context.Context, server, query string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", server)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if _, err := fmt.Fprintf(conn, "%s\r\n", query); err != nil {
+		return "", err
+	}
+	reply, err := io.ReadAll(io.LimitReader(conn, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	return string(reply), nil
+}
+
+// Cleanup performs cleanup
+func (dp *DomainPlugin) Cleanup() error {
+	return nil
+}
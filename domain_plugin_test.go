@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serveWHOIS answers every query on a local listener with reply and returns its address
+func serveWHOIS(t *testing.T, reply string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			bufio.NewReader(conn).ReadString('\n')
+			fmt.Fprint(conn, reply)
+			conn.Close()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestDomainPluginFetch(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/dns.json":
+			fmt.Fprintf(w, `{"services": [[["com", "net"], ["%s/rdap/"]]]}`, server.URL)
+		case "/rdap/domain/example.com":
+			w.Write([]byte(`{
+				"status": ["active"],
+				"events": [{"eventAction": "registration", "eventDate": "1995-08-14T04:00:00Z"},
+				           {"eventAction": "expiration", "eventDate": "2099-08-13T04:00:00Z"}],
+				"entities": [{"roles": ["registrar"], "vcardArray": ["vcard", [["version", {}, "text", "4.0"], ["fn", {}, "text", "Example Registrar, Inc."]]]}]
+			}`))
+		case "/rdap/domain/lapsed.net":
+			w.Write([]byte(`{"status": ["redemption period"], "events": [{"eventAction": "expiration", "eventDate": "2000-01-01T00:00:00Z"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	registry := serveWHOIS(t, "Domain Name: example.io\r\nRegistry Expiry Date: 2099-03-01T12:00:00Z\r\nRegistrar: IO Registrar\r\n")
+	root := serveWHOIS(t, "domain:       IO\r\nrefer:        "+registry+"\r\n")
+
+	plugin := NewDomainPlugin()
+	if err := plugin.Initialize(map[string]interface{}{
+		"domains": []string{"Example.com.", "missing.com", "example.io", "lapsed.net"},
+		"whois":   true,
+	}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	plugin.bootstrapURL = server.URL + "/dns.json"
+	plugin.whoisServer = root
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	domains := data.([]DomainStatus)
+	if len(domains) != 4 {
+		t.Fatalf("Expected 4 domains, got %+v", domains)
+	}
+	if domains[0].Domain != "missing.com" || domains[0].Err != "not registered" || domains[0].Level != "🔴" {
+		t.Errorf("Expected the unregistered domain first, got %+v", domains[0])
+	}
+	if domains[1].Domain != "lapsed.net" || domains[1].Problem != "redemption period" || domains[1].Level != "🔴" {
+		t.Errorf("Expected the lapsed domain in its redemption period, got %+v", domains[1])
+	}
+	if got := domains[2]; got.Domain != "example.io" || got.Source != "whois" || got.Registrar != "IO Registrar" ||
+		!got.Expires.Equal(time.Date(2099, 3, 1, 12, 0, 0, 0, time.UTC)) || got.Level != "🟢" {
+		t.Errorf("Expected example.io from WHOIS, got %+v", got)
+	}
+	if got := domains[3]; got.Domain != "example.com" || got.Source != "rdap" || got.Registrar != "Example Registrar, Inc." || got.Expires.Year() != 2099 {
+		t.Errorf("Expected example.com from RDAP, got %+v", got)
+	}
+}
+
+func TestDomainPluginInitialize(t *testing.T) {
+	if err := NewDomainPlugin().Initialize(map[string]interface{}{"domains": []string{"https://example.com"}}); err == nil {
+		t.Error("Expected a URL to be rejected as domain")
+	}
+	if err := NewDomainPlugin().Initialize(map[string]interface{}{"domains": []string{"localhost"}}); err == nil {
+		t.Error("Expected a name without a top-level domain to be rejected")
+	}
+	if _, err := NewDomainPlugin().Fetch(context.Background()); err == nil {
+		t.Error("Expected an error without domains")
+	}
+}
+
+func TestRDAPServerFor(t *testing.T) {
+	servers := map[string]string{"uk": "https://uk/", "co.uk": "https://co.uk/"}
+	if got := rdapServerFor("example.co.uk", servers); got != "https://co.uk/" {
+		t.Errorf("Expected the longest suffix to win, got '%s'", got)
+	}
+	if got := rdapServerFor("example.io", servers); got != "" {
+		t.Errorf("Expected no server for an unlisted top-level domain, got '%s'", got)
+	}
+}
+
+func TestUpdateDomainsWidget(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	domains := []DomainStatus{
+		{Domain: "gone.dev", Err: "not registered", Level: "🔴"},
+		{Domain: "side.dev", Expires: now.Add(12 * 24 * time.Hour), Registrar: "Porkbun", Level: "🟡"},
+		{Domain: "example.com", Expires: now.Add(400 * 24 * time.Hour), Level: "🟢"},
+	}
+
+	wm := NewWidgetManager()
+	wm.UpdateDomainsWidget(domains, now)
+	widget := wm.Widgets["domains"]
+	if widget.Count != 2 {
+		t.Errorf("Expected 2 domains needing attention, got %d", widget.Count)
+	}
+	if widget.Items[0].Subtitle != "not registered" || widget.Items[0].URL != "" {
+		t.Errorf("Expected the lookup error without a link, got %+v", widget.Items[0])
+	}
+	if got := widget.Items[1].Subtitle; !strings.HasPrefix(got, "expires in 12 days • ") || !strings.HasSuffix(got, "Porkbun") {
+		t.Errorf("Expected days left and registrar, got '%s'", got)
+	}
+	if widget.Items[2].URL != "https://example.com" {
+		t.Errorf("Expected a link to the domain, got '%s'", widget.Items[2].URL)
+	}
+}
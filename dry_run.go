@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DryRunLog records the write requests GoDay would have sent while safety.dry_run is on,
+// such as PR approvals or issue labels, one line per request
+type DryRunLog struct {
+	path string
+	now  func() time.Time
+	mu   sync.Mutex
+}
+
+// NewDryRunLog returns a dry run log writing to ~/.goday/dry_run.log
+func NewDryRunLog() *DryRunLog {
+	return &DryRunLog{path: dryRunLogPath(), now: time.Now}
+}
+
+// dryRunLogFor returns the dry run log for cfg, or nil when writes are sent for real
+func dryRunLogFor(cfg *Config) *DryRunLog {
+	if cfg == nil || !cfg.Safety.DryRun {
+		return nil
+	}
+	return NewDryRunLog()
+}
+
+// dryRunLogPath returns ~/.goday/dry_run.log
+func dryRunLogPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "dry_run.log"
+	}
+	return filepath.Join(homeDir, ".goday", "dry_run.log")
+}
+
+// Record appends a request that was not sent, with the JSON it would have carried
+func (l *DryRunLog) Record(method, url string, payload interface{}) error {
+	line := fmt.Sprintf("%s DRY RUN %s %s", l.now().Format(time.RFC3339), method, url)
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		line += " " + string(data)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Other goday processes, and goday sync, write to the same file
+	return withFileLock(l.path, func() error {
+		file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = fmt.Fprintln(file, line)
+		return err
+	})
+}
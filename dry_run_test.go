@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGitHubAPIDryRun(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"login":"octocat"}`)
+	}))
+	defer server.Close()
+
+	cfg := &Config{Features: map[string]bool{FeatureWriteActions: true}}
+	cfg.Safety.DryRun = true
+	api := newGitHubAPI(cfg)
+	api.apiURL = server.URL
+	api.dryRun.now = func() time.Time { return time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC) }
+
+	// Reads still go out, so the dashboard shows real data
+	if user, err := api.login(context.Background()); err != nil || user != "octocat" {
+		t.Errorf("Expected reads to be sent, got '%s', %v", user, err)
+	}
+	err := api.do(context.Background(), "POST", "/repos/octocat/api/issues/7/labels", map[string][]string{"labels": {"bug"}}, nil)
+	if err != nil {
+		t.Fatalf("Expected the dry run write to succeed, got %v", err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("Expected only the read to reach GitHub, got %v", requests)
+	}
+
+	data, err := os.ReadFile(dryRunLogPath())
+	if err != nil {
+		t.Fatalf("Expected a dry run log: %v", err)
+	}
+	want := "2026-10-16T09:30:00Z DRY RUN POST " + server.URL + `/repos/octocat/api/issues/7/labels {"labels":["bug"]}`
+	if strings.TrimSpace(string(data)) != want {
+		t.Errorf("Expected '%s', got '%s'", want, data)
+	}
+}
+
+func TestDryRunOff(t *testing.T) {
+	if dryRunLogFor(nil) != nil || dryRunLogFor(&Config{}) != nil {
+		t.Error("Expected writes to be sent unless dry run is on")
+	}
+
+	opts, err := ParseFlags([]string{"--dry-run"})
+	if err != nil {
+		t.Fatalf("ParseFlags failed: %v", err)
+	}
+	cfg := &Config{}
+	opts.Apply(cfg)
+	if !cfg.Safety.DryRun {
+		t.Error("Expected --dry-run to turn on safety.dry_run")
+	}
+}
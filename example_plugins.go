@@ -198,6 +198,8 @@ type CalendarEvent struct {
 	EndTime     time.Time `json:"end"`
 	Location    string    `json:"location"`
 	URL         string    `json:"htmlLink"`
+	Calendar    string    `json:"calendar,omitempty"` // Source calendar label, shown when merging calendars
+	Attendees   []string  `json:"attendees,omitempty"`
 }
 
 // NewCalendarPlugin creates a new calendar plugin
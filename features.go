@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Feature flags gate subsystems that are risky or still experimental, so they can ship
+// off by default and be turned on per user under features in config.yaml
+const (
+	// FeatureWriteActions lets the dependency updates and issue triage panels write to GitHub,
+	// and the time trackers start and stop timers
+	FeatureWriteActions = "write_actions"
+	// FeatureWHOIS lets the Domains tile fall back to WHOIS for top-level domains without RDAP
+	FeatureWHOIS = "whois"
+)
+
+// featureDefaults is whether each known feature is on when features does not set it
+var featureDefaults = map[string]bool{
+	FeatureWriteActions: false,
+	FeatureWHOIS:        false,
+}
+
+// FeatureEnabled reports whether a feature is on, from features or else its default
+func (c *Config) FeatureEnabled(name string) bool {
+	if c != nil {
+		if on, ok := c.Features[name]; ok {
+			return on
+		}
+	}
+	return featureDefaults[name]
+}
+
+// validateFeatures rejects flags GoDay does not know, which are usually typos
+func validateFeatures(features map[string]bool) error {
+	for name := range features {
+		if _, ok := featureDefaults[name]; !ok {
+			return fmt.Errorf("unknown feature %q (expected one of %s)", name, strings.Join(knownFeatureNames(), ", "))
+		}
+	}
+	return nil
+}
+
+// knownFeatureNames returns the names usable under features, sorted
+func knownFeatureNames() []string {
+	var names []string
+	for name := range featureDefaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
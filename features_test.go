@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFeatureEnabled(t *testing.T) {
+	var cfg *Config
+	if cfg.FeatureEnabled(FeatureWriteActions) || cfg.FeatureEnabled(FeatureWHOIS) {
+		t.Error("Expected the risky features off without a config")
+	}
+	cfg = &Config{Features: map[string]bool{FeatureWriteActions: true, FeatureWHOIS: true}}
+	if !cfg.FeatureEnabled(FeatureWriteActions) || !cfg.FeatureEnabled(FeatureWHOIS) {
+		t.Error("Expected features to override the defaults")
+	}
+	if cfg.FeatureEnabled("web") {
+		t.Error("Expected unknown features to be off")
+	}
+}
+
+func TestLoadConfigUnknownFeature(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("features:\n  whios: true\n"), 0644)
+	_, err := LoadConfig(path)
+	if err == nil || !strings.Contains(err.Error(), `unknown feature "whios"`) {
+		t.Errorf("Expected the misspelt feature to be rejected, got %v", err)
+	}
+
+	os.WriteFile(path, []byte("features:\n  whois: true\n"), 0644)
+	cfg, err := LoadConfig(path)
+	if err != nil || !cfg.FeatureEnabled(FeatureWHOIS) {
+		t.Errorf("Expected whois to be on, got %v", err)
+	}
+}
+
+func TestGitHubAPIWriteActionsOff(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Write([]byte(`{"login":"octocat"}`))
+	}))
+	defer server.Close()
+
+	api := newGitHubAPI(&Config{})
+	api.apiURL = server.URL
+	if _, err := api.login(context.Background()); err != nil {
+		t.Errorf("Expected reads to be sent, got %v", err)
+	}
+	err := api.do(context.Background(), "PUT", "/repos/octocat/api/pulls/7/merge", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "features.write_actions") {
+		t.Errorf("Expected the write to be refused, got %v", err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("Expected only the read to reach GitHub, got %v", requests)
+	}
+}
+
+func TestDomainPluginWithoutWHOIS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"services": []}`))
+	}))
+	defer server.Close()
+
+	plugin := NewDomainPlugin()
+	plugin.Initialize(map[string]interface{}{"domains": []string{"example.io"}})
+	plugin.bootstrapURL = server.URL
+	plugin.whoisServer = "127.0.0.1:1"
+
+	data, _ := plugin.Fetch(context.Background())
+	domains := data.([]DomainStatus)
+	if len(domains) != 1 || !strings.Contains(domains[0].Err, "features.whois") {
+		t.Errorf("Expected WHOIS to be skipped while off, got %+v", domains)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startFetch marks widget's fetch stamped with version as in flight, so its tile shows
+// a spinner in the title until the result arrives. It returns the command that turns
+// the spinner, or nil when another fetch already turns it.
+func (m *Model) startFetch(widget string, version uint64) tea.Cmd {
+	var cmd tea.Cmd
+	if len(m.fetching) == 0 {
+		m.fetching = make(map[string]uint64)
+		// Each spin starts from the first frame; ticks of an earlier one are ignored.
+		// Reduced motion and low power show fetchingMarker and never turn it.
+		if !m.reducedMotion() && !m.lowPower(time.Now()) {
+			m.spinner = spinner.New(spinner.WithSpinner(spinner.MiniDot))
+			cmd = m.spinner.Tick
+		}
+	}
+	m.fetching[widget] = version
+	return cmd
+}
+
+// finishFetch clears widget's spinner once the result of its latest fetch arrives,
+// whether data or an error; an older result leaves it turning
+func (m *Model) finishFetch(widget string, version uint64) {
+	if latest, ok := m.fetching[widget]; !ok || version < latest {
+		return
+	}
+	delete(m.fetching, widget)
+	m.refresh.Done(widget)
+}
+
+// fetchSpinner returns the spinner frame for widget's tile title, or fetchingMarker with
+// reduced motion or in low power, empty when no fetch of it is in flight
+func (m Model) fetchSpinner(widget string) string {
+	if _, ok := m.fetching[widget]; !ok {
+		return ""
+	}
+	if m.reducedMotion() || m.lowPower(time.Now()) {
+		return fetchingMarker
+	}
+	return m.spinner.View()
+}
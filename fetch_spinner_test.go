@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newsResultPlugin returns fixed news, or an error
+type newsResultPlugin struct {
+	items []NewsItem
+	err   error
+}
+
+func (np *newsResultPlugin) GetID() string                                  { return "test-news" }
+func (np *newsResultPlugin) GetType() string                                { return "news" }
+func (np *newsResultPlugin) Initialize(config map[string]interface{}) error { return nil }
+func (np *newsResultPlugin) GetMetadata() PluginMetadata                    { return PluginMetadata{Name: "Test News"} }
+func (np *newsResultPlugin) Cleanup() error                                 { return nil }
+
+func (np *newsResultPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	return np.items, np.err
+}
+
+func TestFetchSpinner(t *testing.T) {
+	plugin := &newsResultPlugin{items: []NewsItem{{Title: "Go 1.24 released", Source: "hn"}}}
+	m := benchmarkModel(120, 40)
+	m.pluginManager = NewPluginManager(&PluginConfig{Plugins: map[string]map[string]interface{}{}})
+	m.pluginManager.RegisterPlugin(plugin)
+	m.widgetPlugins = map[string]string{"news": plugin.GetID()}
+	m.versions = NewDataVersions()
+	m.refresh = NewRefreshProgress()
+	m.refresh.Start([]string{"news"})
+
+	// fetch refreshes news and returns the result the background fetch reports
+	fetch := func() newsMsg {
+		model, cmd := m.Update(refreshNowMsg{fetch: fetchNewsCmd{}})
+		m = model.(Model)
+		for _, msg := range immediateMsgs(cmd) {
+			if result, ok := msg.(newsMsg); ok {
+				return result
+			}
+		}
+		t.Fatal("Expected the fetch to report news")
+		return newsMsg{}
+	}
+	apply := func(msg tea.Msg) tea.Cmd {
+		model, cmd := m.Update(msg)
+		m = model.(Model)
+		return cmd
+	}
+
+	result := fetch()
+	view := m.View()
+	if !strings.Contains(view, "Tech News item 1") || !strings.Contains(view, "Tech News (12) "+m.spinner.View()) {
+		t.Fatalf("Expected the news to stay with a spinner in the title while fetched, got:\n%s", view)
+	}
+	if !m.refresh.Running() {
+		t.Error("Expected the refresh to wait for the news")
+	}
+	apply(result)
+	if m.refresh.Running() {
+		t.Error("Expected the news result to finish the refresh")
+	}
+	if view := m.View(); !strings.Contains(view, "Go 1.24 released") || strings.Contains(view, m.spinner.View()) {
+		t.Errorf("Expected the news without the spinner once fetched, got:\n%s", view)
+	}
+	if cmd := apply(m.spinner.Tick()); cmd != nil {
+		t.Error("Expected the spinner to stop once no fetch is in flight")
+	}
+
+	// A fetch asked for while one runs waits for it, and the spinner turns until both report
+	running := fetch()
+	plugin.items, plugin.err = nil, errors.New("connection refused")
+	for _, msg := range immediateMsgs(apply(refreshNowMsg{fetch: fetchNewsCmd{}})) {
+		if _, ok := msg.(newsMsg); ok {
+			t.Fatal("Expected no second news fetch while one runs")
+		}
+	}
+	var queued tea.Msg
+	for _, msg := range immediateMsgs(apply(running)) {
+		if _, ok := msg.(newsMsg); ok {
+			queued = msg
+		}
+	}
+	if queued == nil || m.fetchSpinner("news") == "" {
+		t.Fatal("Expected the waiting fetch to start, with the spinner still turning, once the first reports")
+	}
+	apply(queued)
+	view = m.View()
+	if !strings.Contains(view, "Failed to fetch news") || m.fetchSpinner("news") != "" {
+		t.Errorf("Expected an error to clear the spinner, got:\n%s", view)
+	}
+}
+
+func TestCycleTagRefetchesNews(t *testing.T) {
+	plugin := &newsResultPlugin{items: []NewsItem{{Title: "Go 1.24 released", Source: "hn"}}}
+	m := benchmarkModel(120, 40)
+	m.pluginManager = NewPluginManager(&PluginConfig{Plugins: map[string]map[string]interface{}{}})
+	m.pluginManager.RegisterPlugin(plugin)
+	m.widgetPlugins = map[string]string{"news": plugin.GetID()}
+	m.widgetManager.NewsTags = []string{"golang", "security"}
+	m.versions = NewDataVersions()
+
+	// fetches updates m with msg, and with what it leads to, and counts the news fetched
+	var fetches func(msg tea.Msg) int
+	fetches = func(msg tea.Msg) int {
+		model, cmd := m.Update(msg)
+		m = model.(Model)
+		count := 0
+		for _, msg := range immediateMsgs(cmd) {
+			switch msg.(type) {
+			case newsMsg:
+				model, _ := m.Update(msg)
+				m = model.(Model)
+				count++
+			case refreshNowMsg, fetchNewsCmd:
+				count += fetches(msg)
+			}
+		}
+		return count
+	}
+	if fetches(fetchNewsCmd{}) != 1 {
+		t.Fatal("Expected the scheduled fetch to run")
+	}
+	if fetches(fetchNewsCmd{}) != 0 {
+		t.Error("Expected a second scheduled fetch so soon after to be dropped")
+	}
+	// Changing the tag right after still fetches its news
+	for _, key := range []string{"t", "T"} {
+		if fetches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}) != 1 {
+			t.Errorf("Expected %s to fetch the news for its tag at once", key)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// lockFile does nothing where flock is not available; writes are still atomic
+func lockFile(file *os.File, wait bool) error {
+	return nil
+}
+
+// unlockFile releases a lock taken with lockFile
+func unlockFile(file *os.File) error {
+	return nil
+}
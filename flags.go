@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// CLIOptions holds command-line overrides that are layered on top of config.yaml
+type CLIOptions struct {
+	Location string
+	Widgets  []string
+	TTLs     map[string]string
+	DryRun   bool
+	Instance instanceMode // how to share the profile with a dashboard that is already running
+}
+
+// ttlFlag collects repeatable --ttl widget=duration overrides
+type ttlFlag map[string]string
+
+func (t ttlFlag) String() string {
+	var pairs []string
+	for widget, ttl := range t {
+		pairs = append(pairs, widget+"="+ttl)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (t ttlFlag) Set(value string) error {
+	for _, pair := range strings.Split(value, ",") {
+		widget, ttl, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || widget == "" || ttl == "" {
+			return fmt.Errorf("expected widget=duration, got %q", pair)
+		}
+		t[widget] = ttl
+	}
+	return nil
+}
+
+// ParseFlags parses startup flags such as --location, --widgets and --ttl
+func ParseFlags(args []string) (*CLIOptions, error) {
+	opts := &CLIOptions{TTLs: make(map[string]string)}
+
+	var widgets string
+	fs := flag.NewFlagSet("goday", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&opts.Location, "location", "", "override user.location (e.g. \"Berlin,DE\")")
+	fs.StringVar(&widgets, "widgets", "", "comma-separated widgets to show (e.g. news,calendar)")
+	fs.Var(ttlFlag(opts.TTLs), "ttl", "override a widget refresh interval (e.g. news=300s), repeatable")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "log write actions instead of sending them (safety.dry_run)")
+	attach := fs.Bool("attach", false, "show the running dashboard's data instead of polling again")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+	if *attach {
+		opts.Instance = instanceAttach
+	}
+
+	if widgets != "" {
+		for _, name := range strings.Split(widgets, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			if !isDashboardWidget(name) {
+				return nil, fmt.Errorf("unknown widget %q in --widgets", name)
+			}
+			opts.Widgets = append(opts.Widgets, name)
+		}
+	}
+
+	// Validate TTL overrides up front so typos fail before the TUI starts
+	for widget, ttl := range opts.TTLs {
+		if err := new(Config).SetWidgetTTL(widget, ttl); err != nil {
+			return nil, err
+		}
+	}
+
+	return opts, nil
+}
+
+// Apply layers the overrides on top of a loaded config
+func (o *CLIOptions) Apply(cfg *Config) error {
+	if o == nil || cfg == nil {
+		return nil
+	}
+
+	if o.Location != "" {
+		cfg.User.Location = o.Location
+	}
+	if len(o.Widgets) > 0 {
+		cfg.UI.Widgets = o.Widgets
+	}
+	if o.DryRun {
+		cfg.Safety.DryRun = true
+	}
+	for widget, ttl := range o.TTLs {
+		if err := cfg.SetWidgetTTL(widget, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestParseFlags(t *testing.T) {
+	opts, err := ParseFlags([]string{
+		"--location", "Berlin,DE",
+		"--widgets", "news, Calendar",
+		"--ttl", "news=300s",
+		"--ttl", "weather=15m,traffic=60s",
+	})
+	if err != nil {
+		t.Fatalf("ParseFlags failed: %v", err)
+	}
+
+	if opts.Location != "Berlin,DE" {
+		t.Errorf("Expected location 'Berlin,DE', got '%s'", opts.Location)
+	}
+
+	if len(opts.Widgets) != 2 || opts.Widgets[0] != "news" || opts.Widgets[1] != "calendar" {
+		t.Errorf("Expected widgets [news calendar], got %v", opts.Widgets)
+	}
+
+	if len(opts.TTLs) != 3 || opts.TTLs["weather"] != "15m" {
+		t.Errorf("Expected 3 ttl overrides with weather=15m, got %v", opts.TTLs)
+	}
+}
+
+func TestParseFlagsRejectsInvalidValues(t *testing.T) {
+	tests := [][]string{
+		{"--widgets", "news,unknown"},
+		{"--ttl", "news"},
+		{"--ttl", "news=soon"},
+		{"--ttl", "todos=30s"},
+		{"stray"},
+	}
+
+	for _, args := range tests {
+		if _, err := ParseFlags(args); err == nil {
+			t.Errorf("Expected ParseFlags(%v) to fail", args)
+		}
+	}
+}
+
+func TestCLIOptionsApply(t *testing.T) {
+	cfg := &Config{}
+	cfg.User.Location = "Bengaluru,IN"
+	cfg.Widgets.News.TTL = "600s"
+
+	opts := &CLIOptions{
+		Location: "Berlin,DE",
+		Widgets:  []string{"news"},
+		TTLs:     map[string]string{"news": "300s"},
+	}
+	if err := opts.Apply(cfg); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	if cfg.User.Location != "Berlin,DE" {
+		t.Errorf("Expected location 'Berlin,DE', got '%s'", cfg.User.Location)
+	}
+	if cfg.Widgets.News.TTL != "300s" {
+		t.Errorf("Expected news TTL '300s', got '%s'", cfg.Widgets.News.TTL)
+	}
+	if len(cfg.UI.Widgets) != 1 || cfg.UI.Widgets[0] != "news" {
+		t.Errorf("Expected visible widgets [news], got %v", cfg.UI.Widgets)
+	}
+
+	// Empty options leave the config untouched
+	if err := (&CLIOptions{}).Apply(cfg); err != nil {
+		t.Fatalf("Apply with no overrides failed: %v", err)
+	}
+	if cfg.User.Location != "Berlin,DE" {
+		t.Errorf("Expected location to stay 'Berlin,DE', got '%s'", cfg.User.Location)
+	}
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FXRate is the latest reference rate of a currency pair and its change since the
+// previous business day
+type FXRate struct {
+	Base          string
+	Quote         string
+	Rate          float64
+	Change        float64
+	ChangePercent float64
+	Date          time.Time
+}
+
+// Pair returns the pair as BASE/QUOTE
+func (r FXRate) Pair() string {
+	return r.Base + "/" + r.Quote
+}
+
+// FXPlugin shows exchange rates for configured currency pairs from the keyless
+// Frankfurter API, which publishes European Central Bank reference rates once a day
+type FXPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	pairs       []string
+	apiURL      string
+	now         func() time.Time
+	client      *http.Client
+	lastData    []FXRate
+}
+
+// NewFXPlugin creates a new exchange rates plugin
+func NewFXPlugin() *FXPlugin {
+	return &FXPlugin{
+		id:          "fx",
+		pluginType:  "finance",
+		name:        "Exchange Rates",
+		version:     "1.0.0",
+		description: "Shows currency exchange rates and daily changes from Frankfurter",
+		author:      "GoDay Team",
+		apiURL:      "https://api.frankfurter.app",
+		now:         time.Now,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		lastData:    []FXRate{},
+	}
+}
+
+// GetID returns the plugin ID
+func (fp *FXPlugin) GetID() string {
+	return fp.id
+}
+
+// GetType returns the plugin type
+func (fp *FXPlugin) GetType() string {
+	return fp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (fp *FXPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        fp.name,
+		Version:     fp.version,
+		Description: fp.description,
+		Author:      fp.author,
+		Type:        fp.pluginType,
+		Config: map[string]string{
+			"pairs": strings.Join(fp.pairs, ","),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (fp *FXPlugin) Initialize(config map[string]interface{}) error {
+	fp.pairs = nil
+	for _, pair := range configStringList(config["pairs"]) {
+		fp.pairs = append(fp.pairs, strings.ToUpper(strings.TrimSpace(pair)))
+	}
+	return nil
+}
+
+// splitPair parses a BASE/QUOTE pair such as USD/INR
+func splitPair(pair string) (string, string, error) {
+	base, quote, ok := strings.Cut(pair, "/")
+	if !ok || len(base) != 3 || len(quote) != 3 {
+		return "", "", fmt.Errorf("invalid currency pair %q (expected BASE/QUOTE, e.g. USD/INR)", pair)
+	}
+	return base, quote, nil
+}
+
+// Fetch returns a rate per pair, in configured order. Pairs sharing a base currency
+// are fetched together.
+func (fp *FXPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if len(fp.pairs) == 0 {
+		return fp.lastData, fmt.Errorf("no currency pairs configured (widgets.fx.pairs)")
+	}
+
+	quotesByBase := make(map[string][]string)
+	var bases []string
+	for _, pair := range fp.pairs {
+		base, quote, err := splitPair(pair)
+		if err != nil {
+			return fp.lastData, err
+		}
+		if _, ok := quotesByBase[base]; !ok {
+			bases = append(bases, base)
+		}
+		quotesByBase[base] = append(quotesByBase[base], quote)
+	}
+
+	rates := make(map[string]FXRate)
+	for _, base := range bases {
+		baseRates, err := fp.fetchBase(ctx, base, quotesByBase[base])
+		if err != nil {
+			return fp.lastData, err
+		}
+		for _, rate := range baseRates {
+			rates[rate.Pair()] = rate
+		}
+	}
+
+	var result []FXRate
+	for _, pair := range fp.pairs {
+		rate, ok := rates[pair]
+		if !ok {
+			return fp.lastData, fmt.Errorf("no rate for %s", pair)
+		}
+		result = append(result, rate)
+	}
+
+	fp.lastData = result
+	return result, nil
+}
+
+// fetchBase fetches the last week of rates from base to each quote currency and compares
+// the two latest business days. A week covers weekends and bank holidays.
+func (fp *FXPlugin) fetchBase(ctx context.Context, base string, quotes []string) ([]FXRate, error) {
+	start := fp.now().AddDate(0, 0, -7).Format("2006-01-02")
+	query := url.Values{}
+	query.Set("from", base)
+	query.Set("to", strings.Join(quotes, ","))
+
+	var series struct {
+		Rates map[string]map[string]float64 `json:"rates"`
+	}
+	if err := fp.get(ctx, "/"+start+"..?"+query.Encode(), &series); err != nil {
+		return nil, fmt.Errorf("%s: %w", base, err)
+	}
+
+	var dates []string
+	for date := range series.Rates {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	if len(dates) == 0 {
+		return nil, fmt.Errorf("no %s rates published in the last week", base)
+	}
+	latest := dates[len(dates)-1]
+	date, _ := time.Parse("2006-01-02", latest)
+
+	var rates []FXRate
+	for _, quote := range quotes {
+		rate, ok := series.Rates[latest][quote]
+		if !ok {
+			continue
+		}
+		fxRate := FXRate{Base: base, Quote: quote, Rate: rate, Date: date}
+		if len(dates) > 1 {
+			if previous, ok := series.Rates[dates[len(dates)-2]][quote]; ok && previous != 0 {
+				fxRate.Change = rate - previous
+				fxRate.ChangePercent = (rate - previous) / previous * 100
+			}
+		}
+		rates = append(rates, fxRate)
+	}
+	return rates, nil
+}
+
+// get performs a Frankfurter API request and decodes the JSON response
+func (fp *FXPlugin) get(ctx context.Context, path string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", fp.apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := fp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity {
+		return fmt.Errorf("unknown currency")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Frankfurter API returned status %d", resp.StatusCode)
+	}
+	return json.Unmarshal(body, target)
+}
+
+// Cleanup performs cleanup
+func (fp *FXPlugin) Cleanup() error {
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFXPluginFetch(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path+"?"+r.URL.RawQuery)
+		switch r.URL.Query().Get("from") {
+		case "USD":
+			// Friday and Monday around a weekend
+			fmt.Fprint(w, `{"base":"USD","rates":{
+				"2026-10-12":{"INR":84.0,"EUR":0.92},
+				"2026-10-09":{"INR":83.0,"EUR":0.92}}}`)
+		case "EUR":
+			fmt.Fprint(w, `{"base":"EUR","rates":{"2026-10-12":{"USD":1.0870}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	plugin := NewFXPlugin()
+	plugin.apiURL = server.URL
+	plugin.now = func() time.Time { return time.Date(2026, 10, 13, 9, 0, 0, 0, time.UTC) }
+	if err := plugin.Initialize(map[string]interface{}{"pairs": []string{"usd/inr", "EUR/USD", "USD/EUR"}}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	rates := data.([]FXRate)
+	if len(rates) != 3 || rates[0].Pair() != "USD/INR" || rates[1].Pair() != "EUR/USD" || rates[2].Pair() != "USD/EUR" {
+		t.Fatalf("Expected a rate per pair in configured order, got %+v", rates)
+	}
+	if len(paths) != 2 || !strings.HasPrefix(paths[0], "/2026-10-06..?") {
+		t.Errorf("Expected one request per base over the last week, got %v", paths)
+	}
+	if rates[0].Rate != 84 || rates[0].Change != 1 || fmt.Sprintf("%.2f", rates[0].ChangePercent) != "1.20" {
+		t.Errorf("Expected the change since the previous business day, got %+v", rates[0])
+	}
+
+	wm := NewWidgetManager()
+	wm.UpdateFXWidget(rates)
+	items := wm.Widgets["fx"].Items
+	if items[0].Title != "USD/INR  84.0000" || items[0].Subtitle != "+1.0000 (+1.20%) • 12 Oct" || items[0].Status != "🟢" {
+		t.Errorf("Expected the rate with its daily change, got %+v", items[0])
+	}
+	if items[1].Status != "⚪" || items[1].URL != "https://finance.yahoo.com/quote/EURUSD=X" {
+		t.Errorf("Expected a flat pair without a previous day, got %+v", items[1])
+	}
+
+	plugin.Initialize(map[string]interface{}{"pairs": []string{"USDINR"}})
+	if _, err := plugin.Fetch(context.Background()); err == nil || !strings.Contains(err.Error(), "BASE/QUOTE") {
+		t.Errorf("Expected a malformed pair to fail, got %v", err)
+	}
+	plugin.Initialize(map[string]interface{}{"pairs": []string{"XXX/USD"}})
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected an unknown currency to fail")
+	}
+}
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -20,6 +23,7 @@ type GitCommit struct {
 	Author     string    `json:"author"`
 	Date       time.Time `json:"date"`
 	Repository string    `json:"repository"`
+	Body       string    `json:"body,omitempty"` // the message below the subject line
 }
 
 // GitPullRequest represents a GitHub Pull Request
@@ -34,6 +38,9 @@ type GitPullRequest struct {
 	URL        string    `json:"url"`
 	IsDraft    bool      `json:"draft"`
 	Mergeable  *bool     `json:"mergeable"`
+	Labels     []string  `json:"labels"`
+	IsBot      bool      `json:"is_bot"` // opened by a bot such as dependabot or renovate
+	Body       string    `json:"body,omitempty"`
 }
 
 // LocalGitCommitsPlugin fetches commits from local Git repositories
@@ -108,7 +115,7 @@ func (lgc *LocalGitCommitsPlugin) GetMetadata() PluginMetadata {
 
 // Initialize sets up the plugin with configuration
 func (lgc *LocalGitCommitsPlugin) Initialize(config map[string]interface{}) error {
-	if repos, ok := config["repositories"].([]string); ok {
+	if repos := configStringList(config["repositories"]); len(repos) > 0 {
 		lgc.repositories = repos
 	} else {
 		// Default to current directory and common dev locations
@@ -179,15 +186,16 @@ func (lgc *LocalGitCommitsPlugin) getCommitsFromRepo(ctx context.Context, repoPa
 		return nil, fmt.Errorf("not a git repository: %s", repoPath)
 	}
 
-	// Get recent commits (last 20 commits)
-	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "log", "--oneline", "--format=%H|%s|%an|%ad", "--date=iso", "-20")
+	// Get recent commits (last 20 commits); fields are split by unit separators and commits by
+	// record separators, as subjects and bodies may contain anything else
+	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "log", "--format=%H%x1f%s%x1f%an%x1f%ad%x1f%b%x1e", "--date=iso", "-20")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get git log: %w", err)
 	}
 
 	var commits []GitCommit
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	records := strings.Split(string(output), "\x1e")
 
 	repoName := filepath.Base(repoPath)
 	if repoName == "." {
@@ -196,13 +204,14 @@ func (lgc *LocalGitCommitsPlugin) getCommitsFromRepo(ctx context.Context, repoPa
 		repoName = filepath.Base(pwd)
 	}
 
-	for _, line := range lines {
-		if line == "" {
+	for _, record := range records {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
 			continue
 		}
 
-		parts := strings.Split(line, "|")
-		if len(parts) != 4 {
+		parts := strings.Split(record, "\x1f")
+		if len(parts) != 5 {
 			continue
 		}
 
@@ -226,6 +235,7 @@ func (lgc *LocalGitCommitsPlugin) getCommitsFromRepo(ctx context.Context, repoPa
 			Author:     author,
 			Date:       date,
 			Repository: repoName,
+			Body:       strings.TrimSpace(parts[4]),
 		})
 	}
 
@@ -247,10 +257,35 @@ type GitHubPRsPlugin struct {
 	author      string
 	githubToken string
 	githubUser  string
+	involvement string   // search qualifier relating PRs to the user, e.g. author or involves
+	orgs        []string // limit results to these orgs; empty searches everywhere
+	maxResults  int
+	hideDrafts  bool
+	hideWIP     bool
+	wipLabels   []string
+	hideBots    bool
+	apiURL      string
 	client      *http.Client
 	lastData    []GitPullRequest
+	rateLimitTracker
 }
 
+const (
+	// githubSearchPageSize is the largest page GitHub search returns
+	githubSearchPageSize = 100
+	// githubSearchMaxResults is how far GitHub search results can be paged
+	githubSearchMaxResults = 1000
+)
+
+// githubInvolvements are the search qualifiers accepted for the involvement setting
+var githubInvolvements = []string{"author", "involves", "review-requested", "assignee", "mentions"}
+
+// defaultWIPLabels mark PRs that are not ready for review
+var defaultWIPLabels = []string{"wip", "do-not-review", "do not review"}
+
+// knownBotLogins are bots that open PRs under a plain user account
+var knownBotLogins = map[string]bool{"dependabot": true, "renovate": true, "renovate-bot": true, "github-actions": true}
+
 // NewGitHubPRsPlugin creates a new GitHub PRs plugin
 func NewGitHubPRsPlugin() *GitHubPRsPlugin {
 	// Try to get GitHub token from environment
@@ -278,6 +313,10 @@ func NewGitHubPRsPlugin() *GitHubPRsPlugin {
 		author:      "GoDay Team",
 		githubToken: githubToken,
 		githubUser:  githubUser,
+		involvement: "author",
+		maxResults:  10,
+		wipLabels:   defaultWIPLabels,
+		apiURL:      "https://api.github.com",
 		client:      &http.Client{Timeout: 15 * time.Second},
 		lastData:    []GitPullRequest{},
 	}
@@ -304,6 +343,9 @@ func (gpr *GitHubPRsPlugin) GetMetadata() PluginMetadata {
 		Config: map[string]string{
 			"github_user":      gpr.githubUser,
 			"has_github_token": fmt.Sprintf("%t", gpr.githubToken != ""),
+			"involvement":      gpr.involvement,
+			"orgs":             strings.Join(gpr.orgs, ","),
+			"max_results":      strconv.Itoa(gpr.maxResults),
 		},
 	}
 }
@@ -316,23 +358,177 @@ func (gpr *GitHubPRsPlugin) Initialize(config map[string]interface{}) error {
 	if user, ok := config["github_user"].(string); ok && user != "" {
 		gpr.githubUser = user
 	}
+	if involvement, ok := config["involvement"].(string); ok && involvement != "" {
+		valid := false
+		for _, known := range githubInvolvements {
+			valid = valid || involvement == known
+		}
+		if !valid {
+			return fmt.Errorf("invalid involvement %q (expected one of %s)", involvement, strings.Join(githubInvolvements, ", "))
+		}
+		gpr.involvement = involvement
+	}
+	gpr.orgs = configStringList(config["orgs"])
+	if maxResults, ok := config["max_results"].(int); ok && maxResults > 0 {
+		gpr.maxResults = maxResults
+	}
+	if hideDrafts, ok := config["hide_drafts"].(bool); ok {
+		gpr.hideDrafts = hideDrafts
+	}
+	if hideWIP, ok := config["hide_wip"].(bool); ok {
+		gpr.hideWIP = hideWIP
+	}
+	if labels := configStringList(config["wip_labels"]); labels != nil {
+		gpr.wipLabels = labels
+	}
+	if bots, ok := config["bots"].(string); ok {
+		gpr.hideBots = bots == "hide"
+	}
 	return nil
 }
 
-// Fetch retrieves Pull Requests from GitHub
+// Fetch retrieves open Pull Requests from GitHub search, following pages up to maxResults
 func (gpr *GitHubPRsPlugin) Fetch(ctx context.Context) (interface{}, error) {
-	if gpr.githubUser == "" {
-		return gpr.lastData, fmt.Errorf("GitHub user not configured")
+	query, err := gpr.searchQuery()
+	if err != nil {
+		return gpr.lastData, err
 	}
 
-	// Search for PRs created by the user
-	url := fmt.Sprintf("https://api.github.com/search/issues?q=type:pr+author:%s+is:open&sort=updated&per_page=10", gpr.githubUser)
+	perPage := gpr.maxResults
+	if perPage > githubSearchPageSize {
+		perPage = githubSearchPageSize
+	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	first, total, err := gpr.searchPage(ctx, query, 1, perPage)
 	if err != nil {
 		return gpr.lastData, err
 	}
 
+	// The first page tells us how many results exist, so the rest can be fetched together
+	wanted := gpr.maxResults
+	if total < wanted {
+		wanted = total
+	}
+	if wanted > githubSearchMaxResults {
+		wanted = githubSearchMaxResults
+	}
+	pageCount := (wanted + perPage - 1) / perPage
+
+	pages := make([][]GitPullRequest, pageCount)
+	if pageCount > 0 {
+		pages[0] = first
+	}
+	errs := make([]error, pageCount)
+	var wg sync.WaitGroup
+	for page := 2; page <= pageCount; page++ {
+		wg.Add(1)
+		go func(page int) {
+			defer wg.Done()
+			pages[page-1], _, errs[page-1] = gpr.searchPage(ctx, query, page, perPage)
+		}(page)
+	}
+	wg.Wait()
+
+	// Merge in page order; results can shift between pages while they are fetched
+	prs := make([]GitPullRequest, 0, wanted)
+	seen := make(map[string]bool, wanted)
+	for i, page := range pages {
+		if errs[i] != nil {
+			return gpr.lastData, fmt.Errorf("page %d: %w", i+1, errs[i])
+		}
+		for _, pr := range page {
+			if seen[pr.URL] || len(prs) >= gpr.maxResults || !gpr.keep(pr) {
+				continue
+			}
+			seen[pr.URL] = true
+			prs = append(prs, pr)
+		}
+	}
+
+	gpr.lastData = prs
+	return prs, nil
+}
+
+// searchQuery builds the issue search query for the configured involvement and orgs
+func (gpr *GitHubPRsPlugin) searchQuery() (string, error) {
+	// @me resolves to the token's user, which is more reliable than a Git user name
+	who := gpr.githubUser
+	if gpr.githubToken != "" {
+		who = "@me"
+	}
+	if who == "" {
+		return "", fmt.Errorf("GitHub user not configured")
+	}
+
+	qualifiers := []string{"type:pr", "is:open", gpr.involvement + ":" + who}
+	for _, org := range gpr.orgs {
+		qualifiers = append(qualifiers, "org:"+org)
+	}
+	// Excluding in the query keeps hidden PRs from using up max_results
+	if gpr.hideDrafts {
+		qualifiers = append(qualifiers, "draft:false")
+	}
+	if gpr.hideWIP {
+		for _, label := range gpr.wipLabels {
+			qualifiers = append(qualifiers, fmt.Sprintf("-label:%q", label))
+		}
+	}
+	return strings.Join(qualifiers, " "), nil
+}
+
+// keep reports whether a PR passes the draft, WIP and bot filters
+func (gpr *GitHubPRsPlugin) keep(pr GitPullRequest) bool {
+	if gpr.hideDrafts && pr.IsDraft {
+		return false
+	}
+	if gpr.hideBots && pr.IsBot {
+		return false
+	}
+	if gpr.hideWIP {
+		if isWIPTitle(pr.Title) {
+			return false
+		}
+		for _, label := range pr.Labels {
+			for _, wip := range gpr.wipLabels {
+				if strings.EqualFold(label, wip) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// isWIPTitle reports whether a PR title marks it as work in progress, e.g. "WIP: ..." or "[WIP] ..."
+func isWIPTitle(title string) bool {
+	title = strings.ToLower(strings.TrimSpace(title))
+	for _, prefix := range []string{"[wip]", "(wip)", "wip:", "wip ", "draft:", "[draft]"} {
+		if strings.HasPrefix(title, prefix) {
+			return true
+		}
+	}
+	return title == "wip"
+}
+
+// isBotAuthor reports whether a PR author is a bot account
+func isBotAuthor(login, userType string) bool {
+	return userType == "Bot" || strings.HasSuffix(login, "[bot]") || knownBotLogins[strings.ToLower(login)]
+}
+
+// searchPage fetches one page of search results and the total result count
+func (gpr *GitHubPRsPlugin) searchPage(ctx context.Context, query string, page, perPage int) ([]GitPullRequest, int, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("sort", "updated")
+	params.Set("order", "desc")
+	params.Set("per_page", strconv.Itoa(perPage))
+	params.Set("page", strconv.Itoa(page))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", gpr.apiURL+"/search/issues?"+params.Encode(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Add GitHub token if available
 	if gpr.githubToken != "" {
 		req.Header.Set("Authorization", "token "+gpr.githubToken)
@@ -341,39 +537,55 @@ func (gpr *GitHubPRsPlugin) Fetch(ctx context.Context) (interface{}, error) {
 
 	resp, err := gpr.client.Do(req)
 	if err != nil {
-		return gpr.lastData, err
+		return nil, 0, err
 	}
 	defer resp.Body.Close()
+	gpr.observeRateLimit(resp)
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return gpr.lastData, err
+		return nil, 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		json.Unmarshal(body, &apiErr)
+		return nil, 0, fmt.Errorf("GitHub search returned status %d: %s", resp.StatusCode, apiErr.Message)
 	}
 
 	var searchResult struct {
-		Items []struct {
+		TotalCount int `json:"total_count"`
+		Items      []struct {
 			Number int    `json:"number"`
 			Title  string `json:"title"`
+			Body   string `json:"body"`
 			State  string `json:"state"`
 			User   struct {
 				Login string `json:"login"`
+				Type  string `json:"type"`
 			} `json:"user"`
-			CreatedAt  time.Time `json:"created_at"`
-			UpdatedAt  time.Time `json:"updated_at"`
-			HTMLURL    string    `json:"html_url"`
-			Draft      bool      `json:"draft"`
-			Repository struct {
+			Labels []struct {
 				Name string `json:"name"`
-			} `json:"repository"`
+			} `json:"labels"`
+			CreatedAt     time.Time `json:"created_at"`
+			UpdatedAt     time.Time `json:"updated_at"`
+			HTMLURL       string    `json:"html_url"`
+			Draft         bool      `json:"draft"`
+			RepositoryURL string    `json:"repository_url"`
 		} `json:"items"`
 	}
 
 	if err := json.Unmarshal(body, &searchResult); err != nil {
-		return gpr.lastData, err
+		return nil, 0, err
 	}
 
-	var prs []GitPullRequest
+	prs := make([]GitPullRequest, 0, len(searchResult.Items))
 	for _, item := range searchResult.Items {
+		labels := make([]string, 0, len(item.Labels))
+		for _, label := range item.Labels {
+			labels = append(labels, label.Name)
+		}
 		prs = append(prs, GitPullRequest{
 			Number:     item.Number,
 			Title:      item.Title,
@@ -381,14 +593,24 @@ func (gpr *GitHubPRsPlugin) Fetch(ctx context.Context) (interface{}, error) {
 			Author:     item.User.Login,
 			CreatedAt:  item.CreatedAt,
 			UpdatedAt:  item.UpdatedAt,
-			Repository: item.Repository.Name,
+			Repository: repositoryFromURL(item.RepositoryURL),
 			URL:        item.HTMLURL,
 			IsDraft:    item.Draft,
+			Labels:     labels,
+			IsBot:      isBotAuthor(item.User.Login, item.User.Type),
+			Body:       item.Body,
 		})
 	}
+	return prs, searchResult.TotalCount, nil
+}
 
-	gpr.lastData = prs
-	return prs, nil
+// repositoryFromURL turns https://api.github.com/repos/owner/name into owner/name
+func repositoryFromURL(repositoryURL string) string {
+	_, repo, found := strings.Cut(repositoryURL, "/repos/")
+	if !found {
+		return ""
+	}
+	return repo
 }
 
 // Cleanup performs cleanup
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// githubAPI makes authenticated GitHub REST calls on behalf of the user. It shares the
+// github-prs plugin's token, user and orgs; the token falls back to GITHUB_TOKEN/GH_TOKEN.
+type githubAPI struct {
+	apiURL string
+	token  string
+	user   string
+	orgs   []string
+	client *http.Client
+	dryRun *DryRunLog // set when write requests are only logged
+	writes bool       // features.write_actions; without it only GETs are sent
+}
+
+// newGitHubAPI reads the GitHub settings from config
+func newGitHubAPI(cfg *Config) githubAPI {
+	api := githubAPI{
+		apiURL: "https://api.github.com",
+		token:  os.Getenv("GITHUB_TOKEN"),
+		client: &http.Client{Timeout: 15 * time.Second},
+		dryRun: dryRunLogFor(cfg),
+		writes: cfg.FeatureEnabled(FeatureWriteActions),
+	}
+	if api.token == "" {
+		api.token = os.Getenv("GH_TOKEN")
+	}
+
+	if cfg != nil {
+		settings := cfg.Plugins["github-prs"]
+		if token, ok := settings["github_token"].(string); ok && token != "" {
+			api.token = token
+		}
+		if user, ok := settings["github_user"].(string); ok {
+			api.user = user
+		}
+		api.orgs = configStringList(settings["orgs"])
+	}
+	return api
+}
+
+// login returns the configured GitHub user, looking it up from the token if needed
+func (g *githubAPI) login(ctx context.Context) (string, error) {
+	if g.user == "" {
+		var me struct {
+			Login string `json:"login"`
+		}
+		if err := g.do(ctx, "GET", "/user", nil, &me); err != nil {
+			return "", err
+		}
+		g.user = me.Login
+	}
+	return g.user, nil
+}
+
+// ownerQualifiers returns search qualifiers for repos owned by the user or the
+// configured orgs. GitHub ORs repeated owner qualifiers.
+func (g *githubAPI) ownerQualifiers(ctx context.Context) ([]string, error) {
+	user, err := g.login(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	qualifiers := []string{"user:" + user}
+	for _, org := range g.orgs {
+		qualifiers = append(qualifiers, "org:"+org)
+	}
+	return qualifiers, nil
+}
+
+// do sends an authenticated GitHub API request and decodes the JSON response into target, if given.
+// In dry run mode anything but a GET is logged instead and reported as done.
+func (g *githubAPI) do(ctx context.Context, method, path string, payload, target interface{}) error {
+	if !g.writes && method != "GET" {
+		return fmt.Errorf("write actions are turned off (features.%s)", FeatureWriteActions)
+	}
+	if g.dryRun != nil && method != "GET" {
+		return g.dryRun.Record(method, g.apiURL+path, payload)
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, g.apiURL+path, body)
+	if err != nil {
+		return err
+	}
+	// Public data such as releases can be read without a token, at a lower rate limit
+	if g.token != "" {
+		req.Header.Set("Authorization", "token "+g.token)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		json.Unmarshal(data, &apiErr)
+		if apiErr.Message != "" {
+			return fmt.Errorf("GitHub returned status %d: %s", resp.StatusCode, apiErr.Message)
+		}
+		return fmt.Errorf("GitHub returned status %d", resp.StatusCode)
+	}
+	if target == nil {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestGitHubPRsPluginPaginates(t *testing.T) {
+	const total = 250
+	var mu sync.Mutex
+	var queries []string
+	pagesRequested := make(map[int]bool)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+
+		mu.Lock()
+		queries = append(queries, r.URL.Query().Get("q"))
+		pagesRequested[page] = true
+		mu.Unlock()
+
+		w.Header().Set("X-RateLimit-Limit", "30")
+		w.Header().Set("X-RateLimit-Remaining", "27")
+		var items []map[string]interface{}
+		for i := (page - 1) * perPage; i < page*perPage && i < total; i++ {
+			// Page 2 repeats the last PR of page 1, as happens when results shift
+			number := i
+			if page == 2 && i == perPage {
+				number = perPage - 1
+			}
+			items = append(items, map[string]interface{}{
+				"number":         number,
+				"title":          fmt.Sprintf("PR %d", number),
+				"state":          "open",
+				"html_url":       fmt.Sprintf("https://github.com/acme/api/pull/%d", number),
+				"repository_url": "https://api.github.com/repos/acme/api",
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"total_count": total, "items": items})
+	}))
+	defer server.Close()
+
+	plugin := NewGitHubPRsPlugin()
+	plugin.apiURL = server.URL
+	err := plugin.Initialize(map[string]interface{}{
+		"github_token": "token",
+		"involvement":  "involves",
+		"orgs":         []interface{}{"acme", "widgets"},
+		"max_results":  230,
+	})
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	prs := data.([]GitPullRequest)
+
+	if len(pagesRequested) != 3 || !pagesRequested[1] || !pagesRequested[2] || !pagesRequested[3] {
+		t.Errorf("Expected pages 1-3 to be requested, got %v", pagesRequested)
+	}
+	for _, query := range queries {
+		if query != "type:pr is:open involves:@me org:acme org:widgets" {
+			t.Errorf("Unexpected search query '%s'", query)
+		}
+	}
+
+	if len(prs) != 230 {
+		t.Fatalf("Expected results to be capped at 230, got %d", len(prs))
+	}
+	seen := make(map[string]bool)
+	for _, pr := range prs {
+		if seen[pr.URL] {
+			t.Errorf("Expected duplicate %s to be merged", pr.URL)
+		}
+		seen[pr.URL] = true
+	}
+	if prs[0].Repository != "acme/api" {
+		t.Errorf("Expected repository 'acme/api', got '%s'", prs[0].Repository)
+	}
+	if rl, ok := plugin.RateLimit(); !ok || rl.Remaining != 27 {
+		t.Errorf("Expected the search rate limit to be tracked, got %+v", rl)
+	}
+}
+
+func TestGitHubPRsPluginQuery(t *testing.T) {
+	plugin := NewGitHubPRsPlugin()
+	plugin.githubToken = ""
+	plugin.githubUser = "octocat"
+
+	query, err := plugin.searchQuery()
+	if err != nil || query != "type:pr is:open author:octocat" {
+		t.Errorf("Expected the default author query, got '%s' (%v)", query, err)
+	}
+
+	plugin.githubUser = ""
+	if _, err := plugin.searchQuery(); err == nil {
+		t.Error("Expected an error without a user or token")
+	}
+
+	if err := plugin.Initialize(map[string]interface{}{"involvement": "stars"}); err == nil {
+		t.Error("Expected an unknown involvement to fail")
+	}
+}
+
+func TestGitHubPRsPluginReportsSearchErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"message":"Validation Failed"}`)
+	}))
+	defer server.Close()
+
+	plugin := NewGitHubPRsPlugin()
+	plugin.apiURL = server.URL
+	plugin.githubToken = "token"
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected a failed search to return an error")
+	}
+}
+
+func TestGitHubPRsPluginFilters(t *testing.T) {
+	plugin := NewGitHubPRsPlugin()
+	plugin.githubToken = "token"
+	err := plugin.Initialize(map[string]interface{}{
+		"hide_drafts": true,
+		"hide_wip":    true,
+		"wip_labels":  []interface{}{"wip", "do not review"},
+		"bots":        "hide",
+	})
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	query, _ := plugin.searchQuery()
+	expected := `type:pr is:open author:@me draft:false -label:"wip" -label:"do not review"`
+	if query != expected {
+		t.Errorf("Expected query '%s', got '%s'", expected, query)
+	}
+
+	tests := []struct {
+		pr   GitPullRequest
+		keep bool
+	}{
+		{GitPullRequest{Title: "Add retries"}, true},
+		{GitPullRequest{Title: "Add retries", IsDraft: true}, false},
+		{GitPullRequest{Title: "[WIP] Add retries"}, false},
+		{GitPullRequest{Title: "wip: retries"}, false},
+		{GitPullRequest{Title: "Wipe caches on logout"}, true},
+		{GitPullRequest{Title: "Add retries", Labels: []string{"Do Not Review"}}, false},
+		{GitPullRequest{Title: "Bump golang.org/x/net", IsBot: true}, false},
+	}
+	for _, tt := range tests {
+		if got := plugin.keep(tt.pr); got != tt.keep {
+			t.Errorf("keep(%q, draft=%t, labels=%v, bot=%t) = %t, expected %t", tt.pr.Title, tt.pr.IsDraft, tt.pr.Labels, tt.pr.IsBot, got, tt.keep)
+		}
+	}
+
+	if !isBotAuthor("dependabot[bot]", "Bot") || !isBotAuthor("renovate", "User") || isBotAuthor("octocat", "User") {
+		t.Error("Expected dependabot and renovate to be detected as bots, and octocat not")
+	}
+}
+
+func TestUpdateGitHubPRsWidgetGroupsBots(t *testing.T) {
+	wm := NewWidgetManager()
+	cfg := &Config{}
+	cfg.Widgets.PRs.Bots = "group"
+	wm.InitializeWidgets(cfg)
+
+	wm.UpdateGitHubPRsWidget([]GitPullRequest{
+		{Title: "Bump golang.org/x/net", IsBot: true, URL: "https://github.com/acme/api/pull/1"},
+		{Title: "Add retries", URL: "https://github.com/acme/api/pull/2"},
+		{Title: "Update module github.com/foo/bar", IsBot: true, URL: "https://github.com/acme/api/pull/3"},
+	})
+
+	widget := wm.Widgets["prs"]
+	if len(widget.Items) != 2 || widget.Count != 3 {
+		t.Fatalf("Expected one PR plus a collapsed bots header for 3 PRs, got %d items, count %d", len(widget.Items), widget.Count)
+	}
+	if widget.Items[1].Title != botPRsGroupTitle+" (2)" || widget.Items[1].URL != "" {
+		t.Errorf("Expected a '%s (2)' header without a URL, got %+v", botPRsGroupTitle, widget.Items[1])
+	}
+
+	wm.ToggleBotPRs()
+	if items := wm.Widgets["prs"].Items; len(items) != 4 || items[1].Status != "▾" || items[2].Title != "  Bump golang.org/x/net" {
+		t.Errorf("Expected the bots section to expand below its header, got %+v", items)
+	}
+
+	wm.ToggleBotPRs()
+	if items := wm.Widgets["prs"].Items; len(items) != 2 {
+		t.Errorf("Expected the bots section to collapse again, got %d items", len(items))
+	}
+}
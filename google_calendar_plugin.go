@@ -9,6 +9,9 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -31,6 +34,7 @@ type GoogleCalendarPlugin struct {
 	tokenFile       string
 	maxEvents       int
 	daysAhead       int
+	calendars       []string
 
 	// Internal state
 	config      *oauth2.Config
@@ -51,6 +55,7 @@ type GoogleCalendarEvent struct {
 	URL         string    `json:"htmlLink"`
 	Status      string    `json:"status"`
 	Attendees   []string  `json:"attendees"`
+	Calendar    string    `json:"calendar"` // Short label of the source calendar when several are configured
 }
 
 // NewGoogleCalendarPlugin creates a new Google Calendar plugin
@@ -64,6 +69,7 @@ func NewGoogleCalendarPlugin() *GoogleCalendarPlugin {
 		author:      "GoDay Team",
 		maxEvents:   10,
 		daysAhead:   7,
+		calendars:   []string{"primary"},
 		lastData:    []GoogleCalendarEvent{},
 	}
 }
@@ -96,6 +102,9 @@ func (gcp *GoogleCalendarPlugin) Initialize(config map[string]interface{}) error
 	if daysAhead, ok := config["days_ahead"].(int); ok {
 		gcp.daysAhead = daysAhead
 	}
+	if calendars := configStringList(config["calendars"]); len(calendars) > 0 {
+		gcp.calendars = calendars
+	}
 
 	// Initialize OAuth2 configuration - don't fail if credentials are missing
 	if err := gcp.initializeOAuth(); err != nil {
@@ -199,12 +208,14 @@ func (gcp *GoogleCalendarPlugin) tokenFromFile() (*oauth2.Token, error) {
 // saveToken saves a token to a file path
 func (gcp *GoogleCalendarPlugin) saveToken(token *oauth2.Token) {
 	fmt.Printf("Saving credential file to: %s\n", gcp.tokenFile)
-	f, err := os.OpenFile(gcp.tokenFile, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	data, err := json.Marshal(token)
 	if err != nil {
 		log.Fatalf("Unable to cache oauth token: %v", err)
 	}
-	defer f.Close()
-	json.NewEncoder(f).Encode(token)
+	// A crash mid-write would otherwise leave a token file that sends the user back through OAuth
+	if err := writeFileAtomic(gcp.tokenFile, data, 0600); err != nil {
+		log.Fatalf("Unable to cache oauth token: %v", err)
+	}
 }
 
 func (gcp *GoogleCalendarPlugin) Fetch(ctx context.Context) (interface{}, error) {
@@ -225,8 +236,48 @@ func (gcp *GoogleCalendarPlugin) Fetch(ctx context.Context) (interface{}, error)
 	timeMin := now.Format(time.RFC3339)
 	timeMax := now.AddDate(0, 0, gcp.daysAhead).Format(time.RFC3339)
 
-	// Fetch events from primary calendar
-	events, err := gcp.service.Events.List("primary").
+	// Fetch all configured calendars concurrently
+	results := make([][]GoogleCalendarEvent, len(gcp.calendars))
+	errs := make([]error, len(gcp.calendars))
+	var wg sync.WaitGroup
+	for i, calendarID := range gcp.calendars {
+		wg.Add(1)
+		go func(i int, calendarID string) {
+			defer wg.Done()
+			results[i], errs[i] = gcp.fetchCalendar(ctx, calendarID, timeMin, timeMax)
+		}(i, calendarID)
+	}
+	wg.Wait()
+
+	// Merge the calendars, failing only if none could be read
+	var calendarEvents []GoogleCalendarEvent
+	var failures []string
+	for i, events := range results {
+		if errs[i] != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", gcp.calendars[i], errs[i]))
+			continue
+		}
+		calendarEvents = append(calendarEvents, events...)
+	}
+	if len(failures) == len(gcp.calendars) {
+		return nil, fmt.Errorf("unable to retrieve user's events: %s", strings.Join(failures, "; "))
+	}
+
+	sort.SliceStable(calendarEvents, func(i, j int) bool {
+		return calendarEvents[i].StartTime.Before(calendarEvents[j].StartTime)
+	})
+	if len(calendarEvents) > gcp.maxEvents {
+		calendarEvents = calendarEvents[:gcp.maxEvents]
+	}
+
+	gcp.lastData = calendarEvents
+	return calendarEvents, nil
+}
+
+// fetchCalendar retrieves the events of a single calendar between timeMin and timeMax
+func (gcp *GoogleCalendarPlugin) fetchCalendar(ctx context.Context, calendarID, timeMin, timeMax string) ([]GoogleCalendarEvent, error) {
+	events, err := gcp.service.Events.List(calendarID).
+		Context(ctx).
 		ShowDeleted(false).
 		SingleEvents(true).
 		TimeMin(timeMin).
@@ -236,7 +287,13 @@ func (gcp *GoogleCalendarPlugin) Fetch(ctx context.Context) (interface{}, error)
 		Do()
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve user's events: %w", err)
+		return nil, err
+	}
+
+	// Only label events when they come from more than one calendar
+	var label string
+	if len(gcp.calendars) > 1 {
+		label = calendarLabel(calendarID, events.Summary)
 	}
 
 	// Convert to our GoogleCalendarEvent format
@@ -249,6 +306,14 @@ func (gcp *GoogleCalendarPlugin) Fetch(ctx context.Context) (interface{}, error)
 			Location:    item.Location,
 			URL:         item.HtmlLink,
 			Status:      item.Status,
+			Calendar:    label,
+		}
+		for _, attendee := range item.Attendees {
+			if attendee.DisplayName != "" {
+				event.Attendees = append(event.Attendees, attendee.DisplayName)
+			} else if attendee.Email != "" {
+				event.Attendees = append(event.Attendees, attendee.Email)
+			}
 		}
 
 		// Parse start time
@@ -283,10 +348,25 @@ func (gcp *GoogleCalendarPlugin) Fetch(ctx context.Context) (interface{}, error)
 		calendarEvents = append(calendarEvents, event)
 	}
 
-	gcp.lastData = calendarEvents
 	return calendarEvents, nil
 }
 
+// calendarLabel returns a short label for a calendar, preferring its title over its ID
+func calendarLabel(calendarID, title string) string {
+	label := title
+	if label == "" {
+		label = calendarID
+	}
+	// team@group.calendar.google.com -> team
+	if at := strings.Index(label, "@"); at > 0 {
+		label = label[:at]
+	}
+	if runes := []rune(label); len(runes) > 12 {
+		label = string(runes[:11]) + "…"
+	}
+	return label
+}
+
 func (gcp *GoogleCalendarPlugin) GetMetadata() PluginMetadata {
 	return PluginMetadata{
 		Name:        gcp.name,
@@ -299,6 +379,7 @@ func (gcp *GoogleCalendarPlugin) GetMetadata() PluginMetadata {
 			"token_file":       "Path to store OAuth2 tokens",
 			"max_events":       "Maximum number of events to fetch (default: 10)",
 			"days_ahead":       "Number of days ahead to fetch events (default: 7)",
+			"calendars":        "Calendar IDs to fetch and merge (default: [primary])",
 		},
 	}
 }
@@ -315,8 +396,6 @@ func (gcp *GoogleCalendarPlugin) GetLastData() []GoogleCalendarEvent {
 
 // FormatEventsForDisplay formats calendar events for display in the widget
 func (gcp *GoogleCalendarPlugin) FormatEventsForDisplay() []WidgetItem {
-	var items []WidgetItem
-
 	// Handle setup case
 	if !gcp.initialized && len(gcp.lastData) > 0 && gcp.lastData[0].ID == "setup" {
 		return []WidgetItem{
@@ -338,70 +417,26 @@ func (gcp *GoogleCalendarPlugin) FormatEventsForDisplay() []WidgetItem {
 		}
 	}
 
-	now := time.Now()
-	today := now.Format("2006-01-02")
+	return formatCalendarItems(gcp.Events(), time.Now())
+}
 
+// Events returns the last fetched events
+func (gcp *GoogleCalendarPlugin) Events() []CalendarEvent {
+	events := make([]CalendarEvent, 0, len(gcp.lastData))
 	for _, event := range gcp.lastData {
-		// Skip past events (except for current ongoing events)
-		if event.EndTime.Before(now) {
-			continue
-		}
-
-		// Format time display
-		var timeStr string
-		eventDate := event.StartTime.Format("2006-01-02")
-
-		if eventDate == today {
-			// Today's events - show time only
-			if event.StartTime.Format("15:04") == event.EndTime.Format("15:04") {
-				// All-day event
-				timeStr = "All day"
-			} else {
-				timeStr = event.StartTime.Format("15:04")
-				if !event.EndTime.IsZero() {
-					timeStr += "-" + event.EndTime.Format("15:04")
-				}
-			}
-		} else {
-			// Future events - show date and time
-			timeStr = event.StartTime.Format("Jan 2")
-			if event.StartTime.Format("15:04") != "00:00" {
-				timeStr += " " + event.StartTime.Format("15:04")
-			}
-		}
-
-		// Create status indicator
-		var status string
-		if event.StartTime.Before(now) && event.EndTime.After(now) {
-			status = "🔴" // Currently happening
-		} else if event.StartTime.Sub(now) < 30*time.Minute {
-			status = "🟡" // Starting soon
-		} else {
-			status = "🟢" // Future event
-		}
-
-		items = append(items, WidgetItem{
-			Title:    event.Title,
-			Subtitle: timeStr,
-			Status:   status,
-			URL:      event.URL,
-		})
-
-		// Limit to reasonable number for display
-		if len(items) >= 5 {
-			break
-		}
-	}
-
-	if len(items) == 0 {
-		items = append(items, WidgetItem{
-			Title:    "No upcoming events",
-			Subtitle: "Your calendar is clear",
-			Status:   "📅",
+		events = append(events, CalendarEvent{
+			ID:          event.ID,
+			Title:       event.Title,
+			Description: event.Description,
+			StartTime:   event.StartTime,
+			EndTime:     event.EndTime,
+			Location:    event.Location,
+			URL:         event.URL,
+			Calendar:    event.Calendar,
+			Attendees:   event.Attendees,
 		})
 	}
-
-	return items
+	return events
 }
 
 // SetupOAuth performs the OAuth flow for calendar setup
@@ -1,8 +1,16 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
 )
 
 func TestGoogleCalendarPlugin(t *testing.T) {
@@ -99,3 +107,60 @@ func TestCalendarWidgetUpdate(t *testing.T) {
 		t.Errorf("Expected calendar item title 'Test Event', got '%s'", wm.Widgets["calendar"].Items[0].Title)
 	}
 }
+
+func TestGoogleCalendarMultipleCalendars(t *testing.T) {
+	now := time.Now()
+	soon := now.Add(time.Hour).Format(time.RFC3339)
+	later := now.Add(3 * time.Hour).Format(time.RFC3339)
+	end := now.Add(4 * time.Hour).Format(time.RFC3339)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/calendars/primary/"):
+			fmt.Fprintf(w, `{"summary": "me@example.com", "items": [{"id": "p1", "summary": "Later", "start": {"dateTime": %q}, "end": {"dateTime": %q}}]}`, later, end)
+		case strings.Contains(r.URL.Path, "/calendars/team@group.calendar.google.com/"):
+			fmt.Fprintf(w, `{"summary": "Platform Team Calendar", "items": [{"id": "t1", "summary": "Soon", "start": {"dateTime": %q}, "end": {"dateTime": %q}}]}`, soon, later)
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	service, err := calendar.NewService(context.Background(), option.WithHTTPClient(server.Client()), option.WithEndpoint(server.URL+"/"))
+	if err != nil {
+		t.Fatalf("Failed to create calendar service: %v", err)
+	}
+
+	plugin := NewGoogleCalendarPlugin()
+	plugin.calendars = []string{"primary", "team@group.calendar.google.com", "missing"}
+	plugin.service = service
+	plugin.initialized = true
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+
+	events := data.([]GoogleCalendarEvent)
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 merged events, got %d", len(events))
+	}
+	if events[0].ID != "t1" || events[1].ID != "p1" {
+		t.Errorf("Expected events sorted by start time, got %s, %s", events[0].ID, events[1].ID)
+	}
+	if events[0].Calendar != "Platform Te…" || events[1].Calendar != "me" {
+		t.Errorf("Expected short calendar labels, got '%s' and '%s'", events[0].Calendar, events[1].Calendar)
+	}
+
+	items := plugin.FormatEventsForDisplay()
+	if !strings.HasSuffix(items[1].Subtitle, " • me") {
+		t.Errorf("Expected calendar label in subtitle, got '%s'", items[1].Subtitle)
+	}
+
+	// Every calendar failing is an error
+	plugin.calendars = []string{"missing"}
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected Fetch to fail when no calendar can be read")
+	}
+}
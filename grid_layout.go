@@ -0,0 +1,72 @@
+package main
+
+// tilePlacement is where a tile sits in the grid and the cells it spans
+type tilePlacement struct {
+	tile int
+	row  int
+	col  int
+	rows int
+	cols int
+}
+
+// span returns the grid cells the tile takes, from ui.tiles.<widget>.columns and rows
+func (wt *WidgetTile) span() (cols, rows int) {
+	return min(max(wt.columns, 1), gridColumns), max(wt.rows, 1)
+}
+
+// layoutTiles places tiles in order, each in the first free cell, row by row, where its
+// span fits, so a smaller tile fills the gap a wide one leaves at the end of a row.
+// Collapsed tiles take no cell. It returns the placements and the number of rows the
+// grid takes.
+func layoutTiles(tiles []WidgetTile) ([]tilePlacement, int) {
+	var taken [][gridColumns]bool
+	free := func(row, col, rows, cols int) bool {
+		for r := row; r < row+rows && r < len(taken); r++ {
+			for c := col; c < col+cols; c++ {
+				if taken[r][c] {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	placements := make([]tilePlacement, 0, len(tiles))
+	gridRows := 0
+	for i := range tiles {
+		if tiles[i].collapsed {
+			continue
+		}
+		cols, rows := tiles[i].span()
+		p := tilePlacement{tile: i, rows: rows, cols: cols}
+	search:
+		for ; ; p.row++ {
+			for p.col = 0; p.col+cols <= gridColumns; p.col++ {
+				if free(p.row, p.col, rows, cols) {
+					break search
+				}
+			}
+		}
+		for len(taken) < p.row+rows {
+			taken = append(taken, [gridColumns]bool{})
+		}
+		for r := p.row; r < p.row+rows; r++ {
+			for c := p.col; c < p.col+cols; c++ {
+				taken[r][c] = true
+			}
+		}
+		placements = append(placements, p)
+		gridRows = max(gridRows, p.row+rows)
+	}
+	return placements, gridRows
+}
+
+// placementAt returns the placement covering a cell of the grid
+func placementAt(placements []tilePlacement, row, col int) (tilePlacement, bool) {
+	for _, p := range placements {
+		if row >= p.row && row < p.row+p.rows && col >= p.col && col < p.col+p.cols {
+			return p, true
+		}
+	}
+	return tilePlacement{}, false
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestLayoutTiles(t *testing.T) {
+	spans := func(spans ...[2]int) []WidgetTile {
+		tiles := make([]WidgetTile, len(spans))
+		for i, span := range spans {
+			tiles[i].columns, tiles[i].rows = span[0], span[1]
+		}
+		return tiles
+	}
+
+	// A wide tile, a tall one beside it, and single tiles below
+	placements, rows := layoutTiles(spans([2]int{2, 1}, [2]int{1, 2}, [2]int{}, [2]int{}, [2]int{}))
+	want := []tilePlacement{
+		{tile: 0, row: 0, col: 0, rows: 1, cols: 2},
+		{tile: 1, row: 0, col: 2, rows: 2, cols: 1},
+		{tile: 2, row: 1, col: 0, rows: 1, cols: 1},
+		{tile: 3, row: 1, col: 1, rows: 1, cols: 1},
+		{tile: 4, row: 2, col: 0, rows: 1, cols: 1},
+	}
+	if rows != 3 || len(placements) != len(want) {
+		t.Fatalf("Expected 5 tiles on 3 rows, got %+v on %d", placements, rows)
+	}
+	for i := range want {
+		if placements[i] != want[i] {
+			t.Errorf("Expected tile %d at %+v, got %+v", i, want[i], placements[i])
+		}
+	}
+
+	// A tile after a full-width one fills the gap left before it
+	placements, rows = layoutTiles(spans([2]int{}, [2]int{3, 1}, [2]int{}))
+	if rows != 2 || placements[1].row != 1 || placements[2].row != 0 || placements[2].col != 1 {
+		t.Errorf("Expected the third tile beside the first, got %+v on %d rows", placements, rows)
+	}
+	if p, ok := placementAt(placements, 1, 2); !ok || p.tile != 1 {
+		t.Errorf("Expected the full-width tile under its last column, got %+v", p)
+	}
+
+	// Spans wider than the grid are clamped
+	if placements, _ := layoutTiles(spans([2]int{5, 1})); placements[0].cols != gridColumns {
+		t.Errorf("Expected a span of %d columns, got %+v", gridColumns, placements[0])
+	}
+}
+
+func TestSpanningTiles(t *testing.T) {
+	m := benchmarkModel(120, 40)
+	m.widgets = m.widgets[:4]
+	m.widgets[0].columns = 2
+	m.widgets[1].rows = 2
+	tileWidth, tileHeight := gridTileSize(120)
+
+	grid := m.renderWidgetGrid()
+	if lipgloss.Height(grid) != 2*(tileHeight+2) || lipgloss.Width(grid) != 3*(tileWidth+2) {
+		t.Errorf("Expected a grid of 3 by 2 cells, got %dx%d", lipgloss.Width(grid), lipgloss.Height(grid))
+	}
+	if m.widgets[0].width != 2*(tileWidth+2)-2 || m.widgets[1].height != 2*(tileHeight+2)-2 {
+		t.Errorf("Expected the spanning tiles to take their cells and borders, got %d wide and %d tall", m.widgets[0].width, m.widgets[1].height)
+	}
+	// The tall tile has room for all 12 items
+	if !strings.Contains(grid, m.widgets[1].title+" item 12") {
+		t.Errorf("Expected the tall tile to show every item, got:\n%s", grid)
+	}
+
+	// A click on the second row of the tall tile lands in it
+	m.View()
+	top := lipgloss.Height(m.renderHeader()) + 1
+	updated, _ := m.handleMouse(tea.MouseMsg{X: 2*(tileWidth+2) + 5, Y: top + tileHeight + 2, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress}, time.Now())
+	m = updated.(Model)
+	if m.focusedWidget != 1 || m.widgets[1].list.Index() != tileHeight {
+		t.Errorf("Expected the click to select item %d of tile 1, got tile %d item %d", tileHeight, m.focusedWidget, m.widgets[1].list.Index())
+	}
+}
@@ -0,0 +1,411 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// harvestRecentEntries is how many of the latest entries the tile lists
+const harvestRecentEntries = 5
+
+// HarvestPlugin shows today's Harvest hours against a daily target and the latest
+// entries, and starts and stops timers; it is the Time Tracking tile's alternative to Toggl
+type HarvestPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	accessToken string
+	accountID   string
+	project     string // project new timers are tracked to, by name or ID
+	task        string // task of that project, by name or ID
+	notes       string // notes of new timers
+	target      time.Duration
+	apiURL      string
+	now         func() time.Time
+	client      *http.Client
+	dryRun      *DryRunLog
+	readOnly    bool // features.write_actions is off; only GETs are sent
+
+	mu       sync.Mutex // guards the lookups below, as timers start and stop off the UI goroutine
+	userID   int64
+	capacity time.Duration // weekly capacity set on the user's Harvest profile
+	webURL   string        // the account's Harvest site, e.g. https://example.harvestapp.com
+	lastData TimeTrackingStatus
+}
+
+// NewHarvestPlugin creates a new Harvest plugin
+func NewHarvestPlugin() *HarvestPlugin {
+	return &HarvestPlugin{
+		id:          "harvest",
+		pluginType:  "productivity",
+		name:        "Harvest",
+		version:     "1.0.0",
+		description: "Shows today's Harvest hours against a target and the latest time entries",
+		author:      "GoDay Team",
+		accessToken: os.Getenv("HARVEST_ACCESS_TOKEN"),
+		accountID:   os.Getenv("HARVEST_ACCOUNT_ID"),
+		apiURL:      "https://api.harvestapp.com/v2",
+		now:         time.Now,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// GetID returns the plugin ID
+func (hp *HarvestPlugin) GetID() string {
+	return hp.id
+}
+
+// GetType returns the plugin type
+func (hp *HarvestPlugin) GetType() string {
+	return hp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (hp *HarvestPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        hp.name,
+		Version:     hp.version,
+		Description: hp.description,
+		Author:      hp.author,
+		Type:        hp.pluginType,
+		Config: map[string]string{
+			"has_access_token": fmt.Sprintf("%t", hp.accessToken != ""),
+			"account_id":       hp.accountID,
+			"project":          hp.project,
+			"task":             hp.task,
+			"target":           hp.target.String(),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (hp *HarvestPlugin) Initialize(config map[string]interface{}) error {
+	if token, ok := config["api_token"].(string); ok && token != "" {
+		hp.accessToken = token
+	}
+	if account, ok := config["account_id"].(string); ok && account != "" {
+		hp.accountID = account
+	}
+	if project, ok := config["project"].(string); ok {
+		hp.project = project
+	}
+	if task, ok := config["task"].(string); ok {
+		hp.task = task
+	}
+	if notes, ok := config["description"].(string); ok {
+		hp.notes = notes
+	}
+	if target, ok := config["target"].(string); ok && target != "" {
+		d, err := time.ParseDuration(target)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid target %q: expected a duration such as 7h30m", target)
+		}
+		hp.target = d
+	}
+	if apiURL, ok := config["api_url"].(string); ok && apiURL != "" {
+		hp.apiURL = apiURL
+	}
+	if writes, ok := config["write_actions"].(bool); ok {
+		hp.readOnly = !writes
+	}
+	if dryRun, ok := config["dry_run"].(bool); ok && dryRun {
+		hp.dryRun = NewDryRunLog()
+	}
+	return nil
+}
+
+// harvestTimeEntry is a time entry as the Harvest API returns it
+type harvestTimeEntry struct {
+	ID             int64      `json:"id"`
+	SpentDate      string     `json:"spent_date"`
+	Hours          float64    `json:"hours"` // includes the running timer so far
+	Notes          string     `json:"notes"`
+	IsRunning      bool       `json:"is_running"`
+	TimerStartedAt *time.Time `json:"timer_started_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Project        struct {
+		Name string `json:"name"`
+	} `json:"project"`
+	Task struct {
+		Name string `json:"name"`
+	} `json:"task"`
+}
+
+// timeEntry converts an entry for the tile
+func (e harvestTimeEntry) timeEntry() TimeEntry {
+	entry := TimeEntry{
+		ID:          e.ID,
+		Description: e.Notes,
+		Project:     e.Project.Name,
+		Task:        e.Task.Name,
+		Start:       e.CreatedAt,
+		Duration:    time.Duration(e.Hours * float64(time.Hour)),
+		Running:     e.IsRunning,
+	}
+	if e.TimerStartedAt != nil {
+		entry.Start = *e.TimerStartedAt
+	}
+	return entry
+}
+
+// Fetch returns the running timer, today's hours against the target and the latest
+// entries of the past week
+func (hp *HarvestPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if err := hp.checkCredentials(); err != nil {
+		return hp.lastData, err
+	}
+	if err := hp.lookupUser(ctx); err != nil {
+		return hp.lastData, err
+	}
+
+	now := hp.now()
+	today := now.Format("2006-01-02")
+	hp.mu.Lock()
+	query := url.Values{
+		"user_id":  {strconv.FormatInt(hp.userID, 10)},
+		"from":     {now.AddDate(0, 0, -7).Format("2006-01-02")},
+		"to":       {today},
+		"per_page": {"100"},
+	}
+	status := TimeTrackingStatus{Target: hp.target, URL: hp.webURL}
+	// Without a target, the capacity on the user's profile is spread over a five-day week
+	if status.Target == 0 {
+		status.Target = hp.capacity / 5
+	}
+	hp.mu.Unlock()
+	if status.URL != "" {
+		status.URL += "/time"
+	}
+
+	var page struct {
+		TimeEntries []harvestTimeEntry `json:"time_entries"`
+	}
+	if err := hp.do(ctx, "GET", "/time_entries?"+query.Encode(), nil, &page); err != nil {
+		return hp.lastData, err
+	}
+	// Entries come newest first
+	for _, e := range page.TimeEntries {
+		entry := e.timeEntry()
+		if e.SpentDate == today {
+			status.Today += entry.Duration
+			status.Entries++
+		}
+		if e.IsRunning && status.Current == nil {
+			status.Current = &entry
+		}
+		if len(status.Recent) < harvestRecentEntries {
+			status.Recent = append(status.Recent, entry)
+		}
+	}
+
+	hp.mu.Lock()
+	hp.lastData = status
+	hp.mu.Unlock()
+	return status, nil
+}
+
+// checkCredentials reports a missing token or account ID
+func (hp *HarvestPlugin) checkCredentials() error {
+	if hp.accessToken == "" || hp.accountID == "" {
+		return fmt.Errorf("Harvest token or account ID not configured (widgets.toggl.api_token and account_id, or HARVEST_ACCESS_TOKEN and HARVEST_ACCOUNT_ID)")
+	}
+	return nil
+}
+
+// lookupUser finds the user's ID, weekly capacity and Harvest site once
+func (hp *HarvestPlugin) lookupUser(ctx context.Context) error {
+	hp.mu.Lock()
+	known := hp.userID != 0
+	hp.mu.Unlock()
+	if known {
+		return nil
+	}
+
+	var me struct {
+		ID             int64 `json:"id"`
+		WeeklyCapacity int64 `json:"weekly_capacity"` // seconds
+	}
+	if err := hp.do(ctx, "GET", "/users/me", nil, &me); err != nil {
+		return err
+	}
+	var company struct {
+		BaseURI string `json:"base_uri"`
+	}
+	// The site only links the tile, so the dashboard works without it
+	hp.do(ctx, "GET", "/company", nil, &company)
+
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+	hp.userID = me.ID
+	hp.capacity = time.Duration(me.WeeklyCapacity) * time.Second
+	hp.webURL = company.BaseURI
+	return nil
+}
+
+// harvestAssignment is a project the user can track time to, with its tasks
+type harvestAssignment struct {
+	Project struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"project"`
+	TaskAssignments []struct {
+		Task struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+		} `json:"task"`
+	} `json:"task_assignments"`
+}
+
+// defaultTask resolves widgets.toggl.project and task, names or IDs, among the user's
+// project assignments. The task may be left out of a project with a single task.
+func (hp *HarvestPlugin) defaultTask(ctx context.Context) (projectID, taskID int64, err error) {
+	if hp.project == "" {
+		return 0, 0, fmt.Errorf("Harvest timers need a project (widgets.toggl.project)")
+	}
+	var page struct {
+		ProjectAssignments []harvestAssignment `json:"project_assignments"`
+	}
+	if err := hp.do(ctx, "GET", "/users/me/project_assignments?per_page=100", nil, &page); err != nil {
+		return 0, 0, err
+	}
+	for _, assignment := range page.ProjectAssignments {
+		project := assignment.Project
+		if project.Name != hp.project && strconv.FormatInt(project.ID, 10) != hp.project {
+			continue
+		}
+		if hp.task == "" && len(assignment.TaskAssignments) == 1 {
+			return project.ID, assignment.TaskAssignments[0].Task.ID, nil
+		}
+		for _, task := range assignment.TaskAssignments {
+			if task.Task.Name == hp.task || strconv.FormatInt(task.Task.ID, 10) == hp.task {
+				return project.ID, task.Task.ID, nil
+			}
+		}
+		if hp.task == "" {
+			return 0, 0, fmt.Errorf("Harvest project %q has several tasks; choose one with widgets.toggl.task", hp.project)
+		}
+		return 0, 0, fmt.Errorf("no task %q in Harvest project %q (widgets.toggl.task)", hp.task, hp.project)
+	}
+	return 0, 0, fmt.Errorf("no Harvest project named %q assigned to you (widgets.toggl.project)", hp.project)
+}
+
+// Start starts a timer on the configured project and task for today. Harvest stops a
+// timer already running.
+func (hp *HarvestPlugin) Start(ctx context.Context) error {
+	if err := hp.checkCredentials(); err != nil {
+		return err
+	}
+	projectID, taskID, err := hp.defaultTask(ctx)
+	if err != nil {
+		return err
+	}
+	// Without hours, Harvest starts a timer on accounts that track time with timers
+	return hp.do(ctx, "POST", "/time_entries", map[string]interface{}{
+		"project_id": projectID,
+		"task_id":    taskID,
+		"spent_date": hp.now().Format("2006-01-02"),
+		"notes":      hp.notes,
+	}, nil)
+}
+
+// Stop stops the running timer, if any
+func (hp *HarvestPlugin) Stop(ctx context.Context) error {
+	if err := hp.checkCredentials(); err != nil {
+		return err
+	}
+	if err := hp.lookupUser(ctx); err != nil {
+		return err
+	}
+	hp.mu.Lock()
+	query := url.Values{"user_id": {strconv.FormatInt(hp.userID, 10)}, "is_running": {"true"}}
+	hp.mu.Unlock()
+
+	var page struct {
+		TimeEntries []harvestTimeEntry `json:"time_entries"`
+	}
+	if err := hp.do(ctx, "GET", "/time_entries?"+query.Encode(), nil, &page); err != nil {
+		return err
+	}
+	if len(page.TimeEntries) == 0 {
+		return fmt.Errorf("no timer is running")
+	}
+	return hp.do(ctx, "PATCH", fmt.Sprintf("/time_entries/%d/stop", page.TimeEntries[0].ID), nil, nil)
+}
+
+// do sends an authenticated Harvest API request and decodes the JSON response into
+// target, if given. In dry run mode anything but a GET is logged instead, and with
+// write actions turned off it fails.
+func (hp *HarvestPlugin) do(ctx context.Context, method, path string, payload, target interface{}) error {
+	if hp.readOnly && method != "GET" {
+		return fmt.Errorf("write actions are turned off (features.%s)", FeatureWriteActions)
+	}
+	if hp.dryRun != nil && method != "GET" {
+		return hp.dryRun.Record(method, hp.apiURL+path, payload)
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, hp.apiURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+hp.accessToken)
+	req.Header.Set("Harvest-Account-Id", hp.accountID)
+	// Harvest rejects requests without a User-Agent naming the application
+	req.Header.Set("User-Agent", "GoDay (https://github.com/bhanu/goday)")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := hp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message          string `json:"message"`
+			ErrorDescription string `json:"error_description"`
+		}
+		json.Unmarshal(data, &apiErr)
+		if apiErr.Message == "" {
+			apiErr.Message = apiErr.ErrorDescription
+		}
+		if apiErr.Message != "" {
+			return fmt.Errorf("Harvest returned status %d: %s", resp.StatusCode, apiErr.Message)
+		}
+		return fmt.Errorf("Harvest returned status %d", resp.StatusCode)
+	}
+	if target == nil {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+// Cleanup performs cleanup
+func (hp *HarvestPlugin) Cleanup() error {
+	return nil
+}
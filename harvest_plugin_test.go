@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeHarvest serves a user with a 40h week, a running timer and two finished entries
+func fakeHarvest(started *map[string]interface{}, stopped *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("Harvest-Account-Id") != "42" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_token","error_description":"The access token provided is expired"}`)
+			return
+		}
+		switch {
+		case r.URL.Path == "/users/me":
+			fmt.Fprint(w, `{"id":7,"weekly_capacity":144000}`)
+		case r.URL.Path == "/company":
+			fmt.Fprint(w, `{"base_uri":"https://example.harvestapp.com"}`)
+		case r.Method == "GET" && r.URL.Path == "/time_entries":
+			if r.URL.Query().Get("user_id") != "7" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"time_entries":[
+				{"id":3,"spent_date":"2026-10-13","hours":0.5,"notes":"reviews","is_running":true,"timer_started_at":"2026-10-13T09:30:00Z","created_at":"2026-10-13T09:30:00Z","project":{"name":"Platform"},"task":{"name":"Development"}},
+				{"id":2,"spent_date":"2026-10-13","hours":1.25,"notes":"standup","is_running":false,"created_at":"2026-10-13T08:00:00Z","project":{"name":"Platform"},"task":{"name":"Meetings"}},
+				{"id":1,"spent_date":"2026-10-12","hours":6,"notes":"","is_running":false,"created_at":"2026-10-12T09:00:00Z","project":{"name":"Platform"},"task":{"name":"Development"}}]}`)
+		case r.URL.Path == "/users/me/project_assignments":
+			fmt.Fprint(w, `{"project_assignments":[{"project":{"id":11,"name":"Platform"},"task_assignments":[
+				{"task":{"id":21,"name":"Development"}},{"task":{"id":22,"name":"Meetings"}}]}]}`)
+		case r.Method == "POST" && r.URL.Path == "/time_entries":
+			json.NewDecoder(r.Body).Decode(started)
+		case r.Method == "PATCH":
+			*stopped = r.URL.Path
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newTestHarvestPlugin(t *testing.T, serverURL string, config map[string]interface{}) *HarvestPlugin {
+	t.Helper()
+	plugin := NewHarvestPlugin()
+	plugin.apiURL = serverURL
+	plugin.now = func() time.Time { return time.Date(2026, 10, 13, 10, 0, 0, 0, time.UTC) }
+	config["api_token"] = "secret"
+	config["account_id"] = "42"
+	if err := plugin.Initialize(config); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	return plugin
+}
+
+func TestHarvestPluginFetch(t *testing.T) {
+	server := fakeHarvest(nil, nil)
+	defer server.Close()
+	plugin := newTestHarvestPlugin(t, server.URL, map[string]interface{}{})
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	status := data.(TimeTrackingStatus)
+	if status.Current == nil || status.Current.Description != "reviews" || status.Current.Task != "Development" {
+		t.Fatalf("Expected the running reviews timer, got %+v", status.Current)
+	}
+	if status.Today != 105*time.Minute || status.Entries != 2 || status.Target != 8*time.Hour {
+		t.Errorf("Expected 1h45m of 8h in 2 entries today, got %s of %s in %d", status.Today, status.Target, status.Entries)
+	}
+	if len(status.Recent) != 3 || status.URL != "https://example.harvestapp.com/time" {
+		t.Errorf("Expected 3 recent entries linking to the Harvest site, got %d and %q", len(status.Recent), status.URL)
+	}
+
+	wm := NewWidgetManager()
+	wm.UpdateTimeTrackingWidget(status)
+	items := wm.Widgets["toggl"].Items
+	if len(items) != 4 || items[1].Title != "1h45m of 8h today" || items[1].Subtitle != "2 entries since midnight • 6h15m to go" {
+		t.Errorf("Expected the running timer, today against the target and 2 finished entries, got %+v", items)
+	}
+	if !strings.HasPrefix(items[0].Subtitle, "Platform / Development • Running 30m") {
+		t.Errorf("Expected the running timer's project and task, got %q", items[0].Subtitle)
+	}
+}
+
+func TestHarvestPluginStartAndStop(t *testing.T) {
+	started := map[string]interface{}{}
+	stopped := ""
+	server := fakeHarvest(&started, &stopped)
+	defer server.Close()
+	plugin := newTestHarvestPlugin(t, server.URL, map[string]interface{}{"project": "Platform", "task": "22", "description": "planning"})
+
+	if err := plugin.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if started["project_id"] != float64(11) || started["task_id"] != float64(22) || started["spent_date"] != "2026-10-13" {
+		t.Errorf("Expected a timer on Platform / Meetings today, got %v", started)
+	}
+	if err := plugin.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	if stopped != "/time_entries/3/stop" {
+		t.Errorf("Expected the running timer to be stopped, got %q", stopped)
+	}
+
+	// A project with several tasks needs one chosen
+	plugin.task = ""
+	if err := plugin.Start(context.Background()); err == nil {
+		t.Error("Expected an error without a task for a project with several")
+	}
+}
+
+func TestHarvestPluginErrors(t *testing.T) {
+	server := fakeHarvest(nil, nil)
+	defer server.Close()
+	plugin := newTestHarvestPlugin(t, server.URL, map[string]interface{}{})
+	plugin.accessToken = "expired"
+
+	_, err := plugin.Fetch(context.Background())
+	if err == nil || err.Error() != "Harvest returned status 401: The access token provided is expired" {
+		t.Errorf("Expected Harvest's error description, got %v", err)
+	}
+	stopped := ""
+	server = fakeHarvest(nil, &stopped)
+	defer server.Close()
+	plugin = newTestHarvestPlugin(t, server.URL, map[string]interface{}{"write_actions": false})
+	if err := plugin.Stop(context.Background()); err == nil || !strings.Contains(err.Error(), "write actions are turned off") {
+		t.Errorf("Expected stopping a timer to fail with write actions off, got %v", err)
+	}
+	if stopped != "" {
+		t.Errorf("Expected no timer to be stopped, got %q", stopped)
+	}
+	if err := NewHarvestPlugin().Initialize(map[string]interface{}{"target": "all day"}); err == nil {
+		t.Error("Expected an invalid target to be rejected")
+	}
+}
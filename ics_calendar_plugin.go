@@ -0,0 +1,731 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ICSCalendarPlugin reads events from one or more iCalendar (.ics) URLs or local files
+type ICSCalendarPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	sources     []string
+	maxEvents   int
+	daysAhead   int
+	client      *http.Client
+	lastData    []CalendarEvent
+}
+
+// NewICSCalendarPlugin creates a new ICS calendar plugin
+func NewICSCalendarPlugin() *ICSCalendarPlugin {
+	return &ICSCalendarPlugin{
+		id:          "ics-calendar",
+		pluginType:  "calendar",
+		name:        "ICS Calendar",
+		version:     "1.0.0",
+		description: "Fetches events from iCalendar (.ics) URLs and files",
+		author:      "GoDay Team",
+		maxEvents:   10,
+		daysAhead:   7,
+		client:      &http.Client{Timeout: 15 * time.Second},
+		lastData:    []CalendarEvent{},
+	}
+}
+
+// GetID returns the plugin ID
+func (ip *ICSCalendarPlugin) GetID() string {
+	return ip.id
+}
+
+// GetType returns the plugin type
+func (ip *ICSCalendarPlugin) GetType() string {
+	return ip.pluginType
+}
+
+// Initialize sets up the plugin with configuration
+func (ip *ICSCalendarPlugin) Initialize(config map[string]interface{}) error {
+	ip.sources = configStringList(config["sources"])
+	if maxEvents, ok := config["max_events"].(int); ok && maxEvents > 0 {
+		ip.maxEvents = maxEvents
+	}
+	if daysAhead, ok := config["days_ahead"].(int); ok && daysAhead > 0 {
+		ip.daysAhead = daysAhead
+	}
+	return nil
+}
+
+// Fetch downloads or reads every configured source and returns upcoming events
+func (ip *ICSCalendarPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if len(ip.sources) == 0 {
+		return ip.lastData, fmt.Errorf("no ICS sources configured (widgets.calendar.ics)")
+	}
+
+	now := time.Now()
+	windowEnd := now.AddDate(0, 0, ip.daysAhead)
+
+	var events []CalendarEvent
+	var failures []string
+	for _, source := range ip.sources {
+		data, err := ip.readSource(ctx, source)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", source, err))
+			continue
+		}
+		events = append(events, expandICSEvents(parseICS(data), now, windowEnd)...)
+	}
+
+	if len(failures) == len(ip.sources) {
+		return ip.lastData, fmt.Errorf("failed to read ICS sources: %s", strings.Join(failures, "; "))
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].StartTime.Before(events[j].StartTime)
+	})
+	if len(events) > ip.maxEvents {
+		events = events[:ip.maxEvents]
+	}
+
+	ip.lastData = events
+	return events, nil
+}
+
+// readSource returns the raw calendar data for a URL or local file path
+func (ip *ICSCalendarPlugin) readSource(ctx context.Context, source string) (string, error) {
+	if strings.HasPrefix(source, "webcal://") {
+		source = "https://" + strings.TrimPrefix(source, "webcal://")
+	}
+
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		req, err := http.NewRequestWithContext(ctx, "GET", source, nil)
+		if err != nil {
+			return "", err
+		}
+
+		resp, err := ip.client.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("server returned status %d", resp.StatusCode)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", err
+		}
+		return string(body), nil
+	}
+
+	if strings.HasPrefix(source, "~/") {
+		home, _ := os.UserHomeDir()
+		source = filepath.Join(home, source[2:])
+	}
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// GetMetadata returns plugin metadata
+func (ip *ICSCalendarPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        ip.name,
+		Version:     ip.version,
+		Description: ip.description,
+		Author:      ip.author,
+		Type:        ip.pluginType,
+		Config: map[string]string{
+			"sources":    "List of .ics URLs (http, https, webcal) or file paths",
+			"max_events": "Maximum number of events to show (default: 10)",
+			"days_ahead": "Number of days ahead to show events (default: 7)",
+		},
+	}
+}
+
+// Cleanup performs cleanup
+func (ip *ICSCalendarPlugin) Cleanup() error {
+	return nil
+}
+
+// FormatEventsForDisplay formats calendar events for display in the widget
+func (ip *ICSCalendarPlugin) FormatEventsForDisplay() []WidgetItem {
+	return formatCalendarItems(ip.lastData, time.Now())
+}
+
+// Events returns the last fetched events
+func (ip *ICSCalendarPlugin) Events() []CalendarEvent {
+	return ip.lastData
+}
+
+// icsEvent is a VEVENT as parsed from the file, before recurrence expansion
+type icsEvent struct {
+	uid          string
+	summary      string
+	description  string
+	location     string
+	url          string
+	attendees    []string
+	start        time.Time
+	end          time.Time
+	allDay       bool
+	rrule        map[string]string
+	exdates      []time.Time
+	recurrenceID time.Time
+}
+
+// icsProperty is a single unfolded content line such as DTSTART;TZID=Europe/Berlin:20240101T090000
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// parseICS extracts the VEVENT components from iCalendar data
+func parseICS(data string) []icsEvent {
+	var events []icsEvent
+	var current *icsEvent
+	var duration time.Duration
+	var hasDuration bool
+	nested := 0
+
+	for _, line := range unfoldICSLines(data) {
+		prop, ok := parseICSProperty(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case prop.name == "BEGIN" && prop.value == "VEVENT":
+			current = &icsEvent{}
+			duration, hasDuration = 0, false
+			continue
+		case prop.name == "BEGIN" && current != nil:
+			// Skip nested components such as VALARM
+			nested++
+			continue
+		case prop.name == "END" && current != nil && nested > 0:
+			nested--
+			continue
+		case prop.name == "END" && prop.value == "VEVENT" && current != nil:
+			if current.end.IsZero() {
+				switch {
+				case hasDuration:
+					current.end = current.start.Add(duration)
+				case current.allDay:
+					current.end = current.start.AddDate(0, 0, 1)
+				default:
+					current.end = current.start
+				}
+			}
+			if !current.start.IsZero() {
+				events = append(events, *current)
+			}
+			current = nil
+			continue
+		}
+
+		if current == nil || nested > 0 {
+			continue
+		}
+
+		switch prop.name {
+		case "UID":
+			current.uid = prop.value
+		case "SUMMARY":
+			current.summary = unescapeICSText(prop.value)
+		case "DESCRIPTION":
+			current.description = unescapeICSText(prop.value)
+		case "LOCATION":
+			current.location = unescapeICSText(prop.value)
+		case "URL":
+			current.url = prop.value
+		case "ATTENDEE":
+			// The common name if given, otherwise the address
+			attendee := prop.params["CN"]
+			if attendee == "" {
+				attendee = strings.TrimPrefix(strings.TrimPrefix(prop.value, "mailto:"), "MAILTO:")
+			}
+			current.attendees = append(current.attendees, attendee)
+		case "DTSTART":
+			current.start, _ = parseICSTime(prop.value, prop.params)
+			current.allDay = prop.params["VALUE"] == "DATE" || len(strings.TrimSpace(prop.value)) == 8
+		case "DTEND":
+			current.end, _ = parseICSTime(prop.value, prop.params)
+		case "DURATION":
+			duration, hasDuration = parseICSDuration(prop.value)
+		case "RRULE":
+			current.rrule = make(map[string]string)
+			for _, part := range strings.Split(prop.value, ";") {
+				if key, value, ok := strings.Cut(part, "="); ok {
+					current.rrule[strings.ToUpper(key)] = strings.ToUpper(value)
+				}
+			}
+		case "EXDATE":
+			for _, value := range strings.Split(prop.value, ",") {
+				if t, err := parseICSTime(value, prop.params); err == nil {
+					current.exdates = append(current.exdates, t)
+				}
+			}
+		case "RECURRENCE-ID":
+			current.recurrenceID, _ = parseICSTime(prop.value, prop.params)
+		}
+	}
+
+	return events
+}
+
+// expandICSEvents expands recurring events and returns every occurrence overlapping [from, to)
+func expandICSEvents(events []icsEvent, from, to time.Time) []CalendarEvent {
+	// Modified occurrences (RECURRENCE-ID) replace the generated instance they point at
+	overridden := make(map[string]bool)
+	for _, event := range events {
+		if !event.recurrenceID.IsZero() {
+			overridden[event.uid+"|"+event.recurrenceID.UTC().Format(time.RFC3339)] = true
+		}
+	}
+
+	var result []CalendarEvent
+	for _, event := range events {
+		length := event.end.Sub(event.start)
+
+		starts := []time.Time{event.start}
+		if event.rrule != nil && event.recurrenceID.IsZero() {
+			starts = expandRRule(event, from, to)
+		}
+
+		for _, start := range starts {
+			if !event.recurrenceID.IsZero() || !overridden[event.uid+"|"+start.UTC().Format(time.RFC3339)] {
+				end := start.Add(length)
+				if end.After(from) && start.Before(to) {
+					result = append(result, CalendarEvent{
+						ID:          event.uid,
+						Title:       event.summary,
+						Description: event.description,
+						StartTime:   start,
+						EndTime:     end,
+						Location:    event.location,
+						URL:         event.url,
+						Attendees:   event.attendees,
+					})
+				}
+			}
+		}
+	}
+	return result
+}
+
+// maxRecurrenceIterations bounds expansion of rules without COUNT or UNTIL
+const maxRecurrenceIterations = 5000
+
+// expandRRule returns the start times of a recurring event up to limit.
+// Supports FREQ=DAILY|WEEKLY|MONTHLY|YEARLY with INTERVAL, COUNT, UNTIL, daily and weekly
+// BYDAY, and BYDAY (with ordinals such as 2TU or -1FR), BYMONTHDAY and BYMONTH for
+// monthly and yearly rules. A rule using anything else shows only its first occurrence, rather than
+// occurrences on the wrong days.
+func expandRRule(event icsEvent, from, limit time.Time) []time.Time {
+	rule := event.rrule
+	if !supportedRRule(rule) {
+		return []time.Time{event.start}
+	}
+	interval := 1
+	if n, err := strconv.Atoi(rule["INTERVAL"]); err == nil && n > 0 {
+		interval = n
+	}
+	count := -1
+	if n, err := strconv.Atoi(rule["COUNT"]); err == nil && n > 0 {
+		count = n
+	}
+	until := limit
+	if value, ok := rule["UNTIL"]; ok {
+		if t, err := parseICSTime(value, map[string]string{}); err == nil && t.Before(until) {
+			until = t
+			if len(value) == 8 {
+				until = t.AddDate(0, 0, 1)
+			}
+		}
+	}
+
+	excluded := func(t time.Time) bool {
+		for _, ex := range event.exdates {
+			if ex.Equal(t) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var starts []time.Time
+	emitted := 0
+	emit := func(t time.Time) bool {
+		if t.Before(event.start) {
+			return true
+		}
+		if t.After(until) || (count >= 0 && emitted >= count) {
+			return false
+		}
+		emitted++
+		if !excluded(t) {
+			starts = append(starts, t)
+		}
+		return true
+	}
+
+	// Without COUNT, long-running daily and weekly series can skip straight to the window
+	first := 0
+	if count < 0 && from.After(event.start) {
+		stepDays := map[string]int{"DAILY": 1, "WEEKLY": 7}[rule["FREQ"]]
+		if stepDays > 0 {
+			first = int(from.Sub(event.start).Hours()/24)/(stepDays*interval) - 1
+			if first < 0 {
+				first = 0
+			}
+		}
+	}
+
+	start := event.start
+	for i := first; i < first+maxRecurrenceIterations; i++ {
+		switch rule["FREQ"] {
+		case "DAILY":
+			t := start.AddDate(0, 0, i*interval)
+			// BYDAY limits a daily rule, e.g. to weekdays
+			if days := parseByDay(rule["BYDAY"]); len(days) > 0 && !containsWeekday(days, t.Weekday()) {
+				continue
+			}
+			if !emit(t) {
+				return starts
+			}
+		case "WEEKLY":
+			weekStart := start.AddDate(0, 0, i*7*interval-int(start.Weekday()))
+			days := parseByDay(rule["BYDAY"])
+			if len(days) == 0 {
+				days = []time.Weekday{start.Weekday()}
+			}
+			for _, day := range days {
+				if !emit(weekStart.AddDate(0, 0, int(day))) {
+					return starts
+				}
+			}
+		case "MONTHLY":
+			month := time.Date(start.Year(), start.Month()+time.Month(i*interval), 1, 0, 0, 0, 0, start.Location())
+			if months := parseByMonth(rule["BYMONTH"]); len(months) > 0 && !containsMonth(months, month.Month()) {
+				continue
+			}
+			for _, t := range monthOccurrences(rule, start, month.Year(), month.Month()) {
+				if !emit(t) {
+					return starts
+				}
+			}
+		case "YEARLY":
+			months := parseByMonth(rule["BYMONTH"])
+			if len(months) == 0 {
+				months = []time.Month{start.Month()}
+			}
+			for _, month := range months {
+				for _, t := range monthOccurrences(rule, start, start.Year()+i*interval, month) {
+					if !emit(t) {
+						return starts
+					}
+				}
+			}
+		default:
+			return []time.Time{event.start}
+		}
+	}
+	return starts
+}
+
+// supportedRRule reports whether expandRRule understands every part of rule
+func supportedRRule(rule map[string]string) bool {
+	for part := range rule {
+		switch part {
+		case "FREQ", "INTERVAL", "COUNT", "UNTIL", "WKST", "BYDAY":
+		case "BYMONTHDAY", "BYMONTH":
+			if rule["FREQ"] != "MONTHLY" && rule["FREQ"] != "YEARLY" {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	switch rule["FREQ"] {
+	case "DAILY", "WEEKLY":
+		// Ordinals only mean something within a month or year
+		for _, day := range parseOrdinalByDay(rule["BYDAY"]) {
+			if day.n != 0 {
+				return false
+			}
+		}
+	case "YEARLY":
+		// Without BYMONTH, BYDAY and BYMONTHDAY count through the whole year
+		if rule["BYMONTH"] == "" && (rule["BYDAY"] != "" || rule["BYMONTHDAY"] != "") {
+			return false
+		}
+	}
+	return true
+}
+
+// monthOccurrences returns the times in a month that a monthly or yearly rule falls on,
+// at the time of day of start. Without BYDAY and BYMONTHDAY that is start's day of the
+// month, which months without it (e.g. the 31st) skip.
+func monthOccurrences(rule map[string]string, start time.Time, year int, month time.Month) []time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	var monthDays map[int]bool
+	if value := rule["BYMONTHDAY"]; value != "" {
+		monthDays = make(map[int]bool)
+		for _, part := range strings.Split(value, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || n == 0 {
+				continue
+			}
+			// Negative days count back from the end of the month: -1 is the last day
+			if n < 0 {
+				n = last + n + 1
+			}
+			monthDays[n] = true
+		}
+	}
+	var weekDays map[int]bool
+	if value := rule["BYDAY"]; value != "" {
+		weekDays = make(map[int]bool)
+		firstWeekday := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+		for _, day := range parseOrdinalByDay(value) {
+			first := 1 + (int(day.weekday)-int(firstWeekday)+7)%7
+			switch {
+			case day.n > 0:
+				weekDays[first+7*(day.n-1)] = true
+			case day.n < 0:
+				weekDays[first+7*((last-first)/7)+7*(day.n+1)] = true
+			default:
+				for d := first; d <= last; d += 7 {
+					weekDays[d] = true
+				}
+			}
+		}
+	}
+
+	var times []time.Time
+	for d := 1; d <= last; d++ {
+		matches := monthDays == nil && weekDays == nil && d == start.Day()
+		if monthDays != nil || weekDays != nil {
+			// Given both, a day must be in both, e.g. Friday the 13th
+			matches = (monthDays == nil || monthDays[d]) && (weekDays == nil || weekDays[d])
+		}
+		if matches {
+			times = append(times, time.Date(year, month, d, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location()))
+		}
+	}
+	return times
+}
+
+// byDay is a BYDAY entry such as -1FR: the last Friday. An n of 0 is every such weekday.
+type byDay struct {
+	n       int
+	weekday time.Weekday
+}
+
+// parseOrdinalByDay parses a BYDAY list such as "2TU,-1FR" with its ordinals
+func parseOrdinalByDay(value string) []byDay {
+	var days []byDay
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if len(part) < 2 {
+			continue
+		}
+		weekday, ok := icsWeekdays[part[len(part)-2:]]
+		if !ok {
+			continue
+		}
+		n := 0
+		if prefix := part[:len(part)-2]; prefix != "" {
+			var err error
+			if n, err = strconv.Atoi(prefix); err != nil {
+				continue
+			}
+		}
+		days = append(days, byDay{n: n, weekday: weekday})
+	}
+	return days
+}
+
+// parseByMonth converts a BYMONTH list such as "3,11" to months
+func parseByMonth(value string) []time.Month {
+	var months []time.Month
+	for _, part := range strings.Split(value, ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(part)); err == nil && n >= 1 && n <= 12 {
+			months = append(months, time.Month(n))
+		}
+	}
+	sort.Slice(months, func(i, j int) bool { return months[i] < months[j] })
+	return months
+}
+
+// containsWeekday reports whether days includes day
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// containsMonth reports whether months includes month
+func containsMonth(months []time.Month, month time.Month) bool {
+	for _, m := range months {
+		if m == month {
+			return true
+		}
+	}
+	return false
+}
+
+// icsWeekdays maps the two-letter iCalendar day names to weekdays
+var icsWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// parseByDay converts a BYDAY list such as "MO,WE,FR" to weekdays in week order
+func parseByDay(value string) []time.Weekday {
+	var days []time.Weekday
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if len(part) < 2 {
+			continue
+		}
+		if day, ok := icsWeekdays[part[len(part)-2:]]; ok {
+			days = append(days, day)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+	return days
+}
+
+// unfoldICSLines joins continuation lines (those starting with a space or tab)
+func unfoldICSLines(data string) []string {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseICSProperty splits a content line into name, parameters and value
+func parseICSProperty(line string) (icsProperty, bool) {
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return icsProperty{}, false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	prop := icsProperty{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string),
+		value:  line[colon+1:],
+	}
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return prop, true
+}
+
+// parseICSTime parses DATE and DATE-TIME values, honouring TZID and the UTC "Z" suffix
+func parseICSTime(value string, params map[string]string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	loc := time.Local
+	if tzid, ok := params["TZID"]; ok {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+
+	switch {
+	case len(value) == 8:
+		return time.ParseInLocation("20060102", value, time.Local)
+	case strings.HasSuffix(value, "Z"):
+		return time.Parse("20060102T150405Z", value)
+	default:
+		return time.ParseInLocation("20060102T150405", value, loc)
+	}
+}
+
+// parseICSDuration parses RFC 5545 durations such as PT1H30M, P1D or P2W
+func parseICSDuration(value string) (time.Duration, bool) {
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "+"), "P")
+	if value == "" {
+		return 0, false
+	}
+
+	var total time.Duration
+	number := ""
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			number += string(r)
+		case r == 'T':
+			continue
+		default:
+			n, err := strconv.Atoi(number)
+			if err != nil {
+				return 0, false
+			}
+			number = ""
+			switch r {
+			case 'W':
+				total += time.Duration(n) * 7 * 24 * time.Hour
+			case 'D':
+				total += time.Duration(n) * 24 * time.Hour
+			case 'H':
+				total += time.Duration(n) * time.Hour
+			case 'M':
+				total += time.Duration(n) * time.Minute
+			case 'S':
+				total += time.Duration(n) * time.Second
+			default:
+				return 0, false
+			}
+		}
+	}
+	return total, true
+}
+
+// unescapeICSText reverses RFC 5545 TEXT escaping
+func unescapeICSText(value string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return replacer.Replace(value)
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:standup\r\n" +
+	"SUMMARY:Team\r\n" +
+	"  standup\r\n" +
+	"DTSTART:20240101T090000Z\r\n" +
+	"DTEND:20240101T091500Z\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=MO,WE;COUNT=6\r\n" +
+	"EXDATE:20240103T090000Z\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"SUMMARY:Alarm should be ignored\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:standup\r\n" +
+	"RECURRENCE-ID:20240108T090000Z\r\n" +
+	"SUMMARY:Team standup (moved)\r\n" +
+	"DTSTART:20240108T110000Z\r\n" +
+	"DURATION:PT15M\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:offsite\r\n" +
+	"SUMMARY:Offsite\\, Berlin\r\n" +
+	"DTSTART;VALUE=DATE:20240105\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:review\r\n" +
+	"SUMMARY:Design review\r\n" +
+	"ATTENDEE;CN=\"Lee, Ana\";ROLE=REQ-PARTICIPANT:mailto:ana@example.com\r\n" +
+	"ATTENDEE:mailto:bo@example.com\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20240109T140000\r\n" +
+	"DTEND;TZID=Europe/Berlin:20240109T150000\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICSExpandsRecurringEvents(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	events := expandICSEvents(parseICS(testICS), from, to)
+
+	var standups []CalendarEvent
+	for _, event := range events {
+		if event.ID == "standup" {
+			standups = append(standups, event)
+		}
+	}
+
+	// COUNT=6 gives Jan 1, 3, 8, 10, 15, 17; Jan 3 is excluded and Jan 8 is moved
+	expected := []time.Time{
+		time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 8, 11, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 17, 9, 0, 0, 0, time.UTC),
+	}
+	if len(standups) != len(expected) {
+		t.Fatalf("Expected %d standups, got %d: %v", len(expected), len(standups), standups)
+	}
+
+	found := make(map[time.Time]CalendarEvent)
+	for _, event := range standups {
+		found[event.StartTime.UTC()] = event
+	}
+	for _, start := range expected {
+		event, ok := found[start]
+		if !ok {
+			t.Errorf("Expected a standup at %v", start)
+			continue
+		}
+		if event.EndTime.Sub(event.StartTime) != 15*time.Minute {
+			t.Errorf("Expected 15 minute standup at %v, got %v", start, event.EndTime.Sub(event.StartTime))
+		}
+	}
+
+	if moved := found[expected[1]]; moved.Title != "Team standup (moved)" {
+		t.Errorf("Expected moved occurrence title, got '%s'", moved.Title)
+	}
+	if first := found[expected[0]]; first.Title != "Team standup" {
+		t.Errorf("Expected folded title 'Team standup', got '%s'", first.Title)
+	}
+}
+
+func TestExpandRRuleMonthlyAndYearly(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 10, 0, 0, 0, time.UTC)
+	}
+	cases := []struct {
+		rrule    string
+		start    time.Time
+		expected []time.Time
+	}{
+		{"FREQ=MONTHLY;BYDAY=2TU;COUNT=3", day(2024, 1, 9), []time.Time{day(2024, 1, 9), day(2024, 2, 13), day(2024, 3, 12)}},
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", day(2024, 1, 26), []time.Time{day(2024, 1, 26), day(2024, 2, 23), day(2024, 3, 29)}},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3", day(2024, 1, 31), []time.Time{day(2024, 1, 31), day(2024, 2, 29), day(2024, 3, 31)}},
+		{"FREQ=MONTHLY;BYMONTHDAY=13;BYDAY=FR;COUNT=2", day(2024, 9, 13), []time.Time{day(2024, 9, 13), day(2024, 12, 13)}},
+		{"FREQ=MONTHLY;COUNT=3", day(2024, 1, 31), []time.Time{day(2024, 1, 31), day(2024, 3, 31), day(2024, 5, 31)}},
+		{"FREQ=YEARLY;BYMONTH=11;BYDAY=4TH;COUNT=2", day(2024, 11, 28), []time.Time{day(2024, 11, 28), day(2025, 11, 27)}},
+		{"FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;COUNT=3", day(2024, 1, 5), []time.Time{day(2024, 1, 5), day(2024, 1, 8), day(2024, 1, 9)}},
+		// BYSETPOS is not supported, so only the first occurrence is shown
+		{"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", day(2024, 1, 31), []time.Time{day(2024, 1, 31)}},
+	}
+	for _, c := range cases {
+		rule := make(map[string]string)
+		for _, part := range strings.Split(c.rrule, ";") {
+			key, value, _ := strings.Cut(part, "=")
+			rule[key] = value
+		}
+		starts := expandRRule(icsEvent{start: c.start, rrule: rule}, c.start, c.start.AddDate(2, 0, 0))
+		if fmt.Sprint(starts) != fmt.Sprint(c.expected) {
+			t.Errorf("Expected %s to give %v, got %v", c.rrule, c.expected, starts)
+		}
+	}
+}
+
+func TestParseICSAllDayAndTimezones(t *testing.T) {
+	events := parseICS(testICS)
+
+	var offsite, review *icsEvent
+	for i := range events {
+		switch events[i].uid {
+		case "offsite":
+			offsite = &events[i]
+		case "review":
+			review = &events[i]
+		}
+	}
+
+	if offsite == nil || !offsite.allDay {
+		t.Fatal("Expected offsite to be parsed as an all-day event")
+	}
+	if offsite.summary != "Offsite, Berlin" {
+		t.Errorf("Expected unescaped summary 'Offsite, Berlin', got '%s'", offsite.summary)
+	}
+	if offsite.end.Sub(offsite.start) != 24*time.Hour {
+		t.Errorf("Expected all-day event to last one day, got %v", offsite.end.Sub(offsite.start))
+	}
+	if review == nil || strings.Join(review.attendees, "; ") != "Lee, Ana; bo@example.com" {
+		t.Errorf("Expected attendees by name or address, got %v", review)
+	}
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("Europe/Berlin timezone data not available")
+	}
+	if review == nil || !review.start.Equal(time.Date(2024, 1, 9, 14, 0, 0, 0, berlin)) {
+		t.Errorf("Expected review to start at 14:00 Berlin time, got %v", review)
+	}
+}
+
+func TestICSCalendarPluginFetch(t *testing.T) {
+	now := time.Now().UTC()
+	upcoming := now.Add(2 * time.Hour).Format("20060102T150405Z")
+	feed := fmt.Sprintf("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:remote\r\nSUMMARY:Remote event\r\nDTSTART:%s\r\nDURATION:PT1H\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n", upcoming)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/calendar")
+		fmt.Fprint(w, feed)
+	}))
+	defer server.Close()
+
+	daily := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:daily\r\nSUMMARY:Daily focus\r\n" +
+		"DTSTART:20200101T080000Z\r\nDTEND:20200101T090000Z\r\nRRULE:FREQ=DAILY\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	path := filepath.Join(t.TempDir(), "personal.ics")
+	if err := os.WriteFile(path, []byte(daily), 0644); err != nil {
+		t.Fatalf("Failed to write ICS file: %v", err)
+	}
+
+	plugin := NewICSCalendarPlugin()
+	err := plugin.Initialize(map[string]interface{}{
+		"sources":    []interface{}{server.URL, path, filepath.Join(t.TempDir(), "missing.ics")},
+		"max_events": 4,
+		"days_ahead": 7,
+	})
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+
+	events, ok := data.([]CalendarEvent)
+	if !ok {
+		t.Fatalf("Expected []CalendarEvent, got %T", data)
+	}
+	if len(events) != 4 {
+		t.Fatalf("Expected events to be capped at 4, got %d", len(events))
+	}
+
+	sawRemote := false
+	for i, event := range events {
+		if i > 0 && event.StartTime.Before(events[i-1].StartTime) {
+			t.Error("Expected events to be sorted by start time")
+		}
+		if event.ID == "remote" {
+			sawRemote = true
+		}
+	}
+	if !sawRemote {
+		t.Error("Expected the event from the ICS URL to be included")
+	}
+
+	if items := plugin.FormatEventsForDisplay(); len(items) == 0 {
+		t.Error("Expected formatted calendar items")
+	}
+}
+
+func TestICSCalendarPluginFetchFailsWithoutSources(t *testing.T) {
+	plugin := NewICSCalendarPlugin()
+	if err := plugin.Initialize(map[string]interface{}{}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected Fetch to fail when no sources are configured")
+	}
+
+	if err := plugin.Initialize(map[string]interface{}{"sources": []string{"/nonexistent/calendar.ics"}}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected Fetch to fail when every source is unreadable")
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// instanceMode is how a dashboard shares the profile with one that is already running
+type instanceMode int
+
+const (
+	instanceOwn    instanceMode = iota // the only dashboard: it polls and notifies
+	instanceAttach                     // mirrors the running dashboard's state file
+	instanceManual                     // its own data, refreshed only with r, without notifications
+	instanceSteal                      // stops the running dashboard and takes over
+	instanceQuit                       // leaves the running dashboard alone
+)
+
+// attachInterval is how often an attached dashboard rereads the state file
+const attachInterval = 5 * time.Second
+
+// stealTimeout is how long to wait for a stopped dashboard to let go of the profile
+const stealTimeout = 5 * time.Second
+
+// askInstanceMode asks what to do about the dashboard already running for this profile
+func askInstanceMode(in io.Reader, out io.Writer, pid int) instanceMode {
+	running := "GoDay is already running"
+	if pid > 0 {
+		running += fmt.Sprintf(" (pid %d)", pid)
+	}
+	fmt.Fprintf(out, `%s for this profile.
+  [a] Attach: show its data here, without polling or notifications
+  [s] Steal: stop it and take over
+  [m] Manual: run alongside, refreshing only with r and without notifications
+  [q] Quit
+Choice [a]: `, running)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	switch {
+	case answer == "" && err == nil, answer == "a", answer == "attach":
+		return instanceAttach
+	case answer == "s", answer == "steal":
+		return instanceSteal
+	case answer == "m", answer == "manual":
+		return instanceManual
+	default:
+		// Including no answer at all, e.g. stdin closed
+		return instanceQuit
+	}
+}
+
+// StealInstance asks the dashboard with pid to quit and takes the single-instance lock
+// once it has
+func StealInstance(path string, pid int) (*InstanceLock, error) {
+	if pid <= 0 {
+		return nil, fmt.Errorf("the running dashboard did not record its pid")
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return nil, err
+	}
+	// Bubble Tea quits cleanly on an interrupt; Windows can only kill
+	if runtime.GOOS == "windows" {
+		err = process.Kill()
+	} else {
+		err = process.Signal(os.Interrupt)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("stopping pid %d: %w", pid, err)
+	}
+
+	deadline := time.Now().Add(stealTimeout)
+	for {
+		lock, _, err := AcquireInstanceLock(path)
+		if !errors.Is(err, errAlreadyRunning) {
+			return lock, err
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("pid %d is still running", pid)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// attachMsg carries the running dashboard's latest state file
+type attachMsg struct {
+	state *DashboardState
+	err   error
+}
+
+// attachCmd reads the state file the running dashboard writes, after delay
+func attachCmd(path string, delay time.Duration) tea.Cmd {
+	read := func() tea.Msg {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return attachMsg{err: err}
+		}
+		var state DashboardState
+		if err := json.Unmarshal(data, &state); err != nil {
+			return attachMsg{err: err}
+		}
+		return attachMsg{state: &state}
+	}
+	if delay == 0 {
+		return read
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg { return read() })
+}
+
+// attachInit starts mirroring the running dashboard when attached
+func (m Model) attachInit() tea.Cmd {
+	if m.instance != instanceAttach {
+		return nil
+	}
+	return attachCmd(m.statePath, 0)
+}
+
+// applyAttachedState shows the running dashboard's header and tiles. Tiles it does not
+// show keep their placeholder.
+func (m *Model) applyAttachedState(state *DashboardState) {
+	if state.Weather != "" {
+		m.weather = state.Weather
+	}
+	m.attachedAt = state.UpdatedAt
+	for i := range m.widgets {
+		widget, ok := state.Widgets[m.widgets[i].key]
+		if !ok {
+			continue
+		}
+		items := make([]WidgetItem, 0, len(widget.Items))
+		for _, item := range widget.Items {
+			items = append(items, WidgetItem{Title: item.Title, Subtitle: item.Subtitle, Status: item.Status, URL: item.URL})
+		}
+		m.widgets[i].UpdateItems(items)
+		m.widgets[i].count = widget.Count
+		m.widgets[i].mirrored = true
+		m.widgets[i].hasError = widget.Error
+	}
+}
+
+// instancePill describes a dashboard that shares its profile, or "" for the only one
+func (m Model) instancePill(now time.Time) string {
+	switch m.instance {
+	case instanceAttach:
+		if m.attachedAt.IsZero() {
+			return "🔗 Attached, waiting for data"
+		}
+		if now.Sub(m.attachedAt) < time.Minute {
+			return "🔗 Attached, updated just now"
+		}
+		return "🔗 Attached, updated " + formatAge(m.attachedAt, now) + " ago"
+	case instanceManual:
+		return "✋ Manual refresh"
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAskInstanceMode(t *testing.T) {
+	var out bytes.Buffer
+	if mode := askInstanceMode(strings.NewReader("s\n"), &out, 4242); mode != instanceSteal {
+		t.Errorf("Expected s to steal, got %v", mode)
+	}
+	if !strings.Contains(out.String(), "pid 4242") {
+		t.Errorf("Expected the prompt to name the running dashboard, got '%s'", out.String())
+	}
+
+	cases := map[string]instanceMode{
+		"\n":       instanceAttach,
+		"attach\n": instanceAttach,
+		"M\n":      instanceManual,
+		"q\n":      instanceQuit,
+		"x\n":      instanceQuit,
+		"":         instanceQuit,
+	}
+	for answer, expected := range cases {
+		if mode := askInstanceMode(strings.NewReader(answer), &out, 0); mode != expected {
+			t.Errorf("Expected %q to choose %v, got %v", answer, expected, mode)
+		}
+	}
+}
+
+func TestAttachMirrorsStateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	owner := Model{
+		weather: "☀ 21°C (Berlin)",
+		widgets: []WidgetTile{NewWidgetTile("prs", "Pull Requests", 40, 10)},
+	}
+	owner.widgets[0].UpdateItems([]WidgetItem{{Title: "Fix login", Status: "open", URL: "https://example.com/pr/1"}})
+	updatedAt := time.Now().Add(-2 * time.Minute)
+	if err := writeStateFile(path, owner.dashboardState(updatedAt)); err != nil {
+		t.Fatalf("writeStateFile failed: %v", err)
+	}
+
+	attached := Model{
+		instance: instanceAttach,
+		weather:  "☁ N/A (Berlin)",
+		widgets:  []WidgetTile{NewWidgetTile("prs", "Pull Requests", 40, 10), NewWidgetTile("news", "News", 40, 10)},
+	}
+	placeholder := len(attached.widgets[1].list.Items())
+	msg, ok := attachCmd(path, 0)().(attachMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("Expected the state file to be read, got %v", msg.err)
+	}
+	attached.applyAttachedState(msg.state)
+
+	if attached.weather != owner.weather {
+		t.Errorf("Expected the running dashboard's weather, got '%s'", attached.weather)
+	}
+	items := attached.widgets[0].list.Items()
+	if len(items) != 1 || items[0].(WidgetListItem).ItemTitle != "Fix login" || items[0].(WidgetListItem).URL != "https://example.com/pr/1" {
+		t.Errorf("Expected the running dashboard's PRs, got %v", items)
+	}
+	if len(attached.widgets[1].list.Items()) != placeholder {
+		t.Errorf("Expected a tile the running dashboard does not show to be left alone")
+	}
+	if pill := attached.instancePill(updatedAt.Add(2 * time.Minute)); !strings.Contains(pill, "Attached") || !strings.Contains(pill, "2m") {
+		t.Errorf("Expected the pill to show when the data was written, got '%s'", pill)
+	}
+}
+
+func TestInstanceModePolling(t *testing.T) {
+	m := Model{instance: instanceManual, versions: NewDataVersions(), refresh: NewRefreshProgress()}
+	m.versions.Fetched("news", time.Now())
+	if _, cmd := m.Update(fetchNewsCmd{}); cmd != nil {
+		t.Error("Expected a scheduled fetch to be dropped on manual refresh")
+	}
+
+	m.instance = instanceAttach
+	if _, cmd := m.Update(refreshNowMsg{fetch: fetchNewsCmd{}}); cmd != nil {
+		t.Error("Expected an attached dashboard not to poll")
+	}
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bhanu/goday/internal/fakeapis"
+)
+
+// newIntegrationModel builds the dashboard from a config file, the way the binary does,
+// with every plugin that goes to the network pointed at the fake APIs: GitHub, Jira,
+// OpenWeatherMap, OSRM, the news feed and the daylight times
+func newIntegrationModel(t *testing.T, api *fakeapis.Server) Model {
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GITHUB_TOKEN", "fake-token")
+	config := `version: 3
+user:
+  name: "Test User"
+  location: "Bengaluru,IN"
+widgets:
+  weather:
+    provider: openweathermap
+    api_key: "fake-key"
+    show_forecast: true
+  jira:
+    base_url: "` + server.URL + `"
+    email: "me@example.com"
+    api_token: "fake-token"
+  mentions:
+    sources: [jira]
+  news:
+    provider: rss
+    feeds:
+      - url: "` + server.URL + `/feed.xml"
+        label: "Fake News"
+  traffic:
+    origin:
+      address: "Electronic City, Bengaluru, Karnataka, India"
+    destination:
+      address: "Whitefield, Bengaluru, Karnataka, India"
+schedule:
+  priorities:
+    news: normal
+`
+	if err := os.MkdirAll(filepath.Join(home, ".goday"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".goday", "config.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := initialModel(nil)
+	if m.config == nil {
+		t.Fatal("Expected the test config to load")
+	}
+	m.statePath = ""
+	m.attention = nil // no desktop notifications from tests
+
+	registry := m.pluginManager.GetRegistry()
+	weather, _ := registry.GetPlugin(m.widgetPlugins["weather"])
+	weather.(*WeatherPlugin).apiURL = server.URL
+	prs, _ := registry.GetPlugin("github-prs")
+	prs.(*GitHubPRsPlugin).apiURL = server.URL
+	traffic, _ := registry.GetPlugin(m.widgetPlugins["traffic"])
+	traffic.(*OSRMTrafficPlugin).geocodeURL = server.URL
+	traffic.(*OSRMTrafficPlugin).routeURL = server.URL
+	daylight, _ := registry.GetPlugin(m.widgetPlugins["daylight"])
+	daylight.(*SunriseSunsetPlugin).apiURL = server.URL
+	daylight.(*SunriseSunsetPlugin).geocodeURL = server.URL
+	return m
+}
+
+// testProgram runs the dashboard in a Bubble Tea program, the way teatest does: keys and
+// messages go in through the program, and what it draws is kept for WaitFor
+type testProgram struct {
+	t       *testing.T
+	program *tea.Program
+	output  *programOutput
+	done    chan struct{} // closed once the program has ended
+	final   tea.Model
+	err     error
+}
+
+// programOutput is the terminal the program draws to
+type programOutput struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (o *programOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.buf.Write(p)
+}
+
+// Screen returns what the terminal shows. Each frame starts at the top left and rewrites
+// the lines that changed, moving past the others with a bare newline.
+func (o *programOutput) Screen() string {
+	o.mu.Lock()
+	frames := strings.Split(o.buf.String(), ansi.CursorHomePosition)
+	o.mu.Unlock()
+
+	var screen []string
+	for _, frame := range frames[1:] {
+		for i, line := range strings.Split(frame, "\n") {
+			for len(screen) <= i {
+				screen = append(screen, "")
+			}
+			if line != "" {
+				screen[i] = strings.TrimSuffix(strings.TrimSuffix(line, "\r"), ansi.EraseLineRight)
+			}
+		}
+	}
+	return strings.Join(screen, "\n")
+}
+
+// startProgram runs m in a 160x100 terminal, tall enough for every tile, until
+// FinalModel quits it
+func startProgram(t *testing.T, m Model) *testProgram {
+	tp := &testProgram{t: t, output: &programOutput{}, done: make(chan struct{})}
+	tp.program = tea.NewProgram(m, tea.WithInput(nil), tea.WithOutput(tp.output), tea.WithoutSignalHandler())
+	go func() {
+		tp.final, tp.err = tp.program.Run()
+		close(tp.done)
+	}()
+	// A test that stops early leaves no program running
+	t.Cleanup(func() {
+		tp.program.Kill()
+		<-tp.done
+	})
+	tp.program.Send(tea.WindowSizeMsg{Width: 160, Height: 100})
+	return tp
+}
+
+// Press types a key
+func (tp *testProgram) Press(k string) {
+	tp.program.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+}
+
+// WaitFor waits until the screen meets condition
+func (tp *testProgram) WaitFor(condition func(screen string) bool) {
+	tp.t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		screen := tp.output.Screen()
+		if condition(screen) {
+			return
+		}
+		if time.Now().After(deadline) {
+			tp.t.Fatalf("Expected the dashboard to change, got:\n%s", screen)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// showing is a WaitFor condition: the screen shows all of texts, and none of hidden
+func showing(texts []string, hidden ...string) func(string) bool {
+	return func(screen string) bool {
+		for _, text := range texts {
+			if !strings.Contains(screen, text) {
+				return false
+			}
+		}
+		for _, text := range hidden {
+			if strings.Contains(screen, text) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// integrationData is what the dashboard shows from the fake APIs
+var integrationData = []string{"21°C", "Add fake upstream server", "GD-101", "45 min", "How we test the dashboard"}
+
+// FinalModel quits the program and returns the model as it left it
+func (tp *testProgram) FinalModel() Model {
+	tp.t.Helper()
+	tp.Press("q")
+	select {
+	case <-tp.done:
+	case <-time.After(10 * time.Second):
+		tp.t.Fatal("Expected the program to quit")
+	}
+	if tp.err != nil {
+		tp.t.Fatalf("Program failed: %v", tp.err)
+	}
+	return tp.final.(Model)
+}
+
+// tileText joins the titles and subtitles a tile shows
+func tileText(m Model, key string) string {
+	tile := m.tileByKey(key)
+	if tile == nil {
+		return ""
+	}
+	var text []string
+	for _, item := range tile.list.Items() {
+		if listItem, ok := item.(WidgetListItem); ok {
+			text = append(text, listItem.ItemTitle, listItem.Subtitle)
+		}
+	}
+	return strings.Join(text, "\n")
+}
+
+func TestIntegrationNormal(t *testing.T) {
+	api := fakeapis.New()
+	tp := startProgram(t, newIntegrationModel(t, api))
+	tp.WaitFor(showing(integrationData))
+	m := tp.FinalModel()
+
+	if text := tileText(m, "weather"); !strings.Contains(text, "Rain") {
+		t.Errorf("Expected the forecast in the weather tile, got '%s'", text)
+	}
+	if text := tileText(m, "traffic"); !strings.Contains(text, "45 min") || !strings.Contains(text, "55 min") {
+		t.Errorf("Expected both commute directions, got '%s'", text)
+	}
+	for _, key := range []string{"weather", "prs", "mentions", "traffic", "news"} {
+		if tile := m.tileByKey(key); tile == nil || tile.hasError {
+			t.Errorf("Expected the %s tile without an error", key)
+		}
+	}
+	if m.daylight == nil || m.daylight.Sunset.Sub(m.daylight.Sunrise) != 12*time.Hour+5*time.Minute {
+		t.Errorf("Expected the fake sunrise and sunset, got %+v", m.daylight)
+	}
+
+	// A scheduled fetch right after the startup fetch does not call the API again
+	requests := api.Requests(fakeapis.OSRM)
+	m.Update(fetchTrafficCmd{})
+	if api.Requests(fakeapis.OSRM) != requests {
+		t.Error("Expected the scheduled fetch to collapse into the startup fetch")
+	}
+}
+
+func TestIntegrationFailures(t *testing.T) {
+	for _, tc := range []struct {
+		scenario fakeapis.Scenario
+		reason   string // expected in the error shown on the mentions tile
+	}{
+		{fakeapis.ServerError, "status 500"},
+		{fakeapis.RateLimited, "status 429"},
+		{fakeapis.AuthError, "status 401"},
+	} {
+		t.Run(string(tc.scenario), func(t *testing.T) {
+			api := fakeapis.New()
+			tp := startProgram(t, newIntegrationModel(t, api))
+			tp.WaitFor(showing(integrationData))
+
+			api.SetScenario(tc.scenario)
+			tp.Press("r")
+			tp.WaitFor(showing([]string{tc.reason}))
+
+			// Everything recovers on the next refresh
+			api.SetScenario(fakeapis.Normal)
+			tp.Press("r")
+			tp.WaitFor(showing(integrationData, tc.reason))
+			m := tp.FinalModel()
+			for _, key := range []string{"weather", "mentions", "traffic"} {
+				if tile := m.tileByKey(key); tile == nil || tile.hasError {
+					t.Errorf("Expected the %s tile to recover", key)
+				}
+			}
+			if text := tileText(m, "prs"); !strings.Contains(text, "Add fake upstream server") {
+				t.Errorf("Expected the PRs after recovering, got '%s'", text)
+			}
+		})
+	}
+}
+
+func TestIntegrationFailuresKeepLastData(t *testing.T) {
+	api := fakeapis.New()
+	tp := startProgram(t, newIntegrationModel(t, api))
+	tp.WaitFor(showing(integrationData))
+
+	api.SetScenario(fakeapis.ServerError)
+	tp.Press("r")
+	tp.WaitFor(showing([]string{"status 500"}))
+	m := tp.FinalModel()
+	for _, key := range []string{"weather", "mentions", "traffic"} {
+		if tile := m.tileByKey(key); tile == nil || !tile.hasError {
+			t.Errorf("Expected the %s tile to show the error", key)
+		}
+	}
+	// The PR tile keeps the PRs it had
+	if text := tileText(m, "prs"); !strings.Contains(text, "Add fake upstream server") {
+		t.Errorf("Expected the last PRs to stay, got '%s'", text)
+	}
+	// The header keeps the last weather rather than showing a made-up one
+	if !strings.Contains(m.weather, "21°C") {
+		t.Errorf("Expected the last weather in the header, got '%s'", m.weather)
+	}
+}
+
+func TestIntegrationGitHubRateLimit(t *testing.T) {
+	api := fakeapis.New()
+	m := newIntegrationModel(t, api)
+	plugin, _ := m.pluginManager.GetRegistry().GetPlugin("github-prs")
+	tp := startProgram(t, m)
+	tp.WaitFor(showing([]string{"Add fake upstream server"}))
+	if rl, ok := plugin.(RateLimited).RateLimit(); !ok || rl.Remaining != fakeapis.GitHubRateLimit-1 {
+		t.Errorf("Expected the rate limit headers to be read, got %+v", rl)
+	}
+
+	// The PRs tile keeps its PRs; the exhausted budget is what changes
+	api.SetScenario(fakeapis.RateLimited, fakeapis.GitHub)
+	tp.Press("r")
+	tp.WaitFor(func(string) bool {
+		rl, _ := plugin.(RateLimited).RateLimit()
+		return rl.Remaining == 0
+	})
+	tp.FinalModel()
+	if rl, _ := plugin.(RateLimited).RateLimit(); !rl.NearExhaustion() {
+		t.Errorf("Expected the exhausted budget to be recorded, got %+v", rl)
+	}
+}
+
+func TestIntegrationSlow(t *testing.T) {
+	api := fakeapis.New()
+	api.SlowDelay = 200 * time.Millisecond
+	api.SetScenario(fakeapis.Slow, fakeapis.Jira)
+
+	start := time.Now()
+	tp := startProgram(t, newIntegrationModel(t, api))
+	tp.WaitFor(showing([]string{"GD-101"}))
+	if elapsed := time.Since(start); elapsed < api.SlowDelay {
+		t.Errorf("Expected the fetch to wait for the slow API, took %v", elapsed)
+	}
+	tp.FinalModel()
+}
@@ -0,0 +1,290 @@
+// Package fakeapis emulates the upstream APIs GoDay talks to — GitHub search, Jira
+// search, OpenWeatherMap, Nominatim + OSRM routing, an RSS news feed and Open-Meteo
+// geocoding + sunrise-sunset.org — with canned data, so the dashboard can be exercised
+// end to end without network access or credentials.
+//
+// Each service can be switched to a failure scenario (slow responses, server errors,
+// rate limiting or rejected credentials) while the dashboard is running.
+package fakeapis
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scenario is how a service answers requests
+type Scenario string
+
+const (
+	// Normal answers with the canned data
+	Normal Scenario = "normal"
+	// Slow answers with the canned data after SlowDelay
+	Slow Scenario = "slow"
+	// ServerError answers with status 500
+	ServerError Scenario = "500"
+	// RateLimited answers as the service does once the request budget is used up
+	RateLimited Scenario = "rate-limited"
+	// AuthError answers as the service does for a missing or revoked credential
+	AuthError Scenario = "auth-error"
+)
+
+// Scenarios lists every scenario
+var Scenarios = []Scenario{Normal, Slow, ServerError, RateLimited, AuthError}
+
+// ParseScenario returns the scenario with the given name
+func ParseScenario(name string) (Scenario, error) {
+	for _, scenario := range Scenarios {
+		if string(scenario) == name {
+			return scenario, nil
+		}
+	}
+	return "", fmt.Errorf("unknown scenario %q", name)
+}
+
+// Service is an upstream API the server emulates
+type Service string
+
+const (
+	GitHub         Service = "github"
+	Jira           Service = "jira"
+	OpenWeatherMap Service = "openweathermap"
+	OSRM           Service = "osrm"     // includes Nominatim geocoding
+	News           Service = "news"     // an RSS feed
+	Daylight       Service = "daylight" // Open-Meteo geocoding and sunrise-sunset.org
+)
+
+// Services lists every emulated service
+var Services = []Service{GitHub, Jira, OpenWeatherMap, OSRM, News, Daylight}
+
+// GitHubRateLimit is the search budget the fake GitHub reports
+const GitHubRateLimit = 30
+
+// Server is an http.Handler serving every emulated API from one address, as each
+// plugin takes its base URL separately
+type Server struct {
+	// SlowDelay is how long the slow scenario holds each response
+	SlowDelay time.Duration
+
+	mu        sync.Mutex
+	scenarios map[Service]Scenario
+	requests  map[Service]int
+	mux       *http.ServeMux
+}
+
+// New creates a server answering every service normally
+func New() *Server {
+	s := &Server{
+		SlowDelay: 2 * time.Second,
+		scenarios: make(map[Service]Scenario),
+		requests:  make(map[Service]int),
+		mux:       http.NewServeMux(),
+	}
+	s.mux.HandleFunc("GET /search/issues", s.handle(GitHub, githubSearch))
+	s.mux.HandleFunc("GET /rest/api/2/search", s.handle(Jira, jiraSearch))
+	s.mux.HandleFunc("GET /data/2.5/weather", s.handle(OpenWeatherMap, owmWeather))
+	s.mux.HandleFunc("GET /data/2.5/forecast", s.handle(OpenWeatherMap, owmForecast))
+	s.mux.HandleFunc("GET /search", s.handle(OSRM, nominatimSearch))
+	s.mux.HandleFunc("GET /route/v1/driving/{coordinates}", s.handle(OSRM, osrmRoute))
+	s.mux.HandleFunc("GET /feed.xml", s.handle(News, rssFeed))
+	s.mux.HandleFunc("GET /v1/search", s.handle(Daylight, openMeteoSearch))
+	s.mux.HandleFunc("GET /json", s.handle(Daylight, sunriseSunset))
+	return s
+}
+
+// SetScenario switches the given services, or every service when none are given
+func (s *Server) SetScenario(scenario Scenario, services ...Service) {
+	if len(services) == 0 {
+		services = Services
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, service := range services {
+		s.scenarios[service] = scenario
+	}
+}
+
+// Scenario returns how a service currently answers
+func (s *Server) Scenario(service Service) Scenario {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if scenario, ok := s.scenarios[service]; ok {
+		return scenario
+	}
+	return Normal
+}
+
+// Requests returns how many requests a service has received
+func (s *Server) Requests(service Service) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[service]
+}
+
+// ServeHTTP routes a request to the emulated API it belongs to
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handle counts a service's requests and applies its scenario before the canned answer
+func (s *Server) handle(service Service, serve http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[service]++
+		s.mu.Unlock()
+
+		switch scenario := s.Scenario(service); scenario {
+		case Slow:
+			select {
+			case <-time.After(s.SlowDelay):
+			case <-r.Context().Done():
+				return
+			}
+		case ServerError, RateLimited, AuthError:
+			fail(w, service, scenario)
+			return
+		}
+		if service == GitHub {
+			setGitHubRateLimit(w, GitHubRateLimit-1)
+		}
+		serve(w, r)
+	}
+}
+
+// fail answers the way the service reports the scenario's failure
+func fail(w http.ResponseWriter, service Service, scenario Scenario) {
+	status, body := http.StatusInternalServerError, `{"message":"Internal Server Error"}`
+	switch scenario {
+	case RateLimited:
+		status = http.StatusTooManyRequests
+		w.Header().Set("Retry-After", "60")
+		body = `{"message":"Too Many Requests"}`
+		if service == GitHub {
+			// GitHub answers 403 and reports the exhausted budget in headers
+			status = http.StatusForbidden
+			setGitHubRateLimit(w, 0)
+			body = `{"message":"API rate limit exceeded for user ID 1."}`
+		}
+	case AuthError:
+		status = http.StatusUnauthorized
+		switch service {
+		case GitHub:
+			body = `{"message":"Bad credentials"}`
+		case Jira:
+			body = `{"errorMessages":["You are not authenticated. Authentication required to perform this operation."]}`
+		case OpenWeatherMap:
+			body = `{"cod":401,"message":"Invalid API key. Please see https://openweathermap.org/faq#error401 for more info."}`
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	fmt.Fprint(w, body)
+}
+
+// setGitHubRateLimit sets the rate limit headers GitHub sends with every response
+func setGitHubRateLimit(w http.ResponseWriter, remaining int) {
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(GitHubRateLimit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+}
+
+func writeJSON(w http.ResponseWriter, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, body)
+}
+
+// githubSearch answers a pull request search with one PR by a person and one by a bot
+func githubSearch(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, `{"total_count":2,"incomplete_results":false,"items":[
+		{"number":42,"title":"Add fake upstream server","state":"open","draft":false,
+		 "user":{"login":"octocat","type":"User"},"labels":[{"name":"testing"}],
+		 "created_at":"2026-10-14T08:00:00Z","updated_at":"2026-10-16T07:30:00Z",
+		 "html_url":"https://github.com/bhanu-lab/goday/pull/42",
+		 "repository_url":"https://api.github.com/repos/bhanu-lab/goday"},
+		{"number":41,"title":"Bump gopkg.in/yaml.v3","state":"open","draft":false,
+		 "user":{"login":"dependabot[bot]","type":"Bot"},"labels":[{"name":"dependencies"}],
+		 "created_at":"2026-10-13T02:00:00Z","updated_at":"2026-10-15T02:00:00Z",
+		 "html_url":"https://github.com/bhanu-lab/goday/pull/41",
+		 "repository_url":"https://api.github.com/repos/bhanu-lab/goday"}]}`)
+}
+
+// jiraSearch answers any JQL with one issue
+func jiraSearch(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, `{"startAt":0,"maxResults":20,"total":1,"issues":[
+		{"key":"GD-101","fields":{"summary":"Dashboard flickers on resize",
+		 "updated":"2026-10-16T09:30:00.000+0000","status":{"name":"In Progress"}}}]}`)
+}
+
+// owmWeather answers the current weather for any city
+func owmWeather(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, `{"weather":[{"id":500,"main":"Rain","description":"light rain"}],
+		"main":{"temp":21.4,"humidity":83},"name":"Bengaluru","cod":200}`)
+}
+
+// owmForecast answers a two-day forecast in three-hour steps
+func owmForecast(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, `{"cod":"200","city":{"name":"Bengaluru","timezone":19800},"list":[
+		{"dt":1792137600,"main":{"temp_min":19.2,"temp_max":24.8},"weather":[{"id":500,"main":"Rain"}]},
+		{"dt":1792148400,"main":{"temp_min":20.1,"temp_max":26.3},"weather":[{"id":803,"main":"Clouds"}]},
+		{"dt":1792224000,"main":{"temp_min":18.7,"temp_max":27.0},"weather":[{"id":800,"main":"Clear"}]}]}`)
+}
+
+// nominatimSearch places Whitefield in the east of Bengaluru and anything else in
+// Electronic City
+func nominatimSearch(w http.ResponseWriter, r *http.Request) {
+	if strings.Contains(strings.ToLower(r.URL.Query().Get("q")), "whitefield") {
+		writeJSON(w, `[{"lat":"12.9698","lon":"77.7500","display_name":"Whitefield, Bengaluru"}]`)
+		return
+	}
+	writeJSON(w, `[{"lat":"12.8452","lon":"77.6602","display_name":"Electronic City, Bengaluru"}]`)
+}
+
+// osrmRoute answers 45 minutes for routes heading east and 55 minutes for the way back
+func osrmRoute(w http.ResponseWriter, r *http.Request) {
+	from, to, ok := strings.Cut(r.PathValue("coordinates"), ";")
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		writeJSON(w, `{"code":"InvalidQuery","message":"Query string malformed"}`)
+		return
+	}
+	fromLon, _ := strconv.ParseFloat(strings.Split(from, ",")[0], 64)
+	toLon, _ := strconv.ParseFloat(strings.Split(to, ",")[0], 64)
+	duration := 3300.0
+	if fromLon < toLon {
+		duration = 2700
+	}
+	writeJSON(w, fmt.Sprintf(`{"code":"Ok","routes":[{"duration":%.1f,"distance":24300.0,
+		"legs":[{"duration":%.1f,"distance":24300.0}]}]}`, duration, duration))
+}
+
+// rssFeed answers with two articles published in the last hours
+func rssFeed(w http.ResponseWriter, r *http.Request) {
+	now := time.Now().UTC()
+	w.Header().Set("Content-Type", "application/rss+xml")
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"><channel><title>Fake News</title><link>https://news.example.com</link>
+<item><title>How we test the dashboard without the network</title>
+ <link>https://news.example.com/offline-tests</link><pubDate>%s</pubDate>
+ <description>Every upstream API is emulated with canned data.</description></item>
+<item><title>Why the terminal is the best place for a dashboard</title>
+ <link>https://news.example.com/terminal</link><pubDate>%s</pubDate>
+ <description>A look at the tools we keep open all day.</description></item>
+</channel></rss>`, now.Add(-2*time.Hour).Format(time.RFC1123Z), now.Add(-5*time.Hour).Format(time.RFC1123Z))
+}
+
+// openMeteoSearch places any city in Bengaluru
+func openMeteoSearch(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, `{"results":[{"name":"Bengaluru","latitude":12.97194,"longitude":77.59369,"country_code":"IN"}]}`)
+}
+
+// sunriseSunset answers sunrise at 06:15 and sunset at 18:20 Indian time on the asked day
+func sunriseSunset(w http.ResponseWriter, r *http.Request) {
+	day := r.URL.Query().Get("date")
+	if _, err := time.Parse("2006-01-02", day); err != nil {
+		day = time.Now().UTC().Format("2006-01-02")
+	}
+	writeJSON(w, fmt.Sprintf(`{"results":{"sunrise":"%[1]sT00:45:00+00:00","sunset":"%[1]sT12:50:00+00:00"},"status":"OK"}`, day))
+}
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Triage actions that can be applied to an issue from the triage panel
+const (
+	triageLabel  = "label"
+	triageAssign = "assign"
+	triageClose  = "close"
+)
+
+// defaultTriageLabels are the labels on the 1-9 keys when none are configured
+var defaultTriageLabels = []string{"bug", "enhancement", "question", "duplicate"}
+
+// defaultCloseComment prefills the comment posted when closing an issue
+const defaultCloseComment = "Closing this as not planned. Thanks for the report!"
+
+// TriageIssue is an open issue waiting for triage
+type TriageIssue struct {
+	Repository string
+	Number     int
+	Title      string
+	Author     string
+	URL        string
+	CreatedAt  time.Time
+}
+
+// triageAction is an action picked in the triage panel for one issue
+type triageAction struct {
+	Issue TriageIssue
+	Kind  string
+	Arg   string // label name or closing comment
+}
+
+// triageIssuesMsg carries the issues to triage back to the TUI
+type triageIssuesMsg struct {
+	issues []TriageIssue
+	err    error
+}
+
+// triageResultMsg carries the outcome of a triage action back to the TUI
+type triageResultMsg struct {
+	action triageAction
+	err    error
+}
+
+// IssueTriager finds unlabeled issues in the repos the user maintains and triages them
+type IssueTriager struct {
+	githubAPI
+	repos        []string
+	labels       []string
+	closeComment string
+}
+
+// NewIssueTriager creates a triager from config. Without widgets.issues.repos it
+// searches the repos owned by the user and the github-prs orgs.
+func NewIssueTriager(cfg *Config) *IssueTriager {
+	triager := &IssueTriager{
+		githubAPI:    newGitHubAPI(cfg),
+		labels:       defaultTriageLabels,
+		closeComment: defaultCloseComment,
+	}
+	if cfg != nil {
+		triager.repos = cfg.Widgets.Issues.Repos
+		if len(cfg.Widgets.Issues.Labels) > 0 {
+			triager.labels = cfg.Widgets.Issues.Labels
+		}
+		if cfg.Widgets.Issues.CloseComment != "" {
+			triager.closeComment = cfg.Widgets.Issues.CloseComment
+		}
+	}
+	return triager
+}
+
+// List returns open issues without labels, newest first
+func (t *IssueTriager) List(ctx context.Context) ([]TriageIssue, error) {
+	if t.token == "" {
+		return nil, fmt.Errorf("a GitHub token is required to triage issues")
+	}
+
+	qualifiers := []string{"type:issue", "is:open", "no:label", "archived:false"}
+	if len(t.repos) > 0 {
+		for _, repo := range t.repos {
+			qualifiers = append(qualifiers, "repo:"+repo)
+		}
+	} else {
+		owners, err := t.ownerQualifiers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		qualifiers = append(qualifiers, owners...)
+	}
+
+	params := url.Values{}
+	params.Set("q", strings.Join(qualifiers, " "))
+	params.Set("sort", "created")
+	params.Set("order", "desc")
+	params.Set("per_page", "50")
+
+	var result struct {
+		Items []struct {
+			Number int    `json:"number"`
+			Title  string `json:"title"`
+			User   struct {
+				Login string `json:"login"`
+			} `json:"user"`
+			HTMLURL       string    `json:"html_url"`
+			RepositoryURL string    `json:"repository_url"`
+			CreatedAt     time.Time `json:"created_at"`
+		} `json:"items"`
+	}
+	if err := t.do(ctx, "GET", "/search/issues?"+params.Encode(), nil, &result); err != nil {
+		return nil, err
+	}
+
+	issues := make([]TriageIssue, 0, len(result.Items))
+	for _, item := range result.Items {
+		issues = append(issues, TriageIssue{
+			Repository: repositoryFromURL(item.RepositoryURL),
+			Number:     item.Number,
+			Title:      item.Title,
+			Author:     item.User.Login,
+			URL:        item.HTMLURL,
+			CreatedAt:  item.CreatedAt,
+		})
+	}
+	return issues, nil
+}
+
+// Apply labels, assigns or closes an issue. Issues are assigned to the token's user,
+// and closed as not planned after posting the comment, if any.
+func (t *IssueTriager) Apply(ctx context.Context, action triageAction) error {
+	issuePath := fmt.Sprintf("/repos/%s/issues/%d", action.Issue.Repository, action.Issue.Number)
+
+	switch action.Kind {
+	case triageLabel:
+		return t.do(ctx, "POST", issuePath+"/labels", map[string][]string{"labels": {action.Arg}}, nil)
+	case triageAssign:
+		user, err := t.login(ctx)
+		if err != nil {
+			return err
+		}
+		return t.do(ctx, "POST", issuePath+"/assignees", map[string][]string{"assignees": {user}}, nil)
+	case triageClose:
+		if action.Arg != "" {
+			if err := t.do(ctx, "POST", issuePath+"/comments", map[string]string{"body": action.Arg}, nil); err != nil {
+				return err
+			}
+		}
+		return t.do(ctx, "PATCH", issuePath, map[string]string{"state": "closed", "state_reason": "not_planned"}, nil)
+	}
+	return fmt.Errorf("unknown triage action %q", action.Kind)
+}
+
+// listTriageIssuesCmd finds issues to triage in the background
+func listTriageIssuesCmd(triager *IssueTriager) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+
+		issues, err := triager.List(ctx)
+		return triageIssuesMsg{issues: issues, err: err}
+	}
+}
+
+// runTriageActionCmd applies a triage action in the background
+func runTriageActionCmd(triager *IssueTriager, action triageAction) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+
+		return triageResultMsg{action: action, err: triager.Apply(ctx, action)}
+	}
+}
+
+// IssueTriagePanel is the overlay listing issues to triage with quick label, assign and close keys
+type IssueTriagePanel struct {
+	issues       []TriageIssue
+	selected     int
+	loading      bool
+	labels       []string
+	closeComment string
+	input        textinput.Model
+	prompt       string            // triage action the input is for, empty when not typing
+	outcomes     map[string]string // result shown next to each issue URL
+	err          string
+}
+
+// NewIssueTriagePanel creates a panel that waits for its issues to load
+func NewIssueTriagePanel(labels []string, closeComment string) *IssueTriagePanel {
+	input := textinput.New()
+	input.CharLimit = 500
+
+	return &IssueTriagePanel{
+		loading:      true,
+		labels:       labels,
+		closeComment: closeComment,
+		input:        input,
+		outcomes:     make(map[string]string),
+	}
+}
+
+// Update handles a key press. It reports whether the panel should close, the triage
+// action to run (if any) and a URL to open (if any).
+func (p *IssueTriagePanel) Update(msg tea.KeyMsg) (done bool, run *triageAction, open string) {
+	if p.prompt != "" {
+		switch msg.String() {
+		case "enter":
+			value := strings.TrimSpace(p.input.Value())
+			if value == "" && p.prompt == triageLabel {
+				return false, nil, ""
+			}
+			run = p.start(p.prompt, value)
+			p.prompt = ""
+			p.input.Blur()
+		case "esc":
+			p.prompt = ""
+			p.input.Blur()
+		default:
+			p.input, _ = p.input.Update(msg)
+		}
+		return false, run, ""
+	}
+
+	key := msg.String()
+	switch key {
+	case "esc", "q", "i":
+		return true, nil, ""
+	case "up", "k":
+		if p.selected > 0 {
+			p.selected--
+		}
+	case "down", "j":
+		if p.selected < len(p.issues)-1 {
+			p.selected++
+		}
+	case "enter":
+		if p.selected < len(p.issues) {
+			return false, nil, p.issues[p.selected].URL
+		}
+	case "a":
+		return false, p.start(triageAssign, ""), ""
+	case "l", "c":
+		if p.selected < len(p.issues) {
+			p.prompt = triageLabel
+			p.input.Prompt = "Label: "
+			p.input.SetValue("")
+			if key == "c" {
+				p.prompt = triageClose
+				p.input.Prompt = "Comment: "
+				p.input.SetValue(p.closeComment)
+			}
+			p.input.Focus()
+		}
+	default:
+		// 1-9 apply the quick labels
+		if len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+			if n := int(key[0] - '1'); n < len(p.labels) {
+				return false, p.start(triageLabel, p.labels[n]), ""
+			}
+		}
+	}
+	return false, nil, ""
+}
+
+// start returns the action for the selected issue and marks it as in progress
+func (p *IssueTriagePanel) start(kind, arg string) *triageAction {
+	if p.selected >= len(p.issues) {
+		return nil
+	}
+	issue := p.issues[p.selected]
+	p.outcomes[issue.URL] = "🔄"
+	return &triageAction{Issue: issue, Kind: kind, Arg: arg}
+}
+
+// SetIssues shows the issues found
+func (p *IssueTriagePanel) SetIssues(msg triageIssuesMsg) {
+	p.loading = false
+	p.issues = msg.issues
+	p.selected = 0
+	if msg.err != nil {
+		p.err = msg.err.Error()
+	}
+}
+
+// SetResult records the outcome of a triage action next to its issue
+func (p *IssueTriagePanel) SetResult(msg triageResultMsg) {
+	outcome := "❌ " + fmt.Sprint(msg.err)
+	if msg.err == nil {
+		switch msg.action.Kind {
+		case triageLabel:
+			outcome = "🏷 " + msg.action.Arg
+		case triageAssign:
+			outcome = "👤 assigned"
+		case triageClose:
+			outcome = "✖ closed"
+		}
+	}
+	p.outcomes[msg.action.Issue.URL] = outcome
+}
+
+// View renders the panel as a bordered box
+func (p *IssueTriagePanel) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colorTitle)
+	selectedStyle := lipgloss.NewStyle().Foreground(colorAccent).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(colorMuted).Italic(true)
+	errorStyle := lipgloss.NewStyle().Foreground(colorAlert)
+
+	lines := []string{titleStyle.Render("Issue Triage"), ""}
+	switch {
+	case p.loading:
+		lines = append(lines, "🔄 Finding unlabeled issues...")
+	case p.err != "":
+		lines = append(lines, errorStyle.Render("❌ "+p.err))
+	case len(p.issues) == 0:
+		lines = append(lines, dimStyle.Render("No unlabeled issues - all triaged"))
+	}
+
+	start := 0
+	if p.selected >= maxPaletteRows {
+		start = p.selected - maxPaletteRows + 1
+	}
+	for i := start; i < len(p.issues) && i < start+maxPaletteRows; i++ {
+		issue := p.issues[i]
+		line := fmt.Sprintf("%s#%d %s  %s", issue.Repository, issue.Number, issue.Title,
+			dimStyle.Render(fmt.Sprintf("@%s • %s", issue.Author, formatTimeAgo(issue.CreatedAt))))
+		if outcome, ok := p.outcomes[issue.URL]; ok {
+			line += "  " + outcome
+		}
+		if i == p.selected {
+			lines = append(lines, selectedStyle.Render("▶ ")+line)
+		} else {
+			lines = append(lines, "  "+line)
+		}
+	}
+
+	lines = append(lines, "")
+	if p.prompt != "" {
+		lines = append(lines, p.input.View(), dimStyle.Render("Enter apply • Esc cancel"))
+	} else {
+		var quick []string
+		for i, label := range p.labels {
+			if i == 9 {
+				break
+			}
+			quick = append(quick, fmt.Sprintf("%d %s", i+1, label))
+		}
+		lines = append(lines,
+			dimStyle.Render(strings.Join(quick, " • ")),
+			dimStyle.Render("l label • a assign me • c close with comment • Enter open • Esc close"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Padding(1, 2).
+		Width(80).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestIssueTriagerListAndApply(t *testing.T) {
+	var query string
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/user":
+			fmt.Fprint(w, `{"login":"octocat"}`)
+		case r.URL.Path == "/search/issues":
+			query = r.URL.Query().Get("q")
+			fmt.Fprint(w, `{"items":[{"number":7,"title":"Crash on start","user":{"login":"alice"},"html_url":"https://github.com/octocat/api/issues/7","repository_url":"https://api.github.com/repos/octocat/api","created_at":"2024-01-02T10:00:00Z"}]}`)
+		default:
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			requests = append(requests, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, body))
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &Config{
+		Plugins:  map[string]map[string]interface{}{"github-prs": {"github_token": "token"}},
+		Features: map[string]bool{FeatureWriteActions: true},
+	}
+	cfg.Widgets.Issues.Repos = []string{"octocat/api", "octocat/web"}
+	triager := NewIssueTriager(cfg)
+	triager.apiURL = server.URL
+
+	issues, err := triager.List(context.Background())
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if query != "type:issue is:open no:label archived:false repo:octocat/api repo:octocat/web" {
+		t.Errorf("Unexpected search query '%s'", query)
+	}
+	if len(issues) != 1 || issues[0].Repository != "octocat/api" || issues[0].Number != 7 || issues[0].Author != "alice" {
+		t.Fatalf("Expected octocat/api#7 by alice, got %+v", issues)
+	}
+
+	issue := issues[0]
+	for _, action := range []triageAction{
+		{Issue: issue, Kind: triageLabel, Arg: "bug"},
+		{Issue: issue, Kind: triageAssign},
+		{Issue: issue, Kind: triageClose, Arg: "Duplicate of #3"},
+	} {
+		if err := triager.Apply(context.Background(), action); err != nil {
+			t.Errorf("%s failed: %v", action.Kind, err)
+		}
+	}
+
+	expected := []string{
+		"POST /repos/octocat/api/issues/7/labels map[labels:[bug]]",
+		"POST /repos/octocat/api/issues/7/assignees map[assignees:[octocat]]",
+		"POST /repos/octocat/api/issues/7/comments map[body:Duplicate of #3]",
+		"PATCH /repos/octocat/api/issues/7 map[state:closed state_reason:not_planned]",
+	}
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected requests:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(requests, "\n"))
+	}
+}
+
+func TestIssueTriagePanelKeys(t *testing.T) {
+	key := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	panel := NewIssueTriagePanel([]string{"bug", "docs"}, "Thanks!")
+	issue := TriageIssue{Repository: "octocat/api", Number: 7, Title: "Crash", URL: "https://github.com/octocat/api/issues/7"}
+	panel.SetIssues(triageIssuesMsg{issues: []TriageIssue{issue}})
+
+	if _, run, _ := panel.Update(key("2")); run == nil || run.Kind != triageLabel || run.Arg != "docs" {
+		t.Errorf("Expected 2 to apply the second quick label, got %+v", run)
+	}
+	if _, run, _ := panel.Update(key("3")); run != nil {
+		t.Errorf("Expected no action for a key without a quick label, got %+v", run)
+	}
+
+	// c opens a prefilled comment; Enter closes with it
+	panel.Update(key("c"))
+	if _, run, _ := panel.Update(key("x")); run != nil {
+		t.Error("Expected typing into the comment not to run an action")
+	}
+	_, run, _ := panel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if run == nil || run.Kind != triageClose || run.Arg != "Thanks!x" {
+		t.Errorf("Expected a close with comment 'Thanks!x', got %+v", run)
+	}
+
+	panel.SetResult(triageResultMsg{action: *run})
+	if !strings.Contains(panel.View(), "✖ closed") {
+		t.Errorf("Expected the issue to be marked closed, got:\n%s", panel.View())
+	}
+
+	if done, _, _ := panel.Update(key("i")); !done {
+		t.Error("Expected i to close the panel")
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// itemDetailMaxLines caps the details shown, so a long PR body or description still
+// fits on the screen
+const itemDetailMaxLines = 20
+
+// renderItemDetail shows everything known about an item: its whole title, subtitle and
+// link, and the details its widget adds, wrapped at width
+func renderItemDetail(item WidgetListItem, width int) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colorTitle)
+	subtitleStyle := lipgloss.NewStyle().Foreground(colorMuted)
+	urlStyle := lipgloss.NewStyle().Foreground(colorAccent)
+	helpStyle := lipgloss.NewStyle().Foreground(colorMuted).Italic(true)
+
+	width = max(width, 20)
+	wrap := lipgloss.NewStyle().Width(width)
+
+	title := item.ItemTitle
+	if item.Status != "" {
+		title += " " + item.Status
+	}
+	lines := []string{wrap.Inherit(titleStyle).Render(title)}
+	if item.Subtitle != "" {
+		lines = append(lines, wrap.Inherit(subtitleStyle).Render(item.Subtitle))
+	}
+
+	// Details up to the first blank one are shown as they are; the description after it
+	// is rendered as markdown
+	var details []string
+	for i, detail := range item.Details {
+		if detail == "" && i > 0 {
+			if description := strings.TrimSpace(strings.Join(item.Details[i+1:], "\n")); description != "" {
+				details = append(details, "", renderMarkdown(strings.ReplaceAll(description, "\r\n", "\n"), width))
+			}
+			break
+		}
+		details = append(details, wrap.Render(strings.ReplaceAll(detail, "\r\n", "\n")))
+	}
+	details = strings.Split(strings.Join(details, "\n"), "\n")
+	if len(details) > itemDetailMaxLines {
+		details = append(details[:itemDetailMaxLines], "…")
+	}
+	if len(item.Details) > 0 {
+		lines = append(lines, "", strings.Join(details, "\n"))
+	}
+
+	help := "Esc close"
+	if item.URL != "" {
+		lines = append(lines, "", urlStyle.Render(item.URL))
+		help = "Enter open link • Esc close"
+	}
+	lines = append(lines, "", helpStyle.Render(help))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+}
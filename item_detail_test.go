@@ -0,0 +1,87 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRenderItemDetail(t *testing.T) {
+	item := WidgetListItem{
+		ItemTitle: "Add a detail view for items whose title is far too long for a tile",
+		Subtitle:  "octocat • 2h ago",
+		URL:       "https://github.com/acme/app/pull/7",
+		Details:   []string{"acme/app#7 by octocat", "", "First line\r\nSecond line"},
+	}
+	view := renderItemDetail(item, 80)
+	for _, want := range []string{"far too long for a tile", "acme/app#7 by octocat", "Second line", "https://github.com/acme/app/pull/7", "Enter open link"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the detail view, got:\n%s", want, view)
+		}
+	}
+
+	long := WidgetListItem{ItemTitle: "Long", Details: []string{strings.Repeat("line\n", 30)}}
+	if view := renderItemDetail(long, 80); strings.Count(view, "line") != itemDetailMaxLines || strings.Contains(view, "Enter open link") {
+		t.Errorf("Expected %d lines and no link, got:\n%s", itemDetailMaxLines, view)
+	}
+}
+
+func TestItemDetailKeys(t *testing.T) {
+	m := benchmarkModel(120, 40)
+	m.widgets[0].UpdateItems([]WidgetItem{{Title: "Fix login", Details: []string{"Location: Room 4"}}})
+
+	updated, _ := m.update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	m = updated.(Model)
+	if m.detailItem == nil || !strings.Contains(m.View(), "Location: Room 4") {
+		t.Fatalf("Expected Space to show the selected item's details")
+	}
+	updated, _ = m.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	m = updated.(Model)
+	if m.detailItem == nil {
+		t.Errorf("Expected other keys to leave the detail view open")
+	}
+	updated, _ = m.update(tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.(Model).detailItem != nil {
+		t.Errorf("Expected Esc to close the detail view")
+	}
+}
+
+func TestItemDetails(t *testing.T) {
+	pr := formatPRItem(GitPullRequest{Number: 7, Title: "Fix login", Author: "octocat", Repository: "acme/app", Labels: []string{"bug"}, Body: "Fixes #6"})
+	if details := strings.Join(pr.Details, "\n"); !strings.Contains(details, "acme/app#7 by octocat") || !strings.Contains(details, "Labels: bug") || !strings.HasSuffix(details, "Fixes #6") {
+		t.Errorf("Expected the PR number, labels and body, got %q", details)
+	}
+
+	news := formatNewsItem(NewsItem{Title: "Go 1.30", Author: "gopher", Source: "hackernews", Description: "Released\n today.", Tags: []string{"golang"}})
+	if details := strings.Join(news.Details, "\n"); details != "gopher\nTags: golang\n\nReleased today." || news.Subtitle != "gopher • HN" {
+		t.Errorf("Expected the author, tags and description next to the HN subtitle, got %q and %q", details, news.Subtitle)
+	}
+
+	start := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	event := CalendarEvent{Title: "Planning", StartTime: start, EndTime: start.Add(time.Hour), Location: "Room 4", Attendees: []string{"Ana", "bo@example.com"}}
+	if details := strings.Join(calendarEventDetails(event), "\n"); details != "Fri 16 Oct 2026 14:00 - 15:00\nLocation: Room 4\nAttendees: Ana, bo@example.com" {
+		t.Errorf("Expected the time, location and attendees, got %q", details)
+	}
+}
+
+func TestItemDetailMarkdown(t *testing.T) {
+	if md := htmlToMarkdown("<p>Go <b>1.30</b> is out &amp; <code>go fix</code> works.</p><ul><li>Faster</li></ul>"); md != "\n\nGo **1.30** is out & `go fix` works.\n\n\n- Faster" {
+		t.Errorf("Expected the HTML as markdown, got %q", md)
+	}
+
+	item := WidgetListItem{
+		ItemTitle: "Go 1.30",
+		Details:   []string{"gopher", "Tags: *golang*", "", "<p>Released <em>today</em>.</p><ul><li>Faster builds</li></ul>"},
+	}
+	view := renderItemDetail(item, 80)
+	for _, want := range []string{"Tags: *golang*", "Released", "today", "Faster builds"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the detail view, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "<p>") || strings.Contains(view, "_today_") || strings.Contains(view, "- Faster") {
+		t.Errorf("Expected the description rendered as markdown, got:\n%s", view)
+	}
+}
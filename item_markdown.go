@@ -0,0 +1,136 @@
+package main
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// markdownKey identifies a description rendered at a width, color profile and background
+type markdownKey struct {
+	text    string
+	width   int
+	profile termenv.Profile
+	dark    bool
+}
+
+// markdownCache keeps rendered descriptions, since the detail view is redrawn on every
+// frame and rendering markdown is slow
+var markdownCache = struct {
+	sync.Mutex
+	entries map[markdownKey]string
+}{entries: map[markdownKey]string{}}
+
+// markdownCacheSize caps the cache; it is emptied once it grows past this
+const markdownCacheSize = 64
+
+// htmlMarkdown turns the tags descriptions commonly carry into their markdown
+var htmlMarkdown = []struct {
+	tag  *regexp.Regexp
+	with string
+}{
+	{regexp.MustCompile(`(?i)<br\s*/?>`), "\n"},
+	{regexp.MustCompile(`(?i)</?p(\s[^>]*)?>`), "\n\n"},
+	{regexp.MustCompile(`(?i)</?h[1-6](\s[^>]*)?>`), "\n\n"},
+	{regexp.MustCompile(`(?i)<li(\s[^>]*)?>`), "\n- "},
+	{regexp.MustCompile(`(?i)</?(b|strong)(\s[^>]*)?>`), "**"},
+	{regexp.MustCompile(`(?i)</?(i|em)(\s[^>]*)?>`), "_"},
+	{regexp.MustCompile(`(?i)</?code(\s[^>]*)?>`), "`"},
+}
+
+// htmlToMarkdown rewrites an HTML description, such as a Dev.to snippet or a calendar
+// invite, as markdown, dropping the tags markdown has no use for
+func htmlToMarkdown(text string) string {
+	if !strings.Contains(text, "<") {
+		return text
+	}
+	for _, rule := range htmlMarkdown {
+		text = rule.tag.ReplaceAllString(text, rule.with)
+	}
+	text = html.UnescapeString(stripTags(text))
+	lines := strings.Split(text, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " \t")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// stripTags removes what tags htmlMarkdown left, keeping the text between them
+func stripTags(text string) string {
+	var out strings.Builder
+	inTag := false
+	for _, r := range text {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>' && inTag:
+			inTag = false
+		case !inTag:
+			out.WriteRune(r)
+		}
+	}
+	return out.String()
+}
+
+// renderMarkdown renders a description as styled markdown wrapped at width, falling
+// back to the text itself if glamour fails
+func renderMarkdown(text string, width int) string {
+	key := markdownKey{text: text, width: width, profile: lipgloss.ColorProfile(), dark: lipgloss.HasDarkBackground()}
+	markdownCache.Lock()
+	defer markdownCache.Unlock()
+	if rendered, ok := markdownCache.entries[key]; ok {
+		return rendered
+	}
+
+	style := styles.LightStyleConfig
+	switch {
+	case key.profile == termenv.Ascii:
+		// Without colors, emphasis is shown with the markdown's own marks
+		style = styles.NoTTYStyleConfig
+	case key.dark:
+		style = styles.DarkStyleConfig
+	}
+	// The detail view pads its box already
+	var margin uint
+	style.Document.Margin = &margin
+	style.Document.BlockPrefix = ""
+	style.Document.BlockSuffix = ""
+
+	rendered := text
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStyles(style),
+		glamour.WithColorProfile(key.profile),
+		glamour.WithWordWrap(width),
+	)
+	if err == nil {
+		if out, err := renderer.Render(htmlToMarkdown(text)); err == nil {
+			rendered = trimRendered(out)
+		}
+	}
+
+	if len(markdownCache.entries) >= markdownCacheSize {
+		markdownCache.entries = map[markdownKey]string{}
+	}
+	markdownCache.entries[key] = rendered
+	return rendered
+}
+
+// renderedPadding matches the spaces, each styled on its own, glamour pads a line to the
+// wrap width with
+var renderedPadding = regexp.MustCompile(`(\x1b\[[0-9;]*m \x1b\[0m| )+$`)
+
+// trimRendered drops the blank lines glamour puts around a document and the padding at
+// the end of each line, which would otherwise widen the detail view to the wrap width
+func trimRendered(out string) string {
+	lines := strings.Split(out, "\n")
+	for i := range lines {
+		lines[i] = renderedPadding.ReplaceAllString(lines[i], "")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
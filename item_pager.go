@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// itemPagedMsg reports that the pager showing an item exited
+type itemPagedMsg struct {
+	path string // the temporary file the item was written to
+	err  error
+}
+
+// itemText returns an item as the pager shows it: the whole title and subtitle, then
+// its details, such as a story's description or a commit's message body, and its link
+func itemText(item WidgetListItem) string {
+	lines := []string{item.ItemTitle}
+	if item.Subtitle != "" {
+		lines = append(lines, item.Subtitle)
+	}
+	if len(item.Details) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, item.Details...)
+	}
+	if item.URL != "" {
+		lines = append(lines, "", item.URL)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// pagerCommand returns the command showing path in $PAGER, or else in $VISUAL or $EDITOR,
+// or else in less, or more on Windows
+func pagerCommand(path string) *exec.Cmd {
+	if args := strings.Fields(os.Getenv("PAGER")); len(args) > 0 {
+		return exec.Command(args[0], append(args[1:], path)...)
+	}
+	if os.Getenv("VISUAL") != "" || os.Getenv("EDITOR") != "" {
+		return editorCommand(path)
+	}
+	if runtime.GOOS == "windows" {
+		return exec.Command("more", path)
+	}
+	return exec.Command("less", path)
+}
+
+// pageItemCmd hands the terminal to the pager on the item until it exits
+func pageItemCmd(item WidgetListItem) tea.Cmd {
+	file, err := os.CreateTemp("", "goday-item-*.txt")
+	if err != nil {
+		return func() tea.Msg { return itemPagedMsg{err: err} }
+	}
+	_, err = file.WriteString(itemText(item))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return func() tea.Msg { return itemPagedMsg{path: file.Name(), err: err} }
+	}
+	return tea.ExecProcess(pagerCommand(file.Name()), func(err error) tea.Msg {
+		return itemPagedMsg{path: file.Name(), err: err}
+	})
+}
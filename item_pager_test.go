@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestItemText(t *testing.T) {
+	item := WidgetListItem{
+		ItemTitle: "fix: resolve auth issue",
+		Subtitle:  "2 hours ago • goday",
+		Details:   []string{"1a2b3c4d by Ann", "", "Tokens refreshed after expiry\nwere dropped."},
+		URL:       "https://github.com/bhanu/goday/commit/1a2b3c4d",
+	}
+	want := "fix: resolve auth issue\n2 hours ago • goday\n\n1a2b3c4d by Ann\n\nTokens refreshed after expiry\nwere dropped.\n\nhttps://github.com/bhanu/goday/commit/1a2b3c4d\n"
+	if text := itemText(item); text != want {
+		t.Errorf("Expected %q, got %q", want, text)
+	}
+}
+
+func TestPagerCommand(t *testing.T) {
+	t.Setenv("PAGER", "less -R")
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "nano")
+	if args := pagerCommand("/tmp/item.txt").Args; strings.Join(args, " ") != "less -R /tmp/item.txt" {
+		t.Errorf("Expected $PAGER with its flags, got %q", args)
+	}
+	t.Setenv("PAGER", "")
+	if args := pagerCommand("/tmp/item.txt").Args; strings.Join(args, " ") != "nano /tmp/item.txt" {
+		t.Errorf("Expected $EDITOR without $PAGER, got %q", args)
+	}
+	t.Setenv("EDITOR", "")
+	want := "less /tmp/item.txt"
+	if runtime.GOOS == "windows" {
+		want = "more /tmp/item.txt"
+	}
+	if args := pagerCommand("/tmp/item.txt").Args; strings.Join(args, " ") != want {
+		t.Errorf("Expected %q without either, got %q", want, args)
+	}
+}
+
+func TestItemPaged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goday-item.txt")
+	if err := os.WriteFile(path, []byte("Standup\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	m := benchmarkModel(120, 40)
+	updated, _ := m.Update(itemPagedMsg{path: path, err: errors.New(`exec: "less": executable file not found in $PATH`)})
+	m = updated.(Model)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected the item's file to be removed once the pager exits")
+	}
+	if view := m.View(); !strings.Contains(view, "Could not open the pager") {
+		t.Errorf("Expected the pager's failure in the footer, got:\n%s", view)
+	}
+}
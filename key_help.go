@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// keyHelpSection is a panel or tile with keys of its own, listed in the help view
+type keyHelpSection struct {
+	title string
+	keys  []key.Binding
+}
+
+// helpBinding is a key shown in the help view that is handled outside the KeyMap
+func helpBinding(keys, desc string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys), key.WithHelp(keys, desc))
+}
+
+// describedAs returns a binding with a help text for another context
+func describedAs(b key.Binding, desc string) key.Binding {
+	return key.NewBinding(key.WithKeys(b.Keys()...), key.WithHelp(b.Help().Key, desc))
+}
+
+// panelKeys returns the keys of the panels and tiles that have their own. Those of
+// the details view and the PR tile follow the bindings; the panels' keys are fixed.
+func panelKeys(km *KeyMap) []keyHelpSection {
+	opened := func(title string, b key.Binding) string {
+		return fmt.Sprintf("%s (%s)", title, b.Help().Key)
+	}
+	return []keyHelpSection{
+		{opened("Filter", km.Filter), []key.Binding{
+			helpBinding("enter", "keep filter"),
+			helpBinding("esc", "drop filter"),
+		}},
+		{opened("Item details", km.Details), []key.Binding{
+			describedAs(km.Open, "open link"),
+			describedAs(km.Back, "close"),
+		}},
+		{"Pull requests tile", []key.Binding{
+			describedAs(km.Open, "show or hide 🤖 Bots"),
+		}},
+		{opened("Saved searches", km.Search), []key.Binding{
+			helpBinding("enter", "run, open result"),
+			helpBinding("esc", "back, close"),
+		}},
+		{fmt.Sprintf("News tags (%s twice)", km.ResetTag.Help().Key), []key.Binding{
+			helpBinding("a/n/+", "add tag"),
+			helpBinding("d/x/-", "remove tag"),
+			helpBinding("esc", "close"),
+		}},
+		{opened("Dependency updates", km.Dependencies), []key.Binding{
+			helpBinding("enter", "open PR"),
+			helpBinding("a", "approve green PRs"),
+			helpBinding("m", "merge green PRs"),
+			helpBinding("y/n", "confirm, cancel"),
+			helpBinding("esc", "close"),
+		}},
+		{opened("Issue triage", km.Triage), []key.Binding{
+			helpBinding("enter", "open issue"),
+			helpBinding("1-9", "quick label"),
+			helpBinding("l", "type a label"),
+			helpBinding("a", "assign me"),
+			helpBinding("c", "comment and close"),
+			helpBinding("esc", "close"),
+		}},
+		{opened("Quick note", km.Note), []key.Binding{
+			helpBinding("enter", "save"),
+			helpBinding("esc", "cancel"),
+		}},
+	}
+}
+
+// keyGroupTitles name the columns of KeyMap.FullHelp
+var keyGroupTitles = []string{"Tiles", "Panels", "Focus and time", "Dashboard"}
+
+// renderKeySections renders sections side by side, wrapping onto another row once the
+// next one would not fit in width
+func renderKeySections(sections []keyHelpSection, width int) string {
+	styles := help.New().Styles
+	sectionStyle := lipgloss.NewStyle().Bold(true)
+
+	var rows []string
+	var row []string
+	rowWidth := 0
+	for _, section := range sections {
+		keyWidth := 0
+		for _, b := range section.keys {
+			keyWidth = max(keyWidth, lipgloss.Width(b.Help().Key))
+		}
+		lines := []string{sectionStyle.Render(section.title)}
+		for _, b := range section.keys {
+			keyText := b.Help().Key + strings.Repeat(" ", keyWidth-lipgloss.Width(b.Help().Key))
+			lines = append(lines, styles.FullKey.Render(keyText)+" "+styles.FullDesc.Render(b.Help().Desc))
+		}
+		column := lipgloss.NewStyle().PaddingRight(4).Render(strings.Join(lines, "\n"))
+
+		if len(row) > 0 && rowWidth+lipgloss.Width(column) > width {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, rowWidth = nil, 0
+		}
+		row = append(row, column)
+		rowWidth += lipgloss.Width(column)
+	}
+	if len(row) > 0 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+	return strings.Join(rows, "\n\n")
+}
+
+// keyHelpLines returns the lines of the help view: the dashboard's bindings, then the
+// keys of panels and tiles
+func keyHelpLines(km *KeyMap, width int) []string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colorTitle)
+
+	var global []keyHelpSection
+	for i, group := range km.FullHelp() {
+		global = append(global, keyHelpSection{title: keyGroupTitles[i], keys: group})
+	}
+	body := strings.Join([]string{
+		titleStyle.Render("Keys"),
+		"",
+		renderKeySections(global, width),
+		"",
+		titleStyle.Render("In panels and tiles"),
+		"",
+		renderKeySections(panelKeys(km), width),
+	}, "\n")
+	return strings.Split(body, "\n")
+}
+
+// keyHelpMaxScroll returns how far the help view scrolls on a screen of width by height
+func keyHelpMaxScroll(km *KeyMap, width, height int) int {
+	// The border, padding and the line below take 6 columns and 6 rows
+	return max(0, len(keyHelpLines(km, width-6))-(height-6))
+}
+
+// renderKeyHelp renders the help view opened with ? over the whole screen, scrolled by
+// offset lines when it is taller than the screen
+func renderKeyHelp(km *KeyMap, width, height, offset int) string {
+	helpStyle := lipgloss.NewStyle().Foreground(colorMuted).Italic(true).MaxWidth(width - 6)
+
+	lines := keyHelpLines(km, width-6)
+	footer := fmt.Sprintf("%s close • remap keys under keybindings in config.yaml", km.Back.Help().Key)
+	if visible := max(1, height-6); len(lines) > visible {
+		offset = max(0, min(offset, len(lines)-visible))
+		lines = lines[offset : offset+visible]
+		footer = fmt.Sprintf("%s %s scroll • %s", km.Up.Help().Key, km.Down.Help().Key, footer)
+	}
+	lines = append(lines, "", helpStyle.Render(footer))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestKeyHelp(t *testing.T) {
+	m := benchmarkModel(120, 40)
+	if view := m.View(); !strings.Contains(view, "? all keys") || strings.Contains(view, "pomodoro") {
+		t.Errorf("Expected the legend to show the main keys and point to ?, got %q", view)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	m = updated.(Model)
+	view := m.View()
+	for _, want := range []string{"pomodoro skip", "Issue triage (i)", "1-9", "News tags (T twice)", "show or hide 🤖 Bots"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the key help, got %q", want, view)
+		}
+	}
+	if lipgloss.Height(view) > 40 || lipgloss.Width(view) > 120 {
+		t.Errorf("Expected the key help to fit the screen, got %dx%d", lipgloss.Width(view), lipgloss.Height(view))
+	}
+	if strings.Contains(view, "Test User") {
+		t.Error("Expected the key help to take the whole screen")
+	}
+
+	// Rebound keys show in the section titles
+	m.keys, _ = NewKeyMap(map[string][]string{"triage": {"I"}})
+	if view := m.View(); !strings.Contains(view, "Issue triage (I)") {
+		t.Errorf("Expected the triage panel under its new key, got %q", view)
+	}
+}
+
+func TestKeyHelpScroll(t *testing.T) {
+	m := benchmarkModel(80, 24)
+	m.keyHelp = true
+	press := func(s string) {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+		m = updated.(Model)
+	}
+
+	view := m.View()
+	if lipgloss.Height(view) > 24 || lipgloss.Width(view) > 80 || !strings.Contains(view, "scroll") {
+		t.Fatalf("Expected a scrollable key help fitting 80x24, got %dx%d: %q", lipgloss.Width(view), lipgloss.Height(view), view)
+	}
+	for i := 0; i < 100; i++ {
+		press("j")
+	}
+	if m.keyHelpScroll != keyHelpMaxScroll(m.keyMap(), 80, 24) || !strings.Contains(m.View(), "Quick note (n)") {
+		t.Errorf("Expected j to scroll to the end of the key help, got %d", m.keyHelpScroll)
+	}
+	press("k")
+	press("esc")
+	if m.keyHelp || m.keyHelpScroll != 0 {
+		t.Errorf("Expected esc to close the key help and reset its scroll, got %v %d", m.keyHelp, m.keyHelpScroll)
+	}
+}
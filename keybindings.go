@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyMap holds the dashboard's key bindings: the defaults, with the keys set under
+// keybindings in the config. Keys inside panels, such as the dependency updates, and
+// while text is typed stay fixed, and ctrl+c always quits.
+type KeyMap struct {
+	NextWidget    key.Binding
+	PrevWidget    key.Binding
+	Up            key.Binding
+	Down          key.Binding
+	Open          key.Binding
+	Details       key.Binding
+	Page          key.Binding
+	Copy          key.Binding
+	Filter        key.Binding
+	Expand        key.Binding
+	Collapse      key.Binding
+	Back          key.Binding
+	CycleTag      key.Binding
+	ResetTag      key.Binding
+	Search        key.Binding
+	PluginStatus  key.Binding
+	Dependencies  key.Binding
+	Triage        key.Binding
+	QuietOverride key.Binding
+	LowPower      key.Binding
+	Pomodoro      key.Binding
+	PomodoroSkip  key.Binding
+	Note          key.Binding
+	EditNotes     key.Binding
+	TimerStart    key.Binding
+	TimerStop     key.Binding
+	Refresh       key.Binding
+	Retry         key.Binding
+	Help          key.Binding
+	Suspend       key.Binding
+	Quit          key.Binding
+}
+
+// keyAction is an action that can be bound under keybindings
+type keyAction struct {
+	name    string
+	keys    []string // default keys
+	help    string
+	group   int // column of the help view
+	binding func(*KeyMap) *key.Binding
+}
+
+// keyActions are the actions in the order the help lists them
+var keyActions = []keyAction{
+	{"next_widget", []string{"tab"}, "next tile", 0, func(k *KeyMap) *key.Binding { return &k.NextWidget }},
+	{"prev_widget", []string{"shift+tab"}, "previous tile", 0, func(k *KeyMap) *key.Binding { return &k.PrevWidget }},
+	{"up", []string{"up", "k"}, "previous item", 0, func(k *KeyMap) *key.Binding { return &k.Up }},
+	{"down", []string{"down", "j"}, "next item", 0, func(k *KeyMap) *key.Binding { return &k.Down }},
+	{"open", []string{"enter"}, "open link", 0, func(k *KeyMap) *key.Binding { return &k.Open }},
+	{"details", []string{"space"}, "item details", 0, func(k *KeyMap) *key.Binding { return &k.Details }},
+	{"page", []string{"O"}, "read item in pager", 0, func(k *KeyMap) *key.Binding { return &k.Page }},
+	{"copy", []string{"y"}, "copy link", 0, func(k *KeyMap) *key.Binding { return &k.Copy }},
+	{"filter", []string{"/"}, "filter tile", 0, func(k *KeyMap) *key.Binding { return &k.Filter }},
+	{"expand", []string{"z"}, "expand tile, again: zen", 0, func(k *KeyMap) *key.Binding { return &k.Expand }},
+	{"collapse", []string{"x"}, "collapse tile", 0, func(k *KeyMap) *key.Binding { return &k.Collapse }},
+	{"back", []string{"esc"}, "clear filter, restore grid", 0, func(k *KeyMap) *key.Binding { return &k.Back }},
+	{"cycle_tag", []string{"t"}, "next news tag", 1, func(k *KeyMap) *key.Binding { return &k.CycleTag }},
+	{"reset_tag", []string{"T"}, "all news (twice: edit tags)", 1, func(k *KeyMap) *key.Binding { return &k.ResetTag }},
+	{"search", []string{"s"}, "saved searches", 1, func(k *KeyMap) *key.Binding { return &k.Search }},
+	{"plugin_status", []string{"p"}, "plugin status", 1, func(k *KeyMap) *key.Binding { return &k.PluginStatus }},
+	{"dependencies", []string{"d"}, "dependency updates", 1, func(k *KeyMap) *key.Binding { return &k.Dependencies }},
+	{"triage", []string{"i"}, "issue triage", 1, func(k *KeyMap) *key.Binding { return &k.Triage }},
+	{"quiet_override", []string{"o"}, "override quiet time", 2, func(k *KeyMap) *key.Binding { return &k.QuietOverride }},
+	{"low_power", []string{"b"}, "low power", 2, func(k *KeyMap) *key.Binding { return &k.LowPower }},
+	{"pomodoro", []string{"f"}, "pomodoro start/pause", 2, func(k *KeyMap) *key.Binding { return &k.Pomodoro }},
+	{"pomodoro_skip", []string{"F"}, "pomodoro skip", 2, func(k *KeyMap) *key.Binding { return &k.PomodoroSkip }},
+	{"note", []string{"n"}, "quick note", 2, func(k *KeyMap) *key.Binding { return &k.Note }},
+	{"edit_notes", []string{"N"}, "edit notes", 2, func(k *KeyMap) *key.Binding { return &k.EditNotes }},
+	{"timer_start", []string{"g"}, "start timer", 2, func(k *KeyMap) *key.Binding { return &k.TimerStart }},
+	{"timer_stop", []string{"G"}, "stop timer", 2, func(k *KeyMap) *key.Binding { return &k.TimerStop }},
+	{"refresh", []string{"r", "R"}, "refresh all", 3, func(k *KeyMap) *key.Binding { return &k.Refresh }},
+	{"retry", []string{"e"}, "retry failed tile", 3, func(k *KeyMap) *key.Binding { return &k.Retry }},
+	{"help", []string{"?"}, "all keys", 3, func(k *KeyMap) *key.Binding { return &k.Help }},
+	{"suspend", []string{"ctrl+z"}, "suspend to shell", 3, func(k *KeyMap) *key.Binding { return &k.Suspend }},
+	{"quit", []string{"q"}, "quit", 3, func(k *KeyMap) *key.Binding { return &k.Quit }},
+}
+
+// tileListKeys leaves only paging to a tile's list; the dashboard's bindings do the rest,
+// so a remapped or unbound key does not move or quit through the list's own keys
+var tileListKeys = list.KeyMap{
+	PrevPage:  key.NewBinding(key.WithKeys("pgup")),
+	NextPage:  key.NewBinding(key.WithKeys("pgdown")),
+	GoToStart: key.NewBinding(key.WithKeys("home")),
+	GoToEnd:   key.NewBinding(key.WithKeys("end")),
+}
+
+// defaultKeyMap is used by dashboards without keybindings in the config
+var defaultKeyMap, _ = NewKeyMap(nil)
+
+// NewKeyMap returns the default bindings with the keys set in bindings, keyed by action.
+// Unknown actions, actions without keys and keys bound to two actions are reported;
+// the rest of the bindings still apply.
+func NewKeyMap(bindings map[string][]string) (*KeyMap, error) {
+	km := &KeyMap{}
+	for _, action := range keyActions {
+		*action.binding(km) = newKeyBinding(action.keys, action.help)
+	}
+
+	var problems []string
+	for name, keys := range bindings {
+		action, ok := findKeyAction(name)
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("keybindings.%s: unknown action", name))
+		case len(keys) == 0:
+			problems = append(problems, fmt.Sprintf("keybindings.%s: no keys", name))
+		default:
+			*action.binding(km) = newKeyBinding(keys, action.help)
+		}
+	}
+
+	bound := make(map[string]string)
+	for _, action := range keyActions {
+		for _, k := range action.binding(km).Keys() {
+			if other, ok := bound[k]; ok {
+				problems = append(problems, fmt.Sprintf("keybindings: %s is bound to both %s and %s", formatKeys([]string{k}), other, action.name))
+				continue
+			}
+			bound[k] = action.name
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return km, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return km, nil
+}
+
+// findKeyAction returns the action named under keybindings
+func findKeyAction(name string) (keyAction, bool) {
+	for _, action := range keyActions {
+		if action.name == name {
+			return action, true
+		}
+	}
+	return keyAction{}, false
+}
+
+// newKeyBinding binds keys as Bubble Tea names them; "space" is accepted for " "
+func newKeyBinding(keys []string, help string) key.Binding {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		if k == "space" {
+			k = " "
+		}
+		names[i] = k
+	}
+	return key.NewBinding(key.WithKeys(names...), key.WithHelp(formatKeys(names), help))
+}
+
+// formatKeys shows keys the way the legend and help name them
+func formatKeys(keys []string) string {
+	shown := make([]string, len(keys))
+	for i, k := range keys {
+		switch k {
+		case " ":
+			k = "space"
+		case "up":
+			k = "↑"
+		case "down":
+			k = "↓"
+		}
+		shown[i] = k
+	}
+	return strings.Join(shown, "/")
+}
+
+// Action returns the name of the action msg is bound to, or "" for any other key
+func (km *KeyMap) Action(msg tea.KeyMsg) string {
+	for _, action := range keyActions {
+		if key.Matches(msg, *action.binding(km)) {
+			return action.name
+		}
+	}
+	return ""
+}
+
+// ShortHelp returns the bindings the legend below the dashboard shows
+func (km *KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.Help, km.NextWidget, km.Down, km.Open, km.Details, km.Filter, km.Refresh, km.Quit}
+}
+
+// FullHelp returns the bindings in columns, for the help view
+func (km *KeyMap) FullHelp() [][]key.Binding {
+	var groups [][]key.Binding
+	for _, action := range keyActions {
+		for len(groups) <= action.group {
+			groups = append(groups, nil)
+		}
+		groups[action.group] = append(groups[action.group], *action.binding(km))
+	}
+	return groups
+}
+
+// keyMap returns the dashboard's bindings, the defaults unless the config sets some
+func (m Model) keyMap() *KeyMap {
+	if m.keys == nil {
+		return defaultKeyMap
+	}
+	return m.keys
+}
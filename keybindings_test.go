@@ -0,0 +1,91 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNewKeyMap(t *testing.T) {
+	keys, err := NewKeyMap(map[string][]string{
+		"down":      {"down", "t"},
+		"cycle_tag": {"#"},
+		"details":   {"space"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")}, keys.Down) || key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}, keys.Down) {
+		t.Errorf("Expected t to replace j for down, got %v", keys.Down.Keys())
+	}
+	if !key.Matches(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}, keys.Details) {
+		t.Errorf("Expected space to open the details, got %q", keys.Details.Keys())
+	}
+	if help := keys.Down.Help(); help.Key != "↓/t" || help.Desc != "next item" {
+		t.Errorf("Expected the help to show the new keys, got %+v", help)
+	}
+	if name := keys.Action(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("#")}); name != "cycle_tag" {
+		t.Errorf("Expected # to be the cycle_tag action, got '%s'", name)
+	}
+	if name := keys.Action(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}); name != "collapse" {
+		t.Errorf("Expected x to keep collapsing, got '%s'", name)
+	}
+	if name := keys.Action(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}); name != "" {
+		t.Errorf("Expected an unbound key to be no action, got '%s'", name)
+	}
+	if view := renderKeyHelp(keys, 120, 40, 0); !strings.Contains(view, "# ") || !strings.Contains(view, "space") {
+		t.Errorf("Expected the help to follow the bindings, got %q", view)
+	}
+
+	// The rest of the bindings still apply when some are wrong
+	keys, err = NewKeyMap(map[string][]string{"worklog": {"w"}, "refresh": {"q"}, "search": {}})
+	if err == nil {
+		t.Fatal("Expected an unknown action, a key bound twice and an action without keys to be reported")
+	}
+	for _, want := range []string{"keybindings.worklog: unknown action", "q is bound to both refresh and quit", "keybindings.search: no keys"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in the error, got %v", want, err)
+		}
+	}
+	if keys.Search.Keys()[0] != "s" {
+		t.Errorf("Expected search to keep its default key, got %v", keys.Search.Keys())
+	}
+
+	// The defaults bind no key twice
+	if _, err := NewKeyMap(nil); err != nil {
+		t.Errorf("Expected the default bindings to be valid, got %v", err)
+	}
+}
+
+func TestRemappedKeys(t *testing.T) {
+	m := benchmarkModel(120, 40)
+	m.keys, _ = NewKeyMap(map[string][]string{"down": {"down", "t"}, "cycle_tag": {"#"}, "help": {"h"}})
+	press := func(s string) {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+		m = updated.(Model)
+	}
+
+	press("t")
+	if m.widgets[0].list.Index() != 1 {
+		t.Errorf("Expected t to move down, got item %d", m.widgets[0].list.Index())
+	}
+	press("j")
+	if m.widgets[0].list.Index() != 1 {
+		t.Errorf("Expected j to do nothing once unbound, got item %d", m.widgets[0].list.Index())
+	}
+
+	press("h")
+	if !m.keyHelp || !strings.Contains(m.View(), "next news tag") {
+		t.Fatal("Expected h to open the key help")
+	}
+	press("t")
+	if !m.keyHelp || m.widgets[0].list.Index() != 1 {
+		t.Error("Expected the key help to ignore other keys")
+	}
+	press("h")
+	if m.keyHelp {
+		t.Error("Expected h to close the key help")
+	}
+}
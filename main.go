@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -10,7 +11,10 @@ import (
 
 	"context"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -20,11 +24,103 @@ const (
 	weatherInterval = 600 * time.Second
 	baseTileWidth   = 30
 	baseTileHeight  = 8
+	gridColumns     = 3 // tiles per row, for readability
 )
 
+// dashboardTile is a widget tile that can be placed on the dashboard
+type dashboardTile struct {
+	key   string
+	title string
+	// optional tiles are left out of the default layout unless their widget is configured
+	optional bool
+}
+
+// dashboardTiles lists every widget tile in its default grid order
+var dashboardTiles = []dashboardTile{
+	{key: "jira", title: "JIRA"},
+	{key: "prs", title: "PRs"},
+	{key: "builds", title: "Builds"},
+	{key: "commits", title: "Commits"},
+	{key: "discussions", title: "Discussions", optional: true},
+	{key: "calendar", title: "Calendar"},
+	{key: "mentions", title: "Mentions", optional: true},
+	{key: "slack", title: "Slack"},
+	{key: "teams", title: "Teams", optional: true},
+	{key: "discord", title: "Discord", optional: true},
+	{key: "todos", title: "Todos"},
+	{key: "stocks", title: "Stocks", optional: true},
+	{key: "crypto", title: "Crypto", optional: true},
+	{key: "fx", title: "Exchange Rates", optional: true},
+	{key: "weather", title: "Weather", optional: true},
+	{key: "aqi", title: "Air Quality", optional: true},
+	{key: "confluence", title: "Confluence"},
+	{key: "pagerduty", title: "PagerDuty"},
+	{key: "alerts", title: "Alerts", optional: true},
+	{key: "status", title: "Service Status", optional: true},
+	{key: "uptime", title: "Uptime", optional: true},
+	{key: "certs", title: "Certificates", optional: true},
+	{key: "domains", title: "Domains", optional: true},
+	{key: "trends", title: "Trends", optional: true},
+	{key: "releases", title: "Releases", optional: true},
+	{key: "vulns", title: "Go Vulnerabilities", optional: true},
+	{key: "system", title: "System", optional: true},
+	{key: "network", title: "Network", optional: true},
+	{key: "toggl", title: "Time Tracking", optional: true},
+	{key: "pomodoro", title: "Pomodoro", optional: true},
+	{key: "notes", title: "Notes", optional: true},
+	{key: "reminders", title: "Reminders", optional: true},
+	{key: "news", title: "Tech News"},
+	{key: "traffic", title: "Traffic"},
+}
+
+// isDashboardWidget reports whether key names one of the dashboard tiles
+func isDashboardWidget(key string) bool {
+	for _, tile := range dashboardTiles {
+		if tile.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// selectTiles returns the tiles named in keys, in that order. When keys is empty it
+// returns the default layout: every tile except optional ones that are not configured.
+func selectTiles(keys []string, configured map[string]bool) []dashboardTile {
+	var selected []dashboardTile
+	for _, key := range keys {
+		for _, tile := range dashboardTiles {
+			if tile.key == key {
+				selected = append(selected, tile)
+				break
+			}
+		}
+	}
+	if len(selected) > 0 {
+		return selected
+	}
+
+	for _, tile := range dashboardTiles {
+		if !tile.optional || configured[tile.key] {
+			selected = append(selected, tile)
+		}
+	}
+	return selected
+}
+
 type clockMsg string
-type weatherMsg string
-type newsMsg []NewsItem
+
+// weatherMsg is a fetched weather pill, stamped with its version
+type weatherMsg struct {
+	pill    string
+	version uint64
+}
+
+// newsMsg is fetched news or the error fetching it, stamped with its version
+type newsMsg struct {
+	items   []NewsItem
+	err     error
+	version uint64
+}
 
 // Commands that can access the model
 type fetchWeatherCmd struct{}
@@ -33,13 +129,61 @@ type fetchGitCommitsCmd struct{}
 type fetchGitHubPRsCmd struct{}
 type fetchTrafficCmd struct{}
 type fetchCalendarCmd struct{}
-
-func (fetchWeatherCmd) String() string    { return "fetch weather" }
-func (fetchNewsCmd) String() string       { return "fetch news" }
-func (fetchGitCommitsCmd) String() string { return "fetch git commits" }
-func (fetchGitHubPRsCmd) String() string  { return "fetch github prs" }
-func (fetchTrafficCmd) String() string    { return "fetch traffic" }
-func (fetchCalendarCmd) String() string   { return "fetch calendar" }
+type fetchDiscussionsCmd struct{}
+type fetchMentionsCmd struct{}
+type fetchStocksCmd struct{}
+type fetchCryptoCmd struct{}
+type fetchFXCmd struct{}
+type fetchAQICmd struct{}
+type fetchDaylightCmd struct{}
+type fetchOnCallCmd struct{}
+type fetchAlertsCmd struct{}
+type fetchStatusPagesCmd struct{}
+type fetchUptimeCmd struct{}
+type fetchCertsCmd struct{}
+type fetchDomainsCmd struct{}
+type fetchTrendsCmd struct{}
+type fetchReleasesCmd struct{}
+type fetchVulnsCmd struct{}
+type fetchSystemCmd struct{}
+type fetchNetworkCmd struct{}
+type fetchTogglCmd struct{}
+type fetchRemindersCmd struct{}
+
+// fetchChatCmd refreshes a chat widget, e.g. teams, from its plugin
+type fetchChatCmd struct{ widget string }
+
+// refreshNowMsg is a fetch asked for with r, which runs even while the widget is paused
+// or was just fetched
+type refreshNowMsg struct{ fetch tea.Msg }
+
+func (fetchWeatherCmd) String() string     { return "fetch weather" }
+func (fetchNewsCmd) String() string        { return "fetch news" }
+func (fetchGitCommitsCmd) String() string  { return "fetch git commits" }
+func (fetchGitHubPRsCmd) String() string   { return "fetch github prs" }
+func (fetchTrafficCmd) String() string     { return "fetch traffic" }
+func (fetchCalendarCmd) String() string    { return "fetch calendar" }
+func (fetchDiscussionsCmd) String() string { return "fetch discussions" }
+func (fetchMentionsCmd) String() string    { return "fetch mentions" }
+func (fetchStocksCmd) String() string      { return "fetch stocks" }
+func (fetchCryptoCmd) String() string      { return "fetch crypto" }
+func (fetchFXCmd) String() string          { return "fetch fx" }
+func (fetchAQICmd) String() string         { return "fetch aqi" }
+func (fetchDaylightCmd) String() string    { return "fetch daylight" }
+func (fetchOnCallCmd) String() string      { return "fetch on-call" }
+func (fetchAlertsCmd) String() string      { return "fetch alerts" }
+func (fetchStatusPagesCmd) String() string { return "fetch status" }
+func (fetchUptimeCmd) String() string      { return "fetch uptime" }
+func (fetchCertsCmd) String() string       { return "fetch certs" }
+func (fetchDomainsCmd) String() string     { return "fetch domains" }
+func (fetchTrendsCmd) String() string      { return "fetch trends" }
+func (fetchReleasesCmd) String() string    { return "fetch releases" }
+func (fetchVulnsCmd) String() string       { return "fetch vulns" }
+func (fetchSystemCmd) String() string      { return "fetch system" }
+func (fetchNetworkCmd) String() string     { return "fetch network" }
+func (fetchTogglCmd) String() string       { return "fetch toggl" }
+func (fetchRemindersCmd) String() string   { return "fetch reminders" }
+func (c fetchChatCmd) String() string      { return "fetch " + c.widget }
 
 // openURL opens a URL in the default browser
 func openURL(url string) error {
@@ -65,6 +209,10 @@ type WidgetListItem struct {
 	Subtitle  string
 	Status    string
 	URL       string
+	Details   []string
+	Since     time.Time // when the item happened; zero for items without a relative time
+
+	subtitleAge *SubtitleAge // where Subtitle shows Since, to write it again as time passes
 }
 
 func (i WidgetListItem) Title() string       { return i.ItemTitle }
@@ -73,15 +221,31 @@ func (i WidgetListItem) FilterValue() string { return i.ItemTitle }
 
 // Widget tile model
 type WidgetTile struct {
-	title    string
-	count    int
-	hasError bool
-	list     list.Model
-	width    int
-	height   int
+	key         string
+	title       string
+	count       int
+	counter     *TileCount // what the title counts; nil counts every item
+	mirrored    bool       // count was taken from a running dashboard's state file
+	lastSession bool       // items are the last session's, shown until the first refresh
+	hasError    bool
+	highlight   map[string]bool // attention keys of new items to highlight
+	expanded    bool            // fills the grid area, showing whole lines around the selection
+	offset      int             // first item shown, scrolled to keep the selection in view
+	details     bool            // expanded in zen mode: each item's details shown below it
+	status      string          // when the widget was last and is next fetched, at the bottom
+	spinner     string          // spinner frame in the title while the widget is fetched
+	retryKey    string          // key that fetches a failed widget again, for its error banner
+	markers     bool            // mark the selected item and a stale status in text too, for high contrast
+	stale       bool            // the widget's next fetch is overdue
+	columns     int             // grid columns the tile spans; 0 is one
+	collapsed   bool            // folded into its title below the grid with x
+	rows        int             // grid rows the tile spans; 0 is one
+	list        list.Model
+	width       int
+	height      int
 }
 
-func NewWidgetTile(title string, width, height int) WidgetTile {
+func NewWidgetTile(key, title string, width, height int) WidgetTile {
 	// Create list items for the widget
 	items := []list.Item{
 		WidgetListItem{ItemTitle: "Loading...", Subtitle: ""},
@@ -93,9 +257,10 @@ func NewWidgetTile(title string, width, height int) WidgetTile {
 	l.SetShowTitle(false)
 	l.SetShowStatusBar(false)
 	l.SetShowPagination(false)
-	l.SetFilteringEnabled(false)
+	l.KeyMap = tileListKeys
 
 	return WidgetTile{
+		key:    key,
 		title:  title,
 		count:  0,
 		width:  width,
@@ -113,78 +278,102 @@ func (wt *WidgetTile) UpdateItems(items []WidgetItem) {
 	} else {
 		for _, item := range items {
 			listItems = append(listItems, WidgetListItem{
-				ItemTitle: item.Title,
-				Subtitle:  item.Subtitle,
-				Status:    item.Status,
-				URL:       item.URL,
+				ItemTitle:   item.Title,
+				Subtitle:    item.Subtitle,
+				Status:      item.Status,
+				URL:         item.URL,
+				Details:     item.Details,
+				Since:       item.Since,
+				subtitleAge: item.Age,
 			})
 		}
 	}
 	wt.list.SetItems(listItems)
+	wt.refilter()
 	wt.count = len(items)
+	wt.lastSession = false
 }
 
 func (wt *WidgetTile) View() string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("229")).
+		Foreground(colorTitle).
 		Align(lipgloss.Center).
 		Width(wt.width - 2).
-		Background(lipgloss.Color("235"))
+		Background(colorTitleBar)
 
-	title := fmt.Sprintf("%s (%d)", wt.title, wt.count)
+	title := wt.title
+	if count, ok := wt.titleCount(); ok {
+		title = fmt.Sprintf("%s (%d)", wt.title, count)
+	}
+	if wt.list.FilterState() != list.Unfiltered {
+		title = wt.filterTitle()
+	}
 	if wt.hasError {
 		title += " ❌"
 	}
+	if wt.spinner != "" {
+		title += " " + wt.spinner
+	}
 
-	// Get items directly from the list instead of using list.View()
-	items := wt.list.Items()
+	// Get items directly from the list instead of using list.View(); a filter narrows them
+	items := wt.list.VisibleItems()
 	selectedIndex := wt.list.Index()
 	var contentLines []string
 
-	// Process each item to create readable content
-	for i, item := range items {
-		if widgetItem, ok := item.(WidgetListItem); ok {
-			// Create a formatted line for each item
-			line := widgetItem.ItemTitle
-			if widgetItem.Subtitle != "" {
-				line += " • " + widgetItem.Subtitle
-			}
-			if widgetItem.Status != "" {
-				line += " " + widgetItem.Status
-			}
+	// Items beyond the tile's rows scroll into view with the selection
+	from, to := 0, len(items)
+	rows, footer := wt.itemRows(len(items))
+	if !wt.expanded {
+		from, to = tileWindow(wt.offset, selectedIndex, len(items), rows)
+		wt.offset = from
+	}
 
-			// Truncate if too long
-			if len(line) > wt.width-4 {
-				line = line[:wt.width-7] + "..."
-			}
+	// A failed fetch shows its error instead of the items
+	if wt.hasError && wt.list.FilterState() == list.Unfiltered {
+		rows, footer = wt.itemRows(0)
+		contentLines = wt.errorBanner(items, rows)
+		items, from, to = nil, 0, 0
+	}
+
+	// Process each item to create readable content
+	for i := from; i < to; i++ {
+		if widgetItem, ok := items[i].(WidgetListItem); ok {
+			line, isNew := wt.itemLine(widgetItem, i == selectedIndex)
 
 			// Highlight selected item
 			if i == selectedIndex {
 				selectedStyle := lipgloss.NewStyle().
-					Foreground(lipgloss.Color("0")).
-					Background(lipgloss.Color("33")).
+					Foreground(colorOnAccent).
+					Background(colorAccent).
 					Bold(true)
 				line = selectedStyle.Render(line)
+			} else if isNew {
+				line = lipgloss.NewStyle().Foreground(colorWarn).Bold(true).Render(line)
+			}
+			if details := wt.itemDetails(widgetItem); details != "" {
+				line += "\n" + lipgloss.NewStyle().Foreground(colorMuted).Render(details)
 			}
 
 			contentLines = append(contentLines, line)
-
-			// Limit to prevent overflow
-			if i >= wt.height-4 { // Leave space for title and borders
-				remaining := len(items) - i - 1
-				if remaining > 0 {
-					contentLines = append(contentLines, fmt.Sprintf("+%d more…", remaining))
-				}
-				break
-			}
 		}
 	}
+	if footer && !wt.expanded {
+		// The footer keeps to the bottom row
+		for len(contentLines) < rows {
+			contentLines = append(contentLines, "")
+		}
+		contentLines = append(contentLines, wt.footer(from, to, len(items)))
+	}
 
 	// Ensure we have some content
 	if len(contentLines) == 0 {
 		contentLines = []string{"No items"}
 	}
+	if wt.expanded {
+		from, to := expandedWindow(contentLines, selectedIndex, wt.width-4, wt.height-2)
+		contentLines = contentLines[from:to]
+	}
 
 	// Join content with proper spacing
 	contentText := strings.Join(contentLines, "\n")
@@ -205,32 +394,262 @@ func (wt *WidgetTile) View() string {
 	return fullContent
 }
 
+// itemRows returns the rows of a tile's content left for items, and whether a footer
+// takes the last row: the refresh status, and which items are in view when not all fit
+func (wt *WidgetTile) itemRows(count int) (rows int, footer bool) {
+	rows = wt.height - 2
+	if wt.status != "" || count > rows {
+		return max(1, rows-1), true
+	}
+	return rows, false
+}
+
+// footer renders the last row of a tile: the refresh status on the left and, when not
+// all items fit, which are in view on the right
+func (wt *WidgetTile) footer(from, to, count int) string {
+	width := wt.width - 4
+	position := ""
+	if to-from < count {
+		position = fmt.Sprintf("%d–%d of %d", from+1, to, count)
+	}
+	// A narrow tile shortens the status, e.g. to "2m ago · next 8m", before cutting it
+	status := wt.status
+	statusWidth := max(0, width-lipgloss.Width(position)-1)
+	if lipgloss.Width(status) > statusWidth {
+		status = shortStatus.Replace(status)
+	}
+	if lipgloss.Width(status) > statusWidth {
+		status = lipgloss.NewStyle().MaxWidth(statusWidth).Render(status)
+	}
+	gap := strings.Repeat(" ", max(0, width-lipgloss.Width(status)-lipgloss.Width(position)))
+	muted := lipgloss.NewStyle().Foreground(colorMuted)
+	if wt.stale {
+		if wt.markers {
+			status = staleMarker + status
+			gap = strings.Repeat(" ", max(0, width-lipgloss.Width(status)-lipgloss.Width(position)))
+		}
+		return lipgloss.NewStyle().Foreground(colorWarn).Render(status) + muted.Render(gap+position)
+	}
+	return muted.Render(status + gap + position)
+}
+
+// shortStatus shortens a refresh status for a narrow tile
+var shortStatus = strings.NewReplacer("updated ", "", "next in ", "next ")
+
+// tileWindow returns the items a tile shows in rows, scrolled from offset just enough to
+// keep the selected one in view
+func tileWindow(offset, selected, count, rows int) (from, to int) {
+	if count <= rows {
+		return 0, count
+	}
+	rows = max(1, rows)
+	if selected < offset {
+		offset = selected
+	} else if selected >= offset+rows {
+		offset = selected - rows + 1
+	}
+	from = max(0, min(offset, count-rows))
+	return from, from + rows
+}
+
+// expandedWindow returns the lines an expanded tile shows: as many as fit in height
+// rows when wrapped at width, from the first one on or scrolled to keep the selected one
+func expandedWindow(lines []string, selected, width, height int) (from, to int) {
+	rows := func(line string) int { return wrappedRows(line, width) }
+	selected = min(max(selected, 0), len(lines)-1)
+	used := 0
+	for i := 0; i <= selected; i++ {
+		used += rows(lines[i])
+	}
+	for used > height && from < selected {
+		used -= rows(lines[from])
+		from++
+	}
+	to = selected + 1
+	for to < len(lines) && used+rows(lines[to]) <= height {
+		used += rows(lines[to])
+		to++
+	}
+	return from, to
+}
+
+// itemLine formats an item as the tile shows it, marking new items, and whether it is new
+func (wt *WidgetTile) itemLine(item WidgetListItem, selected bool) (string, bool) {
+	line := item.ItemTitle
+	isNew := wt.highlight[attentionKey(item)]
+	if isNew {
+		line = "● " + line
+	}
+	if selected && wt.markers {
+		line = "▶ " + line
+	}
+	if item.Subtitle != "" {
+		line += " • " + item.Subtitle
+	}
+	if item.Status != "" {
+		line += " " + item.Status
+	}
+
+	// Truncate if too long; an expanded tile wraps the whole line instead
+	if !wt.expanded && len(line) > wt.width-4 {
+		line = line[:wt.width-7] + "..."
+	}
+	return line, isNew
+}
+
+// itemAt returns the index of the item shown on a row of the tile's content, below its
+// title, as View lays it out
+func (wt *WidgetTile) itemAt(row int) (int, bool) {
+	items := wt.list.VisibleItems()
+	if row < 0 {
+		return 0, false
+	}
+	if !wt.expanded {
+		// One line per item, as scrolled when the tile was last shown
+		rows, _ := wt.itemRows(len(items))
+		from, to := tileWindow(wt.offset, wt.list.Index(), len(items), rows)
+		return from + row, from+row < to
+	}
+
+	lines := make([]string, 0, len(items))
+	for i, item := range items {
+		if widgetItem, ok := item.(WidgetListItem); ok {
+			line, _ := wt.itemLine(widgetItem, i == wt.list.Index())
+			if details := wt.itemDetails(widgetItem); details != "" {
+				line += "\n" + details
+			}
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return 0, false
+	}
+	from, to := expandedWindow(lines, wt.list.Index(), wt.width-4, wt.height-2)
+	for i := from; i < to; i++ {
+		row -= wrappedRows(lines[i], wt.width-4)
+		if row < 0 {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// itemDetails returns what zen mode shows below an item: its details and link, indented
+func (wt *WidgetTile) itemDetails(item WidgetListItem) string {
+	if !wt.details {
+		return ""
+	}
+	var lines []string
+	for _, detail := range item.Details {
+		lines = append(lines, strings.Split(strings.ReplaceAll(detail, "\r\n", "\n"), "\n")...)
+	}
+	if len(lines) > itemDetailMaxLines {
+		lines = append(lines[:itemDetailMaxLines], "…")
+	}
+	if item.URL != "" {
+		lines = append(lines, item.URL)
+	}
+	for i := range lines {
+		lines[i] = "  " + lines[i]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrappedRows returns how many rows a line takes when wrapped at width, line breaks
+// included
+func wrappedRows(line string, width int) int {
+	if width <= 0 {
+		return strings.Count(line, "\n") + 1
+	}
+	rows := 0
+	for _, part := range strings.Split(line, "\n") {
+		rows += max(1, (lipgloss.Width(part)+width-1)/width)
+	}
+	return rows
+}
+
 type Model struct {
 	userName       string
 	dateTime       string
 	weather        string
+	forecast       []WeatherHour // hourly forecast from the weather provider, if it has one
 	location       string
 	config         *Config
+	configPath     string              // config file backing config; empty when running on defaults
+	statePath      string              // state file written after each refresh; empty when turned off
+	instance       instanceMode        // how this dashboard shares the profile with a running one
+	attachedAt     time.Time           // when the attached dashboard last wrote its state
+	lastSessionAt  time.Time           // when the session whose items are shown until refreshed quit
+	terminal       TerminalProfile     // what the terminal can render
+	capabilities   *Capabilities       // optional programs found missing at startup
+	attention      *AttentionTracker   // escalates new items; nil when running headless
+	audit          *AuditLog           // trail of write actions; nil in dry run
+	telemetry      *Telemetry          // usage counters; nil unless opted in
+	preview        *Previewer          // tomorrow's preview; nil when turned off or running headless
+	newsLanguage   *NewsLanguage       // news language filter and translation; nil when not configured
+	daylight       *Daylight           // today's sunrise and sunset, once fetched
+	battery        BatteryStatus       // laptop battery, read with the clock
+	goldenHour     *GoldenHourReminder // nil without a golden hour reminder
+	pomodoro       *Pomodoro           // focus timer; nil without widgets.pomodoro
+	notes          *Notes              // scratchpad file, written with n even without its tile
+	versions       *DataVersions       // versions of widget results, to drop out-of-order ones
+	refresh        *RefreshProgress    // progress of the refresh started with R
+	fetching       map[string]uint64   // version of each widget's fetch in flight
+	spinner        spinner.Model       // turns in the titles of tiles being fetched
+	waitFetches    bool                // fetches finish before update returns, for commands that print once
+	newsQueued     bool                // news was asked for while a fetch ran; fetched again once it ends
+	toast          string              // shown in place of the key legend for a moment, e.g. after copying
+	toastID        int                 // the toast's number, so only its own timer clears it
 	widgetManager  *WidgetManager
 	pluginManager  *PluginManager
 	scheduler      *Scheduler
+	widgetPlugins  map[string]string // widget key -> ID of the plugin chosen by its provider
 	cancel         context.CancelFunc
 	widgets        []WidgetTile
+	tagEditor      *NewsTagEditor    // non-nil while the news tag editor is open
+	searchPalette  *SearchPalette    // non-nil while the saved search palette is open
+	noteCapture    *NoteCapture      // non-nil while a quick note is being typed
+	pluginStatus   bool              // true while the plugin status view is open
+	detailItem     *WidgetListItem   // non-nil while the item detail view is open
+	keyHelp        bool              // true while the key help view is open
+	keyHelpScroll  int               // lines the key help view is scrolled down
+	keys           *KeyMap           // nil uses defaultKeyMap
+	expanded       bool              // true while the focused tile fills the grid area
+	zen            bool              // true while the focused tile fills the terminal, with details
+	lastClick      mouseClick        // last click on an item, to tell a double-click
+	depPanel       *DependencyPanel  // non-nil while the dependency updates panel is open
+	triagePanel    *IssueTriagePanel // non-nil while the issue triage panel is open
+	searchRunner   *SavedSearchRunner
+	depUpdater     *DependencyUpdater
+	triager        *IssueTriager
 	focusedWidget  int
 	terminalWidth  int
 	terminalHeight int
 }
 
-func initialModel() Model {
+func initialModel(opts *CLIOptions) Model {
+	if opts == nil {
+		opts = &CLIOptions{}
+	}
+
 	cfg, err := LoadConfigFromDefaultPath()
 	userName := "Unknown User"
 	location := "Bengaluru,IN"
+	var configPath string
 	if err == nil && cfg != nil {
+		configPath, _ = GetConfigPath()
+		// Layer command-line overrides on top of the config file
+		if err := opts.Apply(cfg); err != nil {
+			fmt.Printf("Warning: Could not apply flag overrides: %v\n", err)
+		}
 		userName = cfg.User.Name
 		location = cfg.User.Location
 	} else {
 		// Log the error but continue with defaults
 		fmt.Printf("Warning: Could not load config: %v\n", err)
+		if opts.Location != "" {
+			location = opts.Location
+		}
 	}
 
 	widgetManager := NewWidgetManager()
@@ -239,186 +658,286 @@ func initialModel() Model {
 	pluginConfig := &PluginConfig{
 		Plugins: make(map[string]map[string]interface{}),
 	}
-
+	// Settings from the `plugins:` section reach plugins without a dedicated config field
 	if cfg != nil {
-		// Configure weather plugin
-		pluginConfig.Plugins["openweathermap"] = map[string]interface{}{
-			"api_key": cfg.Widgets.Weather.APIKey,
-			"city":    location,
-		}
-
-		// Configure news plugins
-		pluginConfig.Plugins["hackernews"] = map[string]interface{}{
-			"tags":        cfg.Widgets.News.Tags,
-			"current_tag": "all",
-		}
-		pluginConfig.Plugins["devto"] = map[string]interface{}{
-			"tags":        cfg.Widgets.News.Tags,
-			"current_tag": "all",
-		}
-		pluginConfig.Plugins["aggregate-news"] = map[string]interface{}{
-			"tags":        cfg.Widgets.News.Tags,
-			"current_tag": "all",
-		}
-
-		// Configure traffic plugin (OSRM - no API key needed)
-		pluginConfig.Plugins["osrm_traffic"] = map[string]interface{}{
-			"origin":      cfg.Widgets.Traffic.Origin,
-			"destination": cfg.Widgets.Traffic.Destination,
-		}
-
-		// Configure calendar plugin
-		calendarConfig := map[string]interface{}{
-			"max_events": cfg.Widgets.Calendar.MaxEvents,
-			"days_ahead": cfg.Widgets.Calendar.DaysAhead,
-		}
-		// Add credentials_file and token_file if provided in config
-		if cfg.Widgets.Calendar.CredentialsFile != "" {
-			calendarConfig["credentials_file"] = cfg.Widgets.Calendar.CredentialsFile
-		}
-		if cfg.Widgets.Calendar.TokenFile != "" {
-			calendarConfig["token_file"] = cfg.Widgets.Calendar.TokenFile
-		}
-		pluginConfig.Plugins["google-calendar"] = calendarConfig
-	} else {
-		// Default config when no config file is found
-		defaultTags := []string{"golang", "security", "ai"}
-
-		pluginConfig.Plugins["openweathermap"] = map[string]interface{}{
-			"api_key": "YOUR_OWM_API_KEY",
-			"city":    location,
-		}
-
-		pluginConfig.Plugins["hackernews"] = map[string]interface{}{
-			"tags":        defaultTags,
-			"current_tag": "all",
-		}
-		pluginConfig.Plugins["devto"] = map[string]interface{}{
-			"tags":        defaultTags,
-			"current_tag": "all",
-		}
-		pluginConfig.Plugins["aggregate-news"] = map[string]interface{}{
-			"tags":        defaultTags,
-			"current_tag": "all",
+		for id, settings := range cfg.Plugins {
+			pluginConfig.Plugins[id] = settings
 		}
+	}
+	pluginManager := NewPluginManager(pluginConfig)
 
-		// Configure traffic plugin with defaults (OSRM - no API key needed)
-		pluginConfig.Plugins["osrm_traffic"] = map[string]interface{}{
-			"origin":      "Electronic City, Bengaluru, Karnataka, India",
-			"destination": "Whitefield, Bengaluru, Karnataka, India",
+	// Create the plugin behind each provider-backed widget from its `provider:` setting
+	providers := DefaultProviderRegistry()
+	widgetPlugins := make(map[string]string)
+	for _, widget := range providers.Widgets() {
+		name := cfg.WidgetProviderName(widget)
+		plugin, config, err := providers.Create(widget, name, cfg, location)
+		if err != nil {
+			fmt.Printf("Warning: %v, using %s\n", err, providers.DefaultProvider(widget))
+			plugin, config, _ = providers.Create(widget, "", cfg, location)
 		}
-
-		// Configure calendar plugin with defaults
-		pluginConfig.Plugins["google-calendar"] = map[string]interface{}{
-			"max_events": 10,
-			"days_ahead": 7,
+		// Explicit `plugins:` settings win over those derived from the widget section
+		pluginConfig.Plugins[plugin.GetID()] = mergePluginConfig(config, pluginConfig.Plugins[plugin.GetID()])
+		if err := pluginManager.RegisterPlugin(plugin); err != nil {
+			fmt.Printf("Warning: %v\n", err)
 		}
+		widgetPlugins[widget] = plugin.GetID()
 	}
 
-	pluginManager := NewPluginManager(pluginConfig)
-
-	// Register plugins - handle nil config gracefully
-	var apiKey string
-	if cfg != nil {
-		apiKey = cfg.Widgets.Weather.APIKey
-	}
-	weatherPlugin := NewWeatherPlugin(apiKey, location)
-	pluginManager.RegisterPlugin(weatherPlugin)
-
-	// Create individual news plugins
-	hackerNewsPlugin := NewHackerNewsPlugin()
-	devToPlugin := NewDevToPlugin()
-	hackernoonPlugin := NewHackernoonPlugin()
-	pluginManager.RegisterPlugin(hackerNewsPlugin)
-	pluginManager.RegisterPlugin(devToPlugin)
-	pluginManager.RegisterPlugin(hackernoonPlugin)
-
-	// Create aggregate news plugin with only tech-focused sources
-	// Removed Hacker News as it includes general news articles
-	aggregateNewsPlugin := NewAggregateNewsPlugin([]NewsPlugin{
-		hackernoonPlugin,
-		devToPlugin,
-	})
-	pluginManager.RegisterPlugin(aggregateNewsPlugin)
-
 	// Create Git plugins
 	gitCommitsPlugin := NewLocalGitCommitsPlugin()
 	githubPRsPlugin := NewGitHubPRsPlugin()
+	// PR filters come from widgets.prs; plugins.github-prs can still override them
+	if cfg != nil {
+		prsConfig := map[string]interface{}{
+			"hide_drafts": cfg.Widgets.PRs.HideDrafts,
+			"hide_wip":    cfg.Widgets.PRs.HideWIP,
+			"wip_labels":  cfg.Widgets.PRs.WIPLabels,
+			"bots":        cfg.Widgets.PRs.Bots,
+		}
+		pluginConfig.Plugins[githubPRsPlugin.GetID()] = mergePluginConfig(prsConfig, pluginConfig.Plugins[githubPRsPlugin.GetID()])
+	}
 	pluginManager.RegisterPlugin(gitCommitsPlugin)
 	pluginManager.RegisterPlugin(githubPRsPlugin)
 
-	// Create Traffic plugin (OSRM - no API key required)
-	trafficPlugin := NewOSRMTrafficPlugin()
-	pluginManager.RegisterPlugin(trafficPlugin)
-
-	// Create Google Calendar plugin
-	calendarPlugin := NewGoogleCalendarPlugin()
-	pluginManager.RegisterPlugin(calendarPlugin)
-
 	scheduler := NewScheduler()
+	registry := pluginManager.GetRegistry()
+	if cfg != nil {
+		for id := range cfg.Plugins {
+			if _, exists := registry.GetPlugin(id); !exists {
+				fmt.Printf("Warning: plugins.%s is configured but no plugin with that ID is registered\n", id)
+			}
+		}
+	}
+	widgetPlugin := func(widget string) Plugin {
+		plugin, _ := registry.GetPlugin(widgetPlugins[widget])
+		return plugin
+	}
 
 	// Add scheduled tasks for each widget with their TTL
 	if cfg != nil {
-		scheduler.AddTask("weather", ParseTTL(cfg.Widgets.Weather.TTL), weatherPlugin)
-		scheduler.AddTask("news", ParseTTL(cfg.Widgets.News.TTL), aggregateNewsPlugin)
+		scheduler.AddTask("weather", ParseTTL(cfg.Widgets.Weather.TTL), widgetPlugin("weather"))
+		scheduler.AddTask("news", ParseTTL(cfg.Widgets.News.TTL), widgetPlugin("news"))
 		scheduler.AddTask("slack", ParseTTL(cfg.Widgets.Slack.TTL), nil)
+		scheduler.AddTask("teams", ParseTTL(cfg.Widgets.Teams.TTL), widgetPlugin("teams"))
+		scheduler.AddTask("discord", ParseTTL(cfg.Widgets.Discord.TTL), widgetPlugin("discord"))
+		scheduler.AddTask("discussions", ParseTTL(cfg.Widgets.Discussions.TTL), widgetPlugin("discussions"))
+		scheduler.AddTask("mentions", ParseTTL(cfg.Widgets.Mentions.TTL), widgetPlugin("mentions"))
+		scheduler.AddTask("stocks", ParseTTL(cfg.Widgets.Stocks.TTL), widgetPlugin("stocks"))
+		scheduler.AddTask("crypto", ParseTTL(cfg.Widgets.Crypto.TTL), widgetPlugin("crypto"))
+		scheduler.AddTask("fx", ParseTTL(cfg.Widgets.FX.TTL), widgetPlugin("fx"))
+		scheduler.AddTask("aqi", ParseTTL(cfg.Widgets.AQI.TTL), widgetPlugin("aqi"))
+		scheduler.AddTask("daylight", ParseTTL(cfg.Widgets.Daylight.TTL), widgetPlugin("daylight"))
 		scheduler.AddTask("confluence", ParseTTL(cfg.Widgets.Confluence.TTL), nil)
+		scheduler.AddTask("pagerduty", ParseTTL(cfg.Widgets.PagerDuty.TTL), widgetPlugin("pagerduty"))
+		scheduler.AddTask("alerts", ParseTTL(cfg.Widgets.Alerts.TTL), widgetPlugin("alerts"))
+		scheduler.AddTask("status", ParseTTL(cfg.Widgets.Status.TTL), widgetPlugin("status"))
+		scheduler.AddTask("uptime", ParseTTL(cfg.Widgets.Uptime.TTL), widgetPlugin("uptime"))
+		scheduler.AddTask("certs", ParseTTL(cfg.Widgets.Certs.TTL), widgetPlugin("certs"))
+		scheduler.AddTask("domains", ParseTTL(cfg.Widgets.Domains.TTL), widgetPlugin("domains"))
+		scheduler.AddTask("trends", ParseTTL(cfg.Widgets.Trends.TTL), widgetPlugin("trends"))
+		scheduler.AddTask("releases", ParseTTL(cfg.Widgets.Releases.TTL), widgetPlugin("releases"))
+		scheduler.AddTask("vulns", ParseTTL(cfg.Widgets.Vulns.TTL), widgetPlugin("vulns"))
+		scheduler.AddTask("system", ParseTTL(cfg.Widgets.System.TTL), widgetPlugin("system"))
+		scheduler.AddTask("network", ParseTTL(cfg.Widgets.Network.TTL), widgetPlugin("network"))
+		scheduler.AddTask("toggl", ParseTTL(cfg.Widgets.Toggl.TTL), widgetPlugin("toggl"))
+		scheduler.AddTask("reminders", ParseTTL(cfg.Widgets.Reminders.TTL), widgetPlugin("reminders"))
 		scheduler.AddTask("jira", ParseTTL(cfg.Widgets.Jira.TTL), nil)
-		scheduler.AddTask("traffic", ParseTTL(cfg.Widgets.Traffic.TTL), trafficPlugin)
-		scheduler.AddTask("calendar", ParseTTL(cfg.Widgets.Calendar.TTL), calendarPlugin)
+		scheduler.AddTask("traffic", ParseTTL(cfg.Widgets.Traffic.TTL), widgetPlugin("traffic"))
+		scheduler.AddTask("calendar", ParseTTL(cfg.Widgets.Calendar.TTL), widgetPlugin("calendar"))
 	} else {
 		// Default TTL values when no config
-		scheduler.AddTask("weather", 600*time.Second, weatherPlugin)
-		scheduler.AddTask("news", 600*time.Second, aggregateNewsPlugin)
+		scheduler.AddTask("weather", 600*time.Second, widgetPlugin("weather"))
+		scheduler.AddTask("news", 600*time.Second, widgetPlugin("news"))
 		scheduler.AddTask("slack", 20*time.Second, nil)
+		scheduler.AddTask("teams", 120*time.Second, widgetPlugin("teams"))
+		scheduler.AddTask("discord", 60*time.Second, widgetPlugin("discord"))
+		scheduler.AddTask("discussions", 600*time.Second, widgetPlugin("discussions"))
+		scheduler.AddTask("mentions", 120*time.Second, widgetPlugin("mentions"))
+		scheduler.AddTask("stocks", 60*time.Second, widgetPlugin("stocks"))
+		scheduler.AddTask("crypto", 120*time.Second, widgetPlugin("crypto"))
+		scheduler.AddTask("fx", 3600*time.Second, widgetPlugin("fx"))
+		scheduler.AddTask("aqi", 1800*time.Second, widgetPlugin("aqi"))
+		scheduler.AddTask("daylight", 3600*time.Second, widgetPlugin("daylight"))
 		scheduler.AddTask("confluence", 300*time.Second, nil)
+		scheduler.AddTask("pagerduty", 60*time.Second, widgetPlugin("pagerduty"))
+		scheduler.AddTask("alerts", 60*time.Second, widgetPlugin("alerts"))
+		scheduler.AddTask("status", 120*time.Second, widgetPlugin("status"))
+		scheduler.AddTask("uptime", 60*time.Second, widgetPlugin("uptime"))
+		scheduler.AddTask("certs", time.Hour, widgetPlugin("certs"))
+		scheduler.AddTask("domains", 24*time.Hour, widgetPlugin("domains"))
+		scheduler.AddTask("trends", time.Hour, widgetPlugin("trends"))
+		scheduler.AddTask("releases", time.Hour, widgetPlugin("releases"))
+		scheduler.AddTask("vulns", 24*time.Hour, widgetPlugin("vulns"))
+		scheduler.AddTask("system", 15*time.Second, widgetPlugin("system"))
+		scheduler.AddTask("network", 60*time.Second, widgetPlugin("network"))
+		scheduler.AddTask("toggl", 60*time.Second, widgetPlugin("toggl"))
+		scheduler.AddTask("reminders", 600*time.Second, widgetPlugin("reminders"))
 		scheduler.AddTask("jira", 45*time.Second, nil)
-		scheduler.AddTask("traffic", 300*time.Second, trafficPlugin)
-		scheduler.AddTask("calendar", 300*time.Second, calendarPlugin)
+		scheduler.AddTask("traffic", 300*time.Second, widgetPlugin("traffic"))
+		scheduler.AddTask("calendar", 300*time.Second, widgetPlugin("calendar"))
+	}
+	// Scheduled so the PR refresh slows down when the GitHub rate limit runs low
+	scheduler.AddTask("prs", 5*time.Minute, githubPRsPlugin)
+
+	quiet, err := NewQuietSchedule(cfg)
+	if err != nil {
+		fmt.Printf("Warning: Could not apply schedule: %v\n", err)
+	}
+	scheduler.SetQuietSchedule(quiet)
+	lowPower, err := NewLowPower(cfg)
+	if err != nil {
+		fmt.Printf("Warning: Could not apply schedule.low_power, polling at the configured rate: %v\n", err)
+	}
+	scheduler.SetLowPower(lowPower)
+	priorities, err := NewFetchPriorities(cfg)
+	if err != nil {
+		fmt.Printf("Warning: Skipped entries of schedule.priorities: %v\n", err)
+	}
+	scheduler.SetFetchPriorities(priorities)
+
+	preview, err := NewPreviewer(cfg, LoadTrafficHistory(TrafficHistoryPath()))
+	if err != nil {
+		fmt.Printf("Warning: Could not apply preview: %v\n", err)
+	}
+	goldenHour, err := NewGoldenHourReminder(cfg)
+	if err != nil {
+		fmt.Printf("Warning: Could not apply golden hour reminder: %v\n", err)
+	}
+	pomodoro, err := NewPomodoro(cfg)
+	if err != nil {
+		fmt.Printf("Warning: Could not apply pomodoro: %v\n", err)
+	}
+	var keybindings map[string][]string
+	if cfg != nil {
+		keybindings = cfg.Keybindings
+	}
+	keys, err := NewKeyMap(keybindings)
+	if err != nil {
+		fmt.Printf("Warning: Could not apply keybindings: %v\n", err)
+	}
+
+	// Create widget tiles with fixed sizes, restricted to the selected widgets if any
+	visible := opts.Widgets
+	if len(visible) == 0 && cfg != nil {
+		visible = cfg.UI.Widgets
 	}
 
-	// Create widget tiles with fixed sizes
-	widgets := []WidgetTile{
-		NewWidgetTile("JIRA", baseTileWidth, baseTileHeight),
-		NewWidgetTile("PRs", baseTileWidth, baseTileHeight),
-		NewWidgetTile("Builds", baseTileWidth, baseTileHeight),
-		NewWidgetTile("Commits", baseTileWidth, baseTileHeight),
-		NewWidgetTile("Calendar", baseTileWidth, baseTileHeight),
-		NewWidgetTile("Slack", baseTileWidth, baseTileHeight),
-		NewWidgetTile("Todos", baseTileWidth, baseTileHeight),
-		NewWidgetTile("Confluence", baseTileWidth, baseTileHeight),
-		NewWidgetTile("PagerDuty", baseTileWidth, baseTileHeight),
-		NewWidgetTile("Tech News", baseTileWidth, baseTileHeight),
-		NewWidgetTile("Traffic", baseTileWidth, baseTileHeight),
+	var widgets []WidgetTile
+	for _, tile := range selectTiles(visible, cfg.ConfiguredWidgets()) {
+		if !cfg.TileEnabled(tile.key) {
+			continue
+		}
+		widgets = append(widgets, NewWidgetTile(tile.key, tile.title, baseTileWidth, baseTileHeight))
+	}
+	if err := applyTileConfig(widgets, cfg); err != nil {
+		fmt.Printf("Warning: Could not apply tile settings: %v\n", err)
+	}
+
+	// Widgets whose program is missing say so once instead of failing on every fetch
+	capabilities := DetectCapabilities(runtime.GOOS, exec.LookPath)
+	capabilities.Degrade(widgetManager)
+	if pomodoro != nil {
+		widgetManager.UpdatePomodoroWidget(pomodoro.Status(time.Now()))
+	}
+	notes := NewNotes(NotesPath(cfg))
+	if loaded, err := notes.Load(); err == nil {
+		widgetManager.UpdateNotesWidget(loaded)
 	}
 
 	// Populate widgets with data
-	widgetNames := []string{"jira", "prs", "builds", "commits", "calendar", "slack", "todos", "confluence", "pagerduty", "news", "traffic"}
-	for i, name := range widgetNames {
-		if widget, exists := widgetManager.Widgets[name]; exists {
-			var items []WidgetItem
-			for _, item := range widget.Items {
-				items = append(items, WidgetItem{
-					Title:    item.Title,
-					Subtitle: item.Subtitle,
-					Status:   item.Status,
-					URL:      item.URL,
-				})
-			}
-			widgets[i].UpdateItems(items)
+	for i := range widgets {
+		if widget, exists := widgetManager.Widgets[widgets[i].key]; exists {
+			widgets[i].UpdateItems(widget.Items)
 			widgets[i].hasError = widget.HasError
 		}
 	}
 
+	// The last session's items stand in until fresh data loads; an attached dashboard
+	// shows the running one's instead
+	var lastSessionAt time.Time
+	if opts.Instance != instanceAttach {
+		if state, err := LoadLastSession(LastSessionDir(cfg)); err == nil {
+			restoreLastSession(widgets, state, capabilities)
+			lastSessionAt = state.UpdatedAt
+		}
+	}
+
+	// Record the placeholders, so the first data a tile shows is not taken as new
+	attention := NewAttentionTracker(cfg)
+	attention.Observe(widgets)
+	if capabilities.Missing("notify-send") {
+		attention.notify = nil
+	}
+
+	// Dry run changes nothing, so there is nothing to audit; its actions go to the dry run log
+	var auditLog *AuditLog
+	if cfg == nil || !cfg.Safety.DryRun {
+		auditLog = NewAuditLog(AuditLogPath())
+	}
+
+	var shown []string
+	for _, tile := range widgets {
+		shown = append(shown, tile.key)
+	}
+
+	// Attaching needs the running dashboard's state file; without one, refresh by hand
+	instance := opts.Instance
+	if instance == instanceAttach && StatePath(cfg) == "" {
+		instance = instanceManual
+	}
+
+	// On first run, ask the terminal what it can render and keep the answer for next time
+	terminal, chosen := TerminalProfileFromConfig(cfg, os.Getenv)
+	if !chosen {
+		terminal = DetectTerminal(os.Stdin, os.Stdout, os.Getenv)
+		if terminal.probed && configPath != "" {
+			if err := SaveTerminalProfile(configPath, terminal); err != nil {
+				fmt.Printf("Warning: Could not save the terminal profile: %v\n", err)
+			}
+		}
+	}
+	// Without either, lipgloss keeps the colors it detected itself, on the background
+	// guessed from the environment
+	if chosen || terminal.probed {
+		terminal.Apply()
+	} else {
+		terminal.ApplyBackground()
+	}
+	if cfg != nil && cfg.UI.Accessibility.HighContrast {
+		useHighContrastTheme()
+	}
+
 	return Model{
 		userName:       userName,
 		dateTime:       time.Now().Format("Mon 02 Jan 2006 15:04"),
 		weather:        fmt.Sprintf("☁ N/A (%s)", location),
 		location:       location,
 		config:         cfg,
+		configPath:     configPath,
+		statePath:      StatePath(cfg),
+		instance:       instance,
+		terminal:       terminal,
+		capabilities:   capabilities,
+		lastSessionAt:  lastSessionAt,
+		attention:      attention,
+		preview:        preview,
+		newsLanguage:   NewNewsLanguage(cfg),
+		goldenHour:     goldenHour,
+		pomodoro:       pomodoro,
+		notes:          notes,
+		keys:           keys,
+		versions:       NewDataVersions(),
+		refresh:        NewRefreshProgress(),
+		searchRunner:   NewSavedSearchRunner(cfg),
+		audit:          auditLog,
+		telemetry:      NewTelemetry(cfg, TelemetryPath(), shown),
+		depUpdater:     NewDependencyUpdater(cfg),
+		triager:        NewIssueTriager(cfg),
 		widgetManager:  widgetManager,
 		pluginManager:  pluginManager,
 		scheduler:      scheduler,
+		widgetPlugins:  widgetPlugins,
 		widgets:        widgets,
 		focusedWidget:  0,
 		terminalWidth:  100,
@@ -431,13 +950,12 @@ func (m Model) Init() tea.Cmd {
 		tickClock(),
 		tickWeather(),
 		tickNews(),
-		func() tea.Msg { return fetchNewsCmd{} }, // Immediate news fetch
-		func() tea.Msg { return fetchWeatherCmd{} },    // Immediate weather fetch
-		func() tea.Msg { return fetchGitCommitsCmd{} }, // Immediate git commits fetch
-		func() tea.Msg { return fetchGitHubPRsCmd{} },  // Immediate GitHub PRs fetch
-		func() tea.Msg { return fetchTrafficCmd{} },    // Immediate traffic fetch
-		func() tea.Msg { return fetchCalendarCmd{} },   // Immediate calendar fetch
-		tea.EnterAltScreen,
+		m.startupFetches(), // Immediate fetch of every widget, by priority (hidden ones are skipped)
+		func() tea.Msg { return fetchDaylightCmd{} }, // Immediate sunrise and sunset fetch
+		m.telemetry.sendCmd(),                        // Usage report, when opted in and due
+		m.attachInit(),                               // Running dashboard's data, when attached
+		m.altScreenInit(),                            // Alternate screen, unless the terminal lacks it
+		m.readBatteryCmd(),                           // Battery next to the clock, on laptops
 	)
 }
 
@@ -453,69 +971,365 @@ func tickWeather() tea.Cmd {
 	})
 }
 
+// fetchNews fetches news in the background and reports it, or the error, with newsMsg
+func fetchNews(plugin Plugin, language *NewsLanguage, version uint64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		data, err := plugin.Fetch(ctx)
+		if err != nil {
+			return newsMsg{err: err, version: version}
+		}
+		items, ok := data.([]NewsItem)
+		if !ok {
+			return newsMsg{err: fmt.Errorf("data type error: got %T", data), version: version}
+		}
+		return newsMsg{items: language.Apply(ctx, items), version: version}
+	}
+}
+
+// fetchNewsInBackground starts a news fetch; the tile keeps its news while it runs, with
+// a spinner in its title until the result or error arrives
+func (m *Model) fetchNewsInBackground(plugin Plugin) tea.Cmd {
+	version := m.versions.Next("news")
+	fetch := fetchNews(plugin, m.newsLanguage, version)
+	if m.waitFetches {
+		result := fetch()
+		fetch = func() tea.Msg { return result }
+	}
+	return tea.Batch(m.startFetch("news", version), fetch)
+}
+
+// fetchable reports whether widget is fetched at all. A widget whose program is missing
+// says so from the start instead, and a disabled one is left alone, except the weather
+// the header shows.
+func (m Model) fetchable(widget string) bool {
+	if _, missing := m.capabilities.MissingFor(widget); missing {
+		return false
+	}
+	return m.config.TileEnabled(widget) || widget == "weather"
+}
+
 func tickNews() tea.Cmd {
 	return tea.Tick(weatherInterval, func(t time.Time) tea.Msg {
 		return fetchNewsCmd{}
 	})
 }
 
+// Update handles a message. After each refresh new items are escalated by the attention
+// rules and the dashboard state is written to the state file for status bar tools.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	manual := false
+	if refresh, ok := msg.(refreshNowMsg); ok {
+		msg, manual = refresh.fetch, true
+	}
+	widget, isFetch := fetchWidget(msg)
+	if isFetch {
+		if !m.fetchable(widget) {
+			// Report a manual refresh's fetch so its progress does not wait for it
+			if manual {
+				return m, func() tea.Msg { return refreshDoneMsg{widget: widget} }
+			}
+			return m, nil
+		}
+		// Attached, the running dashboard does the polling; on manual refresh only r polls
+		// once each widget has had its first fetch
+		if m.instance == instanceAttach || (m.instance == instanceManual && !manual && m.versions.Seen(widget)) {
+			return m, nil
+		}
+		now := time.Now()
+		if !manual && m.scheduler != nil {
+			// During quiet time a paused widget is not polled; its fetch comes back later instead
+			if m.scheduler.Paused(widget, now) {
+				return m, tea.Tick(m.scheduler.GetInterval(widget, quietRecheck), func(time.Time) tea.Msg { return msg })
+			}
+			// A second fetch chain, such as one a manual refresh started, ends when it comes
+			// within half an interval of the last fetch
+			if m.versions.Recent(widget, now, m.scheduler.GetInterval(widget, time.Minute)/2) {
+				return m, nil
+			}
+		}
+		m.versions.Fetched(widget, now)
+		if m.scheduler != nil {
+			m.scheduler.Ran(widget, now)
+		}
+	}
+
+	model, cmd := m.update(msg)
+	updated, ok := model.(Model)
+	if _, inFlight := updated.fetching[widget]; manual && isFetch && !(ok && inFlight) {
+		// The fetch has run by now; report it so the refresh progress moves on. One still
+		// in flight reports it with its result.
+		cmd = tea.Batch(cmd, func() tea.Msg { return refreshDoneMsg{widget: widget} })
+	}
+	if !ok || !isRefreshMsg(msg) {
+		return model, cmd
+	}
+
+	if updated.attention != nil {
+		if events := updated.attention.Observe(updated.widgets); len(events) > 0 {
+			// Quiet time holds alerts back to its own level
+			if updated.scheduler != nil && updated.scheduler.quiet != nil {
+				limit := updated.scheduler.quiet.AlertCap(time.Now())
+				for i := range events {
+					if events[i].Level > limit {
+						events[i].Level = limit
+					}
+				}
+			}
+			// The running dashboard already rings and notifies for the same items
+			if updated.instance != instanceOwn {
+				for i := range events {
+					if events[i].Level > AttentionFlash {
+						events[i].Level = AttentionFlash
+					}
+				}
+			}
+			// Low power shows the status bar without flashing, which would wake twice a second,
+			// and so does reduced motion
+			updated.attention.steady = updated.lowPower(time.Now()) || updated.reducedMotion()
+			cmd = tea.Batch(cmd, updated.attention.Escalate(events))
+		}
+	}
+	if updated.statePath != "" && updated.instance == instanceOwn {
+		// A failed write must not disturb the dashboard; the next refresh retries
+		writeStateFile(updated.statePath, updated.dashboardState(time.Now()))
+	}
+	return model, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.terminalWidth = msg.Width
 		m.terminalHeight = msg.Height
 		return m, nil
+	case tea.MouseMsg:
+		return m.handleMouse(msg, time.Now())
+	case suspendMsg:
+		return m, m.suspend(time.Now())
+	case tea.ResumeMsg:
+		return m, m.resume(time.Now())
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
-			if m.cancel != nil {
-				m.cancel()
+		keys := m.keyMap()
+		// Suspending to the shell works whatever is open
+		if key.Matches(msg, keys.Suspend) {
+			return m, m.suspend(time.Now())
+		}
+
+		// The tag editor takes all keys while open
+		if m.tagEditor != nil && msg.String() != "ctrl+c" {
+			done, changed := m.tagEditor.Update(msg)
+			if changed {
+				if err := m.applyNewsTags(m.tagEditor.Tags()); err != nil {
+					m.tagEditor.SetError(err)
+				}
 			}
-			return m, tea.Quit
-		case "tab":
-			m.focusedWidget = (m.focusedWidget + 1) % len(m.widgets)
-			return m, nil
-		case "shift+tab":
-			m.focusedWidget = (m.focusedWidget - 1 + len(m.widgets)) % len(m.widgets)
-			return m, nil
-		case "up", "k":
-			// Navigate up within the focused widget
-			if m.focusedWidget < len(m.widgets) {
-				var cmd tea.Cmd
-				m.widgets[m.focusedWidget].list, cmd = m.widgets[m.focusedWidget].list.Update(msg)
-				return m, cmd
+			if done {
+				m.tagEditor = nil
+				return m, func() tea.Msg { return refreshNowMsg{fetch: fetchNewsCmd{}} }
 			}
 			return m, nil
-		case "down", "j":
-			// Navigate down within the focused widget
-			if m.focusedWidget < len(m.widgets) {
-				var cmd tea.Cmd
-				m.widgets[m.focusedWidget].list, cmd = m.widgets[m.focusedWidget].list.Update(msg)
-				return m, cmd
+		}
+
+		// The quick note overlay takes all keys while open
+		if m.noteCapture != nil && msg.String() != "ctrl+c" {
+			done, note := m.noteCapture.Update(msg)
+			if note != "" {
+				if err := m.notes.Append(note, time.Now()); err != nil {
+					m.noteCapture.SetError(err)
+					return m, nil
+				}
+				m.updateNotes(true)
 			}
-			return m, nil
-		case "t":
-			m.widgetManager.CycleNewsTag()
-			// Update the Tech News widget and refresh news
-			m.updateNewsWidget()
-			// Set the current tag in the news plugins
-			currentTag := m.widgetManager.GetCurrentNewsTag()
-			tagToSet := "all"
-			if currentTag != "All" {
-				tagToSet = strings.ToLower(currentTag)
+			if done {
+				m.noteCapture = nil
 			}
+			return m, nil
+		}
 
-			// Update all news plugins
-			newsPlugins := m.pluginManager.GetRegistry().GetAllNewsPlugins()
-			for _, plugin := range newsPlugins {
-				plugin.SetCurrentTag(tagToSet)
+		// The search palette takes all keys while open
+		if m.searchPalette != nil && msg.String() != "ctrl+c" {
+			done, search, link := m.searchPalette.Update(msg)
+			if done {
+				m.searchPalette = nil
+			}
+			if link != "" {
+				go openURL(link)
 			}
+			if search != nil {
+				return m, runSavedSearchCmd(m.searchRunner, *search)
+			}
+			return m, nil
+		}
 
-			// Trigger immediate news refresh
-			return m, func() tea.Msg { return fetchNewsCmd{} }
-		case "T":
-			m.widgetManager.NewsTagIndex = 0 // Reset to "All"
-			// Update the Tech News widget and refresh news
+		// The dependency updates panel takes all keys while open
+		if m.depPanel != nil && msg.String() != "ctrl+c" {
+			done, action, link := m.depPanel.Update(msg)
+			if done {
+				m.depPanel = nil
+			}
+			if link != "" {
+				go openURL(link)
+			}
+			if action != "" {
+				return m, runBulkActionCmd(m.depUpdater, action, m.depPanel.Green())
+			}
+			return m, nil
+		}
+
+		// The issue triage panel takes all keys while open
+		if m.triagePanel != nil && msg.String() != "ctrl+c" {
+			done, action, link := m.triagePanel.Update(msg)
+			if done {
+				m.triagePanel = nil
+			}
+			if link != "" {
+				go openURL(link)
+			}
+			if action != nil {
+				return m, runTriageActionCmd(m.triager, *action)
+			}
+			return m, nil
+		}
+
+		// The item detail view opens its link or closes, and ignores the rest
+		if m.detailItem != nil && msg.String() != "ctrl+c" {
+			switch {
+			case key.Matches(msg, keys.Open):
+				if m.detailItem.URL != "" {
+					go openURL(m.detailItem.URL)
+				}
+				m.detailItem = nil
+			case key.Matches(msg, keys.Back, keys.Details, keys.Quit):
+				m.detailItem = nil
+			}
+			return m, nil
+		}
+
+		// The plugin status view closes on its own keys and ignores the rest
+		if m.pluginStatus && msg.String() != "ctrl+c" {
+			if key.Matches(msg, keys.Back, keys.Open, keys.Quit, keys.PluginStatus) {
+				m.pluginStatus = false
+			}
+			return m, nil
+		}
+
+		// The key help view scrolls and closes on its own keys and ignores the rest
+		if m.keyHelp && msg.String() != "ctrl+c" {
+			switch {
+			case key.Matches(msg, keys.Back, keys.Help, keys.Quit):
+				m.keyHelp, m.keyHelpScroll = false, 0
+			case key.Matches(msg, keys.Up):
+				m.keyHelpScroll = max(0, m.keyHelpScroll-1)
+			case key.Matches(msg, keys.Down):
+				m.keyHelpScroll = min(m.keyHelpScroll+1, keyHelpMaxScroll(keys, m.terminalWidth, m.terminalHeight))
+			}
+			return m, nil
+		}
+
+		// A tile's filter takes all keys while it is typed
+		if m.focusedWidget < len(m.widgets) && m.widgets[m.focusedWidget].list.SettingFilter() && msg.String() != "ctrl+c" {
+			m.widgets[m.focusedWidget].updateFilter(msg)
+			return m, nil
+		}
+
+		m.telemetry.CountAction(keys.Action(msg))
+		switch {
+		case msg.String() == "ctrl+c", key.Matches(msg, keys.Quit):
+			if m.cancel != nil {
+				m.cancel()
+			}
+			// Counts not saved now are lost; a failed save must not block quitting
+			m.telemetry.Save()
+			return m, tea.Quit
+		case key.Matches(msg, keys.Expand):
+			// Expand the focused tile over the whole grid, then over the whole terminal with
+			// its items' details, then restore the grid
+			switch {
+			case !m.expanded:
+				m.expanded = true
+			case !m.zen:
+				m.zen = true
+			default:
+				m.expanded, m.zen = false, false
+			}
+			return m, nil
+		case key.Matches(msg, keys.Collapse):
+			// Fold the focused tile into its title below the grid, or unfold it
+			if m.focusedWidget < len(m.widgets) {
+				m.widgets[m.focusedWidget].collapsed = !m.widgets[m.focusedWidget].collapsed
+			}
+			return m, nil
+		case key.Matches(msg, keys.Back):
+			// Esc drops the focused tile's filter first, then restores the grid
+			if m.focusedWidget < len(m.widgets) && m.widgets[m.focusedWidget].list.IsFiltered() {
+				m.widgets[m.focusedWidget].list.ResetFilter()
+				return m, nil
+			}
+			m.expanded, m.zen = false, false
+			return m, nil
+		case key.Matches(msg, keys.Filter):
+			// Filter the focused tile's items as the filter is typed
+			if m.focusedWidget < len(m.widgets) {
+				m.widgets[m.focusedWidget].startFilter()
+			}
+			return m, nil
+		case key.Matches(msg, keys.NextWidget):
+			// Every tile may be disabled
+			if len(m.widgets) > 0 {
+				m.focusedWidget = (m.focusedWidget + 1) % len(m.widgets)
+			}
+			return m, nil
+		case key.Matches(msg, keys.PrevWidget):
+			if len(m.widgets) > 0 {
+				m.focusedWidget = (m.focusedWidget - 1 + len(m.widgets)) % len(m.widgets)
+			}
+			return m, nil
+		case key.Matches(msg, keys.Up):
+			// Navigate up within the focused widget
+			if m.focusedWidget < len(m.widgets) {
+				m.widgets[m.focusedWidget].list.CursorUp()
+			}
+			return m, nil
+		case key.Matches(msg, keys.Down):
+			// Navigate down within the focused widget
+			if m.focusedWidget < len(m.widgets) {
+				m.widgets[m.focusedWidget].list.CursorDown()
+			}
+			return m, nil
+		case key.Matches(msg, keys.Help):
+			m.keyHelp = true
+			return m, nil
+		case key.Matches(msg, keys.CycleTag):
+			m.widgetManager.CycleNewsTag()
+			// Update the Tech News widget and refresh news
+			m.updateNewsWidget()
+			// Set the current tag in the news plugins
+			currentTag := m.widgetManager.GetCurrentNewsTag()
+			tagToSet := "all"
+			if currentTag != "All" {
+				tagToSet = strings.ToLower(currentTag)
+			}
+
+			// Update all news plugins
+			newsPlugins := m.pluginManager.GetRegistry().GetAllNewsPlugins()
+			for _, plugin := range newsPlugins {
+				plugin.SetCurrentTag(tagToSet)
+			}
+
+			// Trigger immediate news refresh
+			return m, func() tea.Msg { return refreshNowMsg{fetch: fetchNewsCmd{}} }
+		case key.Matches(msg, keys.ResetTag):
+			// A second T, with the filter already on "All", opens the tag editor
+			if m.widgetManager.NewsTagIndex == 0 {
+				m.tagEditor = NewNewsTagEditor(m.widgetManager.NewsTags)
+				return m, nil
+			}
+
+			m.widgetManager.NewsTagIndex = 0 // Reset to "All"
+			// Update the Tech News widget and refresh news
 			m.updateNewsWidget()
 
 			// Set tag to "all" on all news plugins
@@ -525,14 +1339,128 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			// Trigger immediate news refresh
-			return m, func() tea.Msg { return fetchNewsCmd{} }
-		case "r", "R":
-			// Refresh all widgets
-			return m, tea.Batch(tickWeather(), tickNews())
-		case "enter":
+			return m, func() tea.Msg { return refreshNowMsg{fetch: fetchNewsCmd{}} }
+		case key.Matches(msg, keys.Search):
+			m.searchPalette = NewSearchPalette(m.config.SavedSearches())
+			return m, nil
+		case key.Matches(msg, keys.PluginStatus):
+			m.pluginStatus = true
+			return m, nil
+		case key.Matches(msg, keys.Dependencies):
+			m.depPanel = NewDependencyPanel(m.depUpdater.mergeMethod)
+			return m, listDependencyUpdatesCmd(m.depUpdater)
+		case key.Matches(msg, keys.Triage):
+			m.triagePanel = NewIssueTriagePanel(m.triager.labels, m.triager.closeComment)
+			return m, listTriageIssuesCmd(m.triager)
+		case key.Matches(msg, keys.QuietOverride):
+			// Working late: lift the quiet time until it ends, or restore it
+			if m.scheduler.quiet != nil {
+				m.scheduler.quiet.ToggleOverride(time.Now())
+			}
+			return m, nil
+		case key.Matches(msg, keys.LowPower):
+			// Low power: halve polling until toggled back, e.g. when unplugging
+			if m.scheduler.lowPower != nil {
+				m.scheduler.lowPower.Toggle(time.Now())
+			}
+			return m, nil
+		case key.Matches(msg, keys.Pomodoro):
+			// Start a pomodoro, or pause and resume the running one
+			if m.pomodoro == nil {
+				return m, nil
+			}
+			m.pomodoro.Toggle(time.Now())
+			return m, m.updatePomodoro()
+		case key.Matches(msg, keys.PomodoroSkip):
+			// Skip the rest of the session or break
+			if m.pomodoro == nil {
+				return m, nil
+			}
+			m.pomodoro.Skip(time.Now())
+			return m, m.updatePomodoro()
+		case key.Matches(msg, keys.Note):
+			m.noteCapture = NewNoteCapture()
+			return m, nil
+		case key.Matches(msg, keys.EditNotes):
+			// The dashboard waits while the notes file is open in the editor
+			return m, m.notes.editNotesCmd()
+		case key.Matches(msg, keys.TimerStart, keys.TimerStop):
+			// Start a timer on the default project, or stop the running one
+			plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["toggl"])
+			tracker, ok := plugin.(TimeTracker)
+			if !exists || !ok || m.tileByKey("toggl") == nil {
+				return m, nil
+			}
+			if key.Matches(msg, keys.TimerStop) {
+				return m, timerActionCmd(tracker, "stop")
+			}
+			return m, timerActionCmd(tracker, "start")
+		case key.Matches(msg, keys.Refresh):
+			// Attached, refreshing rereads the running dashboard's state
+			if m.instance == instanceAttach {
+				return m, attachCmd(m.statePath, 0)
+			}
+			// Refresh all widgets now; scheduled fetches that follow too soon are skipped
+			var cmds []tea.Cmd
+			var widgets []string
+			for _, fetch := range widgetFetchMsgs() {
+				widget, ok := fetchWidget(fetch)
+				if !ok || !m.fetchable(widget) {
+					continue
+				}
+				cmds = append(cmds, func() tea.Msg { return refreshNowMsg{fetch: fetch} })
+				widgets = append(widgets, widget)
+			}
+			m.refresh.Start(widgets)
+			return m, tea.Batch(cmds...)
+		case key.Matches(msg, keys.Retry):
+			// Fetch the focused widget again now if its last fetch failed
+			if m.focusedWidget >= len(m.widgets) || !m.widgets[m.focusedWidget].hasError {
+				return m, nil
+			}
+			if m.instance == instanceAttach {
+				return m, attachCmd(m.statePath, 0)
+			}
+			if fetch, ok := widgetFetchMsg(m.widgets[m.focusedWidget].key); ok {
+				return m, func() tea.Msg { return refreshNowMsg{fetch: fetch} }
+			}
+			return m, nil
+		case key.Matches(msg, keys.Details):
+			// Show the whole selected item and what its widget knows about it
+			if m.focusedWidget < len(m.widgets) {
+				if item, ok := m.widgets[m.focusedWidget].list.SelectedItem().(WidgetListItem); ok {
+					m.detailItem = &item
+				}
+			}
+			return m, nil
+		case key.Matches(msg, keys.Page):
+			// Read the selected item in full in $PAGER, suspending the dashboard meanwhile
+			if m.focusedWidget < len(m.widgets) {
+				if item, ok := m.widgets[m.focusedWidget].list.SelectedItem().(WidgetListItem); ok {
+					return m, pageItemCmd(item)
+				}
+			}
+			return m, nil
+		case key.Matches(msg, keys.Copy):
+			// Copy the selected item's link, or its title when it has none
+			title, _, url := m.getSelectedItemDetails()
+			if url != "" {
+				return m, copyToClipboard("link", url)
+			}
+			if title != "" {
+				return m, copyToClipboard("title", title)
+			}
+			return m, nil
+		case key.Matches(msg, keys.Open):
 			// Open the selected item in the focused widget
 			if m.focusedWidget < len(m.widgets) {
 				selected := m.widgets[m.focusedWidget].list.SelectedItem()
+				// The bots header in the PR widget expands and collapses its section
+				if item, ok := selected.(WidgetListItem); ok && m.widgets[m.focusedWidget].key == "prs" && strings.HasPrefix(item.ItemTitle, botPRsGroupTitle) {
+					m.widgetManager.ToggleBotPRs()
+					m.syncTile("prs")
+					return m, nil
+				}
 				if item, ok := selected.(WidgetListItem); ok && item.URL != "" {
 					// Open URL in browser
 					go func() {
@@ -546,46 +1474,168 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		}
+	case searchResultsMsg:
+		if m.searchPalette != nil {
+			m.searchPalette.SetResults(msg)
+		}
+		return m, nil
+	case dependencyUpdatesMsg:
+		if m.depPanel != nil {
+			m.depPanel.SetUpdates(msg)
+		}
+		return m, nil
+	case bulkActionMsg:
+		m.audit.RecordBulkAction(msg)
+		if m.depPanel != nil {
+			m.depPanel.SetResults(msg)
+		}
+		return m, nil
+	case triageIssuesMsg:
+		if m.triagePanel != nil {
+			m.triagePanel.SetIssues(msg)
+		}
+		return m, nil
+	case triageResultMsg:
+		m.audit.RecordTriage(msg)
+		if m.triagePanel != nil {
+			m.triagePanel.SetResult(msg)
+		}
+		return m, nil
 	case clockMsg:
 		m.dateTime = string(msg)
-		return m, tickClock()
+		now := time.Now()
+		for i := range m.widgets {
+			m.widgets[i].refreshAges(now)
+		}
+		// Quiet time holds the reminder back like other notifications
+		if m.goldenHour.Due(m.daylight, now) && m.instance == instanceOwn && (m.scheduler == nil || m.scheduler.quiet == nil || m.scheduler.quiet.AlertCap(now) >= AttentionNotify) {
+			desktopNotify("Golden hour", fmt.Sprintf("Golden hour starts at %s, sunset at %s", m.daylight.GoldenHour().Format("15:04"), m.daylight.Sunset.Format("15:04")))
+		}
+		// Notes edited outside the dashboard show up within a minute
+		m.updateNotes(false)
+		return m, tea.Batch(tickClock(), m.readBatteryCmd())
+	case notesEditedMsg:
+		m.updateNotes(true)
+		if msg.err != nil {
+			widget := m.widgetManager.Widgets["notes"]
+			widget.Items = append([]WidgetItem{{Title: "Editor failed", Subtitle: msg.err.Error(), Status: "❌"}}, widget.Items...)
+			m.syncTile("notes")
+		}
+		// Leaving the editor restores the terminal without mouse reporting
+		if m.terminal.Mouse {
+			return m, tea.EnableMouseCellMotion
+		}
+		return m, nil
+	case pomodoroTickMsg:
+		if m.pomodoro == nil || msg.run != m.pomodoro.run {
+			return m, nil
+		}
+		now := time.Now()
+		var record tea.Cmd
+		if ended := m.pomodoro.Advance(now); ended != pomodoroIdle {
+			if ended == pomodoroWork {
+				record = m.pomodoro.recordPomodoroCmd()
+			}
+			// Quiet time holds the notification back like other notifications
+			if m.pomodoro.notify && !m.capabilities.Missing("notify-send") && (m.scheduler == nil || m.scheduler.quiet == nil || m.scheduler.quiet.AlertCap(now) >= AttentionNotify) {
+				desktopNotify(m.pomodoro.Notification(ended, now))
+			}
+		}
+		return m, tea.Batch(record, m.updatePomodoro())
+	case batteryMsg:
+		m.battery = BatteryStatus(msg)
+		return m, nil
+	case attentionFlashMsg:
+		if m.attention == nil {
+			return m, nil
+		}
+		return m, m.attention.Flash()
+	case refreshDoneMsg:
+		m.refresh.Done(msg.widget)
+		return m, nil
+	case itemPagedMsg:
+		if msg.path != "" {
+			os.Remove(msg.path)
+		}
+		// Leaving the pager restores the terminal without mouse reporting
+		var cmd tea.Cmd
+		if m.terminal.Mouse {
+			cmd = tea.EnableMouseCellMotion
+		}
+		if msg.err != nil {
+			return m, tea.Batch(cmd, m.showToast("Could not open the pager: "+msg.err.Error()))
+		}
+		return m, cmd
+	case copiedMsg:
+		if msg.err != nil {
+			return m, m.showToast("Could not copy: " + msg.err.Error())
+		}
+		return m, m.showToast(fmt.Sprintf("📋 Copied %s: %s", msg.what, msg.text))
+	case toastDoneMsg:
+		if msg.id == m.toastID {
+			m.toast = ""
+		}
+		return m, nil
+	case spinner.TickMsg:
+		// The spinner stops turning once no fetch is in flight, or in low power
+		if len(m.fetching) == 0 || m.lowPower(time.Now()) {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	case attachMsg:
+		// Until the running dashboard has written its state, keep the placeholders
+		if msg.err == nil {
+			m.applyAttachedState(msg.state)
+		}
+		return m, attachCmd(m.statePath, attachInterval)
 	case weatherMsg:
-		m.weather = string(msg)
-		return m, tickWeather()
+		// A slower fetch must not bring back an older pill; fetches schedule the next one
+		if m.versions.Apply("weather", msg.version) {
+			m.weather = msg.pill
+		}
+		return m, nil
 	case newsMsg:
-		// Update news widget with real data
-		if len(msg) > 0 {
-			var items []WidgetItem
-			for _, news := range msg {
-				// Format subtitle to include source
-				subtitle := news.Author
-				if news.Source == "hackernews" {
-					subtitle = fmt.Sprintf("%s • HN", news.Author)
-					if news.Points > 0 {
-						subtitle = fmt.Sprintf("%s • %d pts", subtitle, news.Points)
-					}
-				} else if news.Source == "devto" {
-					subtitle = fmt.Sprintf("%s • Dev.to", news.Author)
-				}
-
-				items = append(items, WidgetItem{
-					Title:    news.Title,
-					Subtitle: subtitle,
-					URL:      news.URL,
+		// Update news widget with real data, unless newer news is already shown
+		m.finishFetch("news", msg.version)
+		var queued tea.Cmd
+		if _, running := m.fetching["news"]; m.newsQueued && !running {
+			m.newsQueued = false
+			if newsPlugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["news"]); exists {
+				queued = m.fetchNewsInBackground(newsPlugin)
+			}
+		}
+		if !m.versions.Apply("news", msg.version) {
+			return m, queued
+		}
+		if msg.err != nil {
+			if tile := m.tileByKey("news"); tile != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Failed to fetch news", Subtitle: msg.err.Error(), Status: "❌"},
 				})
+				tile.hasError = true
+			}
+			return m, queued
+		}
+		if len(msg.items) > 0 {
+			var items []WidgetItem
+			for _, news := range msg.items {
+				items = append(items, formatNewsItem(news))
 			}
-			// Update the Tech News widget (index 9)
-			if len(m.widgets) > 9 {
-				m.widgets[9].UpdateItems(items)
+			// Update the Tech News widget
+			if tile := m.tileByKey("news"); tile != nil {
+				tile.UpdateItems(items)
+				tile.hasError = false
 			}
 		}
-		return m, tickNews()
+		return m, queued
 	case fetchWeatherCmd:
 		// Fetch real weather data using plugin
-		weatherPlugin, exists := m.pluginManager.GetRegistry().GetPlugin("openweathermap")
+		weatherPlugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["weather"])
 		if !exists {
 			return m, tea.Batch(
-				tea.Tick(weatherInterval, func(t time.Time) tea.Msg { return fetchWeatherCmd{} }),
+				tea.Tick(m.scheduler.GetInterval("weather", weatherInterval), func(t time.Time) tea.Msg { return fetchWeatherCmd{} }),
 			)
 		}
 
@@ -594,78 +1644,56 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		data, err := weatherPlugin.Fetch(ctx)
 		if err != nil {
+			if tile := m.tileByKey("weather"); tile != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Forecast unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
 			return m, tea.Batch(
-				tea.Tick(weatherInterval, func(t time.Time) tea.Msg { return fetchWeatherCmd{} }),
+				tea.Tick(m.scheduler.GetInterval("weather", weatherInterval), func(t time.Time) tea.Msg { return fetchWeatherCmd{} }),
 			)
 		}
 
 		if weatherData, ok := data.(*WeatherData); ok {
+			m.forecast = weatherData.Hourly
+			m.widgetManager.UpdateWeatherWidget(weatherData, time.Now())
+			m.syncTile("weather")
+			version := m.versions.Next("weather")
 			return m, tea.Batch(
-				tea.Tick(weatherInterval, func(t time.Time) tea.Msg { return fetchWeatherCmd{} }),
+				tea.Tick(m.scheduler.GetInterval("weather", weatherInterval), func(t time.Time) tea.Msg { return fetchWeatherCmd{} }),
 				func() tea.Msg {
-					return weatherMsg(fmt.Sprintf("%s %d°C (%s)", weatherData.Icon, weatherData.Temperature, m.location))
+					return weatherMsg{pill: formatWeatherPill(weatherData, m.location, time.Now()), version: version}
 				},
 			)
 		}
 
 		return m, tea.Batch(
-			tea.Tick(weatherInterval, func(t time.Time) tea.Msg { return fetchWeatherCmd{} }),
+			tea.Tick(m.scheduler.GetInterval("weather", weatherInterval), func(t time.Time) tea.Msg { return fetchWeatherCmd{} }),
 		)
 	case fetchNewsCmd:
-		// Fetch real news data using aggregate plugin
-		newsPlugin, exists := m.pluginManager.GetRegistry().GetPlugin("aggregate-news")
+		// Fetch real news data using the configured news plugin
+		newsPlugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["news"])
 		if !exists {
 			// Update news widget to show error
-			if len(m.widgets) > 9 {
-				m.widgets[9].UpdateItems([]WidgetItem{
-					{Title: "Plugin not found", Subtitle: "aggregate-news missing", Status: "❌"},
-				})
-			}
-			return m, tea.Batch(
-				tea.Tick(weatherInterval, func(t time.Time) tea.Msg { return fetchNewsCmd{} }),
-			)
-		}
-
-		// Show fetching status
-		if len(m.widgets) > 9 {
-			m.widgets[9].UpdateItems([]WidgetItem{
-				{Title: "Fetching news...", Subtitle: "Connecting to APIs", Status: "🔄"},
-			})
-		}
-
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-
-		data, err := newsPlugin.Fetch(ctx)
-		if err != nil {
-			// Update news widget to show error
-			if len(m.widgets) > 9 {
-				m.widgets[9].UpdateItems([]WidgetItem{
-					{Title: "Failed to fetch news", Subtitle: err.Error(), Status: "❌"},
+			if tile := m.tileByKey("news"); tile != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Plugin not found", Subtitle: "news provider missing", Status: "❌"},
 				})
 			}
 			return m, tea.Batch(
-				tea.Tick(weatherInterval, func(t time.Time) tea.Msg { return fetchNewsCmd{} }),
+				tea.Tick(m.scheduler.GetInterval("news", weatherInterval), func(t time.Time) tea.Msg { return fetchNewsCmd{} }),
 			)
 		}
 
-		if items, ok := data.([]NewsItem); ok {
-			return m, tea.Batch(
-				tea.Tick(weatherInterval, func(t time.Time) tea.Msg { return fetchNewsCmd{} }),
-				func() tea.Msg { return newsMsg(items) },
-			)
-		} else {
-			// Update news widget to show type error
-			if len(m.widgets) > 9 {
-				m.widgets[9].UpdateItems([]WidgetItem{
-					{Title: "Data type error", Subtitle: fmt.Sprintf("Got %T", data), Status: "❌"},
-				})
-			}
+		next := tea.Tick(m.scheduler.GetInterval("news", weatherInterval), func(t time.Time) tea.Msg { return fetchNewsCmd{} })
+		// One news fetch runs at a time, as the plugins and translations keep state; one
+		// asked for meanwhile, say for a new tag, follows it
+		if _, running := m.fetching["news"]; running {
+			m.newsQueued = true
+			return m, next
 		}
-
-		return m, tea.Batch(
-			tea.Tick(weatherInterval, func(t time.Time) tea.Msg { return fetchNewsCmd{} }),
-		)
+		return m, tea.Batch(next, m.fetchNewsInBackground(newsPlugin))
 	case fetchGitCommitsCmd:
 		// Fetch Git commits using local Git plugin
 		gitPlugin, exists := m.pluginManager.GetRegistry().GetPlugin("local-git-commits")
@@ -695,16 +1723,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if err == nil {
 				if prs, ok := data.([]GitPullRequest); ok {
 					m.widgetManager.UpdateGitHubPRsWidget(prs)
+					m.syncTile("prs")
 				}
 			}
 		}
 
 		return m, tea.Batch(
-			tea.Tick(5*time.Minute, func(t time.Time) tea.Msg { return fetchGitHubPRsCmd{} }),
+			tea.Tick(m.scheduler.GetInterval("prs", 5*time.Minute), func(t time.Time) tea.Msg { return fetchGitHubPRsCmd{} }),
 		)
 	case fetchTrafficCmd:
-		// Fetch traffic data using OSRM plugin
-		trafficPlugin, exists := m.pluginManager.GetRegistry().GetPlugin("osrm_traffic")
+		// Fetch traffic data using the configured traffic plugin
+		trafficPlugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["traffic"])
 		if exists {
 			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 			defer cancel()
@@ -713,110 +1742,593 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if err == nil {
 				if biTraffic, ok := data.(*BiDirectionalTrafficData); ok {
 					m.widgetManager.UpdateBiDirectionalTrafficWidget(biTraffic)
-					// Update the traffic widget (index 10)
-					if len(m.widgets) > 10 {
-						if widget, exists := m.widgetManager.Widgets["traffic"]; exists {
-							var items []WidgetItem
-							for _, item := range widget.Items {
-								items = append(items, WidgetItem{
-									Title:    item.Title,
-									Subtitle: item.Subtitle,
-									Status:   item.Status,
-									URL:      item.URL,
-								})
-							}
-							m.widgets[10].UpdateItems(items)
-							m.widgets[10].hasError = widget.HasError
-						}
+					m.syncTile("traffic")
+					if m.preview != nil {
+						m.preview.RecordCommute(&biTraffic.OriginToDestination, time.Now())
 					}
 				} else if traffic, ok := data.(*TrafficData); ok {
 					// Fallback for single direction traffic data
 					m.widgetManager.UpdateTrafficWidget(traffic)
-					// Update the traffic widget (index 10)
-					if len(m.widgets) > 10 {
-						if widget, exists := m.widgetManager.Widgets["traffic"]; exists {
-							var items []WidgetItem
-							for _, item := range widget.Items {
-								items = append(items, WidgetItem{
-									Title:    item.Title,
-									Subtitle: item.Subtitle,
-									Status:   item.Status,
-									URL:      item.URL,
-								})
-							}
-							m.widgets[10].UpdateItems(items)
-							m.widgets[10].hasError = widget.HasError
-						}
+					m.syncTile("traffic")
+					if m.preview != nil {
+						m.preview.RecordCommute(traffic, time.Now())
 					}
 				}
 			} else {
 				// Update traffic widget to show error
-				if len(m.widgets) > 10 {
-					m.widgets[10].UpdateItems([]WidgetItem{
+				if tile := m.tileByKey("traffic"); tile != nil {
+					tile.UpdateItems([]WidgetItem{
 						{Title: "Traffic unavailable", Subtitle: err.Error(), Status: "❌"},
 					})
-					m.widgets[10].hasError = true
+					tile.hasError = true
 				}
 			}
 		}
 
 		return m, tea.Batch(
-			tea.Tick(5*time.Minute, func(t time.Time) tea.Msg { return fetchTrafficCmd{} }),
+			tea.Tick(m.scheduler.GetInterval("traffic", 5*time.Minute), func(t time.Time) tea.Msg { return fetchTrafficCmd{} }),
 		)
 	case fetchCalendarCmd:
-		// Fetch calendar data using Google Calendar plugin
-		calendarPlugin, exists := m.pluginManager.GetRegistry().GetPlugin("google-calendar")
-		if exists {
+		// Fetch calendar data using the configured calendar plugin
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["calendar"])
+		calendarPlugin, isCalendar := plugin.(CalendarSource)
+		if exists && isCalendar {
 			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 			defer cancel()
 
-			data, err := calendarPlugin.Fetch(ctx)
+			_, err := calendarPlugin.Fetch(ctx)
 			if err == nil {
-				if events, ok := data.([]GoogleCalendarEvent); ok && len(events) > 0 {
-					// Type assert to GoogleCalendarPlugin to access FormatEventsForDisplay
-					if gcPlugin, ok := calendarPlugin.(*GoogleCalendarPlugin); ok {
-						m.widgetManager.UpdateCalendarWidget(gcPlugin)
-						// Update the calendar widget (index 4)
-						if len(m.widgets) > 4 {
-							if widget, exists := m.widgetManager.Widgets["calendar"]; exists {
-								var items []WidgetItem
-								for _, item := range widget.Items {
-									items = append(items, WidgetItem{
-										Title:    item.Title,
-										Subtitle: item.Subtitle,
-										Status:   item.Status,
-										URL:      item.URL,
-									})
-								}
-								m.widgets[4].UpdateItems(items)
-								m.widgets[4].hasError = widget.HasError
-							}
-						}
-					}
-				}
+				m.widgetManager.UpdateCalendarWidget(calendarPlugin)
+				m.syncTile("calendar")
 			} else {
 				// Update calendar widget to show error
-				if len(m.widgets) > 4 {
+				if tile := m.tileByKey("calendar"); tile != nil {
 					// Check if it's an OAuth error requiring setup
 					errorMsg := err.Error()
 					if strings.Contains(errorMsg, "credentials") || strings.Contains(errorMsg, "oauth") {
-						m.widgets[4].UpdateItems([]WidgetItem{
+						tile.UpdateItems([]WidgetItem{
 							{Title: "Calendar Setup Required", Subtitle: "See ~/.goday/google_calendar_credentials.json", Status: "🔧"},
 							{Title: "Setup Guide", Subtitle: "Check console.cloud.google.com", Status: "📋"},
 						})
 					} else {
-						m.widgets[4].UpdateItems([]WidgetItem{
+						tile.UpdateItems([]WidgetItem{
 							{Title: "Calendar unavailable", Subtitle: errorMsg, Status: "❌"},
 						})
 					}
-					m.widgets[4].hasError = true
+					tile.hasError = true
 				}
 			}
 		}
 
 		return m, tea.Batch(
-			tea.Tick(5*time.Minute, func(t time.Time) tea.Msg { return fetchCalendarCmd{} }),
+			tea.Tick(m.scheduler.GetInterval("calendar", 5*time.Minute), func(t time.Time) tea.Msg { return fetchCalendarCmd{} }),
 		)
+	case fetchDiscussionsCmd:
+		// The discussions tile is optional, so skip the API calls while it is hidden
+		tile := m.tileByKey("discussions")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["discussions"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if discussions, ok := data.([]Discussion); ok && err == nil {
+				m.widgetManager.UpdateDiscussionsWidget(discussions)
+				m.syncTile("discussions")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Discussions unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("discussions", 10*time.Minute), func(t time.Time) tea.Msg { return fetchDiscussionsCmd{} })
+	case fetchMentionsCmd:
+		// The mentions tile is optional, so skip the API calls while it is hidden
+		tile := m.tileByKey("mentions")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["mentions"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if mentions, ok := data.([]Mention); ok && err == nil {
+				m.widgetManager.UpdateMentionsWidget(mentions)
+				m.syncTile("mentions")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Mentions unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("mentions", 2*time.Minute), func(t time.Time) tea.Msg { return fetchMentionsCmd{} })
+	case fetchStocksCmd:
+		// The stocks tile is optional, so skip the API calls while it is hidden
+		tile := m.tileByKey("stocks")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["stocks"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if quotes, ok := data.([]StockQuote); ok && err == nil {
+				m.widgetManager.UpdateStocksWidget(quotes)
+				m.syncTile("stocks")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Stocks unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("stocks", time.Minute), func(t time.Time) tea.Msg { return fetchStocksCmd{} })
+	case fetchOnCallCmd:
+		// Without an on-call backend set up the tile keeps its placeholder
+		tile := m.tileByKey("pagerduty")
+		if tile == nil || !m.config.ConfiguredWidgets()["pagerduty"] {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["pagerduty"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if status, ok := data.(*OnCallStatus); ok && err == nil {
+				m.widgetManager.UpdateOnCallWidget(status)
+				m.syncTile("pagerduty")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "On-call unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("pagerduty", time.Minute), func(t time.Time) tea.Msg { return fetchOnCallCmd{} })
+	case fetchAlertsCmd:
+		// The alerts tile is optional, so skip the API call while it is hidden
+		tile := m.tileByKey("alerts")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["alerts"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if groups, ok := data.([]AlertGroup); ok && err == nil {
+				m.widgetManager.UpdateAlertsWidget(groups)
+				m.syncTile("alerts")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Alerts unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("alerts", time.Minute), func(t time.Time) tea.Msg { return fetchAlertsCmd{} })
+	case fetchStatusPagesCmd:
+		// The status tile is optional, so skip the status pages while it is hidden
+		tile := m.tileByKey("status")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["status"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if services, ok := data.([]ServiceStatus); ok && err == nil {
+				m.widgetManager.UpdateStatusPagesWidget(services)
+				m.syncTile("status")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Status pages unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("status", 2*time.Minute), func(t time.Time) tea.Msg { return fetchStatusPagesCmd{} })
+	case fetchUptimeCmd:
+		// The uptime tile is optional, so skip the checks while it is hidden
+		tile := m.tileByKey("uptime")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["uptime"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if endpoints, ok := data.([]EndpointStatus); ok && err == nil {
+				m.widgetManager.UpdateUptimeWidget(endpoints)
+				m.syncTile("uptime")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Uptime checks unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("uptime", time.Minute), func(t time.Time) tea.Msg { return fetchUptimeCmd{} })
+	case fetchCertsCmd:
+		// The certificates tile is optional, so skip the checks while it is hidden
+		tile := m.tileByKey("certs")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["certs"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if certs, ok := data.([]CertStatus); ok && err == nil {
+				m.widgetManager.UpdateCertsWidget(certs, time.Now())
+				m.syncTile("certs")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Certificate checks unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("certs", time.Hour), func(t time.Time) tea.Msg { return fetchCertsCmd{} })
+	case fetchDomainsCmd:
+		// The domains tile is optional, so skip the lookups while it is hidden
+		tile := m.tileByKey("domains")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["domains"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if domains, ok := data.([]DomainStatus); ok && err == nil {
+				m.widgetManager.UpdateDomainsWidget(domains, time.Now())
+				m.syncTile("domains")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Domain lookups unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("domains", 24*time.Hour), func(t time.Time) tea.Msg { return fetchDomainsCmd{} })
+	case fetchReleasesCmd:
+		// The releases tile is optional, so skip the API calls while it is hidden
+		tile := m.tileByKey("releases")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["releases"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if releases, ok := data.([]Release); ok && err == nil {
+				m.widgetManager.UpdateReleasesWidget(releases, time.Now())
+				m.syncTile("releases")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Releases unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("releases", time.Hour), func(t time.Time) tea.Msg { return fetchReleasesCmd{} })
+	case fetchVulnsCmd:
+		// The vulnerabilities tile is optional, so skip the scan while it is hidden
+		if m.tileByKey("vulns") == nil {
+			return m, nil
+		}
+		if plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["vulns"]); exists {
+			// The scan reports back with vulnsMsg, which schedules the next one
+			return m, scanVulnsCmd(plugin)
+		}
+		return m, tea.Tick(m.scheduler.GetInterval("vulns", 24*time.Hour), func(t time.Time) tea.Msg { return fetchVulnsCmd{} })
+	case vulnsMsg:
+		// A scan asked for while another ran leaves the scheduling to that one
+		if errors.Is(msg.err, errScanRunning) {
+			return m, nil
+		}
+		if tile := m.tileByKey("vulns"); tile != nil {
+			if msg.err == nil {
+				m.widgetManager.UpdateVulnsWidget(msg.reports)
+				m.syncTile("vulns")
+			} else {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Vulnerability scan unavailable", Subtitle: msg.err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+		return m, tea.Tick(m.scheduler.GetInterval("vulns", 24*time.Hour), func(t time.Time) tea.Msg { return fetchVulnsCmd{} })
+	case fetchTrendsCmd:
+		// The trends tile is optional, so skip the counts while it is hidden
+		tile := m.tileByKey("trends")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["trends"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if trend, ok := data.(WorkloadTrend); ok && err == nil {
+				m.widgetManager.UpdateTrendsWidget(trend)
+				m.syncTile("trends")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Trends unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("trends", time.Hour), func(t time.Time) tea.Msg { return fetchTrendsCmd{} })
+	case fetchSystemCmd:
+		// The system tile is optional, so skip the readings while it is hidden
+		tile := m.tileByKey("system")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["system"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if snapshot, ok := data.(SystemSnapshot); ok && err == nil {
+				m.widgetManager.UpdateSystemWidget(snapshot)
+				m.syncTile("system")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "System readings unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("system", 15*time.Second), func(t time.Time) tea.Msg { return fetchSystemCmd{} })
+	case fetchNetworkCmd:
+		// The network tile is optional, so skip the measurements while it is hidden
+		tile := m.tileByKey("network")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["network"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if status, ok := data.(NetworkStatus); ok && err == nil {
+				m.widgetManager.UpdateNetworkWidget(status)
+				m.syncTile("network")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Network measurements unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("network", 60*time.Second), func(t time.Time) tea.Msg { return fetchNetworkCmd{} })
+	case fetchTogglCmd:
+		// The time tracking tile is optional, so skip the API calls while it is hidden
+		tile := m.tileByKey("toggl")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["toggl"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if status, ok := data.(TimeTrackingStatus); ok && err == nil {
+				m.widgetManager.UpdateTimeTrackingWidget(status)
+				m.syncTile("toggl")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Time tracking unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("toggl", 60*time.Second), func(t time.Time) tea.Msg { return fetchTogglCmd{} })
+	case timerActionMsg:
+		if msg.err != nil {
+			if tile := m.tileByKey("toggl"); tile != nil {
+				tile.UpdateItems(append([]WidgetItem{
+					{Title: "Could not " + msg.action + " the timer", Subtitle: msg.err.Error(), Status: "❌"},
+				}, m.widgetManager.Widgets["toggl"].Items...))
+			}
+			return m, nil
+		}
+		m.audit.RecordTimer(msg)
+		// Show the timer as the tracker now has it; the scheduled refresh carries on as before
+		return m, func() tea.Msg { return refreshNowMsg{fetch: fetchTogglCmd{}} }
+	case fetchRemindersCmd:
+		// The reminders tile is optional, so skip reading the file while it is hidden
+		tile := m.tileByKey("reminders")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["reminders"])
+		if exists {
+			data, err := plugin.Fetch(context.Background())
+			if reminders, ok := data.([]UpcomingReminder); ok && err == nil {
+				m.widgetManager.UpdateRemindersWidget(reminders)
+				m.syncTile("reminders")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Reminders unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("reminders", 600*time.Second), func(t time.Time) tea.Msg { return fetchRemindersCmd{} })
+	case fetchCryptoCmd:
+		// The crypto tile is optional, so skip the API call while it is hidden
+		tile := m.tileByKey("crypto")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["crypto"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if quotes, ok := data.([]CryptoQuote); ok && err == nil {
+				m.widgetManager.UpdateCryptoWidget(quotes)
+				m.syncTile("crypto")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Crypto prices unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("crypto", 2*time.Minute), func(t time.Time) tea.Msg { return fetchCryptoCmd{} })
+	case fetchFXCmd:
+		// The exchange rates tile is optional, so skip the API calls while it is hidden
+		tile := m.tileByKey("fx")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["fx"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if rates, ok := data.([]FXRate); ok && err == nil {
+				m.widgetManager.UpdateFXWidget(rates)
+				m.syncTile("fx")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Exchange rates unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("fx", time.Hour), func(t time.Time) tea.Msg { return fetchFXCmd{} })
+	case fetchDaylightCmd:
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["daylight"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+
+			// On failure the header keeps today's times, if it has them
+			data, err := plugin.Fetch(ctx)
+			if daylight, ok := data.(*Daylight); ok && daylight != nil && err == nil {
+				m.daylight = daylight
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("daylight", time.Hour), func(t time.Time) tea.Msg { return fetchDaylightCmd{} })
+	case fetchAQICmd:
+		// The air quality tile is optional, so skip the API call while it is hidden
+		tile := m.tileByKey("aqi")
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["aqi"])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if quality, ok := data.(*AirQuality); ok && quality != nil && err == nil {
+				m.widgetManager.UpdateAQIWidget(quality)
+				m.syncTile("aqi")
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Air quality unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		return m, tea.Tick(m.scheduler.GetInterval("aqi", 30*time.Minute), func(t time.Time) tea.Msg { return fetchAQICmd{} })
+	case fetchChatCmd:
+		// Chat widgets are optional, so skip the API calls while the tile is hidden
+		tile := m.tileByKey(msg.widget)
+		if tile == nil {
+			return m, nil
+		}
+
+		plugin, exists := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins[msg.widget])
+		if exists {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+
+			data, err := plugin.Fetch(ctx)
+			if activity, ok := data.([]ChatActivity); ok && err == nil {
+				m.widgetManager.UpdateChatWidget(msg.widget, activity)
+				m.syncTile(msg.widget)
+			} else if err != nil {
+				tile.UpdateItems([]WidgetItem{
+					{Title: "Chat unavailable", Subtitle: err.Error(), Status: "❌"},
+				})
+				tile.hasError = true
+			}
+		}
+
+		widget := msg.widget
+		return m, tea.Tick(m.scheduler.GetInterval(widget, 2*time.Minute), func(t time.Time) tea.Msg { return fetchChatCmd{widget: widget} })
 	}
 
 	// Handle list updates for the focused widget
@@ -829,11 +2341,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m Model) View() string {
+// renderHeader renders the bar above the grid: the user, clock, weather and status pills
+func (m Model) renderHeader() string {
 	// Header styling with proper weather pill
 	headerStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("236")).
-		Foreground(lipgloss.Color("229")).
+		Background(colorBar).
+		Foreground(colorTitle).
 		Bold(true).
 		Padding(0, 2).
 		Width(m.terminalWidth - 4).
@@ -851,32 +2364,150 @@ func (m Model) View() string {
 		Padding(0, 1).
 		Bold(true)
 
-	headerContent := fmt.Sprintf("%s  •  %s  •  %s  •  %s",
+	clock := m.dateTime
+	if pill := formatBatteryPill(m.battery); pill != "" {
+		batteryStyle := lipgloss.NewStyle().
+			Background(lipgloss.Color("238")).
+			Foreground(lipgloss.Color("15")).
+			Padding(0, 1)
+		if m.battery.Percent < batteryLowPercent {
+			batteryStyle = batteryStyle.Background(lipgloss.Color("160")).Bold(true)
+			if m.highContrast() {
+				pill += " low"
+			}
+		}
+		clock += "  " + batteryStyle.Render(pill)
+	}
+	headerContent := fmt.Sprintf("%s  •  %s  •  %s",
 		m.userName,
-		m.dateTime,
+		clock,
 		weatherPill.Render(m.weather),
-		refreshPill.Render("R Refresh"),
 	)
+	if pill := formatDaylightPill(m.daylight, time.Now()); pill != "" {
+		headerContent += "  •  " + lipgloss.NewStyle().
+			Background(lipgloss.Color("94")).
+			Foreground(lipgloss.Color("15")).
+			Padding(0, 1).
+			Render(pill)
+	}
+	if pill := m.goldenHour.Pill(m.daylight, time.Now()); pill != "" {
+		headerContent += "  •  " + lipgloss.NewStyle().
+			Background(lipgloss.Color("172")).
+			Foreground(lipgloss.Color("16")).
+			Padding(0, 1).
+			Bold(true).
+			Render(pill)
+	}
+	if pill := m.pomodoro.Pill(time.Now()); pill != "" {
+		headerContent += "  •  " + lipgloss.NewStyle().
+			Background(lipgloss.Color("124")).
+			Foreground(lipgloss.Color("15")).
+			Padding(0, 1).
+			Bold(true).
+			Render(pill)
+	}
+	if pill := m.refresh.Pill(); pill != "" {
+		headerContent += "  •  " + refreshPill.Render(pill)
+	} else {
+		headerContent += "  •  " + refreshPill.Render("R Refresh")
+	}
+	if m.config != nil && m.config.Safety.DryRun {
+		headerContent += "  •  " + lipgloss.NewStyle().
+			Background(lipgloss.Color("130")).
+			Foreground(lipgloss.Color("15")).
+			Padding(0, 1).
+			Render("🧪 Dry run")
+	}
+	if pill := m.instancePill(time.Now()); pill != "" {
+		headerContent += "  •  " + lipgloss.NewStyle().
+			Background(lipgloss.Color("24")).
+			Foreground(lipgloss.Color("15")).
+			Padding(0, 1).
+			Render(pill)
+	}
+	if m.lowPower(time.Now()) {
+		headerContent += "  •  " + lipgloss.NewStyle().
+			Background(lipgloss.Color("58")).
+			Foreground(lipgloss.Color("15")).
+			Padding(0, 1).
+			Render("🔋 Low power")
+	}
+	if pill := m.lastSessionPill(time.Now()); pill != "" {
+		headerContent += "  •  " + lipgloss.NewStyle().
+			Background(lipgloss.Color("240")).
+			Foreground(lipgloss.Color("15")).
+			Padding(0, 1).
+			Render(pill)
+	}
+	if pill := m.quietPill(time.Now()); pill != "" {
+		headerContent += "  •  " + lipgloss.NewStyle().
+			Background(lipgloss.Color("54")).
+			Foreground(lipgloss.Color("15")).
+			Padding(0, 1).
+			Render(pill)
+	}
+
+	return headerStyle.Render(headerContent)
+}
 
-	header := headerStyle.Render(headerContent)
+func (m Model) View() string {
+	// The key help takes the whole screen
+	if m.keyHelp {
+		return lipgloss.Place(m.terminalWidth, m.terminalHeight, lipgloss.Center, lipgloss.Center, renderKeyHelp(m.keyMap(), m.terminalWidth, m.terminalHeight, m.keyHelpScroll))
+	}
 
-	grid := m.renderWidgetGrid()
+	var header, grid string
+	zen := m.zen && m.focusedWidget < len(m.widgets)
+	if zen {
+		// Zen mode shows the focused tile alone over the whole terminal, with item details
+		grid = m.renderExpandedTile(m.terminalWidth-2, m.terminalHeight-2, true)
+	} else {
+		header = m.renderHeader()
+		grid = m.renderWidgetGrid()
+	}
+	if m.tagEditor != nil {
+		grid = lipgloss.Place(lipgloss.Width(grid), lipgloss.Height(grid), lipgloss.Center, lipgloss.Center, m.tagEditor.View())
+	} else if m.noteCapture != nil {
+		grid = lipgloss.Place(lipgloss.Width(grid), lipgloss.Height(grid), lipgloss.Center, lipgloss.Center, m.noteCapture.View())
+	} else if m.searchPalette != nil {
+		grid = lipgloss.Place(lipgloss.Width(grid), lipgloss.Height(grid), lipgloss.Center, lipgloss.Center, m.searchPalette.View())
+	} else if m.triagePanel != nil {
+		grid = lipgloss.Place(lipgloss.Width(grid), lipgloss.Height(grid), lipgloss.Center, lipgloss.Center, m.triagePanel.View())
+	} else if m.depPanel != nil {
+		grid = lipgloss.Place(lipgloss.Width(grid), lipgloss.Height(grid), lipgloss.Center, lipgloss.Center, m.depPanel.View())
+	} else if m.detailItem != nil {
+		grid = lipgloss.Place(lipgloss.Width(grid), lipgloss.Height(grid), lipgloss.Center, lipgloss.Center, renderItemDetail(*m.detailItem, min(lipgloss.Width(grid)-8, 100)))
+	} else if m.pluginStatus {
+		grid = lipgloss.Place(lipgloss.Width(grid), lipgloss.Height(grid), lipgloss.Center, lipgloss.Center, renderPluginStatus(pluginStatusRows(m.scheduler)))
+	}
+	if zen {
+		if m.terminal.NarrowEmoji {
+			grid = plainSymbols(grid)
+		}
+		return grid
+	}
 
 	// Legend styling
 	legendStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("243")).
+		Foreground(colorMuted).
 		Italic(true).
 		Padding(1, 2)
 
-	legend := legendStyle.Render("Legend: [w] log work; Enter opens link; ↑↓/jk navigate items; Tab/Shift+Tab moves focus; t/T cycles news tags; r/R refresh")
+	// The most used keys; ? shows them all
+	keyLegend := help.New()
+	keyLegend.Width = m.terminalWidth - 4
+	legend := legendStyle.Render(keyLegend.ShortHelpView(m.keyMap().ShortHelp()))
+	if m.toast != "" {
+		legend = legendStyle.Foreground(colorTitle).Italic(false).Render(lipgloss.NewStyle().MaxWidth(keyLegend.Width).Render(m.toast))
+	}
 
 	// Get selected item URL for display
 	selectedURL := m.getSelectedItemURL()
 	urlDisplay := ""
 	if selectedURL != "" {
 		urlStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("33")).
-			Background(lipgloss.Color("236")).
+			Foreground(colorAccent).
+			Background(colorBar).
 			Padding(0, 2).
 			Bold(true)
 		urlDisplay = urlStyle.Render(m.formatURLDisplay(selectedURL))
@@ -886,8 +2517,8 @@ func (m Model) View() string {
 			title, subtitle, _ := m.getSelectedItemDetails()
 			if title != "" {
 				infoStyle := lipgloss.NewStyle().
-					Foreground(lipgloss.Color("245")).
-					Background(lipgloss.Color("236")).
+					Foreground(colorMuted).
+					Background(colorBar).
 					Padding(0, 2).
 					Italic(true)
 
@@ -905,93 +2536,227 @@ func (m Model) View() string {
 	var contentParts []string
 	contentParts = append(contentParts, header, "", grid)
 
+	// In the evening, a card previews tomorrow's first meeting and when to leave for it
+	if preview := m.morningPreview(time.Now()); preview != nil {
+		contentParts = append(contentParts, "", renderMorningPreview(preview, time.Now()))
+	}
+
 	if urlDisplay != "" {
 		contentParts = append(contentParts, "", urlDisplay)
 	}
 
+	// The latest event at flash level or above stays in the status bar for a while
+	if m.attention != nil {
+		if banner, flashOn := m.attention.Banner(); banner != "" {
+			bannerStyle := lipgloss.NewStyle().
+				Foreground(colorAlert).
+				Background(colorBar).
+				Padding(0, 2).
+				Bold(true)
+			if flashOn {
+				bannerStyle = bannerStyle.Foreground(lipgloss.Color("15")).Background(lipgloss.Color("160"))
+			}
+			contentParts = append(contentParts, "", bannerStyle.Render(banner))
+		}
+	}
+
 	contentParts = append(contentParts, "", legend)
 
 	content := lipgloss.JoinVertical(lipgloss.Left, contentParts...)
+	if m.terminal.NarrowEmoji {
+		content = plainSymbols(content)
+	}
 
 	return content
 }
 
+// gridTileSize returns the tile size for a terminal width
+func gridTileSize(terminalWidth int) (width, height int) {
+	// Make tiles much larger and use more screen space
+	if terminalWidth > 120 {
+		return (terminalWidth - 10) / 3, baseTileHeight + 3 // Use most of screen width
+	} else if terminalWidth > 90 {
+		return baseTileWidth + 15, baseTileHeight + 2
+	}
+	return baseTileWidth, baseTileHeight
+}
+
 func (m Model) renderWidgetGrid() string {
-	// Calculate tiles per row (3 for better readability)
-	tilesPerRow := 3
 	// Dynamic tile sizing based on terminal width
-	tileWidth := baseTileWidth
-	tileHeight := baseTileHeight
-
-	// Make tiles much larger and use more screen space
-	if m.terminalWidth > 120 {
-		tileWidth = (m.terminalWidth - 10) / 3 // Use most of screen width
-		tileHeight = baseTileHeight + 3
-	} else if m.terminalWidth > 90 {
-		tileWidth = baseTileWidth + 15
-		tileHeight = baseTileHeight + 2
-	}
-
-	var rows []string
-
-	for i := 0; i < len(m.widgets); i += tilesPerRow {
-		var rowTiles []string
-		for j := 0; j < tilesPerRow && i+j < len(m.widgets); j++ {
-			tileIndex := i + j
-			tile := m.widgets[tileIndex]
-
-			// Update tile dimensions
-			tile.width = tileWidth
-			tile.height = tileHeight
-
-			// Update the list dimensions to match new tile size
-			tile.list.SetSize(tileWidth-6, tileHeight-4)
-
-			// Create tile content
-			tileContent := tile.View()
-
-			// Apply border styling
-			var borderStyle lipgloss.Style
-			if tileIndex == m.focusedWidget {
-				borderStyle = lipgloss.NewStyle().
-					Border(lipgloss.RoundedBorder()).
-					BorderForeground(lipgloss.Color("33")).
-					Width(tileWidth).
-					Height(tileHeight).
-					Bold(true).
-					BorderStyle(lipgloss.DoubleBorder())
-			} else {
-				borderStyle = lipgloss.NewStyle().
-					Border(lipgloss.RoundedBorder()).
-					BorderForeground(lipgloss.Color("240")).
-					Width(tileWidth).
-					Height(tileHeight)
-			}
+	tileWidth, tileHeight := gridTileSize(m.terminalWidth)
+	placements, gridRows := layoutTiles(m.widgets)
+	now := time.Now()
+	retryKey := m.keyMap().Retry.Help().Key
+	if m.expanded && m.focusedWidget < len(m.widgets) {
+		return m.renderExpandedTile(gridColumns*(tileWidth+2)-2, gridRows*(tileHeight+2)-2, false)
+	}
 
-			styledTile := borderStyle.Render(tileContent)
-			rowTiles = append(rowTiles, styledTile)
+	// Each cell of the grid holds a tile with its border; a tile spanning cells takes
+	// their borders too
+	cellWidth, cellHeight := tileWidth+2, tileHeight+2
+	boxes := make([][]string, len(m.widgets))
+	for _, p := range placements {
+		tile := m.widgets[p.tile]
+		width, height := p.cols*cellWidth-2, p.rows*cellHeight-2
+
+		// Update tile dimensions
+		tile.width = width
+		tile.height = height
+		tile.expanded = false
+		tile.details = false
+		tile.status, tile.stale = m.tileStatus(tile.key, now)
+		tile.spinner = m.fetchSpinner(tile.key)
+		tile.retryKey = retryKey
+		tile.markers = m.highContrast()
+
+		// Update the list dimensions to match new tile size
+		tile.list.SetSize(width-6, height-4)
+		if m.attention != nil {
+			tile.highlight = m.attention.Highlighted(tile.key)
+		}
 
-			// Update the original widget in the model
-			m.widgets[tileIndex] = tile
+		// Create tile content
+		tileContent := tile.View()
+
+		// Apply border styling
+		var borderStyle lipgloss.Style
+		if p.tile == m.focusedWidget {
+			borderStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(colorAccent).
+				Width(width).
+				Height(height).
+				Bold(true).
+				BorderStyle(lipgloss.DoubleBorder())
+		} else {
+			borderStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(colorBorder).
+				Width(width).
+				Height(height)
 		}
+		boxes[p.tile] = strings.Split(borderStyle.Render(tileContent), "\n")
 
-		// Join tiles horizontally with spacing
-		row := lipgloss.JoinHorizontal(lipgloss.Top, rowTiles...)
-		rows = append(rows, row)
+		// Update the original widget in the model
+		m.widgets[p.tile] = tile
 	}
 
-	// Join all rows vertically with spacing
-	grid := lipgloss.JoinVertical(lipgloss.Left, rows...)
+	// Lay the tiles out line by line, each line taking the tiles over it from left to right
+	lines := make([]string, 0, gridRows*cellHeight)
+	blank := strings.Repeat(" ", cellWidth)
+	for y := 0; y < gridRows*cellHeight; y++ {
+		var line strings.Builder
+		for col := 0; col < gridColumns; {
+			p, ok := placementAt(placements, y/cellHeight, col)
+			if !ok {
+				line.WriteString(blank)
+				col++
+				continue
+			}
+			if box := boxes[p.tile]; y-p.row*cellHeight < len(box) {
+				line.WriteString(box[y-p.row*cellHeight])
+			}
+			col += p.cols
+		}
+		lines = append(lines, line.String())
+	}
+	if bar := m.renderCollapsedTiles(gridColumns * cellWidth); bar != "" {
+		lines = append(lines, bar)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderExpandedTile renders the focused tile alone at the given size, with each item's
+// details below it in zen mode
+func (m Model) renderExpandedTile(width, height int, details bool) string {
+	tile := m.widgets[m.focusedWidget]
+	tile.width = width
+	tile.height = height
+	tile.expanded = true
+	tile.details = details
+	tile.spinner = m.fetchSpinner(tile.key)
+	tile.retryKey = m.keyMap().Retry.Help().Key
+	tile.markers = m.highContrast()
+	tile.list.SetSize(width-6, height-4)
+	if m.attention != nil {
+		tile.highlight = m.attention.Highlighted(tile.key)
+	}
+	m.widgets[m.focusedWidget] = tile
 
-	return grid
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Width(width).
+		Height(height).
+		Bold(true).
+		BorderStyle(lipgloss.DoubleBorder()).
+		Render(tile.View())
 }
 
 func (m *Model) updateNewsWidget() {
 	currentTag := m.widgetManager.GetCurrentNewsTag()
 	// Update the Tech News widget title to show current tag
-	if len(m.widgets) > 9 {
-		m.widgets[9].title = fmt.Sprintf("Tech News [%s]", currentTag)
+	if tile := m.tileByKey("news"); tile != nil {
+		tile.title = fmt.Sprintf("Tech News [%s]", currentTag)
+	}
+}
+
+// applyNewsTags replaces the news tags at runtime and saves them to the config file
+func (m *Model) applyNewsTags(tags []string) error {
+	m.widgetManager.NewsTags = tags
+	m.widgetManager.NewsTagIndex = 0
+	m.updateNewsWidget()
+
+	for _, plugin := range m.pluginManager.GetRegistry().GetAllNewsPlugins() {
+		plugin.SetTags(tags)
+		plugin.SetCurrentTag("all")
+	}
+
+	if m.config == nil || m.configPath == "" {
+		return nil
+	}
+	m.config.Widgets.News.Tags = tags
+	if err := SaveNewsTags(m.configPath, tags); err != nil {
+		return fmt.Errorf("tags applied but not saved: %w", err)
 	}
+	return nil
+}
+
+// quietPill describes the quiet time in the header, or "" outside quiet time
+func (m Model) quietPill(now time.Time) string {
+	if m.scheduler == nil || m.scheduler.quiet == nil {
+		return ""
+	}
+	quiet := m.scheduler.quiet
+	if quiet.Quiet(now) {
+		return "🌙 Quiet until " + quiet.QuietEnd(now).Format("Mon 15:04")
+	}
+	if quiet.Overridden(now) {
+		return "💡 Working late"
+	}
+	return ""
+}
+
+// tileByKey returns the visible tile for a widget key, or nil if it is hidden
+func (m *Model) tileByKey(key string) *WidgetTile {
+	for i := range m.widgets {
+		if m.widgets[i].key == key {
+			return &m.widgets[i]
+		}
+	}
+	return nil
+}
+
+// syncTile copies a widget manager widget into its visible tile
+func (m *Model) syncTile(key string) {
+	tile := m.tileByKey(key)
+	widget, exists := m.widgetManager.Widgets[key]
+	if tile == nil || !exists {
+		return
+	}
+	tile.UpdateItems(widget.Items)
+	tile.hasError = widget.HasError
 }
 
 // getSelectedItemURL returns the URL of the currently selected item
@@ -1056,6 +2821,16 @@ func main() {
 	if len(os.Args) > 1 {
 		switch os.Args[1] {
 		case "config", "--config", "-c":
+			if len(os.Args) > 2 && os.Args[2] == "schema" {
+				schema, err := ConfigSchemaJSON()
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error generating config schema: %v\n", err)
+					os.Exit(1)
+				}
+				fmt.Println(string(schema))
+				return
+			}
+
 			configPath, err := GetConfigPath()
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error getting config path: %v\n", err)
@@ -1070,22 +2845,116 @@ func main() {
 				fmt.Println("Config file exists and ready to use.")
 			}
 			return
+		case "statusline":
+			if err := runStatusline(os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(2)
+			}
+			return
+		case "search":
+			if err := runSearch(os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(2)
+			}
+			return
+		case "sync":
+			if err := runSync(os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "audit":
+			if err := runAudit(os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(2)
+			}
+			return
+		case "doctor":
+			if err := runDoctor(os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "telemetry":
+			if err := runTelemetry(os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(2)
+			}
+			return
 		case "help", "--help", "-h":
 			fmt.Println("GoDay Terminal Dashboard")
 			fmt.Println("")
 			fmt.Println("Usage:")
 			fmt.Println("  goday              Start the dashboard")
 			fmt.Println("  goday config       Show config file location")
+			fmt.Println("  goday config schema  Print the JSON Schema for config.yaml")
+			fmt.Println("  goday statusline [--format xbar]  Refresh once and print an xbar/SwiftBar menu")
+			fmt.Println("  goday search QUERY [--json|--rofi]  Print matching widget items for a launcher")
+			fmt.Println("  goday audit [--limit N] [--json]  Show the actions taken from the dashboard")
+			fmt.Println("  goday sync         Merge state with your other machines through sync.dir")
+			fmt.Println("  goday telemetry status  Show whether usage telemetry is on and what it sends")
+			fmt.Println("  goday doctor       Detect what the terminal can render and save it to the config")
 			fmt.Println("  goday help         Show this help message")
 			fmt.Println("")
+			fmt.Println("Flags (override config.yaml for this run):")
+			fmt.Println("  --location CITY          Weather location, e.g. \"Berlin,DE\"")
+			fmt.Println("  --widgets a,b,...        Only show these widgets, e.g. news,calendar")
+			fmt.Println("  --ttl widget=duration    Refresh interval, e.g. news=300s (repeatable)")
+			fmt.Println("")
 			fmt.Println("Config file: ~/.goday/config.yaml")
 			fmt.Println("Setup:       ./setup-config.sh")
 			return
 		}
 	}
 
-	p := tea.NewProgram(initialModel())
-	if err := p.Start(); err != nil {
+	opts, err := ParseFlags(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintln(os.Stderr, "Run 'goday help' for usage.")
+		os.Exit(2)
+	}
+
+	// A second dashboard would double every API call and notification, so ask how to share
+	lockPath := InstanceLockPath()
+	instance, pid, err := AcquireInstanceLock(lockPath)
+	if errors.Is(err, errAlreadyRunning) {
+		mode := opts.Instance
+		if mode == instanceOwn {
+			mode = askInstanceMode(os.Stdin, os.Stdout, pid)
+		}
+		switch mode {
+		case instanceQuit:
+			return
+		case instanceSteal:
+			if instance, err = StealInstance(lockPath, pid); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			mode = instanceOwn
+		}
+		opts.Instance = mode
+	} else {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not check for a running dashboard: %v\n", err)
+		}
+		// Nothing to attach to, so this is the dashboard that polls
+		opts.Instance = instanceOwn
+	}
+	defer instance.Release()
+
+	model := initialModel(opts)
+	p := tea.NewProgram(model, model.terminal.ProgramOptions()...)
+	watchSuspend(p)
+	final, err := p.Run()
+	// Saved however the dashboard quit, so the next start shows this session's items
+	if final, ok := final.(Model); ok && final.instance == instanceOwn {
+		if dir := LastSessionDir(final.config); dir != "" {
+			if err := final.SaveLastSession(dir, time.Now()); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not save the last session: %v\n", err)
+			}
+		}
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -181,6 +181,7 @@ func TestParseTTL(t *testing.T) {
 		{"20s", 20 * time.Second},
 		{"", 600 * time.Second},        // Default
 		{"invalid", 600 * time.Second}, // Default on error
+		{"0s", 600 * time.Second},      // Default rather than a busy loop
 	}
 
 	for _, test := range tests {
@@ -190,3 +191,48 @@ func TestParseTTL(t *testing.T) {
 		}
 	}
 }
+
+func FuzzLoadConfig(f *testing.F) {
+	if data, err := os.ReadFile("config.yaml"); err == nil {
+		f.Add(string(data))
+	}
+	f.Add("user:\n  name: \"Test User\"\nwidgets:\n  weather:\n    ttl: 600s\n")
+	f.Add("widgets: null\n")
+	f.Add("widgets:\n  traffic:\n    origin: 12\n    destination: {latitude: 12, longitude: \"77.6\"}\n")
+	f.Add("user: [a, b]\nui:\n  widgets: {prs: true}\n")
+	f.Add("schedule:\n  quiet:\n    - days: [sat]\n      from: \"25:00\"\n")
+	f.Add("plugins:\n  github-prs: ~\n  1: x\n")
+
+	path := f.TempDir() + "/config.yaml"
+	f.Fuzz(func(t *testing.T, data string) {
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := LoadConfig(path)
+		if err != nil {
+			return
+		}
+		if cfg == nil {
+			t.Fatal("Expected a config when loading succeeds")
+		}
+		// What the dashboard does with a loaded config must not panic either
+		cfg.ConfiguredWidgets()
+		for _, widget := range DefaultProviderRegistry().Widgets() {
+			cfg.WidgetProviderName(widget)
+		}
+		NewQuietSchedule(cfg)
+		NewGoldenHourReminder(cfg)
+		ParseTTL(cfg.Widgets.Weather.TTL)
+	})
+}
+
+func FuzzParseTTL(f *testing.F) {
+	for _, seed := range []string{"600s", "20s", "", "invalid", "0s", "-5m", "1h30m", "9223372036854775807ns", "1e3s", "١٠s"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, ttl string) {
+		if got := ParseTTL(ttl); got <= 0 {
+			t.Errorf("ParseTTL(%q) = %v, expected a positive interval", ttl, got)
+		}
+	})
+}
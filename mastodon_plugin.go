@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MastodonPlugin shows mentions and hashtag timelines from a Mastodon instance
+type MastodonPlugin struct {
+	*BaseNewsPlugin
+	instance    string
+	accessToken string
+	hashtags    []string
+	rateLimitTracker
+}
+
+type mastodonStatus struct {
+	Content   string    `json:"content"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+	Account   struct {
+		Acct string `json:"acct"`
+	} `json:"account"`
+	Tags []struct {
+		Name string `json:"name"`
+	} `json:"tags"`
+	FavouritesCount int `json:"favourites_count"`
+}
+
+// NewMastodonPlugin creates a new Mastodon plugin
+func NewMastodonPlugin() *MastodonPlugin {
+	base := NewBaseNewsPlugin(
+		"mastodon",
+		"Mastodon",
+		"1.0.0",
+		"Shows Mastodon mentions and hashtag timelines",
+		"GoDay Team",
+	)
+	base.supportedTags = []string{"all"}
+
+	return &MastodonPlugin{
+		BaseNewsPlugin: base,
+		accessToken:    os.Getenv("MASTODON_ACCESS_TOKEN"),
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (mp *MastodonPlugin) Initialize(config map[string]interface{}) error {
+	if tags := configStringList(config["tags"]); tags != nil {
+		mp.SetTags(tags)
+	}
+	if currentTag, ok := config["current_tag"].(string); ok {
+		mp.SetCurrentTag(currentTag)
+	}
+	if instance, ok := config["instance"].(string); ok && instance != "" {
+		if !strings.Contains(instance, "://") {
+			instance = "https://" + instance
+		}
+		mp.instance = strings.TrimRight(instance, "/")
+	}
+	if token, ok := config["access_token"].(string); ok && token != "" {
+		mp.accessToken = token
+	}
+	mp.hashtags = nil
+	for _, tag := range configStringList(config["hashtags"]) {
+		mp.hashtags = append(mp.hashtags, strings.TrimPrefix(tag, "#"))
+	}
+	mp.supportedTags = append([]string{"all"}, mp.hashtags...)
+	return nil
+}
+
+// Fetch returns recent mentions followed by posts from the hashtag timelines. When a
+// news tag is selected with t, that tag's timeline is shown instead of the configured hashtags.
+func (mp *MastodonPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if mp.instance == "" {
+		return mp.lastData, fmt.Errorf("Mastodon instance not configured (widgets.news.mastodon.instance)")
+	}
+
+	var items []NewsItem
+	if mp.accessToken != "" {
+		mentions, err := mp.fetchMentions(ctx)
+		if err != nil {
+			return mp.lastData, err
+		}
+		items = append(items, mp.filterByCurrentTag(mentions)...)
+	}
+
+	// Without configured hashtags, follow the news tags
+	hashtags := mp.hashtags
+	if len(hashtags) == 0 {
+		hashtags = mp.tags
+	}
+	if tag := mp.GetCurrentTag(); tag != "all" && tag != "" {
+		hashtags = []string{tag}
+	}
+
+	var posts []NewsItem
+	for _, tag := range hashtags {
+		var statuses []mastodonStatus
+		if err := mp.get(ctx, "/api/v1/timelines/tag/"+url.PathEscape(tag)+"?limit=10", &statuses); err != nil {
+			return mp.lastData, fmt.Errorf("#%s: %w", tag, err)
+		}
+		for _, status := range statuses {
+			posts = append(posts, mastodonNewsItem(status))
+		}
+	}
+	sort.SliceStable(posts, func(i, j int) bool {
+		return posts[i].CreatedAt > posts[j].CreatedAt
+	})
+	items = append(items, dedupeNewsItems(posts, items)...)
+
+	if len(items) > 12 {
+		items = items[:12]
+	}
+
+	mp.lastData = items
+	return items, nil
+}
+
+// fetchMentions returns the statuses that mention the token's account, newest first
+func (mp *MastodonPlugin) fetchMentions(ctx context.Context) ([]NewsItem, error) {
+	var notifications []struct {
+		Status *mastodonStatus `json:"status"`
+	}
+	if err := mp.get(ctx, "/api/v1/notifications?types[]=mention&limit=10", &notifications); err != nil {
+		return nil, fmt.Errorf("mentions: %w", err)
+	}
+
+	var items []NewsItem
+	for _, notification := range notifications {
+		if notification.Status == nil {
+			continue
+		}
+		item := mastodonNewsItem(*notification.Status)
+		item.Source = "mastodon-mention"
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// get performs a Mastodon API request and decodes the JSON response. Hashtag
+// timelines are public, so the token is only sent when one is configured.
+func (mp *MastodonPlugin) get(ctx context.Context, path string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", mp.instance+path, nil)
+	if err != nil {
+		return err
+	}
+	if mp.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+mp.accessToken)
+	}
+
+	resp, err := mp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	mp.observeRateLimit(resp)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("Mastodon access token rejected")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Mastodon API returned status %d", resp.StatusCode)
+	}
+	return json.Unmarshal(body, target)
+}
+
+// mastodonNewsItem converts a status into a news item titled with its text
+func mastodonNewsItem(status mastodonStatus) NewsItem {
+	text := stripHTML(status.Content)
+	title := text
+	if runes := []rune(title); len(runes) > 100 {
+		title = string(runes[:99]) + "…"
+	}
+	if title == "" {
+		title = "(media)"
+	}
+
+	var tags []string
+	for _, tag := range status.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	return NewsItem{
+		Title:       title,
+		URL:         status.URL,
+		Points:      status.FavouritesCount,
+		Author:      "@" + status.Account.Acct,
+		CreatedAt:   status.CreatedAt.Unix(),
+		Source:      "mastodon",
+		Description: text,
+		Tags:        tags,
+	}
+}
+
+// dedupeNewsItems returns the items whose URL does not appear in seen or earlier in items
+func dedupeNewsItems(items, seen []NewsItem) []NewsItem {
+	urls := make(map[string]bool)
+	for _, item := range seen {
+		urls[item.URL] = true
+	}
+
+	var unique []NewsItem
+	for _, item := range items {
+		if urls[item.URL] {
+			continue
+		}
+		urls[item.URL] = true
+		unique = append(unique, item)
+	}
+	return unique
+}
+
+// stripHTML reduces status HTML to a single line of text
+func stripHTML(content string) string {
+	var text, tag strings.Builder
+	inTag := false
+	for _, r := range content {
+		switch {
+		case r == '<':
+			inTag = true
+			tag.Reset()
+		case r == '>':
+			inTag = false
+			// Paragraphs and line breaks become spaces; inline tags like <span> vanish
+			if fields := strings.Fields(tag.String()); len(fields) > 0 {
+				name := strings.ToLower(strings.Trim(fields[0], "/"))
+				if name == "p" || name == "br" {
+					text.WriteRune(' ')
+				}
+			}
+		case inTag:
+			tag.WriteRune(r)
+		default:
+			text.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(html.UnescapeString(text.String())), " ")
+}
+
+// formatMastodonItem shows who posted and when, flagging mentions of you like unread
+// chat messages
+func formatMastodonItem(news NewsItem) WidgetItem {
+	status := ""
+	if news.Source == "mastodon-mention" {
+		status = "💬"
+	}
+	return WidgetItem{
+		Title:    news.Title,
+		Subtitle: fmt.Sprintf("%s • %s", news.Author, formatTimeAgo(time.Unix(news.CreatedAt, 0))),
+		Status:   status,
+		URL:      news.URL,
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func mastodonTestStatus(id, content, acct string, created time.Time, tags ...string) string {
+	tagJSON := ""
+	for i, tag := range tags {
+		if i > 0 {
+			tagJSON += ","
+		}
+		tagJSON += fmt.Sprintf(`{"name":%q}`, tag)
+	}
+	return fmt.Sprintf(`{"content":%q,"url":"https://social.example/@%s/%s","created_at":%q,"account":{"acct":%q},"tags":[%s]}`,
+		content, acct, id, created.Format(time.RFC3339), acct, tagJSON)
+}
+
+func TestMastodonPluginFetch(t *testing.T) {
+	now := time.Now().UTC()
+	var requested []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v1/notifications":
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprintf(w, `[{"status":%s},{"status":null}]`,
+				mastodonTestStatus("1", `<p><span class="h-card"><a href="#">@<span>me</span></a></span> lunch &amp; <a href="#">#<span>golang</span></a>?</p>`, "alice", now.Add(-time.Minute), "golang"))
+		case "/api/v1/timelines/tag/golang":
+			fmt.Fprintf(w, `[%s,%s]`,
+				mastodonTestStatus("1", "<p>duplicate of the mention</p>", "alice", now.Add(-time.Minute), "golang"),
+				mastodonTestStatus("2", "<p>Go 1.30 released</p><p>Details inside</p>", "gopher", now.Add(-time.Hour), "golang"))
+		case "/api/v1/timelines/tag/rust":
+			fmt.Fprintf(w, `[%s]`, mastodonTestStatus("3", "<p>Rust news</p>", "crab", now.Add(-30*time.Minute), "rust"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	plugin := NewMastodonPlugin()
+	err := plugin.Initialize(map[string]interface{}{
+		"instance":     server.URL + "/",
+		"access_token": "test-token",
+		"hashtags":     []interface{}{"#golang", "rust"},
+		"tags":         []string{"golang", "rust"},
+		"current_tag":  "all",
+	})
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	items := data.([]NewsItem)
+	if len(items) != 3 {
+		t.Fatalf("Expected a mention and 2 unique hashtag posts, got %d: %+v", len(items), items)
+	}
+
+	if items[0].Source != "mastodon-mention" || items[0].Title != "@me lunch & #golang?" {
+		t.Errorf("Expected the mention first with plain text, got %s '%s'", items[0].Source, items[0].Title)
+	}
+	if items[1].Title != "Rust news" || items[2].Title != "Go 1.30 released Details inside" {
+		t.Errorf("Expected hashtag posts newest first, got '%s', '%s'", items[1].Title, items[2].Title)
+	}
+	if items[2].Author != "@gopher" || items[2].Source != "mastodon" {
+		t.Errorf("Expected author '@gopher' from mastodon, got '%s' from %s", items[2].Author, items[2].Source)
+	}
+
+	// Selecting a tag with t narrows to that tag's timeline
+	requested = nil
+	plugin.SetCurrentTag("rust")
+	data, err = plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch with tag failed: %v", err)
+	}
+	items = data.([]NewsItem)
+	if len(items) != 1 || items[0].Title != "Rust news" {
+		t.Errorf("Expected only the rust timeline, got %+v", items)
+	}
+	for _, path := range requested {
+		if path == "/api/v1/timelines/tag/golang" {
+			t.Error("Expected the golang timeline not to be fetched while rust is selected")
+		}
+	}
+}
+
+func TestMastodonPluginRequiresInstance(t *testing.T) {
+	plugin := NewMastodonPlugin()
+	if err := plugin.Initialize(map[string]interface{}{}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected Fetch to fail without an instance")
+	}
+
+	plugin.Initialize(map[string]interface{}{"instance": "fosstodon.org"})
+	if plugin.instance != "https://fosstodon.org" {
+		t.Errorf("Expected instance 'https://fosstodon.org', got '%s'", plugin.instance)
+	}
+}
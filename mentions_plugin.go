@@ -0,0 +1,531 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// mentionSources are the systems the mentions tile can merge, in deduplication order:
+// when the same thread shows up twice, the earlier source wins, so notification emails
+// give way to the GitHub or Jira item they are about.
+var mentionSources = []string{"github", "jira", "slack", "email"}
+
+// defaultMentionsJQL finds Jira issues with recent comments that @mention you
+const defaultMentionsJQL = "comment ~ currentUser() AND updated >= -7d ORDER BY updated DESC"
+
+// Mention is something on one of the connected systems that needs you
+type Mention struct {
+	Source  string // github, jira, slack or email
+	Title   string
+	From    string // who or where the mention came from
+	URL     string
+	Updated time.Time
+	key     string // identifies the thread across sources
+}
+
+// MentionsPlugin merges mentions from GitHub, Jira, Slack and email into one list
+type MentionsPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	sources     []string // empty means every source with credentials
+	github      githubAPI
+	jira        *SavedSearchRunner
+	jiraJQL     string
+	slackURL    string
+	slackToken  string
+	slackUserID string
+	gmailURL    string
+	gmailToken  string
+	emailQuery  string
+	maxResults  int
+	client      *http.Client
+	lastData    []Mention
+}
+
+// NewMentionsPlugin creates a new mentions plugin. Tokens fall back to GITHUB_TOKEN/GH_TOKEN,
+// JIRA_API_TOKEN, SLACK_USER_TOKEN and GMAIL_ACCESS_TOKEN.
+func NewMentionsPlugin() *MentionsPlugin {
+	client := &http.Client{Timeout: 15 * time.Second}
+	return &MentionsPlugin{
+		id:          "mentions",
+		pluginType:  "chat",
+		name:        "Mentions",
+		version:     "1.0.0",
+		description: "Merges mentions from GitHub, Jira, Slack and email",
+		author:      "GoDay Team",
+		github:      newGitHubAPI(nil),
+		jira:        NewSavedSearchRunner(nil),
+		jiraJQL:     defaultMentionsJQL,
+		slackURL:    "https://slack.com/api",
+		slackToken:  os.Getenv("SLACK_USER_TOKEN"),
+		gmailURL:    "https://gmail.googleapis.com/gmail/v1/users/me",
+		gmailToken:  os.Getenv("GMAIL_ACCESS_TOKEN"),
+		emailQuery:  "is:unread to:me newer_than:7d",
+		maxResults:  15,
+		client:      client,
+		lastData:    []Mention{},
+	}
+}
+
+// GetID returns the plugin ID
+func (mp *MentionsPlugin) GetID() string {
+	return mp.id
+}
+
+// GetType returns the plugin type
+func (mp *MentionsPlugin) GetType() string {
+	return mp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (mp *MentionsPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        mp.name,
+		Version:     mp.version,
+		Description: mp.description,
+		Author:      mp.author,
+		Type:        mp.pluginType,
+		Config: map[string]string{
+			"sources":     "Systems to merge: github, jira, slack, email (default: every one with credentials)",
+			"slack_token": "Slack user token with search:read (default: $SLACK_USER_TOKEN)",
+			"gmail_token": "Gmail API access token with gmail.readonly (default: $GMAIL_ACCESS_TOKEN)",
+			"jira_jql":    "JQL for Jira mentions",
+			"email_query": "Gmail search for mail that needs you",
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (mp *MentionsPlugin) Initialize(config map[string]interface{}) error {
+	mp.sources = configStringList(config["sources"])
+	for _, source := range mp.sources {
+		if !containsString(mentionSources, source) {
+			return fmt.Errorf("unknown mentions source %q (expected %s)", source, strings.Join(mentionSources, ", "))
+		}
+	}
+
+	if token, ok := config["github_token"].(string); ok && token != "" {
+		mp.github.token = token
+	}
+	if jiraURL, ok := config["jira_url"].(string); ok {
+		mp.jira.jiraURL = strings.TrimRight(jiraURL, "/")
+	}
+	if email, ok := config["jira_email"].(string); ok {
+		mp.jira.jiraEmail = email
+	}
+	if token, ok := config["jira_token"].(string); ok && token != "" {
+		mp.jira.jiraToken = token
+	}
+	if jql, ok := config["jira_jql"].(string); ok && jql != "" {
+		mp.jiraJQL = jql
+	}
+	if token, ok := config["slack_token"].(string); ok && token != "" {
+		mp.slackToken = token
+	}
+	if token, ok := config["gmail_token"].(string); ok && token != "" {
+		mp.gmailToken = token
+	}
+	if query, ok := config["email_query"].(string); ok && query != "" {
+		mp.emailQuery = query
+	}
+	return nil
+}
+
+// enabledSources returns the configured sources, or every source with credentials
+func (mp *MentionsPlugin) enabledSources() []string {
+	if len(mp.sources) > 0 {
+		return mp.sources
+	}
+	var enabled []string
+	for _, source := range mentionSources {
+		switch {
+		case source == "github" && mp.github.token != "",
+			source == "jira" && mp.jira.jiraURL != "",
+			source == "slack" && mp.slackToken != "",
+			source == "email" && mp.gmailToken != "":
+			enabled = append(enabled, source)
+		}
+	}
+	return enabled
+}
+
+// Fetch returns the merged mentions, newest first. A source that fails is skipped until
+// the next refresh; Fetch only fails when every source does.
+func (mp *MentionsPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	enabled := mp.enabledSources()
+	if len(enabled) == 0 {
+		return mp.lastData, fmt.Errorf("no mention sources configured (set widgets.mentions.sources or a Slack, Gmail or GitHub token)")
+	}
+
+	// Fetch in deduplication order so the earlier source's item is kept
+	var all []Mention
+	var errs []string
+	for _, source := range mentionSources {
+		if !containsString(enabled, source) {
+			continue
+		}
+		var mentions []Mention
+		var err error
+		switch source {
+		case "github":
+			mentions, err = mp.fetchGitHub(ctx)
+		case "jira":
+			mentions, err = mp.fetchJira(ctx)
+		case "slack":
+			mentions, err = mp.fetchSlack(ctx)
+		case "email":
+			mentions, err = mp.fetchEmail(ctx)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", source, err))
+			continue
+		}
+		all = append(all, mentions...)
+	}
+	if len(errs) == len(enabled) {
+		return mp.lastData, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+
+	mentions := dedupeMentions(all)
+	sort.SliceStable(mentions, func(i, j int) bool {
+		return mentions[i].Updated.After(mentions[j].Updated)
+	})
+	if len(mentions) > mp.maxResults {
+		mentions = mentions[:mp.maxResults]
+	}
+
+	mp.lastData = mentions
+	return mentions, nil
+}
+
+// dedupeMentions drops mentions of a thread already seen, keeping the first
+func dedupeMentions(mentions []Mention) []Mention {
+	seen := make(map[string]bool)
+	var unique []Mention
+	for _, m := range mentions {
+		key := m.key
+		if key == "" {
+			key = m.URL
+		}
+		if key != "" && seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, m)
+	}
+	return unique
+}
+
+var (
+	githubThreadPath   = regexp.MustCompile(`^/(issues|pull)/(\d+)`)
+	githubEmailSubject = regexp.MustCompile(`\[([\w.-]+/[\w.-]+)\].*\(#(\d+)\)`)
+	jiraEmailSubject   = regexp.MustCompile(`\[JIRA\].*?\b([A-Z][A-Z0-9_]+-\d+)\b`)
+)
+
+// githubThreadKey identifies an issue or pull request across sources
+func githubThreadKey(repo string, number string) string {
+	return "github:" + strings.ToLower(repo) + "#" + number
+}
+
+// fetchGitHub returns unread notifications for threads that @mention you or your teams
+func (mp *MentionsPlugin) fetchGitHub(ctx context.Context) ([]Mention, error) {
+	if mp.github.token == "" {
+		return nil, fmt.Errorf("GitHub token not configured")
+	}
+
+	var notifications []struct {
+		Reason    string    `json:"reason"`
+		UpdatedAt time.Time `json:"updated_at"`
+		Subject   struct {
+			Title string `json:"title"`
+			URL   string `json:"url"`
+		} `json:"subject"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+	}
+	if err := mp.github.do(ctx, "GET", "/notifications?participating=true", nil, &notifications); err != nil {
+		return nil, err
+	}
+
+	var mentions []Mention
+	for _, n := range notifications {
+		if n.Reason != "mention" && n.Reason != "team_mention" {
+			continue
+		}
+
+		// Subject URLs point at the API; map them onto the repository's web URL
+		link := n.Repository.HTMLURL
+		var key string
+		if i := strings.Index(n.Subject.URL, "/repos/"+n.Repository.FullName); i >= 0 {
+			rest := strings.Replace(n.Subject.URL[i+len("/repos/"+n.Repository.FullName):], "/pulls/", "/pull/", 1)
+			link += rest
+			if match := githubThreadPath.FindStringSubmatch(rest); match != nil {
+				key = githubThreadKey(n.Repository.FullName, match[2])
+			}
+		}
+
+		from := n.Repository.FullName
+		if n.Reason == "team_mention" {
+			from += " (team)"
+		}
+		mentions = append(mentions, Mention{
+			Source:  "github",
+			Title:   n.Subject.Title,
+			From:    from,
+			URL:     link,
+			Updated: n.UpdatedAt,
+			key:     key,
+		})
+	}
+	return mentions, nil
+}
+
+// fetchJira returns issues matching the mentions JQL
+func (mp *MentionsPlugin) fetchJira(ctx context.Context) ([]Mention, error) {
+	results, err := mp.jira.searchJira(ctx, mp.jiraJQL)
+	if err != nil {
+		return nil, err
+	}
+
+	var mentions []Mention
+	for _, result := range results {
+		issueKey := result.Item.URL[strings.LastIndex(result.Item.URL, "/")+1:]
+		mentions = append(mentions, Mention{
+			Source:  "jira",
+			Title:   result.Item.Title,
+			From:    strings.TrimPrefix(result.Item.Subtitle, "Jira • "),
+			URL:     result.Item.URL,
+			Updated: result.Updated,
+			key:     "jira:" + issueKey,
+		})
+	}
+	return mentions, nil
+}
+
+// slackMarkup matches user, channel and link markup such as <@U123> or <https://x|label>
+var slackMarkup = regexp.MustCompile(`<([^>|]*)(?:\|([^>]*))?>`)
+
+// fetchSlack searches Slack for messages that @mention you. Search needs a user token.
+func (mp *MentionsPlugin) fetchSlack(ctx context.Context) ([]Mention, error) {
+	if mp.slackToken == "" {
+		return nil, fmt.Errorf("Slack token not configured (widgets.slack.token or SLACK_USER_TOKEN)")
+	}
+
+	if mp.slackUserID == "" {
+		var auth struct {
+			UserID string `json:"user_id"`
+		}
+		if err := mp.slackGet(ctx, "/auth.test", url.Values{}, &auth); err != nil {
+			return nil, err
+		}
+		mp.slackUserID = auth.UserID
+	}
+
+	var search struct {
+		Messages struct {
+			Matches []struct {
+				Text      string `json:"text"`
+				Permalink string `json:"permalink"`
+				Username  string `json:"username"`
+				TS        string `json:"ts"`
+				Channel   struct {
+					Name string `json:"name"`
+				} `json:"channel"`
+			} `json:"matches"`
+		} `json:"messages"`
+	}
+	params := url.Values{}
+	params.Set("query", "<@"+mp.slackUserID+">")
+	params.Set("sort", "timestamp")
+	params.Set("count", "20")
+	if err := mp.slackGet(ctx, "/search.messages", params, &search); err != nil {
+		return nil, err
+	}
+
+	var mentions []Mention
+	for _, match := range search.Messages.Matches {
+		text := slackMarkup.ReplaceAllStringFunc(match.Text, func(markup string) string {
+			parts := slackMarkup.FindStringSubmatch(markup)
+			switch {
+			case parts[1] == "@"+mp.slackUserID:
+				return "@you"
+			case parts[2] != "":
+				return parts[2]
+			}
+			return parts[1]
+		})
+		if i := strings.Index(text, "\n"); i >= 0 {
+			text = text[:i]
+		}
+
+		seconds, _ := strconv.ParseFloat(match.TS, 64)
+		from := match.Username
+		if match.Channel.Name != "" {
+			from += " in #" + match.Channel.Name
+		}
+		mentions = append(mentions, Mention{
+			Source:  "slack",
+			Title:   strings.TrimSpace(text),
+			From:    from,
+			URL:     match.Permalink,
+			Updated: time.Unix(int64(seconds), 0),
+		})
+	}
+	return mentions, nil
+}
+
+// slackGet performs a Slack Web API request. Slack reports failures in the body.
+func (mp *MentionsPlugin) slackGet(ctx context.Context, method string, params url.Values, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", mp.slackURL+method+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+mp.slackToken)
+
+	body, err := mp.getBody(req)
+	if err != nil {
+		return err
+	}
+	var status struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return err
+	}
+	if !status.OK {
+		return fmt.Errorf("Slack API error: %s", status.Error)
+	}
+	return json.Unmarshal(body, target)
+}
+
+// fetchEmail returns the Gmail messages matching the email query, one per thread
+func (mp *MentionsPlugin) fetchEmail(ctx context.Context) ([]Mention, error) {
+	if mp.gmailToken == "" {
+		return nil, fmt.Errorf("Gmail token not configured (widgets.mentions.gmail_token or GMAIL_ACCESS_TOKEN)")
+	}
+
+	var list struct {
+		Messages []struct {
+			ID string `json:"id"`
+		} `json:"messages"`
+	}
+	params := url.Values{}
+	params.Set("q", mp.emailQuery)
+	params.Set("maxResults", "10")
+	if err := mp.gmailGet(ctx, "/messages?"+params.Encode(), &list); err != nil {
+		return nil, err
+	}
+
+	var mentions []Mention
+	for _, listed := range list.Messages {
+		var message struct {
+			ThreadID     string `json:"threadId"`
+			InternalDate string `json:"internalDate"`
+			Payload      struct {
+				Headers []struct {
+					Name  string `json:"name"`
+					Value string `json:"value"`
+				} `json:"headers"`
+			} `json:"payload"`
+		}
+		path := "/messages/" + listed.ID + "?format=metadata&metadataHeaders=From&metadataHeaders=Subject"
+		if err := mp.gmailGet(ctx, path, &message); err != nil {
+			return nil, err
+		}
+
+		var subject, from string
+		for _, header := range message.Payload.Headers {
+			switch strings.ToLower(header.Name) {
+			case "subject":
+				subject = header.Value
+			case "from":
+				from = header.Value
+				if address, err := mail.ParseAddress(header.Value); err == nil {
+					from = address.Name
+					if from == "" {
+						from = address.Address
+					}
+				}
+			}
+		}
+
+		// Notification emails about a GitHub or Jira thread share its key
+		key := "email:" + message.ThreadID
+		if match := githubEmailSubject.FindStringSubmatch(subject); match != nil {
+			key = githubThreadKey(match[1], match[2])
+		} else if match := jiraEmailSubject.FindStringSubmatch(subject); match != nil {
+			key = "jira:" + match[1]
+		}
+
+		millis, _ := strconv.ParseInt(message.InternalDate, 10, 64)
+		mentions = append(mentions, Mention{
+			Source:  "email",
+			Title:   subject,
+			From:    from,
+			URL:     "https://mail.google.com/mail/u/0/#inbox/" + message.ThreadID,
+			Updated: time.UnixMilli(millis),
+			key:     key,
+		})
+	}
+	return mentions, nil
+}
+
+// gmailGet performs an authenticated Gmail API request and decodes the JSON response
+func (mp *MentionsPlugin) gmailGet(ctx context.Context, path string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", mp.gmailURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+mp.gmailToken)
+
+	body, err := mp.getBody(req)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, target)
+}
+
+// getBody performs a request and returns the body of a successful response
+func (mp *MentionsPlugin) getBody(req *http.Request) ([]byte, error) {
+	req.Header.Set("Accept", "application/json")
+	resp, err := mp.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("%s token rejected or expired", req.URL.Host)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d", req.URL.Host, resp.StatusCode)
+	}
+	return body, nil
+}
+
+// Cleanup performs cleanup
+func (mp *MentionsPlugin) Cleanup() error {
+	return nil
+}
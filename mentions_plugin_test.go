@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMentionsPluginFetch(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	at := func(age time.Duration) string { return now.Add(-age).Format(time.RFC3339) }
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/github/notifications":
+			fmt.Fprintf(w, `[
+				{"reason":"mention","updated_at":%q,"subject":{"title":"Fix flaky test","url":"https://api.github.com/repos/acme/api/pulls/5"},"repository":{"full_name":"acme/api","html_url":"https://github.com/acme/api"}},
+				{"reason":"subscribed","updated_at":%q,"subject":{"title":"Release notes","url":"https://api.github.com/repos/acme/api/issues/6"},"repository":{"full_name":"acme/api","html_url":"https://github.com/acme/api"}}
+			]`, at(time.Hour), at(time.Minute))
+		case "/jira/rest/api/2/search":
+			if r.URL.Query().Get("jql") != defaultMentionsJQL {
+				t.Errorf("Unexpected JQL '%s'", r.URL.Query().Get("jql"))
+			}
+			fmt.Fprintf(w, `{"issues":[{"key":"OPS-7","fields":{"summary":"Rotate keys","updated":%q,"status":{"name":"In Progress"}}}]}`,
+				now.Add(-3*time.Hour).Format("2006-01-02T15:04:05.000-0700"))
+		case "/slack/auth.test":
+			fmt.Fprint(w, `{"ok":true,"user_id":"U1"}`)
+		case "/slack/search.messages":
+			if r.URL.Query().Get("query") != "<@U1>" {
+				t.Errorf("Unexpected Slack query '%s'", r.URL.Query().Get("query"))
+			}
+			fmt.Fprintf(w, `{"ok":true,"messages":{"matches":[{"text":"<@U1> can you review <https://example.com|the doc>?\nthanks","permalink":"https://acme.slack.com/archives/C1/p1","username":"bob","ts":"%d.000200","channel":{"name":"dev"}}]}}`,
+				now.Add(-2*time.Minute).Unix())
+		case "/gmail/messages":
+			fmt.Fprint(w, `{"messages":[{"id":"m1"},{"id":"m2"},{"id":"m3"}]}`)
+		case "/gmail/messages/m1", "/gmail/messages/m2", "/gmail/messages/m3":
+			subject := map[string]string{
+				"/gmail/messages/m1": "Re: [acme/api] Fix flaky test (#5)",
+				"/gmail/messages/m2": "[JIRA] (OPS-7) Rotate keys",
+				"/gmail/messages/m3": "Lunch on Friday?",
+			}[r.URL.Path]
+			fmt.Fprintf(w, `{"threadId":"t%s","internalDate":"%d","payload":{"headers":[{"name":"Subject","value":%q},{"name":"From","value":"Carol <carol@example.com>"}]}}`,
+				r.URL.Path[len(r.URL.Path)-1:], now.Add(-30*time.Minute).UnixMilli(), subject)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	plugin := NewMentionsPlugin()
+	plugin.github.apiURL = server.URL + "/github"
+	plugin.slackURL = server.URL + "/slack"
+	plugin.gmailURL = server.URL + "/gmail"
+	err := plugin.Initialize(map[string]interface{}{
+		"sources":      []string{"github", "jira", "slack", "email"},
+		"github_token": "gh",
+		"jira_url":     server.URL + "/jira/",
+		"jira_token":   "jira",
+		"slack_token":  "xoxp",
+		"gmail_token":  "gmail",
+	})
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	mentions := data.([]Mention)
+
+	var sources []string
+	for _, m := range mentions {
+		sources = append(sources, m.Source)
+	}
+	if fmt.Sprint(sources) != "[slack email github jira]" {
+		t.Fatalf("Expected notification emails to be deduplicated and the rest newest first, got %v: %+v", sources, mentions)
+	}
+	if mentions[0].Title != "@you can you review the doc?" || mentions[0].From != "bob in #dev" {
+		t.Errorf("Expected the Slack markup to be cleaned up, got %+v", mentions[0])
+	}
+	if mentions[1].Title != "Lunch on Friday?" || mentions[1].From != "Carol" {
+		t.Errorf("Expected the unrelated email to be kept, got %+v", mentions[1])
+	}
+	if mentions[2].URL != "https://github.com/acme/api/pull/5" {
+		t.Errorf("Expected the GitHub web URL, got '%s'", mentions[2].URL)
+	}
+
+	wm := NewWidgetManager()
+	wm.UpdateMentionsWidget(mentions)
+	items := wm.Widgets["mentions"].Items
+	if items[0].Status != "💬" || items[3].Status != "🎫" || items[2].Subtitle != "acme/api • 1h ago" {
+		t.Errorf("Expected source icons and ages, got %+v", items)
+	}
+}
+
+func TestMentionsPluginSources(t *testing.T) {
+	plugin := NewMentionsPlugin()
+	if err := plugin.Initialize(map[string]interface{}{"sources": []string{"pager"}}); err == nil {
+		t.Error("Expected an unknown source to fail")
+	}
+
+	plugin = NewMentionsPlugin()
+	plugin.github.token = ""
+	plugin.slackToken = ""
+	plugin.gmailToken = ""
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected Fetch to fail without any credentials")
+	}
+
+	// A failing source is skipped while the others still show
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/notifications" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		fmt.Fprint(w, `{"ok":false,"error":"missing_scope"}`)
+	}))
+	defer server.Close()
+	plugin.github.apiURL = server.URL
+	plugin.github.token = "gh"
+	plugin.slackURL = server.URL
+	plugin.slackToken = "xoxb"
+	if got := plugin.enabledSources(); fmt.Sprint(got) != "[github slack]" {
+		t.Errorf("Expected the sources with credentials, got %v", got)
+	}
+	if _, err := plugin.Fetch(context.Background()); err != nil {
+		t.Errorf("Expected the Slack failure to be skipped, got %v", err)
+	}
+
+	t.Setenv("SLACK_USER_TOKEN", "")
+	t.Setenv("GMAIL_ACCESS_TOKEN", "")
+	cfg := &Config{}
+	cfg.Widgets.Jira.BaseURL = "https://acme.atlassian.net"
+	if cfg.ConfiguredWidgets()["mentions"] {
+		t.Error("Expected Jira credentials alone not to show the mentions tile")
+	}
+	cfg.Widgets.Mentions.Sources = []string{"github"}
+	if !cfg.ConfiguredWidgets()["mentions"] {
+		t.Error("Expected the mentions tile to be shown once sources are set")
+	}
+}
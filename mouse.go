@@ -0,0 +1,114 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// doubleClickInterval is how soon a second click on the same item opens its link
+const doubleClickInterval = 400 * time.Millisecond
+
+// mouseClick is the last click on an item, to tell a double-click
+type mouseClick struct {
+	tile int
+	item int
+	at   time.Time
+}
+
+// handleMouse lets the dashboard be used without a keyboard: a click focuses a tile and
+// selects the item under it, a double-click or a click on the link below the grid opens
+// it, and the wheel scrolls the tile under the pointer. Overlays ignore the mouse.
+func (m Model) handleMouse(msg tea.MouseMsg, now time.Time) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress || m.overlayOpen() || len(m.widgets) == 0 {
+		return m, nil
+	}
+	// A filter being typed keeps the focus until it is kept or dropped
+	if m.focusedWidget < len(m.widgets) && m.widgets[m.focusedWidget].list.SettingFilter() {
+		return m, nil
+	}
+	tile, row, onTile := m.tileAt(msg.X, msg.Y)
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp, tea.MouseButtonWheelDown:
+		if !onTile {
+			tile = m.focusedWidget
+		}
+		if tile >= len(m.widgets) {
+			return m, nil
+		}
+		if msg.Button == tea.MouseButtonWheelUp {
+			m.widgets[tile].list.CursorUp()
+		} else {
+			m.widgets[tile].list.CursorDown()
+		}
+	case tea.MouseButtonLeft:
+		if !onTile {
+			if url := m.getSelectedItemURL(); url != "" && msg.Y == m.urlBarRow(now) {
+				go openURL(url)
+			}
+			return m, nil
+		}
+		m.focusedWidget = tile
+		item, ok := m.widgets[tile].itemAt(row)
+		if !ok {
+			return m, nil
+		}
+		m.widgets[tile].list.Select(item)
+		last := m.lastClick
+		m.lastClick = mouseClick{tile: tile, item: item, at: now}
+		if last.tile == tile && last.item == item && now.Sub(last.at) <= doubleClickInterval {
+			m.lastClick = mouseClick{}
+			if url := m.getSelectedItemURL(); url != "" {
+				go openURL(url)
+			}
+		}
+	}
+	return m, nil
+}
+
+// overlayOpen reports whether a panel or view is shown over the grid
+func (m Model) overlayOpen() bool {
+	return m.tagEditor != nil || m.noteCapture != nil || m.searchPalette != nil || m.triagePanel != nil ||
+		m.depPanel != nil || m.detailItem != nil || m.pluginStatus || m.keyHelp
+}
+
+// tileAt returns the tile under a cell of the screen and the row of its content the cell
+// is on, negative on the border or title, as View lays the grid out below the header
+func (m Model) tileAt(x, y int) (tile, row int, ok bool) {
+	// In zen mode the focused tile is all there is
+	if m.zen && m.expanded && m.focusedWidget < len(m.widgets) {
+		return m.focusedWidget, y - 2, x >= 0 && y >= 0 && y < m.terminalHeight
+	}
+	top := lipgloss.Height(m.renderHeader()) + 1
+	tileWidth, tileHeight := gridTileSize(m.terminalWidth)
+	// Each tile has a border around it, and content starts below the title
+	cellWidth, cellHeight := tileWidth+2, tileHeight+2
+	placements, gridRows := layoutTiles(m.widgets)
+	if x < 0 || y < top || x >= gridColumns*cellWidth || y >= top+gridRows*cellHeight {
+		return 0, 0, false
+	}
+	if m.expanded && m.focusedWidget < len(m.widgets) {
+		return m.focusedWidget, y - top - 2, true
+	}
+	p, ok := placementAt(placements, (y-top)/cellHeight, x/cellWidth)
+	if !ok {
+		return 0, 0, false
+	}
+	return p.tile, y - top - p.row*cellHeight - 2, true
+}
+
+// urlBarRow returns the screen row of the selected item's link below the grid
+func (m Model) urlBarRow(now time.Time) int {
+	_, tileHeight := gridTileSize(m.terminalWidth)
+	_, gridRows := layoutTiles(m.widgets)
+	row := lipgloss.Height(m.renderHeader()) + 1 + gridRows*(tileHeight+2) + 1
+	if m.hasCollapsedTiles() {
+		row++
+	}
+	if preview := m.morningPreview(now); preview != nil {
+		row += lipgloss.Height(renderMorningPreview(preview, now)) + 1
+	}
+	return row
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestMouseClicks(t *testing.T) {
+	m := benchmarkModel(120, 40)
+	m.View()
+	top := lipgloss.Height(m.renderHeader()) + 1
+	tileWidth, tileHeight := gridTileSize(120)
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	press := func(button tea.MouseButton, x, y int, at time.Time) {
+		updated, _ := m.handleMouse(tea.MouseMsg{X: x, Y: y, Button: button, Action: tea.MouseActionPress}, at)
+		m = updated.(Model)
+	}
+
+	// The third item of the second tile in the second row: below its border and title
+	press(tea.MouseButtonLeft, tileWidth+2+5, top+tileHeight+2+2+2, now)
+	if m.focusedWidget != 4 || m.widgets[4].list.Index() != 2 {
+		t.Fatalf("Expected the click to focus tile 4 and select item 2, got tile %d item %d", m.focusedWidget, m.widgets[4].list.Index())
+	}
+	press(tea.MouseButtonLeft, tileWidth+2+5, top+tileHeight+2+2+2, now.Add(200*time.Millisecond))
+	if m.lastClick != (mouseClick{}) {
+		t.Errorf("Expected a second click on the item to be a double-click, got %+v", m.lastClick)
+	}
+
+	// A click on a title focuses the tile without selecting
+	press(tea.MouseButtonLeft, 5, top+1, now.Add(time.Second))
+	if m.focusedWidget != 0 || m.widgets[0].list.Index() != 0 {
+		t.Errorf("Expected a click on the title to focus tile 0, got tile %d item %d", m.focusedWidget, m.widgets[0].list.Index())
+	}
+
+	// The wheel scrolls the tile under the pointer, not the focused one
+	press(tea.MouseButtonWheelDown, 2*(tileWidth+2)+5, top+3, now)
+	if m.widgets[2].list.Index() != 1 || m.widgets[0].list.Index() != 0 {
+		t.Errorf("Expected the wheel to move through tile 2, got %d", m.widgets[2].list.Index())
+	}
+
+	// Expanded, rows map to the focused tile's items
+	m.expanded = true
+	m.View()
+	press(tea.MouseButtonLeft, 2*(tileWidth+2)+5, top+2+5, now.Add(2*time.Second))
+	if m.focusedWidget != 0 || m.widgets[0].list.Index() != 5 {
+		t.Errorf("Expected a click in the expanded tile to select item 5, got tile %d item %d", m.focusedWidget, m.widgets[0].list.Index())
+	}
+
+	// Overlays ignore the mouse
+	m.pluginStatus = true
+	press(tea.MouseButtonLeft, tileWidth+2+5, top+3, now.Add(3*time.Second))
+	if m.focusedWidget != 0 {
+		t.Errorf("Expected clicks to be ignored under an overlay, got tile %d", m.focusedWidget)
+	}
+}
@@ -0,0 +1,391 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// networkDefaultWarnMS turns an anchor yellow without widgets.network.warn_ms
+	networkDefaultWarnMS = 100
+	// networkDefaultCriticalMS turns an anchor red without widgets.network.critical_ms
+	networkDefaultCriticalMS = 300
+	// networkProbes is how many connections each fetch opens to an anchor
+	networkProbes = 3
+	// networkProbeTimeout bounds one connection attempt
+	networkProbeTimeout = 2 * time.Second
+	// speedTestDuration bounds a speed test; the speed is measured over what arrived by then
+	speedTestDuration = 10 * time.Second
+	// defaultSpeedTestURL serves as many bytes as asked for
+	defaultSpeedTestURL = "https://speed.cloudflare.com/__down?bytes=25000000"
+	// gatewayAnchor stands for the default gateway, the home router
+	gatewayAnchor = "gateway"
+)
+
+// defaultNetworkAnchors are measured without widgets.network.anchors: the home router,
+// then a well-known address past it
+var defaultNetworkAnchors = []string{gatewayAnchor, "1.1.1.1"}
+
+// AnchorLatency is how quickly one anchor answered
+type AnchorLatency struct {
+	Name    string        // as configured, e.g. gateway or 1.1.1.1
+	Address string        // host:port connected to
+	Latency time.Duration // median of the answered probes
+	Lost    int           // probes out of networkProbes that got no answer
+	Err     string        // why the anchor could not be measured at all
+	Level   string        // 🟢, or 🟡 and 🔴 by warn_ms, critical_ms and lost probes
+}
+
+// SpeedTest is the download speed measured by the last speed test
+type SpeedTest struct {
+	Mbps float64
+	At   time.Time
+	Err  string
+}
+
+// NetworkStatus is the latency to each anchor and the latest speed test, if any
+type NetworkStatus struct {
+	Anchors []AnchorLatency
+	Speed   *SpeedTest
+}
+
+// NetworkPlugin measures latency to a few anchors, such as the home router and a public
+// address, and optionally runs a periodic download speed test. Latency is the time to
+// open a TCP connection, since ping needs raw sockets; a refused connection still
+// answers, so it counts.
+type NetworkPlugin struct {
+	id            string
+	pluginType    string
+	name          string
+	version       string
+	description   string
+	author        string
+	anchors       []string
+	warnMS        int
+	criticalMS    int
+	speedInterval time.Duration // 0 runs no speed test
+	speedURL      string
+	procDir       string // /proc, replaced in tests
+	client        *http.Client
+	mu            sync.Mutex
+	lastSpeed     *SpeedTest
+	lastData      NetworkStatus
+}
+
+// NewNetworkPlugin creates a new network connectivity plugin
+func NewNetworkPlugin() *NetworkPlugin {
+	return &NetworkPlugin{
+		id:          "network",
+		pluginType:  "network",
+		name:        "Network",
+		version:     "1.0.0",
+		description: "Shows latency to your router and the internet, and download speed",
+		author:      "GoDay Team",
+		anchors:     defaultNetworkAnchors,
+		warnMS:      networkDefaultWarnMS,
+		criticalMS:  networkDefaultCriticalMS,
+		speedURL:    defaultSpeedTestURL,
+		procDir:     "/proc",
+		client:      &http.Client{},
+	}
+}
+
+// GetID returns the plugin ID
+func (np *NetworkPlugin) GetID() string {
+	return np.id
+}
+
+// GetType returns the plugin type
+func (np *NetworkPlugin) GetType() string {
+	return np.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (np *NetworkPlugin) GetMetadata() PluginMetadata {
+	speedTest := "off"
+	if np.speedInterval > 0 {
+		speedTest = np.speedInterval.String()
+	}
+	return PluginMetadata{
+		Name:        np.name,
+		Version:     np.version,
+		Description: np.description,
+		Author:      np.author,
+		Type:        np.pluginType,
+		Config: map[string]string{
+			"anchors":     strings.Join(np.anchors, ","),
+			"warn_ms":     fmt.Sprintf("%d", np.warnMS),
+			"critical_ms": fmt.Sprintf("%d", np.criticalMS),
+			"speed_test":  speedTest,
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (np *NetworkPlugin) Initialize(config map[string]interface{}) error {
+	if anchors := configStringList(config["anchors"]); len(anchors) > 0 {
+		np.anchors = anchors
+	}
+	for _, anchor := range np.anchors {
+		if strings.Contains(anchor, "/") {
+			return fmt.Errorf("network anchor %q must be a host, optionally with :port", anchor)
+		}
+	}
+	if ms, ok := config["warn_ms"].(int); ok && ms > 0 {
+		np.warnMS = ms
+	}
+	if ms, ok := config["critical_ms"].(int); ok && ms > 0 {
+		np.criticalMS = ms
+	}
+	if np.warnMS > np.criticalMS {
+		return fmt.Errorf("warn_ms (%d) must not be more than critical_ms (%d)", np.warnMS, np.criticalMS)
+	}
+	if interval, ok := config["speed_test"].(string); ok && interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil || d < time.Minute {
+			return fmt.Errorf("invalid speed_test interval %q: expected a duration of at least 1m, e.g. 1h", interval)
+		}
+		np.speedInterval = d
+	}
+	if url, ok := config["speed_test_url"].(string); ok && url != "" {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			return fmt.Errorf("speed_test_url %q must start with http:// or https://", url)
+		}
+		np.speedURL = url
+	}
+	return nil
+}
+
+// Fetch measures every anchor concurrently and, when one is due, runs a speed test
+func (np *NetworkPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	results := make([]AnchorLatency, len(np.anchors))
+	var wg sync.WaitGroup
+	for i, anchor := range np.anchors {
+		wg.Add(1)
+		go func(i int, anchor string) {
+			defer wg.Done()
+			results[i] = np.measure(ctx, anchor)
+			results[i].Level = latencyLevel(results[i], np.warnMS, np.criticalMS)
+		}(i, anchor)
+	}
+	wg.Wait()
+
+	status := NetworkStatus{Anchors: results}
+	if np.speedInterval > 0 {
+		np.mu.Lock()
+		if np.lastSpeed == nil || time.Since(np.lastSpeed.At) >= np.speedInterval {
+			np.lastSpeed = np.speedTest(ctx)
+		}
+		status.Speed = np.lastSpeed
+		np.mu.Unlock()
+	}
+
+	np.lastData = status
+	return status, nil
+}
+
+// measure opens networkProbes connections to an anchor, one after another
+func (np *NetworkPlugin) measure(ctx context.Context, anchor string) AnchorLatency {
+	result := AnchorLatency{Name: anchor}
+	host, port, err := net.SplitHostPort(anchor)
+	if err != nil {
+		host, port = anchor, "443"
+		if host == gatewayAnchor {
+			// Routers serve their admin page over HTTP far more often than HTTPS
+			port = "80"
+		}
+	}
+	if host == gatewayAnchor {
+		if host, err = np.defaultGateway(ctx); err != nil {
+			result.Err = err.Error()
+			return result
+		}
+	}
+	result.Address = net.JoinHostPort(host, port)
+
+	var latencies []time.Duration
+	var lastErr error
+	for i := 0; i < networkProbes; i++ {
+		latency, err := probeLatency(ctx, result.Address)
+		if err != nil {
+			lastErr = err
+			result.Lost++
+			continue
+		}
+		latencies = append(latencies, latency)
+	}
+	if len(latencies) == 0 {
+		result.Err = "no answer"
+		if lastErr != nil && !isTimeout(lastErr) {
+			result.Err = lastErr.Error()
+		}
+		return result
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	result.Latency = latencies[len(latencies)/2]
+	return result
+}
+
+// probeLatency returns how long address took to answer a TCP connection, accepted or
+// refused
+func probeLatency(ctx context.Context, address string) (time.Duration, error) {
+	dialer := net.Dialer{Timeout: networkProbeTimeout}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	latency := time.Since(start)
+	if err == nil {
+		conn.Close()
+		return latency, nil
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return latency, nil
+	}
+	return 0, err
+}
+
+// isTimeout reports whether err is a connection attempt that ran out of time
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// defaultGateway returns the address of the default route's gateway: from
+// /proc/net/route on Linux and route on macOS
+func (np *NetworkPlugin) defaultGateway(ctx context.Context) (string, error) {
+	switch runtime.GOOS {
+	case "linux":
+		data, err := os.ReadFile(filepath.Join(np.procDir, "net", "route"))
+		if err != nil {
+			return "", err
+		}
+		return parseProcRoute(string(data))
+	case "darwin":
+		out, err := exec.CommandContext(ctx, "route", "-n", "get", "default").Output()
+		if err != nil {
+			return "", fmt.Errorf("no default route")
+		}
+		for _, line := range strings.Split(string(out), "\n") {
+			if gateway, ok := strings.CutPrefix(strings.TrimSpace(line), "gateway:"); ok {
+				return strings.TrimSpace(gateway), nil
+			}
+		}
+		return "", fmt.Errorf("no default route")
+	}
+	return "", fmt.Errorf("the gateway cannot be found on %s; list its address in widgets.network.anchors", runtime.GOOS)
+}
+
+// parseProcRoute finds the default route's gateway in /proc/net/route, where addresses
+// are hex in host byte order
+func parseProcRoute(table string) (string, error) {
+	for _, line := range strings.Split(table, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[1] != "00000000" || fields[2] == "00000000" {
+			continue
+		}
+		raw, err := hex.DecodeString(fields[2])
+		if err != nil || len(raw) != 4 {
+			continue
+		}
+		ip := make(net.IP, 4)
+		binary.BigEndian.PutUint32(ip, binary.LittleEndian.Uint32(raw))
+		return ip.String(), nil
+	}
+	return "", fmt.Errorf("no default route")
+}
+
+// speedTest downloads from the speed test URL for at most speedTestDuration and returns
+// the speed over what arrived
+func (np *NetworkPlugin) speedTest(ctx context.Context) *SpeedTest {
+	result := &SpeedTest{At: time.Now()}
+	ctx, cancel := context.WithTimeout(ctx, speedTestDuration)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", np.speedURL, nil)
+	if err != nil {
+		result.Err = err.Error()
+		return result
+	}
+	req.Header.Set("User-Agent", "GoDay-Network/1.0")
+	start := time.Now()
+	resp, err := np.client.Do(req)
+	if err != nil {
+		result.Err = "speed test server unreachable"
+		return result
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		result.Err = fmt.Sprintf("speed test server returned HTTP %d", resp.StatusCode)
+		return result
+	}
+
+	// Running out of time ends the test early rather than failing it
+	n, err := io.Copy(io.Discard, resp.Body)
+	elapsed := time.Since(start)
+	if err != nil && ctx.Err() == nil {
+		result.Err = "download failed"
+		return result
+	}
+	if n == 0 || elapsed <= 0 {
+		result.Err = "nothing downloaded"
+		return result
+	}
+	result.Mbps = float64(n) * 8 / elapsed.Seconds() / 1e6
+	return result
+}
+
+// latencyLevel returns the status icon for an anchor: 🔴 when it did not answer or took
+// criticalMS or more, 🟡 from warnMS or when probes were lost, otherwise 🟢
+func latencyLevel(anchor AnchorLatency, warnMS, criticalMS int) string {
+	ms := anchor.Latency.Milliseconds()
+	switch {
+	case anchor.Err != "", ms >= int64(criticalMS):
+		return "🔴"
+	case anchor.Lost > 0, ms >= int64(warnMS):
+		return "🟡"
+	}
+	return "🟢"
+}
+
+// networkVerdict sums up the anchors: whether the connection is healthy and, when not,
+// whether the trouble is on the home network or past the router
+func networkVerdict(anchors []AnchorLatency) (title, subtitle, level string) {
+	var gatewayLevel string
+	worst := "🟢"
+	for _, anchor := range anchors {
+		if anchor.Name == gatewayAnchor || strings.HasPrefix(anchor.Name, gatewayAnchor+":") {
+			gatewayLevel = anchor.Level
+		}
+		if anchor.Level == "🔴" || (anchor.Level == "🟡" && worst == "🟢") {
+			worst = anchor.Level
+		}
+	}
+	switch {
+	case worst == "🟢":
+		return "Connection healthy", "Every anchor answers quickly", "🟢"
+	case gatewayLevel != "" && gatewayLevel != "🟢":
+		return "Home network degraded", "The router is slow to answer; check Wi-Fi", worst
+	case gatewayLevel == "🟢":
+		return "Internet connection degraded", "The router answers quickly; the trouble is past it", worst
+	}
+	return "Connection degraded", "Some anchors are slow or not answering", worst
+}
+
+// Cleanup performs cleanup
+func (np *NetworkPlugin) Cleanup() error {
+	return nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNetworkPluginMeasure(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	// A closed port refuses the connection, which still answers
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	refused := closed.Addr().String()
+	closed.Close()
+
+	plugin := NewNetworkPlugin()
+	if err := plugin.Initialize(map[string]interface{}{
+		"anchors": []string{listener.Addr().String(), refused},
+	}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	status := data.(NetworkStatus)
+	if len(status.Anchors) != 2 {
+		t.Fatalf("Expected 2 anchors, got %d", len(status.Anchors))
+	}
+	for _, anchor := range status.Anchors {
+		if anchor.Err != "" || anchor.Lost != 0 || anchor.Level != "🟢" {
+			t.Errorf("Expected %s to answer quickly, got %+v", anchor.Name, anchor)
+		}
+	}
+	if status.Speed != nil {
+		t.Error("Expected no speed test without speed_test")
+	}
+}
+
+func TestNetworkPluginInitialize(t *testing.T) {
+	for _, config := range []map[string]interface{}{
+		{"anchors": []string{"https://example.com"}},
+		{"warn_ms": 500, "critical_ms": 200},
+		{"speed_test": "30s"},
+		{"speed_test": "hourly"},
+		{"speed_test_url": "ftp://example.com/big"},
+	} {
+		if err := NewNetworkPlugin().Initialize(config); err == nil {
+			t.Errorf("Expected %v to be rejected", config)
+		}
+	}
+}
+
+func TestNetworkSpeedTest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(make([]byte, 1<<20))
+	}))
+	defer server.Close()
+
+	plugin := NewNetworkPlugin()
+	if err := plugin.Initialize(map[string]interface{}{
+		"speed_test":     "1h",
+		"speed_test_url": server.URL,
+	}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	plugin.anchors = nil
+	data, _ := plugin.Fetch(context.Background())
+	first := data.(NetworkStatus).Speed
+	if first == nil || first.Err != "" || first.Mbps <= 0 {
+		t.Fatalf("Expected a measured speed, got %+v", first)
+	}
+
+	// The next test waits for the interval
+	data, _ = plugin.Fetch(context.Background())
+	if data.(NetworkStatus).Speed != first {
+		t.Error("Expected the last speed test to be reused within the interval")
+	}
+	plugin.lastSpeed.At = time.Now().Add(-2 * time.Hour)
+	data, _ = plugin.Fetch(context.Background())
+	if data.(NetworkStatus).Speed == first {
+		t.Error("Expected a new speed test once the interval passed")
+	}
+}
+
+func TestParseProcRoute(t *testing.T) {
+	table := "Iface\tDestination\tGateway \tFlags\tRefCnt\tUse\tMetric\tMask\n" +
+		"eth0\t0001A8C0\t00000000\t0001\t0\t0\t0\t00FFFFFF\n" +
+		"eth0\t00000000\t0101A8C0\t0003\t0\t0\t0\t00000000\n"
+	if gateway, err := parseProcRoute(table); err != nil || gateway != "192.168.1.1" {
+		t.Errorf("Expected 192.168.1.1, got %q (%v)", gateway, err)
+	}
+	if _, err := parseProcRoute("Iface\tDestination\tGateway\n"); err == nil {
+		t.Error("Expected an error without a default route")
+	}
+}
+
+func TestNetworkVerdict(t *testing.T) {
+	anchor := func(name, level string) AnchorLatency {
+		return AnchorLatency{Name: name, Level: level}
+	}
+	tests := []struct {
+		anchors  []AnchorLatency
+		expected string
+		level    string
+	}{
+		{[]AnchorLatency{anchor("gateway", "🟢"), anchor("1.1.1.1", "🟢")}, "Connection healthy", "🟢"},
+		{[]AnchorLatency{anchor("gateway", "🟡"), anchor("1.1.1.1", "🔴")}, "Home network degraded", "🔴"},
+		{[]AnchorLatency{anchor("gateway", "🟢"), anchor("1.1.1.1", "🟡")}, "Internet connection degraded", "🟡"},
+		{[]AnchorLatency{anchor("1.1.1.1", "🔴")}, "Connection degraded", "🔴"},
+	}
+	for _, test := range tests {
+		title, _, level := networkVerdict(test.anchors)
+		if title != test.expected || level != test.level {
+			t.Errorf("Expected %s %s, got %s %s", test.level, test.expected, level, title)
+		}
+	}
+
+	slow := AnchorLatency{Latency: 150 * time.Millisecond}
+	if level := latencyLevel(slow, 100, 300); level != "🟡" {
+		t.Errorf("Expected 🟡 from warn_ms, got %s", level)
+	}
+	if level := latencyLevel(AnchorLatency{Lost: 1}, 100, 300); level != "🟡" {
+		t.Errorf("Expected 🟡 for lost probes, got %s", level)
+	}
+}
+
+func TestUpdateNetworkWidget(t *testing.T) {
+	wm := NewWidgetManager()
+	wm.UpdateNetworkWidget(NetworkStatus{
+		Anchors: []AnchorLatency{
+			{Name: "gateway", Address: "192.168.1.1:80", Latency: 3 * time.Millisecond, Level: "🟢"},
+			{Name: "1.1.1.1", Address: "1.1.1.1:443", Latency: 400 * time.Millisecond, Lost: 1, Level: "🔴"},
+		},
+		Speed: &SpeedTest{Mbps: 94.6, At: time.Date(2026, 10, 16, 9, 30, 0, 0, time.Local)},
+	})
+	widget := wm.Widgets["network"]
+	if widget.Count != 1 {
+		t.Errorf("Expected 1 anchor needing attention, got %d", widget.Count)
+	}
+	if len(widget.Items) != 4 || widget.Items[0].Title != "Internet connection degraded" {
+		t.Fatalf("Expected a verdict, 2 anchors and a speed test, got %+v", widget.Items)
+	}
+	if !strings.Contains(widget.Items[2].Subtitle, "1 of 3 probes lost") {
+		t.Errorf("Expected lost probes to be shown, got %q", widget.Items[2].Subtitle)
+	}
+	if widget.Items[3].Title != "Download 95 Mbps" {
+		t.Errorf("Expected the download speed, got %q", widget.Items[3].Title)
+	}
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// translateTimeout bounds one translation, so a hanging command cannot stall the news
+const translateTimeout = 10 * time.Second
+
+// NewsLanguage drops news in unwanted languages and translates foreign-language RSS
+// articles, as set under widgets.news.language
+type NewsLanguage struct {
+	keep      []string          // languages to keep; empty keeps all
+	translate string            // shell command or LibreTranslate-compatible URL; empty turns it off
+	apiKey    string            // key for the translation API
+	client    *http.Client      // for the translation API
+	cache     map[string]string // article URL -> translated title
+	mu        sync.Mutex        // guards cache, for fetches running in the background
+	run       func(ctx context.Context, command, text, lang string) (string, error)
+}
+
+// NewNewsLanguage creates the filter from the config. It returns nil when neither a
+// filter nor a translation hook is configured.
+func NewNewsLanguage(cfg *Config) *NewsLanguage {
+	if cfg == nil {
+		return nil
+	}
+	settings := cfg.Widgets.News.Language
+	if len(settings.Keep) == 0 && settings.Translate == "" {
+		return nil
+	}
+
+	nl := &NewsLanguage{
+		translate: strings.TrimSpace(settings.Translate),
+		apiKey:    settings.APIKey,
+		client:    &http.Client{Timeout: translateTimeout},
+		cache:     make(map[string]string),
+		run:       runTranslateCommand,
+	}
+	if nl.apiKey == "" {
+		nl.apiKey = os.Getenv("LIBRETRANSLATE_API_KEY")
+	}
+	for _, lang := range settings.Keep {
+		nl.keep = append(nl.keep, strings.ToLower(strings.TrimSpace(lang)))
+	}
+	return nl
+}
+
+// Apply returns items without those in languages that are not kept. Foreign-language RSS
+// articles are translated to English first, when a hook is set, and then count as English.
+// Articles whose language cannot be told are kept; a failed translation keeps the original.
+func (nl *NewsLanguage) Apply(ctx context.Context, items []NewsItem) []NewsItem {
+	if nl == nil {
+		return items
+	}
+	kept := make([]NewsItem, 0, len(items))
+	current := make(map[string]bool)
+	for _, item := range items {
+		current[item.URL] = true
+		lang := detectLanguage(item.Title + " " + stripHTML(item.Description))
+		if lang == "" {
+			lang = item.Language
+		}
+		item.Language = lang
+
+		if lang != "" && lang != "en" && item.Source == "rss" && nl.translate != "" {
+			if title, err := nl.translateTitle(ctx, item, lang); err == nil {
+				item.Title = title
+				item.Translated = true
+				lang = "en"
+			}
+		}
+		if lang != "" && len(nl.keep) > 0 && !containsString(nl.keep, lang) {
+			continue
+		}
+		kept = append(kept, item)
+	}
+	// Translations of articles that have left the feeds are not needed again
+	nl.mu.Lock()
+	defer nl.mu.Unlock()
+	for url := range nl.cache {
+		if !current[url] {
+			delete(nl.cache, url)
+		}
+	}
+	return kept
+}
+
+// translateTitle translates an article title to English, once per article
+func (nl *NewsLanguage) translateTitle(ctx context.Context, item NewsItem, lang string) (string, error) {
+	nl.mu.Lock()
+	title, ok := nl.cache[item.URL]
+	nl.mu.Unlock()
+	if ok {
+		return title, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, translateTimeout)
+	defer cancel()
+
+	var err error
+	if strings.HasPrefix(nl.translate, "http://") || strings.HasPrefix(nl.translate, "https://") {
+		title, err = nl.translateAPI(ctx, item.Title, lang)
+	} else {
+		title, err = nl.run(ctx, nl.translate, item.Title, lang)
+	}
+	title = strings.TrimSpace(title)
+	if err != nil {
+		return "", err
+	}
+	if title == "" {
+		return "", fmt.Errorf("empty translation")
+	}
+	nl.mu.Lock()
+	nl.cache[item.URL] = title
+	nl.mu.Unlock()
+	return title, nil
+}
+
+// translateAPI calls a LibreTranslate-compatible /translate endpoint
+func (nl *NewsLanguage) translateAPI(ctx context.Context, text, lang string) (string, error) {
+	body, err := json.Marshal(map[string]string{
+		"q":       text,
+		"source":  lang,
+		"target":  "en",
+		"format":  "text",
+		"api_key": nl.apiKey,
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", nl.translate, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := nl.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("translation API returned status %d", resp.StatusCode)
+	}
+	var result struct {
+		TranslatedText string `json:"translatedText"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	return result.TranslatedText, nil
+}
+
+// runTranslateCommand runs a translation command through the shell with the text on
+// stdin and its language in $GODAY_SOURCE_LANG, and returns what it prints
+func runTranslateCommand(ctx context.Context, command, text, lang string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Env = append(os.Environ(), "GODAY_SOURCE_LANG="+lang)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("translate command: %w", err)
+	}
+	return string(out), nil
+}
+
+// languageScripts names the language of text mostly written in a script other than Latin
+var languageScripts = []struct {
+	table *unicode.RangeTable
+	lang  string
+}{
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Han, "zh"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Greek, "el"},
+	{unicode.Devanagari, "hi"},
+	{unicode.Thai, "th"},
+}
+
+// languageStopwords are common short words that give away a Latin-script language
+var languageStopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "in", "is", "for", "with", "on", "how", "why", "what", "your", "you", "it", "are", "from", "new", "this"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "mit", "für", "ein", "eine", "auf", "den", "dem", "wie", "warum", "ich", "sie", "wird", "bei", "zum"},
+	"fr": {"le", "la", "les", "et", "est", "des", "une", "un", "du", "pour", "dans", "sur", "avec", "pas", "qui", "que", "au", "ce", "nous", "comment"},
+	"es": {"el", "la", "los", "las", "y", "es", "del", "una", "un", "para", "con", "por", "que", "en", "cómo", "qué", "su", "se", "al", "lo"},
+	"pt": {"o", "os", "as", "e", "é", "do", "da", "dos", "uma", "um", "para", "com", "não", "que", "em", "como", "no", "na", "ao", "seu"},
+	"it": {"il", "lo", "gli", "e", "è", "di", "della", "una", "un", "per", "con", "non", "che", "come", "nel", "alla", "del", "sono", "anche", "più"},
+	"nl": {"de", "het", "een", "en", "is", "van", "voor", "met", "niet", "op", "dat", "hoe", "wat", "zijn", "ook", "naar", "bij", "je", "wordt", "nieuwe"},
+}
+
+// detectLanguage guesses the ISO 639-1 language of a short text such as a headline: by
+// its script, and for Latin script by its most common words. It returns "" when unsure,
+// which short technical titles without any common words often are.
+func detectLanguage(text string) string {
+	scripts := make(map[string]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for _, script := range languageScripts {
+			if unicode.Is(script.table, r) {
+				scripts[script.lang]++
+				break
+			}
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+	// Kana next to kanji is Japanese rather than Chinese
+	if scripts["ja"] > 0 {
+		scripts["ja"] += scripts["zh"]
+		delete(scripts, "zh")
+	}
+	best, bestCount := "", 0
+	for lang, count := range scripts {
+		if count > bestCount || (count == bestCount && lang < best) {
+			best, bestCount = lang, count
+		}
+	}
+	if bestCount*3 > letters {
+		return best
+	}
+
+	scores := make(map[string]int)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		for lang, stopwords := range languageStopwords {
+			if containsString(stopwords, word) {
+				scores[lang]++
+			}
+		}
+	}
+	best, bestScore := "", 0
+	for lang, score := range scores {
+		if score > bestScore || (score == bestScore && lang < best) {
+			best, bestScore = lang, score
+		}
+	}
+	runnerUp := 0
+	for lang, score := range scores {
+		if lang != best && score > runnerUp {
+			runnerUp = score
+		}
+	}
+	// English needs one telling word; other languages two, and a clear lead
+	switch {
+	case bestScore == 0:
+		return ""
+	case scores["en"] == bestScore:
+		return "en"
+	case bestScore >= 2 && bestScore > runnerUp:
+		return best
+	}
+	return ""
+}
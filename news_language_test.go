@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	cases := map[string]string{
+		"How we cut the build time of our monorepo in half":        "en",
+		"Warum die neue Version nicht mit Go 1.22 läuft":           "de",
+		"Comment nous avons migré vers Kubernetes sans les pannes": "fr",
+		"Cómo escalar una base de datos con Postgres y Go":         "es",
+		"Как мы перешли на Go":                                     "ru",
+		"Go 言語で書かれたツールを公開しました":                                     "ja",
+		"Kubernetes 1.31": "",
+		"":                "",
+	}
+	for text, expected := range cases {
+		if lang := detectLanguage(text); lang != expected {
+			t.Errorf("Expected %q to be detected as '%s', got '%s'", text, expected, lang)
+		}
+	}
+}
+
+func TestNewsLanguageApply(t *testing.T) {
+	cfg := &Config{}
+	cfg.Widgets.News.Language.Keep = []string{"en"}
+	cfg.Widgets.News.Language.Translate = "trans -b :en"
+	nl := NewNewsLanguage(cfg)
+	calls := 0
+	nl.run = func(ctx context.Context, command, text, lang string) (string, error) {
+		calls++
+		if lang != "de" {
+			return "", fmt.Errorf("unexpected language %s", lang)
+		}
+		return "Why the new version does not run\n", nil
+	}
+
+	items := []NewsItem{
+		{Title: "How we cut the build time in half", URL: "https://example.com/1", Source: "devto"},
+		{Title: "Warum die neue Version nicht läuft", URL: "https://example.com/2", Source: "rss", Feed: "Heise"},
+		{Title: "Warum die neue Version nicht läuft", URL: "https://example.com/3", Source: "hackernews"},
+		{Title: "Kubernetes 1.31", URL: "https://example.com/4", Source: "rss", Language: "fr"},
+		{Title: "Go 1.23", URL: "https://example.com/5", Source: "rss"},
+	}
+	kept := nl.Apply(context.Background(), items)
+
+	var urls []string
+	for _, item := range kept {
+		urls = append(urls, item.URL[len(item.URL)-1:])
+	}
+	// The French feed's item is translated too, but the stub only knows German
+	if strings.Join(urls, ",") != "1,2,5" {
+		t.Fatalf("Expected English, translated and undetected items, got %v", urls)
+	}
+	if kept[1].Title != "Why the new version does not run" || !kept[1].Translated || kept[1].Language != "de" {
+		t.Errorf("Expected the German RSS title to be translated, got %+v", kept[1])
+	}
+	if subtitle := formatRSSItem(kept[1]).Subtitle; !strings.Contains(subtitle, "🌐 de") {
+		t.Errorf("Expected the subtitle to mark the translation, got '%s'", subtitle)
+	}
+
+	nl.Apply(context.Background(), items)
+	if calls != 3 {
+		t.Errorf("Expected successful translations to be cached, got %d calls", calls)
+	}
+
+	if NewNewsLanguage(&Config{}) != nil {
+		t.Error("Expected no filter without settings")
+	}
+}
+
+func TestNewsLanguageTranslateAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.Method != "POST" || body["source"] != "de" || body["target"] != "en" || body["api_key"] != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"translatedText": "Translated: " + body["q"]})
+	}))
+	defer server.Close()
+
+	cfg := &Config{}
+	cfg.Widgets.News.Language.Translate = server.URL + "/translate"
+	cfg.Widgets.News.Language.APIKey = "secret"
+	nl := NewNewsLanguage(cfg)
+
+	kept := nl.Apply(context.Background(), []NewsItem{{Title: "Die neue Version ist da", URL: "https://example.com/1", Source: "rss"}})
+	if len(kept) != 1 || kept[0].Title != "Translated: Die neue Version ist da" {
+		t.Errorf("Expected the title from the API, got %+v", kept)
+	}
+}
+
+func TestNewsLanguageConcurrentApply(t *testing.T) {
+	cfg := &Config{}
+	cfg.Widgets.News.Language.Translate = "trans -b :en"
+	nl := NewNewsLanguage(cfg)
+	nl.run = func(ctx context.Context, command, text, lang string) (string, error) {
+		return "Why the new version does not run", nil
+	}
+	items := []NewsItem{{Title: "Warum die neue Version nicht läuft", URL: "https://example.com/2", Source: "rss"}}
+
+	// A scheduled fetch and a refresh may translate at the same time; run with -race
+	done := make(chan []NewsItem)
+	for i := 0; i < 2; i++ {
+		go func() { done <- nl.Apply(context.Background(), items) }()
+	}
+	for i := 0; i < 2; i++ {
+		if kept := <-done; len(kept) != 1 || !kept[0].Translated {
+			t.Errorf("Expected the title translated, got %+v", kept)
+		}
+	}
+}
@@ -7,9 +7,8 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
-
-	"github.com/mmcdole/gofeed"
 )
 
 // BaseNewsPlugin provides common functionality for news plugins
@@ -22,6 +21,7 @@ type BaseNewsPlugin struct {
 	author        string
 	tags          []string
 	currentTag    string
+	tagMu         sync.RWMutex // guards currentTag, which the dashboard sets while a fetch runs
 	supportedTags []string
 	client        *http.Client
 	lastData      []NewsItem
@@ -62,7 +62,7 @@ func (bnp *BaseNewsPlugin) GetMetadata() PluginMetadata {
 		Author:      bnp.author,
 		Type:        bnp.pluginType,
 		Config: map[string]string{
-			"current_tag":    bnp.currentTag,
+			"current_tag":    bnp.GetCurrentTag(),
 			"supported_tags": strings.Join(bnp.supportedTags, ","),
 		},
 	}
@@ -75,11 +75,15 @@ func (bnp *BaseNewsPlugin) SetTags(tags []string) {
 
 // GetCurrentTag returns the current active tag
 func (bnp *BaseNewsPlugin) GetCurrentTag() string {
+	bnp.tagMu.RLock()
+	defer bnp.tagMu.RUnlock()
 	return bnp.currentTag
 }
 
 // SetCurrentTag sets the current active tag
 func (bnp *BaseNewsPlugin) SetCurrentTag(tag string) {
+	bnp.tagMu.Lock()
+	defer bnp.tagMu.Unlock()
 	bnp.currentTag = tag
 }
 
@@ -96,12 +100,13 @@ func (bnp *BaseNewsPlugin) Cleanup() error {
 
 // filterByCurrentTag filters news items by the current tag
 func (bnp *BaseNewsPlugin) filterByCurrentTag(items []NewsItem) []NewsItem {
-	if bnp.currentTag == "all" || bnp.currentTag == "" {
+	currentTag := bnp.GetCurrentTag()
+	if currentTag == "all" || currentTag == "" {
 		return items
 	}
 
 	var filtered []NewsItem
-	tagLower := strings.ToLower(bnp.currentTag)
+	tagLower := strings.ToLower(currentTag)
 
 	for _, item := range items {
 		// Check title and description for the tag
@@ -150,7 +155,7 @@ func NewHackerNewsPlugin() *HackerNewsPlugin {
 // Initialize sets up the plugin with configuration
 func (hn *HackerNewsPlugin) Initialize(config map[string]interface{}) error {
 	// Hacker News doesn't require API keys, so just validate config
-	if tags, ok := config["tags"].([]string); ok {
+	if tags := configStringList(config["tags"]); tags != nil {
 		hn.SetTags(tags)
 	}
 	if currentTag, ok := config["current_tag"].(string); ok {
@@ -162,8 +167,8 @@ func (hn *HackerNewsPlugin) Initialize(config map[string]interface{}) error {
 // Fetch retrieves news from Hacker News
 func (hn *HackerNewsPlugin) Fetch(ctx context.Context) (interface{}, error) {
 	query := "story"
-	if hn.currentTag != "all" && hn.currentTag != "" {
-		query = hn.currentTag
+	if tag := hn.GetCurrentTag(); tag != "all" && tag != "" {
+		query = tag
 	}
 
 	url := fmt.Sprintf("https://hn.algolia.com/api/v1/search_by_date?tags=story&query=%s&hitsPerPage=15", query)
@@ -252,7 +257,7 @@ func NewDevToPlugin() *DevToPlugin {
 
 // Initialize sets up the plugin with configuration
 func (dt *DevToPlugin) Initialize(config map[string]interface{}) error {
-	if tags, ok := config["tags"].([]string); ok {
+	if tags := configStringList(config["tags"]); tags != nil {
 		dt.SetTags(tags)
 	}
 	if currentTag, ok := config["current_tag"].(string); ok {
@@ -264,8 +269,8 @@ func (dt *DevToPlugin) Initialize(config map[string]interface{}) error {
 // Fetch retrieves articles from Dev.to
 func (dt *DevToPlugin) Fetch(ctx context.Context) (interface{}, error) {
 	url := "https://dev.to/api/articles?per_page=15&top=7"
-	if dt.currentTag != "all" && dt.currentTag != "" {
-		url = fmt.Sprintf("https://dev.to/api/articles?tag=%s&per_page=15&top=7", dt.currentTag)
+	if tag := dt.GetCurrentTag(); tag != "all" && tag != "" {
+		url = fmt.Sprintf("https://dev.to/api/articles?tag=%s&per_page=15&top=7", tag)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -327,6 +332,83 @@ func (dt *DevToPlugin) Fetch(ctx context.Context) (interface{}, error) {
 	return filtered, nil
 }
 
+// aggregateNewsSources are the sources widgets.news.sources can pick for the aggregate
+// provider. Sources that need their own settings, such as mastodon, are standalone only.
+var aggregateNewsSources = map[string]func() NewsPlugin{
+	"hn":          func() NewsPlugin { return NewHackerNewsPlugin() },
+	"devto":       func() NewsPlugin { return NewDevToPlugin() },
+	"hackernoon":  func() NewsPlugin { return NewHackernoonPlugin() },
+	"rss":         func() NewsPlugin { return NewRSSPlugin() },
+	"producthunt": func() NewsPlugin { return NewProductHuntPlugin() },
+	"arxiv":       func() NewsPlugin { return NewArxivPlugin() },
+	"security":    func() NewsPlugin { return NewSecurityNewsPlugin() },
+}
+
+// NewsItemFormatter turns a news item into the row the Tech News tile shows
+type NewsItemFormatter func(news NewsItem) WidgetItem
+
+// newsItemFormatters format items by their NewsItem.Source. Each formatter lives next to
+// the plugin producing the items; sources without one show the author.
+var newsItemFormatters = map[string]NewsItemFormatter{
+	"hackernews":               formatHackerNewsItem,
+	"devto":                    formatDevToItem,
+	"rss":                      formatRSSItem,
+	"mastodon":                 formatMastodonItem,
+	"mastodon-mention":         formatMastodonItem,
+	"stackoverflow":            formatStackOverflowItem,
+	"stackoverflow-inbox":      formatStackOverflowInboxItem,
+	"stackoverflow-reputation": formatStackOverflowReputationItem,
+	"arxiv":                    formatArxivItem,
+	"producthunt":              formatProductHuntItem,
+	"security":                 formatSecurityItem,
+}
+
+// formatNewsItem formats a news item with the formatter of its source
+func formatNewsItem(news NewsItem) WidgetItem {
+	item := WidgetItem{Title: news.Title, Subtitle: news.Author, URL: news.URL}
+	if format, ok := newsItemFormatters[news.Source]; ok {
+		item = format(news)
+	}
+	item.Details = newsItemDetails(news)
+	if news.CreatedAt > 0 {
+		item.Since = time.Unix(news.CreatedAt, 0)
+	}
+	return item
+}
+
+// newsItemDetails lists who posted a story and when, its tags and description
+func newsItemDetails(news NewsItem) []string {
+	var details []string
+	byline := news.Author
+	if news.CreatedAt > 0 {
+		byline = strings.TrimPrefix(byline+" • "+time.Unix(news.CreatedAt, 0).Format("Mon 2 Jan 2006 15:04"), " • ")
+	}
+	if byline != "" {
+		details = append(details, byline)
+	}
+	if len(news.Tags) > 0 {
+		details = append(details, "Tags: "+strings.Join(news.Tags, ", "))
+	}
+	if description := strings.Join(strings.Fields(news.Description), " "); description != "" {
+		details = append(details, "", description)
+	}
+	return details
+}
+
+// formatHackerNewsItem shows the author and points of a Hacker News story
+func formatHackerNewsItem(news NewsItem) WidgetItem {
+	subtitle := fmt.Sprintf("%s • HN", news.Author)
+	if news.Points > 0 {
+		subtitle = fmt.Sprintf("%s • %d pts", subtitle, news.Points)
+	}
+	return WidgetItem{Title: news.Title, Subtitle: subtitle, URL: news.URL}
+}
+
+// formatDevToItem shows the author of a Dev.to article
+func formatDevToItem(news NewsItem) WidgetItem {
+	return WidgetItem{Title: news.Title, Subtitle: fmt.Sprintf("%s • Dev.to", news.Author), URL: news.URL}
+}
+
 // AggregateNewsPlugin combines multiple news sources
 type AggregateNewsPlugin struct {
 	*BaseNewsPlugin
@@ -343,7 +425,15 @@ func NewAggregateNewsPlugin(sources []NewsPlugin) *AggregateNewsPlugin {
 		"GoDay Team",
 	)
 
-	// Collect all supported tags from sources
+	an := &AggregateNewsPlugin{
+		BaseNewsPlugin: base,
+	}
+	an.setSources(sources)
+	return an
+}
+
+// setSources replaces the combined sources and collects their supported tags
+func (an *AggregateNewsPlugin) setSources(sources []NewsPlugin) {
 	var allTags []string
 	tagSet := make(map[string]bool)
 	tagSet["all"] = true
@@ -358,19 +448,27 @@ func NewAggregateNewsPlugin(sources []NewsPlugin) *AggregateNewsPlugin {
 		}
 	}
 
-	base.supportedTags = allTags
-
-	return &AggregateNewsPlugin{
-		BaseNewsPlugin: base,
-		sources:        sources,
-	}
+	an.sources = sources
+	an.supportedTags = allTags
 }
 
 // Initialize sets up the plugin with configuration
 func (an *AggregateNewsPlugin) Initialize(config map[string]interface{}) error {
-	if tags, ok := config["tags"].([]string); ok {
+	if tags := configStringList(config["tags"]); tags != nil {
 		an.SetTags(tags)
 	}
+	// Configured sources replace the defaults
+	if names := configStringList(config["sources"]); len(names) > 0 {
+		var sources []NewsPlugin
+		for _, name := range names {
+			newSource, ok := aggregateNewsSources[name]
+			if !ok {
+				return fmt.Errorf("unknown news source %q", name)
+			}
+			sources = append(sources, newSource())
+		}
+		an.setSources(sources)
+	}
 	if currentTag, ok := config["current_tag"].(string); ok {
 		an.SetCurrentTag(currentTag)
 	}
@@ -387,11 +485,12 @@ func (an *AggregateNewsPlugin) Initialize(config map[string]interface{}) error {
 
 // Fetch retrieves news from all sources and aggregates them
 func (an *AggregateNewsPlugin) Fetch(ctx context.Context) (interface{}, error) {
-	var allItems []NewsItem
+	var perSource [][]NewsItem
 
 	// Set current tag on all sources
+	tag := an.GetCurrentTag()
 	for _, source := range an.sources {
-		source.SetCurrentTag(an.currentTag)
+		source.SetCurrentTag(tag)
 
 		// Fetch from each source
 		data, err := source.Fetch(ctx)
@@ -402,7 +501,22 @@ func (an *AggregateNewsPlugin) Fetch(ctx context.Context) (interface{}, error) {
 		}
 
 		if items, ok := data.([]NewsItem); ok {
-			allItems = append(allItems, items...)
+			perSource = append(perSource, items)
+		}
+	}
+
+	// Interleave sources so the item limit below does not cut off the later ones
+	var allItems []NewsItem
+	for i := 0; ; i++ {
+		added := false
+		for _, items := range perSource {
+			if i < len(items) {
+				allItems = append(allItems, items[i])
+				added = true
+			}
+		}
+		if !added {
+			break
 		}
 	}
 
@@ -425,39 +539,37 @@ func (an *AggregateNewsPlugin) Fetch(ctx context.Context) (interface{}, error) {
 
 // SetCurrentTag sets the current tag on the aggregate plugin and all sources
 func (an *AggregateNewsPlugin) SetCurrentTag(tag string) {
-	an.currentTag = tag
+	an.BaseNewsPlugin.SetCurrentTag(tag)
 	for _, source := range an.sources {
 		source.SetCurrentTag(tag)
 	}
 }
 
-// HackernoonPlugin implements news fetching from Hackernoon RSS feed
+// HackernoonPlugin implements news fetching from the Hackernoon RSS feed
 type HackernoonPlugin struct {
-	*BaseNewsPlugin
-	feedParser *gofeed.Parser
+	*RSSPlugin
 }
 
 // NewHackernoonPlugin creates a new Hackernoon RSS plugin
 func NewHackernoonPlugin() *HackernoonPlugin {
-	base := NewBaseNewsPlugin(
-		"hackernoon",
-		"Hackernoon",
-		"1.0.0",
-		"Fetches tech articles from Hackernoon RSS feed",
-		"GoDay Team",
-	)
-
-	base.supportedTags = []string{"all", "tech", "programming", "blockchain", "ai", "startup", "cybersecurity", "javascript", "python", "golang"}
+	rss := NewRSSPlugin()
+	rss.id = "hackernoon"
+	rss.name = "Hackernoon"
+	rss.description = "Fetches tech articles from Hackernoon RSS feed"
+	rss.supportedTags = []string{"all", "tech", "programming", "blockchain", "ai", "startup", "cybersecurity", "javascript", "python", "golang"}
+	rss.feeds = []NewsFeed{{URL: "https://hackernoon.com/feed", Label: "Hackernoon"}}
+	rss.source = "hackernoon"
+	rss.maxItems = 10
 
 	return &HackernoonPlugin{
-		BaseNewsPlugin: base,
-		feedParser:     gofeed.NewParser(),
+		RSSPlugin: rss,
 	}
 }
 
-// Initialize sets up the plugin with configuration
+// Initialize sets up the plugin with configuration. The feed is fixed, so any
+// configured feeds (meant for the rss provider) are ignored.
 func (hn *HackernoonPlugin) Initialize(config map[string]interface{}) error {
-	if tags, ok := config["tags"].([]string); ok {
+	if tags := configStringList(config["tags"]); tags != nil {
 		hn.SetTags(tags)
 	}
 	if currentTag, ok := config["current_tag"].(string); ok {
@@ -465,76 +577,3 @@ func (hn *HackernoonPlugin) Initialize(config map[string]interface{}) error {
 	}
 	return nil
 }
-
-// Fetch retrieves articles from Hackernoon RSS feed
-func (hn *HackernoonPlugin) Fetch(ctx context.Context) (interface{}, error) {
-	// Hackernoon RSS feed URL
-	url := "https://hackernoon.com/feed"
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return hn.lastData, err
-	}
-
-	resp, err := hn.client.Do(req)
-	if err != nil {
-		return hn.lastData, err
-	}
-	defer resp.Body.Close()
-
-	feed, err := hn.feedParser.Parse(resp.Body)
-	if err != nil {
-		return hn.lastData, err
-	}
-
-	var items []NewsItem
-	for _, item := range feed.Items {
-		if item.Link == "" || item.Title == "" {
-			continue
-		}
-
-		// Extract tags from categories
-		var tags []string
-		for _, category := range item.Categories {
-			tags = append(tags, category)
-		}
-
-		// Parse published date
-		var createdAt int64
-		if item.PublishedParsed != nil {
-			createdAt = item.PublishedParsed.Unix()
-		}
-
-		// Get author name
-		author := "Hackernoon"
-		if len(item.Authors) > 0 && item.Authors[0].Name != "" {
-			author = item.Authors[0].Name
-		}
-
-		items = append(items, NewsItem{
-			Title:       item.Title,
-			URL:         item.Link,
-			Author:      author,
-			Description: item.Description,
-			Tags:        tags,
-			Source:      "hackernoon",
-			CreatedAt:   createdAt,
-		})
-
-		// Limit to 15 items from RSS
-		if len(items) >= 15 {
-			break
-		}
-	}
-
-	// Filter by current tag
-	filtered := hn.filterByCurrentTag(items)
-
-	// Limit to 10 items
-	if len(filtered) > 10 {
-		filtered = filtered[:10]
-	}
-
-	hn.lastData = filtered
-	return filtered, nil
-}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// NewsTagEditor is the overlay for adding and removing the news tags cycled with t
+type NewsTagEditor struct {
+	tags   []string
+	cursor int
+	input  textinput.Model
+	adding bool
+	err    string
+}
+
+// NewNewsTagEditor creates a tag editor for a copy of tags
+func NewNewsTagEditor(tags []string) *NewsTagEditor {
+	input := textinput.New()
+	input.Placeholder = "new tag"
+	input.CharLimit = 32
+	input.Prompt = "+ "
+
+	return &NewsTagEditor{
+		tags:  append([]string(nil), tags...),
+		input: input,
+	}
+}
+
+// Tags returns the edited tag list
+func (e *NewsTagEditor) Tags() []string {
+	return e.tags
+}
+
+// Update handles a key press. It reports whether the editor should close and
+// whether the tag list changed.
+func (e *NewsTagEditor) Update(msg tea.KeyMsg) (done, changed bool) {
+	if e.adding {
+		switch msg.String() {
+		case "enter":
+			changed = e.addTag(e.input.Value())
+			if e.err == "" {
+				e.input.SetValue("")
+				e.input.Blur()
+				e.adding = false
+			}
+		case "esc":
+			e.input.SetValue("")
+			e.input.Blur()
+			e.adding = false
+			e.err = ""
+		default:
+			e.input, _ = e.input.Update(msg)
+		}
+		return false, changed
+	}
+
+	e.err = ""
+	switch msg.String() {
+	case "esc", "enter", "q", "T":
+		return true, false
+	case "up", "k":
+		if e.cursor > 0 {
+			e.cursor--
+		}
+	case "down", "j":
+		if e.cursor < len(e.tags)-1 {
+			e.cursor++
+		}
+	case "a", "n", "+":
+		e.adding = true
+		e.input.Focus()
+	case "d", "x", "-", "delete", "backspace":
+		if len(e.tags) > 0 {
+			e.tags = append(e.tags[:e.cursor], e.tags[e.cursor+1:]...)
+			if e.cursor >= len(e.tags) && e.cursor > 0 {
+				e.cursor--
+			}
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// addTag appends a tag, rejecting empty and duplicate names
+func (e *NewsTagEditor) addTag(tag string) bool {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		e.err = "tag cannot be empty"
+		return false
+	}
+	for _, existing := range e.tags {
+		if strings.EqualFold(existing, tag) {
+			e.err = fmt.Sprintf("%q is already a tag", tag)
+			return false
+		}
+	}
+
+	e.tags = append(e.tags, tag)
+	e.cursor = len(e.tags) - 1
+	e.err = ""
+	return true
+}
+
+// SetError shows an error below the tag list, e.g. when saving fails
+func (e *NewsTagEditor) SetError(err error) {
+	e.err = err.Error()
+}
+
+// View renders the editor as a bordered box
+func (e *NewsTagEditor) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colorTitle)
+	selectedStyle := lipgloss.NewStyle().Foreground(colorAccent).Bold(true)
+	helpStyle := lipgloss.NewStyle().Foreground(colorMuted).Italic(true)
+	errorStyle := lipgloss.NewStyle().Foreground(colorAlert)
+
+	lines := []string{titleStyle.Render("News Tags"), ""}
+	if len(e.tags) == 0 {
+		lines = append(lines, helpStyle.Render("No tags - news shows all stories"))
+	}
+	for i, tag := range e.tags {
+		if i == e.cursor && !e.adding {
+			lines = append(lines, selectedStyle.Render("▶ "+tag))
+		} else {
+			lines = append(lines, "  "+tag)
+		}
+	}
+
+	if e.adding {
+		lines = append(lines, "", e.input.View())
+	}
+	if e.err != "" {
+		lines = append(lines, "", errorStyle.Render(e.err))
+	}
+
+	lines = append(lines, "")
+	if e.adding {
+		lines = append(lines, helpStyle.Render("Enter add • Esc cancel"))
+	} else {
+		lines = append(lines, helpStyle.Render("a add • d remove • ↑↓/jk select • Esc done"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Padding(1, 2).
+		Width(48).
+		Render(strings.Join(lines, "\n"))
+}
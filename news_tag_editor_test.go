@@ -0,0 +1,131 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func keyPress(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+func TestNewsTagEditor(t *testing.T) {
+	editor := NewNewsTagEditor([]string{"golang", "security"})
+
+	// Add a tag
+	editor.Update(keyPress("a"))
+	for _, r := range "rust" {
+		editor.Update(keyPress(string(r)))
+	}
+	if _, changed := editor.Update(keyPress("enter")); !changed {
+		t.Error("Expected adding a tag to report a change")
+	}
+
+	// Duplicates are rejected case-insensitively
+	editor.Update(keyPress("a"))
+	for _, r := range "GoLang" {
+		editor.Update(keyPress(string(r)))
+	}
+	if _, changed := editor.Update(keyPress("enter")); changed {
+		t.Error("Expected a duplicate tag to be rejected")
+	}
+	if !strings.Contains(editor.View(), "already a tag") {
+		t.Error("Expected the duplicate error to be shown")
+	}
+	editor.Update(keyPress("esc"))
+
+	// Remove the first tag
+	editor.Update(keyPress("k"))
+	editor.Update(keyPress("k"))
+	if _, changed := editor.Update(keyPress("d")); !changed {
+		t.Error("Expected removing a tag to report a change")
+	}
+
+	expected := []string{"security", "rust"}
+	if strings.Join(editor.Tags(), ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected tags %v, got %v", expected, editor.Tags())
+	}
+
+	if done, _ := editor.Update(keyPress("esc")); !done {
+		t.Error("Expected Esc to close the editor")
+	}
+}
+
+func TestSaveNewsTagsKeepsComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `# My dashboard
+widgets:
+  news:
+    ttl: 600s
+    tags: [golang, security]  # Filter tech news by these tags
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if err := SaveNewsTags(path, []string{"golang", "rust"}); err != nil {
+		t.Fatalf("SaveNewsTags failed: %v", err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if strings.Join(cfg.Widgets.News.Tags, ",") != "golang,rust" {
+		t.Errorf("Expected saved tags [golang rust], got %v", cfg.Widgets.News.Tags)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "# My dashboard") || !strings.Contains(string(data), "# Filter tech news") {
+		t.Errorf("Expected comments to be preserved, got:\n%s", data)
+	}
+}
+
+func TestApplyNewsTags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("user:\n  name: Test\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	pluginManager := NewPluginManager(&PluginConfig{Plugins: map[string]map[string]interface{}{}})
+	newsPlugin := NewDevToPlugin()
+	pluginManager.RegisterPlugin(newsPlugin)
+	newsPlugin.SetCurrentTag("golang")
+
+	m := Model{
+		config:        &Config{},
+		configPath:    path,
+		widgetManager: NewWidgetManager(),
+		pluginManager: pluginManager,
+	}
+	m.widgetManager.NewsTagIndex = 1
+
+	if err := m.applyNewsTags([]string{"kubernetes"}); err != nil {
+		t.Fatalf("applyNewsTags failed: %v", err)
+	}
+
+	if m.widgetManager.GetCurrentNewsTag() != "All" {
+		t.Errorf("Expected tag filter to reset to 'All', got '%s'", m.widgetManager.GetCurrentNewsTag())
+	}
+	if newsPlugin.GetCurrentTag() != "all" {
+		t.Errorf("Expected news plugin tag 'all', got '%s'", newsPlugin.GetCurrentTag())
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if len(cfg.Widgets.News.Tags) != 1 || cfg.Widgets.News.Tags[0] != "kubernetes" {
+		t.Errorf("Expected tags to be persisted, got %v", cfg.Widgets.News.Tags)
+	}
+}
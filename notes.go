@@ -0,0 +1,227 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// noteTimeLayout stamps each captured note, e.g. "- 2026-03-06 14:03 call the bank"
+const noteTimeLayout = "2006-01-02 15:04"
+
+// Note is one line of the notes file
+type Note struct {
+	Text string
+	At   time.Time // when it was captured; zero for lines written in the editor
+}
+
+// notesEditedMsg is sent when the editor opened on the notes file exits
+type notesEditedMsg struct{ err error }
+
+// Notes is the scratchpad file behind the Notes tile
+type Notes struct {
+	path    string
+	modTime time.Time // of the file when last read, to reread it only after changes
+}
+
+// NotesPath returns the notes file: widgets.notes.path, or ~/.goday/notes.md
+func NotesPath(cfg *Config) string {
+	path := ""
+	if cfg != nil {
+		path = cfg.Widgets.Notes.Path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	if path == "" {
+		return filepath.Join(homeDir, ".goday", "notes.md")
+	}
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(homeDir, path[2:])
+	}
+	return path
+}
+
+// NewNotes creates the scratchpad kept in path
+func NewNotes(path string) *Notes {
+	return &Notes{path: path}
+}
+
+// Changed reports whether the file was modified since it was last read
+func (n *Notes) Changed() bool {
+	info, err := os.Stat(n.path)
+	if err != nil {
+		return !n.modTime.IsZero()
+	}
+	return !info.ModTime().Equal(n.modTime)
+}
+
+// Load reads the notes, latest first. Blank lines and headings are skipped and list
+// markers dropped; a missing file has no notes.
+func (n *Notes) Load() ([]Note, error) {
+	data, err := os.ReadFile(n.path)
+	if os.IsNotExist(err) {
+		n.modTime = time.Time{}
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(n.path); err == nil {
+		n.modTime = info.ModTime()
+	}
+
+	var notes []Note
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		notes = append([]Note{parseNote(line)}, notes...)
+	}
+	return notes, nil
+}
+
+// parseNote reads a line of the notes file, with its capture time if it has one
+func parseNote(line string) Note {
+	for _, marker := range []string{"- [ ] ", "- ", "* "} {
+		if strings.HasPrefix(line, marker) {
+			line = strings.TrimSpace(line[len(marker):])
+			break
+		}
+	}
+	if len(line) > len(noteTimeLayout) {
+		if at, err := time.ParseInLocation(noteTimeLayout, line[:len(noteTimeLayout)], time.Local); err == nil {
+			return Note{Text: strings.TrimSpace(line[len(noteTimeLayout):]), At: at}
+		}
+	}
+	return Note{Text: line}
+}
+
+// Append adds a note to the end of the file, stamped with now, creating the file if needed
+func (n *Notes) Append(text string, now time.Time) error {
+	line := "- " + now.Format(noteTimeLayout) + " " + strings.Join(strings.Fields(text), " ") + "\n"
+	// The editor or another dashboard may be writing the file too
+	return withFileLock(n.path, func() error {
+		file, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		if _, err := file.WriteString(line); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	})
+}
+
+// editorCommand returns the command opening path in $VISUAL or $EDITOR, which may carry
+// arguments such as "code --wait", or in a default editor for the system
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+		if runtime.GOOS == "windows" {
+			args = []string{"notepad"}
+		}
+	}
+	return exec.Command(args[0], append(args[1:], path)...)
+}
+
+// editNotesCmd hands the terminal to the editor on the notes file until it exits
+func (n *Notes) editNotesCmd() tea.Cmd {
+	// The editor starts on an empty file rather than failing on a missing one
+	if err := os.MkdirAll(filepath.Dir(n.path), 0755); err != nil {
+		return func() tea.Msg { return notesEditedMsg{err: err} }
+	}
+	return tea.ExecProcess(editorCommand(n.path), func(err error) tea.Msg {
+		return notesEditedMsg{err: err}
+	})
+}
+
+// updateNotes rereads the notes file into its tile if it changed; force rereads it anyway
+func (m *Model) updateNotes(force bool) {
+	if m.notes == nil || (!force && !m.notes.Changed()) {
+		return
+	}
+	notes, err := m.notes.Load()
+	if err != nil {
+		m.widgetManager.Widgets["notes"].Items = []WidgetItem{{Title: "Notes unavailable", Subtitle: err.Error(), Status: "❌"}}
+		m.widgetManager.Widgets["notes"].HasError = true
+	} else {
+		m.widgetManager.UpdateNotesWidget(notes)
+	}
+	m.syncTile("notes")
+}
+
+// NoteCapture is the overlay opened with n for jotting a note down
+type NoteCapture struct {
+	input textinput.Model
+	err   string
+}
+
+// NewNoteCapture creates an empty, focused capture overlay
+func NewNoteCapture() *NoteCapture {
+	input := textinput.New()
+	input.Placeholder = "what's on your mind?"
+	input.CharLimit = 500
+	input.Width = 40
+	input.Prompt = "✎ "
+	input.Focus()
+	return &NoteCapture{input: input}
+}
+
+// Update handles a key press. It reports whether the overlay should close and the note
+// to save, empty when cancelled.
+func (c *NoteCapture) Update(msg tea.KeyMsg) (done bool, note string) {
+	switch msg.String() {
+	case "esc":
+		return true, ""
+	case "enter":
+		note = strings.TrimSpace(c.input.Value())
+		if note == "" {
+			c.err = "note cannot be empty"
+			return false, ""
+		}
+		return true, note
+	}
+	c.err = ""
+	c.input, _ = c.input.Update(msg)
+	return false, ""
+}
+
+// SetError shows an error below the input, e.g. when saving fails
+func (c *NoteCapture) SetError(err error) {
+	c.err = err.Error()
+}
+
+// View renders the overlay as a bordered box
+func (c *NoteCapture) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colorTitle)
+	helpStyle := lipgloss.NewStyle().Foreground(colorMuted).Italic(true)
+	errorStyle := lipgloss.NewStyle().Foreground(colorAlert)
+
+	lines := []string{titleStyle.Render("Quick Note"), "", c.input.View()}
+	if c.err != "" {
+		lines = append(lines, "", errorStyle.Render(c.err))
+	}
+	lines = append(lines, "", helpStyle.Render("Enter save • Esc cancel"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Padding(1, 2).
+		Width(56).
+		Render(strings.Join(lines, "\n"))
+}
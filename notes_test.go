@@ -0,0 +1,97 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNotesAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes", "notes.md")
+	notes := NewNotes(path)
+
+	if loaded, err := notes.Load(); err != nil || len(loaded) != 0 {
+		t.Errorf("Expected no notes without a file, got %v, %v", loaded, err)
+	}
+
+	at := time.Date(2026, 3, 6, 14, 3, 0, 0, time.Local)
+	if err := notes.Append("call   the bank", at); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !notes.Changed() {
+		t.Error("Expected the new file to count as changed")
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString("\n# Ideas\n* written in the editor\n")
+	file.Close()
+
+	loaded, err := notes.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []Note{{Text: "written in the editor"}, {Text: "call the bank", At: at}}
+	if !reflect.DeepEqual(loaded, expected) {
+		t.Errorf("Expected %v, got %v", expected, loaded)
+	}
+	if notes.Changed() {
+		t.Error("Expected the file not to count as changed once read")
+	}
+}
+
+func TestParseNote(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected Note
+	}{
+		{"- 2026-03-06 14:03 call the bank", Note{Text: "call the bank", At: time.Date(2026, 3, 6, 14, 3, 0, 0, time.Local)}},
+		{"- [ ] renew passport", Note{Text: "renew passport"}},
+		{"2026 plans", Note{Text: "2026 plans"}},
+	}
+	for _, test := range tests {
+		if note := parseNote(test.line); !reflect.DeepEqual(note, test.expected) {
+			t.Errorf("Expected %v for %q, got %v", test.expected, test.line, note)
+		}
+	}
+}
+
+func TestNoteCapture(t *testing.T) {
+	capture := NewNoteCapture()
+	if done, note := capture.Update(tea.KeyMsg{Type: tea.KeyEnter}); done || note != "" || capture.err == "" {
+		t.Errorf("Expected an empty note to be refused, got done=%v note=%q", done, note)
+	}
+	for _, r := range "buy milk" {
+		capture.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if done, note := capture.Update(tea.KeyMsg{Type: tea.KeyEnter}); !done || note != "buy milk" {
+		t.Errorf("Expected the note to be saved, got done=%v note=%q", done, note)
+	}
+	if done, note := NewNoteCapture().Update(tea.KeyMsg{Type: tea.KeyEsc}); !done || note != "" {
+		t.Errorf("Expected Esc to cancel, got done=%v note=%q", done, note)
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	cmd := editorCommand("/tmp/notes.md")
+	if expected := []string{"code", "--wait", "/tmp/notes.md"}; !reflect.DeepEqual(cmd.Args, expected) {
+		t.Errorf("Expected %v, got %v", expected, cmd.Args)
+	}
+}
+
+func TestNotesWidget(t *testing.T) {
+	wm := NewWidgetManager()
+	wm.InitializeWidgets(nil)
+	wm.UpdateNotesWidget([]Note{{Text: "call the bank", At: time.Date(2026, 3, 6, 14, 3, 0, 0, time.Local)}})
+	widget := wm.Widgets["notes"]
+	if widget.Count != 1 || widget.Items[0].Subtitle != "Fri 06 Mar 14:03" {
+		t.Errorf("Expected one note captured Fri 06 Mar 14:03, got %v", widget.Items)
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpenMeteoWeatherPlugin fetches current weather and an hourly and daily forecast from
+// Open-Meteo, which needs no API key
+type OpenMeteoWeatherPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	city        string
+	apiURL      string
+	geocodeURL  string
+	client      *http.Client
+	lastData    *WeatherData
+	latitude    float64
+	longitude   float64
+	located     string // city the coordinates were looked up for
+	now         func() time.Time
+}
+
+// NewOpenMeteoWeatherPlugin creates a new Open-Meteo weather plugin
+func NewOpenMeteoWeatherPlugin() *OpenMeteoWeatherPlugin {
+	return &OpenMeteoWeatherPlugin{
+		id:          "open-meteo",
+		pluginType:  "weather",
+		name:        "Open-Meteo",
+		version:     "1.0.0",
+		description: "Fetches current weather and an hourly forecast from Open-Meteo without an API key",
+		author:      "GoDay Team",
+		apiURL:      "https://api.open-meteo.com",
+		geocodeURL:  "https://geocoding-api.open-meteo.com",
+		client:      &http.Client{Timeout: 10 * time.Second},
+		now:         time.Now,
+	}
+}
+
+// GetID returns the plugin ID
+func (op *OpenMeteoWeatherPlugin) GetID() string {
+	return op.id
+}
+
+// GetType returns the plugin type
+func (op *OpenMeteoWeatherPlugin) GetType() string {
+	return op.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (op *OpenMeteoWeatherPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        op.name,
+		Version:     op.version,
+		Description: op.description,
+		Author:      op.author,
+		Type:        op.pluginType,
+		Config: map[string]string{
+			"city": op.city,
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (op *OpenMeteoWeatherPlugin) Initialize(config map[string]interface{}) error {
+	if city, ok := config["city"].(string); ok {
+		op.city = city
+	}
+	return nil
+}
+
+// getJSON fetches an Open-Meteo endpoint and decodes its JSON response
+func (op *OpenMeteoWeatherPlugin) getJSON(ctx context.Context, endpoint string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := op.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Open-Meteo returned status %d", resp.StatusCode)
+	}
+	return json.Unmarshal(body, out)
+}
+
+// locate looks up the coordinates of the city, such as "Bengaluru,IN", once
+func (op *OpenMeteoWeatherPlugin) locate(ctx context.Context) error {
+	if op.located == op.city && op.city != "" {
+		return nil
+	}
+
+	latitude, longitude, err := openMeteoGeocode(ctx, op.client, op.geocodeURL, op.city)
+	if err != nil {
+		return err
+	}
+	op.latitude, op.longitude = latitude, longitude
+	op.located = op.city
+	return nil
+}
+
+// openMeteoGeocode looks up the coordinates of a city such as "Bengaluru,IN" with the
+// keyless Open-Meteo geocoding API, using the country code after the comma
+func openMeteoGeocode(ctx context.Context, client *http.Client, geocodeURL, city string) (float64, float64, error) {
+	name, country, _ := strings.Cut(city, ",")
+	params := url.Values{}
+	params.Set("name", strings.TrimSpace(name))
+	params.Set("count", "1")
+	if country = strings.TrimSpace(country); country != "" {
+		params.Set("countryCode", country)
+	}
+
+	var result struct {
+		Results []struct {
+			Latitude  float64 `json:"latitude"`
+			Longitude float64 `json:"longitude"`
+		} `json:"results"`
+	}
+	if err := fetchJSON(ctx, client, "Open-Meteo", geocodeURL+"/v1/search?"+params.Encode(), &result); err != nil {
+		return 0, 0, err
+	}
+	if len(result.Results) == 0 {
+		return 0, 0, fmt.Errorf("Open-Meteo does not know the location %q", city)
+	}
+	return result.Results[0].Latitude, result.Results[0].Longitude, nil
+}
+
+// Fetch retrieves the current conditions and the hourly and daily forecast
+func (op *OpenMeteoWeatherPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if err := op.locate(ctx); err != nil {
+		return op.lastData, err
+	}
+
+	params := url.Values{}
+	params.Set("latitude", fmt.Sprintf("%.4f", op.latitude))
+	params.Set("longitude", fmt.Sprintf("%.4f", op.longitude))
+	params.Set("current", "temperature_2m,weather_code")
+	params.Set("hourly", "temperature_2m,weather_code")
+	params.Set("daily", "weather_code,temperature_2m_max,temperature_2m_min")
+	params.Set("forecast_days", strconv.Itoa(weatherForecastDays))
+	params.Set("timezone", "auto")
+
+	var forecast struct {
+		UTCOffsetSeconds int `json:"utc_offset_seconds"`
+		Current          struct {
+			Temperature float64 `json:"temperature_2m"`
+			WeatherCode int     `json:"weather_code"`
+		} `json:"current"`
+		Hourly struct {
+			Time        []string  `json:"time"`
+			Temperature []float64 `json:"temperature_2m"`
+			WeatherCode []int     `json:"weather_code"`
+		} `json:"hourly"`
+		Daily struct {
+			Time        []string  `json:"time"`
+			WeatherCode []int     `json:"weather_code"`
+			High        []float64 `json:"temperature_2m_max"`
+			Low         []float64 `json:"temperature_2m_min"`
+		} `json:"daily"`
+	}
+	if err := op.getJSON(ctx, op.apiURL+"/v1/forecast?"+params.Encode(), &forecast); err != nil {
+		return op.lastData, err
+	}
+
+	condition, icon, _ := wmoWeather(forecast.Current.WeatherCode)
+	data := &WeatherData{
+		Temperature: int(math.Round(forecast.Current.Temperature)),
+		Condition:   condition,
+		Icon:        icon,
+	}
+
+	// Hours are local to the location, without an offset in the timestamps
+	zone := time.FixedZone("", forecast.UTCOffsetSeconds)
+	now := op.now()
+	for i, stamp := range forecast.Hourly.Time {
+		if i >= len(forecast.Hourly.Temperature) || i >= len(forecast.Hourly.WeatherCode) {
+			break
+		}
+		start, err := time.ParseInLocation("2006-01-02T15:04", stamp, zone)
+		if err != nil || !start.Add(time.Hour).After(now) {
+			continue
+		}
+		condition, icon, precipitation := wmoWeather(forecast.Hourly.WeatherCode[i])
+		data.Hourly = append(data.Hourly, WeatherHour{
+			Time:          start,
+			Temperature:   int(math.Round(forecast.Hourly.Temperature[i])),
+			Condition:     condition,
+			Icon:          icon,
+			Precipitation: precipitation,
+		})
+	}
+
+	for i, stamp := range forecast.Daily.Time {
+		if i >= len(forecast.Daily.WeatherCode) || i >= len(forecast.Daily.High) || i >= len(forecast.Daily.Low) {
+			break
+		}
+		date, err := time.ParseInLocation("2006-01-02", stamp, zone)
+		if err != nil {
+			continue
+		}
+		condition, icon, _ := wmoWeather(forecast.Daily.WeatherCode[i])
+		data.Daily = append(data.Daily, WeatherDay{
+			Date:      date,
+			High:      int(math.Round(forecast.Daily.High[i])),
+			Low:       int(math.Round(forecast.Daily.Low[i])),
+			Condition: condition,
+			Icon:      icon,
+		})
+	}
+
+	op.lastData = data
+	return data, nil
+}
+
+// Cleanup performs cleanup
+func (op *OpenMeteoWeatherPlugin) Cleanup() error {
+	return nil
+}
+
+// wmoWeather maps a WMO weather interpretation code, as used by Open-Meteo, to a
+// condition, an icon and the kind of precipitation
+func wmoWeather(code int) (string, string, string) {
+	switch code {
+	case 0:
+		return "Clear sky", "☀", ""
+	case 1:
+		return "Mainly clear", "☀", ""
+	case 2:
+		return "Partly cloudy", "⛅", ""
+	case 3:
+		return "Overcast", "☁", ""
+	case 45, 48:
+		return "Fog", "🌫", ""
+	case 51, 53, 55, 56, 57:
+		return "Drizzle", "🌧", "rain"
+	case 61, 63, 65, 66, 67:
+		return "Rain", "🌧", "rain"
+	case 80, 81, 82:
+		return "Rain showers", "🌧", "rain"
+	case 71, 73, 75, 77, 85, 86:
+		return "Snow", "❄", "snow"
+	case 95, 96, 99:
+		return "Thunderstorm", "⛈", "storms"
+	default:
+		return "Unknown", "☁", ""
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOpenMeteoWeatherPluginFetch(t *testing.T) {
+	geocodes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/v1/search":
+			geocodes++
+			if query.Get("name") != "Bengaluru" || query.Get("countryCode") != "IN" {
+				fmt.Fprint(w, `{}`)
+				return
+			}
+			fmt.Fprint(w, `{"results":[{"name":"Bengaluru","latitude":12.97194,"longitude":77.59369}]}`)
+		case "/v1/forecast":
+			if query.Get("latitude") != "12.9719" || query.Get("hourly") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"utc_offset_seconds":19800,
+				"current":{"temperature_2m":27.6,"weather_code":2},
+				"hourly":{
+					"time":["2026-10-16T13:00","2026-10-16T14:00","2026-10-16T15:00","2026-10-16T16:00","2026-10-16T17:00"],
+					"temperature_2m":[28.1,28.4,27.9,26.0,24.2],
+					"weather_code":[1,2,2,3,61]},
+				"daily":{"time":["2026-10-16","2026-10-17"],"weather_code":[61,0],
+					"temperature_2m_max":[28.4,30.6],"temperature_2m_min":[19.2,18.5]}}`)
+		}
+	}))
+	defer server.Close()
+
+	ist := time.FixedZone("IST", 19800)
+	now := time.Date(2026, 10, 16, 14, 20, 0, 0, ist)
+	plugin := NewOpenMeteoWeatherPlugin()
+	plugin.apiURL = server.URL
+	plugin.geocodeURL = server.URL
+	plugin.now = func() time.Time { return now }
+	plugin.Initialize(map[string]interface{}{"city": "Bengaluru,IN"})
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	weather := data.(*WeatherData)
+	if weather.Temperature != 28 || weather.Icon != "⛅" || weather.Condition != "Partly cloudy" {
+		t.Errorf("Expected partly cloudy at 28°C, got %+v", weather)
+	}
+	if len(weather.Hourly) != 4 || !weather.Hourly[0].Time.Equal(time.Date(2026, 10, 16, 14, 0, 0, 0, ist)) {
+		t.Fatalf("Expected the hours from the current one on, got %+v", weather.Hourly)
+	}
+	if last := weather.Hourly[3]; last.Precipitation != "rain" || last.Temperature != 24 {
+		t.Errorf("Expected rain at 17:00, got %+v", last)
+	}
+
+	if len(weather.Daily) != 2 || weather.Daily[1].High != 31 || weather.Daily[1].Low != 19 || weather.Daily[1].Icon != "☀" {
+		t.Errorf("Expected a daily forecast, got %+v", weather.Daily)
+	}
+
+	if pill := formatWeatherPill(weather, "Bengaluru,IN", now); pill != "⛅ 28°C → 🌧 rain at 17:00 (Bengaluru,IN)" {
+		t.Errorf("Expected now and the coming rain, got '%s'", pill)
+	}
+	if pill := formatWeatherPill(&WeatherData{Temperature: 14, Icon: "🌧"}, "Berlin,DE", now); pill != "🌧 14°C (Berlin,DE)" {
+		t.Errorf("Expected just now without a forecast, got '%s'", pill)
+	}
+
+	// The coordinates are looked up once per city
+	plugin.Fetch(context.Background())
+	if geocodes != 1 {
+		t.Errorf("Expected one geocoding request, got %d", geocodes)
+	}
+	plugin.Initialize(map[string]interface{}{"city": "Atlantis"})
+	if data, err := plugin.Fetch(context.Background()); err == nil || data.(*WeatherData).Temperature != 28 {
+		t.Errorf("Expected an unknown city to fail with the last data, got %+v, %v", data, err)
+	}
+}
+
+func TestWeatherOutlook(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	hour := func(h int, icon, precipitation string) WeatherHour {
+		return WeatherHour{Time: time.Date(2026, 10, 16, h, 0, 0, 0, time.UTC), Icon: icon, Precipitation: precipitation}
+	}
+
+	for _, tc := range []struct {
+		hourly []WeatherHour
+		want   string
+	}{
+		{[]WeatherHour{hour(9, "🌧", "rain"), hour(10, "🌧", "rain"), hour(11, "☁", "")}, "☁ dry at 11:00"},
+		{[]WeatherHour{hour(9, "☀", ""), hour(12, "⛈", "storms")}, "⛈ storms at 12:00"},
+		{[]WeatherHour{hour(9, "☀", ""), hour(22, "❄", "snow")}, ""},
+		{[]WeatherHour{hour(11, "🌧", "rain")}, ""},
+	} {
+		if got := weatherOutlook(tc.hourly, now); got != tc.want {
+			t.Errorf("Expected '%s', got '%s'", tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OnCallShift is who is currently on call for a schedule
+type OnCallShift struct {
+	Schedule   string
+	Recipients []string
+}
+
+// OnCallAlert is an open alert or incident
+type OnCallAlert struct {
+	ID           string
+	TinyID       string
+	Message      string
+	Priority     string // P1 (critical) to P5 (informational), when the backend has priorities
+	Owner        string
+	Acknowledged bool
+	Resolved     bool
+	CreatedAt    time.Time
+	URL          string
+}
+
+// OnCallStatus is who is on call and the alerts they are looking at
+type OnCallStatus struct {
+	OnCall []OnCallShift
+	Alerts []OnCallAlert
+}
+
+// OpsgeniePlugin shows who is on call for the configured schedules and the open alerts,
+// most urgent first, from Opsgenie
+type OpsgeniePlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	apiKey      string
+	region      string
+	schedules   []string
+	apiURL      string
+	client      *http.Client
+	lastData    *OnCallStatus
+	rateLimitTracker
+}
+
+// opsgenieAlertLimit caps how many open alerts are listed
+const opsgenieAlertLimit = 20
+
+// NewOpsgeniePlugin creates a new Opsgenie plugin
+func NewOpsgeniePlugin() *OpsgeniePlugin {
+	return &OpsgeniePlugin{
+		id:          "opsgenie",
+		pluginType:  "on-call",
+		name:        "Opsgenie",
+		version:     "1.0.0",
+		description: "Shows who is on call and open alerts from Opsgenie",
+		author:      "GoDay Team",
+		apiKey:      os.Getenv("OPSGENIE_API_KEY"),
+		region:      "us",
+		apiURL:      "https://api.opsgenie.com",
+		client:      &http.Client{Timeout: 10 * time.Second},
+		lastData:    &OnCallStatus{},
+	}
+}
+
+// GetID returns the plugin ID
+func (op *OpsgeniePlugin) GetID() string {
+	return op.id
+}
+
+// GetType returns the plugin type
+func (op *OpsgeniePlugin) GetType() string {
+	return op.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (op *OpsgeniePlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        op.name,
+		Version:     op.version,
+		Description: op.description,
+		Author:      op.author,
+		Type:        op.pluginType,
+		Config: map[string]string{
+			"has_api_key": fmt.Sprintf("%t", op.apiKey != ""),
+			"region":      op.region,
+			"schedules":   strings.Join(op.schedules, ","),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (op *OpsgeniePlugin) Initialize(config map[string]interface{}) error {
+	if apiKey, ok := config["api_key"].(string); ok && apiKey != "" {
+		op.apiKey = apiKey
+	}
+	if region, ok := config["region"].(string); ok && region != "" {
+		switch strings.ToLower(region) {
+		case "us":
+			op.region, op.apiURL = "us", "https://api.opsgenie.com"
+		case "eu":
+			op.region, op.apiURL = "eu", "https://api.eu.opsgenie.com"
+		default:
+			return fmt.Errorf("unknown Opsgenie region %q (use us or eu)", region)
+		}
+	}
+	op.schedules = nil
+	for _, schedule := range configStringList(config["schedules"]) {
+		if schedule = strings.TrimSpace(schedule); schedule != "" {
+			op.schedules = append(op.schedules, schedule)
+		}
+	}
+	return nil
+}
+
+// Fetch returns the on-call recipients of the configured schedules, or of every enabled
+// schedule when none are configured, and the open alerts
+func (op *OpsgeniePlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if op.apiKey == "" {
+		return op.lastData, fmt.Errorf("Opsgenie API key not configured (widgets.pagerduty.api_key or OPSGENIE_API_KEY)")
+	}
+
+	var onCalls struct {
+		Data []struct {
+			Parent struct {
+				ID      string `json:"id"`
+				Name    string `json:"name"`
+				Enabled bool   `json:"enabled"`
+			} `json:"_parent"`
+			OnCallRecipients []string `json:"onCallRecipients"`
+		} `json:"data"`
+	}
+	if err := op.get(ctx, "/v2/schedules/on-calls?flat=true", &onCalls); err != nil {
+		return op.lastData, fmt.Errorf("on-calls: %w", err)
+	}
+
+	status := &OnCallStatus{}
+	for _, schedule := range onCalls.Data {
+		if !op.wantSchedule(schedule.Parent.ID, schedule.Parent.Name, schedule.Parent.Enabled) {
+			continue
+		}
+		status.OnCall = append(status.OnCall, OnCallShift{
+			Schedule:   schedule.Parent.Name,
+			Recipients: schedule.OnCallRecipients,
+		})
+	}
+
+	var alerts struct {
+		Data []struct {
+			ID           string    `json:"id"`
+			TinyID       string    `json:"tinyId"`
+			Message      string    `json:"message"`
+			Priority     string    `json:"priority"`
+			Owner        string    `json:"owner"`
+			Acknowledged bool      `json:"acknowledged"`
+			CreatedAt    time.Time `json:"createdAt"`
+		} `json:"data"`
+	}
+	query := url.Values{
+		"query": {"status:open"},
+		"limit": {fmt.Sprint(opsgenieAlertLimit)},
+		"sort":  {"createdAt"},
+		"order": {"desc"},
+	}
+	if err := op.get(ctx, "/v2/alerts?"+query.Encode(), &alerts); err != nil {
+		return op.lastData, fmt.Errorf("alerts: %w", err)
+	}
+
+	appURL := "https://app.opsgenie.com"
+	if op.region == "eu" {
+		appURL = "https://app.eu.opsgenie.com"
+	}
+	for _, alert := range alerts.Data {
+		status.Alerts = append(status.Alerts, OnCallAlert{
+			ID:           alert.ID,
+			TinyID:       alert.TinyID,
+			Message:      alert.Message,
+			Priority:     alert.Priority,
+			Owner:        alert.Owner,
+			Acknowledged: alert.Acknowledged,
+			CreatedAt:    alert.CreatedAt,
+			URL:          appURL + "/alert/detail/" + url.PathEscape(alert.ID) + "/details",
+		})
+	}
+	// Most urgent first; the API already returns the newest first within a priority
+	sort.SliceStable(status.Alerts, func(i, j int) bool {
+		return status.Alerts[i].Priority < status.Alerts[j].Priority
+	})
+
+	op.lastData = status
+	return status, nil
+}
+
+// wantSchedule reports whether a schedule is one of the configured ones, matched by name
+// or id, or any enabled schedule when none are configured
+func (op *OpsgeniePlugin) wantSchedule(id, name string, enabled bool) bool {
+	if len(op.schedules) == 0 {
+		return enabled
+	}
+	for _, schedule := range op.schedules {
+		if schedule == id || strings.EqualFold(schedule, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// get performs an Opsgenie API request and decodes the JSON response
+func (op *OpsgeniePlugin) get(ctx context.Context, path string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", op.apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "GenieKey "+op.apiKey)
+
+	resp, err := op.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	op.observeRateLimit(resp)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("Opsgenie API returned status %d: %s", resp.StatusCode, apiErr.Message)
+		}
+		return fmt.Errorf("Opsgenie API returned status %d", resp.StatusCode)
+	}
+	return json.Unmarshal(body, target)
+}
+
+// Cleanup performs cleanup
+func (op *OpsgeniePlugin) Cleanup() error {
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpsgeniePluginFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "GenieKey test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"Key format is not valid!","took":0.001,"requestId":"x"}`)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/schedules/on-calls":
+			fmt.Fprint(w, `{"data":[
+				{"_parent":{"id":"s1","name":"Platform","enabled":true},"onCallRecipients":["alice@example.com"]},
+				{"_parent":{"id":"s2","name":"Payments","enabled":true},"onCallRecipients":["bob@example.com","carol@example.com"]},
+				{"_parent":{"id":"s3","name":"Legacy","enabled":false},"onCallRecipients":[]}]}`)
+		case "/v2/alerts":
+			if r.URL.Query().Get("query") != "status:open" {
+				t.Errorf("Expected only open alerts to be asked for, got '%s'", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"data":[
+				{"id":"a2","tinyId":"12","message":"Disk almost full","priority":"P3","acknowledged":true,
+				 "owner":"alice@example.com","createdAt":"2026-10-16T08:00:00Z"},
+				{"id":"a1","tinyId":"11","message":"Checkout down","priority":"P1","acknowledged":false,
+				 "createdAt":"2026-10-16T07:00:00Z"}]}`)
+		}
+	}))
+	defer server.Close()
+
+	plugin := NewOpsgeniePlugin()
+	if err := plugin.Initialize(map[string]interface{}{"api_key": "test-key"}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	plugin.apiURL = server.URL
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	status := data.(*OnCallStatus)
+	if len(status.OnCall) != 2 || status.OnCall[1].Schedule != "Payments" || len(status.OnCall[1].Recipients) != 2 {
+		t.Errorf("Expected the two enabled schedules, got %+v", status.OnCall)
+	}
+	if len(status.Alerts) != 2 || status.Alerts[0].TinyID != "11" {
+		t.Fatalf("Expected the P1 alert first, got %+v", status.Alerts)
+	}
+	if !strings.HasSuffix(status.Alerts[0].URL, "/alert/detail/a1/details") {
+		t.Errorf("Expected a link to the alert, got '%s'", status.Alerts[0].URL)
+	}
+
+	// Configured schedules are matched by name or id
+	plugin.Initialize(map[string]interface{}{"schedules": []interface{}{"payments", "s3"}})
+	data, _ = plugin.Fetch(context.Background())
+	if onCall := data.(*OnCallStatus).OnCall; len(onCall) != 2 || onCall[0].Schedule != "Payments" || onCall[1].Schedule != "Legacy" {
+		t.Errorf("Expected only the configured schedules, got %+v", onCall)
+	}
+
+	plugin.apiKey = "wrong"
+	if data, err := plugin.Fetch(context.Background()); err == nil || !strings.Contains(err.Error(), "Key format is not valid") || len(data.(*OnCallStatus).Alerts) != 2 {
+		t.Errorf("Expected a rejected key to fail with Opsgenie's message and the last data, got %v", err)
+	}
+}
+
+func TestOpsgeniePluginConfig(t *testing.T) {
+	t.Setenv("OPSGENIE_API_KEY", "")
+	plugin := NewOpsgeniePlugin()
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected a missing API key to fail")
+	}
+	if err := plugin.Initialize(map[string]interface{}{"region": "EU"}); err != nil || plugin.apiURL != "https://api.eu.opsgenie.com" {
+		t.Errorf("Expected the EU API, got '%s', %v", plugin.apiURL, err)
+	}
+	if err := plugin.Initialize(map[string]interface{}{"region": "apac"}); err == nil {
+		t.Error("Expected an unknown region to fail")
+	}
+
+	cfg := &Config{}
+	if cfg.ConfiguredWidgets()["pagerduty"] {
+		t.Error("Expected no on-call backend by default")
+	}
+	cfg.Widgets.PagerDuty.Provider = "opsgenie"
+	if !cfg.ConfiguredWidgets()["pagerduty"] {
+		t.Error("Expected the selected provider to fill the tile")
+	}
+}
+
+func TestUpdateOnCallWidget(t *testing.T) {
+	wm := NewWidgetManager()
+	wm.UpdateOnCallWidget(&OnCallStatus{
+		OnCall: []OnCallShift{{Schedule: "Platform"}},
+		Alerts: []OnCallAlert{{TinyID: "11", Message: "Checkout down", Priority: "P1", Acknowledged: true}},
+	})
+
+	widget := wm.Widgets["pagerduty"]
+	if widget.Count != 1 || len(widget.Items) != 2 {
+		t.Fatalf("Expected one schedule and one alert, got %+v", widget)
+	}
+	if widget.Items[0].Subtitle != "On call: nobody" {
+		t.Errorf("Expected an empty rotation to say so, got '%s'", widget.Items[0].Subtitle)
+	}
+	if widget.Items[1].Title != "P1 #11 Checkout down" || widget.Items[1].Status != "🔴" || !strings.HasPrefix(widget.Items[1].Subtitle, "acked") {
+		t.Errorf("Expected the acknowledged P1 alert, got %+v", widget.Items[1])
+	}
+}
@@ -33,14 +33,18 @@ type OSRMTrafficPlugin struct {
 	origin      LocationConfig
 	destination LocationConfig
 	isReversed  bool
+	geocodeURL  string
+	routeURL    string
 	client      *http.Client
 }
 
 // NewOSRMTrafficPlugin creates a new OSRM traffic plugin (no API key required)
 func NewOSRMTrafficPlugin() *OSRMTrafficPlugin {
 	return &OSRMTrafficPlugin{
-		id:     "osrm_traffic",
-		client: &http.Client{Timeout: 30 * time.Second},
+		id:         "osrm_traffic",
+		geocodeURL: "https://nominatim.openstreetmap.org",
+		routeURL:   "https://router.project-osrm.org",
+		client:     &http.Client{Timeout: 30 * time.Second},
 	}
 }
 
@@ -57,12 +61,12 @@ func (o *OSRMTrafficPlugin) GetType() string {
 // Initialize sets up the plugin with configuration
 func (o *OSRMTrafficPlugin) Initialize(config map[string]interface{}) error {
 	// Parse origin configuration
-	if err := o.parseLocationConfig("origin", config, &o.origin); err != nil {
+	if err := parseLocationConfig("origin", config, &o.origin); err != nil {
 		return err
 	}
 
 	// Parse destination configuration
-	if err := o.parseLocationConfig("destination", config, &o.destination); err != nil {
+	if err := parseLocationConfig("destination", config, &o.destination); err != nil {
 		return err
 	}
 
@@ -71,21 +75,24 @@ func (o *OSRMTrafficPlugin) Initialize(config map[string]interface{}) error {
 }
 
 // parseLocationConfig parses location configuration from config map
-func (o *OSRMTrafficPlugin) parseLocationConfig(key string, config map[string]interface{}, location *LocationConfig) error {
+func parseLocationConfig(key string, config map[string]interface{}, location *LocationConfig) error {
 	if locationData, ok := config[key]; ok {
 		switch v := locationData.(type) {
 		case string:
 			// Simple string address
+			if strings.TrimSpace(v) == "" {
+				return fmt.Errorf("%s address is empty", key)
+			}
 			location.Address = v
 		case map[string]interface{}:
 			// Complex configuration with lat/lng or address
 			if address, hasAddress := v["address"].(string); hasAddress {
 				location.Address = address
 			}
-			if lat, hasLat := v["latitude"].(float64); hasLat {
+			if lat, hasLat := configFloat(v["latitude"]); hasLat {
 				location.Latitude = lat
 			}
-			if lng, hasLng := v["longitude"].(float64); hasLng {
+			if lng, hasLng := configFloat(v["longitude"]); hasLng {
 				location.Longitude = lng
 			}
 			if name, hasName := v["name"].(string); hasName {
@@ -94,10 +101,13 @@ func (o *OSRMTrafficPlugin) parseLocationConfig(key string, config map[string]in
 
 			// Validate that we have either address or lat/lng
 			hasCoords := location.Latitude != 0 && location.Longitude != 0
-			hasAddress := location.Address != ""
+			hasAddress := strings.TrimSpace(location.Address) != ""
 			if !hasCoords && !hasAddress {
 				return fmt.Errorf("%s must have either 'address' or 'latitude'+'longitude'", key)
 			}
+			if location.Latitude < -90 || location.Latitude > 90 || location.Longitude < -180 || location.Longitude > 180 {
+				return fmt.Errorf("%s coordinates %g,%g are out of range", key, location.Latitude, location.Longitude)
+			}
 		default:
 			return fmt.Errorf("invalid %s configuration: must be string or object", key)
 		}
@@ -151,7 +161,7 @@ func (o *OSRMTrafficPlugin) geocodeLocation(location string) (lat, lon string, e
 // tryGeocoding performs a single geocoding attempt
 func (o *OSRMTrafficPlugin) tryGeocoding(location string) (lat, lon string, err error) {
 	// Use Nominatim for geocoding (free OpenStreetMap service)
-	baseURL := "https://nominatim.openstreetmap.org/search"
+	baseURL := o.geocodeURL + "/search"
 	params := url.Values{}
 	params.Add("q", location)
 	params.Add("format", "json")
@@ -293,7 +303,7 @@ func (o *OSRMTrafficPlugin) Fetch(ctx context.Context) (interface{}, error) {
 
 // getRoute makes a single OSRM API call for a specific route
 func (o *OSRMTrafficPlugin) getRoute(ctx context.Context, fromLon, fromLat, toLon, toLat string) (*OSRMResponse, error) {
-	baseURL := "https://router.project-osrm.org/route/v1/driving"
+	baseURL := o.routeURL + "/route/v1/driving"
 	coordinates := fmt.Sprintf("%s,%s;%s,%s", fromLon, fromLat, toLon, toLat)
 	apiURL := fmt.Sprintf("%s/%s?overview=false&alternatives=false&steps=false", baseURL, coordinates)
 
@@ -347,10 +357,15 @@ func (o *OSRMTrafficPlugin) formatDuration(seconds int) string {
 
 // getLocationShortName extracts a readable short name from full address
 func (o *OSRMTrafficPlugin) getLocationShortName(address string) string {
-	// Extract meaningful name from full address
-	parts := strings.Split(address, ",")
+	// Extract meaningful name from full address, skipping empty parts as in ", , Whitefield"
+	var parts []string
+	for _, part := range strings.Split(address, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
 	if len(parts) > 0 {
-		firstPart := strings.TrimSpace(parts[0])
+		firstPart := parts[0]
 
 		// If the first part looks like a building/complex name, use it
 		if len(firstPart) > 0 && !strings.Contains(strings.ToLower(firstPart), "road") &&
@@ -361,7 +376,7 @@ func (o *OSRMTrafficPlugin) getLocationShortName(address string) string {
 
 		// If first part is a road/street, try to use a landmark from the second part
 		if len(parts) > 1 {
-			secondPart := strings.TrimSpace(parts[1])
+			secondPart := parts[1]
 			if len(secondPart) > 0 && !strings.Contains(strings.ToLower(secondPart), "bengaluru") &&
 				!strings.Contains(strings.ToLower(secondPart), "karnataka") {
 				return secondPart
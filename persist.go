@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// writeFileAtomic replaces path with data so that readers, other goday instances and a
+// crash mid-write only ever see the old or the new file, never a partial one. The data
+// goes to a uniquely named temporary file next to path, is flushed to disk, and is then
+// renamed over path. As with os.WriteFile, perm only applies to a new file: an existing
+// one keeps its mode, and a symlink, say into a dotfiles repository, stays a symlink to
+// the file that is replaced.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	// Removing the temporary file fails harmlessly once it has been renamed
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// withFileLock runs fn while holding an exclusive advisory lock on path, so read-modify-
+// write updates from several goday instances do not lose each other's changes. The lock
+// is kept in path.lock, which is left in place for the next writer.
+func withFileLock(path string, fn func() error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := lockFile(file, true); err != nil {
+		return fmt.Errorf("locking %s: %w", path, err)
+	}
+	defer unlockFile(file)
+	return fn()
+}
+
+// errAlreadyRunning is returned by AcquireInstanceLock while another dashboard runs
+var errAlreadyRunning = errors.New("goday is already running")
+
+// InstanceLock marks the running dashboard, so a second one can ask what to do
+type InstanceLock struct {
+	file *os.File
+}
+
+// InstanceLockPath returns the single-instance lock file: ~/.goday/goday.lock
+func InstanceLockPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".goday", "goday.lock")
+}
+
+// AcquireInstanceLock takes the single-instance lock for the life of the process. While
+// another dashboard holds it, it returns errAlreadyRunning and that dashboard's pid, if
+// known. The operating system drops the lock when the process exits, even on a crash.
+func AcquireInstanceLock(path string) (*InstanceLock, int, error) {
+	if path == "" {
+		return nil, 0, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, 0, err
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := lockFile(file, false); err != nil {
+		data, _ := io.ReadAll(file)
+		file.Close()
+		pid, _ := strconv.Atoi(strings.TrimSpace(string(data)))
+		return nil, pid, errAlreadyRunning
+	}
+	// The pid is only informational; the lock itself is what counts
+	if err := file.Truncate(0); err == nil {
+		file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	}
+	return &InstanceLock{file: file}, 0, nil
+}
+
+// Release gives up the single-instance lock
+func (l *InstanceLock) Release() {
+	if l == nil {
+		return
+	}
+	unlockFile(l.file)
+	l.file.Close()
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "state.json")
+	if err := writeFileAtomic(path, []byte("one"), 0600); err != nil {
+		t.Fatalf("writeFileAtomic failed: %v", err)
+	}
+	if err := writeFileAtomic(path, []byte("two"), 0600); err != nil {
+		t.Fatalf("writeFileAtomic failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "two" {
+		t.Errorf("Expected the file to be replaced, got '%s'", data)
+	}
+	if info, err := os.Stat(path); err != nil || (runtime.GOOS != "windows" && info.Mode().Perm() != 0600) {
+		t.Errorf("Expected mode 0600, got %v", info.Mode())
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("Expected no temporary files left behind, got %d entries", len(entries))
+	}
+}
+
+func TestWithFileLock(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("advisory locks are not used on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "counter")
+	// Without the lock, concurrent read-modify-write updates would lose increments
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := withFileLock(path, func() error {
+				data, _ := os.ReadFile(path)
+				count, _ := strconv.Atoi(string(data))
+				return writeFileAtomic(path, []byte(strconv.Itoa(count+1)), 0644)
+			})
+			if err != nil {
+				t.Errorf("withFileLock failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if data, _ := os.ReadFile(path); string(data) != "20" {
+		t.Errorf("Expected every update to be kept, got %s", data)
+	}
+}
+
+func TestAcquireInstanceLock(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("advisory locks are not used on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "goday.lock")
+	first, _, err := AcquireInstanceLock(path)
+	if err != nil {
+		t.Fatalf("AcquireInstanceLock failed: %v", err)
+	}
+
+	if _, pid, err := AcquireInstanceLock(path); !errors.Is(err, errAlreadyRunning) || pid != os.Getpid() {
+		t.Errorf("Expected the running dashboard and its pid, got %d, %v", pid, err)
+	}
+
+	first.Release()
+	second, _, err := AcquireInstanceLock(path)
+	if err != nil {
+		t.Errorf("Expected the lock to be free once released, got %v", err)
+	}
+	second.Release()
+}
+
+func TestWriteFileAtomicKeepsModeAndSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes and symlinks differ on Windows")
+	}
+	dir := t.TempDir()
+	target := filepath.Join(dir, "dotfiles", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(target, []byte("token: secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "config.yaml")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeFileAtomic(link, []byte("token: rotated\n"), 0644); err != nil {
+		t.Fatalf("writeFileAtomic failed: %v", err)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("Expected the config to stay a symlink, got %v", info.Mode())
+	}
+	if data, _ := os.ReadFile(target); string(data) != "token: rotated\n" {
+		t.Errorf("Expected the symlink's target to be replaced, got '%s'", data)
+	}
+	if info, err := os.Stat(target); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the config to stay 0600, got %v", info.Mode())
+	}
+}
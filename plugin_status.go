@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pluginStatusRow is one scheduled plugin in the plugin status view
+type pluginStatusRow struct {
+	Widget   string
+	Plugin   string
+	Interval time.Duration // configured refresh interval
+	Current  time.Duration // interval in use, stretched when the budget runs low
+	Budget   string        // remaining rate limit or quota, if known
+	Low      bool
+}
+
+// pluginStatusRows lists the widgets whose refresh is driven by a plugin, sorted by widget
+func pluginStatusRows(scheduler *Scheduler) []pluginStatusRow {
+	var rows []pluginStatusRow
+	for _, task := range scheduler.GetTasks() {
+		plugin, ok := task.Provider.(Plugin)
+		if !ok || plugin == nil {
+			continue
+		}
+
+		row := pluginStatusRow{
+			Widget:   task.ID,
+			Plugin:   plugin.GetMetadata().Name,
+			Interval: task.Interval,
+			Current:  scheduler.GetInterval(task.ID, task.Interval),
+			Budget:   "—",
+		}
+		if limited, ok := plugin.(RateLimited); ok {
+			if rl, known := limited.RateLimit(); known {
+				row.Budget = rl.String()
+				row.Low = rl.NearExhaustion()
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Widget < rows[j].Widget })
+	return rows
+}
+
+// formatInterval formats a refresh interval compactly, e.g. 90s as "1m30s" and 1h as "1h"
+func formatInterval(d time.Duration) string {
+	d = d.Round(time.Second)
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// renderPluginStatus renders the plugin status overlay opened with p
+func renderPluginStatus(rows []pluginStatusRow) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colorTitle)
+	headerStyle := lipgloss.NewStyle().Foreground(colorMuted).Bold(true)
+	warnStyle := lipgloss.NewStyle().Foreground(colorWarn)
+	helpStyle := lipgloss.NewStyle().Foreground(colorMuted).Italic(true)
+
+	row := func(widget, plugin, refresh, budget string) string {
+		return fmt.Sprintf("%-10s %-22s %-16s %s", widget, plugin, refresh, budget)
+	}
+
+	lines := []string{titleStyle.Render("Plugin Status"), "", headerStyle.Render(row("Widget", "Plugin", "Refresh", "Budget"))}
+	if len(rows) == 0 {
+		lines = append(lines, helpStyle.Render("No scheduled plugins"))
+	}
+	for _, r := range rows {
+		refresh := formatInterval(r.Current)
+		if r.Current != r.Interval {
+			refresh += " (was " + formatInterval(r.Interval) + ")"
+		}
+		line := row(r.Widget, r.Plugin, refresh, r.Budget)
+		if r.Low {
+			line = warnStyle.Render(line + " ⚠")
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", helpStyle.Render("Refreshes slow down when less than 10% of a budget is left • Esc close"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+}
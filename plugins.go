@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"time"
+	"io"
+	"math"
+	"net/http"
 )
 
 // Plugin represents a generic plugin interface for all widget types
@@ -54,6 +57,66 @@ type NewsPlugin interface {
 	GetSupportedTags() []string
 }
 
+// CalendarSource is implemented by calendar plugins that render their events for the calendar widget
+type CalendarSource interface {
+	Plugin
+
+	// FormatEventsForDisplay returns the last fetched events as widget items
+	FormatEventsForDisplay() []WidgetItem
+
+	// Events returns the last fetched events
+	Events() []CalendarEvent
+}
+
+// configStringList reads a list of strings from plugin config, accepting both
+// []string (set from Go) and []interface{} (decoded from YAML)
+func configStringList(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		var list []string
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				list = append(list, s)
+			}
+		}
+		return list
+	case string:
+		if v != "" {
+			return []string{v}
+		}
+	}
+	return nil
+}
+
+// configFloat reads a finite number from plugin config; YAML decodes whole numbers
+// such as `latitude: 12` as int
+func configFloat(value interface{}) (float64, bool) {
+	var f float64
+	switch v := value.(type) {
+	case float64:
+		f = v
+	case int:
+		f = float64(v)
+	default:
+		return 0, false
+	}
+	return f, !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// mergePluginConfig returns base with the keys from overrides applied on top
+func mergePluginConfig(base, overrides map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(overrides))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged
+}
+
 // PluginRegistry manages all registered plugins
 type PluginRegistry struct {
 	plugins    map[string]Plugin
@@ -161,9 +224,8 @@ func (pr *PluginRegistry) ListPlugins() []PluginMetadata {
 
 // PluginManager handles plugin lifecycle and execution
 type PluginManager struct {
-	registry  *PluginRegistry
-	scheduler *PluginScheduler
-	config    *PluginConfig
+	registry *PluginRegistry
+	config   *PluginConfig
 }
 
 // PluginConfig holds configuration for all plugins
@@ -171,38 +233,11 @@ type PluginConfig struct {
 	Plugins map[string]map[string]interface{} `yaml:"plugins"`
 }
 
-// PluginScheduler manages scheduled execution of plugins
-type PluginScheduler struct {
-	tasks   map[string]*PluginTask
-	stopCh  chan struct{}
-	running bool
-}
-
-// PluginTask represents a scheduled plugin execution
-type PluginTask struct {
-	ID       string
-	Plugin   Plugin
-	Interval time.Duration
-	LastRun  time.Time
-	NextRun  time.Time
-	Context  context.Context
-	Cancel   context.CancelFunc
-}
-
 // NewPluginManager creates a new plugin manager
 func NewPluginManager(config *PluginConfig) *PluginManager {
 	return &PluginManager{
-		registry:  NewPluginRegistry(),
-		scheduler: NewPluginScheduler(),
-		config:    config,
-	}
-}
-
-// NewPluginScheduler creates a new plugin scheduler
-func NewPluginScheduler() *PluginScheduler {
-	return &PluginScheduler{
-		tasks:  make(map[string]*PluginTask),
-		stopCh: make(chan struct{}),
+		registry: NewPluginRegistry(),
+		config:   config,
 	}
 }
 
@@ -224,42 +259,13 @@ func (pm *PluginManager) RegisterPlugin(plugin Plugin) error {
 	return nil
 }
 
-// SchedulePlugin schedules a plugin for periodic execution
-func (pm *PluginManager) SchedulePlugin(pluginID string, interval time.Duration) error {
-	plugin, exists := pm.registry.GetPlugin(pluginID)
-	if !exists {
-		return fmt.Errorf("plugin %s not found", pluginID)
-	}
-
-	ctx, cancel := context.WithCancel(context.Background())
-	task := &PluginTask{
-		ID:       pluginID,
-		Plugin:   plugin,
-		Interval: interval,
-		LastRun:  time.Now(),
-		NextRun:  time.Now().Add(interval),
-		Context:  ctx,
-		Cancel:   cancel,
-	}
-
-	pm.scheduler.AddTask(task)
-	return nil
-}
-
 // GetRegistry returns the plugin registry
 func (pm *PluginManager) GetRegistry() *PluginRegistry {
 	return pm.registry
 }
 
-// GetScheduler returns the plugin scheduler
-func (pm *PluginManager) GetScheduler() *PluginScheduler {
-	return pm.scheduler
-}
-
 // Cleanup shuts down the plugin manager
 func (pm *PluginManager) Cleanup() error {
-	pm.scheduler.Stop()
-
 	// Cleanup all plugins
 	for _, plugin := range pm.registry.plugins {
 		if err := plugin.Cleanup(); err != nil {
@@ -270,86 +276,28 @@ func (pm *PluginManager) Cleanup() error {
 	return nil
 }
 
-// AddTask adds a task to the scheduler
-func (ps *PluginScheduler) AddTask(task *PluginTask) {
-	ps.tasks[task.ID] = task
-}
-
-// RemoveTask removes a task from the scheduler
-func (ps *PluginScheduler) RemoveTask(taskID string) {
-	if task, exists := ps.tasks[taskID]; exists {
-		task.Cancel()
-		delete(ps.tasks, taskID)
-	}
-}
-
-// Start starts the plugin scheduler
-func (ps *PluginScheduler) Start() {
-	if ps.running {
-		return
-	}
-	ps.running = true
-
-	go ps.run()
-}
-
-// Stop stops the plugin scheduler
-func (ps *PluginScheduler) Stop() {
-	if !ps.running {
-		return
+// fetchJSON fetches an API endpoint and decodes its JSON response, naming the service
+// in errors
+func fetchJSON(ctx context.Context, client *http.Client, service, endpoint string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return err
 	}
-
-	close(ps.stopCh)
-	ps.running = false
-
-	// Cancel all tasks
-	for _, task := range ps.tasks {
-		task.Cancel()
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
 	}
-}
+	defer resp.Body.Close()
 
-// run is the main scheduler loop
-func (ps *PluginScheduler) run() {
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ps.stopCh:
-			return
-		case now := <-ticker.C:
-			ps.checkAndExecuteTasks(now)
-		}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
 	}
-}
-
-// checkAndExecuteTasks checks for due tasks and executes them
-func (ps *PluginScheduler) checkAndExecuteTasks(now time.Time) {
-	for _, task := range ps.tasks {
-		if now.After(task.NextRun) || now.Equal(task.NextRun) {
-			go ps.executeTask(task, now)
-		}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("%s rejected the API key", service)
 	}
-}
-
-// executeTask executes a plugin task
-func (ps *PluginScheduler) executeTask(task *PluginTask, now time.Time) {
-	defer func() {
-		if r := recover(); r != nil {
-			fmt.Printf("Plugin %s panicked: %v\n", task.ID, r)
-		}
-	}()
-
-	// Update timing
-	task.LastRun = now
-	task.NextRun = now.Add(task.Interval)
-
-	// Execute plugin
-	ctx, cancel := context.WithTimeout(task.Context, 30*time.Second)
-	defer cancel()
-
-	_, err := task.Plugin.Fetch(ctx)
-	if err != nil {
-		fmt.Printf("Plugin %s execution failed: %v\n", task.ID, err)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", service, resp.StatusCode)
 	}
+	return json.Unmarshal(body, target)
 }
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatNewsItem(t *testing.T) {
+	for _, tc := range []struct {
+		news     NewsItem
+		subtitle string
+		status   string
+	}{
+		{NewsItem{Source: "hackernews", Author: "pg", Points: 120}, "pg • HN • 120 pts", ""},
+		{NewsItem{Source: "devto", Author: "ben"}, "ben • Dev.to", ""},
+		{NewsItem{Source: "rss", Author: "Go Blog", Feed: "Go Blog"}, "Go Blog", ""},
+		{NewsItem{Source: "producthunt", Points: 42, Author: "maker"}, "▲ 42 • Product Hunt • maker", ""},
+		{NewsItem{Source: "stackoverflow-reputation", Author: "+25 today"}, "+25 today", "🏆"},
+		{NewsItem{Source: "lobsters", Author: "jcs"}, "jcs", ""},
+	} {
+		item := formatNewsItem(tc.news)
+		if item.Subtitle != tc.subtitle || item.Status != tc.status {
+			t.Errorf("Expected '%s' %q for %s, got '%s' %q", tc.subtitle, tc.status, tc.news.Source, item.Subtitle, item.Status)
+		}
+	}
+
+	if item := formatNewsItem(NewsItem{Source: "mastodon-mention", Author: "@me", CreatedAt: time.Now().Unix()}); item.Status != "💬" {
+		t.Errorf("Expected mentions to be flagged, got %+v", item)
+	}
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// pomodoroDefaultWork is the length of a focus session without widgets.pomodoro.work
+	pomodoroDefaultWork = 25 * time.Minute
+	// pomodoroDefaultShortBreak follows each session without widgets.pomodoro.short_break
+	pomodoroDefaultShortBreak = 5 * time.Minute
+	// pomodoroDefaultLongBreak follows every few sessions without widgets.pomodoro.long_break
+	pomodoroDefaultLongBreak = 15 * time.Minute
+	// pomodoroDefaultLongBreakEvery is how many sessions come before a long break
+	pomodoroDefaultLongBreakEvery = 4
+	// pomodoroLogDays is how long completed sessions are kept in the log
+	pomodoroLogDays = 90
+)
+
+// Pomodoro phases
+const (
+	pomodoroIdle  = ""
+	pomodoroWork  = "work"
+	pomodoroBreak = "break"
+)
+
+// PomodoroSession is a completed focus session
+type PomodoroSession struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// PomodoroStatus is what the Pomodoro tile shows
+type PomodoroStatus struct {
+	Phase     string // pomodoroIdle, pomodoroWork or pomodoroBreak
+	Paused    bool
+	Remaining time.Duration // of the current phase
+	Work      time.Duration // length of the next focus session, shown while idle
+	Today     []PomodoroSession
+}
+
+// pomodoroTickMsg counts the timer down; ticks of an earlier run are dropped
+type pomodoroTickMsg struct{ run int }
+
+// Pomodoro is a focus timer alternating work sessions with short and long breaks.
+// A break starts when a session ends; the next session starts with f.
+type Pomodoro struct {
+	work, shortBreak, longBreak time.Duration
+	longBreakEvery              int
+	notify                      bool
+	logPath                     string // empty keeps completed sessions in memory only
+
+	phase    string
+	started  time.Time     // start of the current phase
+	endsAt   time.Time     // end of the current phase while running
+	left     time.Duration // rest of the current phase while paused
+	paused   bool
+	streak   int // sessions completed since the last long break
+	run      int // bumped whenever a new tick chain starts, so the previous one stops
+	sessions []PomodoroSession
+}
+
+// PomodoroLogPath returns where completed sessions are kept: ~/.goday/pomodoro.json
+func PomodoroLogPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".goday", "pomodoro.json")
+}
+
+// NewPomodoro creates the timer configured by widgets.pomodoro; it returns nil
+// without widgets.pomodoro.work
+func NewPomodoro(cfg *Config) (*Pomodoro, error) {
+	if cfg == nil || cfg.Widgets.Pomodoro.Work == "" {
+		return nil, nil
+	}
+	settings := cfg.Widgets.Pomodoro
+	p := &Pomodoro{
+		work:           pomodoroDefaultWork,
+		shortBreak:     pomodoroDefaultShortBreak,
+		longBreak:      pomodoroDefaultLongBreak,
+		longBreakEvery: pomodoroDefaultLongBreakEvery,
+		notify:         settings.Notify,
+	}
+	for _, length := range []struct {
+		name, value string
+		into        *time.Duration
+	}{
+		{"work", settings.Work, &p.work},
+		{"short_break", settings.ShortBreak, &p.shortBreak},
+		{"long_break", settings.LongBreak, &p.longBreak},
+	} {
+		if length.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(length.value)
+		if err != nil || d < time.Minute {
+			return nil, fmt.Errorf("invalid widgets.pomodoro.%s %q: must be at least 1m", length.name, length.value)
+		}
+		*length.into = d
+	}
+	if settings.LongBreakEvery < 0 {
+		return nil, fmt.Errorf("invalid widgets.pomodoro.long_break_every %d", settings.LongBreakEvery)
+	} else if settings.LongBreakEvery > 0 {
+		p.longBreakEvery = settings.LongBreakEvery
+	}
+	if settings.Log {
+		p.logPath = PomodoroLogPath()
+		p.sessions = loadPomodoroLog(p.logPath)
+	}
+	return p, nil
+}
+
+// Running reports whether the timer is counting down
+func (p *Pomodoro) Running() bool {
+	return p != nil && p.phase != pomodoroIdle && !p.paused
+}
+
+// Toggle starts a session when idle, and otherwise pauses or resumes the current phase
+func (p *Pomodoro) Toggle(now time.Time) {
+	switch {
+	case p.phase == pomodoroIdle:
+		p.start(pomodoroWork, p.work, now)
+	case p.paused:
+		p.paused = false
+		p.endsAt = now.Add(p.left)
+		p.run++
+	default:
+		p.paused = true
+		p.left = p.endsAt.Sub(now)
+	}
+}
+
+// Skip ends the current phase early: a skipped session is not counted and goes on to
+// its break, a skipped break leaves the timer idle
+func (p *Pomodoro) Skip(now time.Time) {
+	switch p.phase {
+	case pomodoroWork:
+		p.startBreak(now)
+	case pomodoroBreak:
+		p.phase = pomodoroIdle
+		p.paused = false
+	}
+}
+
+// Advance ends the current phase once its time is up and returns which phase ended,
+// or pomodoroIdle while it is still running. A completed session is counted and
+// followed by its break.
+func (p *Pomodoro) Advance(now time.Time) string {
+	if !p.Running() || now.Before(p.endsAt) {
+		return pomodoroIdle
+	}
+	ended := p.phase
+	switch ended {
+	case pomodoroWork:
+		p.sessions = append(p.sessions, PomodoroSession{Start: p.started, End: now})
+		p.streak++
+		p.startBreak(now)
+	case pomodoroBreak:
+		p.phase = pomodoroIdle
+	}
+	return ended
+}
+
+// start begins a phase of length d
+func (p *Pomodoro) start(phase string, d time.Duration, now time.Time) {
+	p.phase = phase
+	p.started = now
+	p.endsAt = now.Add(d)
+	p.paused = false
+	p.run++
+}
+
+// startBreak begins the break after a session, a long one every longBreakEvery sessions
+func (p *Pomodoro) startBreak(now time.Time) {
+	if p.streak >= p.longBreakEvery {
+		p.streak = 0
+		p.start(pomodoroBreak, p.longBreak, now)
+		return
+	}
+	p.start(pomodoroBreak, p.shortBreak, now)
+}
+
+// Remaining returns how long the current phase has left
+func (p *Pomodoro) Remaining(now time.Time) time.Duration {
+	if p.paused {
+		return p.left
+	}
+	if left := p.endsAt.Sub(now); left > 0 {
+		return left
+	}
+	return 0
+}
+
+// Today returns the sessions completed on now's local day
+func (p *Pomodoro) Today(now time.Time) []PomodoroSession {
+	var today []PomodoroSession
+	day := workloadDayKey(now)
+	for _, session := range p.sessions {
+		if workloadDayKey(session.End) == day {
+			today = append(today, session)
+		}
+	}
+	return today
+}
+
+// Status returns what the tile shows at now
+func (p *Pomodoro) Status(now time.Time) PomodoroStatus {
+	status := PomodoroStatus{Phase: p.phase, Paused: p.paused, Work: p.work, Today: p.Today(now)}
+	if p.phase != pomodoroIdle {
+		status.Remaining = p.Remaining(now)
+	}
+	return status
+}
+
+// Pill shows the countdown for the header, e.g. "🍅 18:42" or "☕ 04:12"; it is empty
+// while idle
+func (p *Pomodoro) Pill(now time.Time) string {
+	if p == nil || p.phase == pomodoroIdle {
+		return ""
+	}
+	pill := "🍅 " + formatCountdown(p.Remaining(now))
+	if p.phase == pomodoroBreak {
+		pill = "☕ " + formatCountdown(p.Remaining(now))
+	}
+	if p.paused {
+		pill += " ⏸"
+	}
+	return pill
+}
+
+// Notification returns the desktop notification for the end of a phase
+func (p *Pomodoro) Notification(ended string, now time.Time) (title, body string) {
+	if ended == pomodoroWork {
+		return "Pomodoro done", fmt.Sprintf("%d today; take a %s break", len(p.Today(now)), formatMinutes(p.Remaining(now)))
+	}
+	return "Break over", "Press f to start the next pomodoro"
+}
+
+// formatCountdown shows d as mm:ss, rounded up so that 00:00 means done
+func formatCountdown(d time.Duration) string {
+	seconds := int((d + time.Second - 1) / time.Second)
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
+// formatMinutes shows d in whole minutes, e.g. "25m"
+func formatMinutes(d time.Duration) string {
+	return fmt.Sprintf("%dm", int(d.Round(time.Minute)/time.Minute))
+}
+
+// tickPomodoro counts the timer down after interval
+func tickPomodoro(run int, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg { return pomodoroTickMsg{run: run} })
+}
+
+// pomodoroTickInterval is how long the countdown waits before its next tick: a second,
+// or in low power until the next whole minute left, so it still ends on time
+func pomodoroTickInterval(remaining time.Duration, lowPower bool) time.Duration {
+	if !lowPower {
+		return time.Second
+	}
+	if wait := remaining % time.Minute; wait > 0 {
+		return wait
+	}
+	return time.Minute
+}
+
+// pomodoroLog is the file completed sessions are kept in
+type pomodoroLog struct {
+	Sessions []PomodoroSession `json:"sessions"`
+}
+
+// loadPomodoroLog reads the sessions kept in path; a missing or unreadable file has none
+func loadPomodoroLog(path string) []PomodoroSession {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var saved pomodoroLog
+	if json.Unmarshal(data, &saved) != nil {
+		return nil
+	}
+	return saved.Sessions
+}
+
+// RecordPomodoroSession appends a completed session to the log in path, dropping
+// sessions older than pomodoroLogDays
+func RecordPomodoroSession(path string, session PomodoroSession) error {
+	// Another dashboard may have logged a session since this one started
+	return withFileLock(path, func() error {
+		oldest := session.End.AddDate(0, 0, -pomodoroLogDays)
+		var kept []PomodoroSession
+		for _, saved := range loadPomodoroLog(path) {
+			if saved.End.After(oldest) {
+				kept = append(kept, saved)
+			}
+		}
+		data, err := json.MarshalIndent(pomodoroLog{Sessions: append(kept, session)}, "", "  ")
+		if err != nil {
+			return err
+		}
+		return writeFileAtomic(path, data, 0600)
+	})
+}
+
+// recordPomodoroCmd logs the session just completed off the UI goroutine; without
+// widgets.pomodoro.log it returns nil
+func (p *Pomodoro) recordPomodoroCmd() tea.Cmd {
+	if p.logPath == "" || len(p.sessions) == 0 {
+		return nil
+	}
+	path, session := p.logPath, p.sessions[len(p.sessions)-1]
+	return func() tea.Msg {
+		// A session that cannot be logged is still counted for today
+		RecordPomodoroSession(path, session)
+		return nil
+	}
+}
+
+// updatePomodoro shows the timer in its tile and keeps it ticking while it runs
+func (m *Model) updatePomodoro() tea.Cmd {
+	now := time.Now()
+	m.widgetManager.UpdatePomodoroWidget(m.pomodoro.Status(now))
+	m.syncTile("pomodoro")
+	if !m.pomodoro.Running() {
+		return nil
+	}
+	return tickPomodoro(m.pomodoro.run, pomodoroTickInterval(m.pomodoro.Remaining(now), m.lowPower(now)))
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestPomodoro(t *testing.T, work string) *Pomodoro {
+	t.Helper()
+	cfg := &Config{}
+	cfg.Widgets.Pomodoro.Work = work
+	cfg.Widgets.Pomodoro.LongBreakEvery = 2
+	p, err := NewPomodoro(cfg)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return p
+}
+
+func TestPomodoroCycle(t *testing.T) {
+	p := newTestPomodoro(t, "25m")
+	start := time.Date(2026, 3, 6, 9, 0, 0, 0, time.Local)
+
+	p.Toggle(start)
+	if !p.Running() || p.Pill(start.Add(time.Minute)) != "🍅 24:00" {
+		t.Errorf("Expected a running session showing 🍅 24:00, got %q", p.Pill(start.Add(time.Minute)))
+	}
+	if ended := p.Advance(start.Add(24 * time.Minute)); ended != pomodoroIdle {
+		t.Errorf("Expected the session to still run, got %q ended", ended)
+	}
+
+	done := start.Add(25 * time.Minute)
+	if ended := p.Advance(done); ended != pomodoroWork {
+		t.Errorf("Expected the session to end, got %q", ended)
+	}
+	if p.phase != pomodoroBreak || p.Remaining(done) != 5*time.Minute {
+		t.Errorf("Expected a 5m break, got %s of %q", p.Remaining(done), p.phase)
+	}
+	if today := p.Today(done); len(today) != 1 || !today[0].Start.Equal(start) {
+		t.Errorf("Expected one session completed today, got %v", today)
+	}
+
+	if ended := p.Advance(done.Add(5 * time.Minute)); ended != pomodoroBreak || p.Running() {
+		t.Errorf("Expected the break to end and the timer to stop, got %q", ended)
+	}
+
+	// The second session is followed by the long break
+	second := done.Add(10 * time.Minute)
+	p.Toggle(second)
+	p.Advance(second.Add(25 * time.Minute))
+	if left := p.Remaining(second.Add(25 * time.Minute)); left != 15*time.Minute {
+		t.Errorf("Expected a 15m long break after 2 sessions, got %s", left)
+	}
+}
+
+func TestPomodoroPauseAndSkip(t *testing.T) {
+	p := newTestPomodoro(t, "25m")
+	start := time.Date(2026, 3, 6, 9, 0, 0, 0, time.Local)
+
+	p.Toggle(start)
+	run := p.run
+	p.Toggle(start.Add(10 * time.Minute))
+	later := start.Add(time.Hour)
+	if p.Running() || p.Pill(later) != "🍅 15:00 ⏸" {
+		t.Errorf("Expected a paused session with 15m left, got %q", p.Pill(later))
+	}
+	if ended := p.Advance(later); ended != pomodoroIdle {
+		t.Errorf("Expected a paused session not to end, got %q", ended)
+	}
+
+	p.Toggle(later)
+	if p.run == run || p.Remaining(later.Add(5*time.Minute)) != 10*time.Minute {
+		t.Errorf("Expected resuming to start a new tick run with 10m left, got %s", p.Remaining(later.Add(5*time.Minute)))
+	}
+
+	p.Skip(later)
+	if p.phase != pomodoroBreak || len(p.Today(later)) != 0 {
+		t.Errorf("Expected skipping to start the break without counting the session, got %q and %d", p.phase, len(p.Today(later)))
+	}
+	p.Skip(later)
+	if p.phase != pomodoroIdle || p.Pill(later) != "" {
+		t.Errorf("Expected skipping the break to leave the timer idle, got %q", p.Pill(later))
+	}
+}
+
+func TestNewPomodoro(t *testing.T) {
+	if p, err := NewPomodoro(&Config{}); p != nil || err != nil {
+		t.Errorf("Expected no timer without widgets.pomodoro.work, got %v, %v", p, err)
+	}
+	cfg := &Config{}
+	cfg.Widgets.Pomodoro.Work = "25m"
+	cfg.Widgets.Pomodoro.ShortBreak = "30s"
+	if _, err := NewPomodoro(cfg); err == nil {
+		t.Error("Expected a break shorter than a minute to be rejected")
+	}
+}
+
+func TestRecordPomodoroSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pomodoro.json")
+	end := time.Date(2026, 3, 6, 9, 25, 0, 0, time.UTC)
+
+	old := PomodoroSession{Start: end.AddDate(0, 0, -pomodoroLogDays-1), End: end.AddDate(0, 0, -pomodoroLogDays-1)}
+	if err := RecordPomodoroSession(path, old); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := RecordPomodoroSession(path, PomodoroSession{Start: end.Add(-25 * time.Minute), End: end}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	sessions := loadPomodoroLog(path)
+	if len(sessions) != 1 || !sessions[0].End.Equal(end) {
+		t.Errorf("Expected only the recent session to be kept, got %v", sessions)
+	}
+}
+
+func TestPomodoroWidget(t *testing.T) {
+	wm := NewWidgetManager()
+	wm.InitializeWidgets(nil)
+	start := time.Date(2026, 3, 6, 9, 0, 0, 0, time.Local)
+
+	wm.UpdatePomodoroWidget(PomodoroStatus{
+		Phase:     pomodoroBreak,
+		Remaining: 4*time.Minute + 12*time.Second,
+		Today:     []PomodoroSession{{Start: start, End: start.Add(25 * time.Minute)}},
+	})
+	widget := wm.Widgets["pomodoro"]
+	if len(widget.Items) != 3 || widget.Items[0].Title != "Break 04:12" {
+		t.Errorf("Expected the break countdown, a summary and one session, got %v", widget.Items)
+	}
+	if widget.Count != 1 || widget.Items[1].Subtitle != "25m focused" {
+		t.Errorf("Expected 1 session and 25m focused, got %d and %q", widget.Count, widget.Items[1].Subtitle)
+	}
+}
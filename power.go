@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// powerCheckInterval is how often battery mode looks at the power source
+const powerCheckInterval = 2 * time.Minute
+
+// LowPower halves every poll frequency, always or only while on battery, to reduce
+// wakeups on laptops
+type LowPower struct {
+	mode      string // off, on or battery
+	toggled   bool   // switched by hand with the b key
+	toggledOn bool
+	onBattery func() bool
+	battery   bool // power source at the last check
+	checked   time.Time
+}
+
+// NewLowPower creates the low power setting from the config
+func NewLowPower(cfg *Config) (*LowPower, error) {
+	lp := &LowPower{mode: "off", onBattery: onBattery}
+	if cfg == nil || cfg.Schedule.LowPower == "" {
+		return lp, nil
+	}
+	switch mode := strings.ToLower(cfg.Schedule.LowPower); mode {
+	case "off", "on", "battery":
+		lp.mode = mode
+	default:
+		return lp, fmt.Errorf("invalid low_power %q (expected off, on or battery)", cfg.Schedule.LowPower)
+	}
+	return lp, nil
+}
+
+// Active reports whether polling is slowed down at t
+func (lp *LowPower) Active(t time.Time) bool {
+	if lp.toggled {
+		return lp.toggledOn
+	}
+	switch lp.mode {
+	case "on":
+		return true
+	case "battery":
+		if lp.checked.IsZero() || t.Sub(lp.checked) >= powerCheckInterval {
+			lp.battery = lp.onBattery()
+			lp.checked = t
+		}
+		return lp.battery
+	}
+	return false
+}
+
+// Toggle switches low power by hand, overriding the config until toggled again. It
+// reports whether low power is now on.
+func (lp *LowPower) Toggle(now time.Time) bool {
+	lp.toggledOn = !lp.Active(now)
+	lp.toggled = true
+	return lp.toggledOn
+}
+
+// onBattery reports whether the machine runs on battery, as readBattery finds it.
+// Desktops without a battery, and systems it cannot be read on, count as plugged in.
+func onBattery() bool {
+	battery := readBattery()
+	return battery.Present && !battery.PluggedIn
+}
+
+// linuxOnBattery reports whether a battery under the sysfs power supply directory is
+// discharging
+func linuxOnBattery(dir string) bool {
+	battery := linuxBattery(dir)
+	return battery.Present && !battery.PluggedIn
+}
+
+// SetLowPower sets when poll frequencies are halved; nil polls at the configured rate
+func (s *Scheduler) SetLowPower(lp *LowPower) {
+	s.lowPower = lp
+}
+
+// LowPower reports whether polling is slowed down at t
+func (s *Scheduler) LowPower(t time.Time) bool {
+	return s.lowPower != nil && s.lowPower.Active(t)
+}
+
+// lowPower reports whether the dashboard saves wakeups at t: besides the slower polls,
+// the fetch spinner stands still and countdowns tick once a minute instead of every second
+func (m Model) lowPower(t time.Time) bool {
+	return m.scheduler != nil && m.scheduler.LowPower(t)
+}
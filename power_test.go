@@ -0,0 +1,119 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLowPower(t *testing.T) {
+	cfg := &Config{}
+	cfg.Schedule.LowPower = "battery"
+	lp, err := NewLowPower(cfg)
+	if err != nil {
+		t.Fatalf("NewLowPower failed: %v", err)
+	}
+	checks := 0
+	battery := true
+	lp.onBattery = func() bool {
+		checks++
+		return battery
+	}
+
+	scheduler := NewScheduler()
+	scheduler.AddTask("news", 10*time.Minute, nil)
+	scheduler.SetLowPower(lp)
+	now := time.Now()
+	if got := scheduler.GetInterval("news", time.Minute); got != 20*time.Minute {
+		t.Errorf("Expected the interval to double on battery, got %v", got)
+	}
+	if got := scheduler.GetInterval("builds", time.Minute); got != 2*time.Minute {
+		t.Errorf("Expected the fallback to double on battery, got %v", got)
+	}
+
+	// The power source is checked at most every few minutes
+	battery = false
+	if !lp.Active(now) || checks != 1 {
+		t.Errorf("Expected the cached battery state, got %d checks", checks)
+	}
+	if lp.Active(now.Add(powerCheckInterval+time.Second)) || checks != 2 {
+		t.Errorf("Expected plugging in to be noticed, got %d checks", checks)
+	}
+
+	// The b key overrides the config either way
+	if !lp.Toggle(now) || !scheduler.LowPower(now.Add(time.Hour)) {
+		t.Error("Expected toggling to turn low power on while plugged in")
+	}
+	if lp.Toggle(now) || scheduler.LowPower(now) {
+		t.Error("Expected toggling again to turn it off")
+	}
+
+	cfg.Schedule.LowPower = "sometimes"
+	if _, err := NewLowPower(cfg); err == nil {
+		t.Error("Expected an unknown mode to fail")
+	}
+	if lp, _ := NewLowPower(nil); lp.Active(now) {
+		t.Error("Expected low power to be off by default")
+	}
+}
+
+func TestLinuxOnBattery(t *testing.T) {
+	dir := t.TempDir()
+	supply := func(name, kind, status string) {
+		os.MkdirAll(filepath.Join(dir, name), 0755)
+		os.WriteFile(filepath.Join(dir, name, "type"), []byte(kind+"\n"), 0644)
+		if status != "" {
+			os.WriteFile(filepath.Join(dir, name, "status"), []byte(status+"\n"), 0644)
+		}
+	}
+
+	supply("AC", "Mains", "")
+	supply("BAT0", "Battery", "Charging")
+	if linuxOnBattery(dir) {
+		t.Error("Expected a charging battery to count as plugged in")
+	}
+	supply("BAT0", "Battery", "Discharging")
+	if !linuxOnBattery(dir) {
+		t.Error("Expected a discharging battery to count as on battery")
+	}
+	if linuxOnBattery(filepath.Join(dir, "missing")) {
+		t.Error("Expected a machine without power supplies to count as plugged in")
+	}
+}
+
+func TestLowPowerStopsSecondTimers(t *testing.T) {
+	lp, _ := NewLowPower(nil)
+	m := benchmarkModel(120, 40)
+	m.scheduler = NewScheduler()
+	m.scheduler.SetLowPower(lp)
+	now := time.Now()
+	lp.Toggle(now)
+
+	if cmd := m.startFetch("news", 1); cmd != nil {
+		t.Error("Expected no spinner tick in low power")
+	}
+	if got := m.fetchSpinner("news"); got != fetchingMarker {
+		t.Errorf("Expected %q while fetching in low power, got %q", fetchingMarker, got)
+	}
+	model, cmd := m.Update(m.spinner.Tick())
+	if m = model.(Model); cmd != nil {
+		t.Error("Expected a pending spinner tick to stop in low power")
+	}
+
+	// The pomodoro countdown ticks on the whole minutes left, so it still ends on time
+	if got := pomodoroTickInterval(24*time.Minute+30*time.Second, true); got != 30*time.Second {
+		t.Errorf("Expected 30s to the next whole minute, got %v", got)
+	}
+	if got := pomodoroTickInterval(24*time.Minute, true); got != time.Minute {
+		t.Errorf("Expected a minute on a whole minute, got %v", got)
+	}
+	if got := pomodoroTickInterval(24*time.Minute+30*time.Second, false); got != time.Second {
+		t.Errorf("Expected a second outside low power, got %v", got)
+	}
+
+	lp.Toggle(now)
+	if got := m.fetchSpinner("news"); got != m.spinner.View() {
+		t.Errorf("Expected the spinner back once low power is off, got %q", got)
+	}
+}
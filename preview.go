@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// defaultPreviewFrom is when tomorrow's preview appears, in minutes since midnight
+	defaultPreviewFrom = 17 * 60
+	// defaultPreviewBuffer is the slack added to the commute for the leave-by time
+	defaultPreviewBuffer = 10 * time.Minute
+)
+
+// MorningPreview is the evening card about tomorrow: the first meeting, when to leave
+// for it and the weather
+type MorningPreview struct {
+	Meeting CalendarEvent
+	Commute time.Duration // expected trip when leaving; zero without traffic history
+	LeaveBy time.Time
+	Weather string // the forecast for the meeting, or else the weather now
+}
+
+// Previewer shows tomorrow's preview from the evening on, and keeps the commute
+// history it estimates the trip from
+type Previewer struct {
+	from    int // minutes since midnight
+	buffer  time.Duration
+	history *TrafficHistory
+}
+
+// NewPreviewer creates the previewer from the config. It returns nil when the preview
+// is turned off with from: off.
+func NewPreviewer(cfg *Config, history *TrafficHistory) (*Previewer, error) {
+	p := &Previewer{from: defaultPreviewFrom, buffer: defaultPreviewBuffer, history: history}
+	if cfg == nil {
+		return p, nil
+	}
+	if cfg.Preview.From == "off" {
+		return nil, nil
+	}
+	if cfg.Preview.From != "" {
+		from, err := parseClock(cfg.Preview.From)
+		if err != nil {
+			return nil, err
+		}
+		p.from = from
+	}
+	if cfg.Preview.Buffer != "" {
+		buffer, err := time.ParseDuration(cfg.Preview.Buffer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid preview buffer %q: %w", cfg.Preview.Buffer, err)
+		}
+		p.buffer = buffer
+	}
+	return p, nil
+}
+
+// RecordCommute adds a trip from origin to destination, fetched at the given time, to
+// the commute history
+func (p *Previewer) RecordCommute(trip *TrafficData, at time.Time) error {
+	if trip == nil || trip.IsReversed {
+		return nil
+	}
+	return p.history.Record(time.Duration(trip.DurationSec)*time.Second, at)
+}
+
+// firstMeetingTomorrow returns the first timed event starting tomorrow; all-day events
+// are skipped
+func firstMeetingTomorrow(events []CalendarEvent, now time.Time) (CalendarEvent, bool) {
+	start := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	end := start.AddDate(0, 0, 1)
+
+	var first CalendarEvent
+	found := false
+	for _, event := range events {
+		if event.StartTime.Before(start) || !event.StartTime.Before(end) {
+			continue
+		}
+		// All-day events start and end at the same time of day, like in the calendar tile
+		if event.StartTime.Format("15:04") == event.EndTime.Format("15:04") {
+			continue
+		}
+		if !found || event.StartTime.Before(first.StartTime) {
+			first, found = event, true
+		}
+	}
+	return first, found
+}
+
+// Preview returns tomorrow's preview, or nil before the evening or without a meeting
+// tomorrow. The commute is estimated for the hour of leaving, which depends on the
+// commute itself, so the estimate for the meeting time is refined once.
+func (p *Previewer) Preview(events []CalendarEvent, weather string, forecast []WeatherHour, now time.Time) *MorningPreview {
+	if now.Hour()*60+now.Minute() < p.from {
+		return nil
+	}
+	meeting, ok := firstMeetingTomorrow(events, now)
+	if !ok {
+		return nil
+	}
+
+	preview := &MorningPreview{Meeting: meeting}
+	if hour, ok := forecastAt(forecast, meeting.StartTime); ok {
+		preview.Weather = fmt.Sprintf("Forecast: %s %d°C, %s", hour.Icon, hour.Temperature, hour.Condition)
+	} else if weather != "" {
+		preview.Weather = "Weather now: " + weather
+	}
+	if commute, ok := p.history.Estimate(meeting.StartTime); ok {
+		if refined, ok := p.history.Estimate(meeting.StartTime.Add(-commute)); ok {
+			commute = refined
+		}
+		preview.Commute = commute
+		preview.LeaveBy = meeting.StartTime.Add(-commute - p.buffer)
+	}
+	return preview
+}
+
+// morningPreview builds tomorrow's preview from the calendar widget's events
+func (m Model) morningPreview(now time.Time) *MorningPreview {
+	if m.preview == nil {
+		return nil
+	}
+	plugin, _ := m.pluginManager.GetRegistry().GetPlugin(m.widgetPlugins["calendar"])
+	calendar, ok := plugin.(CalendarSource)
+	if !ok {
+		return nil
+	}
+	return m.preview.Preview(calendar.Events(), m.weather, m.forecast, now)
+}
+
+// renderMorningPreview renders the preview card shown below the tiles
+func renderMorningPreview(preview *MorningPreview, now time.Time) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colorTitle)
+	mutedStyle := lipgloss.NewStyle().Foreground(colorMuted)
+
+	start := preview.Meeting.StartTime.In(now.Location())
+	meeting := fmt.Sprintf("📅 %s %s", start.Format("15:04"), preview.Meeting.Title)
+	if preview.Meeting.Location != "" {
+		meeting += " • " + preview.Meeting.Location
+	}
+
+	commute := mutedStyle.Render("🚗 No commute history for that time yet")
+	if preview.Commute > 0 {
+		commute = fmt.Sprintf("🚗 ~%s commute • leave by %s",
+			formatInterval(preview.Commute.Round(time.Minute)), preview.LeaveBy.In(now.Location()).Format("15:04"))
+	}
+
+	lines := []string{titleStyle.Render("🌅 Tomorrow, " + start.Format("Mon 02 Jan")), meeting, commute}
+	if preview.Weather != "" {
+		lines = append(lines, preview.Weather)
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("214")).
+		Padding(0, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
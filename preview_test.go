@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMorningPreview(t *testing.T) {
+	now := time.Date(2026, 10, 15, 18, 30, 0, 0, time.Local) // Thursday evening
+	friday := func(hour, minute int) time.Time {
+		return time.Date(2026, 10, 16, hour, minute, 0, 0, time.Local)
+	}
+	events := []CalendarEvent{
+		{Title: "Late standup", StartTime: now.Add(time.Hour), EndTime: now.Add(90 * time.Minute)},
+		{Title: "Design review", Location: "Office", StartTime: friday(10, 0), EndTime: friday(11, 0)},
+		{Title: "Offsite", StartTime: friday(0, 0), EndTime: friday(0, 0).AddDate(0, 0, 1)},
+		{Title: "1:1", StartTime: friday(9, 30), EndTime: friday(10, 0)},
+		{Title: "Planning", StartTime: friday(0, 0).AddDate(0, 0, 3).Add(8 * time.Hour), EndTime: friday(0, 0).AddDate(0, 0, 3).Add(9 * time.Hour)},
+	}
+
+	history := LoadTrafficHistory("")
+	history.Record(40*time.Minute, friday(9, 5).AddDate(0, 0, -7))
+	history.Record(45*time.Minute, friday(8, 40).AddDate(0, 0, -7))
+	cfg := &Config{}
+	cfg.Preview.Buffer = "5m"
+	previewer, err := NewPreviewer(cfg, history)
+	if err != nil {
+		t.Fatalf("NewPreviewer failed: %v", err)
+	}
+
+	if p := previewer.Preview(events, "☀ 24°C (Bengaluru)", nil, now.Add(-2*time.Hour)); p != nil {
+		t.Errorf("Expected no preview before 17:00, got %+v", p)
+	}
+
+	p := previewer.Preview(events, "☀ 24°C (Bengaluru)", nil, now)
+	if p == nil || p.Meeting.Title != "1:1" {
+		t.Fatalf("Expected tomorrow's first timed meeting, got %+v", p)
+	}
+	// Leaving at 09:xx takes 40m, which means leaving at 08:xx, which takes 45m
+	if p.Commute != 45*time.Minute || !p.LeaveBy.Equal(friday(8, 40)) {
+		t.Errorf("Expected a 45m commute and leaving by 08:40, got %v by %s", p.Commute, p.LeaveBy.Format("15:04"))
+	}
+
+	card := renderMorningPreview(p, now)
+	for _, want := range []string{"Tomorrow, Fri 16 Oct", "09:30 1:1", "~45m commute • leave by 08:40", "☀ 24°C"} {
+		if !strings.Contains(card, want) {
+			t.Errorf("Expected the card to contain '%s', got:\n%s", want, card)
+		}
+	}
+
+	// With an hourly forecast the card shows the weather for the meeting
+	forecast := []WeatherHour{
+		{Time: friday(9, 0), Temperature: 19, Condition: "Rain", Icon: "🌧", Precipitation: "rain"},
+		{Time: friday(10, 0), Temperature: 21, Condition: "Overcast", Icon: "☁"},
+	}
+	if p := previewer.Preview(events, "☀ 24°C (Bengaluru)", forecast, now); p == nil || p.Weather != "Forecast: 🌧 19°C, Rain" {
+		t.Errorf("Expected the forecast for 09:30, got %+v", p)
+	}
+
+	// Without history for that hour the card says so
+	empty, _ := NewPreviewer(nil, LoadTrafficHistory(""))
+	if p := empty.Preview(events, "", nil, now); p == nil || p.Commute != 0 || !strings.Contains(renderMorningPreview(p, now), "No commute history") {
+		t.Errorf("Expected a preview without a commute, got %+v", p)
+	}
+
+	cfg.Preview.From = "off"
+	if previewer, err := NewPreviewer(cfg, history); previewer != nil || err != nil {
+		t.Errorf("Expected from: off to turn the preview off, got %v (%v)", previewer, err)
+	}
+	cfg.Preview.From = "5pm"
+	if _, err := NewPreviewer(cfg, history); err == nil {
+		t.Error("Expected an invalid time to fail")
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// productHuntQuery fetches the day's launches ordered by votes
+const productHuntQuery = `query($after: DateTime!) {
+  posts(order: VOTES, postedAfter: $after, first: 20) {
+    edges {
+      node {
+        name
+        tagline
+        url
+        votesCount
+        createdAt
+        topics(first: 5) { edges { node { name slug } } }
+      }
+    }
+  }
+}`
+
+// ProductHuntPlugin shows today's top Product Hunt launches by votes
+type ProductHuntPlugin struct {
+	*BaseNewsPlugin
+	apiURL string
+	token  string
+	now    func() time.Time
+	rateLimitTracker
+}
+
+// NewProductHuntPlugin creates a new Product Hunt plugin
+func NewProductHuntPlugin() *ProductHuntPlugin {
+	base := NewBaseNewsPlugin(
+		"producthunt",
+		"Product Hunt",
+		"1.0.0",
+		"Shows today's top Product Hunt launches",
+		"GoDay Team",
+	)
+	base.supportedTags = []string{"all", "developer-tools", "artificial-intelligence", "productivity", "saas", "open-source"}
+
+	return &ProductHuntPlugin{
+		BaseNewsPlugin: base,
+		apiURL:         "https://api.producthunt.com/v2/api/graphql",
+		token:          os.Getenv("PRODUCTHUNT_TOKEN"),
+		now:            time.Now,
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (ph *ProductHuntPlugin) Initialize(config map[string]interface{}) error {
+	if tags := configStringList(config["tags"]); tags != nil {
+		ph.SetTags(tags)
+	}
+	if currentTag, ok := config["current_tag"].(string); ok {
+		ph.SetCurrentTag(currentTag)
+	}
+	if token, ok := config["producthunt_token"].(string); ok && token != "" {
+		ph.token = token
+	}
+	return nil
+}
+
+// productHuntDayStart returns midnight of the current Product Hunt day, which runs on
+// Pacific time
+func productHuntDayStart(now time.Time) time.Time {
+	pacific, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		pacific = time.FixedZone("PST", -8*60*60)
+	}
+	local := now.In(pacific)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, pacific)
+}
+
+// Fetch retrieves today's launches, most votes first
+func (ph *ProductHuntPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if ph.token == "" {
+		return ph.lastData, fmt.Errorf("Product Hunt token not configured (widgets.news.producthunt.token)")
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"query":     productHuntQuery,
+		"variables": map[string]string{"after": productHuntDayStart(ph.now()).Format(time.RFC3339)},
+	})
+	if err != nil {
+		return ph.lastData, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", ph.apiURL, bytes.NewReader(payload))
+	if err != nil {
+		return ph.lastData, err
+	}
+	req.Header.Set("Authorization", "Bearer "+ph.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := ph.client.Do(req)
+	if err != nil {
+		return ph.lastData, err
+	}
+	defer resp.Body.Close()
+	ph.observeProductHuntLimit(resp.Header)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ph.lastData, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return ph.lastData, fmt.Errorf("Product Hunt API returned status %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Data struct {
+			Posts struct {
+				Edges []struct {
+					Node struct {
+						Name       string    `json:"name"`
+						Tagline    string    `json:"tagline"`
+						URL        string    `json:"url"`
+						VotesCount int       `json:"votesCount"`
+						CreatedAt  time.Time `json:"createdAt"`
+						Topics     struct {
+							Edges []struct {
+								Node struct {
+									Name string `json:"name"`
+									Slug string `json:"slug"`
+								} `json:"node"`
+							} `json:"edges"`
+						} `json:"topics"`
+					} `json:"node"`
+				} `json:"edges"`
+			} `json:"posts"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return ph.lastData, err
+	}
+	if len(response.Errors) > 0 {
+		return ph.lastData, fmt.Errorf("Product Hunt API error: %s", response.Errors[0].Message)
+	}
+
+	var items []NewsItem
+	for _, edge := range response.Data.Posts.Edges {
+		post := edge.Node
+		// Topic slugs such as developer-tools work as news tags
+		var tags, topics []string
+		for _, topic := range post.Topics.Edges {
+			tags = append(tags, topic.Node.Slug)
+			topics = append(topics, topic.Node.Name)
+		}
+
+		items = append(items, NewsItem{
+			Title:       fmt.Sprintf("%s — %s", post.Name, post.Tagline),
+			URL:         post.URL,
+			Points:      post.VotesCount,
+			Author:      strings.Join(topics, ", "),
+			CreatedAt:   post.CreatedAt.Unix(),
+			Source:      "producthunt",
+			Description: post.Tagline,
+			Tags:        tags,
+		})
+	}
+
+	filtered := ph.filterByCurrentTag(items)
+	if len(filtered) > 10 {
+		filtered = filtered[:10]
+	}
+
+	ph.lastData = filtered
+	return filtered, nil
+}
+
+// observeProductHuntLimit records the query complexity budget. Product Hunt spells the
+// headers X-Rate-Limit-* and sends the reset as seconds from now.
+func (ph *ProductHuntPlugin) observeProductHuntLimit(header http.Header) {
+	limit, err := strconv.Atoi(header.Get("X-Rate-Limit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(header.Get("X-Rate-Limit-Remaining"))
+	if err != nil {
+		return
+	}
+	rl := RateLimit{Limit: limit, Remaining: remaining}
+	if seconds, err := strconv.Atoi(header.Get("X-Rate-Limit-Reset")); err == nil {
+		rl.Reset = ph.now().Add(time.Duration(seconds) * time.Second)
+	}
+	ph.recordRateLimit(rl)
+}
+
+// formatProductHuntItem shows the votes and maker of a launch
+func formatProductHuntItem(news NewsItem) WidgetItem {
+	subtitle := fmt.Sprintf("▲ %d • Product Hunt", news.Points)
+	if news.Author != "" {
+		subtitle = fmt.Sprintf("%s • %s", subtitle, news.Author)
+	}
+	return WidgetItem{Title: news.Title, Subtitle: subtitle, URL: news.URL}
+}
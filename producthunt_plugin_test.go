@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestProductHuntPluginFetch(t *testing.T) {
+	var after string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body struct {
+			Variables map[string]string `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		after = body.Variables["after"]
+
+		post := func(name string, votes int, topic string) string {
+			return fmt.Sprintf(`{"node":{"name":%q,"tagline":"Does things","url":"https://www.producthunt.com/posts/%s","votesCount":%d,`+
+				`"createdAt":"2026-10-16T08:01:00Z","topics":{"edges":[{"node":{"name":"Developer Tools","slug":%q}}]}}}`,
+				name, name, votes, topic)
+		}
+		w.Header().Set("X-Rate-Limit-Limit", "6250")
+		w.Header().Set("X-Rate-Limit-Remaining", "6200")
+		w.Header().Set("X-Rate-Limit-Reset", "900")
+		fmt.Fprintf(w, `{"data":{"posts":{"edges":[%s,%s]}}}`, post("Launchly", 512, "developer-tools"), post("Snapdoc", 130, "productivity"))
+	}))
+	defer server.Close()
+
+	plugin := NewProductHuntPlugin()
+	plugin.apiURL = server.URL
+	plugin.now = func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) }
+	if err := plugin.Initialize(map[string]interface{}{"producthunt_token": "token", "current_tag": "all"}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	items := data.([]NewsItem)
+	if len(items) != 2 || items[0].Title != "Launchly — Does things" || items[0].Points != 512 || items[0].Source != "producthunt" {
+		t.Fatalf("Expected today's launches with votes, got %+v", items)
+	}
+	if items[0].Author != "Developer Tools" {
+		t.Errorf("Expected the topics as the author line, got '%s'", items[0].Author)
+	}
+	if start, _ := time.Parse(time.RFC3339, after); !start.Equal(productHuntDayStart(plugin.now())) || start.Hour() != 0 {
+		t.Errorf("Expected posts since midnight Pacific, got '%s'", after)
+	}
+	if rl, ok := plugin.RateLimit(); !ok || rl.Remaining != 6200 || !rl.Reset.Equal(plugin.now().Add(15*time.Minute)) {
+		t.Errorf("Expected the complexity budget to be tracked, got %+v", rl)
+	}
+
+	plugin.SetCurrentTag("productivity")
+	data, _ = plugin.Fetch(context.Background())
+	if items := data.([]NewsItem); len(items) != 1 || items[0].Points != 130 {
+		t.Errorf("Expected the tag to filter by topic, got %+v", items)
+	}
+
+	plugin.token = ""
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected Fetch to fail without a token")
+	}
+}
+
+func TestAggregateNewsPluginSources(t *testing.T) {
+	aggregate := NewAggregateNewsPlugin([]NewsPlugin{NewHackernoonPlugin()})
+	if err := aggregate.Initialize(map[string]interface{}{"sources": []string{"devto", "producthunt"}}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if len(aggregate.sources) != 2 || aggregate.sources[1].GetID() != "producthunt" {
+		t.Errorf("Expected the configured sources to replace the defaults, got %d sources", len(aggregate.sources))
+	}
+	found := false
+	for _, tag := range aggregate.GetSupportedTags() {
+		found = found || tag == "developer-tools"
+	}
+	if !found {
+		t.Error("Expected the Product Hunt topics among the supported tags")
+	}
+
+	if err := aggregate.Initialize(map[string]interface{}{"sources": []string{"mastodon"}}); err == nil {
+		t.Error("Expected an unsupported source to fail")
+	}
+}
@@ -17,9 +17,78 @@ type WeatherProvider struct {
 }
 
 type WeatherData struct {
-	Temperature int    `json:"temp"`
-	Condition   string `json:"condition"`
-	Icon        string `json:"icon"`
+	Temperature int           `json:"temp"`
+	Condition   string        `json:"condition"`
+	Icon        string        `json:"icon"`
+	Hourly      []WeatherHour `json:"hourly,omitempty"` // from the current hour on; empty without a forecast
+	Daily       []WeatherDay  `json:"daily,omitempty"`  // from today on; empty without a forecast
+}
+
+// weatherForecastDays is how many days the weather tile shows
+const weatherForecastDays = 5
+
+// WeatherDay is the forecast for one day
+type WeatherDay struct {
+	Date      time.Time `json:"date"`
+	High      int       `json:"high"`
+	Low       int       `json:"low"`
+	Condition string    `json:"condition"`
+	Icon      string    `json:"icon"`
+}
+
+// WeatherHour is the forecast for the hour starting at Time
+type WeatherHour struct {
+	Time          time.Time `json:"time"`
+	Temperature   int       `json:"temp"`
+	Condition     string    `json:"condition"`
+	Icon          string    `json:"icon"`
+	Precipitation string    `json:"precipitation,omitempty"` // rain, snow or storms; empty when dry
+}
+
+// weatherOutlookHours is how far ahead the header looks for a change in the weather
+const weatherOutlookHours = 12
+
+// forecastAt returns the forecast for the hour containing t
+func forecastAt(hourly []WeatherHour, t time.Time) (WeatherHour, bool) {
+	for _, hour := range hourly {
+		if !t.Before(hour.Time) && t.Before(hour.Time.Add(time.Hour)) {
+			return hour, true
+		}
+	}
+	return WeatherHour{}, false
+}
+
+// weatherOutlook describes the next change between dry and wet weather within the next
+// hours, e.g. "🌧 rain at 17:00", or "" when the weather stays as it is
+func weatherOutlook(hourly []WeatherHour, now time.Time) string {
+	current, ok := forecastAt(hourly, now)
+	if !ok {
+		return ""
+	}
+	for _, hour := range hourly {
+		if !hour.Time.After(current.Time) || hour.Time.Sub(current.Time) > weatherOutlookHours*time.Hour {
+			continue
+		}
+		if hour.Precipitation == current.Precipitation {
+			continue
+		}
+		change := hour.Precipitation
+		if change == "" {
+			change = "dry"
+		}
+		return fmt.Sprintf("%s %s at %s", hour.Icon, change, hour.Time.In(now.Location()).Format("15:04"))
+	}
+	return ""
+}
+
+// formatWeatherPill formats the weather for the header: now, and the next change when
+// the provider has a forecast, e.g. "☀ 28°C → 🌧 rain at 17:00 (Bengaluru)"
+func formatWeatherPill(data *WeatherData, location string, now time.Time) string {
+	pill := fmt.Sprintf("%s %d°C", data.Icon, data.Temperature)
+	if outlook := weatherOutlook(data.Hourly, now); outlook != "" {
+		pill += " → " + outlook
+	}
+	return fmt.Sprintf("%s (%s)", pill, location)
 }
 
 type WeatherResponse struct {
@@ -129,7 +198,10 @@ type NewsItem struct {
 	Author      string   `json:"author"`
 	CreatedAt   int64    `json:"created_at_i"`
 	ObjectID    string   `json:"objectID"`
-	Source      string   // "hackernews" or "devto"
+	Source      string   // e.g. "hackernews", "devto" or "rss"; picks the formatter in newsItemFormatters
+	Feed        string   // label of the RSS/Atom feed the item came from
+	Language    string   // ISO 639-1 language, as declared by the feed or detected; "" if unknown
+	Translated  bool     // Title was translated to English
 	Description string   `json:"description"`
 	Tags        []string `json:"tag_list"`
 }
@@ -171,6 +243,21 @@ func (n *NewsProvider) SetCurrentTag(tag string) {
 	n.CurrentTag = tag
 }
 
+// matchesTags reports whether a title mentions any of the configured tags
+func (n *NewsProvider) matchesTags(title string) bool {
+	if len(n.Tags) == 0 {
+		return true
+	}
+
+	titleLower := strings.ToLower(title)
+	for _, tag := range n.Tags {
+		if strings.Contains(titleLower, strings.ToLower(tag)) {
+			return true
+		}
+	}
+	return false
+}
+
 func (n *NewsProvider) Fetch() ([]NewsItem, error) {
 	var allItems []NewsItem
 
@@ -320,7 +407,11 @@ func (n *NewsProvider) filterByCurrentTag(items []NewsItem) []NewsItem {
 
 // Scheduler manages widget refresh intervals
 type Scheduler struct {
-	tasks map[string]*Task
+	tasks       map[string]*Task
+	quiet       *QuietSchedule    // nil when no quiet time is configured
+	lowPower    *LowPower         // nil polls at the configured rate
+	suspendedAt time.Time         // when the dashboard was suspended to the shell; zero while running
+	priorities  map[string]string // startup fetch priority of widgets that are not normal
 }
 
 type Task struct {
@@ -364,6 +455,14 @@ func (s *Scheduler) UpdateTask(id string) {
 	}
 }
 
+// Ran records that a task was fetched at t; its next fetch is due an interval later
+func (s *Scheduler) Ran(id string, t time.Time) {
+	if task, exists := s.tasks[id]; exists {
+		task.LastRun = t
+		task.NextRun = t.Add(s.GetInterval(id, task.Interval))
+	}
+}
+
 func (s *Scheduler) RemoveTask(id string) {
 	delete(s.tasks, id)
 }
@@ -376,6 +475,25 @@ func (s *Scheduler) GetTasks() []*Task {
 	return tasks
 }
 
+// GetInterval returns the refresh interval of a task, or fallback if it is not scheduled.
+// The interval is stretched while the task's plugin is close to its rate limit, and
+// doubled in low power mode.
+func (s *Scheduler) GetInterval(id string, fallback time.Duration) time.Duration {
+	interval := fallback
+	if task, exists := s.tasks[id]; exists && task.Interval > 0 {
+		interval = task.Interval
+		if limited, ok := task.Provider.(RateLimited); ok {
+			if rl, known := limited.RateLimit(); known {
+				interval = rl.StretchInterval(task.Interval, time.Now())
+			}
+		}
+	}
+	if s.LowPower(time.Now()) {
+		interval *= 2
+	}
+	return interval
+}
+
 func (s *Scheduler) GetNextWakeTime() time.Time {
 	next := s.GetNextTask()
 	if next == nil {
@@ -391,8 +509,8 @@ func ParseTTL(ttlStr string) time.Duration {
 	}
 
 	duration, err := time.ParseDuration(ttlStr)
-	if err != nil {
-		return 600 * time.Second // Default on parse error
+	if err != nil || duration <= 0 {
+		return 600 * time.Second // Default on parse error; "0s" would refresh in a busy loop
 	}
 	return duration
 }
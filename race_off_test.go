@@ -0,0 +1,7 @@
+//go:build !race
+
+package main
+
+// raceEnabled reports whether the tests run with the race detector, which allocates on
+// its own and throws off allocation counts
+const raceEnabled = false
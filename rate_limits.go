@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit is the remaining request budget reported by, or counted for, an upstream API
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RateLimited is implemented by plugins that know their upstream request budget
+type RateLimited interface {
+	// RateLimit returns the last known budget, or false before one has been observed
+	RateLimit() (RateLimit, bool)
+}
+
+// NearExhaustion reports whether less than a tenth of the budget is left
+func (rl RateLimit) NearExhaustion() bool {
+	return rl.Limit > 0 && rl.Remaining*10 < rl.Limit
+}
+
+// StretchInterval lengthens a refresh interval near exhaustion so that the remaining
+// requests last until the budget resets. Each refresh is assumed to cost one request.
+func (rl RateLimit) StretchInterval(interval time.Duration, now time.Time) time.Duration {
+	if !rl.NearExhaustion() || !rl.Reset.After(now) {
+		return interval
+	}
+
+	stretched := rl.Reset.Sub(now)
+	if rl.Remaining > 0 {
+		stretched /= time.Duration(rl.Remaining)
+	}
+	if stretched > interval {
+		return stretched
+	}
+	return interval
+}
+
+// String formats the budget for the plugin status view, e.g. "4812/5000, resets 14:05"
+func (rl RateLimit) String() string {
+	budget := fmt.Sprintf("%d/%d", rl.Remaining, rl.Limit)
+	if !rl.Reset.IsZero() {
+		budget += ", resets " + rl.Reset.Local().Format("15:04")
+	}
+	return budget
+}
+
+// rateLimitFromHeaders reads the X-RateLimit-* headers sent by GitHub, Mastodon and Discord.
+// The reset is either epoch seconds (GitHub, Discord) or an RFC 3339 timestamp (Mastodon).
+func rateLimitFromHeaders(header http.Header) (RateLimit, bool) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	rl := RateLimit{Limit: limit, Remaining: remaining}
+	reset := header.Get("X-RateLimit-Reset")
+	if seconds, err := strconv.ParseFloat(reset, 64); err == nil {
+		rl.Reset = time.Unix(int64(seconds), 0)
+	} else if t, err := time.Parse(time.RFC3339, reset); err == nil {
+		rl.Reset = t
+	}
+	return rl, true
+}
+
+// rateLimitTracker records the latest budget seen in API responses. Plugins embed it
+// to implement RateLimited.
+type rateLimitTracker struct {
+	mu       sync.Mutex
+	limit    RateLimit
+	observed bool
+}
+
+// observeRateLimit records the budget from a response's headers, if it has any
+func (rt *rateLimitTracker) observeRateLimit(resp *http.Response) {
+	if rl, ok := rateLimitFromHeaders(resp.Header); ok {
+		rt.recordRateLimit(rl)
+	}
+}
+
+// recordRateLimit records a budget reported some other way, e.g. in a response body
+func (rt *rateLimitTracker) recordRateLimit(rl RateLimit) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.limit = rl
+	rt.observed = true
+}
+
+// RateLimit returns the last budget seen
+func (rt *rateLimitTracker) RateLimit() (RateLimit, bool) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	return rt.limit, rt.observed
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimitFromHeaders(t *testing.T) {
+	github := http.Header{}
+	github.Set("X-RateLimit-Limit", "5000")
+	github.Set("X-RateLimit-Remaining", "4812")
+	github.Set("X-RateLimit-Reset", "1700000000")
+	rl, ok := rateLimitFromHeaders(github)
+	if !ok || rl.Limit != 5000 || rl.Remaining != 4812 || !rl.Reset.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Expected GitHub budget 4812/5000 with epoch reset, got %+v (ok=%t)", rl, ok)
+	}
+
+	mastodon := http.Header{}
+	mastodon.Set("X-RateLimit-Limit", "300")
+	mastodon.Set("X-RateLimit-Remaining", "299")
+	mastodon.Set("X-RateLimit-Reset", "2024-01-01T12:05:00.000Z")
+	rl, ok = rateLimitFromHeaders(mastodon)
+	if !ok || !rl.Reset.Equal(time.Date(2024, 1, 1, 12, 5, 0, 0, time.UTC)) {
+		t.Errorf("Expected Mastodon RFC 3339 reset, got %+v (ok=%t)", rl, ok)
+	}
+
+	if _, ok := rateLimitFromHeaders(http.Header{}); ok {
+		t.Error("Expected no budget without rate-limit headers")
+	}
+}
+
+func TestRateLimitStretchInterval(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	reset := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		limit    RateLimit
+		expected time.Duration
+	}{
+		{"plenty left", RateLimit{Limit: 5000, Remaining: 4000, Reset: reset}, 5 * time.Minute},
+		{"near exhaustion", RateLimit{Limit: 5000, Remaining: 4, Reset: reset}, 15 * time.Minute},
+		{"exhausted", RateLimit{Limit: 5000, Remaining: 0, Reset: reset}, time.Hour},
+		{"low but enough for the interval", RateLimit{Limit: 5000, Remaining: 400, Reset: reset}, 5 * time.Minute},
+		{"reset already passed", RateLimit{Limit: 5000, Remaining: 0, Reset: now.Add(-time.Minute)}, 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := tt.limit.StretchInterval(5*time.Minute, now); got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+type fakeRateLimitedPlugin struct {
+	*TeamsPlugin
+	limit RateLimit
+}
+
+func (f fakeRateLimitedPlugin) RateLimit() (RateLimit, bool) {
+	return f.limit, true
+}
+
+func TestSchedulerStretchesRateLimitedTasks(t *testing.T) {
+	scheduler := NewScheduler()
+	plugin := fakeRateLimitedPlugin{
+		TeamsPlugin: NewTeamsPlugin(),
+		limit:       RateLimit{Limit: 100, Remaining: 2, Reset: time.Now().Add(time.Hour)},
+	}
+	scheduler.AddTask("teams", 2*time.Minute, plugin)
+	scheduler.AddTask("slack", 20*time.Second, nil)
+
+	if got := scheduler.GetInterval("teams", time.Minute); got < 29*time.Minute {
+		t.Errorf("Expected the interval to stretch to about 30m, got %v", got)
+	}
+	if got := scheduler.GetInterval("slack", time.Minute); got != 20*time.Second {
+		t.Errorf("Expected an unlimited task to keep its interval, got %v", got)
+	}
+
+	rows := pluginStatusRows(scheduler)
+	if len(rows) != 1 || rows[0].Widget != "teams" || !rows[0].Low || rows[0].Current == rows[0].Interval {
+		t.Fatalf("Expected one low, stretched teams row, got %+v", rows)
+	}
+	if !strings.HasPrefix(rows[0].Budget, "2/100") {
+		t.Errorf("Expected budget '2/100, resets ...', got '%s'", rows[0].Budget)
+	}
+	if view := renderPluginStatus(rows); !strings.Contains(view, "(was 2m)") {
+		t.Errorf("Expected the view to show the configured interval, got:\n%s", view)
+	}
+}
+
+func TestWeatherPluginCountsDailyQuota(t *testing.T) {
+	plugin := NewWeatherPlugin("", "")
+	plugin.Initialize(map[string]interface{}{"daily_quota": 50})
+	if _, ok := plugin.RateLimit(); ok {
+		t.Error("Expected no quota before the first call")
+	}
+
+	// Demo mode makes no calls
+	plugin.Fetch(context.Background())
+	if _, ok := plugin.RateLimit(); ok {
+		t.Error("Expected demo data not to count against the quota")
+	}
+
+	now := time.Now()
+	plugin.countCall(now)
+	plugin.countCall(now)
+	rl, ok := plugin.RateLimit()
+	if !ok || rl.Limit != 50 || rl.Remaining != 48 {
+		t.Errorf("Expected 48/50 calls left, got %+v", rl)
+	}
+	if !rl.Reset.After(now) || rl.Reset.Sub(now) > 24*time.Hour {
+		t.Errorf("Expected the quota to reset at the next UTC midnight, got %v", rl.Reset)
+	}
+}
+
+func TestFormatInterval(t *testing.T) {
+	for d, expected := range map[time.Duration]string{
+		20 * time.Second: "20s",
+		5 * time.Minute:  "5m",
+		90 * time.Second: "1m30s",
+		time.Hour:        "1h",
+	} {
+		if got := formatInterval(d); got != expected {
+			t.Errorf("Expected %v to format as '%s', got '%s'", d, expected, got)
+		}
+	}
+}
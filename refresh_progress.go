@@ -0,0 +1,54 @@
+package main
+
+import "fmt"
+
+// refreshDoneMsg reports that a widget's fetch for a manual refresh has finished
+type refreshDoneMsg struct{ widget string }
+
+// RefreshProgress follows a whole-dashboard refresh started with R, so the header can
+// show how many widgets are up to date until every one has been fetched
+type RefreshProgress struct {
+	pending map[string]bool
+	total   int
+}
+
+// NewRefreshProgress creates a tracker with no refresh running
+func NewRefreshProgress() *RefreshProgress {
+	return &RefreshProgress{pending: make(map[string]bool)}
+}
+
+// Start begins a refresh of widgets, replacing one that is still running
+func (p *RefreshProgress) Start(widgets []string) {
+	if p == nil {
+		return
+	}
+	p.pending = make(map[string]bool)
+	for _, widget := range widgets {
+		p.pending[widget] = true
+	}
+	p.total = len(p.pending)
+}
+
+// Done records that widget has been fetched; widgets outside the refresh are ignored
+func (p *RefreshProgress) Done(widget string) {
+	if p == nil {
+		return
+	}
+	delete(p.pending, widget)
+	if len(p.pending) == 0 {
+		p.total = 0
+	}
+}
+
+// Running reports whether a refresh is still waiting for widgets
+func (p *RefreshProgress) Running() bool {
+	return p != nil && len(p.pending) > 0
+}
+
+// Pill returns the header text for a running refresh, e.g. "refreshing 4/11…"
+func (p *RefreshProgress) Pill() string {
+	if !p.Running() {
+		return ""
+	}
+	return fmt.Sprintf("⟳ refreshing %d/%d…", p.total-len(p.pending), p.total)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRefreshProgress(t *testing.T) {
+	p := NewRefreshProgress()
+	if p.Running() || p.Pill() != "" {
+		t.Error("Expected no refresh before R is pressed")
+	}
+
+	p.Start([]string{"weather", "news", "prs"})
+	p.Done("weather")
+	p.Done("weather")
+	p.Done("jira") // not part of the refresh
+	if got := p.Pill(); got != "⟳ refreshing 1/3…" {
+		t.Errorf("Expected one of three widgets done, got '%s'", got)
+	}
+
+	// Pressing R again starts over
+	p.Start([]string{"weather", "news"})
+	if got := p.Pill(); got != "⟳ refreshing 0/2…" {
+		t.Errorf("Expected a fresh count, got '%s'", got)
+	}
+	p.Done("weather")
+	p.Done("news")
+	if p.Running() || p.Pill() != "" {
+		t.Errorf("Expected the pill to go once everything is fetched, got '%s'", p.Pill())
+	}
+
+	var off *RefreshProgress
+	off.Start([]string{"weather"})
+	if off.Running() || off.Pill() != "" {
+		t.Error("Expected a nil tracker to show nothing")
+	}
+}
+
+func TestRefreshProgressInHeader(t *testing.T) {
+	t.Setenv("OPSGENIE_API_KEY", "")
+	t.Setenv("VICTOROPS_API_KEY", "")
+	m := benchmarkModel(220, 60)
+	m.refresh = NewRefreshProgress()
+
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	m = model.(Model)
+	total := len(widgetFetchMsgs())
+	if cmd == nil || !m.refresh.Running() {
+		t.Fatal("Expected R to start a refresh of every widget")
+	}
+	if view := m.View(); !strings.Contains(view, "refreshing 0/") || strings.Contains(view, "R Refresh") {
+		t.Error("Expected the header to show the refresh progress instead of the R hint")
+	}
+
+	// The on-call fetch returns straight away without a backend, and reports it is done
+	model, cmd = m.Update(refreshNowMsg{fetch: fetchOnCallCmd{}})
+	m = model.(Model)
+	for _, msg := range immediateMsgs(cmd) {
+		if done, ok := msg.(refreshDoneMsg); ok {
+			model, _ = m.Update(done)
+			m = model.(Model)
+		}
+	}
+	if got, want := m.refresh.Pill(), fmt.Sprintf("⟳ refreshing 1/%d…", total); got != want {
+		t.Errorf("Expected the on-call widget to count as done, got '%s'", got)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import "time"
+
+// SubtitleAge is where a subtitle shows how long ago its item's Since was: the text
+// before and after the relative time, and how it is written. The relative time itself is
+// not stored, so it can be written again from Since as time passes.
+type SubtitleAge struct {
+	Before  string
+	After   string
+	Compact bool // "2h" by formatAge rather than "2 hours ago" by timeAgo
+}
+
+// Render returns the subtitle with the relative time of since at now
+func (a SubtitleAge) Render(since, now time.Time) string {
+	if a.Compact {
+		return a.Before + formatAge(since, now) + a.After
+	}
+	return a.Before + timeAgo(since, now) + a.After
+}
+
+// withAge returns the item with a subtitle of before, the relative time of its Since at
+// now and after, which the tile keeps up to date
+func (item WidgetItem) withAge(now time.Time, before string, compact bool, after string) WidgetItem {
+	item.Age = &SubtitleAge{Before: before, After: after, Compact: compact}
+	item.Subtitle = item.Age.Render(item.Since, now)
+	return item
+}
+
+// age returns the item with the relative time in its subtitle written again at now, and
+// whether it changed
+func (i WidgetListItem) age(now time.Time) (WidgetListItem, bool) {
+	if i.subtitleAge == nil || i.Since.IsZero() {
+		return i, false
+	}
+	subtitle := i.subtitleAge.Render(i.Since, now)
+	if subtitle == i.Subtitle {
+		return i, false
+	}
+	i.Subtitle = subtitle
+	return i, true
+}
+
+// refreshAges recomputes the relative times of the tile's items at now, so a dashboard
+// left open overnight does not show a commit from last evening as "2 hours ago"
+func (wt *WidgetTile) refreshAges(now time.Time) {
+	changed := false
+	for index, listItem := range wt.list.Items() {
+		item, ok := listItem.(WidgetListItem)
+		if !ok {
+			continue
+		}
+		if item, ok := item.age(now); ok {
+			wt.list.SetItem(index, item)
+			changed = true
+		}
+	}
+	if changed {
+		wt.refilter()
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubtitleAgeRender(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		age      SubtitleAge
+		since    time.Time
+		subtitle string
+	}{
+		{SubtitleAge{Before: "golang/go • "}, now.Add(-2 * time.Hour), "golang/go • 2 hours ago"},
+		{SubtitleAge{Before: "octocat • ", After: " ago", Compact: true}, now.Add(-45 * time.Minute), "octocat • 45m ago"},
+		{SubtitleAge{Before: "P1 • for ", After: " • disk full", Compact: true}, now.Add(-80 * time.Hour), "P1 • for 3d • disk full"},
+	}
+	for _, test := range tests {
+		if subtitle := test.age.Render(test.since, now); subtitle != test.subtitle {
+			t.Errorf("Expected %q, got %q", test.subtitle, subtitle)
+		}
+	}
+}
+
+func TestRefreshAges(t *testing.T) {
+	now := time.Now()
+	tile := NewWidgetTile("commits", "Commits", baseTileWidth, baseTileHeight)
+	tile.UpdateItems([]WidgetItem{
+		WidgetItem{Title: "fix: resolve auth issue", Since: now.Add(-2 * time.Hour)}.withAge(now, "", false, " • goday"),
+		WidgetItem{Title: "Standup", Since: now.Add(-45 * time.Minute)}.withAge(now, "alice • ", true, " ago"),
+		// A subtitle that only happens to read like a relative time is left alone
+		{Title: "docs: update README", Subtitle: "goday • 5m", Since: now.Add(-5 * time.Minute)},
+	})
+
+	// The next morning
+	tile.refreshAges(now.Add(14 * time.Hour))
+	want := []string{"16 hours ago • goday", "alice • 14h ago", "goday • 5m"}
+	for i, listItem := range tile.list.Items() {
+		if item := listItem.(WidgetListItem); item.Subtitle != want[i] {
+			t.Errorf("Expected item %d to read %q, got %q", i, want[i], item.Subtitle)
+		}
+	}
+
+	// Ages keep counting from the time, not from the last subtitle
+	tile.refreshAges(now.Add(26 * time.Hour))
+	if item := tile.list.Items()[0].(WidgetListItem); item.Subtitle != "1 day ago • goday" {
+		t.Errorf("Expected the commit to be a day old, got %q", item.Subtitle)
+	}
+}
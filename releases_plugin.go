@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// releasesPerRepo is how many of a repo's latest releases are compared with the last seen
+	releasesPerRepo = 10
+	// releaseNewFor is how long a release stays marked new after it was first seen
+	releaseNewFor = 7 * 24 * time.Hour
+)
+
+// Release is the latest release or tag of a watched repository
+type Release struct {
+	Repo      string
+	Tag       string
+	Name      string
+	URL       string    // the release notes, or the tag's page for repos without releases
+	Published time.Time // zero for plain tags
+	Previous  string    // tag seen before this one, if it changed since GoDay started watching
+	Newer     int       // releases since Previous, at most releasesPerRepo
+	New       bool      // first seen within releaseNewFor
+	Err       string    // why the releases could not be listed
+}
+
+// seenRelease is the latest tag of a repo as of the last check
+type seenRelease struct {
+	Tag      string    `json:"tag"`
+	Previous string    `json:"previous,omitempty"`
+	Newer    int       `json:"newer,omitempty"`
+	SeenAt   time.Time `json:"seen_at,omitempty"` // zero when it was already out when watching began
+}
+
+// ReleasesStatePath returns where the last seen releases are kept: ~/.goday/releases.json
+func ReleasesStatePath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".goday", "releases.json")
+}
+
+// loadSeenReleases reads the last seen releases by repo; a missing or unreadable file
+// has none, so every repo starts over from its current release
+func loadSeenReleases(path string) map[string]seenRelease {
+	seen := make(map[string]seenRelease)
+	if path == "" {
+		return seen
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &seen)
+	}
+	return seen
+}
+
+// ReleasesPlugin watches GitHub repositories for new releases, remembering the latest
+// one seen of each between runs
+type ReleasesPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	github      githubAPI
+	repos       []string
+	prereleases bool
+	statePath   string                 // file the seen releases are kept in; empty keeps them in memory
+	seen        map[string]seenRelease // as of the last check
+	now         func() time.Time
+	lastData    []Release
+}
+
+// NewReleasesPlugin creates a new release watcher plugin. The token falls back to
+// GITHUB_TOKEN/GH_TOKEN; public repos are read without one.
+func NewReleasesPlugin() *ReleasesPlugin {
+	return &ReleasesPlugin{
+		id:          "releases",
+		pluginType:  "releases",
+		name:        "Releases",
+		version:     "1.0.0",
+		description: "Lists new releases of the repositories you depend on",
+		author:      "GoDay Team",
+		github:      newGitHubAPI(nil),
+		statePath:   ReleasesStatePath(),
+		now:         time.Now,
+	}
+}
+
+// GetID returns the plugin ID
+func (rp *ReleasesPlugin) GetID() string {
+	return rp.id
+}
+
+// GetType returns the plugin type
+func (rp *ReleasesPlugin) GetType() string {
+	return rp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (rp *ReleasesPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        rp.name,
+		Version:     rp.version,
+		Description: rp.description,
+		Author:      rp.author,
+		Type:        rp.pluginType,
+		Config: map[string]string{
+			"repos":       strings.Join(rp.repos, ","),
+			"prereleases": fmt.Sprintf("%t", rp.prereleases),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (rp *ReleasesPlugin) Initialize(config map[string]interface{}) error {
+	if token, ok := config["github_token"].(string); ok && token != "" {
+		rp.github.token = token
+	}
+	if repos := configStringList(config["repos"]); len(repos) > 0 {
+		for _, repo := range repos {
+			if parts := strings.Split(repo, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				return fmt.Errorf("release repo %q must be owner/name", repo)
+			}
+		}
+		rp.repos = repos
+	}
+	if prereleases, ok := config["prereleases"].(bool); ok {
+		rp.prereleases = prereleases
+	}
+	return nil
+}
+
+// githubRelease is a release as the GitHub API returns it
+type githubRelease struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	HTMLURL     string    `json:"html_url"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// Fetch lists the latest release of each repo, newest first, and records any that
+// changed since the last check
+func (rp *ReleasesPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if len(rp.repos) == 0 {
+		return rp.lastData, fmt.Errorf("no repositories configured (widgets.releases.repos)")
+	}
+
+	latest := make([][]Release, len(rp.repos))
+	var wg sync.WaitGroup
+	for i, repo := range rp.repos {
+		wg.Add(1)
+		go func(i int, repo string) {
+			defer wg.Done()
+			latest[i] = rp.latest(ctx, repo)
+		}(i, repo)
+	}
+	wg.Wait()
+
+	now := rp.now()
+	var results []Release
+	record := func() error {
+		if rp.statePath != "" {
+			rp.seen = loadSeenReleases(rp.statePath)
+		} else if rp.seen == nil {
+			rp.seen = make(map[string]seenRelease)
+		}
+		results = nil
+		for i, repo := range rp.repos {
+			release := latest[i][0]
+			if release.Err == "" {
+				last := markSeen(rp.seen[repo], latest[i], now)
+				rp.seen[repo] = last
+				release.Previous = last.Previous
+				release.Newer = last.Newer
+				release.New = !last.SeenAt.IsZero() && now.Sub(last.SeenAt) < releaseNewFor
+			}
+			results = append(results, release)
+		}
+		if rp.statePath == "" {
+			return nil
+		}
+		data, err := json.Marshal(rp.seen)
+		if err != nil {
+			return err
+		}
+		return writeFileAtomic(rp.statePath, data, 0600)
+	}
+	// Another dashboard may have seen the same releases since this one last checked
+	var err error
+	if rp.statePath == "" {
+		err = record()
+	} else {
+		err = withFileLock(rp.statePath, record)
+	}
+	if err != nil {
+		return rp.lastData, err
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].New != results[j].New {
+			return results[i].New
+		}
+		return results[i].Published.After(results[j].Published)
+	})
+	rp.lastData = results
+	return results, nil
+}
+
+// markSeen updates what was last seen of a repo with its latest releases, newest first.
+// A repo checked for the first time has nothing new.
+func markSeen(last seenRelease, releases []Release, now time.Time) seenRelease {
+	tag := releases[0].Tag
+	if last.Tag == "" {
+		return seenRelease{Tag: tag}
+	}
+	if last.Tag == tag {
+		return last
+	}
+	newer := len(releases)
+	for i, release := range releases {
+		if release.Tag == last.Tag {
+			newer = i
+			break
+		}
+	}
+	return seenRelease{Tag: tag, Previous: last.Tag, Newer: newer, SeenAt: now}
+}
+
+// latest returns a repo's latest releases, newest first, skipping drafts and, unless
+// widgets.releases.prereleases is set, prereleases. Repos that only push tags get their
+// latest tag. On failure it returns a single release carrying the error.
+func (rp *ReleasesPlugin) latest(ctx context.Context, repo string) []Release {
+	var listed []githubRelease
+	if err := rp.github.do(ctx, "GET", fmt.Sprintf("/repos/%s/releases?per_page=%d", repo, releasesPerRepo), nil, &listed); err != nil {
+		return []Release{{Repo: repo, Err: err.Error()}}
+	}
+	var releases []Release
+	for _, release := range listed {
+		if release.Draft || (release.Prerelease && !rp.prereleases) {
+			continue
+		}
+		releases = append(releases, Release{
+			Repo:      repo,
+			Tag:       release.TagName,
+			Name:      release.Name,
+			URL:       release.HTMLURL,
+			Published: release.PublishedAt,
+		})
+	}
+	if len(releases) > 0 {
+		return releases
+	}
+
+	var tags []struct {
+		Name string `json:"name"`
+	}
+	if err := rp.github.do(ctx, "GET", fmt.Sprintf("/repos/%s/tags?per_page=1", repo), nil, &tags); err != nil {
+		return []Release{{Repo: repo, Err: err.Error()}}
+	}
+	if len(tags) == 0 {
+		return []Release{{Repo: repo, Err: "no releases or tags yet"}}
+	}
+	return []Release{{
+		Repo: repo,
+		Tag:  tags[0].Name,
+		URL:  fmt.Sprintf("https://github.com/%s/releases/tag/%s", repo, tags[0].Name),
+	}}
+}
+
+// Cleanup performs cleanup
+func (rp *ReleasesPlugin) Cleanup() error {
+	return nil
+}
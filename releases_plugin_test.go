@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeReleases serves acme/tool's releases, newest first, and a repo that only pushes tags
+func fakeReleases(releases *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/tool/releases":
+			fmt.Fprint(w, *releases)
+		case "/repos/acme/lib/releases":
+			fmt.Fprint(w, `[]`)
+		case "/repos/acme/lib/tags":
+			fmt.Fprint(w, `[{"name":"v0.9.0"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Not Found"}`)
+		}
+	}))
+}
+
+func newTestReleasesPlugin(t *testing.T, serverURL string, now *time.Time) *ReleasesPlugin {
+	t.Helper()
+	plugin := NewReleasesPlugin()
+	plugin.github.apiURL = serverURL
+	plugin.github.token = ""
+	plugin.statePath = filepath.Join(t.TempDir(), "releases.json")
+	plugin.now = func() time.Time { return *now }
+	if err := plugin.Initialize(map[string]interface{}{"repos": []string{"acme/tool", "acme/lib", "acme/gone"}}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	return plugin
+}
+
+func TestReleasesPluginFetch(t *testing.T) {
+	releases := `[{"tag_name":"v1.1.0","html_url":"https://github.com/acme/tool/releases/tag/v1.1.0","published_at":"2026-10-01T00:00:00Z"}]`
+	server := fakeReleases(&releases)
+	defer server.Close()
+	now := time.Date(2026, 10, 13, 9, 0, 0, 0, time.UTC)
+	plugin := newTestReleasesPlugin(t, server.URL, &now)
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	first := data.([]Release)
+	if len(first) != 3 || first[0].Tag != "v1.1.0" || first[0].New {
+		t.Fatalf("Expected the current release to be recorded without being new, got %+v", first)
+	}
+	if first[1].Tag != "v0.9.0" || first[1].URL != "https://github.com/acme/lib/releases/tag/v0.9.0" {
+		t.Errorf("Expected the latest tag of a repo without releases, got %+v", first[1])
+	}
+	if first[2].Err == "" {
+		t.Error("Expected an error for a missing repo")
+	}
+
+	// Two releases later, the draft and prerelease aside
+	releases = `[
+		{"tag_name":"v2.0.0-rc1","prerelease":true,"published_at":"2026-10-12T00:00:00Z"},
+		{"tag_name":"v1.3.0","name":"Faster startup","html_url":"https://github.com/acme/tool/releases/tag/v1.3.0","published_at":"2026-10-11T00:00:00Z"},
+		{"tag_name":"v1.4.0","draft":true},
+		{"tag_name":"v1.2.0","published_at":"2026-10-05T00:00:00Z"},
+		{"tag_name":"v1.1.0","published_at":"2026-10-01T00:00:00Z"}]`
+	now = now.Add(time.Hour)
+	data, err = plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	second := data.([]Release)
+	if !second[0].New || second[0].Tag != "v1.3.0" || second[0].Previous != "v1.1.0" || second[0].Newer != 2 {
+		t.Errorf("Expected v1.3.0 to be new, 2 releases since v1.1.0, got %+v", second[0])
+	}
+
+	wm := NewWidgetManager()
+	wm.UpdateReleasesWidget(second, now)
+	widget := wm.Widgets["releases"]
+	if widget.Count != 1 || widget.Items[0].Subtitle != "Faster startup • 2 releases since v1.1.0 • 2d ago" {
+		t.Errorf("Expected one new release with its name and age, got %d and %q", widget.Count, widget.Items[0].Subtitle)
+	}
+
+	// The seen releases outlive the plugin, and stop being new after a week
+	now = now.Add(releaseNewFor)
+	restarted := newTestReleasesPlugin(t, server.URL, &now)
+	restarted.statePath = plugin.statePath
+	data, _ = restarted.Fetch(context.Background())
+	if third := data.([]Release); third[0].Tag != "v1.3.0" || third[0].New {
+		t.Errorf("Expected v1.3.0 to no longer be new a week on, got %+v", third[0])
+	}
+}
+
+func TestReleasesPluginInitialize(t *testing.T) {
+	if err := NewReleasesPlugin().Initialize(map[string]interface{}{"repos": []string{"bubbletea"}}); err == nil {
+		t.Error("Expected a repo without an owner to be rejected")
+	}
+	if _, err := NewReleasesPlugin().Fetch(context.Background()); err == nil {
+		t.Error("Expected an error without repos")
+	}
+}
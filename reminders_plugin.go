@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// remindersDefaultDays is how far ahead reminders show without widgets.reminders.days
+const remindersDefaultDays = 30
+
+// How a reminder repeats
+const (
+	repeatYearly  = "yearly"
+	repeatMonthly = "monthly"
+	repeatOnce    = "once"
+)
+
+// Reminder is an entry of the reminders file, e.g. a birthday or a renewal
+type Reminder struct {
+	Name   string `yaml:"name"`
+	Date   string `yaml:"date"`             // 2006-01-02, or 01-02 for a yearly date without a year
+	Repeat string `yaml:"repeat,omitempty"` // yearly (default), monthly or once
+	URL    string `yaml:"url,omitempty"`
+}
+
+// UpcomingReminder is the next occurrence of a reminder
+type UpcomingReminder struct {
+	Reminder
+	On    time.Time
+	Days  int // until On; 0 is today
+	Years int // since the first occurrence, for yearly dates with a year; 0 otherwise
+}
+
+// RemindersPath returns the reminders file: widgets.reminders.path, or
+// ~/.goday/reminders.yaml
+func RemindersPath(cfg *Config) string {
+	path := ""
+	if cfg != nil {
+		path = cfg.Widgets.Reminders.Path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	if path == "" {
+		return filepath.Join(homeDir, ".goday", "reminders.yaml")
+	}
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(homeDir, path[2:])
+	}
+	return path
+}
+
+// RemindersPlugin lists today's and upcoming dates from a YAML or CSV file
+type RemindersPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	path        string
+	days        int
+	now         func() time.Time
+	lastData    []UpcomingReminder
+}
+
+// NewRemindersPlugin creates a new reminders plugin
+func NewRemindersPlugin() *RemindersPlugin {
+	return &RemindersPlugin{
+		id:          "reminders",
+		pluginType:  "productivity",
+		name:        "Reminders",
+		version:     "1.0.0",
+		description: "Counts down to birthdays, renewals and other dates from a file",
+		author:      "GoDay Team",
+		path:        RemindersPath(nil),
+		days:        remindersDefaultDays,
+		now:         time.Now,
+	}
+}
+
+// GetID returns the plugin ID
+func (rp *RemindersPlugin) GetID() string {
+	return rp.id
+}
+
+// GetType returns the plugin type
+func (rp *RemindersPlugin) GetType() string {
+	return rp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (rp *RemindersPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        rp.name,
+		Version:     rp.version,
+		Description: rp.description,
+		Author:      rp.author,
+		Type:        rp.pluginType,
+		Config: map[string]string{
+			"path": rp.path,
+			"days": fmt.Sprintf("%d", rp.days),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (rp *RemindersPlugin) Initialize(config map[string]interface{}) error {
+	if path, ok := config["path"].(string); ok && path != "" {
+		rp.path = path
+	}
+	if days, ok := config["days"].(int); ok && days > 0 {
+		rp.days = days
+	}
+	return nil
+}
+
+// Fetch reads the file again, so edits show on the next refresh, and returns the
+// reminders due within the configured days, soonest first
+func (rp *RemindersPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	reminders, err := LoadReminders(rp.path)
+	if err != nil {
+		return rp.lastData, err
+	}
+	upcoming, err := upcomingReminders(reminders, rp.now(), rp.days)
+	if err != nil {
+		return rp.lastData, fmt.Errorf("%s: %w", filepath.Base(rp.path), err)
+	}
+	rp.lastData = upcoming
+	return upcoming, nil
+}
+
+// LoadReminders reads a reminders file: a YAML list of name, date, repeat and url, or
+// CSV with those columns when the file ends in .csv. A CSV header row is skipped.
+func LoadReminders(path string) ([]Reminder, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(filepath.Ext(path), ".csv") {
+		var reminders []Reminder
+		if err := yaml.Unmarshal(data, &reminders); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		return reminders, nil
+	}
+
+	reader := csv.NewReader(strings.NewReader(string(data)))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	var reminders []Reminder
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(record[0], "name") {
+			continue
+		}
+		for len(record) < 4 {
+			record = append(record, "")
+		}
+		reminders = append(reminders, Reminder{Name: record[0], Date: record[1], Repeat: record[2], URL: record[3]})
+	}
+	return reminders, nil
+}
+
+// upcomingReminders returns the next occurrence of each reminder from today on, within
+// days, soonest first. Reminders that happened only once before today are dropped.
+func upcomingReminders(reminders []Reminder, now time.Time, days int) ([]UpcomingReminder, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var upcoming []UpcomingReminder
+	for _, reminder := range reminders {
+		on, years, err := nextOccurrence(reminder, today)
+		if err != nil {
+			return nil, fmt.Errorf("reminder %q: %w", reminder.Name, err)
+		}
+		if on.IsZero() {
+			continue
+		}
+		// Counted by calendar date, so a daylight saving change does not shift a day
+		left := int(on.Sub(today).Hours()/24 + 0.5)
+		if left > days {
+			continue
+		}
+		upcoming = append(upcoming, UpcomingReminder{Reminder: reminder, On: on, Days: left, Years: years})
+	}
+	sort.SliceStable(upcoming, func(i, j int) bool { return upcoming[i].On.Before(upcoming[j].On) })
+	return upcoming, nil
+}
+
+// nextOccurrence returns the first date on or after today the reminder falls on, zero
+// for a past one-off, and for yearly dates with a year how many years it marks. Dates
+// past the end of a month, such as 29 February, fall on its last day.
+func nextOccurrence(reminder Reminder, today time.Time) (time.Time, int, error) {
+	if strings.TrimSpace(reminder.Name) == "" {
+		return time.Time{}, 0, fmt.Errorf("name is required")
+	}
+	date := strings.TrimSpace(reminder.Date)
+	hasYear := true
+	start, err := time.ParseInLocation("2006-01-02", date, today.Location())
+	if err != nil {
+		hasYear = false
+		// A leap year, so 02-29 parses
+		if start, err = time.ParseInLocation("2006-01-02", "2000-"+date, today.Location()); err != nil {
+			return time.Time{}, 0, fmt.Errorf("invalid date %q: expected YYYY-MM-DD or MM-DD", reminder.Date)
+		}
+	}
+
+	repeat := strings.ToLower(strings.TrimSpace(reminder.Repeat))
+	switch repeat {
+	case "", repeatYearly:
+		on := clampedDate(today.Year(), start.Month(), start.Day(), today.Location())
+		if on.Before(today) {
+			on = clampedDate(today.Year()+1, start.Month(), start.Day(), today.Location())
+		}
+		if !hasYear {
+			return on, 0, nil
+		}
+		if on.Before(start) {
+			on = start
+		}
+		return on, on.Year() - start.Year(), nil
+	case repeatMonthly:
+		on := clampedDate(today.Year(), today.Month(), start.Day(), today.Location())
+		if on.Before(today) {
+			on = clampedDate(today.Year(), today.Month()+1, start.Day(), today.Location())
+		}
+		if hasYear && on.Before(start) {
+			on = start
+		}
+		return on, 0, nil
+	case repeatOnce:
+		if !hasYear {
+			return time.Time{}, 0, fmt.Errorf("a one-off date needs a year")
+		}
+		if start.Before(today) {
+			return time.Time{}, 0, nil
+		}
+		return start, 0, nil
+	}
+	return time.Time{}, 0, fmt.Errorf("invalid repeat %q: expected yearly, monthly or once", reminder.Repeat)
+}
+
+// clampedDate returns the day of the month, or the month's last day when it is shorter
+func clampedDate(year int, month time.Month, day int, loc *time.Location) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	last := first.AddDate(0, 1, -1).Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, loc)
+}
+
+// formatReminderCountdown describes how far away a reminder is
+func formatReminderCountdown(days int) string {
+	switch days {
+	case 0:
+		return "today"
+	case 1:
+		return "tomorrow"
+	}
+	return fmt.Sprintf("in %d days", days)
+}
+
+// Cleanup performs cleanup
+func (rp *RemindersPlugin) Cleanup() error {
+	return nil
+}
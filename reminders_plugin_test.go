@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUpcomingReminders(t *testing.T) {
+	now := time.Date(2027, 2, 26, 18, 30, 0, 0, time.UTC)
+	reminders := []Reminder{
+		{Name: "Mum's birthday", Date: "1958-03-06"},
+		{Name: "Leap day friend", Date: "02-29"},
+		{Name: "Rent", Date: "2026-01-31", Repeat: "monthly"},
+		{Name: "Passport renewal", Date: "2027-02-26", Repeat: "once"},
+		{Name: "Old one-off", Date: "2026-05-01", Repeat: "once"},
+		{Name: "Far away", Date: "09-01"},
+	}
+
+	upcoming, err := upcomingReminders(reminders, now, 30)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(upcoming) != 4 {
+		t.Fatalf("Expected 4 reminders in the next 30 days, got %+v", upcoming)
+	}
+	if upcoming[0].Name != "Passport renewal" || upcoming[0].Days != 0 {
+		t.Errorf("Expected the passport renewal today first, got %+v", upcoming[0])
+	}
+	// 29 February falls on the 28th outside leap years, and the 31st on a month's last day
+	if upcoming[1].Name != "Leap day friend" || upcoming[1].Days != 2 || upcoming[2].Name != "Rent" || upcoming[2].On.Day() != 28 {
+		t.Errorf("Expected dates past the month's end on its last day, got %+v and %+v", upcoming[1], upcoming[2])
+	}
+	if upcoming[3].Name != "Mum's birthday" || upcoming[3].Days != 8 || upcoming[3].Years != 69 {
+		t.Errorf("Expected the 69th birthday in 8 days, got %+v", upcoming[3])
+	}
+
+	if _, err := upcomingReminders([]Reminder{{Name: "Typo", Date: "2027-13-01"}}, now, 30); err == nil {
+		t.Error("Expected an invalid date to be rejected")
+	}
+	if _, err := upcomingReminders([]Reminder{{Name: "Someday", Date: "05-01", Repeat: "once"}}, now, 30); err == nil {
+		t.Error("Expected a one-off date without a year to be rejected")
+	}
+}
+
+func TestRemindersPluginFetch(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "dates.csv")
+	os.WriteFile(csvPath, []byte("name,date,repeat,url\n# renewals\nDomain renewal, 2027-03-01, once, https://registrar.example.com\n"), 0600)
+	yamlPath := filepath.Join(dir, "reminders.yaml")
+	os.WriteFile(yamlPath, []byte("- name: Anniversary\n  date: 2020-02-26\n"), 0600)
+
+	plugin := NewRemindersPlugin()
+	plugin.now = func() time.Time { return time.Date(2027, 2, 26, 9, 0, 0, 0, time.UTC) }
+	plugin.Initialize(map[string]interface{}{"path": csvPath})
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if upcoming := data.([]UpcomingReminder); len(upcoming) != 1 || upcoming[0].URL != "https://registrar.example.com" {
+		t.Errorf("Expected the CSV renewal without the header or comment, got %+v", upcoming)
+	}
+
+	plugin.Initialize(map[string]interface{}{"path": yamlPath})
+	data, err = plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	wm := NewWidgetManager()
+	wm.UpdateRemindersWidget(data.([]UpcomingReminder))
+	widget := wm.Widgets["reminders"]
+	if widget.Count != 1 || widget.Items[0].Subtitle != "today • Fri 26 Feb • 7 years" || widget.Items[0].Status != "🔔" {
+		t.Errorf("Expected the 7th anniversary today, got %d and %+v", widget.Count, widget.Items)
+	}
+}
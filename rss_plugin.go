@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// rssItemsPerFeed is how many entries are read from each feed
+const rssItemsPerFeed = 15
+
+// RSSPlugin implements news fetching from any RSS or Atom feeds
+type RSSPlugin struct {
+	*BaseNewsPlugin
+	feeds      []NewsFeed
+	source     string // NewsItem.Source for items from these feeds
+	maxItems   int
+	feedParser *gofeed.Parser
+}
+
+// NewRSSPlugin creates a plugin for the feeds listed under widgets.news.feeds
+func NewRSSPlugin() *RSSPlugin {
+	base := NewBaseNewsPlugin(
+		"rss",
+		"RSS Feeds",
+		"1.0.0",
+		"Fetches articles from configured RSS and Atom feeds",
+		"GoDay Team",
+	)
+	base.supportedTags = []string{"all"}
+
+	return &RSSPlugin{
+		BaseNewsPlugin: base,
+		source:         "rss",
+		maxItems:       12,
+		feedParser:     gofeed.NewParser(),
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (rp *RSSPlugin) Initialize(config map[string]interface{}) error {
+	if tags := configStringList(config["tags"]); tags != nil {
+		rp.SetTags(tags)
+	}
+	if currentTag, ok := config["current_tag"].(string); ok {
+		rp.SetCurrentTag(currentTag)
+	}
+
+	feeds, err := newsFeedsFromConfig(config["feeds"])
+	if err != nil {
+		return err
+	}
+	rp.feeds = feeds
+
+	// Feed tags can be cycled with t like the built-in ones
+	supported := []string{"all"}
+	seen := map[string]bool{"all": true}
+	for _, feed := range rp.feeds {
+		for _, tag := range feed.Tags {
+			if tag = strings.ToLower(tag); !seen[tag] {
+				seen[tag] = true
+				supported = append(supported, tag)
+			}
+		}
+	}
+	rp.supportedTags = supported
+	return nil
+}
+
+// newsFeedsFromConfig reads the feeds setting, given either as []NewsFeed from
+// widgets.news.feeds or as a list of url/label/tags maps from the plugins section
+func newsFeedsFromConfig(value interface{}) ([]NewsFeed, error) {
+	var feeds []NewsFeed
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []NewsFeed:
+		feeds = append(feeds, v...)
+	case []interface{}:
+		for _, entry := range v {
+			fields, ok := entry.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("feed entries must have a url, got %v", entry)
+			}
+			url, _ := fields["url"].(string)
+			label, _ := fields["label"].(string)
+			feeds = append(feeds, NewsFeed{URL: url, Label: label, Tags: configStringList(fields["tags"])})
+		}
+	default:
+		return nil, fmt.Errorf("feeds must be a list, got %T", value)
+	}
+
+	for _, feed := range feeds {
+		if !strings.HasPrefix(feed.URL, "http://") && !strings.HasPrefix(feed.URL, "https://") {
+			return nil, fmt.Errorf("feed URL %q must start with http:// or https://", feed.URL)
+		}
+	}
+	return feeds, nil
+}
+
+// Fetch retrieves every feed concurrently and returns their entries newest first.
+// A feed that fails is skipped; Fetch only fails when every feed does.
+func (rp *RSSPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if len(rp.feeds) == 0 {
+		return []NewsItem{}, nil
+	}
+
+	results := make([][]NewsItem, len(rp.feeds))
+	errs := make([]error, len(rp.feeds))
+	var wg sync.WaitGroup
+	for i, feed := range rp.feeds {
+		wg.Add(1)
+		go func(i int, feed NewsFeed) {
+			defer wg.Done()
+			results[i], errs[i] = rp.fetchFeed(ctx, feed)
+		}(i, feed)
+	}
+	wg.Wait()
+
+	var items []NewsItem
+	var failures []string
+	for i, feedItems := range results {
+		if errs[i] != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", rp.feeds[i].URL, errs[i]))
+			continue
+		}
+		items = append(items, feedItems...)
+	}
+	if len(failures) == len(rp.feeds) {
+		return rp.lastData, fmt.Errorf("all feeds failed: %s", strings.Join(failures, "; "))
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].CreatedAt > items[j].CreatedAt
+	})
+
+	filtered := rp.filterByCurrentTag(items)
+	if len(filtered) > rp.maxItems {
+		filtered = filtered[:rp.maxItems]
+	}
+
+	rp.lastData = filtered
+	return filtered, nil
+}
+
+// fetchFeed downloads and parses one feed. The feed's tags are added to every entry.
+func (rp *RSSPlugin) fetchFeed(ctx context.Context, feed NewsFeed) ([]NewsItem, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", feed.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rp.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	parsed, err := rp.feedParser.Parse(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	label := feed.Label
+	if label == "" {
+		label = parsed.Title
+	}
+	// Feeds declare e.g. de-DE; news language filtering compares the primary language
+	language, _, _ := strings.Cut(strings.ToLower(parsed.Language), "-")
+
+	var items []NewsItem
+	for _, item := range parsed.Items {
+		if item.Link == "" || item.Title == "" {
+			continue
+		}
+
+		tags := append([]string{}, item.Categories...)
+		tags = append(tags, feed.Tags...)
+
+		// Atom feeds often only carry an updated date
+		var createdAt int64
+		if item.PublishedParsed != nil {
+			createdAt = item.PublishedParsed.Unix()
+		} else if item.UpdatedParsed != nil {
+			createdAt = item.UpdatedParsed.Unix()
+		}
+
+		author := label
+		if len(item.Authors) > 0 && item.Authors[0].Name != "" {
+			author = item.Authors[0].Name
+		}
+
+		items = append(items, NewsItem{
+			Title:       item.Title,
+			URL:         item.Link,
+			Author:      author,
+			Description: item.Description,
+			Tags:        tags,
+			Source:      rp.source,
+			Feed:        label,
+			Language:    language,
+			CreatedAt:   createdAt,
+		})
+
+		if len(items) >= rssItemsPerFeed {
+			break
+		}
+	}
+	return items, nil
+}
+
+// formatRSSItem shows the author and, when it says something more, the feed of an item,
+// and the original language of a translated title
+func formatRSSItem(news NewsItem) WidgetItem {
+	subtitle := news.Author
+	if news.Feed != "" && news.Feed != news.Author {
+		subtitle = fmt.Sprintf("%s • %s", news.Author, news.Feed)
+	}
+	if news.Translated {
+		subtitle += " • 🌐 " + news.Language
+	}
+	return WidgetItem{Title: news.Title, Subtitle: subtitle, URL: news.URL}
+}
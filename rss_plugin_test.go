@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testRSSFeed = `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Team Blog</title>
+<item><title>Postmortem: Tuesday outage</title><link>https://blog.example/outage</link><pubDate>Tue, 02 Jan 2024 10:00:00 GMT</pubDate><category>ops</category></item>
+<item><title>Welcome</title><link>https://blog.example/welcome</link><pubDate>Mon, 01 Jan 2024 10:00:00 GMT</pubDate></item>
+</channel></rss>`
+
+const testAtomFeed = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom"><title>Go Blog</title>
+<entry><title>Go 1.30 is released</title><link href="https://go.dev/blog/go1.30"/><updated>2024-01-03T10:00:00Z</updated><author><name>The Go Team</name></author></entry>
+</feed>`
+
+func TestRSSPluginFetchesConfiguredFeeds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rss":
+			fmt.Fprint(w, testRSSFeed)
+		case "/atom":
+			fmt.Fprint(w, testAtomFeed)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	plugin := NewRSSPlugin()
+	err := plugin.Initialize(map[string]interface{}{
+		"current_tag": "all",
+		"feeds": []interface{}{
+			map[string]interface{}{"url": server.URL + "/rss"},
+			map[string]interface{}{"url": server.URL + "/atom", "label": "Go", "tags": []interface{}{"golang"}},
+			map[string]interface{}{"url": server.URL + "/missing"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Expected a failing feed to be skipped, got %v", err)
+	}
+	items := data.([]NewsItem)
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 items, got %d: %+v", len(items), items)
+	}
+	if items[0].Title != "Go 1.30 is released" || items[1].Title != "Postmortem: Tuesday outage" {
+		t.Errorf("Expected items newest first across feeds, got '%s', '%s'", items[0].Title, items[1].Title)
+	}
+	if items[0].Author != "The Go Team" || items[0].Feed != "Go" || items[0].Source != "rss" {
+		t.Errorf("Expected the Atom entry by The Go Team from feed 'Go', got %+v", items[0])
+	}
+	if items[1].Author != "Team Blog" || items[1].Feed != "Team Blog" {
+		t.Errorf("Expected the feed title as label and author fallback, got author '%s', feed '%s'", items[1].Author, items[1].Feed)
+	}
+
+	plugin.SetCurrentTag("golang")
+	data, _ = plugin.Fetch(context.Background())
+	if items := data.([]NewsItem); len(items) != 1 || items[0].Feed != "Go" {
+		t.Errorf("Expected the feed's tags to filter its articles, got %+v", items)
+	}
+	if tags := plugin.GetSupportedTags(); len(tags) != 2 || tags[1] != "golang" {
+		t.Errorf("Expected supported tags [all golang], got %v", tags)
+	}
+}
+
+func TestRSSPluginConfig(t *testing.T) {
+	plugin := NewRSSPlugin()
+	if err := plugin.Initialize(map[string]interface{}{}); err != nil {
+		t.Fatalf("Initialize without feeds failed: %v", err)
+	}
+	if data, err := plugin.Fetch(context.Background()); err != nil || len(data.([]NewsItem)) != 0 {
+		t.Errorf("Expected no items and no error without feeds, got %v (%v)", data, err)
+	}
+
+	if err := plugin.Initialize(map[string]interface{}{"feeds": []NewsFeed{{URL: "blog.example/feed"}}}); err == nil {
+		t.Error("Expected a feed URL without a scheme to fail")
+	}
+
+	// Hackernoon reuses the RSS plugin but ignores feeds meant for the rss provider
+	hackernoon := NewHackernoonPlugin()
+	hackernoon.Initialize(map[string]interface{}{"feeds": []NewsFeed{{URL: "https://blog.example/feed"}}})
+	if len(hackernoon.feeds) != 1 || hackernoon.feeds[0].URL != "https://hackernoon.com/feed" || hackernoon.GetID() != "hackernoon" {
+		t.Errorf("Expected Hackernoon to keep its own feed, got %+v", hackernoon.feeds)
+	}
+}
+
+func TestAggregateNewsInterleavesSources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<?xml version="1.0"?><rss version="2.0"><channel><title>Feed</title>`)
+		for i := 0; i < 10; i++ {
+			fmt.Fprintf(w, `<item><title>%s %d</title><link>https://example.com%s/%d</link></item>`, r.URL.Path, i, r.URL.Path, i)
+		}
+		fmt.Fprint(w, `</channel></rss>`)
+	}))
+	defer server.Close()
+
+	first, second := NewRSSPlugin(), NewRSSPlugin()
+	first.Initialize(map[string]interface{}{"feeds": []NewsFeed{{URL: server.URL + "/a"}}})
+	second.Initialize(map[string]interface{}{"feeds": []NewsFeed{{URL: server.URL + "/b"}}})
+
+	aggregate := NewAggregateNewsPlugin([]NewsPlugin{first, second})
+	data, err := aggregate.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	items := data.([]NewsItem)
+	if len(items) != 12 || items[0].Title != "/a 0" || items[1].Title != "/b 0" || items[11].Title != "/b 5" {
+		t.Errorf("Expected 12 items alternating between sources, got %d starting %+v", len(items), items[:2])
+	}
+}
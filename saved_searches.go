@@ -0,0 +1,431 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SavedSearch is a named query run against Jira and/or GitHub on demand
+type SavedSearch struct {
+	Name   string
+	JQL    string
+	GitHub string
+}
+
+// SavedSearchResult is one work item returned by a saved search
+type SavedSearchResult struct {
+	Item    WidgetItem
+	Updated time.Time
+}
+
+// searchResultsMsg carries the outcome of a saved search back to the TUI
+type searchResultsMsg struct {
+	name  string
+	items []WidgetItem
+	err   error
+}
+
+// SavedSearchRunner runs saved searches against the configured Jira site and GitHub
+type SavedSearchRunner struct {
+	jiraURL     string
+	jiraEmail   string
+	jiraToken   string
+	githubAPI   string
+	githubToken string
+	client      *http.Client
+}
+
+// NewSavedSearchRunner creates a runner from config. Tokens fall back to the
+// JIRA_API_TOKEN and GITHUB_TOKEN/GH_TOKEN environment variables.
+func NewSavedSearchRunner(cfg *Config) *SavedSearchRunner {
+	runner := &SavedSearchRunner{
+		githubAPI:   "https://api.github.com",
+		githubToken: os.Getenv("GITHUB_TOKEN"),
+		jiraToken:   os.Getenv("JIRA_API_TOKEN"),
+		client:      &http.Client{Timeout: 15 * time.Second},
+	}
+	if runner.githubToken == "" {
+		runner.githubToken = os.Getenv("GH_TOKEN")
+	}
+
+	if cfg != nil {
+		runner.jiraURL = strings.TrimRight(cfg.Widgets.Jira.BaseURL, "/")
+		runner.jiraEmail = cfg.Widgets.Jira.Email
+		if cfg.Widgets.Jira.APIToken != "" {
+			runner.jiraToken = cfg.Widgets.Jira.APIToken
+		}
+		if token, ok := cfg.Plugins["github-prs"]["github_token"].(string); ok && token != "" {
+			runner.githubToken = token
+		}
+	}
+	return runner
+}
+
+// SavedSearches returns the searches defined in config, sorted by name
+func (c *Config) SavedSearches() []SavedSearch {
+	if c == nil {
+		return nil
+	}
+
+	var searches []SavedSearch
+	for name, search := range c.Searches {
+		searches = append(searches, SavedSearch{Name: name, JQL: search.JQL, GitHub: search.GitHub})
+	}
+	sort.Slice(searches, func(i, j int) bool {
+		return searches[i].Name < searches[j].Name
+	})
+	return searches
+}
+
+// Run queries every system the search defines concurrently and returns the
+// results newest first. Partial failures are returned as error items.
+func (r *SavedSearchRunner) Run(ctx context.Context, search SavedSearch) ([]WidgetItem, error) {
+	type outcome struct {
+		source  string
+		results []SavedSearchResult
+		err     error
+	}
+
+	var outcomes []*outcome
+	var wg sync.WaitGroup
+	run := func(source string, fetch func() ([]SavedSearchResult, error)) {
+		o := &outcome{source: source}
+		outcomes = append(outcomes, o)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			o.results, o.err = fetch()
+		}()
+	}
+
+	if search.JQL != "" {
+		run("Jira", func() ([]SavedSearchResult, error) { return r.searchJira(ctx, search.JQL) })
+	}
+	if search.GitHub != "" {
+		run("GitHub", func() ([]SavedSearchResult, error) { return r.searchGitHub(ctx, search.GitHub) })
+	}
+	if len(outcomes) == 0 {
+		return nil, fmt.Errorf("search %q has no jql or github query", search.Name)
+	}
+	wg.Wait()
+
+	var results []SavedSearchResult
+	var errorItems []WidgetItem
+	for _, o := range outcomes {
+		if o.err != nil {
+			errorItems = append(errorItems, WidgetItem{Title: o.source + " search failed", Subtitle: o.err.Error(), Status: "❌"})
+			continue
+		}
+		results = append(results, o.results...)
+	}
+	if len(errorItems) == len(outcomes) {
+		var messages []string
+		for _, item := range errorItems {
+			messages = append(messages, item.Title+": "+item.Subtitle)
+		}
+		return nil, fmt.Errorf("%s", strings.Join(messages, "; "))
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Updated.After(results[j].Updated)
+	})
+
+	items := errorItems
+	for _, result := range results {
+		items = append(items, result.Item)
+	}
+	return items, nil
+}
+
+// searchJira runs a JQL query using the Jira REST API
+func (r *SavedSearchRunner) searchJira(ctx context.Context, jql string) ([]SavedSearchResult, error) {
+	if r.jiraURL == "" {
+		return nil, fmt.Errorf("widgets.jira.base_url is not configured")
+	}
+
+	query := url.Values{}
+	query.Set("jql", jql)
+	query.Set("maxResults", "20")
+	query.Set("fields", "summary,status,updated")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", r.jiraURL+"/rest/api/2/search?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	// Jira Cloud uses email + API token; Jira Server/Data Center uses a personal access token
+	if r.jiraEmail != "" {
+		req.SetBasicAuth(r.jiraEmail, r.jiraToken)
+	} else if r.jiraToken != "" {
+		req.Header.Set("Authorization", "Bearer "+r.jiraToken)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	var response struct {
+		Issues []struct {
+			Key    string `json:"key"`
+			Fields struct {
+				Summary string `json:"summary"`
+				Updated string `json:"updated"`
+				Status  struct {
+					Name string `json:"name"`
+				} `json:"status"`
+			} `json:"fields"`
+		} `json:"issues"`
+	}
+	if err := r.getJSON(req, &response); err != nil {
+		return nil, err
+	}
+
+	var results []SavedSearchResult
+	for _, issue := range response.Issues {
+		updated, _ := time.Parse("2006-01-02T15:04:05.000-0700", issue.Fields.Updated)
+		results = append(results, SavedSearchResult{
+			Item: WidgetItem{
+				Title:    fmt.Sprintf("%s %s", issue.Key, issue.Fields.Summary),
+				Subtitle: fmt.Sprintf("Jira • %s", issue.Fields.Status.Name),
+				Status:   "🎫",
+				URL:      r.jiraURL + "/browse/" + issue.Key,
+			},
+			Updated: updated,
+		})
+	}
+	return results, nil
+}
+
+// searchGitHub runs an issue/PR search query using the GitHub search API
+func (r *SavedSearchRunner) searchGitHub(ctx context.Context, q string) ([]SavedSearchResult, error) {
+	query := url.Values{}
+	query.Set("q", q)
+	query.Set("sort", "updated")
+	query.Set("per_page", "20")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", r.githubAPI+"/search/issues?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if r.githubToken != "" {
+		req.Header.Set("Authorization", "token "+r.githubToken)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	var response struct {
+		Items []struct {
+			Number        int       `json:"number"`
+			Title         string    `json:"title"`
+			HTMLURL       string    `json:"html_url"`
+			RepositoryURL string    `json:"repository_url"`
+			UpdatedAt     time.Time `json:"updated_at"`
+			PullRequest   *struct{} `json:"pull_request"`
+		} `json:"items"`
+	}
+	if err := r.getJSON(req, &response); err != nil {
+		return nil, err
+	}
+
+	var results []SavedSearchResult
+	for _, item := range response.Items {
+		status := "🐛"
+		if item.PullRequest != nil {
+			status = "🔀"
+		}
+		results = append(results, SavedSearchResult{
+			Item: WidgetItem{
+				Title:    fmt.Sprintf("#%d %s", item.Number, item.Title),
+				Subtitle: fmt.Sprintf("GitHub • %s", path.Base(item.RepositoryURL)),
+				Status:   status,
+				URL:      item.HTMLURL,
+			},
+			Updated: item.UpdatedAt,
+		})
+	}
+	return results, nil
+}
+
+// getJSON performs a request and decodes a JSON response body
+func (r *SavedSearchRunner) getJSON(req *http.Request, target interface{}) error {
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", req.URL.Host, resp.StatusCode)
+	}
+	return json.Unmarshal(body, target)
+}
+
+// runSavedSearchCmd runs a saved search in the background
+func runSavedSearchCmd(runner *SavedSearchRunner, search SavedSearch) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+
+		items, err := runner.Run(ctx, search)
+		return searchResultsMsg{name: search.Name, items: items, err: err}
+	}
+}
+
+// maxPaletteRows is the number of results visible in the search palette at once
+const maxPaletteRows = 12
+
+// SearchPalette is the overlay listing saved searches and the results of the one that was run
+type SearchPalette struct {
+	searches []SavedSearch
+	cursor   int
+	active   string // name of the search whose results are shown or pending
+	running  bool
+	results  []WidgetItem
+	selected int
+	err      string
+}
+
+// NewSearchPalette creates a palette for the given saved searches
+func NewSearchPalette(searches []SavedSearch) *SearchPalette {
+	return &SearchPalette{searches: searches}
+}
+
+// Update handles a key press. It reports whether the palette should close, the
+// search to run (if any) and a URL to open (if any).
+func (p *SearchPalette) Update(msg tea.KeyMsg) (done bool, run *SavedSearch, open string) {
+	showingResults := p.active != ""
+
+	switch msg.String() {
+	case "esc", "q":
+		if showingResults {
+			// Back to the search list
+			p.active, p.running, p.results, p.err = "", false, nil, ""
+			return false, nil, ""
+		}
+		return true, nil, ""
+	case "s":
+		if !showingResults {
+			return true, nil, ""
+		}
+	case "up", "k":
+		if showingResults {
+			if p.selected > 0 {
+				p.selected--
+			}
+		} else if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if showingResults {
+			if p.selected < len(p.results)-1 {
+				p.selected++
+			}
+		} else if p.cursor < len(p.searches)-1 {
+			p.cursor++
+		}
+	case "enter":
+		if showingResults {
+			if p.selected < len(p.results) {
+				return false, nil, p.results[p.selected].URL
+			}
+		} else if p.cursor < len(p.searches) {
+			search := p.searches[p.cursor]
+			p.active, p.running, p.results, p.selected, p.err = search.Name, true, nil, 0, ""
+			return false, &search, ""
+		}
+	}
+	return false, nil, ""
+}
+
+// SetResults shows the results of a finished search, ignoring stale ones
+func (p *SearchPalette) SetResults(msg searchResultsMsg) {
+	if msg.name != p.active {
+		return
+	}
+	p.running = false
+	p.results = msg.items
+	p.selected = 0
+	if msg.err != nil {
+		p.err = msg.err.Error()
+	}
+}
+
+// View renders the palette as a bordered box
+func (p *SearchPalette) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colorTitle)
+	selectedStyle := lipgloss.NewStyle().Foreground(colorAccent).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(colorMuted).Italic(true)
+	errorStyle := lipgloss.NewStyle().Foreground(colorAlert)
+
+	var lines []string
+	switch {
+	case p.active == "":
+		lines = append(lines, titleStyle.Render("Saved Searches"), "")
+		if len(p.searches) == 0 {
+			lines = append(lines, dimStyle.Render("No saved searches - add a searches: section to config.yaml"))
+		}
+		for i, search := range p.searches {
+			var systems []string
+			if search.JQL != "" {
+				systems = append(systems, "Jira")
+			}
+			if search.GitHub != "" {
+				systems = append(systems, "GitHub")
+			}
+			line := fmt.Sprintf("%s  %s", search.Name, dimStyle.Render(strings.Join(systems, " + ")))
+			if i == p.cursor {
+				lines = append(lines, selectedStyle.Render("▶ ")+line)
+			} else {
+				lines = append(lines, "  "+line)
+			}
+		}
+		lines = append(lines, "", dimStyle.Render("Enter run • ↑↓/jk select • Esc close"))
+	default:
+		lines = append(lines, titleStyle.Render("Search: "+p.active), "")
+		switch {
+		case p.running:
+			lines = append(lines, "🔄 Searching...")
+		case p.err != "":
+			lines = append(lines, errorStyle.Render("❌ "+p.err))
+		case len(p.results) == 0:
+			lines = append(lines, dimStyle.Render("No matching items"))
+		}
+		// Scroll so the selected result stays visible
+		start := 0
+		if p.selected >= maxPaletteRows {
+			start = p.selected - maxPaletteRows + 1
+		}
+		for i := start; i < len(p.results) && i < start+maxPaletteRows; i++ {
+			item := p.results[i]
+			line := fmt.Sprintf("%s %s  %s", item.Status, item.Title, dimStyle.Render(item.Subtitle))
+			if i == p.selected {
+				lines = append(lines, selectedStyle.Render("▶ ")+line)
+			} else {
+				lines = append(lines, "  "+line)
+			}
+		}
+		if len(p.results) > maxPaletteRows {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("%d/%d", p.selected+1, len(p.results))))
+		}
+		lines = append(lines, "", dimStyle.Render("Enter open • ↑↓/jk select • Esc back"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Padding(1, 2).
+		Width(72).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSavedSearchRunner(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			if user, pass, ok := r.BasicAuth(); !ok || user != "me@example.com" || pass != "jira-token" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Query().Get("jql") != "priority = Highest" {
+				t.Errorf("Unexpected JQL %q", r.URL.Query().Get("jql"))
+			}
+			fmt.Fprint(w, `{"issues": [{"key": "ENG-1", "fields": {"summary": "Outage", "updated": "2024-03-01T10:00:00.000+0000", "status": {"name": "In Progress"}}}]}`)
+		case "/search/issues":
+			if r.Header.Get("Authorization") != "token gh-token" {
+				t.Errorf("Expected GitHub token to be sent, got %q", r.Header.Get("Authorization"))
+			}
+			fmt.Fprint(w, `{"items": [{"number": 42, "title": "Fix outage", "html_url": "https://github.com/acme/api/pull/42", "repository_url": "https://api.github.com/repos/acme/api", "updated_at": "2024-03-02T10:00:00Z", "pull_request": {}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &Config{}
+	cfg.Widgets.Jira.BaseURL = server.URL + "/"
+	cfg.Widgets.Jira.Email = "me@example.com"
+	cfg.Widgets.Jira.APIToken = "jira-token"
+	cfg.Plugins = map[string]map[string]interface{}{"github-prs": {"github_token": "gh-token"}}
+	cfg.Searches = map[string]SavedSearchConfig{
+		"urgent": {JQL: "priority = Highest", GitHub: "is:open label:urgent"},
+	}
+
+	runner := NewSavedSearchRunner(cfg)
+	runner.githubAPI = server.URL
+
+	searches := cfg.SavedSearches()
+	if len(searches) != 1 || searches[0].Name != "urgent" {
+		t.Fatalf("Expected one saved search 'urgent', got %v", searches)
+	}
+
+	items, err := runner.Run(context.Background(), searches[0])
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(items))
+	}
+
+	// Newest first: the GitHub PR was updated after the Jira issue
+	if items[0].Title != "#42 Fix outage" || items[0].Subtitle != "GitHub • api" {
+		t.Errorf("Expected GitHub PR first, got %+v", items[0])
+	}
+	if items[1].URL != server.URL+"/browse/ENG-1" {
+		t.Errorf("Expected Jira browse URL, got '%s'", items[1].URL)
+	}
+
+	// A failing system is reported alongside the other system's results
+	runner.jiraToken = "wrong"
+	items, err = runner.Run(context.Background(), searches[0])
+	if err != nil {
+		t.Fatalf("Expected partial results, got error: %v", err)
+	}
+	if len(items) != 2 || items[0].Status != "❌" {
+		t.Errorf("Expected an error item followed by the GitHub result, got %+v", items)
+	}
+
+	if _, err := runner.Run(context.Background(), SavedSearch{Name: "empty"}); err == nil {
+		t.Error("Expected a search without queries to fail")
+	}
+}
+
+func TestSearchPalette(t *testing.T) {
+	palette := NewSearchPalette([]SavedSearch{
+		{Name: "mine", GitHub: "author:@me"},
+		{Name: "urgent", JQL: "priority = Highest"},
+	})
+
+	palette.Update(keyPress("j"))
+	_, search, _ := palette.Update(keyPress("enter"))
+	if search == nil || search.Name != "urgent" {
+		t.Fatalf("Expected 'urgent' to run, got %v", search)
+	}
+	if !strings.Contains(palette.View(), "Searching") {
+		t.Error("Expected a searching indicator while the search runs")
+	}
+
+	// Results of a search that is no longer shown are ignored
+	palette.SetResults(searchResultsMsg{name: "mine", items: []WidgetItem{{Title: "stale"}}})
+	if len(palette.results) != 0 {
+		t.Error("Expected stale results to be ignored")
+	}
+
+	palette.SetResults(searchResultsMsg{name: "urgent", items: []WidgetItem{{Title: "ENG-1 Outage", URL: "https://jira.example.com/browse/ENG-1"}}})
+	if _, _, link := palette.Update(keyPress("enter")); link != "https://jira.example.com/browse/ENG-1" {
+		t.Errorf("Expected Enter to open the selected result, got '%s'", link)
+	}
+
+	// Esc goes back to the list, then closes
+	if done, _, _ := palette.Update(keyPress("esc")); done {
+		t.Error("Expected Esc to return to the search list first")
+	}
+	if done, _, _ := palette.Update(keyPress("esc")); !done {
+		t.Error("Expected Esc on the search list to close the palette")
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// quietRecheck is how often a paused widget without a scheduled interval checks
+// whether quiet time is over
+const quietRecheck = 5 * time.Minute
+
+// QuietSchedule is the time, such as evenings and weekends, when widgets are not
+// polled and alerts are held back
+type QuietSchedule struct {
+	from, to      int // minutes since midnight; the window may cross midnight
+	hasHours      bool
+	weekends      bool
+	widgets       map[string]bool // nil pauses every widget
+	alerts        AttentionLevel  // highest attention level while quiet
+	overrideUntil time.Time
+}
+
+// parseClock parses HH:MM into minutes since midnight
+func parseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(clock))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (expected HH:MM)", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// NewQuietSchedule creates the schedule from the config. It returns nil when no quiet
+// time is configured.
+func NewQuietSchedule(cfg *Config) (*QuietSchedule, error) {
+	if cfg == nil || (cfg.Schedule.QuietHours == "" && !cfg.Schedule.Weekends) {
+		return nil, nil
+	}
+
+	q := &QuietSchedule{weekends: cfg.Schedule.Weekends, alerts: AttentionHighlight}
+	if cfg.Schedule.QuietHours != "" {
+		from, to, ok := strings.Cut(cfg.Schedule.QuietHours, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid quiet_hours %q (expected HH:MM-HH:MM)", cfg.Schedule.QuietHours)
+		}
+		var err error
+		if q.from, err = parseClock(from); err != nil {
+			return nil, err
+		}
+		if q.to, err = parseClock(to); err != nil {
+			return nil, err
+		}
+		q.hasHours = q.from != q.to
+	}
+	if len(cfg.Schedule.Widgets) > 0 {
+		q.widgets = make(map[string]bool)
+		for _, widget := range cfg.Schedule.Widgets {
+			q.widgets[strings.ToLower(widget)] = true
+		}
+	}
+	if cfg.Schedule.Alerts != "" {
+		level, err := ParseAttentionLevel(cfg.Schedule.Alerts)
+		if err != nil {
+			return nil, err
+		}
+		q.alerts = level
+	}
+	return q, nil
+}
+
+// scheduledQuiet reports whether t falls in quiet time, ignoring the override
+func (q *QuietSchedule) scheduledQuiet(t time.Time) bool {
+	if q.weekends && (t.Weekday() == time.Saturday || t.Weekday() == time.Sunday) {
+		return true
+	}
+	if !q.hasHours {
+		return false
+	}
+	minutes := t.Hour()*60 + t.Minute()
+	if q.from < q.to {
+		return minutes >= q.from && minutes < q.to
+	}
+	return minutes >= q.from || minutes < q.to
+}
+
+// Quiet reports whether t is in quiet time and not overridden
+func (q *QuietSchedule) Quiet(t time.Time) bool {
+	return q.scheduledQuiet(t) && !t.Before(q.overrideUntil)
+}
+
+// QuietEnd returns when the quiet time around t ends, e.g. Monday at the end of the
+// quiet hours for a Saturday with weekends on
+func (q *QuietSchedule) QuietEnd(t time.Time) time.Time {
+	end := t
+	// A week of weekends and nights is at most a few hops
+	for i := 0; i < 14 && q.scheduledQuiet(end); i++ {
+		if q.weekends && (end.Weekday() == time.Saturday || end.Weekday() == time.Sunday) {
+			end = time.Date(end.Year(), end.Month(), end.Day()+1, 0, 0, 0, 0, end.Location())
+			continue
+		}
+		next := time.Date(end.Year(), end.Month(), end.Day(), q.to/60, q.to%60, 0, 0, end.Location())
+		if !next.After(end) {
+			next = next.AddDate(0, 0, 1)
+		}
+		end = next
+	}
+	return end
+}
+
+// ToggleOverride lifts the current quiet time until it ends, for working late, or
+// restores it when already lifted. It reports whether the override is now on.
+func (q *QuietSchedule) ToggleOverride(now time.Time) bool {
+	if now.Before(q.overrideUntil) {
+		q.overrideUntil = time.Time{}
+		return false
+	}
+	if !q.scheduledQuiet(now) {
+		return false
+	}
+	q.overrideUntil = q.QuietEnd(now)
+	return true
+}
+
+// Overridden reports whether the quiet time is lifted at t
+func (q *QuietSchedule) Overridden(t time.Time) bool {
+	return t.Before(q.overrideUntil)
+}
+
+// Pauses reports whether the schedule applies to a widget
+func (q *QuietSchedule) Pauses(widget string) bool {
+	return q.widgets == nil || q.widgets[widget]
+}
+
+// AlertCap returns the highest attention level allowed at t
+func (q *QuietSchedule) AlertCap(t time.Time) AttentionLevel {
+	if q.Quiet(t) {
+		return q.alerts
+	}
+	return AttentionNotify
+}
+
+// SetQuietSchedule sets when widgets are not polled; nil polls them at all times
+func (s *Scheduler) SetQuietSchedule(q *QuietSchedule) {
+	s.quiet = q
+}
+
+// Paused reports whether a widget must not be polled at t because of quiet time, or
+// because the dashboard is suspended
+func (s *Scheduler) Paused(id string, t time.Time) bool {
+	return s.Suspended() || (s.quiet != nil && s.quiet.Pauses(id) && s.quiet.Quiet(t))
+}
+
+// fetchWidget returns the widget a fetch message polls
+func fetchWidget(msg tea.Msg) (string, bool) {
+	switch msg := msg.(type) {
+	case fetchWeatherCmd:
+		return "weather", true
+	case fetchNewsCmd:
+		return "news", true
+	case fetchGitCommitsCmd:
+		return "commits", true
+	case fetchGitHubPRsCmd:
+		return "prs", true
+	case fetchTrafficCmd:
+		return "traffic", true
+	case fetchCalendarCmd:
+		return "calendar", true
+	case fetchDiscussionsCmd:
+		return "discussions", true
+	case fetchMentionsCmd:
+		return "mentions", true
+	case fetchStocksCmd:
+		return "stocks", true
+	case fetchCryptoCmd:
+		return "crypto", true
+	case fetchFXCmd:
+		return "fx", true
+	case fetchAQICmd:
+		return "aqi", true
+	case fetchDaylightCmd:
+		return "daylight", true
+	case fetchOnCallCmd:
+		return "pagerduty", true
+	case fetchAlertsCmd:
+		return "alerts", true
+	case fetchStatusPagesCmd:
+		return "status", true
+	case fetchUptimeCmd:
+		return "uptime", true
+	case fetchCertsCmd:
+		return "certs", true
+	case fetchDomainsCmd:
+		return "domains", true
+	case fetchTrendsCmd:
+		return "trends", true
+	case fetchReleasesCmd:
+		return "releases", true
+	case fetchVulnsCmd:
+		return "vulns", true
+	case fetchSystemCmd:
+		return "system", true
+	case fetchNetworkCmd:
+		return "network", true
+	case fetchTogglCmd:
+		return "toggl", true
+	case fetchRemindersCmd:
+		return "reminders", true
+	case fetchChatCmd:
+		return msg.widget, true
+	}
+	return "", false
+}
+
+// widgetFetchMsg returns the message that fetches widget, for widgets refreshed from a
+// plugin
+func widgetFetchMsg(widget string) (tea.Msg, bool) {
+	for _, fetch := range widgetFetchMsgs() {
+		if key, _ := fetchWidget(fetch); key == widget {
+			return fetch, true
+		}
+	}
+	return nil, false
+}
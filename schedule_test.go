@@ -0,0 +1,91 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuietSchedule(t *testing.T) {
+	cfg := &Config{}
+	cfg.Schedule.QuietHours = "19:00-08:00"
+	cfg.Schedule.Weekends = true
+	cfg.Schedule.Widgets = []string{"Mentions", "teams"}
+	quiet, err := NewQuietSchedule(cfg)
+	if err != nil {
+		t.Fatalf("NewQuietSchedule failed: %v", err)
+	}
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC) // 12 Oct 2026 is a Monday
+	}
+	for _, tc := range []struct {
+		at    time.Time
+		quiet bool
+		end   time.Time
+	}{
+		{at(14, 18, 59), false, at(14, 18, 59)},
+		{at(14, 19, 0), true, at(15, 8, 0)},
+		{at(15, 7, 59), true, at(15, 8, 0)},
+		{at(15, 8, 0), false, at(15, 8, 0)},
+		{at(16, 20, 0), true, at(19, 8, 0)}, // Friday night until Monday morning
+		{at(17, 12, 0), true, at(19, 8, 0)}, // Saturday
+	} {
+		if got := quiet.Quiet(tc.at); got != tc.quiet {
+			t.Errorf("Expected quiet=%t at %v, got %t", tc.quiet, tc.at, got)
+		}
+		if got := quiet.QuietEnd(tc.at); !got.Equal(tc.end) {
+			t.Errorf("Expected quiet time at %v to end at %v, got %v", tc.at, tc.end, got)
+		}
+	}
+
+	scheduler := NewScheduler()
+	scheduler.SetQuietSchedule(quiet)
+	if !scheduler.Paused("mentions", at(14, 21, 0)) || scheduler.Paused("weather", at(14, 21, 0)) || scheduler.Paused("mentions", at(14, 12, 0)) {
+		t.Error("Expected only the listed widgets to pause, and only during quiet time")
+	}
+	if quiet.AlertCap(at(14, 21, 0)) != AttentionHighlight || quiet.AlertCap(at(14, 12, 0)) != AttentionNotify {
+		t.Error("Expected alerts to be held back to highlight during quiet time")
+	}
+
+	// Working late lifts the quiet time until it ends, and a second toggle restores it
+	if !quiet.ToggleOverride(at(14, 21, 0)) || quiet.Quiet(at(14, 23, 0)) || !quiet.Overridden(at(15, 7, 0)) {
+		t.Error("Expected the override to lift the quiet time")
+	}
+	if quiet.Quiet(at(15, 7, 59)) || !quiet.Quiet(at(15, 19, 0)) {
+		t.Error("Expected the override to last until the quiet time ends")
+	}
+	quiet.ToggleOverride(at(14, 21, 0))
+	if quiet.ToggleOverride(at(14, 12, 0)) || !quiet.Quiet(at(14, 21, 0)) {
+		t.Error("Expected the override to be restored and not to apply outside quiet time")
+	}
+
+	for _, hours := range []string{"19:00", "7pm-8am"} {
+		cfg.Schedule.QuietHours = hours
+		if _, err := NewQuietSchedule(cfg); err == nil {
+			t.Errorf("Expected quiet_hours %q to fail", hours)
+		}
+	}
+	if quiet, _ := NewQuietSchedule(&Config{}); quiet != nil {
+		t.Error("Expected no schedule without quiet time")
+	}
+}
+
+func TestQuietTimeSkipsPolling(t *testing.T) {
+	cfg := &Config{}
+	cfg.Schedule.QuietHours = "00:00-23:59"
+	quiet, _ := NewQuietSchedule(cfg)
+	scheduler := NewScheduler()
+	scheduler.AddTask("mentions", time.Hour, nil)
+	scheduler.SetQuietSchedule(quiet)
+	m := Model{scheduler: scheduler}
+
+	// Without a mentions tile the handler returns no command, so a command means the
+	// fetch was held back and scheduled again
+	if time.Now().Hour()*60+time.Now().Minute() == 23*60+59 {
+		t.Skip("outside the test's quiet hours")
+	}
+	_, cmd := m.Update(fetchMentionsCmd{})
+	if cmd == nil {
+		t.Fatal("Expected the paused fetch to be scheduled again")
+	}
+}
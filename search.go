@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// searchFormats are the output formats of goday search: text for fzf/dmenu, json for
+// Alfred-style script filters and Raycast, rofi for rofi's script mode
+var searchFormats = []string{"text", "json", "rofi"}
+
+// SearchResult is a widget item matching a launcher query
+type SearchResult struct {
+	Widget   string
+	Title    string
+	Subtitle string
+	URL      string
+}
+
+// launcherItem is an item in the Script Filter JSON format read by Alfred and Raycast
+type launcherItem struct {
+	UID          string `json:"uid"`
+	Title        string `json:"title"`
+	Subtitle     string `json:"subtitle"`
+	Arg          string `json:"arg"`
+	QuickLookURL string `json:"quicklookurl"`
+	Valid        bool   `json:"valid"`
+}
+
+// parseSearchArgs splits the query and the output format from the usual startup flags.
+// Startup flags other than --dry-run take a value, so the word after them is never part
+// of the query.
+func parseSearchArgs(args []string) (string, string, *CLIOptions, error) {
+	format := "text"
+	var query, rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--json":
+			format = "json"
+		case arg == "--rofi":
+			format = "rofi"
+		case arg == "--format" && i+1 < len(args):
+			format = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--format="):
+			format = strings.TrimPrefix(arg, "--format=")
+		case arg == "--dry-run" || arg == "-dry-run":
+			rest = append(rest, arg)
+		case strings.HasPrefix(arg, "-") && !strings.Contains(arg, "="):
+			rest = append(rest, arg)
+			if i+1 < len(args) {
+				rest = append(rest, args[i+1])
+				i++
+			}
+		case strings.HasPrefix(arg, "-"):
+			rest = append(rest, arg)
+		default:
+			query = append(query, arg)
+		}
+	}
+	if !containsString(searchFormats, format) {
+		return "", "", nil, fmt.Errorf("unknown format %q (expected %s)", format, strings.Join(searchFormats, ", "))
+	}
+
+	opts, err := ParseFlags(rest)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return strings.Join(query, " "), format, opts, nil
+}
+
+// searchItems returns the linked items of the visible tiles that contain every word of
+// the query, in tile order. An empty query matches every linked item.
+func (m Model) searchItems(query string) []SearchResult {
+	words := strings.Fields(strings.ToLower(query))
+	var results []SearchResult
+	for _, tile := range m.widgets {
+		for _, listItem := range tile.list.Items() {
+			item, ok := listItem.(WidgetListItem)
+			if !ok || item.URL == "" {
+				continue
+			}
+			text := strings.ToLower(tile.title + " " + item.ItemTitle + " " + item.Subtitle)
+			if !containsAllWords(text, words) {
+				continue
+			}
+			results = append(results, SearchResult{
+				Widget:   tile.title,
+				Title:    item.ItemTitle,
+				Subtitle: item.Subtitle,
+				URL:      item.URL,
+			})
+		}
+	}
+	return results
+}
+
+// containsAllWords reports whether text contains each of the words
+func containsAllWords(text string, words []string) bool {
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// launcherSubtitle prefixes an item's subtitle with its widget
+func launcherSubtitle(result SearchResult) string {
+	if result.Subtitle == "" {
+		return result.Widget
+	}
+	return result.Widget + " • " + result.Subtitle
+}
+
+// formatSearchJSON renders results as Script Filter JSON: {"items": [...]}, with the
+// item URL as the argument the launcher opens
+func formatSearchJSON(results []SearchResult) ([]byte, error) {
+	items := []launcherItem{}
+	for _, result := range results {
+		items = append(items, launcherItem{
+			UID:          result.URL,
+			Title:        result.Title,
+			Subtitle:     launcherSubtitle(result),
+			Arg:          result.URL,
+			QuickLookURL: result.URL,
+			Valid:        true,
+		})
+	}
+	return json.MarshalIndent(map[string][]launcherItem{"items": items}, "", "  ")
+}
+
+// formatSearchRofi renders results as rofi script mode rows. The URL travels in the row's
+// info field, which rofi hands back in ROFI_INFO when the row is picked.
+func formatSearchRofi(results []SearchResult) string {
+	var b strings.Builder
+	for _, result := range results {
+		fmt.Fprintf(&b, "%s  (%s)\x00info\x1f%s\n", xbarText(result.Title), xbarText(launcherSubtitle(result)), result.URL)
+	}
+	return b.String()
+}
+
+// formatSearchText renders results as tab-separated title, subtitle and URL lines
+func formatSearchText(results []SearchResult) string {
+	var b strings.Builder
+	for _, result := range results {
+		fmt.Fprintf(&b, "%s\t%s\t%s\n", xbarText(result.Title), xbarText(launcherSubtitle(result)), result.URL)
+	}
+	return b.String()
+}
+
+// runSearch refreshes every visible widget once and prints the items matching the query
+// for a system launcher
+func runSearch(args []string, out io.Writer) error {
+	query, format, opts, err := parseSearchArgs(args)
+	if err != nil {
+		return err
+	}
+
+	// rofi runs the script again with the picked row; open it instead of searching
+	if format == "rofi" && os.Getenv("ROFI_RETV") == "1" && os.Getenv("ROFI_INFO") != "" {
+		return openURL(os.Getenv("ROFI_INFO"))
+	}
+
+	results := refreshedModel(opts).searchItems(query)
+	switch format {
+	case "json":
+		data, err := formatSearchJSON(results)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	case "rofi":
+		_, err = io.WriteString(out, formatSearchRofi(results))
+	default:
+		_, err = io.WriteString(out, formatSearchText(results))
+	}
+	return err
+}
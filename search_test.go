@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseSearchArgs(t *testing.T) {
+	query, format, opts, err := parseSearchArgs([]string{"login", "--widgets", "prs,news", "--json", "bug"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if query != "login bug" || format != "json" || strings.Join(opts.Widgets, ",") != "prs,news" {
+		t.Errorf("Expected the query, format and widgets, got '%s', '%s' and %v", query, format, opts.Widgets)
+	}
+	if _, format, _, _ := parseSearchArgs([]string{"--rofi"}); format != "rofi" {
+		t.Errorf("Expected the rofi format, got '%s'", format)
+	}
+	if query, _, opts, _ := parseSearchArgs([]string{"--dry-run", "login"}); query != "login" || !opts.DryRun {
+		t.Errorf("Expected --dry-run to take no value, got '%s'", query)
+	}
+	if _, _, _, err := parseSearchArgs([]string{"--format=alfred"}); err == nil {
+		t.Error("Expected an unknown format to fail")
+	}
+}
+
+func TestSearchItems(t *testing.T) {
+	prs := NewWidgetTile("prs", "PRs", 40, 10)
+	prs.UpdateItems([]WidgetItem{
+		{Title: "#1 Fix login", Subtitle: "acme/api", URL: "https://github.com/acme/api/pull/1"},
+		{Title: "#2 Bump deps", Subtitle: "acme/web", URL: "https://github.com/acme/web/pull/2"},
+		{Title: "Login placeholder"},
+	})
+	news := NewWidgetTile("news", "Tech News", 40, 10)
+	news.UpdateItems([]WidgetItem{{Title: "Login flows explained", URL: "https://example.com/login"}})
+	m := Model{widgets: []WidgetTile{prs, news}}
+
+	results := m.searchItems("LOGIN")
+	if len(results) != 2 || results[0].Title != "#1 Fix login" || results[1].Widget != "Tech News" {
+		t.Fatalf("Expected the linked login items in tile order, got %+v", results)
+	}
+	if results := m.searchItems("acme web"); len(results) != 1 || results[0].Title != "#2 Bump deps" {
+		t.Errorf("Expected every word to match, got %+v", results)
+	}
+	if results := m.searchItems("prs"); len(results) != 2 {
+		t.Errorf("Expected the widget title to match, got %+v", results)
+	}
+
+	data, err := formatSearchJSON(results[:1])
+	if err != nil {
+		t.Fatalf("formatSearchJSON failed: %v", err)
+	}
+	var payload struct {
+		Items []launcherItem `json:"items"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("Expected valid JSON: %v", err)
+	}
+	if item := payload.Items[0]; item.Arg != "https://github.com/acme/api/pull/1" || item.Subtitle != "PRs • acme/api" || !item.Valid {
+		t.Errorf("Expected a launcher item opening the PR, got %+v", item)
+	}
+	if data, _ := formatSearchJSON(nil); !strings.Contains(string(data), `"items": []`) {
+		t.Errorf("Expected an empty item list, got %s", data)
+	}
+
+	if row := formatSearchRofi(results[:1]); row != "#1 Fix login  (PRs • acme/api)\x00info\x1fhttps://github.com/acme/api/pull/1\n" {
+		t.Errorf("Expected a rofi row carrying the URL, got %q", row)
+	}
+}
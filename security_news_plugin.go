@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// kevLookback is how far back CISA KEV additions are shown
+	kevLookback = 30 * 24 * time.Hour
+	// nvdLookback is how far back newly published NVD CVEs are searched
+	nvdLookback = 7 * 24 * time.Hour
+)
+
+// SecurityNewsPlugin shows security advisories: vulnerabilities CISA added to its Known
+// Exploited Vulnerabilities catalog, and CVEs newly published in the NVD, filtered by
+// the configured keywords. Each keyword is a tag, so t narrows the list to one.
+type SecurityNewsPlugin struct {
+	*BaseNewsPlugin
+	kevURL    string
+	nvdURL    string
+	nvdAPIKey string
+	keywords  []string
+	now       func() time.Time
+	kevETag   string // of the cached catalog, to download it only after it changes
+	kevCache  []kevVulnerability
+}
+
+// NewSecurityNewsPlugin creates a new security advisories plugin. The NVD API key falls
+// back to NVD_API_KEY.
+func NewSecurityNewsPlugin() *SecurityNewsPlugin {
+	base := NewBaseNewsPlugin(
+		"security",
+		"Security Advisories",
+		"1.0.0",
+		"Fetches CISA known exploited vulnerabilities and new NVD CVEs matching keywords",
+		"GoDay Team",
+	)
+	base.supportedTags = []string{"all"}
+
+	return &SecurityNewsPlugin{
+		BaseNewsPlugin: base,
+		kevURL:         "https://www.cisa.gov/sites/default/files/feeds/known_exploited_vulnerabilities.json",
+		nvdURL:         "https://services.nvd.nist.gov/rest/json/cves/2.0",
+		nvdAPIKey:      os.Getenv("NVD_API_KEY"),
+		now:            time.Now,
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (sp *SecurityNewsPlugin) Initialize(config map[string]interface{}) error {
+	if tags := configStringList(config["tags"]); tags != nil {
+		sp.SetTags(tags)
+	}
+	if currentTag, ok := config["current_tag"].(string); ok {
+		sp.SetCurrentTag(currentTag)
+	}
+	if keywords := configStringList(config["security_keywords"]); len(keywords) > 0 {
+		sp.keywords = keywords
+	}
+	if key, ok := config["nvd_api_key"].(string); ok && key != "" {
+		sp.nvdAPIKey = key
+	}
+	sp.supportedTags = append([]string{"all"}, sp.keywords...)
+	return nil
+}
+
+// kevVulnerability is an entry of the CISA KEV catalog
+type kevVulnerability struct {
+	CVE                string `json:"cveID"`
+	Vendor             string `json:"vendorProject"`
+	Product            string `json:"product"`
+	Name               string `json:"vulnerabilityName"`
+	DateAdded          string `json:"dateAdded"`
+	Description        string `json:"shortDescription"`
+	KnownRansomwareUse string `json:"knownRansomwareCampaignUse"`
+}
+
+// nvdCVE is a CVE as the NVD API 2.0 returns it
+type nvdCVE struct {
+	ID           string `json:"id"`
+	Published    string `json:"published"`
+	Descriptions []struct {
+		Lang  string `json:"lang"`
+		Value string `json:"value"`
+	} `json:"descriptions"`
+	Metrics map[string][]struct {
+		CVSSData struct {
+			BaseScore    float64 `json:"baseScore"`
+			BaseSeverity string  `json:"baseSeverity"`
+		} `json:"cvssData"`
+	} `json:"metrics"`
+}
+
+// Fetch returns the recent advisories matching the keywords, newest first. Without
+// keywords only KEV additions are shown, as the NVD publishes hundreds of CVEs a day.
+// A feed that fails is left out as long as the other returns something.
+func (sp *SecurityNewsPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	keywords := sp.keywords
+	if tag := sp.GetCurrentTag(); containsString(sp.keywords, tag) {
+		keywords = []string{tag}
+	}
+
+	seen := make(map[string]bool)
+	var items []NewsItem
+	kevItems, kevErr := sp.fetchKEV(ctx, keywords)
+	for _, item := range kevItems {
+		seen[item.ObjectID] = true
+		items = append(items, item)
+	}
+	var nvdErr error
+	for _, keyword := range keywords {
+		var nvdItems []NewsItem
+		nvdItems, nvdErr = sp.fetchNVD(ctx, keyword)
+		if nvdErr != nil {
+			break
+		}
+		for _, item := range nvdItems {
+			// KEV entries say more: the CVE is being exploited
+			if !seen[item.ObjectID] {
+				seen[item.ObjectID] = true
+				items = append(items, item)
+			}
+		}
+	}
+	if len(items) == 0 {
+		if kevErr != nil {
+			return sp.lastData, kevErr
+		}
+		if nvdErr != nil {
+			return sp.lastData, nvdErr
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].CreatedAt > items[j].CreatedAt })
+	filtered := sp.filterByCurrentTag(items)
+	if len(filtered) > 12 {
+		filtered = filtered[:12]
+	}
+
+	sp.lastData = filtered
+	return filtered, nil
+}
+
+// fetchKEV returns the catalog entries added within kevLookback that mention a keyword,
+// or all of them without keywords. The catalog is only downloaded again once it changed.
+func (sp *SecurityNewsPlugin) fetchKEV(ctx context.Context, keywords []string) ([]NewsItem, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", sp.kevURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if sp.kevETag != "" {
+		req.Header.Set("If-None-Match", sp.kevETag)
+	}
+	resp, err := sp.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+	case http.StatusOK:
+		var catalog struct {
+			Vulnerabilities []kevVulnerability `json:"vulnerabilities"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&catalog); err != nil {
+			return nil, fmt.Errorf("reading the CISA KEV catalog: %w", err)
+		}
+		sp.kevCache = catalog.Vulnerabilities
+		sp.kevETag = resp.Header.Get("ETag")
+	default:
+		return nil, fmt.Errorf("CISA KEV catalog returned status %d", resp.StatusCode)
+	}
+
+	since := sp.now().Add(-kevLookback)
+	var items []NewsItem
+	for _, vuln := range sp.kevCache {
+		added, err := time.Parse("2006-01-02", vuln.DateAdded)
+		if err != nil || added.Before(since) {
+			continue
+		}
+		matched := matchingKeywords(keywords, vuln.Vendor, vuln.Product, vuln.Name, vuln.Description)
+		if len(keywords) > 0 && len(matched) == 0 {
+			continue
+		}
+		feed := "Known exploited"
+		if vuln.KnownRansomwareUse == "Known" {
+			feed += ", ransomware"
+		}
+		items = append(items, NewsItem{
+			Title:       vuln.CVE + " " + vuln.Name,
+			URL:         "https://nvd.nist.gov/vuln/detail/" + vuln.CVE,
+			Author:      "CISA KEV",
+			CreatedAt:   added.Unix(),
+			ObjectID:    vuln.CVE,
+			Source:      "security",
+			Feed:        feed,
+			Description: vuln.Description,
+			Tags:        matched,
+		})
+	}
+	return items, nil
+}
+
+// fetchNVD returns the CVEs published within nvdLookback that match a keyword search
+func (sp *SecurityNewsPlugin) fetchNVD(ctx context.Context, keyword string) ([]NewsItem, error) {
+	now := sp.now().UTC()
+	params := url.Values{}
+	params.Set("keywordSearch", keyword)
+	params.Set("pubStartDate", now.Add(-nvdLookback).Format("2006-01-02T15:04:05.000Z"))
+	params.Set("pubEndDate", now.Format("2006-01-02T15:04:05.000Z"))
+	params.Set("resultsPerPage", "50")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", sp.nvdURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if sp.nvdAPIKey != "" {
+		req.Header.Set("apiKey", sp.nvdAPIKey)
+	}
+	resp, err := sp.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// Without an API key the NVD allows 5 requests in 30 seconds
+		return nil, fmt.Errorf("NVD API returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Vulnerabilities []struct {
+			CVE nvdCVE `json:"cve"`
+		} `json:"vulnerabilities"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("reading NVD CVEs: %w", err)
+	}
+
+	var items []NewsItem
+	for _, vuln := range result.Vulnerabilities {
+		cve := vuln.CVE
+		var createdAt int64
+		if published, err := time.Parse("2006-01-02T15:04:05.000", cve.Published); err == nil {
+			createdAt = published.Unix()
+		}
+		description := ""
+		for _, d := range cve.Descriptions {
+			if d.Lang == "en" {
+				description = strings.Join(strings.Fields(d.Value), " ")
+				break
+			}
+		}
+		items = append(items, NewsItem{
+			Title:       cve.ID + " " + description,
+			URL:         "https://nvd.nist.gov/vuln/detail/" + cve.ID,
+			Author:      "NVD",
+			CreatedAt:   createdAt,
+			ObjectID:    cve.ID,
+			Source:      "security",
+			Feed:        cve.severity(),
+			Description: description,
+			Tags:        []string{keyword},
+		})
+	}
+	return items, nil
+}
+
+// severity returns the CVE's severity and score by the newest CVSS version scored, e.g.
+// "CRITICAL 9.8"; empty while it awaits analysis
+func (c nvdCVE) severity() string {
+	for _, version := range []string{"cvssMetricV40", "cvssMetricV31", "cvssMetricV30"} {
+		if metrics := c.Metrics[version]; len(metrics) > 0 {
+			return fmt.Sprintf("%s %.1f", metrics[0].CVSSData.BaseSeverity, metrics[0].CVSSData.BaseScore)
+		}
+	}
+	return ""
+}
+
+// matchingKeywords returns the keywords any of the fields mention, ignoring case
+func matchingKeywords(keywords []string, fields ...string) []string {
+	text := strings.ToLower(strings.Join(fields, " "))
+	var matched []string
+	for _, keyword := range keywords {
+		if strings.Contains(text, strings.ToLower(keyword)) {
+			matched = append(matched, keyword)
+		}
+	}
+	return matched
+}
+
+// formatSecurityItem shows how serious an advisory is, where it came from and its age
+func formatSecurityItem(news NewsItem) WidgetItem {
+	status := ""
+	switch {
+	case news.Author == "CISA KEV":
+		status = "🔥"
+	case strings.HasPrefix(news.Feed, "CRITICAL"):
+		status = "🔴"
+	case strings.HasPrefix(news.Feed, "HIGH"):
+		status = "🟠"
+	}
+	parts := []string{}
+	if news.Feed != "" {
+		parts = append(parts, news.Feed)
+	}
+	parts = append(parts, news.Author, formatTimeAgo(time.Unix(news.CreatedAt, 0)))
+	return WidgetItem{Title: news.Title, Subtitle: strings.Join(parts, " • "), Status: status, URL: news.URL}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const kevTestCatalog = `{"catalogVersion":"2026.10.15","vulnerabilities":[
+	{"cveID":"CVE-2026-1111","vendorProject":"OpenSSL","product":"OpenSSL","vulnerabilityName":"OpenSSL Buffer Overflow","dateAdded":"2026-10-14","shortDescription":"OpenSSL contains a buffer overflow.","knownRansomwareCampaignUse":"Known"},
+	{"cveID":"CVE-2026-2222","vendorProject":"Microsoft","product":"Windows","vulnerabilityName":"Windows Privilege Escalation","dateAdded":"2026-10-12","shortDescription":"Windows contains a privilege escalation.","knownRansomwareCampaignUse":"Unknown"},
+	{"cveID":"CVE-2020-3333","vendorProject":"OpenSSL","product":"OpenSSL","vulnerabilityName":"OpenSSL Old Bug","dateAdded":"2022-01-10","shortDescription":"Old.","knownRansomwareCampaignUse":"Unknown"}]}`
+
+const nvdTestResult = `{"vulnerabilities":[
+	{"cve":{"id":"CVE-2026-1111","published":"2026-10-13T10:00:00.000","descriptions":[{"lang":"en","value":"Buffer overflow in OpenSSL."}]}},
+	{"cve":{"id":"CVE-2026-4444","published":"2026-10-15T08:30:00.000","descriptions":[{"lang":"es","value":"Desbordamiento"},{"lang":"en","value":"Denial of  service in\nOpenSSL."}],
+		"metrics":{"cvssMetricV31":[{"cvssData":{"baseScore":7.5,"baseSeverity":"HIGH"}}]}}}]}`
+
+func TestSecurityNewsPluginFetch(t *testing.T) {
+	kevRequests, nvdKeywords := 0, []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/kev":
+			kevRequests++
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			fmt.Fprint(w, kevTestCatalog)
+		case "/nvd":
+			nvdKeywords = append(nvdKeywords, r.URL.Query().Get("keywordSearch"))
+			if r.URL.Query().Get("keywordSearch") == "openssl" {
+				fmt.Fprint(w, nvdTestResult)
+				return
+			}
+			fmt.Fprint(w, `{"vulnerabilities":[]}`)
+		}
+	}))
+	defer server.Close()
+
+	plugin := NewSecurityNewsPlugin()
+	plugin.kevURL = server.URL + "/kev"
+	plugin.nvdURL = server.URL + "/nvd"
+	plugin.now = func() time.Time { return time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC) }
+	if err := plugin.Initialize(map[string]interface{}{"security_keywords": []string{"openssl", "kubernetes"}, "current_tag": "all"}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if tags := plugin.GetSupportedTags(); strings.Join(tags, ",") != "all,openssl,kubernetes" {
+		t.Errorf("Expected a tag per keyword, got %v", tags)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	items := data.([]NewsItem)
+	if len(items) != 2 || items[0].ObjectID != "CVE-2026-4444" || items[1].ObjectID != "CVE-2026-1111" {
+		t.Fatalf("Expected the new NVD CVE, then the recent KEV entry for OpenSSL once, got %+v", items)
+	}
+	if items[1].Author != "CISA KEV" || items[0].Feed != "HIGH 7.5" || items[0].Description != "Denial of service in OpenSSL." {
+		t.Errorf("Expected KEV to win the duplicate and the NVD severity and English description, got %+v", items)
+	}
+	if strings.Join(nvdKeywords, ",") != "openssl,kubernetes" {
+		t.Errorf("Expected an NVD search per keyword, got %v", nvdKeywords)
+	}
+
+	// The catalog is not downloaded again while unchanged, and a tag narrows the searches
+	plugin.SetCurrentTag("kubernetes")
+	nvdKeywords = nil
+	data, err = plugin.Fetch(context.Background())
+	if err != nil || len(data.([]NewsItem)) != 0 || kevRequests != 2 || strings.Join(nvdKeywords, ",") != "kubernetes" {
+		t.Errorf("Expected no kubernetes advisories from one search, got %v, %v and %v", data, err, nvdKeywords)
+	}
+
+	item := formatSecurityItem(items[1])
+	if item.Status != "🔥" || !strings.HasPrefix(item.Subtitle, "Known exploited, ransomware • CISA KEV • ") {
+		t.Errorf("Expected an exploited vulnerability used by ransomware, got %+v", item)
+	}
+}
+
+func TestSecurityNewsPluginWithoutKeywords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/kev" {
+			t.Errorf("Expected no NVD search without keywords, got %s", r.URL.Path)
+		}
+		fmt.Fprint(w, kevTestCatalog)
+	}))
+	defer server.Close()
+
+	plugin := NewSecurityNewsPlugin()
+	plugin.kevURL = server.URL + "/kev"
+	plugin.nvdURL = server.URL + "/nvd"
+	plugin.now = func() time.Time { return time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC) }
+	plugin.Initialize(map[string]interface{}{})
+
+	data, err := plugin.Fetch(context.Background())
+	if items := data.([]NewsItem); err != nil || len(items) != 2 {
+		t.Errorf("Expected every KEV addition of the last 30 days, got %v and %v", items, err)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Files of the last session directory
+const (
+	lastSessionState = "state.json"    // every item of every tile, as DashboardState
+	lastSessionText  = "dashboard.txt" // the dashboard as last drawn, without colors
+)
+
+// LastSessionDir returns where the dashboard is saved on quit: ui.last_session, or
+// ~/.goday/last-session by default. It returns "" when turned off with last_session: off.
+func LastSessionDir(cfg *Config) string {
+	dir := ""
+	if cfg != nil {
+		dir = cfg.UI.LastSession
+	}
+	if dir == "off" {
+		return ""
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return dir
+	}
+	if dir == "" {
+		return filepath.Join(homeDir, ".goday", "last-session")
+	}
+	if strings.HasPrefix(dir, "~/") {
+		return filepath.Join(homeDir, dir[2:])
+	}
+	return dir
+}
+
+// SaveLastSession writes the dashboard as it was at quit: its state with every item, for
+// the next start to show, and the last drawn screen as text
+func (m Model) SaveLastSession(dir string, now time.Time) error {
+	data, err := json.Marshal(m.captureState(now, 0))
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(dir, lastSessionState), data, 0600); err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, lastSessionText), []byte(ansi.Strip(m.View())+"\n"), 0600)
+}
+
+// LoadLastSession reads the state saved by the last session in dir
+func LoadLastSession(dir string) (*DashboardState, error) {
+	data, err := os.ReadFile(filepath.Join(dir, lastSessionState))
+	if err != nil {
+		return nil, err
+	}
+	var state DashboardState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// restoreLastSession shows the last session's items in the tiles that will refresh,
+// until they do. Tiles with demo data, or without the program they need, keep theirs.
+func restoreLastSession(tiles []WidgetTile, state *DashboardState, capabilities *Capabilities) {
+	fetched := make(map[string]bool)
+	for _, msg := range widgetFetchMsgs() {
+		widget, _ := fetchWidget(msg)
+		fetched[widget] = true
+	}
+	for i := range tiles {
+		tile := &tiles[i]
+		widget, ok := state.Widgets[tile.key]
+		if _, missing := capabilities.MissingFor(tile.key); !ok || !fetched[tile.key] || missing || widget.Error {
+			continue
+		}
+		items := make([]WidgetItem, 0, len(widget.Items))
+		for _, item := range widget.Items {
+			items = append(items, WidgetItem{Title: item.Title, Subtitle: item.Subtitle, Status: item.Status, URL: item.URL})
+		}
+		tile.UpdateItems(items)
+		tile.lastSession = true
+	}
+}
+
+// lastSessionPill says when the items still shown from the last session are from, or ""
+// once every tile has refreshed
+func (m Model) lastSessionPill(now time.Time) string {
+	waiting := 0
+	for _, tile := range m.widgets {
+		if tile.lastSession {
+			waiting++
+		}
+	}
+	if waiting == 0 {
+		return ""
+	}
+	when := m.lastSessionAt.Format("Mon 15:04")
+	if m.lastSessionAt.Format("2006-01-02") == now.Format("2006-01-02") {
+		when = m.lastSessionAt.Format("15:04")
+	}
+	if waiting == 1 {
+		return "🕘 1 tile from " + when
+	}
+	return "🕘 " + strconv.Itoa(waiting) + " tiles from " + when
+}
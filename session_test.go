@@ -0,0 +1,77 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLastSessionDir(t *testing.T) {
+	home, _ := os.UserHomeDir()
+	if dir := LastSessionDir(nil); dir != filepath.Join(home, ".goday", "last-session") {
+		t.Errorf("Expected the default directory, got '%s'", dir)
+	}
+	cfg := &Config{}
+	cfg.UI.LastSession = "~/sessions"
+	if dir := LastSessionDir(cfg); dir != filepath.Join(home, "sessions") {
+		t.Errorf("Expected ~ to be expanded, got '%s'", dir)
+	}
+	cfg.UI.LastSession = "off"
+	if dir := LastSessionDir(cfg); dir != "" {
+		t.Errorf("Expected off to turn the snapshot off, got '%s'", dir)
+	}
+}
+
+func TestLastSessionRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	quitAt := time.Date(2026, 10, 16, 17, 42, 0, 0, time.Local)
+	if err := benchmarkModel(120, 40).SaveLastSession(dir, quitAt); err != nil {
+		t.Fatalf("SaveLastSession failed: %v", err)
+	}
+
+	text, err := os.ReadFile(filepath.Join(dir, lastSessionText))
+	if err != nil {
+		t.Fatalf("Expected the dashboard text to be written: %v", err)
+	}
+	if !strings.Contains(string(text), "Test User") || strings.Contains(string(text), "\x1b[") {
+		t.Errorf("Expected the dashboard as plain text, got:\n%s", text)
+	}
+
+	state, err := LoadLastSession(dir)
+	if err != nil {
+		t.Fatalf("LoadLastSession failed: %v", err)
+	}
+	// The snapshot keeps every item, not just the state file's first few
+	if items := state.Widgets["prs"].Items; len(items) != 12 {
+		t.Errorf("Expected all 12 PRs to be saved, got %d", len(items))
+	}
+
+	// The next start shows the saved items in tiles that refresh, until they do
+	tiles := []WidgetTile{
+		NewWidgetTile("prs", "PRs", baseTileWidth, baseTileHeight),
+		NewWidgetTile("jira", "JIRA", baseTileWidth, baseTileHeight),
+		NewWidgetTile("commits", "Commits", baseTileWidth, baseTileHeight),
+	}
+	restoreLastSession(tiles, state, DetectCapabilities("linux", lookPathWithout("git")))
+	if !tiles[0].lastSession || len(tiles[0].list.Items()) != 12 {
+		t.Errorf("Expected the saved PRs to be shown, got %d items", len(tiles[0].list.Items()))
+	}
+	if tiles[1].lastSession {
+		t.Error("Expected a tile with demo data to keep it")
+	}
+	if tiles[2].lastSession {
+		t.Error("Expected a tile missing its program to keep saying so")
+	}
+
+	m := Model{widgets: tiles, lastSessionAt: quitAt}
+	monday := quitAt.Add(62 * time.Hour)
+	if pill := m.lastSessionPill(monday); pill != "🕘 1 tile from Fri 17:42" {
+		t.Errorf("Expected the pill to say when the tile is from, got '%s'", pill)
+	}
+	m.widgets[0].UpdateItems([]WidgetItem{{Title: "Fresh PR"}})
+	if pill := m.lastSessionPill(monday); pill != "" {
+		t.Errorf("Expected no pill once every tile refreshed, got '%s'", pill)
+	}
+}
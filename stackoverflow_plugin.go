@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StackOverflowPlugin shows newest or unanswered questions for the news tags from a
+// Stack Exchange site, plus your unread inbox and reputation when a token is set
+type StackOverflowPlugin struct {
+	*BaseNewsPlugin
+	apiURL       string
+	site         string
+	sort         string // newest or unanswered
+	questionTags []string
+	key          string
+	accessToken  string
+	backoffUntil time.Time
+	rateLimitTracker
+}
+
+// stackExchangeResponse is the wrapper around every Stack Exchange API response
+type stackExchangeResponse struct {
+	Items          json.RawMessage `json:"items"`
+	QuotaMax       int             `json:"quota_max"`
+	QuotaRemaining int             `json:"quota_remaining"`
+	Backoff        int             `json:"backoff"`
+	ErrorID        int             `json:"error_id"`
+	ErrorMessage   string          `json:"error_message"`
+}
+
+// NewStackOverflowPlugin creates a new Stack Overflow plugin
+func NewStackOverflowPlugin() *StackOverflowPlugin {
+	base := NewBaseNewsPlugin(
+		"stackoverflow",
+		"Stack Overflow",
+		"1.0.0",
+		"Shows Stack Exchange questions by tag and your inbox",
+		"GoDay Team",
+	)
+	base.supportedTags = []string{"all"}
+
+	return &StackOverflowPlugin{
+		BaseNewsPlugin: base,
+		apiURL:         "https://api.stackexchange.com/2.3",
+		site:           "stackoverflow",
+		sort:           "newest",
+		accessToken:    os.Getenv("STACKEXCHANGE_ACCESS_TOKEN"),
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (sp *StackOverflowPlugin) Initialize(config map[string]interface{}) error {
+	if tags := configStringList(config["tags"]); tags != nil {
+		sp.SetTags(tags)
+	}
+	if currentTag, ok := config["current_tag"].(string); ok {
+		sp.SetCurrentTag(currentTag)
+	}
+	if site, ok := config["site"].(string); ok && site != "" {
+		sp.site = site
+	}
+	if order, ok := config["sort"].(string); ok && order != "" {
+		if order != "newest" && order != "unanswered" {
+			return fmt.Errorf("invalid sort %q (expected newest or unanswered)", order)
+		}
+		sp.sort = order
+	}
+	if key, ok := config["key"].(string); ok {
+		sp.key = key
+	}
+	if token, ok := config["access_token"].(string); ok && token != "" {
+		sp.accessToken = token
+	}
+	sp.questionTags = configStringList(config["question_tags"])
+	sp.supportedTags = append([]string{"all"}, sp.questionTags...)
+	return nil
+}
+
+// Fetch returns the inbox and reputation items (when a token and key are set) followed
+// by questions for each tag, newest first. Selecting a tag with t narrows to that tag.
+func (sp *StackOverflowPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	// The API asks clients to pause after heavy use
+	if time.Now().Before(sp.backoffUntil) {
+		return sp.lastData, nil
+	}
+
+	var items []NewsItem
+	if sp.accessToken != "" && sp.key != "" {
+		inbox, err := sp.fetchInbox(ctx)
+		if err != nil {
+			return sp.lastData, err
+		}
+		items = append(items, inbox...)
+	}
+
+	tags := sp.questionTags
+	if len(tags) == 0 {
+		tags = sp.tags
+	}
+	if tag := sp.GetCurrentTag(); tag != "all" && tag != "" {
+		tags = []string{tag}
+	}
+
+	path := "/questions"
+	if sp.sort == "unanswered" {
+		path = "/questions/unanswered"
+	}
+
+	var questions []NewsItem
+	for _, tag := range tags {
+		var found []struct {
+			Title        string   `json:"title"`
+			Link         string   `json:"link"`
+			Score        int      `json:"score"`
+			AnswerCount  int      `json:"answer_count"`
+			CreationDate int64    `json:"creation_date"`
+			Tags         []string `json:"tags"`
+			Owner        struct {
+				DisplayName string `json:"display_name"`
+			} `json:"owner"`
+		}
+		params := url.Values{}
+		params.Set("tagged", tag)
+		params.Set("sort", "creation")
+		params.Set("order", "desc")
+		params.Set("pagesize", "10")
+		if err := sp.get(ctx, path, params, &found); err != nil {
+			return sp.lastData, fmt.Errorf("[%s]: %w", tag, err)
+		}
+		for _, q := range found {
+			questions = append(questions, NewsItem{
+				Title:       html.UnescapeString(q.Title),
+				URL:         q.Link,
+				Points:      q.Score,
+				Author:      html.UnescapeString(q.Owner.DisplayName),
+				CreatedAt:   q.CreationDate,
+				Source:      "stackoverflow",
+				Description: fmt.Sprintf("%d answers", q.AnswerCount),
+				Tags:        q.Tags,
+			})
+		}
+	}
+	sort.SliceStable(questions, func(i, j int) bool {
+		return questions[i].CreatedAt > questions[j].CreatedAt
+	})
+	items = append(items, dedupeNewsItems(questions, items)...)
+
+	if len(items) > 12 {
+		items = items[:12]
+	}
+
+	sp.lastData = items
+	return items, nil
+}
+
+// fetchInbox returns unread inbox items and a summary of the last day's reputation changes
+func (sp *StackOverflowPlugin) fetchInbox(ctx context.Context) ([]NewsItem, error) {
+	var inbox []struct {
+		Title        string `json:"title"`
+		Link         string `json:"link"`
+		ItemType     string `json:"item_type"`
+		CreationDate int64  `json:"creation_date"`
+	}
+	if err := sp.get(ctx, "/me/inbox/unread", url.Values{}, &inbox); err != nil {
+		return nil, fmt.Errorf("inbox: %w", err)
+	}
+
+	var items []NewsItem
+	for _, message := range inbox {
+		items = append(items, NewsItem{
+			Title:     html.UnescapeString(message.Title),
+			URL:       message.Link,
+			Author:    message.ItemType,
+			CreatedAt: message.CreationDate,
+			Source:    "stackoverflow-inbox",
+		})
+	}
+
+	var me []struct {
+		Reputation int    `json:"reputation"`
+		Link       string `json:"link"`
+	}
+	if err := sp.get(ctx, "/me", url.Values{}, &me); err != nil || len(me) == 0 {
+		return items, err
+	}
+	var changes []struct {
+		ReputationChange int `json:"reputation_change"`
+	}
+	since := time.Now().Add(-24 * time.Hour)
+	if err := sp.get(ctx, "/me/reputation", url.Values{"fromdate": {strconv.FormatInt(since.Unix(), 10)}}, &changes); err != nil {
+		return items, fmt.Errorf("reputation: %w", err)
+	}
+
+	delta := 0
+	for _, change := range changes {
+		delta += change.ReputationChange
+	}
+	if delta != 0 {
+		items = append(items, NewsItem{
+			Title:     fmt.Sprintf("Reputation %d (%+d today)", me[0].Reputation, delta),
+			URL:       me[0].Link + "?tab=reputation",
+			Points:    delta,
+			CreatedAt: time.Now().Unix(),
+			Source:    "stackoverflow-reputation",
+		})
+	}
+	return items, nil
+}
+
+// get performs a Stack Exchange API request for the configured site and decodes its items.
+// The daily quota reported in the body is tracked as the plugin's rate limit.
+func (sp *StackOverflowPlugin) get(ctx context.Context, path string, params url.Values, target interface{}) error {
+	params.Set("site", sp.site)
+	if sp.key != "" {
+		params.Set("key", sp.key)
+	}
+	if sp.accessToken != "" && sp.key != "" {
+		params.Set("access_token", sp.accessToken)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", sp.apiURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	// The client transparently decompresses the gzip responses
+	resp, err := sp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var response stackExchangeResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("Stack Exchange API returned status %d", resp.StatusCode)
+	}
+	if response.QuotaMax > 0 {
+		// Quotas reset at midnight UTC
+		now := time.Now().UTC()
+		reset := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+		sp.recordRateLimit(RateLimit{Limit: response.QuotaMax, Remaining: response.QuotaRemaining, Reset: reset})
+	}
+	if response.Backoff > 0 {
+		sp.backoffUntil = time.Now().Add(time.Duration(response.Backoff) * time.Second)
+	}
+	if response.ErrorID != 0 {
+		return fmt.Errorf("Stack Exchange API error %d: %s", response.ErrorID, response.ErrorMessage)
+	}
+	return json.Unmarshal(response.Items, target)
+}
+
+// formatStackOverflowItem shows the asker, votes, answers and age of a question
+func formatStackOverflowItem(news NewsItem) WidgetItem {
+	return WidgetItem{
+		Title:    news.Title,
+		Subtitle: fmt.Sprintf("%s • %d votes, %s • %s", news.Author, news.Points, news.Description, formatTimeAgo(time.Unix(news.CreatedAt, 0))),
+		URL:      news.URL,
+	}
+}
+
+// formatStackOverflowInboxItem flags an unread inbox item like an unread chat message
+func formatStackOverflowInboxItem(news NewsItem) WidgetItem {
+	return WidgetItem{
+		Title:    news.Title,
+		Subtitle: fmt.Sprintf("%s • %s", strings.ReplaceAll(news.Author, "_", " "), formatTimeAgo(time.Unix(news.CreatedAt, 0))),
+		Status:   "💬",
+		URL:      news.URL,
+	}
+}
+
+// formatStackOverflowReputationItem marks the reputation summary
+func formatStackOverflowReputationItem(news NewsItem) WidgetItem {
+	return WidgetItem{Title: news.Title, Subtitle: news.Author, Status: "🏆", URL: news.URL}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStackOverflowPluginFetch(t *testing.T) {
+	now := time.Now().Unix()
+	var paths []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path+" "+r.URL.Query().Get("tagged"))
+		if r.URL.Query().Get("site") != "serverfault" {
+			t.Errorf("Expected site 'serverfault', got '%s'", r.URL.Query().Get("site"))
+		}
+		quota := `"quota_max":10000,"quota_remaining":9876`
+		switch r.URL.Path {
+		case "/questions/unanswered":
+			tag := r.URL.Query().Get("tagged")
+			fmt.Fprintf(w, `{"items":[{"title":"Why doesn&#39;t %s work?","link":"https://serverfault.com/q/%s","score":3,"answer_count":0,"creation_date":%d,"tags":[%q],"owner":{"display_name":"J&#246;rg"}}],%s}`,
+				tag, tag, now-int64(len(tag)), tag, quota)
+		case "/me/inbox/unread":
+			if r.URL.Query().Get("access_token") != "token" || r.URL.Query().Get("key") != "key" {
+				t.Error("Expected the inbox to be requested with the token and key")
+			}
+			fmt.Fprintf(w, `{"items":[{"title":"Re: nginx","link":"https://serverfault.com/a/1","item_type":"new_answer","creation_date":%d}],%s}`, now, quota)
+		case "/me":
+			fmt.Fprintf(w, `{"items":[{"reputation":1234,"link":"https://serverfault.com/users/1/me"}],%s}`, quota)
+		case "/me/reputation":
+			fmt.Fprintf(w, `{"items":[{"reputation_change":10},{"reputation_change":25},{"reputation_change":-2}],%s}`, quota)
+		default:
+			fmt.Fprint(w, `{"error_id":404,"error_message":"no method found with this name"}`)
+		}
+	}))
+	defer server.Close()
+
+	plugin := NewStackOverflowPlugin()
+	plugin.apiURL = server.URL
+	err := plugin.Initialize(map[string]interface{}{
+		"tags":          []string{"nginx", "dns"},
+		"current_tag":   "all",
+		"site":          "serverfault",
+		"sort":          "unanswered",
+		"key":           "key",
+		"access_token":  "token",
+		"question_tags": []string{},
+	})
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	items := data.([]NewsItem)
+	if len(items) != 4 {
+		t.Fatalf("Expected an inbox item, a reputation summary and 2 questions, got %d: %+v", len(items), items)
+	}
+	if items[0].Source != "stackoverflow-inbox" || items[0].Author != "new_answer" {
+		t.Errorf("Expected the unread inbox item first, got %+v", items[0])
+	}
+	if items[1].Title != "Reputation 1234 (+33 today)" || items[1].URL != "https://serverfault.com/users/1/me?tab=reputation" {
+		t.Errorf("Expected a reputation summary, got %+v", items[1])
+	}
+	if items[2].Title != "Why doesn't dns work?" || items[2].Author != "Jörg" || items[2].Description != "0 answers" {
+		t.Errorf("Expected the newest question unescaped, got %+v", items[2])
+	}
+	if rl, ok := plugin.RateLimit(); !ok || rl.Limit != 10000 || rl.Remaining != 9876 {
+		t.Errorf("Expected the quota to be tracked, got %+v", rl)
+	}
+
+	// Selecting a tag with t narrows to that tag
+	paths = nil
+	plugin.accessToken = ""
+	plugin.SetCurrentTag("dns")
+	data, _ = plugin.Fetch(context.Background())
+	if items := data.([]NewsItem); len(items) != 1 || len(paths) != 1 || paths[0] != "/questions/unanswered dns" {
+		t.Errorf("Expected only the dns questions, got %+v from %v", items, paths)
+	}
+}
+
+func TestStackOverflowPluginErrorsAndBackoff(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"items":[],"backoff":60,"quota_max":300,"quota_remaining":200}`)
+	}))
+	defer server.Close()
+
+	plugin := NewStackOverflowPlugin()
+	plugin.apiURL = server.URL
+	if err := plugin.Initialize(map[string]interface{}{"sort": "hot"}); err == nil {
+		t.Error("Expected an unknown sort to fail")
+	}
+	plugin.Initialize(map[string]interface{}{"tags": []string{"go"}})
+
+	plugin.Fetch(context.Background())
+	plugin.Fetch(context.Background())
+	if requests != 1 {
+		t.Errorf("Expected the backoff to skip the second fetch, got %d requests", requests)
+	}
+
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error_id":400,"error_message":"site is required"}`)
+	}))
+	defer errorServer.Close()
+	plugin.apiURL = errorServer.URL
+	plugin.backoffUntil = time.Time{}
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected an API error to be returned")
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Startup fetch priorities: high widgets fetch first, lazy ones once the dashboard is
+// interactive, normal ones in between
+const (
+	priorityHigh   = "high"
+	priorityNormal = "normal"
+	priorityLazy   = "lazy"
+)
+
+// lazyStartupDelay is how long lazy widgets wait after the other first fetches, so keys
+// work before they fetch
+const lazyStartupDelay = 3 * time.Second
+
+// defaultFetchPriorities puts the widgets needed first thing, the next meeting and
+// incidents, ahead and leaves the ones read at leisure until later
+var defaultFetchPriorities = map[string]string{
+	"calendar":  priorityHigh,
+	"pagerduty": priorityHigh,
+	"alerts":    priorityHigh,
+	"news":      priorityLazy,
+	"stocks":    priorityLazy,
+	"crypto":    priorityLazy,
+	"fx":        priorityLazy,
+	"domains":   priorityLazy,
+}
+
+// NewFetchPriorities returns the startup priority of each widget that is not normal:
+// the defaults with schedule.priorities laid over them. Bad entries are reported
+// together and skipped; the valid ones still apply.
+func NewFetchPriorities(cfg *Config) (map[string]string, error) {
+	priorities := make(map[string]string)
+	for widget, priority := range defaultFetchPriorities {
+		priorities[widget] = priority
+	}
+	if cfg == nil {
+		return priorities, nil
+	}
+
+	widgets := make([]string, 0, len(cfg.Schedule.Priorities))
+	for widget := range cfg.Schedule.Priorities {
+		widgets = append(widgets, widget)
+	}
+	sort.Strings(widgets)
+	var errs []error
+	for _, widget := range widgets {
+		priority := strings.ToLower(cfg.Schedule.Priorities[widget])
+		if !isDashboardWidget(widget) {
+			errs = append(errs, fmt.Errorf("unknown widget %q in schedule.priorities", widget))
+			continue
+		}
+		switch priority {
+		case priorityHigh, priorityNormal, priorityLazy:
+			priorities[widget] = priority
+		default:
+			errs = append(errs, fmt.Errorf("invalid priority %q for %s (expected high, normal or lazy)", priority, widget))
+		}
+	}
+	return priorities, errors.Join(errs...)
+}
+
+// SetFetchPriorities sets the order widgets first fetch in at startup
+func (s *Scheduler) SetFetchPriorities(priorities map[string]string) {
+	s.priorities = priorities
+}
+
+// FetchPriority returns when a widget first fetches at startup: high, normal or lazy
+func (s *Scheduler) FetchPriority(id string) string {
+	if s == nil || s.priorities[id] == "" {
+		return priorityNormal
+	}
+	return s.priorities[id]
+}
+
+// startupOrder splits the widget fetches into those sent at startup, high priority
+// first, and the lazy ones sent once the dashboard is interactive
+func (s *Scheduler) startupOrder() (first, lazy []tea.Msg) {
+	var high, normal []tea.Msg
+	for _, msg := range widgetFetchMsgs() {
+		widget, _ := fetchWidget(msg)
+		switch s.FetchPriority(widget) {
+		case priorityHigh:
+			high = append(high, msg)
+		case priorityLazy:
+			lazy = append(lazy, msg)
+		default:
+			normal = append(normal, msg)
+		}
+	}
+	return append(high, normal...), lazy
+}
+
+// startupFetches fetches every widget once in startupOrder, the lazy ones
+// lazyStartupDelay after the others
+func (m Model) startupFetches() tea.Cmd {
+	first, lazy := m.scheduler.startupOrder()
+	// Fetches run one at a time in Update, so sending them in order is enough
+	cmds := sendAll(first)
+	if len(lazy) > 0 {
+		cmds = append(cmds, tea.Tick(lazyStartupDelay, func(time.Time) tea.Msg {
+			return tea.Sequence(sendAll(lazy)...)()
+		}))
+	}
+	return tea.Sequence(cmds...)
+}
+
+// sendAll returns a command sending each message
+func sendAll(msgs []tea.Msg) []tea.Cmd {
+	cmds := make([]tea.Cmd, len(msgs))
+	for i, msg := range msgs {
+		msg := msg
+		cmds[i] = func() tea.Msg { return msg }
+	}
+	return cmds
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFetchPriorities(t *testing.T) {
+	cfg := &Config{}
+	cfg.Schedule.Priorities = map[string]string{"prs": "High", "news": "normal"}
+	priorities, err := NewFetchPriorities(cfg)
+	if err != nil {
+		t.Fatalf("NewFetchPriorities failed: %v", err)
+	}
+	scheduler := NewScheduler()
+	scheduler.SetFetchPriorities(priorities)
+	for widget, expected := range map[string]string{
+		"prs":      priorityHigh,
+		"calendar": priorityHigh,
+		"news":     priorityNormal,
+		"stocks":   priorityLazy,
+		"weather":  priorityNormal,
+	} {
+		if got := scheduler.FetchPriority(widget); got != expected {
+			t.Errorf("Expected %s to be %s, got %s", widget, expected, got)
+		}
+	}
+	if got := (*Scheduler)(nil).FetchPriority("calendar"); got != priorityNormal {
+		t.Errorf("Expected normal without a scheduler, got %s", got)
+	}
+
+	cfg.Schedule.Priorities = map[string]string{"news": "later"}
+	if _, err := NewFetchPriorities(cfg); err == nil {
+		t.Error("Expected an unknown priority to fail")
+	}
+	cfg.Schedule.Priorities = map[string]string{"newz": "lazy"}
+	if _, err := NewFetchPriorities(cfg); err == nil {
+		t.Error("Expected an unknown widget to fail")
+	}
+
+	// Every bad entry is reported, and the valid ones still apply
+	cfg.Schedule.Priorities = map[string]string{"newz": "lazy", "news": "later", "prs": "lazy", "weather": "high"}
+	priorities, err = NewFetchPriorities(cfg)
+	if err == nil || !strings.Contains(err.Error(), `"newz"`) || !strings.Contains(err.Error(), `"later"`) {
+		t.Errorf("Expected both bad entries to be reported, got %v", err)
+	}
+	if priorities["prs"] != priorityLazy || priorities["weather"] != priorityHigh || priorities["news"] != priorityLazy {
+		t.Errorf("Expected the valid entries over the defaults, got %v", priorities)
+	}
+}
+
+func TestStartupOrder(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.SetFetchPriorities(map[string]string{"prs": priorityHigh, "news": priorityLazy, "teams": priorityLazy})
+	first, lazy := scheduler.startupOrder()
+
+	if len(first)+len(lazy) != len(widgetFetchMsgs()) {
+		t.Errorf("Expected every widget to fetch once, got %d and %d", len(first), len(lazy))
+	}
+	if widget, _ := fetchWidget(first[0]); widget != "prs" {
+		t.Errorf("Expected prs to fetch first, got %s", widget)
+	}
+	if len(lazy) != 2 || lazy[0] != tea.Msg(fetchNewsCmd{}) || lazy[1] != tea.Msg(fetchChatCmd{widget: "teams"}) {
+		t.Errorf("Expected news and teams to fetch lazily, got %v", lazy)
+	}
+	// Normal widgets keep their usual order
+	if widget, _ := fetchWidget(first[1]); widget != "weather" {
+		t.Errorf("Expected weather to follow the high priority widgets, got %s", widget)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// maxStateItems caps the items written per widget to keep the state file compact
+const maxStateItems = 5
+
+// DashboardState is the snapshot written to the state file after each refresh, for
+// status bar tools such as polybar, xbar/SwiftBar or Hammerspoon
+type DashboardState struct {
+	UpdatedAt time.Time              `json:"updated_at"`
+	User      string                 `json:"user"`
+	Location  string                 `json:"location"`
+	Weather   string                 `json:"weather"`
+	Widgets   map[string]WidgetState `json:"widgets"`
+}
+
+// WidgetState is a visible tile in the state file
+type WidgetState struct {
+	Title string      `json:"title"`
+	Count int         `json:"count"`
+	Error bool        `json:"error,omitempty"`
+	Items []StateItem `json:"items"`
+}
+
+// StateItem is one of a tile's first items in the state file
+type StateItem struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+	Status   string `json:"status,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// StatePath returns where to write the state file: ui.state_file, or ~/.goday/state.json
+// by default. It returns "" when the file is turned off with state_file: off.
+func StatePath(cfg *Config) string {
+	path := ""
+	if cfg != nil {
+		path = cfg.UI.StateFile
+	}
+	if path == "off" {
+		return ""
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	if path == "" {
+		return filepath.Join(homeDir, ".goday", "state.json")
+	}
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(homeDir, path[2:])
+	}
+	return path
+}
+
+// isRefreshMsg reports whether a message refreshes widget data
+func isRefreshMsg(msg interface{}) bool {
+	switch msg.(type) {
+	case weatherMsg, newsMsg, fetchWeatherCmd, fetchNewsCmd, fetchGitCommitsCmd, fetchGitHubPRsCmd,
+		fetchTrafficCmd, fetchCalendarCmd, fetchDiscussionsCmd, fetchMentionsCmd, fetchStocksCmd,
+		fetchCryptoCmd, fetchFXCmd, fetchAQICmd, fetchOnCallCmd, fetchAlertsCmd, fetchStatusPagesCmd,
+		fetchUptimeCmd, fetchCertsCmd, fetchDomainsCmd, fetchTrendsCmd, fetchReleasesCmd, fetchVulnsCmd, vulnsMsg, fetchSystemCmd, fetchNetworkCmd, fetchTogglCmd, fetchRemindersCmd, fetchChatCmd:
+		return true
+	}
+	return false
+}
+
+// dashboardState captures the header and the visible tiles as currently shown, with
+// the first maxStateItems items of each
+func (m Model) dashboardState(now time.Time) DashboardState {
+	return m.captureState(now, maxStateItems)
+}
+
+// captureState captures the header and the visible tiles with up to limit items each,
+// or every item when limit is 0
+func (m Model) captureState(now time.Time, limit int) DashboardState {
+	state := DashboardState{
+		UpdatedAt: now,
+		User:      m.userName,
+		Location:  m.location,
+		Weather:   m.weather,
+		Widgets:   make(map[string]WidgetState),
+	}
+	for _, tile := range m.widgets {
+		// The count is the one the tile's title shows, or every item when the title shows none
+		if m.attention != nil {
+			tile.highlight = m.attention.Highlighted(tile.key)
+		}
+		count, ok := tile.titleCount()
+		if !ok {
+			count = tile.count
+		}
+		widget := WidgetState{Title: tile.title, Count: count, Error: tile.hasError, Items: []StateItem{}}
+		for _, listItem := range tile.list.Items() {
+			item, ok := listItem.(WidgetListItem)
+			if !ok || (limit > 0 && len(widget.Items) == limit) {
+				break
+			}
+			widget.Items = append(widget.Items, StateItem{
+				Title:    item.ItemTitle,
+				Subtitle: item.Subtitle,
+				Status:   item.Status,
+				URL:      item.URL,
+			})
+		}
+		state.Widgets[tile.key] = widget
+	}
+	return state
+}
+
+// writeStateFile writes the state as JSON, atomically, so readers polling the file
+// never see a partial write
+func writeStateFile(path string, state DashboardState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data, 0600)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestStatePath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+
+	cfg := &Config{}
+	if got := StatePath(cfg); got != filepath.Join(home, ".goday", "state.json") {
+		t.Errorf("Expected the default state file, got '%s'", got)
+	}
+	cfg.UI.StateFile = "~/bar/goday.json"
+	if got := StatePath(cfg); got != filepath.Join(home, "bar", "goday.json") {
+		t.Errorf("Expected ~ to be expanded, got '%s'", got)
+	}
+	cfg.UI.StateFile = "off"
+	if got := StatePath(cfg); got != "" {
+		t.Errorf("Expected off to disable the state file, got '%s'", got)
+	}
+}
+
+func TestStateFileWrittenOnRefresh(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+
+	prs := NewWidgetTile("prs", "PRs", 40, 10)
+	prs.UpdateItems([]WidgetItem{
+		{Title: "#1 Fix login", Subtitle: "acme/api", Status: "✅", URL: "https://github.com/acme/api/pull/1"},
+		{Title: "#2", Status: "⏳"}, {Title: "#3"}, {Title: "#4"}, {Title: "#5"}, {Title: "#6"},
+	})
+	news := NewWidgetTile("news", "Tech News", 40, 10)
+	news.hasError = true
+
+	m := Model{
+		userName:  "Ada",
+		location:  "Berlin,DE",
+		statePath: path,
+		widgets:   []WidgetTile{prs, news},
+	}
+
+	// Keys do not refresh anything, so nothing is written
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("Expected no state file before a refresh, got %v", err)
+	}
+
+	m.Update(weatherMsg{pill: "☀ 21°C"})
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the state file after a refresh: %v", err)
+	}
+
+	var state DashboardState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("Expected valid JSON, got %v: %s", err, data)
+	}
+	if state.User != "Ada" || state.Weather != "☀ 21°C" || state.UpdatedAt.IsZero() {
+		t.Errorf("Expected the header after the weather refresh, got %+v", state)
+	}
+	prsState := state.Widgets["prs"]
+	if prsState.Count != 6 || len(prsState.Items) != maxStateItems || prsState.Items[0].URL != "https://github.com/acme/api/pull/1" {
+		t.Errorf("Expected the PR count and first items, got %+v", prsState)
+	}
+	if !state.Widgets["news"].Error {
+		t.Error("Expected the news tile's error to be recorded")
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("Expected the temporary file to be renamed into place")
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// statuslineFormats are the output formats of goday statusline
+var statuslineFormats = []string{"xbar"}
+
+// statuslineSummaryTiles are the tiles whose counts appear in the menu bar itself
+var statuslineSummaryTiles = []string{"mentions", "prs", "discussions"}
+
+// followUpWait is how long a refresh's follow-up command may take to count as
+// immediate. Data is handed over at once; scheduled re-fetches take seconds or more.
+const followUpWait = 50 * time.Millisecond
+
+// parseStatuslineArgs splits --format from the usual startup flags
+func parseStatuslineArgs(args []string) (string, *CLIOptions, error) {
+	format := "xbar"
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--format" || args[i] == "-format":
+			if i+1 >= len(args) {
+				return "", nil, fmt.Errorf("--format needs a value")
+			}
+			format = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--format="):
+			format = strings.TrimPrefix(args[i], "--format=")
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	if !containsString(statuslineFormats, format) {
+		return "", nil, fmt.Errorf("unknown format %q (expected %s)", format, strings.Join(statuslineFormats, ", "))
+	}
+
+	opts, err := ParseFlags(rest)
+	if err != nil {
+		return "", nil, err
+	}
+	return format, opts, nil
+}
+
+// widgetFetchMsgs returns a fetch message for every widget that is refreshed from a plugin
+func widgetFetchMsgs() []tea.Msg {
+	return []tea.Msg{
+		fetchWeatherCmd{}, fetchNewsCmd{}, fetchGitCommitsCmd{}, fetchGitHubPRsCmd{}, fetchTrafficCmd{},
+		fetchCalendarCmd{}, fetchChatCmd{widget: "teams"}, fetchChatCmd{widget: "discord"},
+		fetchDiscussionsCmd{}, fetchMentionsCmd{}, fetchStocksCmd{}, fetchCryptoCmd{}, fetchFXCmd{},
+		fetchAQICmd{}, fetchOnCallCmd{}, fetchAlertsCmd{}, fetchStatusPagesCmd{},
+		fetchUptimeCmd{}, fetchCertsCmd{}, fetchDomainsCmd{}, fetchTrendsCmd{}, fetchReleasesCmd{}, fetchVulnsCmd{},
+		fetchSystemCmd{}, fetchNetworkCmd{}, fetchTogglCmd{}, fetchRemindersCmd{},
+	}
+}
+
+// refreshOnce runs every widget refresh once without the TUI and returns the updated
+// model. Results handed back as follow-up messages, such as fetched news, are applied;
+// the scheduled re-fetches are dropped.
+func refreshOnce(m Model) Model {
+	m.waitFetches = true
+	queue := widgetFetchMsgs()
+	for len(queue) > 0 {
+		model, cmd := m.Update(queue[0])
+		m = model.(Model)
+		queue = queue[1:]
+		for _, msg := range immediateMsgs(cmd) {
+			// Never start a second round of fetches
+			if isDataMsg(msg) || !isRefreshMsg(msg) {
+				queue = append(queue, msg)
+			}
+		}
+	}
+	return m
+}
+
+// refreshedModel builds the dashboard model without the TUI and refreshes it once, for
+// commands that print widget data and exit
+func refreshedModel(opts *CLIOptions) Model {
+	// Plugins print warnings to stdout, which would end up in the command's output
+	stdout := os.Stdout
+	if devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0); err == nil {
+		os.Stdout = devNull
+		defer devNull.Close()
+	}
+	defer func() { os.Stdout = stdout }()
+
+	m := initialModel(opts)
+	m.statePath = "" // the running dashboard owns the state file
+	m.attention = nil
+	m.preview = nil                   // the running dashboard keeps the commute history
+	m.scheduler.SetQuietSchedule(nil) // run on demand, so quiet time does not apply
+	return refreshOnce(m)
+}
+
+// isDataMsg reports whether a message carries fetched data rather than asking for a fetch
+func isDataMsg(msg tea.Msg) bool {
+	switch msg.(type) {
+	case weatherMsg, newsMsg, vulnsMsg:
+		return true
+	}
+	return false
+}
+
+// immediateMsgs runs cmd and returns the messages it produces right away, flattening batches
+func immediateMsgs(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	select {
+	case msg := <-done:
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			var msgs []tea.Msg
+			for _, c := range batch {
+				msgs = append(msgs, immediateMsgs(c)...)
+			}
+			return msgs
+		}
+		return []tea.Msg{msg}
+	case <-time.After(followUpWait):
+		return nil
+	}
+}
+
+// xbarText makes text safe for an xbar line, where | starts the parameters
+func xbarText(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return strings.ReplaceAll(text, "|", "¦")
+}
+
+// formatXbar renders the state in the xbar/SwiftBar plugin format: a menu bar line,
+// then a dropdown with a submenu of linked items per tile, in tile order
+func formatXbar(state DashboardState, order []string) string {
+	var b strings.Builder
+
+	// The menu bar shows the weather without the location, then counts that need you
+	var summary []string
+	if weather, _, _ := strings.Cut(state.Weather, " ("); weather != "" && !strings.Contains(weather, "N/A") {
+		summary = append(summary, weather)
+	}
+	for _, key := range statuslineSummaryTiles {
+		if widget, ok := state.Widgets[key]; ok && widget.Count > 0 && !widget.Error {
+			summary = append(summary, fmt.Sprintf("%s %d", widget.Title, widget.Count))
+		}
+	}
+	if len(summary) == 0 {
+		summary = append(summary, "GoDay")
+	}
+	fmt.Fprintln(&b, xbarText(strings.Join(summary, " · ")))
+	fmt.Fprintln(&b, "---")
+
+	for _, key := range order {
+		widget, ok := state.Widgets[key]
+		if !ok {
+			continue
+		}
+		header := xbarText(widget.Title)
+		if widget.Count > 0 {
+			header = fmt.Sprintf("%s (%d)", header, widget.Count)
+		}
+		if widget.Error {
+			header += " | color=red"
+		}
+		fmt.Fprintln(&b, header)
+
+		for _, item := range widget.Items {
+			line := "--" + xbarText(strings.TrimSpace(item.Status+" "+item.Title))
+			if item.URL != "" {
+				line += " | href=" + item.URL
+			}
+			fmt.Fprintln(&b, line)
+			if item.Subtitle != "" {
+				fmt.Fprintf(&b, "--%s | size=11\n", xbarText(item.Subtitle))
+			}
+		}
+	}
+
+	fmt.Fprintln(&b, "---")
+	fmt.Fprintln(&b, "Updated "+state.UpdatedAt.Format("15:04")+" | size=11")
+	fmt.Fprintln(&b, "Refresh | refresh=true")
+	return b.String()
+}
+
+// runStatusline refreshes every visible widget once and prints the result in a menu bar
+// plugin format, for example from an xbar or SwiftBar plugin script
+func runStatusline(args []string, out io.Writer) error {
+	format, opts, err := parseStatuslineArgs(args)
+	if err != nil {
+		return err
+	}
+
+	m := refreshedModel(opts)
+	var order []string
+	for _, tile := range m.widgets {
+		order = append(order, tile.key)
+	}
+	state := m.dashboardState(time.Now())
+
+	switch format {
+	case "xbar":
+		_, err = io.WriteString(out, formatXbar(state, order))
+	}
+	return err
+}
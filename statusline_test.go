@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseStatuslineArgs(t *testing.T) {
+	format, opts, err := parseStatuslineArgs([]string{"--format", "xbar", "--widgets", "prs,news"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if format != "xbar" || strings.Join(opts.Widgets, ",") != "prs,news" {
+		t.Errorf("Expected the format and widgets, got '%s' and %v", format, opts.Widgets)
+	}
+	if _, _, err := parseStatuslineArgs([]string{"--format=polybar"}); err == nil {
+		t.Error("Expected an unknown format to fail")
+	}
+	if _, _, err := parseStatuslineArgs([]string{"--format"}); err == nil {
+		t.Error("Expected a missing format to fail")
+	}
+}
+
+func TestFormatXbar(t *testing.T) {
+	state := DashboardState{
+		UpdatedAt: time.Date(2026, 10, 16, 9, 5, 0, 0, time.Local),
+		Weather:   "☀ 21°C (Berlin,DE)",
+		Widgets: map[string]WidgetState{
+			"prs": {Title: "PRs", Count: 2, Items: []StateItem{
+				{Title: "#1 Fix | pipes", Subtitle: "acme/api", Status: "✅", URL: "https://github.com/acme/api/pull/1"},
+			}},
+			"mentions": {Title: "Mentions", Count: 0, Items: []StateItem{{Title: "No mentions", Status: "✅"}}},
+			"news":     {Title: "Tech News", Error: true, Items: []StateItem{{Title: "Failed to fetch news"}}},
+		},
+	}
+
+	got := formatXbar(state, []string{"news", "prs", "mentions"})
+	expected := strings.Join([]string{
+		"☀ 21°C · PRs 2",
+		"---",
+		"Tech News | color=red",
+		"--Failed to fetch news",
+		"PRs (2)",
+		"--✅ #1 Fix ¦ pipes | href=https://github.com/acme/api/pull/1",
+		"--acme/api | size=11",
+		"Mentions",
+		"--✅ No mentions",
+		"---",
+		"Updated 09:05 | size=11",
+		"Refresh | refresh=true",
+		"",
+	}, "\n")
+	if got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	if first := strings.SplitN(formatXbar(DashboardState{Weather: "☁ N/A (x)"}, nil), "\n", 2)[0]; first != "GoDay" {
+		t.Errorf("Expected a fallback menu bar title, got '%s'", first)
+	}
+}
+
+func TestImmediateMsgs(t *testing.T) {
+	cmd := tea.Batch(
+		tea.Tick(time.Hour, func(time.Time) tea.Msg { return fetchNewsCmd{} }),
+		func() tea.Msg { return weatherMsg{pill: "☀ 21°C"} },
+	)
+	msgs := immediateMsgs(cmd)
+	if len(msgs) != 1 || msgs[0] != (weatherMsg{pill: "☀ 21°C"}) {
+		t.Errorf("Expected only the immediate message, got %v", msgs)
+	}
+}
@@ -0,0 +1,469 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// statusFeedWindow is how long a status feed entry counts as a current incident
+const statusFeedWindow = 24 * time.Hour
+
+// Service status levels, from healthy to worst
+const (
+	serviceOperational = iota
+	serviceMaintenance
+	serviceDegraded
+	servicePartialOutage
+	serviceMajorOutage
+)
+
+// serviceLevelNames are the shown names of the service status levels
+var serviceLevelNames = []string{"operational", "maintenance", "degraded performance", "partial outage", "major outage"}
+
+// statusPageLevels maps the component and impact statuses of Statuspage.io and instatus
+var statusPageLevels = map[string]int{
+	"operational":          serviceOperational,
+	"none":                 serviceOperational,
+	"up":                   serviceOperational,
+	"under_maintenance":    serviceMaintenance,
+	"undermaintenance":     serviceMaintenance,
+	"maintenance":          serviceMaintenance,
+	"degraded_performance": serviceDegraded,
+	"degradedperformance":  serviceDegraded,
+	"minor":                serviceDegraded,
+	"hasissues":            serviceDegraded,
+	"partial_outage":       servicePartialOutage,
+	"partialoutage":        servicePartialOutage,
+	"major":                servicePartialOutage,
+	"major_outage":         serviceMajorOutage,
+	"majoroutage":          serviceMajorOutage,
+	"critical":             serviceMajorOutage,
+}
+
+// statusPageLevel returns the level of a status, or degraded for one it does not know
+func statusPageLevel(status string) int {
+	if level, ok := statusPageLevels[strings.ToLower(status)]; ok {
+		return level
+	}
+	return serviceDegraded
+}
+
+// knownStatusPages are the services that can be listed by name under widgets.status.services
+var knownStatusPages = map[string]StatusPageConfig{
+	"github":     {Name: "GitHub", URL: "https://www.githubstatus.com", Kind: "statuspage"},
+	"npm":        {Name: "npm", URL: "https://status.npmjs.org", Kind: "statuspage"},
+	"atlassian":  {Name: "Atlassian", URL: "https://status.atlassian.com", Kind: "statuspage"},
+	"cloudflare": {Name: "Cloudflare", URL: "https://www.cloudflarestatus.com", Kind: "statuspage"},
+	"discord":    {Name: "Discord", URL: "https://discordstatus.com", Kind: "statuspage"},
+	"vercel":     {Name: "Vercel", URL: "https://www.vercel-status.com", Kind: "statuspage"},
+	"slack":      {Name: "Slack", URL: "https://slack-status.com/feed/rss", Kind: "feed"},
+	"aws":        {Name: "AWS", URL: "https://status.aws.amazon.com/rss/all.rss", Kind: "feed"},
+}
+
+// ServiceStatus is the current state of one dependency's status page
+type ServiceStatus struct {
+	Name     string
+	URL      string // the status page, or the current incident
+	Level    int
+	Degraded []string // components that are not operational
+	Incident string   // the newest active incident or maintenance, if any
+	Since    time.Time
+	Err      error // the status page could not be read
+}
+
+// StatusPagePlugin polls the status pages of the services you depend on and shows the
+// ones with problems first
+type StatusPagePlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	pages       []StatusPageConfig
+	client      *http.Client
+	feedParser  *gofeed.Parser
+	lastData    []ServiceStatus
+}
+
+// NewStatusPagePlugin creates a new status page plugin
+func NewStatusPagePlugin() *StatusPagePlugin {
+	return &StatusPagePlugin{
+		id:          "statuspage",
+		pluginType:  "status",
+		name:        "Status Pages",
+		version:     "1.0.0",
+		description: "Shows degraded components and incidents on Statuspage.io, instatus and RSS status pages",
+		author:      "GoDay Team",
+		client:      &http.Client{Timeout: 10 * time.Second},
+		feedParser:  gofeed.NewParser(),
+		lastData:    []ServiceStatus{},
+	}
+}
+
+// GetID returns the plugin ID
+func (sp *StatusPagePlugin) GetID() string {
+	return sp.id
+}
+
+// GetType returns the plugin type
+func (sp *StatusPagePlugin) GetType() string {
+	return sp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (sp *StatusPagePlugin) GetMetadata() PluginMetadata {
+	var names []string
+	for _, page := range sp.pages {
+		names = append(names, page.Name)
+	}
+	return PluginMetadata{
+		Name:        sp.name,
+		Version:     sp.version,
+		Description: sp.description,
+		Author:      sp.author,
+		Type:        sp.pluginType,
+		Config: map[string]string{
+			"pages": strings.Join(names, ","),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration: services names known status pages,
+// pages lists others
+func (sp *StatusPagePlugin) Initialize(config map[string]interface{}) error {
+	var pages []StatusPageConfig
+	for _, service := range configStringList(config["services"]) {
+		page, ok := knownStatusPages[strings.ToLower(service)]
+		if !ok {
+			return fmt.Errorf("unknown status page service %q (expected one of %s)", service, strings.Join(knownStatusPageNames(), ", "))
+		}
+		pages = append(pages, page)
+	}
+
+	custom, err := statusPagesFromConfig(config["pages"])
+	if err != nil {
+		return err
+	}
+	sp.pages = append(pages, custom...)
+	return nil
+}
+
+// knownStatusPageNames returns the names usable under services, sorted
+func knownStatusPageNames() []string {
+	var names []string
+	for name := range knownStatusPages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// statusPagesFromConfig reads the pages setting, given either as []StatusPageConfig from
+// widgets.status.pages or as a list of name/url/kind maps from the plugins section
+func statusPagesFromConfig(value interface{}) ([]StatusPageConfig, error) {
+	var pages []StatusPageConfig
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []StatusPageConfig:
+		pages = append(pages, v...)
+	case []interface{}:
+		for _, entry := range v {
+			fields, ok := entry.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("status page entries must have a url, got %v", entry)
+			}
+			name, _ := fields["name"].(string)
+			pageURL, _ := fields["url"].(string)
+			kind, _ := fields["kind"].(string)
+			pages = append(pages, StatusPageConfig{Name: name, URL: pageURL, Kind: kind})
+		}
+	default:
+		return nil, fmt.Errorf("status pages must be a list, got %T", value)
+	}
+
+	for i, page := range pages {
+		if !strings.HasPrefix(page.URL, "http://") && !strings.HasPrefix(page.URL, "https://") {
+			return nil, fmt.Errorf("status page URL %q must start with http:// or https://", page.URL)
+		}
+		pages[i].URL = strings.TrimRight(page.URL, "/")
+		if pages[i].Kind == "" {
+			pages[i].Kind = "statuspage"
+		}
+		if !containsString([]string{"statuspage", "instatus", "feed"}, pages[i].Kind) {
+			return nil, fmt.Errorf("unknown status page kind %q (expected statuspage, instatus or feed)", page.Kind)
+		}
+		if pages[i].Name == "" {
+			pages[i].Name = strings.TrimPrefix(strings.TrimPrefix(pages[i].URL, "https://"), "http://")
+		}
+	}
+	return pages, nil
+}
+
+// Fetch reads every status page concurrently and returns them worst first. A page that
+// cannot be read is listed with its error; Fetch only fails when every page does.
+func (sp *StatusPagePlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if len(sp.pages) == 0 {
+		return sp.lastData, fmt.Errorf("no status pages configured (widgets.status.services or pages)")
+	}
+
+	results := make([]ServiceStatus, len(sp.pages))
+	var wg sync.WaitGroup
+	for i, page := range sp.pages {
+		wg.Add(1)
+		go func(i int, page StatusPageConfig) {
+			defer wg.Done()
+			results[i] = sp.fetchPage(ctx, page)
+		}(i, page)
+	}
+	wg.Wait()
+
+	var failures []string
+	for _, result := range results {
+		if result.Err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", result.Name, result.Err))
+		}
+	}
+	if len(failures) == len(results) {
+		return sp.lastData, fmt.Errorf("all status pages failed: %s", strings.Join(failures, "; "))
+	}
+
+	// Problems first, then unreachable pages, then healthy ones, each in config order
+	rank := func(status ServiceStatus) int {
+		if status.Err != nil {
+			return 0
+		}
+		if status.Level == serviceOperational {
+			return -1
+		}
+		return status.Level
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return rank(results[i]) > rank(results[j])
+	})
+
+	sp.lastData = results
+	return results, nil
+}
+
+// fetchPage reads one status page according to its kind
+func (sp *StatusPagePlugin) fetchPage(ctx context.Context, page StatusPageConfig) ServiceStatus {
+	status := ServiceStatus{Name: page.Name, URL: page.URL}
+	var err error
+	switch page.Kind {
+	case "instatus":
+		err = sp.fetchInstatus(ctx, page, &status)
+	case "feed":
+		err = sp.fetchStatusFeed(ctx, page, &status)
+	default:
+		err = sp.fetchStatuspage(ctx, page, &status)
+	}
+	status.Err = err
+	return status
+}
+
+// getJSON decodes the JSON document at pageURL into v
+func (sp *StatusPagePlugin) getJSON(ctx context.Context, pageURL string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := sp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// fetchStatuspage reads a Statuspage.io page, such as githubstatus.com, from its public
+// summary: components, unresolved incidents and maintenance in progress
+func (sp *StatusPagePlugin) fetchStatuspage(ctx context.Context, page StatusPageConfig, status *ServiceStatus) error {
+	var summary struct {
+		Status struct {
+			Indicator string `json:"indicator"`
+		} `json:"status"`
+		Components []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+			Group  bool   `json:"group"`
+		} `json:"components"`
+		Incidents []struct {
+			Name      string    `json:"name"`
+			Impact    string    `json:"impact"`
+			Shortlink string    `json:"shortlink"`
+			StartedAt time.Time `json:"started_at"`
+		} `json:"incidents"`
+		Maintenances []struct {
+			Name      string    `json:"name"`
+			Status    string    `json:"status"`
+			Shortlink string    `json:"shortlink"`
+			StartedAt time.Time `json:"started_at"`
+		} `json:"scheduled_maintenances"`
+	}
+	if err := sp.getJSON(ctx, page.URL+"/api/v2/summary.json", &summary); err != nil {
+		return err
+	}
+
+	status.Level = statusPageLevel(summary.Status.Indicator)
+	for _, component := range summary.Components {
+		// A group's status repeats the worst of its components
+		if component.Group || statusPageLevel(component.Status) == serviceOperational {
+			continue
+		}
+		status.Degraded = append(status.Degraded, component.Name)
+		if level := statusPageLevel(component.Status); level > status.Level {
+			status.Level = level
+		}
+	}
+	for _, incident := range summary.Incidents {
+		if status.Since.IsZero() || incident.StartedAt.After(status.Since) {
+			status.Incident, status.Since, status.URL = incident.Name, incident.StartedAt, incident.Shortlink
+		}
+		if level := statusPageLevel(incident.Impact); level > status.Level {
+			status.Level = level
+		}
+	}
+	for _, maintenance := range summary.Maintenances {
+		if maintenance.Status != "in_progress" {
+			continue
+		}
+		if status.Incident == "" {
+			status.Incident, status.Since, status.URL = maintenance.Name, maintenance.StartedAt, maintenance.Shortlink
+		}
+		if status.Level < serviceMaintenance {
+			status.Level = serviceMaintenance
+		}
+	}
+	if status.URL == "" {
+		status.URL = page.URL
+	}
+	return nil
+}
+
+// fetchInstatus reads an instatus page from its public summary and component list
+func (sp *StatusPagePlugin) fetchInstatus(ctx context.Context, page StatusPageConfig, status *ServiceStatus) error {
+	var summary struct {
+		Page struct {
+			Status string `json:"status"`
+		} `json:"page"`
+		ActiveIncidents []struct {
+			Name    string    `json:"name"`
+			Impact  string    `json:"impact"`
+			URL     string    `json:"url"`
+			Started time.Time `json:"started"`
+		} `json:"activeIncidents"`
+		ActiveMaintenances []struct {
+			Name    string    `json:"name"`
+			URL     string    `json:"url"`
+			Started time.Time `json:"start"`
+		} `json:"activeMaintenances"`
+	}
+	if err := sp.getJSON(ctx, page.URL+"/summary.json", &summary); err != nil {
+		return err
+	}
+
+	status.Level = statusPageLevel(summary.Page.Status)
+	for _, incident := range summary.ActiveIncidents {
+		if status.Since.IsZero() || incident.Started.After(status.Since) {
+			status.Incident, status.Since, status.URL = incident.Name, incident.Started, incident.URL
+		}
+		if level := statusPageLevel(incident.Impact); level > status.Level {
+			status.Level = level
+		}
+	}
+	for _, maintenance := range summary.ActiveMaintenances {
+		if status.Incident == "" {
+			status.Incident, status.Since, status.URL = maintenance.Name, maintenance.Started, maintenance.URL
+		}
+		if status.Level < serviceMaintenance {
+			status.Level = serviceMaintenance
+		}
+	}
+	if status.URL == "" {
+		status.URL = page.URL
+	}
+
+	// Components name what is affected; the summary alone is enough when they fail
+	var components struct {
+		Components []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+		} `json:"components"`
+	}
+	if status.Level != serviceOperational && sp.getJSON(ctx, page.URL+"/v2/components.json", &components) == nil {
+		for _, component := range components.Components {
+			if level := statusPageLevel(component.Status); level != serviceOperational {
+				status.Degraded = append(status.Degraded, component.Name)
+				if level > status.Level {
+					status.Level = level
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// fetchStatusFeed reads an RSS or Atom status feed, such as AWS's. Entries from the last
+// day count as current incidents unless they say they are resolved.
+func (sp *StatusPagePlugin) fetchStatusFeed(ctx context.Context, page StatusPageConfig, status *ServiceStatus) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", page.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := sp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	feed, err := sp.feedParser.Parse(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	status.Level = serviceOperational
+	if feed.Link != "" {
+		status.URL = feed.Link
+	}
+	for _, item := range feed.Items {
+		published := item.PublishedParsed
+		if item.UpdatedParsed != nil {
+			published = item.UpdatedParsed
+		}
+		if published == nil || time.Since(*published) > statusFeedWindow {
+			continue
+		}
+		text := strings.ToLower(item.Title + " " + stripHTML(item.Description))
+		if strings.Contains(text, "resolved") || strings.Contains(text, "operating normally") || strings.Contains(text, "completed") {
+			continue
+		}
+		status.Level = serviceDegraded
+		if status.Since.IsZero() || published.After(status.Since) {
+			status.Incident, status.Since = strings.TrimSpace(item.Title), *published
+			if item.Link != "" {
+				status.URL = item.Link
+			}
+		}
+	}
+	return nil
+}
+
+// Cleanup performs cleanup
+func (sp *StatusPagePlugin) Cleanup() error {
+	return nil
+}
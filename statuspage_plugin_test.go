@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatusPagePluginFetch(t *testing.T) {
+	recent := time.Now().Add(-30 * time.Minute).UTC()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/github/api/v2/summary.json":
+			fmt.Fprintf(w, `{"status":{"indicator":"minor"},
+				"components":[{"name":"Git Operations","status":"operational"},
+					{"name":"Actions","status":"partial_outage"},
+					{"name":"Packages","status":"degraded_performance"},
+					{"name":"Group","status":"partial_outage","group":true}],
+				"incidents":[{"name":"Delayed Actions runs","impact":"minor","shortlink":"https://stspg.io/abc","started_at":"%s"}],
+				"scheduled_maintenances":[]}`, recent.Format(time.RFC3339))
+		case "/npm/api/v2/summary.json":
+			fmt.Fprint(w, `{"status":{"indicator":"none"},"components":[{"name":"Registry","status":"operational"}],"incidents":[]}`)
+		case "/acme/summary.json":
+			fmt.Fprint(w, `{"page":{"status":"HASISSUES"},"activeIncidents":[{"name":"Login errors","impact":"MAJOROUTAGE","url":"https://acme.example.com/i/1","started":"2026-10-16T08:00:00Z"}]}`)
+		case "/acme/v2/components.json":
+			fmt.Fprint(w, `{"components":[{"name":"Login","status":"MAJOROUTAGE"},{"name":"API","status":"OPERATIONAL"}]}`)
+		case "/aws.rss":
+			fmt.Fprintf(w, `<?xml version="1.0"?><rss version="2.0"><channel><title>AWS</title><link>https://health.aws.amazon.com</link>
+				<item><title>Service is operating normally: [RESOLVED] Increased error rates</title><pubDate>%s</pubDate></item>
+				<item><title>Increased API latencies in us-east-1</title><link>https://health.aws.amazon.com/x</link><pubDate>%s</pubDate></item>
+				<item><title>Old issue</title><pubDate>Mon, 01 Jan 2024 00:00:00 GMT</pubDate></item>
+				</channel></rss>`, recent.Format(time.RFC1123), recent.Format(time.RFC1123))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	plugin := NewStatusPagePlugin()
+	err := plugin.Initialize(map[string]interface{}{
+		"pages": []StatusPageConfig{
+			{Name: "npm", URL: server.URL + "/npm"},
+			{Name: "GitHub", URL: server.URL + "/github/"},
+			{Name: "Acme", URL: server.URL + "/acme", Kind: "instatus"},
+			{Name: "AWS", URL: server.URL + "/aws.rss", Kind: "feed"},
+			{URL: server.URL + "/missing"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	services := data.([]ServiceStatus)
+	var order []string
+	for _, service := range services {
+		order = append(order, service.Name)
+	}
+	if strings.Join(order, ",") != "Acme,GitHub,AWS,"+strings.TrimPrefix(server.URL, "http://")+"/missing,npm" {
+		t.Fatalf("Expected problems first, then unreachable and healthy pages, got %v", order)
+	}
+
+	acme, github, aws := services[0], services[1], services[2]
+	if acme.Level != serviceMajorOutage || strings.Join(acme.Degraded, ",") != "Login" || acme.URL != "https://acme.example.com/i/1" {
+		t.Errorf("Expected the instatus outage with its component, got %+v", acme)
+	}
+	if github.Level != servicePartialOutage || strings.Join(github.Degraded, ",") != "Actions,Packages" || github.Incident != "Delayed Actions runs" || github.URL != "https://stspg.io/abc" {
+		t.Errorf("Expected the degraded GitHub components and incident, got %+v", github)
+	}
+	if aws.Level != serviceDegraded || aws.Incident != "Increased API latencies in us-east-1" {
+		t.Errorf("Expected the unresolved AWS entry, got %+v", aws)
+	}
+	if services[3].Err == nil || services[4].Level != serviceOperational {
+		t.Errorf("Expected the missing page to fail and npm to be operational, got %+v", services[3:])
+	}
+
+	wm := NewWidgetManager()
+	wm.UpdateStatusPagesWidget(services)
+	widget := wm.Widgets["status"]
+	if widget.Count != 3 {
+		t.Errorf("Expected three services with problems, got %d", widget.Count)
+	}
+	if item := widget.Items[1]; item.Title != "GitHub: Actions, Packages" || item.Status != "🟠" || !strings.Contains(item.Subtitle, "partial outage • Delayed Actions runs • for 30m") {
+		t.Errorf("Expected the GitHub line, got %+v", item)
+	}
+	if item := widget.Items[4]; item.Status != "✅" || item.Subtitle != "All systems operational" {
+		t.Errorf("Expected npm to be operational, got %+v", item)
+	}
+}
+
+func TestStatusPagePluginInitialize(t *testing.T) {
+	plugin := NewStatusPagePlugin()
+	err := plugin.Initialize(map[string]interface{}{
+		"services": []interface{}{"GitHub", "aws"},
+		"pages":    []interface{}{map[string]interface{}{"url": "https://status.example.com/"}},
+	})
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if len(plugin.pages) != 3 || plugin.pages[1].Kind != "feed" || plugin.pages[2].Name != "status.example.com" || plugin.pages[2].Kind != "statuspage" {
+		t.Errorf("Expected the named services and the page with defaults, got %+v", plugin.pages)
+	}
+
+	if err := plugin.Initialize(map[string]interface{}{"services": []interface{}{"myspace"}}); err == nil || !strings.Contains(err.Error(), "github") {
+		t.Errorf("Expected an unknown service to be rejected with the known ones, got %v", err)
+	}
+	if err := plugin.Initialize(map[string]interface{}{"pages": []interface{}{map[string]interface{}{"url": "https://x.example.com", "kind": "pingdom"}}}); err == nil {
+		t.Error("Expected an unknown kind to be rejected")
+	}
+
+	if _, err := NewStatusPagePlugin().Fetch(context.Background()); err == nil {
+		t.Error("Expected an error without status pages")
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// StockQuote is the latest price of a symbol
+type StockQuote struct {
+	Symbol        string
+	Price         float64
+	Change        float64
+	ChangePercent float64
+	Updated       time.Time
+	MarketOpen    bool
+}
+
+// StocksPlugin shows quotes for configured symbols from Finnhub. Outside US market
+// hours prices do not move, so it only fetches once and then keeps the last quotes.
+type StocksPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	apiKey      string
+	symbols     []string
+	apiURL      string
+	now         func() time.Time
+	client      *http.Client
+	lastData    []StockQuote
+	rateLimitTracker
+}
+
+// NewStocksPlugin creates a new stocks plugin
+func NewStocksPlugin() *StocksPlugin {
+	return &StocksPlugin{
+		id:          "stocks",
+		pluginType:  "finance",
+		name:        "Stocks",
+		version:     "1.0.0",
+		description: "Shows stock prices and day changes from Finnhub",
+		author:      "GoDay Team",
+		apiKey:      os.Getenv("FINNHUB_API_KEY"),
+		apiURL:      "https://finnhub.io/api/v1",
+		now:         time.Now,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		lastData:    []StockQuote{},
+	}
+}
+
+// GetID returns the plugin ID
+func (sp *StocksPlugin) GetID() string {
+	return sp.id
+}
+
+// GetType returns the plugin type
+func (sp *StocksPlugin) GetType() string {
+	return sp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (sp *StocksPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        sp.name,
+		Version:     sp.version,
+		Description: sp.description,
+		Author:      sp.author,
+		Type:        sp.pluginType,
+		Config: map[string]string{
+			"has_api_key": fmt.Sprintf("%t", sp.apiKey != ""),
+			"symbols":     strings.Join(sp.symbols, ","),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (sp *StocksPlugin) Initialize(config map[string]interface{}) error {
+	if apiKey, ok := config["api_key"].(string); ok && apiKey != "" {
+		sp.apiKey = apiKey
+	}
+	sp.symbols = nil
+	for _, symbol := range configStringList(config["symbols"]) {
+		sp.symbols = append(sp.symbols, strings.ToUpper(strings.TrimSpace(symbol)))
+	}
+	return nil
+}
+
+// usMarketOpen reports whether the NYSE/Nasdaq regular session is open at t. Market
+// holidays are not known, so they count as open.
+func usMarketOpen(t time.Time) bool {
+	eastern, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		eastern = time.FixedZone("EST", -5*60*60)
+	}
+	local := t.In(eastern)
+	if local.Weekday() == time.Saturday || local.Weekday() == time.Sunday {
+		return false
+	}
+	minutes := local.Hour()*60 + local.Minute()
+	return minutes >= 9*60+30 && minutes < 16*60
+}
+
+// Fetch returns a quote per symbol, in configured order
+func (sp *StocksPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if sp.apiKey == "" {
+		return sp.lastData, fmt.Errorf("Finnhub API key not configured (widgets.stocks.api_key or FINNHUB_API_KEY)")
+	}
+
+	open := usMarketOpen(sp.now())
+	if !open && len(sp.lastData) == len(sp.symbols) && len(sp.lastData) > 0 {
+		for i := range sp.lastData {
+			sp.lastData[i].MarketOpen = false
+		}
+		return sp.lastData, nil
+	}
+
+	var quotes []StockQuote
+	for _, symbol := range sp.symbols {
+		var quote struct {
+			Current       float64 `json:"c"`
+			Change        float64 `json:"d"`
+			ChangePercent float64 `json:"dp"`
+			Timestamp     int64   `json:"t"`
+		}
+		if err := sp.get(ctx, "/quote?symbol="+url.QueryEscape(symbol), &quote); err != nil {
+			return sp.lastData, fmt.Errorf("%s: %w", symbol, err)
+		}
+		// Unknown symbols come back as all zeros
+		if quote.Current == 0 && quote.Timestamp == 0 {
+			return sp.lastData, fmt.Errorf("unknown symbol %s", symbol)
+		}
+
+		quotes = append(quotes, StockQuote{
+			Symbol:        symbol,
+			Price:         quote.Current,
+			Change:        quote.Change,
+			ChangePercent: quote.ChangePercent,
+			Updated:       time.Unix(quote.Timestamp, 0),
+			MarketOpen:    open,
+		})
+	}
+
+	sp.lastData = quotes
+	return quotes, nil
+}
+
+// get performs a Finnhub API request and decodes the JSON response
+func (sp *StocksPlugin) get(ctx context.Context, path string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", sp.apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Finnhub-Token", sp.apiKey)
+
+	resp, err := sp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	sp.observeRateLimit(resp)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("Finnhub rate limit reached")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Finnhub API returned status %d", resp.StatusCode)
+	}
+	return json.Unmarshal(body, target)
+}
+
+// Cleanup performs cleanup
+func (sp *StocksPlugin) Cleanup() error {
+	return nil
+}
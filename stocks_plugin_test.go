@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStocksPluginFetch(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("X-Finnhub-Token") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("X-Ratelimit-Limit", "60")
+		w.Header().Set("X-Ratelimit-Remaining", "58")
+		switch r.URL.Query().Get("symbol") {
+		case "AAPL":
+			fmt.Fprint(w, `{"c":189.2,"d":1.23,"dp":0.654,"t":1791900000}`)
+		case "MSFT":
+			fmt.Fprint(w, `{"c":410.5,"d":-2.1,"dp":-0.51,"t":1791900000}`)
+		default:
+			fmt.Fprint(w, `{"c":0,"d":null,"dp":null,"t":0}`)
+		}
+	}))
+	defer server.Close()
+
+	plugin := NewStocksPlugin()
+	plugin.apiURL = server.URL
+	// A Wednesday at 11:00 in New York
+	plugin.now = func() time.Time { return time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC) }
+	if err := plugin.Initialize(map[string]interface{}{"api_key": "key", "symbols": []string{"aapl", "MSFT"}}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	quotes := data.([]StockQuote)
+	if len(quotes) != 2 || quotes[0].Symbol != "AAPL" || quotes[0].Price != 189.2 || !quotes[0].MarketOpen {
+		t.Fatalf("Expected a quote per symbol in order, got %+v", quotes)
+	}
+	if rl, ok := plugin.RateLimit(); !ok || rl.Remaining != 58 {
+		t.Errorf("Expected the Finnhub rate limit to be tracked, got %+v", rl)
+	}
+
+	wm := NewWidgetManager()
+	wm.UpdateStocksWidget(quotes)
+	items := wm.Widgets["stocks"].Items
+	if items[0].Title != "AAPL  189.20" || items[0].Subtitle != "+1.23 (+0.65%)" || items[0].Status != "🟢" || items[1].Status != "🔴" {
+		t.Errorf("Expected prices with colored day changes, got %+v", items)
+	}
+
+	// Saturday: the market is closed, so the last quotes are kept without requests
+	plugin.now = func() time.Time { return time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC) }
+	requests = 0
+	data, _ = plugin.Fetch(context.Background())
+	if quotes := data.([]StockQuote); requests != 0 || quotes[0].MarketOpen {
+		t.Errorf("Expected no requests while the market is closed, got %d", requests)
+	}
+	wm.UpdateStocksWidget(data.([]StockQuote))
+	if subtitle := wm.Widgets["stocks"].Items[0].Subtitle; subtitle != "+1.23 (+0.65%) • market closed" {
+		t.Errorf("Expected the closed market to be shown, got '%s'", subtitle)
+	}
+
+	plugin.Initialize(map[string]interface{}{"symbols": []string{"NOPE"}})
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected an unknown symbol to fail")
+	}
+}
+
+func TestUSMarketOpen(t *testing.T) {
+	for _, tc := range []struct {
+		at   time.Time
+		open bool
+	}{
+		{time.Date(2026, 10, 14, 13, 29, 0, 0, time.UTC), false}, // 9:29 EDT
+		{time.Date(2026, 10, 14, 13, 30, 0, 0, time.UTC), true},  // 9:30 EDT
+		{time.Date(2026, 10, 14, 20, 0, 0, 0, time.UTC), false},  // 16:00 EDT
+		{time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC), false},  // Sunday
+	} {
+		if got := usMarketOpen(tc.at); got != tc.open {
+			t.Errorf("Expected open=%t at %v, got %t", tc.open, tc.at, got)
+		}
+	}
+}
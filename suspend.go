@@ -0,0 +1,69 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// suspendMsg asks the dashboard to suspend, e.g. for a SIGTSTP sent with kill
+type suspendMsg struct{}
+
+// Suspend stops polling until Resume; fetches that come due meanwhile are put off
+func (s *Scheduler) Suspend(now time.Time) {
+	s.suspendedAt = now
+}
+
+// Resume polls again after Suspend
+func (s *Scheduler) Resume() {
+	s.suspendedAt = time.Time{}
+}
+
+// Suspended reports whether the dashboard is suspended to the shell
+func (s *Scheduler) Suspended() bool {
+	return s != nil && !s.suspendedAt.IsZero()
+}
+
+// suspend pauses the scheduler and hands the terminal back to the shell
+func (m Model) suspend(now time.Time) tea.Cmd {
+	if m.scheduler != nil {
+		m.scheduler.Suspend(now)
+	}
+	return tea.Suspend
+}
+
+// resume polls again after fg: the clock is brought up to date and every widget whose
+// data is older than its interval is refreshed at once, as with r, with the header
+// counting them. Bubble Tea has restored the terminal and redraws; mouse reporting has
+// to be turned back on.
+func (m *Model) resume(now time.Time) tea.Cmd {
+	m.dateTime = now.Format("Mon 02 Jan 2006 15:04")
+	if m.scheduler == nil {
+		return nil
+	}
+	m.scheduler.Resume()
+
+	var cmds []tea.Cmd
+	if m.terminal.Mouse {
+		cmds = append(cmds, tea.EnableMouseCellMotion)
+	}
+	switch m.instance {
+	case instanceAttach:
+		return tea.Batch(append(cmds, attachCmd(m.statePath, 0))...)
+	case instanceManual:
+		// Refreshed only with r
+		return tea.Batch(cmds...)
+	}
+
+	var stale []string
+	for _, fetch := range widgetFetchMsgs() {
+		widget, ok := fetchWidget(fetch)
+		if !ok || !m.fetchable(widget) || m.versions.Recent(widget, now, m.scheduler.GetInterval(widget, time.Minute)) {
+			continue
+		}
+		stale = append(stale, widget)
+		cmds = append(cmds, func() tea.Msg { return refreshNowMsg{fetch: fetch} })
+	}
+	m.refresh.Start(stale)
+	return tea.Batch(cmds...)
+}
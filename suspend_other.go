@@ -0,0 +1,8 @@
+//go:build !unix
+
+package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// watchSuspend does nothing where there is no SIGTSTP
+func watchSuspend(p *tea.Program) {}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSuspendAndResume(t *testing.T) {
+	m := benchmarkModel(220, 60)
+	m.versions = NewDataVersions()
+	m.refresh = NewRefreshProgress()
+	m.scheduler.AddTask("weather", 10*time.Minute, nil)
+	m.versions.Fetched("weather", time.Now())
+
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	m = model.(Model)
+	msgs := immediateMsgs(cmd)
+	if len(msgs) != 1 || msgs[0] != (tea.SuspendMsg{}) {
+		t.Errorf("Expected ctrl+z to suspend, got %v", msgs)
+	}
+	if !m.scheduler.Suspended() || !m.scheduler.Paused("news", time.Now()) {
+		t.Error("Expected the scheduler to be paused while suspended")
+	}
+
+	// A fetch that comes due while suspended is put off
+	model, _ = m.Update(fetchNewsCmd{})
+	m = model.(Model)
+	if m.versions.Seen("news") {
+		t.Error("Expected no fetch while suspended")
+	}
+
+	model, cmd = m.Update(tea.ResumeMsg{})
+	m = model.(Model)
+	if m.scheduler.Suspended() {
+		t.Error("Expected polling to go on after resume")
+	}
+	refreshed := make(map[string]bool)
+	for _, msg := range immediateMsgs(cmd) {
+		if refresh, ok := msg.(refreshNowMsg); ok {
+			widget, _ := fetchWidget(refresh.fetch)
+			refreshed[widget] = true
+		}
+	}
+	if refreshed["weather"] || !refreshed["news"] || len(refreshed) != len(widgetFetchMsgs())-1 {
+		t.Errorf("Expected every widget but the fresh weather to be refreshed, got %v", refreshed)
+	}
+	if !m.refresh.Running() {
+		t.Error("Expected the header to count the refresh after resume")
+	}
+
+	// An outside SIGTSTP suspends the same way
+	_, cmd = m.Update(suspendMsg{})
+	if msgs := immediateMsgs(cmd); len(msgs) != 1 || msgs[0] != (tea.SuspendMsg{}) {
+		t.Errorf("Expected SIGTSTP to suspend, got %v", msgs)
+	}
+}
+
+func TestResumeInManualMode(t *testing.T) {
+	m := benchmarkModel(220, 60)
+	m.versions = NewDataVersions()
+	m.instance = instanceManual
+	m.terminal.Mouse = true
+	m.scheduler.Suspend(time.Now())
+
+	cmd := m.resume(time.Now())
+	for _, msg := range immediateMsgs(cmd) {
+		if _, ok := msg.(refreshNowMsg); ok {
+			t.Error("Expected manual refresh to wait for r after resume")
+		}
+	}
+	if cmd == nil {
+		t.Error("Expected mouse reporting to be turned back on")
+	}
+}
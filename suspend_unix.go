@@ -0,0 +1,30 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// watchSuspend turns a SIGTSTP from outside, e.g. kill -TSTP, into the same clean
+// suspend as ctrl+z. Caught, the signal would no longer stop the process, so it is let
+// through again while Bubble Tea sends its own, and caught again once resumed.
+func watchSuspend(p *tea.Program) {
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTSTP)
+	go func() {
+		for range stop {
+			cont := make(chan os.Signal, 1)
+			signal.Notify(cont, syscall.SIGCONT)
+			signal.Reset(syscall.SIGTSTP)
+			p.Send(suspendMsg{})
+			<-cont
+			signal.Stop(cont)
+			signal.Notify(stop, syscall.SIGTSTP)
+		}
+	}()
+}
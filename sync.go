@@ -0,0 +1,290 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// syncedStore is a file in ~/.goday shared between machines by goday sync. Its merge
+// must be commutative and idempotent, so machines agree whatever order they sync in
+// and merging a copy that already holds the local data changes nothing.
+type syncedStore struct {
+	name  string
+	merge func(local, remote []byte) ([]byte, error)
+}
+
+// syncedStores are the stores kept the same on every machine
+var syncedStores = []syncedStore{
+	{name: "audit.jsonl", merge: mergeAuditLogs},
+	{name: "traffic_history.json", merge: mergeTrafficHistories},
+	{name: "workload_history.json", merge: mergeWorkloadHistories},
+}
+
+// mergeAuditLogs is the union of two audit trails, oldest first
+func mergeAuditLogs(local, remote []byte) ([]byte, error) {
+	seen := make(map[string]bool)
+	var lines []string
+	var entries []AuditEntry
+	for _, data := range [][]byte{local, remote} {
+		for _, line := range strings.Split(string(data), "\n") {
+			var entry AuditEntry
+			if json.Unmarshal([]byte(line), &entry) != nil || entry.Action == "" {
+				continue
+			}
+			// Entries are compared re-encoded, so formatting differences do not duplicate them
+			encoded, _ := json.Marshal(entry)
+			if seen[string(encoded)] {
+				continue
+			}
+			seen[string(encoded)] = true
+			entries = append(entries, entry)
+			lines = append(lines, string(encoded))
+		}
+	}
+
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := entries[order[i]], entries[order[j]]
+		if !a.Time.Equal(b.Time) {
+			return a.Time.Before(b.Time)
+		}
+		return lines[order[i]] < lines[order[j]]
+	})
+
+	var out bytes.Buffer
+	for _, i := range order {
+		out.WriteString(lines[i])
+		out.WriteByte('\n')
+	}
+	return out.Bytes(), nil
+}
+
+// mergeTrafficHistories keeps, for each weekday and hour, the average with the most
+// samples behind it. Averages cannot be combined without double counting trips both
+// machines already share, so the better-informed one wins.
+func mergeTrafficHistories(local, remote []byte) ([]byte, error) {
+	merged := &TrafficHistory{Slots: make(map[string]*TrafficSlot)}
+	for _, data := range [][]byte{local, remote} {
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+		var history TrafficHistory
+		if err := json.Unmarshal(data, &history); err != nil {
+			return nil, err
+		}
+		for key, slot := range history.Slots {
+			if slot == nil {
+				continue
+			}
+			current, ok := merged.Slots[key]
+			if !ok || slot.Samples > current.Samples || (slot.Samples == current.Samples && slot.AverageSec > current.AverageSec) {
+				merged.Slots[key] = slot
+			}
+		}
+	}
+	return json.Marshal(merged)
+}
+
+// mergeWorkloadHistories keeps, for each day, the count taken last on either machine
+func mergeWorkloadHistories(local, remote []byte) ([]byte, error) {
+	merged := &WorkloadHistory{Days: make(map[string]WorkloadSample)}
+	for _, data := range [][]byte{local, remote} {
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+		var history WorkloadHistory
+		if err := json.Unmarshal(data, &history); err != nil {
+			return nil, err
+		}
+		for day, sample := range history.Days {
+			current, ok := merged.Days[day]
+			later := sample.At.After(current.At)
+			// Ties need an order too, so both machines pick the same sample
+			if sample.At.Equal(current.At) {
+				later = sample.Reviews > current.Reviews || (sample.Reviews == current.Reviews && sample.Issues > current.Issues)
+			}
+			if !ok || later {
+				merged.Days[day] = sample
+			}
+		}
+	}
+	return json.Marshal(merged)
+}
+
+// StateSync shares the synced stores through a folder every machine can reach, such as
+// a Syncthing folder or a git checkout. Each machine only writes its own subfolder,
+// machines/<name>, so the backend never sees two machines change the same file, and
+// reads every other machine's copy to merge into its own.
+type StateSync struct {
+	dir      string // shared folder
+	stateDir string // local ~/.goday
+	machine  string
+	git      bool // pull before and commit and push after
+	run      func(dir string, args ...string) error
+}
+
+// NewStateSync creates a sync from config, or returns an error when sync.dir is not set
+func NewStateSync(cfg *Config) (*StateSync, error) {
+	if cfg == nil || cfg.Sync.Dir == "" {
+		return nil, fmt.Errorf("no sync folder configured (set sync.dir in config.yaml)")
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := cfg.Sync.Dir
+	if strings.HasPrefix(dir, "~/") {
+		dir = filepath.Join(homeDir, dir[2:])
+	}
+	machine := cfg.Sync.Machine
+	if machine == "" {
+		if machine, err = os.Hostname(); err != nil {
+			return nil, fmt.Errorf("cannot name this machine, set sync.machine: %w", err)
+		}
+	}
+	if strings.ContainsAny(machine, `/\`) || machine == "." || machine == ".." {
+		return nil, fmt.Errorf("invalid sync.machine %q", machine)
+	}
+
+	_, err = os.Stat(filepath.Join(dir, ".git"))
+	return &StateSync{
+		dir:      dir,
+		stateDir: filepath.Join(homeDir, ".goday"),
+		machine:  machine,
+		git:      err == nil,
+		run:      runGit,
+	}, nil
+}
+
+// runGit runs a git command in dir
+func runGit(dir string, args ...string) error {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	if errors.Is(err, exec.ErrNotFound) {
+		return missingBinaryError("git")
+	}
+	if err != nil {
+		return fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Sync merges every other machine's stores into the local ones and publishes the result.
+// It returns the names of the other machines found.
+func (s *StateSync) Sync() ([]string, error) {
+	if s.git {
+		if err := s.run(s.dir, "pull", "--ff-only", "--quiet"); err != nil {
+			return nil, err
+		}
+	}
+
+	machinesDir := filepath.Join(s.dir, "machines")
+	entries, err := os.ReadDir(machinesDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	var others []string
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != s.machine {
+			others = append(others, entry.Name())
+		}
+	}
+
+	own := filepath.Join(machinesDir, s.machine)
+	for _, store := range syncedStores {
+		local := filepath.Join(s.stateDir, store.name)
+		// The dashboard may be adding to the store meanwhile, so merge and write under its lock
+		err := withFileLock(local, func() error {
+			merged, err := readOptional(local)
+			if err != nil {
+				return err
+			}
+			for _, other := range others {
+				remote, err := readOptional(filepath.Join(machinesDir, other, store.name))
+				if err != nil {
+					return err
+				}
+				if remote == nil {
+					continue
+				}
+				if merged, err = store.merge(merged, remote); err != nil {
+					return fmt.Errorf("%s from %s: %w", store.name, other, err)
+				}
+			}
+			if merged == nil {
+				return nil
+			}
+			for _, path := range []string{local, filepath.Join(own, store.name)} {
+				if err := writeFileAtomic(path, merged, 0600); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if s.git {
+		if err := s.run(s.dir, "add", "--", filepath.Join("machines", s.machine)); err != nil {
+			return nil, err
+		}
+		// Nothing to commit is not an error; the push still sends earlier commits
+		s.run(s.dir, "commit", "--quiet", "-m", "goday sync from "+s.machine)
+		if err := s.run(s.dir, "push", "--quiet"); err != nil {
+			return nil, err
+		}
+	}
+	return others, nil
+}
+
+// readOptional reads a file, returning nil without an error when it does not exist
+func readOptional(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// runSync runs goday sync
+func runSync(args []string, out io.Writer) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected argument %q", args[0])
+	}
+	cfg, err := LoadConfigFromDefaultPath()
+	if err != nil {
+		return err
+	}
+	sync, err := NewStateSync(cfg)
+	if err != nil {
+		return err
+	}
+	others, err := sync.Sync()
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, store := range syncedStores {
+		names = append(names, store.name)
+	}
+	if len(others) == 0 {
+		_, err = fmt.Fprintf(out, "Published %s as %s; no other machines yet\n", strings.Join(names, ", "), sync.machine)
+		return err
+	}
+	_, err = fmt.Fprintf(out, "Synced %s with %s\n", strings.Join(names, ", "), strings.Join(others, ", "))
+	return err
+}
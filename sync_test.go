@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergeStoresConflictFree(t *testing.T) {
+	laptop := []byte(`{"time":"2026-10-16T09:00:00Z","action":"pr.approve","target":"octocat/api#1"}
+{"time":"2026-10-16T11:00:00Z","action":"issue.label","target":"octocat/web#7","detail":"bug"}
+`)
+	desktop := []byte(`{"time":"2026-10-16T10:00:00Z","action":"pr.merge","target":"octocat/api#2"}
+{"time": "2026-10-16T09:00:00Z", "action": "pr.approve", "target": "octocat/api#1"}
+`)
+	ab, _ := mergeAuditLogs(laptop, desktop)
+	ba, _ := mergeAuditLogs(desktop, laptop)
+	if !bytes.Equal(ab, ba) {
+		t.Errorf("Expected the merge not to depend on order:\n%s\n%s", ab, ba)
+	}
+	if again, _ := mergeAuditLogs(ab, desktop); !bytes.Equal(again, ab) {
+		t.Errorf("Expected merging a copy again to change nothing, got:\n%s", again)
+	}
+	lines := strings.Split(strings.TrimSpace(string(ab)), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[1], "pr.merge") {
+		t.Errorf("Expected the three distinct actions by time, got:\n%s", ab)
+	}
+
+	busy := []byte(`{"slots":{"Mon 08":{"samples":5,"average_sec":2700},"Tue 09":{"samples":1,"average_sec":1800}}}`)
+	quiet := []byte(`{"slots":{"Mon 08":{"samples":2,"average_sec":3000},"Wed 18":{"samples":3,"average_sec":3300}}}`)
+	ab, _ = mergeTrafficHistories(busy, quiet)
+	ba, _ = mergeTrafficHistories(quiet, busy)
+	if !bytes.Equal(ab, ba) {
+		t.Errorf("Expected the merge not to depend on order:\n%s\n%s", ab, ba)
+	}
+	history := LoadTrafficHistory(writeTemp(t, ab))
+	if len(history.Slots) != 3 || history.Slots["Mon 08"].Samples != 5 {
+		t.Errorf("Expected every slot with the best-informed average, got %s", ab)
+	}
+	if _, err := mergeTrafficHistories(busy, []byte("not json")); err == nil {
+		t.Error("Expected a corrupt history to fail rather than be dropped")
+	}
+}
+
+// writeTemp writes data to a temporary file and returns its path
+func writeTemp(t *testing.T, data []byte) string {
+	path := filepath.Join(t.TempDir(), "data")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestStateSyncBetweenMachines(t *testing.T) {
+	shared := t.TempDir()
+	machine := func(name string) *StateSync {
+		return &StateSync{dir: shared, stateDir: t.TempDir(), machine: name}
+	}
+	laptop, desktop := machine("laptop"), machine("desktop")
+
+	at := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	record := func(s *StateSync, action string, minutes int) {
+		audit := NewAuditLog(filepath.Join(s.stateDir, "audit.jsonl"))
+		audit.now = func() time.Time { return at.Add(time.Duration(minutes) * time.Minute) }
+		audit.Record(action, "octocat/api#1", "", "")
+	}
+	record(laptop, auditPRApprove, 0)
+	record(desktop, auditPRMerge, 5)
+
+	if others, err := laptop.Sync(); err != nil || len(others) != 0 {
+		t.Fatalf("Expected the first machine to publish alone, got %v, %v", others, err)
+	}
+	if others, err := desktop.Sync(); err != nil || len(others) != 1 || others[0] != "laptop" {
+		t.Fatalf("Expected the desktop to find the laptop, got %v, %v", others, err)
+	}
+	laptop.Sync()
+
+	for _, s := range []*StateSync{laptop, desktop} {
+		entries, _ := ReadAuditLog(filepath.Join(s.stateDir, "audit.jsonl"))
+		if len(entries) != 2 || entries[0].Action != auditPRApprove || entries[1].Action != auditPRMerge {
+			t.Errorf("Expected both actions on the %s, got %+v", s.machine, entries)
+		}
+	}
+
+	// Syncing again without changes is stable
+	before, _ := os.ReadFile(filepath.Join(shared, "machines", "laptop", "audit.jsonl"))
+	laptop.Sync()
+	after, _ := os.ReadFile(filepath.Join(shared, "machines", "laptop", "audit.jsonl"))
+	if !bytes.Equal(before, after) {
+		t.Error("Expected a repeated sync to change nothing")
+	}
+}
+
+func TestStateSyncGit(t *testing.T) {
+	var commands []string
+	s := &StateSync{dir: t.TempDir(), stateDir: t.TempDir(), machine: "laptop", git: true,
+		run: func(dir string, args ...string) error {
+			commands = append(commands, args[0])
+			return nil
+		}}
+	if _, err := s.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if got := strings.Join(commands, ","); got != "pull,add,commit,push" {
+		t.Errorf("Expected pull, then commit and push of this machine's folder, got %s", got)
+	}
+}
+
+func TestNewStateSync(t *testing.T) {
+	if _, err := NewStateSync(&Config{}); err == nil {
+		t.Error("Expected a missing sync.dir to fail")
+	}
+	cfg := &Config{}
+	cfg.Sync.Dir = t.TempDir()
+	cfg.Sync.Machine = "../desktop"
+	if _, err := NewStateSync(cfg); err == nil {
+		t.Error("Expected a machine name with a path to fail")
+	}
+	cfg.Sync.Machine = "laptop"
+	if s, err := NewStateSync(cfg); err != nil || s.git {
+		t.Errorf("Expected a plain folder, got %+v, %v", s, err)
+	}
+}
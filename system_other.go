@@ -0,0 +1,13 @@
+//go:build !unix
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// diskUsage is not available on this system
+func diskUsage(path string) (used, total uint64, err error) {
+	return 0, 0, fmt.Errorf("disk use cannot be read on %s", runtime.GOOS)
+}
@@ -0,0 +1,472 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// systemDefaultWarnPercent turns a resource yellow without widgets.system.warn_percent
+	systemDefaultWarnPercent = 75
+	// systemDefaultCriticalPercent turns a resource red without widgets.system.critical_percent
+	systemDefaultCriticalPercent = 90
+	// cpuSampleInterval is how long the first fetch measures CPU use over; later fetches
+	// measure since the one before
+	cpuSampleInterval = 500 * time.Millisecond
+)
+
+// DiskUsage is how full the filesystem holding a path is
+type DiskUsage struct {
+	Path  string
+	Used  uint64 // bytes
+	Total uint64 // bytes
+	Err   string // why the filesystem could not be read
+}
+
+// ProcessUsage is one process's share of the CPU and its resident memory
+type ProcessUsage struct {
+	PID        int
+	Name       string
+	CPUPercent float64 // of the whole machine
+	Memory     uint64  // resident bytes
+}
+
+// SystemSnapshot is the resource use of the machine running GoDay
+type SystemSnapshot struct {
+	CPUPercent  float64 // of all cores
+	Cores       int
+	MemoryUsed  uint64 // bytes, not counting reclaimable cache
+	MemoryTotal uint64
+	Load        [3]float64 // 1, 5 and 15 minute averages; zero where the system has none
+	Disks       []DiskUsage
+	Top         []ProcessUsage // busiest first, when widgets.system.top is set
+
+	WarnPercent     int // use from which a resource is 🟡
+	CriticalPercent int // use from which a resource is 🔴
+}
+
+// percent returns used as a percentage of total, or 0 without a total
+func percent(used, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(used) / float64(total) * 100
+}
+
+// cpuTimes is the CPU time the machine, and each process, has used so far, in clock ticks
+type cpuTimes struct {
+	busy, total uint64
+	processes   map[int]uint64
+}
+
+// SystemPlugin reads CPU, memory, load and disk use from the local machine, so it needs
+// no network: /proc on Linux and sysctl, vm_stat and ps on macOS
+type SystemPlugin struct {
+	id              string
+	pluginType      string
+	name            string
+	version         string
+	description     string
+	author          string
+	disks           []string
+	top             int
+	warnPercent     int
+	criticalPercent int
+	procDir         string    // /proc, replaced in tests
+	last            *cpuTimes // Linux CPU times at the previous fetch
+	lastData        SystemSnapshot
+}
+
+// NewSystemPlugin creates a new system resources plugin
+func NewSystemPlugin() *SystemPlugin {
+	return &SystemPlugin{
+		id:              "system",
+		pluginType:      "system",
+		name:            "System",
+		version:         "1.0.0",
+		description:     "Shows CPU, memory, load and disk use of this machine",
+		author:          "GoDay Team",
+		disks:           []string{defaultDiskPath()},
+		warnPercent:     systemDefaultWarnPercent,
+		criticalPercent: systemDefaultCriticalPercent,
+		procDir:         "/proc",
+	}
+}
+
+// defaultDiskPath is the filesystem shown without widgets.system.disks: the one holding
+// the home directory
+func defaultDiskPath() string {
+	if home, err := os.UserHomeDir(); err == nil {
+		return home
+	}
+	return "/"
+}
+
+// GetID returns the plugin ID
+func (sp *SystemPlugin) GetID() string {
+	return sp.id
+}
+
+// GetType returns the plugin type
+func (sp *SystemPlugin) GetType() string {
+	return sp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (sp *SystemPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        sp.name,
+		Version:     sp.version,
+		Description: sp.description,
+		Author:      sp.author,
+		Type:        sp.pluginType,
+		Config: map[string]string{
+			"disks":            strings.Join(sp.disks, ","),
+			"top":              fmt.Sprintf("%d", sp.top),
+			"warn_percent":     fmt.Sprintf("%d", sp.warnPercent),
+			"critical_percent": fmt.Sprintf("%d", sp.criticalPercent),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (sp *SystemPlugin) Initialize(config map[string]interface{}) error {
+	if disks := configStringList(config["disks"]); len(disks) > 0 {
+		sp.disks = nil
+		for _, disk := range disks {
+			if strings.HasPrefix(disk, "~/") {
+				home, _ := os.UserHomeDir()
+				disk = filepath.Join(home, disk[2:])
+			}
+			sp.disks = append(sp.disks, disk)
+		}
+	}
+	if top, ok := config["top"].(int); ok && top >= 0 {
+		sp.top = top
+	}
+	if warn, ok := config["warn_percent"].(int); ok && warn > 0 {
+		sp.warnPercent = warn
+	}
+	if critical, ok := config["critical_percent"].(int); ok && critical > 0 {
+		sp.criticalPercent = critical
+	}
+	if sp.warnPercent > sp.criticalPercent || sp.criticalPercent > 100 {
+		return fmt.Errorf("warn_percent (%d) must not be more than critical_percent (%d), which is at most 100", sp.warnPercent, sp.criticalPercent)
+	}
+	return nil
+}
+
+// Fetch reads the machine's resource use
+func (sp *SystemPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	snapshot := SystemSnapshot{
+		Cores:           runtime.NumCPU(),
+		WarnPercent:     sp.warnPercent,
+		CriticalPercent: sp.criticalPercent,
+	}
+	var err error
+	switch runtime.GOOS {
+	case "linux":
+		err = sp.readLinux(ctx, &snapshot)
+	case "darwin":
+		err = sp.readDarwin(ctx, &snapshot)
+	default:
+		err = fmt.Errorf("CPU and memory use cannot be read on %s", runtime.GOOS)
+	}
+	if err != nil {
+		return sp.lastData, err
+	}
+
+	for _, path := range sp.disks {
+		disk := DiskUsage{Path: path}
+		if disk.Used, disk.Total, err = diskUsage(path); err != nil {
+			disk.Err = err.Error()
+		}
+		snapshot.Disks = append(snapshot.Disks, disk)
+	}
+
+	sp.lastData = snapshot
+	return snapshot, nil
+}
+
+// readLinux fills the snapshot from /proc. CPU use is the share of time not idle since
+// the previous fetch, or over cpuSampleInterval on the first.
+func (sp *SystemPlugin) readLinux(ctx context.Context, snapshot *SystemSnapshot) error {
+	before := sp.last
+	if before == nil {
+		var err error
+		if before, err = sp.readCPUTimes(); err != nil {
+			return err
+		}
+		select {
+		case <-time.After(cpuSampleInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	now, err := sp.readCPUTimes()
+	if err != nil {
+		return err
+	}
+	sp.last = now
+	elapsed := now.total - before.total
+	if elapsed > 0 {
+		snapshot.CPUPercent = float64(now.busy-before.busy) / float64(elapsed) * 100
+	}
+
+	if err := sp.readMeminfo(snapshot); err != nil {
+		return err
+	}
+	if data, err := os.ReadFile(filepath.Join(sp.procDir, "loadavg")); err == nil {
+		fields := strings.Fields(string(data))
+		for i := 0; i < 3 && i < len(fields); i++ {
+			snapshot.Load[i], _ = strconv.ParseFloat(fields[i], 64)
+		}
+	}
+
+	if sp.top > 0 && elapsed > 0 {
+		for pid, ticks := range now.processes {
+			used := ticks - before.processes[pid]
+			if ticks < before.processes[pid] {
+				// The pid was reused by a new process
+				used = ticks
+			}
+			name, memory := sp.readProcess(pid)
+			snapshot.Top = append(snapshot.Top, ProcessUsage{
+				PID:        pid,
+				Name:       name,
+				CPUPercent: float64(used) / float64(elapsed) * 100,
+				Memory:     memory,
+			})
+		}
+		snapshot.Top = busiest(snapshot.Top, sp.top)
+	}
+	return nil
+}
+
+// readCPUTimes reads the machine's CPU time from /proc/stat and, when processes are
+// shown, each process's from /proc/<pid>/stat
+func (sp *SystemPlugin) readCPUTimes() (*cpuTimes, error) {
+	data, err := os.ReadFile(filepath.Join(sp.procDir, "stat"))
+	if err != nil {
+		return nil, err
+	}
+	times := &cpuTimes{processes: make(map[int]uint64)}
+	line, _, _ := strings.Cut(string(data), "\n")
+	fields := strings.Fields(line)
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return nil, fmt.Errorf("unexpected %s/stat format", sp.procDir)
+	}
+	for i, field := range fields[1:] {
+		// Guest time, after the first 8 fields, is already counted in user time
+		if i >= 8 {
+			break
+		}
+		ticks, _ := strconv.ParseUint(field, 10, 64)
+		times.total += ticks
+		// idle and iowait
+		if i != 3 && i != 4 {
+			times.busy += ticks
+		}
+	}
+
+	if sp.top > 0 {
+		entries, _ := os.ReadDir(sp.procDir)
+		for _, entry := range entries {
+			pid, err := strconv.Atoi(entry.Name())
+			if err != nil {
+				continue
+			}
+			if fields := procStatFields(filepath.Join(sp.procDir, entry.Name(), "stat")); len(fields) > 12 {
+				utime, _ := strconv.ParseUint(fields[11], 10, 64)
+				stime, _ := strconv.ParseUint(fields[12], 10, 64)
+				times.processes[pid] = utime + stime
+			}
+		}
+	}
+	return times, nil
+}
+
+// procStatFields returns the fields of a /proc/<pid>/stat file after the command name,
+// which is in parentheses and may contain spaces, starting with the state
+func procStatFields(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	end := strings.LastIndexByte(string(data), ')')
+	if end < 0 {
+		return nil
+	}
+	return strings.Fields(string(data[end+1:]))
+}
+
+// readProcess returns a process's command name and resident memory
+func (sp *SystemPlugin) readProcess(pid int) (string, uint64) {
+	dir := filepath.Join(sp.procDir, strconv.Itoa(pid))
+	name, _ := os.ReadFile(filepath.Join(dir, "comm"))
+	var memory uint64
+	if fields := procStatFields(filepath.Join(dir, "stat")); len(fields) > 21 {
+		pages, _ := strconv.ParseUint(fields[21], 10, 64)
+		memory = pages * uint64(os.Getpagesize())
+	}
+	return strings.TrimSpace(string(name)), memory
+}
+
+// readMeminfo reads total and available memory from /proc/meminfo
+func (sp *SystemPlugin) readMeminfo(snapshot *SystemSnapshot) error {
+	file, err := os.Open(filepath.Join(sp.procDir, "meminfo"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var available uint64
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		kb, _ := strconv.ParseUint(fields[1], 10, 64)
+		switch fields[0] {
+		case "MemTotal:":
+			snapshot.MemoryTotal = kb * 1024
+		case "MemAvailable:":
+			available = kb * 1024
+		}
+	}
+	if snapshot.MemoryTotal > available {
+		snapshot.MemoryUsed = snapshot.MemoryTotal - available
+	}
+	return scanner.Err()
+}
+
+// readDarwin fills the snapshot from sysctl, vm_stat and ps. CPU use is the sum of every
+// process's recent use as ps reports it.
+func (sp *SystemPlugin) readDarwin(ctx context.Context, snapshot *SystemSnapshot) error {
+	out, err := exec.CommandContext(ctx, "sysctl", "-n", "hw.memsize", "vm.loadavg").Output()
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) < 2 {
+		return fmt.Errorf("unexpected sysctl output")
+	}
+	snapshot.MemoryTotal, _ = strconv.ParseUint(strings.TrimSpace(lines[0]), 10, 64)
+	// vm.loadavg is "{ 1.52 1.61 1.73 }"
+	for i, field := range strings.Fields(strings.Trim(lines[1], "{} ")) {
+		if i < 3 {
+			snapshot.Load[i], _ = strconv.ParseFloat(field, 64)
+		}
+	}
+
+	if out, err := exec.CommandContext(ctx, "vm_stat").Output(); err == nil {
+		snapshot.MemoryUsed = darwinMemoryUsed(string(out))
+	}
+
+	out, err = exec.CommandContext(ctx, "ps", "-Ao", "pid=,pcpu=,rss=,comm=").Output()
+	if err != nil {
+		return err
+	}
+	var processes []ProcessUsage
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		pid, _ := strconv.Atoi(fields[0])
+		cpu, _ := strconv.ParseFloat(fields[1], 64)
+		rss, _ := strconv.ParseUint(fields[2], 10, 64)
+		// ps reports CPU per core
+		cpu /= float64(snapshot.Cores)
+		snapshot.CPUPercent += cpu
+		processes = append(processes, ProcessUsage{
+			PID:        pid,
+			Name:       filepath.Base(strings.Join(fields[3:], " ")),
+			CPUPercent: cpu,
+			Memory:     rss * 1024,
+		})
+	}
+	if snapshot.CPUPercent > 100 {
+		snapshot.CPUPercent = 100
+	}
+	if sp.top > 0 {
+		snapshot.Top = busiest(processes, sp.top)
+	}
+	return nil
+}
+
+// darwinMemoryUsed counts active, wired and compressed pages from vm_stat as used, like
+// Activity Monitor's memory used
+func darwinMemoryUsed(vmStat string) uint64 {
+	pageSize := uint64(4096)
+	var pages uint64
+	for _, line := range strings.Split(vmStat, "\n") {
+		if strings.Contains(line, "page size of") {
+			fields := strings.Fields(line)
+			for i, field := range fields {
+				if field == "of" && i+1 < len(fields) {
+					pageSize, _ = strconv.ParseUint(fields[i+1], 10, 64)
+				}
+			}
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch name {
+		case "Pages active", "Pages wired down", "Pages occupied by compressor":
+			count, _ := strconv.ParseUint(strings.TrimSuffix(strings.TrimSpace(value), "."), 10, 64)
+			pages += count
+		}
+	}
+	return pages * pageSize
+}
+
+// busiest returns the n processes using the most CPU, then memory
+func busiest(processes []ProcessUsage, n int) []ProcessUsage {
+	sort.Slice(processes, func(i, j int) bool {
+		if processes[i].CPUPercent != processes[j].CPUPercent {
+			return processes[i].CPUPercent > processes[j].CPUPercent
+		}
+		return processes[i].Memory > processes[j].Memory
+	})
+	if len(processes) > n {
+		processes = processes[:n]
+	}
+	return processes
+}
+
+// resourceLevel returns 🔴 from critical percent used, 🟡 from warn, otherwise 🟢
+func resourceLevel(used float64, warn, critical int) string {
+	switch {
+	case used >= float64(critical):
+		return "🔴"
+	case used >= float64(warn):
+		return "🟡"
+	}
+	return "🟢"
+}
+
+// formatBytes shows a size in GB from one GB up, otherwise in MB
+func formatBytes(size uint64) string {
+	if size >= 1<<30 {
+		return fmt.Sprintf("%.1f GB", float64(size)/(1<<30))
+	}
+	return fmt.Sprintf("%d MB", size>>20)
+}
+
+// Cleanup performs cleanup
+func (sp *SystemPlugin) Cleanup() error {
+	return nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeProc writes the files of a fake /proc under dir
+func writeProc(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSystemPluginReadLinux(t *testing.T) {
+	dir := t.TempDir()
+	writeProc(t, dir, map[string]string{
+		"stat":      "cpu  100 0 100 700 100 0 0 0 50 0\ncpu0 100 0 100 700 100 0 0 0 50 0\n",
+		"meminfo":   "MemTotal:       16000000 kB\nMemFree:         1000000 kB\nMemAvailable:    4000000 kB\n",
+		"loadavg":   "1.50 1.00 0.50 2/300 4242\n",
+		"10/stat":   "10 (web server) S 1 10 10 0 -1 0 0 0 0 0 100 50 0 0 20 0 1 0 100 1000 2560\n",
+		"10/comm":   "web server\n",
+		"20/stat":   "20 (idle) S 1 20 20 0 -1 0 0 0 0 0 5 5 0 0 20 0 1 0 100 1000 256\n",
+		"20/comm":   "idle\n",
+		"self/stat": "not a pid\n",
+	})
+
+	plugin := NewSystemPlugin()
+	if err := plugin.Initialize(map[string]interface{}{"top": 1}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	plugin.procDir = dir
+	var err error
+	if plugin.last, err = plugin.readCPUTimes(); err != nil {
+		t.Fatalf("readCPUTimes failed: %v", err)
+	}
+
+	// 1000 ticks later, 600 of them busy, 300 of those by the web server
+	writeProc(t, dir, map[string]string{
+		"stat":    "cpu  500 0 300 1000 200 0 0 0 50 0\n",
+		"10/stat": "10 (web server) S 1 10 10 0 -1 0 0 0 0 0 300 150 0 0 20 0 1 0 100 1000 2560\n",
+	})
+	snapshot := SystemSnapshot{Cores: 4}
+	if err := plugin.readLinux(context.Background(), &snapshot); err != nil {
+		t.Fatalf("readLinux failed: %v", err)
+	}
+	if snapshot.CPUPercent != 60 {
+		t.Errorf("Expected 60%% CPU, got %.1f", snapshot.CPUPercent)
+	}
+	if snapshot.MemoryTotal != 16000000*1024 || snapshot.MemoryUsed != 12000000*1024 {
+		t.Errorf("Expected 12 of 16 GB used, got %d of %d", snapshot.MemoryUsed, snapshot.MemoryTotal)
+	}
+	if snapshot.Load != [3]float64{1.5, 1, 0.5} {
+		t.Errorf("Expected the load averages, got %v", snapshot.Load)
+	}
+	if len(snapshot.Top) != 1 || snapshot.Top[0].Name != "web server" || snapshot.Top[0].CPUPercent != 30 ||
+		snapshot.Top[0].Memory != 2560*uint64(os.Getpagesize()) {
+		t.Errorf("Expected the web server as the busiest process, got %+v", snapshot.Top)
+	}
+}
+
+func TestSystemPluginInitialize(t *testing.T) {
+	if err := NewSystemPlugin().Initialize(map[string]interface{}{"warn_percent": 95}); err == nil {
+		t.Error("Expected warn_percent above critical_percent to be rejected")
+	}
+	home, _ := os.UserHomeDir()
+	plugin := NewSystemPlugin()
+	plugin.Initialize(map[string]interface{}{"disks": []string{"~/", "/"}})
+	if len(plugin.disks) != 2 || plugin.disks[0] != home {
+		t.Errorf("Expected ~/ to be the home directory, got %v", plugin.disks)
+	}
+	if used, total, err := diskUsage(t.TempDir()); err == nil && (total == 0 || used > total) {
+		t.Errorf("Expected used within the disk size, got %d of %d", used, total)
+	}
+}
+
+func TestDarwinMemoryUsed(t *testing.T) {
+	vmStat := "Mach Virtual Memory Statistics: (page size of 16384 bytes)\n" +
+		"Pages free:                               10000.\n" +
+		"Pages active:                            100000.\n" +
+		"Pages wired down:                         50000.\n" +
+		"Pages occupied by compressor:             10000.\n"
+	if got := darwinMemoryUsed(vmStat); got != 160000*16384 {
+		t.Errorf("Expected active, wired and compressed pages, got %d", got)
+	}
+}
+
+func TestUpdateSystemWidget(t *testing.T) {
+	wm := NewWidgetManager()
+	wm.UpdateSystemWidget(SystemSnapshot{
+		CPUPercent:      20,
+		Cores:           4,
+		Load:            [3]float64{3.2, 2, 1},
+		MemoryUsed:      15 << 30,
+		MemoryTotal:     16 << 30,
+		Disks:           []DiskUsage{{Path: "/", Used: 100 << 30, Total: 500 << 30}, {Path: "/mnt", Err: "no such file or directory"}},
+		Top:             []ProcessUsage{{PID: 42, Name: "go", CPUPercent: 12.5, Memory: 300 << 20}},
+		WarnPercent:     75,
+		CriticalPercent: 90,
+	})
+	widget := wm.Widgets["system"]
+	want := []string{"🟢", "🟡", "🔴", "🟢", "❌", "⚙️"}
+	if len(widget.Items) != len(want) {
+		t.Fatalf("Expected %d lines, got %+v", len(want), widget.Items)
+	}
+	for i, item := range widget.Items {
+		if item.Status != want[i] {
+			t.Errorf("Expected %s for '%s', got %s", want[i], item.Title, item.Status)
+		}
+	}
+	if widget.Count != 3 {
+		t.Errorf("Expected load, memory and the unreadable disk to count, got %d", widget.Count)
+	}
+	if got := widget.Items[2].Subtitle; got != "15.0 GB of 16.0 GB" {
+		t.Errorf("Expected memory in GB, got '%s'", got)
+	}
+	if got := widget.Items[5].Subtitle; !strings.HasPrefix(got, "12.5% CPU • 300 MB") {
+		t.Errorf("Expected the process's CPU and memory, got '%s'", got)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package main
+
+import "syscall"
+
+// diskUsage returns the bytes used and the size of the filesystem holding path. Used
+// counts space reserved for root, as df does.
+func diskUsage(path string) (used, total uint64, err error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+	blockSize := uint64(stat.Bsize)
+	total = uint64(stat.Blocks) * blockSize
+	used = total - uint64(stat.Bfree)*blockSize
+	return used, total, nil
+}
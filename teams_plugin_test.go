@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func graphTestMessage(created time.Time, fromID, fromName string, mentionIDs ...string) string {
+	var mentions []string
+	for _, id := range mentionIDs {
+		mentions = append(mentions, fmt.Sprintf(`{"mentioned":{"user":{"id":%q}}}`, id))
+	}
+	return fmt.Sprintf(`{"createdDateTime":%q,"messageType":"message","from":{"user":{"id":%q,"displayName":%q}},"mentions":[%s]}`,
+		created.Format(time.RFC3339), fromID, fromName, strings.Join(mentions, ","))
+}
+
+func TestTeamsPluginFetch(t *testing.T) {
+	now := time.Now().UTC()
+	lastRead := now.Add(-time.Hour)
+	stamp := func(d time.Duration) string { return now.Add(d).Format(time.RFC3339) }
+
+	responses := map[string]string{
+		"/me": `{"id":"me"}`,
+		"/me/chats": fmt.Sprintf(`{"value":[
+			{"id":"group","topic":"Release crew","chatType":"group","webUrl":"https://teams/group",
+			 "viewpoint":{"lastMessageReadDateTime":%q},"lastMessagePreview":{"createdDateTime":%q}},
+			{"id":"dm","topic":"","chatType":"oneOnOne","webUrl":"https://teams/dm",
+			 "viewpoint":{"lastMessageReadDateTime":%q},"lastMessagePreview":{"createdDateTime":%q}},
+			{"id":"quiet","topic":"Read already","chatType":"group",
+			 "viewpoint":{"lastMessageReadDateTime":%q},"lastMessagePreview":{"createdDateTime":%q}}
+		]}`, stamp(-time.Hour), stamp(-time.Minute), stamp(-time.Hour), stamp(-2*time.Minute), stamp(0), stamp(-time.Hour)),
+		"/me/chats/group/messages": `{"value":[` + strings.Join([]string{
+			graphTestMessage(now.Add(-5*time.Minute), "alice", "Alice", "me"),
+			graphTestMessage(now.Add(-10*time.Minute), "bob", "Bob"),
+			graphTestMessage(now.Add(-20*time.Minute), "me", "Me", "me"),
+			graphTestMessage(lastRead.Add(-time.Minute), "carol", "Carol", "me"),
+		}, ",") + `]}`,
+		"/me/chats/dm/messages": `{"value":[` + graphTestMessage(now.Add(-2*time.Minute), "dave", "Dave") + `]}`,
+		"/teams/t1/channels/c1": `{"displayName":"General","webUrl":"https://teams/general"}`,
+		"/teams/t1/channels/c1/messages": `{"value":[` + strings.Join([]string{
+			graphTestMessage(now.Add(-30*time.Minute), "erin", "Erin", "me"),
+			graphTestMessage(now.Add(-40*time.Minute), "frank", "Frank", "me"),
+			graphTestMessage(now.Add(-48*time.Hour), "grace", "Grace", "me"),
+		}, ",") + `]}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/me/chats/quiet/messages" {
+			t.Error("Expected read chats not to be fetched")
+		}
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	plugin := NewTeamsPlugin()
+	plugin.graphURL = server.URL
+	err := plugin.Initialize(map[string]interface{}{
+		"access_token": "test-token",
+		"channels":     []interface{}{"t1/c1"},
+		"lookback":     "24h",
+	})
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	activity, ok := data.([]ChatActivity)
+	if !ok {
+		t.Fatalf("Expected []ChatActivity, got %T", data)
+	}
+	if len(activity) != 3 {
+		t.Fatalf("Expected 3 chats with mentions, got %d: %+v", len(activity), activity)
+	}
+
+	// Sorted by mentions, then most recent
+	general, dm, group := activity[0], activity[1], activity[2]
+	if general.Channel != "General" || general.Mentions != 2 || general.From != "Erin" {
+		t.Errorf("Expected 2 recent mentions in General from Erin, got %+v", general)
+	}
+	if group.Channel != "Release crew" || group.Mentions != 1 || group.Unread != 2 || group.From != "Alice" {
+		t.Errorf("Expected 1 mention and 2 unread in Release crew, got %+v", group)
+	}
+	if dm.Channel != "Chat with Dave" || dm.Mentions != 1 || dm.URL != "https://teams/dm" {
+		t.Errorf("Expected unread direct message to count as a mention, got %+v", dm)
+	}
+}
+
+func TestTeamsPluginErrors(t *testing.T) {
+	t.Setenv("MS_GRAPH_TOKEN", "")
+	plugin := NewTeamsPlugin()
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected Fetch to fail without an access token")
+	}
+
+	if err := plugin.Initialize(map[string]interface{}{"lookback": "yesterday"}); err == nil {
+		t.Error("Expected an invalid lookback to fail")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	plugin.graphURL = server.URL
+	plugin.Initialize(map[string]interface{}{"access_token": "expired"})
+	if _, err := plugin.Fetch(context.Background()); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("Expected an expired token error, got %v", err)
+	}
+}
+
+func TestUpdateChatWidget(t *testing.T) {
+	wm := NewWidgetManager()
+	wm.InitializeWidgets(nil)
+
+	wm.UpdateChatWidget("teams", []ChatActivity{
+		{Channel: "General", From: "Erin", Mentions: 2, URL: "https://teams/general"},
+		{Channel: "Release crew", Mentions: 1},
+	})
+	widget := wm.Widgets["teams"]
+	if widget.Title != "Teams" || widget.Count != 3 || len(widget.Items) != 2 {
+		t.Fatalf("Expected Teams widget with 3 mentions in 2 items, got %+v", widget)
+	}
+	if widget.Items[0].Subtitle != "2 mentions • Erin" || widget.Items[0].Status != "🔴" {
+		t.Errorf("Expected '2 mentions • Erin' with 🔴, got '%s' %s", widget.Items[0].Subtitle, widget.Items[0].Status)
+	}
+
+	wm.UpdateChatWidget("teams", nil)
+	if widget := wm.Widgets["teams"]; widget.Count != 0 || widget.Items[0].Title != "No new messages" {
+		t.Errorf("Expected an all-caught-up item, got %+v", widget.Items)
+	}
+}
+
+func TestSelectTilesShowsConfiguredOptionalTiles(t *testing.T) {
+	for _, tile := range selectTiles(nil, nil) {
+		if tile.key == "teams" {
+			t.Error("Expected the Teams tile to be hidden until configured")
+		}
+	}
+
+	found := false
+	for _, tile := range selectTiles(nil, map[string]bool{"teams": true}) {
+		found = found || tile.key == "teams"
+	}
+	if !found {
+		t.Error("Expected the Teams tile once Teams is configured")
+	}
+
+	if tiles := selectTiles([]string{"teams"}, nil); len(tiles) != 1 || tiles[0].key != "teams" {
+		t.Errorf("Expected an explicitly selected Teams tile, got %v", tiles)
+	}
+}
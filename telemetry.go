@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// telemetryEndpoint receives usage reports. Release builds set it with
+// -ldflags "-X main.telemetryEndpoint=..."; GODAY_TELEMETRY_URL overrides it. Without
+// one nothing is sent, even with telemetry on.
+var telemetryEndpoint = ""
+
+// telemetryInterval is how often usage is reported at most
+const telemetryInterval = 24 * time.Hour
+
+// TelemetryReport is everything a usage report holds: counters and the OS, nothing
+// that identifies the user, the machine or what the widgets show
+type TelemetryReport struct {
+	OS      string         `json:"os"`
+	Widgets []string       `json:"widgets"`           // widgets shown
+	Actions map[string]int `json:"actions,omitempty"` // times each dashboard action was used, e.g. cycle_tag
+	Since   time.Time      `json:"since"`             // start of the counting period
+}
+
+// telemetryState is the pending report and when the last one was sent, kept in
+// ~/.goday/telemetry.json between runs
+type telemetryState struct {
+	Pending  TelemetryReport `json:"pending"`
+	LastSent time.Time       `json:"last_sent,omitempty"`
+}
+
+// Telemetry counts feature usage when the user has opted in with telemetry: true
+type Telemetry struct {
+	path     string
+	endpoint string
+	client   *http.Client
+	now      func() time.Time
+	mu       sync.Mutex
+	state    telemetryState
+}
+
+// TelemetryPath returns where usage counters are kept: ~/.goday/telemetry.json
+func TelemetryPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".goday", "telemetry.json")
+}
+
+// TelemetryEndpoint returns where reports are sent, or "" when nowhere
+func TelemetryEndpoint() string {
+	if endpoint := os.Getenv("GODAY_TELEMETRY_URL"); endpoint != "" {
+		return endpoint
+	}
+	return telemetryEndpoint
+}
+
+// NewTelemetry loads the usage counters kept in path, or returns nil unless the
+// config opts in to telemetry
+func NewTelemetry(cfg *Config, path string, widgets []string) *Telemetry {
+	if cfg == nil || !cfg.Telemetry || path == "" {
+		return nil
+	}
+	t := &Telemetry{
+		path:     path,
+		endpoint: TelemetryEndpoint(),
+		client:   &http.Client{Timeout: 10 * time.Second},
+		now:      time.Now,
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		// A damaged file only loses counts
+		json.Unmarshal(data, &t.state)
+	}
+	if t.state.Pending.Since.IsZero() {
+		t.state.Pending.Since = t.now().UTC().Truncate(time.Hour)
+	}
+	t.state.Pending.OS = runtime.GOOS
+	t.state.Pending.Widgets = append([]string{}, widgets...)
+	sort.Strings(t.state.Pending.Widgets)
+	return t
+}
+
+// CountAction counts a use of a dashboard action, by its name under keybindings, so
+// remapped keys count the same. Keys that are no action, such as text typed into the
+// search palette or tag editor, are never looked at.
+func (t *Telemetry) CountAction(name string) {
+	if _, ok := findKeyAction(name); t == nil || !ok {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.state.Pending.Actions == nil {
+		t.state.Pending.Actions = make(map[string]int)
+	}
+	t.state.Pending.Actions[name]++
+}
+
+// Report returns a copy of the report that would be sent next
+func (t *Telemetry) Report() TelemetryReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	report := t.state.Pending
+	report.Actions = make(map[string]int, len(t.state.Pending.Actions))
+	for name, count := range t.state.Pending.Actions {
+		report.Actions[name] = count
+	}
+	return report
+}
+
+// Save keeps the counters for the next run
+func (t *Telemetry) Save() error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	data, err := json.MarshalIndent(t.state, "", "  ")
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(t.path, data, 0600)
+}
+
+// Send posts the pending report when one is due, at most once a day, and starts a new
+// counting period once it has been accepted
+func (t *Telemetry) Send(ctx context.Context) error {
+	if t == nil || t.endpoint == "" {
+		return nil
+	}
+	now := t.now()
+	t.mu.Lock()
+	due := now.Sub(t.state.LastSent) >= telemetryInterval
+	t.mu.Unlock()
+	if !due {
+		return nil
+	}
+
+	report := t.Report()
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry endpoint returned status %d", resp.StatusCode)
+	}
+
+	t.mu.Lock()
+	// Actions used while the report was on its way count towards the next one
+	for name, count := range report.Actions {
+		if t.state.Pending.Actions[name] -= count; t.state.Pending.Actions[name] <= 0 {
+			delete(t.state.Pending.Actions, name)
+		}
+	}
+	t.state.Pending.Since = now.UTC().Truncate(time.Hour)
+	t.state.LastSent = now
+	t.mu.Unlock()
+	return t.Save()
+}
+
+// sendCmd reports usage in the background; failures are retried on the next start
+func (t *Telemetry) sendCmd() tea.Cmd {
+	if t == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		t.Send(ctx)
+		return nil
+	}
+}
+
+// runTelemetry explains what telemetry collects: goday telemetry status
+func runTelemetry(args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] != "status" {
+		return fmt.Errorf("usage: goday telemetry status")
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("unexpected argument %q", args[1])
+	}
+	cfg, err := LoadConfigFromDefaultPath()
+	if err != nil {
+		return err
+	}
+
+	if !cfg.Telemetry {
+		_, err := fmt.Fprintln(out, "Telemetry is off (the default); nothing is collected or sent.\nSet telemetry: true in config.yaml to share which widgets and actions you use.")
+		return err
+	}
+	var widgets []string
+	for _, tile := range selectTiles(cfg.UI.Widgets, cfg.ConfiguredWidgets()) {
+		if cfg.TileEnabled(tile.key) {
+			widgets = append(widgets, tile.key)
+		}
+	}
+	telemetry := NewTelemetry(cfg, TelemetryPath(), widgets)
+
+	lines := []string{"Telemetry is on (telemetry: true in config.yaml)."}
+	if telemetry.endpoint == "" {
+		lines = append(lines, "This build has no telemetry endpoint, so nothing is sent.")
+	} else {
+		lines = append(lines, "Reports go to "+telemetry.endpoint+" at most once a day.")
+	}
+	if !telemetry.state.LastSent.IsZero() {
+		lines = append(lines, "Last sent: "+telemetry.state.LastSent.Local().Format("2006-01-02 15:04"))
+	}
+	report, _ := json.MarshalIndent(telemetry.Report(), "", "  ")
+	lines = append(lines, "Next report, in full:", string(report))
+	_, err = fmt.Fprintln(out, strings.Join(lines, "\n"))
+	return err
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTelemetryOptIn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+	if NewTelemetry(nil, path, nil) != nil || NewTelemetry(&Config{}, path, nil) != nil {
+		t.Error("Expected telemetry to be off by default")
+	}
+
+	// Turned off, the dashboard neither counts nor writes anything
+	var off *Telemetry
+	off.CountAction("refresh")
+	if err := off.Save(); err != nil {
+		t.Errorf("Expected saving without telemetry to do nothing, got %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected no telemetry file without opting in")
+	}
+
+	var cfg Config
+	cfg.Telemetry = true
+	telemetry := NewTelemetry(&cfg, path, []string{"prs", "jira"})
+	telemetry.CountAction("refresh")
+	telemetry.CountAction("refresh")
+	telemetry.CountAction("x") // not an action
+	if err := telemetry.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Counts carry over to the next run
+	report := NewTelemetry(&cfg, path, []string{"prs"}).Report()
+	if report.Actions["refresh"] != 2 || len(report.Actions) != 1 {
+		t.Errorf("Expected only dashboard actions to be counted, got %v", report.Actions)
+	}
+	if len(report.Widgets) != 1 || report.Widgets[0] != "prs" {
+		t.Errorf("Expected the widgets shown now, got %v", report.Widgets)
+	}
+}
+
+func TestTelemetrySend(t *testing.T) {
+	var received []TelemetryReport
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var report TelemetryReport
+		if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+			t.Errorf("Expected a JSON report, got %v", err)
+		}
+		received = append(received, report)
+	}))
+	defer server.Close()
+
+	t.Setenv("GODAY_TELEMETRY_URL", server.URL)
+	var cfg Config
+	cfg.Telemetry = true
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+	telemetry := NewTelemetry(&cfg, path, []string{"prs", "jira"})
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	telemetry.now = func() time.Time { return now }
+	telemetry.CountAction("open")
+
+	if err := telemetry.Send(context.Background()); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if len(received) != 1 || received[0].Actions["open"] != 1 || strings.Join(received[0].Widgets, ",") != "jira,prs" {
+		t.Fatalf("Expected the counters to be reported, got %+v", received)
+	}
+	if report := telemetry.Report(); len(report.Actions) != 0 || !report.Since.Equal(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected a new counting period, got %+v", report)
+	}
+
+	// At most once a day
+	now = now.Add(time.Hour)
+	telemetry.Send(context.Background())
+	if len(received) != 1 {
+		t.Error("Expected no second report on the same day")
+	}
+	if NewTelemetry(&cfg, path, nil).state.LastSent.IsZero() {
+		t.Error("Expected the send time to be kept for the next run")
+	}
+}
+
+func TestRunTelemetryStatus(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GODAY_TELEMETRY_URL", "")
+	if err := os.MkdirAll(filepath.Join(home, ".goday"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(home, ".goday", "config.yaml")
+	if err := CreateDefaultConfig(configPath); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runTelemetry([]string{"status"}, &out); err != nil {
+		t.Fatalf("runTelemetry failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Telemetry is off") {
+		t.Errorf("Expected the default config to leave telemetry off, got '%s'", out.String())
+	}
+
+	data, _ := os.ReadFile(configPath)
+	os.WriteFile(configPath, bytes.Replace(data, []byte("telemetry: false"), []byte("telemetry: true"), 1), 0644)
+	out.Reset()
+	if err := runTelemetry([]string{"status"}, &out); err != nil {
+		t.Fatalf("runTelemetry failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Telemetry is on") || !strings.Contains(out.String(), "nothing is sent") ||
+		!strings.Contains(out.String(), `"widgets"`) {
+		t.Errorf("Expected the status and the next report, got '%s'", out.String())
+	}
+
+	if err := runTelemetry(nil, &out); err == nil {
+		t.Error("Expected a usage error without status")
+	}
+}
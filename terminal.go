@@ -0,0 +1,379 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/cancelreader"
+	"github.com/muesli/termenv"
+	"gopkg.in/yaml.v3"
+)
+
+// probeTimeout is how long the selftest waits for the terminal to answer its queries
+const probeTimeout = time.Second
+
+// probeEmoji is printed to measure how many columns the terminal gives an emoji
+const probeEmoji = "🙂"
+
+// TerminalProfile is what the terminal can render, from ui.terminal or the selftest.
+// The zero value is how the dashboard renders without one: emoji, alt screen, no mouse.
+type TerminalProfile struct {
+	Color       string // truecolor, 256, 16 or none
+	NarrowEmoji bool   // emoji take one column or none, so text symbols replace them
+	NoAltScreen bool   // draw in the normal screen instead of the alternate one
+	Mouse       bool   // clicks focus tiles and select items, the wheel scrolls
+	Background  string // dark or light; empty when unknown, and then taken as dark
+	probed      bool   // the terminal answered the selftest
+}
+
+var (
+	cursorReply           = regexp.MustCompile(`\x1b\[\??(\d+);(\d+)R`)
+	modeReply             = regexp.MustCompile(`\x1b\[\?(\d+);(\d)\$y`)
+	deviceAttributesReply = regexp.MustCompile(`\x1b\[\?[\d;]*c`)
+	backgroundReply       = regexp.MustCompile(`\x1b\]11;rgba?:([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})`)
+)
+
+// envTerminalProfile guesses the profile from the environment, for terminals that
+// cannot be asked
+func envTerminalProfile(getenv func(string) string) TerminalProfile {
+	termName := getenv("TERM")
+	colorTerm := strings.ToLower(getenv("COLORTERM"))
+	p := TerminalProfile{Color: "16"}
+	switch {
+	case getenv("NO_COLOR") != "", termName == "dumb":
+		p.Color = "none"
+	case colorTerm == "truecolor", colorTerm == "24bit", getenv("WT_SESSION") != "":
+		p.Color = "truecolor"
+	case strings.Contains(termName, "256color"):
+		p.Color = "256"
+	}
+	switch termName {
+	case "dumb":
+		p.NarrowEmoji = true
+		p.NoAltScreen = true
+	case "linux":
+		// The Linux console has no emoji font
+		p.NarrowEmoji = true
+	}
+	// rxvt, Konsole and others export "foreground;background" as ANSI color numbers
+	if fgbg := strings.Split(getenv("COLORFGBG"), ";"); len(fgbg) > 1 {
+		if bg, err := strconv.Atoi(fgbg[len(fgbg)-1]); err == nil {
+			p.Background = "dark"
+			if bg == 7 || bg >= 9 {
+				p.Background = "light"
+			}
+		}
+	}
+	return p
+}
+
+// DetectTerminal runs the selftest: it asks the terminal where an emoji left the cursor,
+// whether it knows the alt screen and mouse modes, and its background color. Terminals
+// that do not answer keep the guess from the environment.
+func DetectTerminal(in, out *os.File, getenv func(string) string) TerminalProfile {
+	p := envTerminalProfile(getenv)
+	if !term.IsTerminal(in.Fd()) || !term.IsTerminal(out.Fd()) || getenv("TERM") == "dumb" {
+		return p
+	}
+	state, err := term.MakeRaw(in.Fd())
+	if err != nil {
+		return p
+	}
+	defer term.Restore(in.Fd(), state)
+	reader, err := cancelreader.NewReader(in)
+	if err != nil {
+		return p
+	}
+	defer reader.Close()
+
+	// Every terminal answers the device attributes query, so its reply ends the others
+	fmt.Fprint(out, "\r"+probeEmoji+"\x1b[6n\x1b[?1049$p\x1b[?1000$p\x1b]11;?\x07\x1b[c")
+	replies := readTerminalReplies(reader, probeTimeout)
+	fmt.Fprint(out, "\r\x1b[K")
+
+	parseTerminalReplies(replies, &p)
+	return p
+}
+
+// readTerminalReplies reads what the terminal sends until its device attributes reply,
+// or until timeout
+func readTerminalReplies(reader cancelreader.CancelReader, timeout time.Duration) string {
+	done := make(chan string, 1)
+	go func() {
+		var replies []byte
+		buf := make([]byte, 256)
+		for {
+			n, err := reader.Read(buf)
+			replies = append(replies, buf[:n]...)
+			if err != nil || deviceAttributesReply.Match(replies) {
+				done <- string(replies)
+				return
+			}
+		}
+	}()
+	select {
+	case replies := <-done:
+		return replies
+	case <-time.After(timeout):
+		reader.Cancel()
+		return <-done
+	}
+}
+
+// parseTerminalReplies updates p from the selftest's replies. Modes the terminal reports
+// as unknown (0) or permanently off (4) are unsupported.
+func parseTerminalReplies(replies string, p *TerminalProfile) {
+	if !deviceAttributesReply.MatchString(replies) {
+		return
+	}
+	p.probed = true
+	if match := cursorReply.FindStringSubmatch(replies); match != nil {
+		column, _ := strconv.Atoi(match[2])
+		p.NarrowEmoji = column-1 < 2
+	}
+	for _, match := range modeReply.FindAllStringSubmatch(replies, -1) {
+		supported := match[2] != "0" && match[2] != "4"
+		switch match[1] {
+		case "1049":
+			p.NoAltScreen = !supported
+		case "1000":
+			p.Mouse = supported
+		}
+	}
+	if match := backgroundReply.FindStringSubmatch(replies); match != nil {
+		// Relative luminance of the background, from components of 1 to 4 hex digits
+		var luminance float64
+		for i, weight := range []float64{0.2126, 0.7152, 0.0722} {
+			value, _ := strconv.ParseUint(match[i+1], 16, 16)
+			luminance += weight * float64(value) / float64(uint64(1)<<(4*len(match[i+1]))-1)
+		}
+		p.Background = "dark"
+		if luminance > 0.5 {
+			p.Background = "light"
+		}
+	}
+}
+
+// TerminalProfileFromConfig returns the profile set under ui.terminal, with unset
+// settings guessed from the environment, and false when nothing is set
+func TerminalProfileFromConfig(cfg *Config, getenv func(string) string) (TerminalProfile, bool) {
+	p := envTerminalProfile(getenv)
+	if cfg == nil {
+		return p, false
+	}
+	settings := cfg.UI.Terminal
+	if settings.Color != "" {
+		p.Color = settings.Color
+	}
+	if settings.Emoji != nil {
+		p.NarrowEmoji = !*settings.Emoji
+	}
+	if settings.AltScreen != nil {
+		p.NoAltScreen = !*settings.AltScreen
+	}
+	if settings.Mouse != nil {
+		p.Mouse = *settings.Mouse
+	}
+	if settings.Background != "" {
+		p.Background = settings.Background
+	}
+	return p, settings != (TerminalConfig{})
+}
+
+// ApplyBackground tells lipgloss which variant of the adaptive colors to use. Lipgloss
+// would otherwise ask the terminal itself once the dashboard runs, and the answer could
+// end up in the dashboard's input.
+func (p TerminalProfile) ApplyBackground() {
+	lipgloss.SetHasDarkBackground(p.Background != "light")
+}
+
+// Apply sets the color profile and background lipgloss renders with
+func (p TerminalProfile) Apply() {
+	p.ApplyBackground()
+	switch p.Color {
+	case "truecolor":
+		lipgloss.SetColorProfile(termenv.TrueColor)
+	case "256":
+		lipgloss.SetColorProfile(termenv.ANSI256)
+	case "16":
+		lipgloss.SetColorProfile(termenv.ANSI)
+	case "none":
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// ProgramOptions returns the Bubble Tea options the profile needs
+func (p TerminalProfile) ProgramOptions() []tea.ProgramOption {
+	if p.Mouse {
+		return []tea.ProgramOption{tea.WithMouseCellMotion()}
+	}
+	return nil
+}
+
+// altScreenInit enters the alternate screen, unless the terminal lacks it
+func (m Model) altScreenInit() tea.Cmd {
+	if m.terminal.NoAltScreen {
+		return nil
+	}
+	return tea.EnterAltScreen
+}
+
+// describe lists the profile for goday doctor
+func (p TerminalProfile) describe() []string {
+	yesNo := map[bool]string{true: "yes", false: "no"}
+	emoji := "2 columns, emoji shown"
+	if p.NarrowEmoji {
+		emoji = "narrow, text symbols shown instead"
+	}
+	background := p.Background
+	if background == "" {
+		background = "unknown, dark assumed"
+	}
+	return []string{
+		"  Colors:      " + p.Color,
+		"  Emoji:       " + emoji,
+		"  Alt screen:  " + yesNo[!p.NoAltScreen],
+		"  Mouse:       " + yesNo[p.Mouse],
+		"  Background:  " + background,
+	}
+}
+
+// SaveTerminalProfile writes the profile to ui.terminal in the config file at path,
+// keeping comments and layout
+func SaveTerminalProfile(path string, p TerminalProfile) error {
+	return withFileLock(path, func() error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			return fmt.Errorf("%s: expected a mapping at the top level", path)
+		}
+		ui := ensureMapping(doc.Content[0], "ui")
+
+		scalar := func(tag, value string) *yaml.Node {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+		}
+		boolean := func(value bool) *yaml.Node {
+			return scalar("!!bool", strconv.FormatBool(value))
+		}
+		settings := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+			scalar("!!str", "color"), scalar("!!str", p.Color),
+			scalar("!!str", "emoji"), boolean(!p.NarrowEmoji),
+			scalar("!!str", "alt_screen"), boolean(!p.NoAltScreen),
+			scalar("!!str", "mouse"), boolean(p.Mouse),
+		}}
+		if p.Background != "" {
+			settings.Content = append(settings.Content, scalar("!!str", "background"), scalar("!!str", p.Background))
+		}
+		if existing := mappingValue(ui, "terminal"); existing != nil {
+			*existing = *settings
+		} else {
+			key := scalar("!!str", "terminal")
+			key.HeadComment = "Detected by the terminal selftest; run 'goday doctor' to detect again"
+			ui.Content = append(ui.Content, key, settings)
+		}
+
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(&doc); err != nil {
+			return err
+		}
+		if err := enc.Close(); err != nil {
+			return err
+		}
+		return writeFileAtomic(path, buf.Bytes(), 0644)
+	})
+}
+
+// runDoctor runs the terminal selftest, prints what it found and saves it to the config,
+// then lists the optional programs GoDay looks for
+func runDoctor(args []string, out io.Writer) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected argument %q", args[0])
+	}
+	profile := DetectTerminal(os.Stdin, os.Stdout, os.Getenv)
+
+	termName := os.Getenv("TERM")
+	if termName == "" {
+		termName = "unknown terminal"
+	}
+	how := "answered the selftest"
+	if !profile.probed {
+		how = "did not answer; guessed from the environment"
+	}
+	lines := append([]string{fmt.Sprintf("Terminal: %s (%s)", termName, how)}, profile.describe()...)
+	programs := append([]string{"Optional programs:"}, DetectCapabilities(runtime.GOOS, exec.LookPath).describe()...)
+	if !profile.probed {
+		lines = append(lines, "Nothing saved; set ui.terminal in config.yaml to choose yourself.")
+		_, err := fmt.Fprintln(out, strings.Join(append(lines, programs...), "\n"))
+		return err
+	}
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		return fmt.Errorf("no config at %s yet; start goday once to create it", configPath)
+	}
+	if err := SaveTerminalProfile(configPath, profile); err != nil {
+		return err
+	}
+	lines = append(lines, "Saved to ui.terminal in "+configPath)
+	_, err = fmt.Fprintln(out, strings.Join(append(lines, programs...), "\n"))
+	return err
+}
+
+// textSymbols stand in for common emoji on terminals that draw emoji narrow
+var textSymbols = map[rune]string{
+	'✅': "ok", '❌': "x", '⚠': "!", '🔴': "x", '🟠': "!", '🟡': "~", '🟢': "+",
+	'🔵': "o", '⚪': "o", '⭐': "*", '🔥': "!", '🌙': "z", '🔗': "@", '🔋': "b",
+}
+
+// isEmoji reports whether r is in the emoji blocks; box drawing and arrows are not
+func isEmoji(r rune) bool {
+	return r >= 0x1F000 || (r >= 0x2600 && r <= 0x27BF) || (r >= 0x2B00 && r <= 0x2BFF) || (r >= 0x231A && r <= 0x23FF)
+}
+
+// plainSymbols replaces the emoji in rendered text with text symbols of the same width,
+// so the layout stays aligned on terminals that draw emoji narrow
+func plainSymbols(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\uFE0F' || r == '\u200D':
+			// Emoji presentation selectors and joiners have no width of their own
+		case isEmoji(r):
+			symbol, ok := textSymbols[r]
+			if !ok {
+				symbol = "*"
+			}
+			width := lipgloss.Width(string(r))
+			for len(symbol) < width {
+				symbol += " "
+			}
+			b.WriteString(symbol[:width])
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
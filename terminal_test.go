@@ -0,0 +1,133 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestEnvTerminalProfile(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want TerminalProfile
+	}{
+		{map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, TerminalProfile{Color: "truecolor"}},
+		{map[string]string{"TERM": "screen-256color"}, TerminalProfile{Color: "256"}},
+		{map[string]string{"TERM": "xterm"}, TerminalProfile{Color: "16"}},
+		{map[string]string{"TERM": "xterm-256color", "NO_COLOR": "1"}, TerminalProfile{Color: "none"}},
+		{map[string]string{"TERM": "linux"}, TerminalProfile{Color: "16", NarrowEmoji: true}},
+		{map[string]string{"TERM": "dumb"}, TerminalProfile{Color: "none", NarrowEmoji: true, NoAltScreen: true}},
+		{map[string]string{"TERM": "rxvt", "COLORFGBG": "0;default;15"}, TerminalProfile{Color: "16", Background: "light"}},
+		{map[string]string{"TERM": "xterm", "COLORFGBG": "15;0"}, TerminalProfile{Color: "16", Background: "dark"}},
+	}
+	for _, tt := range tests {
+		got := envTerminalProfile(func(key string) string { return tt.env[key] })
+		if got != tt.want {
+			t.Errorf("Expected %+v for %v, got %+v", tt.want, tt.env, got)
+		}
+	}
+}
+
+func TestParseTerminalReplies(t *testing.T) {
+	// A modern terminal: emoji two columns wide, both modes known
+	p := TerminalProfile{Color: "256"}
+	parseTerminalReplies("\x1b[12;3R\x1b[?1049;2$y\x1b[?1000;2$y\x1b]11;rgb:fdfd/f6f6/e3e3\x1b\\\x1b[?62;22c", &p)
+	if !p.probed || p.NarrowEmoji || p.NoAltScreen || !p.Mouse || p.Color != "256" || p.Background != "light" {
+		t.Errorf("Expected wide emoji, alt screen, mouse and a light background, got %+v", p)
+	}
+
+	// A dark background, answered with two hex digits a color and ended by BEL
+	p = TerminalProfile{}
+	parseTerminalReplies("\x1b]11;rgb:1e/1e/2e\x07\x1b[?62;22c", &p)
+	if p.Background != "dark" {
+		t.Errorf("Expected a dark background, got %+v", p)
+	}
+
+	// Emoji one column wide, and no mouse reporting
+	p = TerminalProfile{}
+	parseTerminalReplies("\x1b[5;2R\x1b[?1049;1$y\x1b[?1000;0$y\x1b[?1;2c", &p)
+	if !p.NarrowEmoji || p.NoAltScreen || p.Mouse {
+		t.Errorf("Expected narrow emoji without mouse, got %+v", p)
+	}
+
+	// Terminals without DECRQM answer only the cursor position and device attributes
+	p = TerminalProfile{}
+	parseTerminalReplies("\x1b[1;3R\x1b[?6c", &p)
+	if !p.probed || p.NarrowEmoji || p.NoAltScreen || p.Mouse {
+		t.Errorf("Expected the unanswered modes to keep their guess, got %+v", p)
+	}
+
+	// No device attributes reply means no answer at all
+	p = TerminalProfile{}
+	parseTerminalReplies("\x1b[1;2R", &p)
+	if p.probed || p.NarrowEmoji {
+		t.Errorf("Expected an incomplete answer to be ignored, got %+v", p)
+	}
+}
+
+func TestSaveTerminalProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `# My dashboard
+ui:
+  layout: at_a_glance  # Three tiles per row
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	saved := TerminalProfile{Color: "256", NarrowEmoji: true, Mouse: true, Background: "light"}
+	if err := SaveTerminalProfile(path, saved); err != nil {
+		t.Fatalf("SaveTerminalProfile failed: %v", err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	profile, chosen := TerminalProfileFromConfig(cfg, func(string) string { return "" })
+	if !chosen || profile != saved {
+		t.Errorf("Expected the saved profile %+v, got %+v", saved, profile)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "# My dashboard") || !strings.Contains(string(data), "# Three tiles per row") {
+		t.Errorf("Expected comments to be preserved, got:\n%s", data)
+	}
+
+	// Running the selftest again replaces the profile
+	if err := SaveTerminalProfile(path, TerminalProfile{Color: "truecolor"}); err != nil {
+		t.Fatalf("SaveTerminalProfile failed: %v", err)
+	}
+	cfg, _ = LoadConfig(path)
+	if cfg.UI.Terminal.Color != "truecolor" || *cfg.UI.Terminal.Emoji != true || *cfg.UI.Terminal.Mouse != false || cfg.UI.Terminal.Background != "" {
+		t.Errorf("Expected the new profile, got %+v", cfg.UI.Terminal)
+	}
+
+	// Settings not in the config come from the environment
+	mouse := true
+	cfg.UI.Terminal = TerminalConfig{Mouse: &mouse}
+	profile, _ = TerminalProfileFromConfig(cfg, func(key string) string { return map[string]string{"TERM": "linux"}[key] })
+	if profile != (TerminalProfile{Color: "16", NarrowEmoji: true, Mouse: true}) {
+		t.Errorf("Expected mouse from the config and the rest from $TERM, got %+v", profile)
+	}
+	if _, chosen := TerminalProfileFromConfig(&Config{}, os.Getenv); chosen {
+		t.Error("Expected no profile without ui.terminal")
+	}
+}
+
+func TestPlainSymbols(t *testing.T) {
+	text := "🔴 API down │ ✅ done ⚠️ 🚀 ↑↓"
+	plain := plainSymbols(text)
+	if plain != "x  API down │ ok done ! *  ↑↓" {
+		t.Errorf("Expected text symbols, got '%s'", plain)
+	}
+	if lipgloss.Width(plain) != lipgloss.Width(strings.ReplaceAll(text, "\uFE0F", "")) {
+		t.Errorf("Expected the width to stay %d, got %d", lipgloss.Width(text), lipgloss.Width(plain))
+	}
+
+	m := Model{weather: "☁ 12°C (Berlin)", terminal: TerminalProfile{NarrowEmoji: true}, terminalWidth: 100}
+	if strings.ContainsRune(m.View(), '☁') {
+		t.Error("Expected no emoji in the view on a terminal that draws them narrow")
+	}
+}
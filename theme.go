@@ -0,0 +1,32 @@
+package main
+
+import "github.com/charmbracelet/lipgloss"
+
+// Colors that would be unreadable on one background have a variant for each. Lipgloss
+// picks it by the background in the terminal profile; see TerminalProfile.Apply.
+var (
+	colorTitle    = lipgloss.AdaptiveColor{Light: "94", Dark: "229"}  // titles and header text
+	colorTitleBar = lipgloss.AdaptiveColor{Light: "254", Dark: "235"} // behind tile titles
+	colorBar      = lipgloss.AdaptiveColor{Light: "253", Dark: "236"} // behind the header and status bars
+	colorMuted    = lipgloss.AdaptiveColor{Light: "240", Dark: "245"} // secondary text
+	colorWarn     = lipgloss.AdaptiveColor{Light: "166", Dark: "214"} // new items and warnings
+	colorAlert    = lipgloss.AdaptiveColor{Light: "160", Dark: "203"} // the alert banner
+	colorAccent   = lipgloss.AdaptiveColor{Light: "33", Dark: "33"}   // the focused tile, selection, links and panels
+	colorOnAccent = lipgloss.AdaptiveColor{Light: "0", Dark: "0"}     // text on colorAccent
+	colorBorder   = lipgloss.AdaptiveColor{Light: "240", Dark: "240"} // borders of tiles out of focus
+)
+
+// useHighContrastTheme switches to black and white text, with the focus in yellow on a
+// dark background and navy on a light one, each well above WCAG's 7:1 for text. The
+// 256-color numbers are used rather than 0 and 15, which terminal themes restyle.
+func useHighContrastTheme() {
+	colorTitle = lipgloss.AdaptiveColor{Light: "16", Dark: "231"}
+	colorTitleBar = lipgloss.AdaptiveColor{Light: "231", Dark: "16"}
+	colorBar = lipgloss.AdaptiveColor{Light: "231", Dark: "16"}
+	colorMuted = lipgloss.AdaptiveColor{Light: "236", Dark: "252"}
+	colorWarn = lipgloss.AdaptiveColor{Light: "94", Dark: "220"}
+	colorAlert = lipgloss.AdaptiveColor{Light: "124", Dark: "210"}
+	colorAccent = lipgloss.AdaptiveColor{Light: "18", Dark: "226"}
+	colorOnAccent = lipgloss.AdaptiveColor{Light: "231", Dark: "16"}
+	colorBorder = lipgloss.AdaptiveColor{Light: "16", Dark: "250"}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// hasCollapsedTiles reports whether a tile is folded into the bar below the grid
+func (m Model) hasCollapsedTiles() bool {
+	for i := range m.widgets {
+		if m.widgets[i].collapsed {
+			return true
+		}
+	}
+	return false
+}
+
+// renderCollapsedTiles renders the titles of collapsed tiles on one line of width, the
+// focused one highlighted, or nothing when no tile is collapsed
+func (m Model) renderCollapsedTiles(width int) string {
+	titleStyle := lipgloss.NewStyle().Foreground(colorTitle).Background(colorTitleBar).Padding(0, 1)
+	focusedStyle := lipgloss.NewStyle().Foreground(colorOnAccent).Background(colorAccent).Bold(true).Padding(0, 1)
+
+	var titles []string
+	for i := range m.widgets {
+		tile := &m.widgets[i]
+		if !tile.collapsed {
+			continue
+		}
+		title := "▸ " + tile.title
+		if i == m.focusedWidget && m.highContrast() {
+			title = "▶ " + tile.title
+		}
+		if count, ok := tile.titleCount(); ok {
+			title = fmt.Sprintf("%s (%d)", title, count)
+		}
+		if tile.hasError {
+			title += " ❌"
+		}
+		if i == m.focusedWidget {
+			titles = append(titles, focusedStyle.Render(title))
+		} else {
+			titles = append(titles, titleStyle.Render(title))
+		}
+	}
+	if len(titles) == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(titles, " "))
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCollapseTile(t *testing.T) {
+	m := benchmarkModel(120, 40)
+	m.widgets = m.widgets[:4]
+	title := m.widgets[0].title
+	x := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}
+
+	updated, _ := m.Update(x)
+	m = updated.(Model)
+	view := m.View()
+	if !m.widgets[0].collapsed || strings.Contains(view, " "+title+" item 2 ") || !strings.Contains(view, "▸ "+title+" (12)") {
+		t.Fatalf("Expected x to fold the tile into its title, got:\n%s", view)
+	}
+	// The other three tiles move up into one row
+	if placements, rows := layoutTiles(m.widgets); len(placements) != 3 || rows != 1 || placements[0].tile != 1 {
+		t.Errorf("Expected the collapsed tile to take no cell, got %+v on %d rows", placements, rows)
+	}
+
+	updated, _ = m.Update(x)
+	m = updated.(Model)
+	if view := m.View(); m.widgets[0].collapsed || !strings.Contains(view, " "+title+" item 2 ") || strings.Contains(view, "▸ ") {
+		t.Errorf("Expected a second x to unfold the tile, got:\n%s", view)
+	}
+}
+
+func TestDisabledTile(t *testing.T) {
+	off := false
+	cfg := &Config{}
+	cfg.UI.Tiles = map[string]TileConfig{"jira": {Enabled: &off}, "weather": {Enabled: &off}}
+	if cfg.TileEnabled("jira") || !cfg.TileEnabled("prs") {
+		t.Error("Expected only jira to be disabled")
+	}
+	if !(*Config)(nil).TileEnabled("jira") {
+		t.Error("Expected tiles to be enabled without a config")
+	}
+
+	// A disabled widget is not fetched
+	cfg.UI.Tiles["pagerduty"] = TileConfig{Enabled: &off}
+	m := benchmarkModel(120, 40)
+	m.config = cfg
+	if _, cmd := m.Update(fetchOnCallCmd{}); cmd != nil {
+		t.Error("Expected no fetch for a disabled widget")
+	}
+
+	// R leaves it out of the refresh, and a refresh of it anyway is reported done
+	m.refresh = NewRefreshProgress()
+	model, _ := m.Update(keyPress("R"))
+	m = model.(Model)
+	if m.refresh.pending["pagerduty"] || !m.refresh.pending["weather"] {
+		t.Errorf("Expected R to refresh the weather but not the disabled widget, got %v", m.refresh.pending)
+	}
+	_, cmd := m.Update(refreshNowMsg{fetch: fetchOnCallCmd{}})
+	if msgs := immediateMsgs(cmd); len(msgs) != 1 || msgs[0] != (refreshDoneMsg{widget: "pagerduty"}) {
+		t.Errorf("Expected the disabled widget's refresh to be reported done, got %v", msgs)
+	}
+}
+
+func TestAllTilesDisabled(t *testing.T) {
+	m := benchmarkModel(120, 40)
+	m.widgets = nil
+	special := map[string]tea.KeyType{
+		"tab": tea.KeyTab, "shift+tab": tea.KeyShiftTab, "up": tea.KeyUp, "down": tea.KeyDown,
+		"enter": tea.KeyEnter, "space": tea.KeySpace, "esc": tea.KeyEsc,
+	}
+	// Every tile key, with no tile to act on
+	for _, action := range keyActions {
+		if action.group != 0 && action.name != "retry" {
+			continue
+		}
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(action.keys[0])}
+		if keyType, ok := special[action.keys[0]]; ok {
+			msg = tea.KeyMsg{Type: keyType}
+		}
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	updated, _ := m.Update(tea.MouseMsg{X: 10, Y: 10, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	m = updated.(Model)
+	if m.focusedWidget != 0 {
+		t.Errorf("Expected the focus to stay put without tiles, got %d", m.focusedWidget)
+	}
+	m.View()
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// TileCount decides the number shown in a tile's title, from ui.tiles.<widget>.count:
+// "total" (the default) counts every item, "off" shows no number, and anything else is an
+// expression counting the items it matches, e.g.
+//
+//	new
+//	status ~ "🔴" or subtitle ~ "critical"
+//	title ~ "review" and not status = "✅"
+//
+// Conditions compare title, subtitle, status or text (all three) with ~ (contains), =, !=
+// or !~, case-insensitively; new matches items highlighted as new. and binds tighter than or.
+type TileCount struct {
+	hidden bool
+	match  countExpr // nil counts every item
+}
+
+// countExpr matches one item; isNew reports whether the tile highlights it as new
+type countExpr func(item WidgetListItem, isNew bool) bool
+
+// ParseTileCount parses a count setting; an empty one counts every item
+func ParseTileCount(setting string) (*TileCount, error) {
+	switch strings.ToLower(strings.TrimSpace(setting)) {
+	case "", "total":
+		return &TileCount{}, nil
+	case "off":
+		return &TileCount{hidden: true}, nil
+	}
+
+	tokens, err := countTokens(setting)
+	if err != nil {
+		return nil, fmt.Errorf("invalid count %q: %w", setting, err)
+	}
+	p := &countParser{tokens: tokens}
+	match, err := p.or()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid count %q: %w", setting, err)
+	}
+	return &TileCount{match: match}, nil
+}
+
+// Count returns the number to show for items, and false when the tile shows none
+func (c *TileCount) Count(items []WidgetListItem, highlight map[string]bool) (int, bool) {
+	if c != nil && c.hidden {
+		return 0, false
+	}
+	if c == nil || c.match == nil {
+		return len(items), true
+	}
+	count := 0
+	for _, item := range items {
+		if c.match(item, highlight[attentionKey(item)]) {
+			count++
+		}
+	}
+	return count, true
+}
+
+// countToken is a word, an operator or a quoted string in a count expression
+type countToken struct {
+	text   string
+	quoted bool
+}
+
+func countTokens(expr string) ([]countToken, error) {
+	var tokens []countToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, countToken{text: string(runes[i+1 : end]), quoted: true})
+			i = end + 1
+		case r == '~' || r == '=':
+			tokens = append(tokens, countToken{text: string(r)})
+			i++
+		case r == '!':
+			if i+1 == len(runes) || (runes[i+1] != '=' && runes[i+1] != '~') {
+				return nil, fmt.Errorf("expected != or !~")
+			}
+			tokens = append(tokens, countToken{text: string(runes[i : i+2])})
+			i += 2
+		default:
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			if i == start {
+				return nil, fmt.Errorf("unexpected %q", string(r))
+			}
+			tokens = append(tokens, countToken{text: strings.ToLower(string(runes[start:i]))})
+		}
+	}
+	return tokens, nil
+}
+
+// countParser parses count tokens by recursive descent: or, and, then not and conditions
+type countParser struct {
+	tokens []countToken
+	pos    int
+}
+
+// accept consumes the next token if it is the keyword word
+func (p *countParser) accept(word string) bool {
+	if p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && p.tokens[p.pos].text == word {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *countParser) or() (countExpr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("or") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		a, b := left, right
+		left = func(item WidgetListItem, isNew bool) bool { return a(item, isNew) || b(item, isNew) }
+	}
+	return left, nil
+}
+
+func (p *countParser) and() (countExpr, error) {
+	left, err := p.condition()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("and") {
+		right, err := p.condition()
+		if err != nil {
+			return nil, err
+		}
+		a, b := left, right
+		left = func(item WidgetListItem, isNew bool) bool { return a(item, isNew) && b(item, isNew) }
+	}
+	return left, nil
+}
+
+func (p *countParser) condition() (countExpr, error) {
+	if p.accept("not") {
+		inner, err := p.condition()
+		if err != nil {
+			return nil, err
+		}
+		return func(item WidgetListItem, isNew bool) bool { return !inner(item, isNew) }, nil
+	}
+	if p.accept("new") {
+		return func(item WidgetListItem, isNew bool) bool { return isNew }, nil
+	}
+
+	if p.pos+3 > len(p.tokens) {
+		return nil, fmt.Errorf("expected new or a condition such as status ~ \"🔴\"")
+	}
+	field, op, value := p.tokens[p.pos], p.tokens[p.pos+1], p.tokens[p.pos+2]
+	text, ok := countFields[field.text]
+	if field.quoted || !ok {
+		return nil, fmt.Errorf("unknown field %q (expected title, subtitle, status or text)", field.text)
+	}
+	if op.quoted || !containsString([]string{"~", "=", "!=", "!~"}, op.text) {
+		return nil, fmt.Errorf("expected ~, =, != or !~ after %s", field.text)
+	}
+	if !value.quoted {
+		return nil, fmt.Errorf("expected a quoted value after %s %s", field.text, op.text)
+	}
+	p.pos += 3
+
+	want := strings.ToLower(value.text)
+	return func(item WidgetListItem, isNew bool) bool {
+		got := strings.ToLower(text(item))
+		switch op.text {
+		case "~":
+			return strings.Contains(got, want)
+		case "!~":
+			return !strings.Contains(got, want)
+		case "=":
+			return got == want
+		default:
+			return got != want
+		}
+	}, nil
+}
+
+// countFields are the item texts a count condition can compare
+var countFields = map[string]func(WidgetListItem) string{
+	"title":    func(item WidgetListItem) string { return item.ItemTitle },
+	"subtitle": func(item WidgetListItem) string { return item.Subtitle },
+	"status":   func(item WidgetListItem) string { return item.Status },
+	"text": func(item WidgetListItem) string {
+		return item.ItemTitle + " " + item.Subtitle + " " + item.Status
+	},
+}
+
+// titleCount returns the number the tile's title shows, and false when it shows none
+func (wt *WidgetTile) titleCount() (int, bool) {
+	// The dashboard a mirrored tile came from has counted already
+	if wt.mirrored || wt.count == 0 {
+		return wt.count, wt.counter == nil || !wt.counter.hidden
+	}
+	var items []WidgetListItem
+	for _, listItem := range wt.list.Items() {
+		if item, ok := listItem.(WidgetListItem); ok {
+			items = append(items, item)
+		}
+	}
+	return wt.counter.Count(items, wt.highlight)
+}
+
+// applyTileConfig renames the tiles and sets their counts from ui.tiles. A tile whose
+// count does not parse keeps counting every item. Unknown widgets in ui.tiles and
+// ui.widgets are reported.
+func applyTileConfig(tiles []WidgetTile, cfg *Config) error {
+	if cfg == nil {
+		return nil
+	}
+	var problems []string
+	for i := range tiles {
+		tileCfg, ok := cfg.UI.Tiles[tiles[i].key]
+		if !ok {
+			continue
+		}
+		if tileCfg.Title != "" {
+			tiles[i].title = tileCfg.Title
+		}
+		if tileCfg.Columns < 0 || tileCfg.Columns > gridColumns {
+			problems = append(problems, fmt.Sprintf("ui.tiles.%s: columns must be 1 to %d", tiles[i].key, gridColumns))
+		} else {
+			tiles[i].columns = tileCfg.Columns
+		}
+		if tileCfg.Rows < 0 {
+			problems = append(problems, fmt.Sprintf("ui.tiles.%s: rows must be 1 or more", tiles[i].key))
+		} else {
+			tiles[i].rows = tileCfg.Rows
+		}
+		counter, err := ParseTileCount(tileCfg.Count)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("ui.tiles.%s: %v", tiles[i].key, err))
+			continue
+		}
+		tiles[i].counter = counter
+	}
+	for key := range cfg.UI.Tiles {
+		if !isDashboardWidget(key) {
+			problems = append(problems, fmt.Sprintf("ui.tiles.%s: unknown widget", key))
+		}
+	}
+	for _, key := range cfg.UI.Widgets {
+		if !isDashboardWidget(key) {
+			problems = append(problems, fmt.Sprintf("ui.widgets: unknown widget %q", key))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTileCount(t *testing.T) {
+	items := []WidgetListItem{
+		{ItemTitle: "Checkout down", Subtitle: "critical • for 5m", Status: "🔴", URL: "a"},
+		{ItemTitle: "Disk filling", Subtitle: "warning • for 1h", Status: "🟡", URL: "b"},
+		{ItemTitle: "Review: login fix", Subtitle: "alice", Status: "✅", URL: "c"},
+	}
+	highlight := map[string]bool{"b": true}
+
+	cases := map[string]int{
+		"":        3,
+		"total":   3,
+		"new":     1,
+		"not new": 2,
+		`status ~ "🔴" or subtitle ~ "warning"`:  2,
+		`title ~ "REVIEW" and not status = "✅"`: 0,
+		`text !~ "for"`: 1,
+		`status != "✅" and new or title ~ 'down'`: 2,
+	}
+	for setting, expected := range cases {
+		counter, err := ParseTileCount(setting)
+		if err != nil {
+			t.Errorf("Expected %q to parse, got %v", setting, err)
+			continue
+		}
+		if count, shown := counter.Count(items, highlight); !shown || count != expected {
+			t.Errorf("Expected %q to count %d, got %d", setting, expected, count)
+		}
+	}
+
+	counter, _ := ParseTileCount("off")
+	if _, shown := counter.Count(items, nil); shown {
+		t.Error("Expected off to hide the count")
+	}
+
+	for _, setting := range []string{"urgent", `status ~`, `owner = "me"`, `status ~ 🔴`, `title ~ "x`, `new and`, `new new`} {
+		if _, err := ParseTileCount(setting); err == nil {
+			t.Errorf("Expected %q to be rejected", setting)
+		}
+	}
+}
+
+func TestApplyTileConfig(t *testing.T) {
+	cfg := &Config{}
+	cfg.UI.Tiles = map[string]TileConfig{
+		"prs":     {Title: "Reviews", Count: "new", Rows: 2},
+		"weather": {Count: "off", Columns: 4},
+		"news":    {Count: "title =", Columns: 2},
+		"nope":    {Title: "Nope"},
+	}
+	cfg.UI.Widgets = []string{"prs", "wether"}
+	tiles := []WidgetTile{
+		NewWidgetTile("prs", "PRs", 40, 10),
+		NewWidgetTile("weather", "Weather", 40, 10),
+		NewWidgetTile("news", "Tech News", 40, 10),
+	}
+	err := applyTileConfig(tiles, cfg)
+	if err == nil || !strings.Contains(err.Error(), "ui.tiles.news") || !strings.Contains(err.Error(), "ui.tiles.nope") || !strings.Contains(err.Error(), `ui.widgets: unknown widget "wether"`) {
+		t.Errorf("Expected the bad count and unknown widgets to be reported, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "ui.tiles.weather: columns must be 1 to 3") || tiles[0].rows != 2 || tiles[2].columns != 2 {
+		t.Errorf("Expected the spans to apply and too many columns to be reported, got %v", err)
+	}
+
+	tiles[0].UpdateItems([]WidgetItem{{Title: "Fix login", URL: "a"}, {Title: "Bump deps", URL: "b"}})
+	tiles[0].highlight = map[string]bool{"b": true}
+	if view := tiles[0].View(); !strings.Contains(view, "Reviews (1)") {
+		t.Errorf("Expected the renamed title to count new items, got:\n%s", view)
+	}
+
+	tiles[1].UpdateItems([]WidgetItem{{Title: "Mon 21°C"}})
+	if view := tiles[1].View(); strings.Contains(view, "Weather (") {
+		t.Errorf("Expected no count in the title, got:\n%s", view)
+	}
+
+	tiles[2].UpdateItems([]WidgetItem{{Title: "One"}, {Title: "Two"}})
+	if count, _ := tiles[2].titleCount(); count != 2 {
+		t.Errorf("Expected a tile with a bad count to count every item, got %d", count)
+	}
+}
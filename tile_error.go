@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// errorBanner renders a failed tile's content in rows: each error item's title, its
+// message wrapped to the tile's width, and the key that retries the fetch on the last row
+func (wt *WidgetTile) errorBanner(items []list.Item, rows int) []string {
+	width := max(1, wt.width-4)
+	titleStyle := lipgloss.NewStyle().Foreground(colorAlert).Bold(true)
+	wrap := lipgloss.NewStyle().Width(width)
+
+	var lines []string
+	for _, listItem := range items {
+		item, ok := listItem.(WidgetListItem)
+		if !ok {
+			continue
+		}
+		title := item.ItemTitle
+		if item.Status != "" {
+			title = item.Status + " " + title
+		}
+		lines = append(lines, titleStyle.Render(lipgloss.NewStyle().MaxWidth(width).Render(title)))
+		if item.Subtitle != "" {
+			for _, line := range strings.Split(wrap.Render(item.Subtitle), "\n") {
+				lines = append(lines, strings.TrimRight(line, " "))
+			}
+		}
+	}
+	if wt.retryKey == "" {
+		return lines[:min(len(lines), rows)]
+	}
+
+	// The hint keeps its row, below a blank one when there is room, cutting the message
+	hint := lipgloss.NewStyle().Foreground(colorMuted).Italic(true).
+		Render(fmt.Sprintf("press %s to retry", wt.retryKey))
+	if len(lines)+2 <= rows {
+		lines = append(lines, "")
+	}
+	lines = lines[:min(len(lines), max(0, rows-1))]
+	return append(lines, hint)
+}
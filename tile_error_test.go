@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestErrorBanner(t *testing.T) {
+	tile := NewWidgetTile("traffic", "Traffic", 30, 10)
+	tile.retryKey = "e"
+	tile.UpdateItems([]WidgetItem{
+		{Title: "Traffic unavailable", Subtitle: "Get \"https://maps.example.com/route\": dial tcp: connection refused", Status: "❌"},
+	})
+	tile.hasError = true
+
+	view := tile.View()
+	for _, want := range []string{"❌ Traffic unavailable", "connection refused", "press e to retry"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the error banner, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, " of 1") {
+		t.Errorf("Expected no position below the banner, got:\n%s", view)
+	}
+
+	// The hint keeps the last row when the message is cut
+	lines := tile.errorBanner(tile.list.Items(), 3)
+	if len(lines) != 3 || !strings.Contains(lines[2], "press e to retry") {
+		t.Errorf("Expected the message cut above the hint, got %q", lines)
+	}
+}
+
+func TestRetryKey(t *testing.T) {
+	m := benchmarkModel(120, 40)
+	for i := range m.widgets {
+		if m.widgets[i].key == "news" {
+			m.focusedWidget = i
+		}
+	}
+	tile := &m.widgets[m.focusedWidget]
+	e := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")}
+
+	if _, cmd := m.Update(e); cmd != nil {
+		t.Error("Expected e to do nothing while the tile's fetch works")
+	}
+
+	tile.UpdateItems([]WidgetItem{{Title: "Failed to fetch news", Subtitle: "timeout", Status: "❌"}})
+	tile.hasError = true
+	if view := m.View(); !strings.Contains(view, "press e to retry") {
+		t.Errorf("Expected the failed tile to show the retry hint, got:\n%s", view)
+	}
+	_, cmd := m.Update(e)
+	if cmd == nil {
+		t.Fatal("Expected e to fetch the failed tile again")
+	}
+	if refresh, ok := cmd().(refreshNowMsg); !ok || refresh.fetch != tea.Msg(fetchNewsCmd{}) {
+		t.Errorf("Expected e to fetch news now, got %#v", cmd())
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startFilter starts typing a filter for the tile's items, editing the one applied
+func (wt *WidgetTile) startFilter() {
+	wt.list.SetFilterState(list.Filtering)
+	wt.refilter()
+}
+
+// updateFilter handles a key while the filter is typed: Enter keeps the filter, Esc
+// drops it, and other keys edit it, narrowing the items as they are typed
+func (wt *WidgetTile) updateFilter(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "esc":
+		wt.list.ResetFilter()
+	case "enter":
+		if wt.list.FilterValue() == "" {
+			wt.list.ResetFilter()
+			return
+		}
+		wt.list.SetFilterText(wt.list.FilterValue())
+		wt.list.FilterInput.Blur()
+	default:
+		wt.list.FilterInput, _ = wt.list.FilterInput.Update(msg)
+		wt.refilter()
+	}
+}
+
+// refilter applies the filter being typed or applied to the items right away, rather
+// than in the command the list returns, so the tile never shows stale matches
+func (wt *WidgetTile) refilter() {
+	switch wt.list.FilterState() {
+	case list.Filtering:
+		wt.list.SetFilterText(wt.list.FilterValue())
+		wt.list.SetFilterState(list.Filtering)
+	case list.FilterApplied:
+		index := wt.list.Index()
+		wt.list.SetFilterText(wt.list.FilterValue())
+		wt.list.Select(max(0, min(index, len(wt.list.VisibleItems())-1)))
+	}
+}
+
+// filterTitle returns the title of a filtered tile: the filter, with a cursor while it
+// is typed, and how many of the items match
+func (wt *WidgetTile) filterTitle() string {
+	filter := "/" + wt.list.FilterValue()
+	if wt.list.SettingFilter() {
+		filter += "▏"
+	}
+	return fmt.Sprintf("%s %s (%d of %d)", wt.title, filter, len(wt.list.VisibleItems()), len(wt.list.Items()))
+}
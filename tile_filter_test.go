@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTileFilter(t *testing.T) {
+	m := benchmarkModel(120, 40)
+	m.widgets[0].UpdateItems([]WidgetItem{{Title: "Fix login"}, {Title: "Bump deps"}, {Title: "Login page copy"}})
+	key := func(s string) {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+		switch s {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		}
+		updated, _ := m.update(msg)
+		m = updated.(Model)
+	}
+
+	key("/")
+	key("l")
+	key("o")
+	key("g")
+	tile := &m.widgets[0]
+	if len(tile.list.VisibleItems()) != 2 || !strings.Contains(tile.View(), "/log▏ (2 of 3)") {
+		t.Fatalf("Expected two items matching as the filter is typed, got:\n%s", tile.View())
+	}
+	if m.widgets[1].list.SettingFilter() || m.tagEditor != nil {
+		t.Errorf("Expected the keys to go to the focused tile's filter only")
+	}
+
+	key("enter")
+	if !tile.list.IsFiltered() || tile.list.SettingFilter() {
+		t.Fatalf("Expected Enter to keep the filter")
+	}
+	// A refresh is filtered right away
+	tile.UpdateItems([]WidgetItem{{Title: "Fix login"}, {Title: "Bump deps"}, {Title: "Logout"}, {Title: "Login page copy"}})
+	if view := tile.View(); !strings.Contains(view, "/log (3 of 4)") || strings.Contains(view, "Bump deps") {
+		t.Errorf("Expected the new items to be filtered, got:\n%s", view)
+	}
+
+	key("j")
+	if tile.list.Index() != 1 || tile.list.SelectedItem().FilterValue() != tile.list.VisibleItems()[1].FilterValue() {
+		t.Errorf("Expected to move through the matching items, got %d", tile.list.Index())
+	}
+
+	key("esc")
+	if tile.list.IsFiltered() || len(tile.list.VisibleItems()) != 4 || !strings.Contains(tile.View(), "(4)") {
+		t.Errorf("Expected Esc to drop the filter, got:\n%s", tile.View())
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// tileStatus returns the line at the bottom of a widget's tile, e.g. "updated 2m ago ·
+// next in 8m", and whether the widget is overdue: its next fetch is more than a minute
+// late, so its data is stale rather than its plugin broken. It is empty before the
+// widget's first fetch and for widgets the scheduler does not refresh.
+func (m Model) tileStatus(widget string, now time.Time) (string, bool) {
+	if m.scheduler == nil || !m.versions.Seen(widget) {
+		return "", false
+	}
+	task, ok := m.scheduler.tasks[widget]
+	if !ok {
+		return "", false
+	}
+	updated := "updated just now"
+	if now.Sub(task.LastRun) >= time.Minute {
+		updated = fmt.Sprintf("updated %s ago", formatAge(task.LastRun, now))
+	}
+	switch {
+	case m.scheduler.Paused(widget, now):
+		return updated + " · paused", false
+	case now.Sub(task.NextRun) > time.Minute:
+		return updated + " · overdue", true
+	case task.NextRun.Sub(now) < time.Minute:
+		return updated + " · next in <1m", false
+	}
+	return fmt.Sprintf("%s · next in %s", updated, formatAge(now, task.NextRun)), false
+}
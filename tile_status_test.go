@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTileStatus(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	m := Model{scheduler: NewScheduler(), versions: NewDataVersions()}
+	m.scheduler.AddTask("news", 10*time.Minute, nil)
+
+	if status, _ := m.tileStatus("news", now); status != "" {
+		t.Errorf("Expected no status before the first fetch, got %q", status)
+	}
+	m.versions.Fetched("news", now.Add(-2*time.Minute))
+	m.scheduler.Ran("news", now.Add(-2*time.Minute))
+	if status, stale := m.tileStatus("news", now); status != "updated 2m ago · next in 8m" || stale {
+		t.Errorf("Expected the last and next fetch, got %q (stale %v)", status, stale)
+	}
+	if status, _ := m.tileStatus("news", now.Add(-90*time.Second)); status != "updated just now · next in 9m" {
+		t.Errorf("Expected a fetch under a minute ago to be just now, got %q", status)
+	}
+	if status, stale := m.tileStatus("news", now.Add(20*time.Minute)); status != "updated 22m ago · overdue" || !stale {
+		t.Errorf("Expected a late fetch to be overdue, got %q (stale %v)", status, stale)
+	}
+	if status, _ := m.tileStatus("builds", now); status != "" {
+		t.Errorf("Expected no status for a widget without a task, got %q", status)
+	}
+}
+
+func TestTileFooter(t *testing.T) {
+	tile := NewWidgetTile("news", "News", baseTileWidth, baseTileHeight)
+	var items []WidgetItem
+	for i := 1; i <= 12; i++ {
+		items = append(items, WidgetItem{Title: fmt.Sprintf("Story %d", i)})
+	}
+	// Below a few items, the status keeps to the bottom row
+	tile.UpdateItems(items[:3])
+	tile.status = "updated 2m ago · next in 8m"
+	tile.width = 40
+	lines := strings.Split(strings.TrimRight(tile.View(), " \n"), "\n")
+	if !strings.Contains(lines[len(lines)-1], "updated 2m ago · next in 8m") || len(lines) != baseTileHeight-1 {
+		t.Errorf("Expected the whole status on the bottom row while it fits, got:\n%s", strings.Join(lines, "\n"))
+	}
+	tile.width = baseTileWidth
+
+	// 6 rows of content: 5 items and the footer, with the status shortened beside the position
+	tile.UpdateItems(items)
+	view := tile.View()
+	if !strings.Contains(view, "2m ago · next 8m") || !strings.Contains(view, "1–5 of 12") || strings.Contains(view, "Story 6 ") {
+		t.Errorf("Expected the status beside the position below 5 items, got:\n%s", view)
+	}
+
+	// The status takes a row even when every item would fit without it
+	tile.UpdateItems(items[:6])
+	view = tile.View()
+	if !strings.Contains(view, "1–5 of 6") {
+		t.Errorf("Expected the status to push the sixth item out of view, got:\n%s", view)
+	}
+	if _, ok := tile.itemAt(5); ok {
+		t.Error("Expected the footer row not to be an item")
+	}
+}
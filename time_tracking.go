@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TimeEntry is a time entry of the time tracker behind the Time Tracking tile
+type TimeEntry struct {
+	ID          int64
+	WorkspaceID int64 // Toggl workspace; unused by Harvest
+	Description string
+	Project     string // name of the entry's project, empty without one
+	Task        string // Harvest task; empty for Toggl
+	Start       time.Time
+	Duration    time.Duration // so far for the running entry
+	Running     bool
+}
+
+// TimeTrackingStatus is the running time entry, if any, and what was tracked today
+type TimeTrackingStatus struct {
+	Current *TimeEntry
+	Today   time.Duration // tracked since local midnight, the running entry included
+	Entries int           // time entries started today
+	Target  time.Duration // time to track a day; zero without a target
+	Recent  []TimeEntry   // latest entries, newest first; only Harvest lists them
+	URL     string        // tracker's web app
+}
+
+// TimeTracker starts and stops timers with g and G
+type TimeTracker interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// timerActionMsg carries the outcome of starting or stopping a timer
+type timerActionMsg struct {
+	action  string // "start" or "stop"
+	tracker string // e.g. Toggl Track
+	err     error
+}
+
+// timerActionCmd starts or stops a timer off the UI goroutine
+func timerActionCmd(tracker TimeTracker, action string) tea.Cmd {
+	name := ""
+	if plugin, ok := tracker.(Plugin); ok {
+		name = plugin.GetMetadata().Name
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+
+		if action == "stop" {
+			return timerActionMsg{action: action, tracker: name, err: tracker.Stop(ctx)}
+		}
+		return timerActionMsg{action: action, tracker: name, err: tracker.Start(ctx)}
+	}
+}
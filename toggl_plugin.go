@@ -0,0 +1,346 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// TogglPlugin shows the running Toggl Track timer and today's tracked time, and starts
+// and stops timers
+type TogglPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	apiToken    string
+	workspaceID int64
+	project     string // project new timers are tracked to, by name or ID
+	entryText   string // description of new timers
+	apiURL      string
+	now         func() time.Time
+	client      *http.Client
+	dryRun      *DryRunLog
+	readOnly    bool // features.write_actions is off; only GETs are sent
+
+	mu       sync.Mutex       // guards the caches below, as timers start and stop off the UI goroutine
+	projects map[int64]string // project names by ID, looked up once
+	lastData TimeTrackingStatus
+}
+
+// NewTogglPlugin creates a new Toggl Track plugin
+func NewTogglPlugin() *TogglPlugin {
+	return &TogglPlugin{
+		id:          "toggl",
+		pluginType:  "productivity",
+		name:        "Toggl Track",
+		version:     "1.0.0",
+		description: "Shows the running Toggl Track timer and today's tracked time",
+		author:      "GoDay Team",
+		apiToken:    os.Getenv("TOGGL_API_TOKEN"),
+		apiURL:      "https://api.track.toggl.com/api/v9",
+		now:         time.Now,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// GetID returns the plugin ID
+func (tp *TogglPlugin) GetID() string {
+	return tp.id
+}
+
+// GetType returns the plugin type
+func (tp *TogglPlugin) GetType() string {
+	return tp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (tp *TogglPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        tp.name,
+		Version:     tp.version,
+		Description: tp.description,
+		Author:      tp.author,
+		Type:        tp.pluginType,
+		Config: map[string]string{
+			"has_api_token": fmt.Sprintf("%t", tp.apiToken != ""),
+			"workspace_id":  strconv.FormatInt(tp.workspaceID, 10),
+			"project":       tp.project,
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (tp *TogglPlugin) Initialize(config map[string]interface{}) error {
+	if token, ok := config["api_token"].(string); ok && token != "" {
+		tp.apiToken = token
+	}
+	if workspace, ok := config["workspace_id"].(int); ok && workspace > 0 {
+		tp.workspaceID = int64(workspace)
+	}
+	if project, ok := config["project"].(string); ok {
+		tp.project = project
+	}
+	if text, ok := config["description"].(string); ok {
+		tp.entryText = text
+	}
+	if apiURL, ok := config["api_url"].(string); ok && apiURL != "" {
+		tp.apiURL = apiURL
+	}
+	if writes, ok := config["write_actions"].(bool); ok {
+		tp.readOnly = !writes
+	}
+	if dryRun, ok := config["dry_run"].(bool); ok && dryRun {
+		tp.dryRun = NewDryRunLog()
+	}
+	return nil
+}
+
+// togglTimeEntry is a time entry as the Toggl Track API returns it
+type togglTimeEntry struct {
+	ID          int64     `json:"id"`
+	WorkspaceID int64     `json:"workspace_id"`
+	ProjectID   *int64    `json:"project_id"`
+	Description string    `json:"description"`
+	Start       time.Time `json:"start"`
+	Duration    int64     `json:"duration"` // seconds; negative while running
+}
+
+// Fetch returns the running timer and the time tracked today
+func (tp *TogglPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if tp.apiToken == "" {
+		return tp.lastData, fmt.Errorf("Toggl API token not configured (widgets.toggl.api_token or TOGGL_API_TOKEN)")
+	}
+
+	now := tp.now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	query := url.Values{
+		"start_date": {midnight.Format(time.RFC3339)},
+		"end_date":   {midnight.AddDate(0, 0, 1).Format(time.RFC3339)},
+	}
+	var entries []togglTimeEntry
+	if err := tp.do(ctx, "GET", "/me/time_entries?"+query.Encode(), nil, &entries); err != nil {
+		return tp.lastData, err
+	}
+	// A timer started before midnight is not among today's entries
+	var current *togglTimeEntry
+	if err := tp.do(ctx, "GET", "/me/time_entries/current", nil, &current); err != nil {
+		return tp.lastData, err
+	}
+
+	status := TimeTrackingStatus{Entries: len(entries), URL: "https://track.toggl.com/timer"}
+	for _, entry := range entries {
+		if entry.Duration >= 0 {
+			status.Today += time.Duration(entry.Duration) * time.Second
+		}
+	}
+	if current != nil {
+		from := current.Start
+		if from.Before(midnight) {
+			from = midnight
+		}
+		status.Today += now.Sub(from)
+
+		entry := TimeEntry{
+			ID:          current.ID,
+			WorkspaceID: current.WorkspaceID,
+			Description: current.Description,
+			Start:       current.Start,
+			Duration:    now.Sub(current.Start),
+			Running:     true,
+		}
+		if current.ProjectID != nil {
+			entry.Project = tp.projectName(ctx, *current.ProjectID)
+		}
+		status.Current = &entry
+	}
+
+	tp.mu.Lock()
+	tp.lastData = status
+	tp.mu.Unlock()
+	return status, nil
+}
+
+// togglProject is a project as the Toggl Track API returns it
+type togglProject struct {
+	ID          int64  `json:"id"`
+	WorkspaceID int64  `json:"workspace_id"`
+	Name        string `json:"name"`
+}
+
+// loadProjects looks up the user's projects once; a failed lookup is retried next time
+func (tp *TogglPlugin) loadProjects(ctx context.Context) ([]togglProject, error) {
+	var projects []togglProject
+	if err := tp.do(ctx, "GET", "/me/projects", nil, &projects); err != nil {
+		return nil, err
+	}
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tp.projects = make(map[int64]string)
+	for _, project := range projects {
+		tp.projects[project.ID] = project.Name
+	}
+	return projects, nil
+}
+
+// projectName returns the name of a project, or its ID when it cannot be looked up
+func (tp *TogglPlugin) projectName(ctx context.Context, id int64) string {
+	tp.mu.Lock()
+	name, ok := tp.projects[id]
+	tp.mu.Unlock()
+	if !ok {
+		// Projects created since the last lookup are picked up here
+		tp.loadProjects(ctx)
+		tp.mu.Lock()
+		name, ok = tp.projects[id]
+		tp.mu.Unlock()
+	}
+	if !ok {
+		return strconv.FormatInt(id, 10)
+	}
+	return name
+}
+
+// defaultProject resolves widgets.toggl.project, a name or an ID, to the project and its
+// workspace; without one it returns no project
+func (tp *TogglPlugin) defaultProject(ctx context.Context) (*togglProject, error) {
+	if tp.project == "" {
+		return nil, nil
+	}
+	projects, err := tp.loadProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		if project.Name == tp.project || strconv.FormatInt(project.ID, 10) == tp.project {
+			return &project, nil
+		}
+	}
+	return nil, fmt.Errorf("no Toggl project named %q (widgets.toggl.project)", tp.project)
+}
+
+// workspace returns the workspace new timers go to: the default project's, then
+// widgets.toggl.workspace_id, then the user's default workspace
+func (tp *TogglPlugin) workspace(ctx context.Context, project *togglProject) (int64, error) {
+	if project != nil {
+		return project.WorkspaceID, nil
+	}
+	if tp.workspaceID != 0 {
+		return tp.workspaceID, nil
+	}
+	var me struct {
+		DefaultWorkspaceID int64 `json:"default_workspace_id"`
+	}
+	if err := tp.do(ctx, "GET", "/me", nil, &me); err != nil {
+		return 0, err
+	}
+	return me.DefaultWorkspaceID, nil
+}
+
+// Start starts a timer on the default project with the configured description. Toggl
+// stops a running timer when another starts.
+func (tp *TogglPlugin) Start(ctx context.Context) error {
+	if tp.apiToken == "" {
+		return fmt.Errorf("Toggl API token not configured (widgets.toggl.api_token or TOGGL_API_TOKEN)")
+	}
+	project, err := tp.defaultProject(ctx)
+	if err != nil {
+		return err
+	}
+	workspaceID, err := tp.workspace(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	entry := map[string]interface{}{
+		"created_with": "GoDay",
+		"description":  tp.entryText,
+		"workspace_id": workspaceID,
+		"start":        tp.now().UTC().Format(time.RFC3339),
+		"duration":     -1,
+	}
+	if project != nil {
+		entry["project_id"] = project.ID
+	}
+	return tp.do(ctx, "POST", fmt.Sprintf("/workspaces/%d/time_entries", workspaceID), entry, nil)
+}
+
+// Stop stops the running timer, if any
+func (tp *TogglPlugin) Stop(ctx context.Context) error {
+	if tp.apiToken == "" {
+		return fmt.Errorf("Toggl API token not configured (widgets.toggl.api_token or TOGGL_API_TOKEN)")
+	}
+	var current *togglTimeEntry
+	if err := tp.do(ctx, "GET", "/me/time_entries/current", nil, &current); err != nil {
+		return err
+	}
+	if current == nil {
+		return fmt.Errorf("no timer is running")
+	}
+	return tp.do(ctx, "PATCH", fmt.Sprintf("/workspaces/%d/time_entries/%d/stop", current.WorkspaceID, current.ID), nil, nil)
+}
+
+// do sends an authenticated Toggl Track API request and decodes the JSON response into
+// target, if given. In dry run mode anything but a GET is logged instead, and with
+// write actions turned off it fails.
+func (tp *TogglPlugin) do(ctx context.Context, method, path string, payload, target interface{}) error {
+	if tp.readOnly && method != "GET" {
+		return fmt.Errorf("write actions are turned off (features.%s)", FeatureWriteActions)
+	}
+	if tp.dryRun != nil && method != "GET" {
+		return tp.dryRun.Record(method, tp.apiURL+path, payload)
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, tp.apiURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(tp.apiToken, "api_token")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := tp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if len(data) > 0 && len(data) < 200 {
+			return fmt.Errorf("Toggl returned status %d: %s", resp.StatusCode, bytes.TrimSpace(data))
+		}
+		return fmt.Errorf("Toggl returned status %d", resp.StatusCode)
+	}
+	if target == nil {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+// Cleanup performs cleanup
+func (tp *TogglPlugin) Cleanup() error {
+	return nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeToggl serves a running timer started before midnight and one finished entry today
+func fakeToggl(started *map[string]interface{}, stopped *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "secret" || pass != "api_token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/me/time_entries":
+			fmt.Fprint(w, `[{"id":1,"workspace_id":9,"description":"standup","start":"2026-10-13T09:00:00Z","duration":1800},
+				{"id":2,"workspace_id":9,"project_id":5,"description":"reviews","start":"2026-10-12T23:30:00Z","duration":-1}]`)
+		case r.Method == "GET" && r.URL.Path == "/me/time_entries/current":
+			fmt.Fprint(w, `{"id":2,"workspace_id":9,"project_id":5,"description":"reviews","start":"2026-10-12T23:30:00Z","duration":-1}`)
+		case r.Method == "GET" && r.URL.Path == "/me/projects":
+			fmt.Fprint(w, `[{"id":5,"workspace_id":9,"name":"Platform"}]`)
+		case r.Method == "POST" && r.URL.Path == "/workspaces/9/time_entries":
+			json.NewDecoder(r.Body).Decode(started)
+		case r.Method == "PATCH":
+			*stopped = r.URL.Path
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newTestTogglPlugin(t *testing.T, serverURL string, config map[string]interface{}) *TogglPlugin {
+	t.Helper()
+	plugin := NewTogglPlugin()
+	plugin.apiURL = serverURL
+	plugin.now = func() time.Time { return time.Date(2026, 10, 13, 10, 0, 0, 0, time.UTC) }
+	config["api_token"] = "secret"
+	if err := plugin.Initialize(config); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	return plugin
+}
+
+func TestTogglPluginFetch(t *testing.T) {
+	server := fakeToggl(nil, nil)
+	defer server.Close()
+	plugin := newTestTogglPlugin(t, server.URL, map[string]interface{}{})
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	status := data.(TimeTrackingStatus)
+	if status.Current == nil || status.Current.Project != "Platform" || status.Current.Duration != 10*time.Hour+30*time.Minute {
+		t.Fatalf("Expected the running timer on Platform for 10h30m, got %+v", status.Current)
+	}
+	// 30 minutes of standup and the running timer from midnight on
+	if status.Today != 10*time.Hour+30*time.Minute {
+		t.Errorf("Expected 10h30m tracked today, got %s", status.Today)
+	}
+
+	wm := NewWidgetManager()
+	wm.UpdateTimeTrackingWidget(status)
+	items := wm.Widgets["toggl"].Items
+	if items[0].Title != "reviews" || items[1].Title != "10h30m tracked today" {
+		t.Errorf("Expected the running timer and today's total, got %+v", items)
+	}
+}
+
+func TestTogglPluginStartAndStop(t *testing.T) {
+	started := map[string]interface{}{}
+	stopped := ""
+	server := fakeToggl(&started, &stopped)
+	defer server.Close()
+	plugin := newTestTogglPlugin(t, server.URL, map[string]interface{}{"project": "Platform", "description": "deep work"})
+
+	if err := plugin.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if started["project_id"] != float64(5) || started["description"] != "deep work" || started["duration"] != float64(-1) {
+		t.Errorf("Expected a running timer on project 5, got %v", started)
+	}
+	if err := plugin.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	if stopped != "/workspaces/9/time_entries/2/stop" {
+		t.Errorf("Expected the running timer to be stopped, got %q", stopped)
+	}
+
+	plugin.project = "Marketing"
+	if err := plugin.Start(context.Background()); err == nil {
+		t.Error("Expected an unknown project to be an error")
+	}
+}
+
+func TestTogglPluginDryRun(t *testing.T) {
+	started := map[string]interface{}{}
+	server := fakeToggl(&started, nil)
+	defer server.Close()
+	plugin := newTestTogglPlugin(t, server.URL, map[string]interface{}{"workspace_id": 9})
+	plugin.dryRun = &DryRunLog{path: filepath.Join(t.TempDir(), "dry_run.log"), now: time.Now}
+
+	if err := plugin.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if len(started) != 0 {
+		t.Errorf("Expected no timer to be started in dry run, got %v", started)
+	}
+}
+
+func TestTogglPluginWriteActionsOff(t *testing.T) {
+	started := map[string]interface{}{}
+	server := fakeToggl(&started, nil)
+	defer server.Close()
+	plugin := newTestTogglPlugin(t, server.URL, map[string]interface{}{"workspace_id": 9, "write_actions": false})
+
+	if err := plugin.Start(context.Background()); err == nil || len(started) != 0 {
+		t.Errorf("Expected starting a timer to fail with write actions off, got %v", err)
+	}
+	if _, err := plugin.Fetch(context.Background()); err != nil {
+		t.Errorf("Expected the timer to still be shown, got %v", err)
+	}
+}
+
+func TestTogglPluginWithoutToken(t *testing.T) {
+	t.Setenv("TOGGL_API_TOKEN", "")
+	plugin := NewTogglPlugin()
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected an error without an API token")
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// trafficHistoryWindow caps the samples behind each average, so it follows a commute
+// that changes within a few weeks
+const trafficHistoryWindow = 12
+
+// TrafficSlot is the average commute in one hour of one weekday
+type TrafficSlot struct {
+	Samples    int     `json:"samples"`
+	AverageSec float64 `json:"average_sec"`
+}
+
+// TrafficHistory records the commute from origin to destination by weekday and hour,
+// to estimate how long it takes at a given time
+type TrafficHistory struct {
+	path  string                  // file the history is kept in; empty keeps it in memory
+	Slots map[string]*TrafficSlot `json:"slots"` // "Mon 08" -> average
+}
+
+// TrafficHistoryPath returns where the commute history is kept: ~/.goday/traffic_history.json
+func TrafficHistoryPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".goday", "traffic_history.json")
+}
+
+// LoadTrafficHistory reads the history kept in path. A missing or unreadable file
+// starts an empty history, which the next recording overwrites.
+func LoadTrafficHistory(path string) *TrafficHistory {
+	history := &TrafficHistory{path: path, Slots: make(map[string]*TrafficSlot)}
+	if path == "" {
+		return history
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return history
+	}
+	var saved TrafficHistory
+	if json.Unmarshal(data, &saved) == nil && saved.Slots != nil {
+		history.Slots = saved.Slots
+	}
+	return history
+}
+
+// trafficSlotKey is the weekday and hour a trip starting at t falls in
+func trafficSlotKey(t time.Time) string {
+	return t.Format("Mon 15")
+}
+
+// Record adds a trip starting at the given time to the history and saves it
+func (h *TrafficHistory) Record(duration time.Duration, at time.Time) error {
+	if duration <= 0 {
+		return nil
+	}
+	if h.path == "" {
+		h.add(duration, at)
+		return nil
+	}
+	// Another dashboard or goday sync may have changed the file since it was loaded
+	return withFileLock(h.path, func() error {
+		if saved := LoadTrafficHistory(h.path); len(saved.Slots) > 0 {
+			h.Slots = saved.Slots
+		}
+		h.add(duration, at)
+		return h.save()
+	})
+}
+
+// add counts a trip towards the average of its weekday and hour
+func (h *TrafficHistory) add(duration time.Duration, at time.Time) {
+	key := trafficSlotKey(at)
+	slot, ok := h.Slots[key]
+	if !ok {
+		slot = &TrafficSlot{}
+		h.Slots[key] = slot
+	}
+	if slot.Samples < trafficHistoryWindow {
+		slot.Samples++
+	}
+	slot.AverageSec += (duration.Seconds() - slot.AverageSec) / float64(slot.Samples)
+}
+
+// Estimate returns the expected duration of a trip starting at t: the average for that
+// weekday and hour, or else for that hour on the same kind of day, weekday or weekend
+func (h *TrafficHistory) Estimate(t time.Time) (time.Duration, bool) {
+	if slot, ok := h.Slots[trafficSlotKey(t)]; ok && slot.Samples > 0 {
+		return time.Duration(slot.AverageSec) * time.Second, true
+	}
+
+	weekend := func(day time.Weekday) bool { return day == time.Saturday || day == time.Sunday }
+	total, samples := 0.0, 0
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if weekend(day) != weekend(t.Weekday()) {
+			continue
+		}
+		// Shift t to the same hour on that weekday
+		other := t.AddDate(0, 0, int(day)-int(t.Weekday()))
+		if slot, ok := h.Slots[trafficSlotKey(other)]; ok {
+			total += slot.AverageSec * float64(slot.Samples)
+			samples += slot.Samples
+		}
+	}
+	if samples == 0 {
+		return 0, false
+	}
+	return time.Duration(total/float64(samples)) * time.Second, true
+}
+
+// save writes the history to its file atomically
+func (h *TrafficHistory) save() error {
+	if h.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(h.path, data, 0600)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTrafficHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traffic_history.json")
+	history := LoadTrafficHistory(path)
+
+	// Two Mondays at 08:xx, one Tuesday at 08:xx
+	monday := time.Date(2026, 10, 12, 8, 15, 0, 0, time.Local)
+	history.Record(30*time.Minute, monday)
+	history.Record(40*time.Minute, monday.AddDate(0, 0, 7).Add(20*time.Minute))
+	if err := history.Record(50*time.Minute, monday.AddDate(0, 0, 1)); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	reloaded := LoadTrafficHistory(path)
+	if d, ok := reloaded.Estimate(monday.AddDate(0, 0, 14)); !ok || d != 35*time.Minute {
+		t.Errorf("Expected the Monday 08:00 average of 35m, got %v (%t)", d, ok)
+	}
+	// Wednesday has no samples of its own, so every weekday at 08:00 counts
+	if d, ok := reloaded.Estimate(monday.AddDate(0, 0, 2)); !ok || d != 40*time.Minute {
+		t.Errorf("Expected the weekday 08:00 average of 40m, got %v (%t)", d, ok)
+	}
+	if _, ok := reloaded.Estimate(monday.AddDate(0, 0, 5)); ok {
+		t.Error("Expected no estimate for a Saturday from weekday trips")
+	}
+	if _, ok := reloaded.Estimate(monday.Add(2 * time.Hour)); ok {
+		t.Error("Expected no estimate for an hour without trips")
+	}
+
+	// The average follows recent trips once the window is full
+	for i := 0; i < 3*trafficHistoryWindow; i++ {
+		history.Record(20*time.Minute, monday)
+	}
+	if d, _ := history.Estimate(monday); d > 21*time.Minute {
+		t.Errorf("Expected the average to follow recent trips, got %v", d)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"time"
 )
@@ -25,9 +26,10 @@ type TrafficData struct {
 type GoogleMapsTrafficPlugin struct {
 	id          string
 	apiKey      string
-	origin      string
-	destination string
+	origin      LocationConfig
+	destination LocationConfig
 	isReversed  bool
+	apiURL      string
 	client      *http.Client
 }
 
@@ -35,6 +37,8 @@ type GoogleMapsTrafficPlugin struct {
 func NewGoogleMapsTrafficPlugin() *GoogleMapsTrafficPlugin {
 	return &GoogleMapsTrafficPlugin{
 		id:     "googlemaps_traffic",
+		apiKey: os.Getenv("GOOGLE_MAPS_API_KEY"),
+		apiURL: "https://maps.googleapis.com",
 		client: &http.Client{Timeout: 30 * time.Second},
 	}
 }
@@ -51,22 +55,16 @@ func (g *GoogleMapsTrafficPlugin) GetType() string {
 
 // Initialize sets up the plugin with configuration
 func (g *GoogleMapsTrafficPlugin) Initialize(config map[string]interface{}) error {
-	if apiKey, ok := config["api_key"].(string); ok {
+	// Without a key in the config, GOOGLE_MAPS_API_KEY is used
+	if apiKey, ok := config["api_key"].(string); ok && apiKey != "" {
 		g.apiKey = apiKey
-	} else {
-		return fmt.Errorf("missing or invalid api_key in config")
 	}
 
-	if origin, ok := config["origin"].(string); ok {
-		g.origin = origin
-	} else {
-		return fmt.Errorf("missing or invalid origin in config")
+	if err := parseLocationConfig("origin", config, &g.origin); err != nil {
+		return err
 	}
-
-	if destination, ok := config["destination"].(string); ok {
-		g.destination = destination
-	} else {
-		return fmt.Errorf("missing or invalid destination in config")
+	if err := parseLocationConfig("destination", config, &g.destination); err != nil {
+		return err
 	}
 
 	g.isReversed = false
@@ -97,33 +95,41 @@ type DistanceMatrixResponse struct {
 	DestinationAddresses []string `json:"destination_addresses"`
 }
 
-// Fetch retrieves traffic data from Google Maps
+// Fetch retrieves traffic-aware durations from Google Maps for both directions
 func (g *GoogleMapsTrafficPlugin) Fetch(ctx context.Context) (interface{}, error) {
 	if g.apiKey == "" || g.apiKey == "YOUR_GOOGLE_MAPS_API_KEY" {
-		return nil, fmt.Errorf("Google Maps API key not configured. Please update config.yaml with a valid API key")
+		return nil, fmt.Errorf("Google Maps API key not configured (widgets.traffic.api_key or GOOGLE_MAPS_API_KEY)")
 	}
 
-	// Determine origin and destination based on current direction
-	origin := g.origin
-	destination := g.destination
-	if g.isReversed {
-		origin = g.destination
-		destination = g.origin
+	// One request per direction, as the Distance Matrix API bills every origin-destination pair
+	originToDest, err := g.getRoute(ctx, g.origin, g.destination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get origin->destination route: %w", err)
+	}
+	destToOrigin, err := g.getRoute(ctx, g.destination, g.origin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination->origin route: %w", err)
 	}
 
-	// Build API URL
-	baseURL := "https://maps.googleapis.com/maps/api/distancematrix/json"
+	return &BiDirectionalTrafficData{
+		OriginToDestination: *originToDest,
+		DestinationToOrigin: *destToOrigin,
+		OriginName:          originToDest.Origin,
+		DestinationName:     originToDest.Destination,
+		Status:              "OK",
+	}, nil
+}
+
+// getRoute makes a single Distance Matrix API call for leaving now from one location to the other
+func (g *GoogleMapsTrafficPlugin) getRoute(ctx context.Context, from, to LocationConfig) (*TrafficData, error) {
 	params := url.Values{}
-	params.Add("origins", origin)
-	params.Add("destinations", destination)
+	params.Add("origins", g.locationQuery(from))
+	params.Add("destinations", g.locationQuery(to))
 	params.Add("departure_time", "now")
 	params.Add("traffic_model", "best_guess")
 	params.Add("key", g.apiKey)
 
-	apiURL := fmt.Sprintf("%s?%s", baseURL, params.Encode())
-
-	// Debug: log the request (without API key for security)
-	fmt.Printf("Traffic API Request: %s → %s\n", origin, destination)
+	apiURL := fmt.Sprintf("%s/maps/api/distancematrix/json?%s", g.apiURL, params.Encode())
 
 	// Make API request
 	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
@@ -179,21 +185,35 @@ func (g *GoogleMapsTrafficPlugin) Fetch(ctx context.Context) (interface{}, error
 		durationSec = element.DurationInTraffic.Value
 	}
 
-	// Get readable location names
-	originName := g.getLocationShortName(origin)
-	destName := g.getLocationShortName(destination)
-
 	return &TrafficData{
-		Origin:      originName,
-		Destination: destName,
+		Origin:      g.getLocationDisplayName(from),
+		Destination: g.getLocationDisplayName(to),
 		Duration:    duration,
 		DurationSec: durationSec,
 		Distance:    element.Distance.Text,
 		Status:      "OK",
-		IsReversed:  g.isReversed,
 	}, nil
 }
 
+// locationQuery returns the address, or "lat,lng" when coordinates are given
+func (g *GoogleMapsTrafficPlugin) locationQuery(location LocationConfig) string {
+	if location.Latitude != 0 && location.Longitude != 0 {
+		return fmt.Sprintf("%f,%f", location.Latitude, location.Longitude)
+	}
+	return location.Address
+}
+
+// getLocationDisplayName gets a display name for the location
+func (g *GoogleMapsTrafficPlugin) getLocationDisplayName(location LocationConfig) string {
+	if location.Name != "" {
+		return location.Name
+	}
+	if location.Address != "" {
+		return g.getLocationShortName(location.Address)
+	}
+	return fmt.Sprintf("%.4f,%.4f", location.Latitude, location.Longitude)
+}
+
 // getLocationShortName extracts a readable short name from full address
 func (g *GoogleMapsTrafficPlugin) getLocationShortName(address string) string {
 	// Extract area name from full address (e.g., "Electronic City" from "Electronic City, Bengaluru, Karnataka, India")
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -87,3 +91,77 @@ func TestUpdateTrafficWidget(t *testing.T) {
 
 	fmt.Println("Traffic widget update tests passed!")
 }
+
+func TestGoogleMapsTrafficFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/maps/api/distancematrix/json" || query.Get("key") != "maps-key" || query.Get("departure_time") != "now" {
+			fmt.Fprint(w, `{"status":"REQUEST_DENIED","rows":[]}`)
+			return
+		}
+		// Heading home takes longer in the evening traffic
+		inTraffic := `{"text":"48 mins","value":2880}`
+		if query.Get("origins") == "12.969800,77.750000" {
+			inTraffic = `{"text":"1 hour 12 mins","value":4320}`
+		}
+		fmt.Fprintf(w, `{"status":"OK","rows":[{"elements":[{"status":"OK",
+			"duration":{"text":"41 mins","value":2460},"duration_in_traffic":%s,
+			"distance":{"text":"25.4 km","value":25400}}]}]}`, inTraffic)
+	}))
+	defer server.Close()
+
+	t.Setenv("GOOGLE_MAPS_API_KEY", "")
+	plugin := NewGoogleMapsTrafficPlugin()
+	plugin.apiURL = server.URL
+	config := map[string]interface{}{
+		"origin":      map[string]interface{}{"address": "Electronic City Phase 1, Bengaluru"},
+		"destination": map[string]interface{}{"latitude": 12.9698, "longitude": 77.75, "name": "Office"},
+	}
+	if err := plugin.Initialize(config); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected an error without an API key")
+	}
+
+	config["api_key"] = "maps-key"
+	plugin.Initialize(config)
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	traffic := data.(*BiDirectionalTrafficData)
+	there, back := traffic.OriginToDestination, traffic.DestinationToOrigin
+	if there.Origin != "Electronic City Phase 1" || there.Destination != "Office" || there.Duration != "48 mins" || there.DurationSec != 2880 {
+		t.Errorf("Expected the traffic-aware duration to the office, got %+v", there)
+	}
+	if back.Origin != "Office" || back.Duration != "1 hour 12 mins" || back.Distance != "25.4 km" {
+		t.Errorf("Expected the duration back home, got %+v", back)
+	}
+
+	config["api_key"] = "revoked"
+	plugin.Initialize(config)
+	if _, err := plugin.Fetch(context.Background()); err == nil || !strings.Contains(err.Error(), "API key invalid") {
+		t.Errorf("Expected a denied request to explain the key problem, got %v", err)
+	}
+}
+
+func TestTrafficProviderSelection(t *testing.T) {
+	registry := DefaultProviderRegistry()
+	cfg := &Config{}
+	cfg.Widgets.Traffic.Provider = "googlemaps"
+	cfg.Widgets.Traffic.APIKey = "maps-key"
+	cfg.Widgets.Traffic.Origin = "Electronic City, Bengaluru"
+	cfg.Widgets.Traffic.Destination = "Whitefield, Bengaluru"
+
+	plugin, config, err := registry.Create("traffic", cfg.WidgetProviderName("traffic"), cfg, "")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if plugin.GetID() != "googlemaps_traffic" || config["api_key"] != "maps-key" {
+		t.Errorf("Expected the Google Maps plugin with the key, got '%s' %v", plugin.GetID(), config)
+	}
+	if err := plugin.Initialize(config); err != nil {
+		t.Errorf("Expected the traffic settings to initialize the plugin, got %v", err)
+	}
+}
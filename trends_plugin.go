@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// trendsDefaultWeeks is how far back the sparklines go without widgets.trends.weeks
+	trendsDefaultWeeks = 4
+	// trendsSparklineWidth is how many points the sparklines show
+	trendsSparklineWidth = 14
+)
+
+// WorkloadTrend is the current workload and its daily history over the tile's weeks
+type WorkloadTrend struct {
+	Current WorkloadSample
+	Days    []WorkloadSample // one per day, oldest first, ending with today
+}
+
+// TrendsPlugin counts the PRs awaiting your review and the issues assigned to you on
+// GitHub, and keeps a daily history of both so the tile can chart them
+type TrendsPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	github      githubAPI
+	weeks       int
+	history     *WorkloadHistory
+	lastData    WorkloadTrend
+}
+
+// NewTrendsPlugin creates a new workload trends plugin. The token falls back to
+// GITHUB_TOKEN/GH_TOKEN.
+func NewTrendsPlugin() *TrendsPlugin {
+	return &TrendsPlugin{
+		id:          "trends",
+		pluginType:  "trends",
+		name:        "Trends",
+		version:     "1.0.0",
+		description: "Charts the PRs awaiting your review and issues assigned to you over the past weeks",
+		author:      "GoDay Team",
+		github:      newGitHubAPI(nil),
+		weeks:       trendsDefaultWeeks,
+		history:     LoadWorkloadHistory(WorkloadHistoryPath()),
+	}
+}
+
+// GetID returns the plugin ID
+func (tp *TrendsPlugin) GetID() string {
+	return tp.id
+}
+
+// GetType returns the plugin type
+func (tp *TrendsPlugin) GetType() string {
+	return tp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (tp *TrendsPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        tp.name,
+		Version:     tp.version,
+		Description: tp.description,
+		Author:      tp.author,
+		Type:        tp.pluginType,
+		Config: map[string]string{
+			"weeks": fmt.Sprintf("%d", tp.weeks),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (tp *TrendsPlugin) Initialize(config map[string]interface{}) error {
+	if token, ok := config["github_token"].(string); ok && token != "" {
+		tp.github.token = token
+	}
+	if weeks, ok := config["weeks"].(int); ok && weeks > 0 {
+		if weeks*7 > workloadHistoryDays {
+			return fmt.Errorf("trends weeks must be at most %d, the history kept", workloadHistoryDays/7)
+		}
+		tp.weeks = weeks
+	}
+	return nil
+}
+
+// Fetch counts the current workload, records it as today's and returns it with the
+// history of the last weeks
+func (tp *TrendsPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if tp.github.token == "" {
+		return tp.lastData, fmt.Errorf("a GitHub token is required for trends")
+	}
+	user, err := tp.github.login(ctx)
+	if err != nil {
+		return tp.lastData, err
+	}
+
+	sample := WorkloadSample{At: time.Now()}
+	if sample.Reviews, err = tp.count(ctx, "is:open is:pr archived:false review-requested:"+user); err != nil {
+		return tp.lastData, err
+	}
+	if sample.Issues, err = tp.count(ctx, "is:open is:issue archived:false assignee:"+user); err != nil {
+		return tp.lastData, err
+	}
+	// A history that cannot be saved still charts this run
+	tp.history.Record(sample)
+
+	tp.lastData = WorkloadTrend{Current: sample, Days: tp.history.Series(sample.At, tp.weeks*7)}
+	return tp.lastData, nil
+}
+
+// count returns how many issues and PRs a GitHub search matches
+func (tp *TrendsPlugin) count(ctx context.Context, query string) (int, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("per_page", "1")
+
+	var result struct {
+		TotalCount int `json:"total_count"`
+	}
+	if err := tp.github.do(ctx, "GET", "/search/issues?"+params.Encode(), nil, &result); err != nil {
+		return 0, err
+	}
+	return result.TotalCount, nil
+}
+
+// formatTrendChange describes how a count moved since the start of the chart
+func formatTrendChange(change int, since time.Time) string {
+	switch {
+	case change > 0:
+		return fmt.Sprintf("+%d since %s", change, since.Format("2 Jan"))
+	case change < 0:
+		return fmt.Sprintf("−%d since %s", -change, since.Format("2 Jan"))
+	}
+	return "no change since " + since.Format("2 Jan")
+}
+
+// trendValues returns one of a workload's counts per day, for a sparkline
+func trendValues(days []WorkloadSample, count func(WorkloadSample) int) []float64 {
+	values := make([]float64, len(days))
+	for i, day := range days {
+		values[i] = float64(count(day))
+	}
+	return values
+}
+
+// trendLine is a Trends tile line: the count now, its sparkline and its change since
+// the first day charted. A rising count is 🔴, a steady or falling one 🟢.
+func trendLine(label string, days []WorkloadSample, count func(WorkloadSample) int, link string) WidgetItem {
+	today := days[len(days)-1]
+	item := WidgetItem{Title: fmt.Sprintf("%s: %d", label, count(today)), Status: "🟢", URL: link}
+	if len(days) < 2 {
+		item.Subtitle = "history starts today"
+		return item
+	}
+	change := count(today) - count(days[0])
+	if change > 0 {
+		item.Status = "🔴"
+	}
+	// Days carried forward keep the time of their sample, so date the chart from today
+	since := today.At.AddDate(0, 0, 1-len(days))
+	item.Subtitle = strings.TrimSpace(sparkline(trendValues(days, count), trendsSparklineWidth) + " " + formatTrendChange(change, since))
+	return item
+}
+
+// Cleanup performs cleanup
+func (tp *TrendsPlugin) Cleanup() error {
+	return nil
+}
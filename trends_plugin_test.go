@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTrendsPluginFetch(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user":
+			fmt.Fprint(w, `{"login":"octocat"}`)
+		case "/search/issues":
+			q := r.URL.Query().Get("q")
+			queries = append(queries, q)
+			count := 3
+			if strings.Contains(q, "is:issue") {
+				count = 8
+			}
+			fmt.Fprintf(w, `{"total_count": %d, "items": []}`, count)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	plugin := NewTrendsPlugin()
+	if err := plugin.Initialize(map[string]interface{}{"github_token": "token", "weeks": 2}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	plugin.github.apiURL = server.URL
+	plugin.history = LoadWorkloadHistory("")
+	plugin.history.Record(WorkloadSample{At: time.Now().AddDate(0, 0, -3), Reviews: 1, Issues: 10})
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	trend := data.(WorkloadTrend)
+	if trend.Current.Reviews != 3 || trend.Current.Issues != 8 {
+		t.Errorf("Expected 3 reviews and 8 issues, got %+v", trend.Current)
+	}
+	if len(trend.Days) != 4 || trend.Days[0].Reviews != 1 {
+		t.Errorf("Expected 4 days from the recorded one, got %+v", trend.Days)
+	}
+	if len(queries) != 2 || !strings.Contains(queries[0], "review-requested:octocat") || !strings.Contains(queries[1], "assignee:octocat") {
+		t.Errorf("Expected searches for the user's reviews and issues, got %v", queries)
+	}
+}
+
+func TestTrendsPluginInitialize(t *testing.T) {
+	if err := NewTrendsPlugin().Initialize(map[string]interface{}{"weeks": 20}); err == nil {
+		t.Error("Expected weeks beyond the kept history to be rejected")
+	}
+	plugin := NewTrendsPlugin()
+	plugin.github.token = ""
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected an error without a GitHub token")
+	}
+}
+
+func TestUpdateTrendsWidget(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	days := []WorkloadSample{
+		{At: now.AddDate(0, 0, -2), Reviews: 2, Issues: 9},
+		{At: now.AddDate(0, 0, -2), Reviews: 2, Issues: 9},
+		{At: now, Reviews: 5, Issues: 6},
+	}
+
+	wm := NewWidgetManager()
+	wm.UpdateTrendsWidget(WorkloadTrend{Current: days[2], Days: days})
+	widget := wm.Widgets["trends"]
+	if widget.Count != 11 {
+		t.Errorf("Expected 11 open items, got %d", widget.Count)
+	}
+	reviews, issues := widget.Items[0], widget.Items[1]
+	if reviews.Title != "Reviews awaiting me: 5" || reviews.Status != "🔴" || !strings.HasSuffix(reviews.Subtitle, "+3 since 14 Oct") {
+		t.Errorf("Expected growing reviews since 14 Oct, got %+v", reviews)
+	}
+	if issues.Status != "🟢" || !strings.HasSuffix(issues.Subtitle, "−3 since 14 Oct") {
+		t.Errorf("Expected fewer issues, got %+v", issues)
+	}
+
+	wm.UpdateTrendsWidget(WorkloadTrend{Current: days[2], Days: days[2:]})
+	if got := wm.Widgets["trends"].Items[0].Subtitle; got != "history starts today" {
+		t.Errorf("Expected a note on the first day, got '%s'", got)
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// uptimeDefaultHistory is how many checks per endpoint are kept without widgets.uptime.history
+	uptimeDefaultHistory = 10
+	// uptimeDefaultTimeout bounds a check without its own timeout
+	uptimeDefaultTimeout = 10 * time.Second
+	// uptimeBodyLimit is how much of a response is searched for the keyword
+	uptimeBodyLimit = 1 << 20
+)
+
+// UptimeSample is the outcome of one check of an endpoint
+type UptimeSample struct {
+	Up      bool
+	Latency time.Duration
+	At      time.Time
+}
+
+// EndpointStatus is the latest check of an endpoint with its recent history
+type EndpointStatus struct {
+	Name       string
+	URL        string
+	Up         bool
+	StatusCode int // 0 when no response arrived
+	Latency    time.Duration
+	Reason     string         // why the endpoint is down
+	History    []UptimeSample // oldest first, ending with the latest check
+}
+
+// Uptime returns the share of the kept checks that were up
+func (e EndpointStatus) Uptime() float64 {
+	if len(e.History) == 0 {
+		return 0
+	}
+	up := 0
+	for _, sample := range e.History {
+		if sample.Up {
+			up++
+		}
+	}
+	return float64(up) / float64(len(e.History))
+}
+
+// UptimePlugin checks a list of URLs on every refresh, like a tiny uptime monitor,
+// and remembers the last checks of each
+type UptimePlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	checks      []UptimeCheck
+	historySize int
+	history     map[string][]UptimeSample // check name + URL -> recent samples
+	mu          sync.Mutex
+	client      *http.Client
+	lastData    []EndpointStatus
+}
+
+// NewUptimePlugin creates a new uptime plugin
+func NewUptimePlugin() *UptimePlugin {
+	return &UptimePlugin{
+		id:          "uptime",
+		pluginType:  "uptime",
+		name:        "Uptime",
+		version:     "1.0.0",
+		description: "Checks URLs for an expected status and keyword and shows latency and recent uptime",
+		author:      "GoDay Team",
+		historySize: uptimeDefaultHistory,
+		history:     make(map[string][]UptimeSample),
+		client: &http.Client{
+			// Redirects are followed, unless a check expects the redirect itself
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if expected, _ := req.Context().Value(uptimeExpectedStatus{}).(int); expected >= 300 && expected < 400 {
+					return http.ErrUseLastResponse
+				}
+				if len(via) >= 10 {
+					return fmt.Errorf("stopped after 10 redirects")
+				}
+				return nil
+			},
+		},
+		lastData: []EndpointStatus{},
+	}
+}
+
+// uptimeExpectedStatus is the context key carrying a check's expected status to CheckRedirect
+type uptimeExpectedStatus struct{}
+
+// GetID returns the plugin ID
+func (up *UptimePlugin) GetID() string {
+	return up.id
+}
+
+// GetType returns the plugin type
+func (up *UptimePlugin) GetType() string {
+	return up.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (up *UptimePlugin) GetMetadata() PluginMetadata {
+	var urls []string
+	for _, check := range up.checks {
+		urls = append(urls, check.URL)
+	}
+	return PluginMetadata{
+		Name:        up.name,
+		Version:     up.version,
+		Description: up.description,
+		Author:      up.author,
+		Type:        up.pluginType,
+		Config: map[string]string{
+			"checks":  strings.Join(urls, ","),
+			"history": fmt.Sprintf("%d", up.historySize),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (up *UptimePlugin) Initialize(config map[string]interface{}) error {
+	checks, err := uptimeChecksFromConfig(config["checks"])
+	if err != nil {
+		return err
+	}
+	up.checks = checks
+	if history, ok := config["history"].(int); ok && history > 0 {
+		up.historySize = history
+	}
+	return nil
+}
+
+// uptimeChecksFromConfig reads the checks setting, given either as []UptimeCheck from
+// widgets.uptime.checks or as a list of maps from the plugins section
+func uptimeChecksFromConfig(value interface{}) ([]UptimeCheck, error) {
+	var checks []UptimeCheck
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []UptimeCheck:
+		checks = append(checks, v...)
+	case []interface{}:
+		for _, entry := range v {
+			fields, ok := entry.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("uptime checks must have a url, got %v", entry)
+			}
+			check := UptimeCheck{}
+			check.Name, _ = fields["name"].(string)
+			check.URL, _ = fields["url"].(string)
+			check.Status, _ = fields["status"].(int)
+			check.Keyword, _ = fields["keyword"].(string)
+			check.Timeout, _ = fields["timeout"].(string)
+			checks = append(checks, check)
+		}
+	default:
+		return nil, fmt.Errorf("uptime checks must be a list, got %T", value)
+	}
+
+	for i, check := range checks {
+		if !strings.HasPrefix(check.URL, "http://") && !strings.HasPrefix(check.URL, "https://") {
+			return nil, fmt.Errorf("uptime check URL %q must start with http:// or https://", check.URL)
+		}
+		if check.Timeout != "" {
+			if _, err := time.ParseDuration(check.Timeout); err != nil {
+				return nil, fmt.Errorf("invalid timeout %q for %s: %w", check.Timeout, check.URL, err)
+			}
+		}
+		if check.Name == "" {
+			checks[i].Name = strings.TrimPrefix(strings.TrimPrefix(check.URL, "https://"), "http://")
+		}
+	}
+	return checks, nil
+}
+
+// Fetch checks every endpoint concurrently and returns them down first, each with its
+// recent history
+func (up *UptimePlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if len(up.checks) == 0 {
+		return up.lastData, fmt.Errorf("no uptime checks configured (widgets.uptime.checks)")
+	}
+
+	results := make([]EndpointStatus, len(up.checks))
+	var wg sync.WaitGroup
+	for i, check := range up.checks {
+		wg.Add(1)
+		go func(i int, check UptimeCheck) {
+			defer wg.Done()
+			results[i] = up.check(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	up.mu.Lock()
+	for i, check := range up.checks {
+		key := check.Name + " " + check.URL
+		sample := UptimeSample{Up: results[i].Up, Latency: results[i].Latency, At: time.Now()}
+		history := append(up.history[key], sample)
+		if len(history) > up.historySize {
+			history = history[len(history)-up.historySize:]
+		}
+		up.history[key] = history
+		results[i].History = append([]UptimeSample{}, history...)
+	}
+	up.mu.Unlock()
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return !results[i].Up && results[j].Up
+	})
+
+	up.lastData = results
+	return results, nil
+}
+
+// check requests one endpoint and decides whether it is up: the expected status, or any
+// 2xx or 3xx without one, and the keyword in the body when one is set
+func (up *UptimePlugin) check(ctx context.Context, check UptimeCheck) EndpointStatus {
+	status := EndpointStatus{Name: check.Name, URL: check.URL}
+
+	timeout := uptimeDefaultTimeout
+	if check.Timeout != "" {
+		timeout, _ = time.ParseDuration(check.Timeout)
+	}
+	ctx, cancel := context.WithTimeout(context.WithValue(ctx, uptimeExpectedStatus{}, check.Status), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", check.URL, nil)
+	if err != nil {
+		status.Reason = err.Error()
+		return status
+	}
+	req.Header.Set("User-Agent", "GoDay-Uptime/1.0")
+
+	start := time.Now()
+	resp, err := up.client.Do(req)
+	if err != nil {
+		status.Latency = time.Since(start)
+		status.Reason = "unreachable"
+		if ctx.Err() == context.DeadlineExceeded {
+			status.Reason = "timed out after " + timeout.String()
+		}
+		return status
+	}
+	defer resp.Body.Close()
+	status.StatusCode = resp.StatusCode
+
+	var body []byte
+	if check.Keyword != "" {
+		body, err = io.ReadAll(io.LimitReader(resp.Body, uptimeBodyLimit))
+	}
+	// Latency includes the body when it is searched, like a page load
+	status.Latency = time.Since(start)
+
+	switch {
+	case check.Status != 0 && resp.StatusCode != check.Status:
+		status.Reason = fmt.Sprintf("HTTP %d, expected %d", resp.StatusCode, check.Status)
+	case check.Status == 0 && resp.StatusCode >= 400:
+		status.Reason = fmt.Sprintf("HTTP %d", resp.StatusCode)
+	case err != nil:
+		status.Reason = "reading the response failed"
+	case check.Keyword != "" && !strings.Contains(string(body), check.Keyword):
+		status.Reason = fmt.Sprintf("%q not found", check.Keyword)
+	default:
+		status.Up = true
+	}
+	return status
+}
+
+// uptimeHistoryBar draws checks oldest first, ▇ for up and ▁ for down
+func uptimeHistoryBar(history []UptimeSample) string {
+	var b strings.Builder
+	for _, sample := range history {
+		if sample.Up {
+			b.WriteRune('▇')
+		} else {
+			b.WriteRune('▁')
+		}
+	}
+	return b.String()
+}
+
+// formatLatency shows a latency in milliseconds, or seconds from one second up
+func formatLatency(d time.Duration) string {
+	if d >= time.Second {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
+
+// Cleanup performs cleanup
+func (up *UptimePlugin) Cleanup() error {
+	return nil
+}
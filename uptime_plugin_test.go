@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUptimePluginFetch(t *testing.T) {
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			if !healthy {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{"status":"ok"}`)
+		case "/old":
+			http.Redirect(w, r, "/healthz", http.StatusMovedPermanently)
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		default:
+			fmt.Fprint(w, "maintenance")
+		}
+	}))
+	defer server.Close()
+
+	plugin := NewUptimePlugin()
+	err := plugin.Initialize(map[string]interface{}{
+		"history": 3,
+		"checks": []UptimeCheck{
+			{Name: "API", URL: server.URL + "/healthz", Keyword: `"status":"ok"`},
+			{Name: "Redirect", URL: server.URL + "/old", Status: 301},
+			{Name: "Followed", URL: server.URL + "/old", Keyword: "ok"},
+			{Name: "Home", URL: server.URL + "/", Keyword: "welcome"},
+			{Name: "Slow", URL: server.URL + "/slow", Timeout: "50ms"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	endpoints := data.([]EndpointStatus)
+	byName := make(map[string]EndpointStatus)
+	var order []string
+	for _, endpoint := range endpoints {
+		byName[endpoint.Name] = endpoint
+		order = append(order, endpoint.Name)
+	}
+	if strings.Join(order, ",") != "Home,Slow,API,Redirect,Followed" {
+		t.Errorf("Expected down endpoints first, got %v", order)
+	}
+	if !byName["API"].Up || !byName["Redirect"].Up || byName["Redirect"].StatusCode != 301 || !byName["Followed"].Up {
+		t.Errorf("Expected API and both redirect checks to be up, got %+v", endpoints)
+	}
+	if home := byName["Home"]; home.Up || home.Reason != `"welcome" not found` {
+		t.Errorf("Expected Home to be down for the missing keyword, got %+v", home)
+	}
+	if slow := byName["Slow"]; slow.Up || !strings.Contains(slow.Reason, "timed out") {
+		t.Errorf("Expected Slow to time out, got %+v", slow)
+	}
+
+	healthy = false
+	for i := 0; i < 3; i++ {
+		data, _ = plugin.Fetch(context.Background())
+	}
+	for _, endpoint := range data.([]EndpointStatus) {
+		if endpoint.Name != "API" {
+			continue
+		}
+		if endpoint.Up || endpoint.Reason != "HTTP 503" || len(endpoint.History) != 3 {
+			t.Errorf("Expected API down with 3 checks kept, got %+v", endpoint)
+		}
+		if bar := uptimeHistoryBar(endpoint.History); bar != "▁▁▁" {
+			t.Errorf("Expected only the last 3 checks, got '%s'", bar)
+		}
+	}
+
+	wm := NewWidgetManager()
+	wm.UpdateUptimeWidget([]EndpointStatus{
+		{Name: "API", URL: "https://api.example.com", Up: true, Latency: 123 * time.Millisecond,
+			History: []UptimeSample{{Up: false}, {Up: true}, {Up: true}, {Up: true}}},
+		{Name: "Web", URL: "https://example.com", Reason: "HTTP 502", History: []UptimeSample{{Up: false}}},
+	})
+	widget := wm.Widgets["uptime"]
+	if widget.Count != 1 {
+		t.Errorf("Expected one endpoint down, got %d", widget.Count)
+	}
+	if item := widget.Items[0]; item.Status != "🟢" || item.Subtitle != "123ms • 75% up • ▁▇▇▇" {
+		t.Errorf("Expected latency, uptime and history, got %+v", item)
+	}
+	if item := widget.Items[1]; item.Status != "🔴" || !strings.HasPrefix(item.Subtitle, "HTTP 502") {
+		t.Errorf("Expected the reason the endpoint is down, got %+v", item)
+	}
+}
+
+func TestUptimeChecksFromConfig(t *testing.T) {
+	checks, err := uptimeChecksFromConfig([]interface{}{
+		map[string]interface{}{"url": "https://example.com/healthz", "status": 200, "timeout": "5s"},
+	})
+	if err != nil {
+		t.Fatalf("uptimeChecksFromConfig failed: %v", err)
+	}
+	if len(checks) != 1 || checks[0].Name != "example.com/healthz" || checks[0].Status != 200 {
+		t.Errorf("Expected the check with its URL as name, got %+v", checks)
+	}
+	if _, err := uptimeChecksFromConfig([]interface{}{map[string]interface{}{"url": "example.com"}}); err == nil {
+		t.Error("Expected a URL without scheme to be rejected")
+	}
+	if _, err := uptimeChecksFromConfig([]interface{}{map[string]interface{}{"url": "https://example.com", "timeout": "soon"}}); err == nil {
+		t.Error("Expected an invalid timeout to be rejected")
+	}
+	if formatLatency(1500*time.Millisecond) != "1.5s" || formatLatency(42*time.Millisecond) != "42ms" {
+		t.Errorf("Expected latencies in ms below a second, got %s and %s", formatLatency(1500*time.Millisecond), formatLatency(42*time.Millisecond))
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"time"
+)
+
+// DataVersions stamps widget results with a per-widget sequence number when they are
+// fetched, so a result that arrives after a newer one for the same widget is dropped
+// rather than bringing back older data. It also records when each widget was last
+// fetched, so overlapping fetch chains, such as one started by a manual refresh next to
+// the scheduled one, collapse into one.
+type DataVersions struct {
+	issued    map[string]uint64
+	applied   map[string]uint64
+	fetchedAt map[string]time.Time
+}
+
+// NewDataVersions creates an empty set of widget versions
+func NewDataVersions() *DataVersions {
+	return &DataVersions{
+		issued:    make(map[string]uint64),
+		applied:   make(map[string]uint64),
+		fetchedAt: make(map[string]time.Time),
+	}
+}
+
+// Next returns the version for a result of widget that was just fetched
+func (v *DataVersions) Next(widget string) uint64 {
+	if v == nil {
+		return 0
+	}
+	v.issued[widget]++
+	return v.issued[widget]
+}
+
+// Apply reports whether a result is newer than the one the widget shows, and records it
+// as shown. Unversioned results are always applied.
+func (v *DataVersions) Apply(widget string, version uint64) bool {
+	if v == nil || version == 0 {
+		return true
+	}
+	if version <= v.applied[widget] {
+		return false
+	}
+	v.applied[widget] = version
+	return true
+}
+
+// Fetched records that widget was fetched at t
+func (v *DataVersions) Fetched(widget string, t time.Time) {
+	if v != nil {
+		v.fetchedAt[widget] = t
+	}
+}
+
+// Seen reports whether widget has been fetched at all
+func (v *DataVersions) Seen(widget string) bool {
+	if v == nil {
+		return false
+	}
+	_, ok := v.fetchedAt[widget]
+	return ok
+}
+
+// Recent reports whether widget was fetched less than d before t
+func (v *DataVersions) Recent(widget string, t time.Time, d time.Duration) bool {
+	if v == nil {
+		return false
+	}
+	last, ok := v.fetchedAt[widget]
+	return ok && t.Sub(last) < d
+}
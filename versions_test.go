@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDataVersions(t *testing.T) {
+	versions := NewDataVersions()
+	older, newer := versions.Next("news"), versions.Next("news")
+	if !versions.Apply("news", newer) {
+		t.Error("Expected the newest result to be applied")
+	}
+	if versions.Apply("news", older) || versions.Apply("news", newer) {
+		t.Error("Expected older and repeated results to be dropped")
+	}
+	if !versions.Apply("weather", versions.Next("weather")) {
+		t.Error("Expected widgets to be versioned separately")
+	}
+	if !versions.Apply("news", 0) {
+		t.Error("Expected unversioned results to be applied")
+	}
+
+	now := time.Now()
+	versions.Fetched("fx", now)
+	if !versions.Recent("fx", now.Add(20*time.Minute), 30*time.Minute) || versions.Recent("fx", now.Add(40*time.Minute), 30*time.Minute) {
+		t.Error("Expected the last fetch to count as recent for the given time only")
+	}
+	if versions.Recent("crypto", now, time.Hour) {
+		t.Error("Expected a widget that was never fetched not to be recent")
+	}
+
+	var none *DataVersions
+	if none.Next("news") != 0 || !none.Apply("news", 3) || none.Recent("news", now, time.Hour) {
+		t.Error("Expected a model without versions to apply everything")
+	}
+}
+
+func TestOutOfOrderWeatherIsDropped(t *testing.T) {
+	m := Model{versions: NewDataVersions(), weather: "☁ N/A"}
+	older, newer := m.versions.Next("weather"), m.versions.Next("weather")
+
+	model, _ := m.Update(weatherMsg{pill: "🌧 14°C", version: newer})
+	model, _ = model.(Model).Update(weatherMsg{pill: "☀ 21°C", version: older})
+	if got := model.(Model).weather; got != "🌧 14°C" {
+		t.Errorf("Expected the newer weather to stay, got '%s'", got)
+	}
+}
+
+func TestOverlappingFetchesCollapse(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.AddTask("fx", time.Hour, nil)
+	m := Model{versions: NewDataVersions(), scheduler: scheduler}
+
+	// The tile is hidden, so fetches return without calling the plugin
+	m.versions.Fetched("fx", time.Now().Add(-10*time.Minute))
+	if _, cmd := m.Update(fetchFXCmd{}); cmd != nil {
+		t.Error("Expected a fetch soon after the last one to end its chain")
+	}
+	if last := m.versions.fetchedAt["fx"]; time.Since(last) < 5*time.Minute {
+		t.Error("Expected a skipped fetch not to count as a fetch")
+	}
+
+	m.Update(refreshNowMsg{fetch: fetchFXCmd{}})
+	if last := m.versions.fetchedAt["fx"]; time.Since(last) > time.Minute {
+		t.Error("Expected a manual refresh to fetch right away")
+	}
+
+	m.versions.Fetched("fx", time.Now().Add(-40*time.Minute))
+	m.Update(fetchFXCmd{})
+	if last := m.versions.fetchedAt["fx"]; time.Since(last) > time.Minute {
+		t.Error("Expected a scheduled fetch after half an interval to run")
+	}
+}
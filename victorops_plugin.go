@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// VictorOpsPlugin shows who is on call for the configured teams and their current
+// incidents, with their ack and resolve state, from Splunk On-Call (VictorOps)
+type VictorOpsPlugin struct {
+	id           string
+	pluginType   string
+	name         string
+	version      string
+	description  string
+	author       string
+	apiID        string
+	apiKey       string
+	organization string
+	teams        []string
+	apiURL       string
+	client       *http.Client
+	lastData     *OnCallStatus
+	rateLimitTracker
+}
+
+// NewVictorOpsPlugin creates a new Splunk On-Call plugin
+func NewVictorOpsPlugin() *VictorOpsPlugin {
+	return &VictorOpsPlugin{
+		id:           "victorops",
+		pluginType:   "on-call",
+		name:         "Splunk On-Call",
+		version:      "1.0.0",
+		description:  "Shows who is on call and current incidents from Splunk On-Call (VictorOps)",
+		author:       "GoDay Team",
+		apiID:        os.Getenv("VICTOROPS_API_ID"),
+		apiKey:       os.Getenv("VICTOROPS_API_KEY"),
+		organization: os.Getenv("VICTOROPS_ORGANIZATION"),
+		apiURL:       "https://api.victorops.com",
+		client:       &http.Client{Timeout: 10 * time.Second},
+		lastData:     &OnCallStatus{},
+	}
+}
+
+// GetID returns the plugin ID
+func (vp *VictorOpsPlugin) GetID() string {
+	return vp.id
+}
+
+// GetType returns the plugin type
+func (vp *VictorOpsPlugin) GetType() string {
+	return vp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (vp *VictorOpsPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        vp.name,
+		Version:     vp.version,
+		Description: vp.description,
+		Author:      vp.author,
+		Type:        vp.pluginType,
+		Config: map[string]string{
+			"has_api_key":  fmt.Sprintf("%t", vp.apiID != "" && vp.apiKey != ""),
+			"organization": vp.organization,
+			"teams":        strings.Join(vp.teams, ","),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (vp *VictorOpsPlugin) Initialize(config map[string]interface{}) error {
+	if apiID, ok := config["api_id"].(string); ok && apiID != "" {
+		vp.apiID = apiID
+	}
+	if apiKey, ok := config["api_key"].(string); ok && apiKey != "" {
+		vp.apiKey = apiKey
+	}
+	if organization, ok := config["organization"].(string); ok && organization != "" {
+		vp.organization = organization
+	}
+	vp.teams = nil
+	for _, team := range configStringList(config["teams"]) {
+		if team = strings.TrimSpace(team); team != "" {
+			vp.teams = append(vp.teams, team)
+		}
+	}
+	return nil
+}
+
+// Fetch returns the on-call users of the configured teams, or of every team when none
+// are configured, and the incidents paged to those teams: unacknowledged first, then
+// acknowledged, then recently resolved
+func (vp *VictorOpsPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if vp.apiID == "" || vp.apiKey == "" {
+		return vp.lastData, fmt.Errorf("Splunk On-Call API id and key not configured (widgets.pagerduty.api_id and api_key, or VICTOROPS_API_ID and VICTOROPS_API_KEY)")
+	}
+
+	var onCall struct {
+		TeamsOnCall []struct {
+			Team struct {
+				Name string `json:"name"`
+				Slug string `json:"slug"`
+			} `json:"team"`
+			OnCallNow []struct {
+				Users []struct {
+					OnCallUser struct {
+						Username string `json:"username"`
+					} `json:"onCalluser"`
+				} `json:"users"`
+			} `json:"oncallNow"`
+		} `json:"teamsOnCall"`
+	}
+	if err := vp.get(ctx, "/api-public/v1/oncall/current", &onCall); err != nil {
+		return vp.lastData, fmt.Errorf("on-call: %w", err)
+	}
+
+	status := &OnCallStatus{}
+	teams := make(map[string]bool) // slugs of the shown teams
+	for _, team := range onCall.TeamsOnCall {
+		if !vp.wantTeam(team.Team.Slug, team.Team.Name) {
+			continue
+		}
+		teams[team.Team.Slug] = true
+		shift := OnCallShift{Schedule: team.Team.Name}
+		for _, policy := range team.OnCallNow {
+			for _, user := range policy.Users {
+				shift.Recipients = append(shift.Recipients, user.OnCallUser.Username)
+			}
+		}
+		status.OnCall = append(status.OnCall, shift)
+	}
+
+	var incidents struct {
+		Incidents []struct {
+			IncidentNumber    string    `json:"incidentNumber"`
+			StartTime         time.Time `json:"startTime"`
+			CurrentPhase      string    `json:"currentPhase"`
+			EntityDisplayName string    `json:"entityDisplayName"`
+			EntityID          string    `json:"entityId"`
+			PagedTeams        []string  `json:"pagedTeams"`
+			Transitions       []struct {
+				Name string `json:"name"`
+				By   string `json:"by"`
+			} `json:"transitions"`
+		} `json:"incidents"`
+	}
+	if err := vp.get(ctx, "/api-public/v1/incidents", &incidents); err != nil {
+		return vp.lastData, fmt.Errorf("incidents: %w", err)
+	}
+
+	for _, incident := range incidents.Incidents {
+		if len(vp.teams) > 0 && !pagedAny(incident.PagedTeams, teams) {
+			continue
+		}
+		message := incident.EntityDisplayName
+		if message == "" {
+			message = incident.EntityID
+		}
+		alert := OnCallAlert{
+			ID:           incident.IncidentNumber,
+			TinyID:       incident.IncidentNumber,
+			Message:      message,
+			Acknowledged: incident.CurrentPhase == "ACKED",
+			Resolved:     incident.CurrentPhase == "RESOLVED",
+			CreatedAt:    incident.StartTime,
+		}
+		// Whoever acknowledged or resolved it last owns it
+		if n := len(incident.Transitions); n > 0 {
+			alert.Owner = incident.Transitions[n-1].By
+		}
+		if vp.organization != "" {
+			alert.URL = fmt.Sprintf("https://portal.victorops.com/ui/%s/incident/%s/details",
+				url.PathEscape(vp.organization), url.PathEscape(incident.IncidentNumber))
+		}
+		status.Alerts = append(status.Alerts, alert)
+	}
+	sort.SliceStable(status.Alerts, func(i, j int) bool {
+		a, b := status.Alerts[i], status.Alerts[j]
+		if a.Resolved != b.Resolved {
+			return b.Resolved
+		}
+		if a.Acknowledged != b.Acknowledged {
+			return b.Acknowledged
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+
+	vp.lastData = status
+	return status, nil
+}
+
+// wantTeam reports whether a team is one of the configured ones, matched by slug or name,
+// or any team when none are configured
+func (vp *VictorOpsPlugin) wantTeam(slug, name string) bool {
+	if len(vp.teams) == 0 {
+		return true
+	}
+	for _, team := range vp.teams {
+		if team == slug || strings.EqualFold(team, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// pagedAny reports whether any of the paged team slugs is in teams
+func pagedAny(paged []string, teams map[string]bool) bool {
+	for _, slug := range paged {
+		if teams[slug] {
+			return true
+		}
+	}
+	return false
+}
+
+// get performs a Splunk On-Call API request and decodes the JSON response
+func (vp *VictorOpsPlugin) get(ctx context.Context, path string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", vp.apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-VO-Api-Id", vp.apiID)
+	req.Header.Set("X-VO-Api-Key", vp.apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := vp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	vp.observeRateLimit(resp)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("Splunk On-Call rate limit reached")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Splunk On-Call API returned status %d", resp.StatusCode)
+	}
+	return json.Unmarshal(body, target)
+}
+
+// Cleanup performs cleanup
+func (vp *VictorOpsPlugin) Cleanup() error {
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVictorOpsPluginFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-VO-Api-Id") != "test-id" || r.Header.Get("X-VO-Api-Key") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api-public/v1/oncall/current":
+			fmt.Fprint(w, `{"teamsOnCall":[
+				{"team":{"name":"Platform","slug":"team-plat"},"oncallNow":[
+					{"escalationPolicy":{"name":"Primary","slug":"pol-1"},"users":[{"onCalluser":{"username":"alice"}}]},
+					{"escalationPolicy":{"name":"Secondary","slug":"pol-2"},"users":[{"onCalluser":{"username":"bob"}}]}]},
+				{"team":{"name":"Payments","slug":"team-pay"},"oncallNow":[]}]}`)
+		case "/api-public/v1/incidents":
+			fmt.Fprint(w, `{"incidents":[
+				{"incidentNumber":"101","startTime":"2026-10-16T06:00:00Z","currentPhase":"RESOLVED",
+				 "entityDisplayName":"Disk full","pagedTeams":["team-plat"],"transitions":[{"name":"RESOLVED","by":"alice"}]},
+				{"incidentNumber":"102","startTime":"2026-10-16T07:00:00Z","currentPhase":"ACKED",
+				 "entityDisplayName":"Latency high","pagedTeams":["team-plat"],"transitions":[{"name":"ACKED","by":"bob"}]},
+				{"incidentNumber":"103","startTime":"2026-10-16T08:00:00Z","currentPhase":"UNACKED",
+				 "entityId":"checkout-5xx","pagedTeams":["team-pay"],"transitions":[]}]}`)
+		}
+	}))
+	defer server.Close()
+
+	plugin := NewVictorOpsPlugin()
+	plugin.Initialize(map[string]interface{}{"api_id": "test-id", "api_key": "test-key", "organization": "acme"})
+	plugin.apiURL = server.URL
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	status := data.(*OnCallStatus)
+	if len(status.OnCall) != 2 || len(status.OnCall[0].Recipients) != 2 || len(status.OnCall[1].Recipients) != 0 {
+		t.Errorf("Expected both teams with their on-call users, got %+v", status.OnCall)
+	}
+	if len(status.Alerts) != 3 {
+		t.Fatalf("Expected every incident, got %+v", status.Alerts)
+	}
+	first, acked, resolved := status.Alerts[0], status.Alerts[1], status.Alerts[2]
+	if first.TinyID != "103" || first.Message != "checkout-5xx" || first.Acknowledged || first.Resolved {
+		t.Errorf("Expected the unacknowledged incident first, named after its entity, got %+v", first)
+	}
+	if !acked.Acknowledged || acked.Owner != "bob" || !resolved.Resolved || resolved.Owner != "alice" {
+		t.Errorf("Expected the acked then the resolved incident with who handled them, got %+v, %+v", acked, resolved)
+	}
+	if first.URL != "https://portal.victorops.com/ui/acme/incident/103/details" {
+		t.Errorf("Expected a link to the incident timeline, got '%s'", first.URL)
+	}
+
+	// Configured teams narrow the incidents to the ones paged to them
+	plugin.Initialize(map[string]interface{}{"teams": []interface{}{"payments"}})
+	data, _ = plugin.Fetch(context.Background())
+	if status := data.(*OnCallStatus); len(status.OnCall) != 1 || len(status.Alerts) != 1 || status.Alerts[0].TinyID != "103" {
+		t.Errorf("Expected only the Payments team and its incident, got %+v", status)
+	}
+
+	plugin.apiKey = "wrong"
+	if _, err := plugin.Fetch(context.Background()); err == nil {
+		t.Error("Expected rejected credentials to fail")
+	}
+}
+
+func TestUpdateOnCallWidgetIncidents(t *testing.T) {
+	wm := NewWidgetManager()
+	wm.UpdateOnCallWidget(&OnCallStatus{Alerts: []OnCallAlert{
+		{TinyID: "103", Message: "checkout-5xx"},
+		{TinyID: "101", Message: "Disk full", Resolved: true, Acknowledged: true},
+	}})
+
+	widget := wm.Widgets["pagerduty"]
+	if widget.Count != 1 {
+		t.Errorf("Expected resolved incidents to be left out of the count, got %d", widget.Count)
+	}
+	if widget.Items[0].Title != "#103 checkout-5xx" || widget.Items[0].Status != "🔴" {
+		t.Errorf("Expected an unacknowledged incident without priority to be urgent, got %+v", widget.Items[0])
+	}
+	if widget.Items[1].Status != "✅" || widget.Items[1].Subtitle[:8] != "resolved" {
+		t.Errorf("Expected the resolved incident to be marked, got %+v", widget.Items[1])
+	}
+}
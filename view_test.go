@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// viewSizes are the terminal sizes rendering is measured at: the 80x24 default, a
+// typical split pane and a full-screen window. The budgets cap allocations per render
+// about a quarter above what was measured, so layout and caching changes that render
+// more wastefully fail TestViewAllocationBudget; lower them when rendering gets cheaper.
+var viewSizes = []struct {
+	width, height int
+	viewAllocs    float64 // Model.View
+	tileAllocs    float64 // WidgetTile.View at the size the grid uses
+}{
+	{80, 24, 3500, 100},
+	{120, 40, 4200, 110},
+	{220, 60, 5200, 125},
+}
+
+// benchmarkModel builds a dashboard with every default tile filled with items, without
+// loading a config or calling any plugin
+func benchmarkModel(width, height int) Model {
+	m := Model{
+		userName:       "Test User",
+		dateTime:       "Fri 16 Oct 2026 09:30",
+		weather:        "🌧 21°C → rain at 14:00 (Bengaluru,IN)",
+		location:       "Bengaluru,IN",
+		widgetManager:  NewWidgetManager(),
+		scheduler:      NewScheduler(),
+		terminalWidth:  width,
+		terminalHeight: height,
+	}
+	for _, tile := range selectTiles(nil, nil) {
+		widget := NewWidgetTile(tile.key, tile.title, baseTileWidth, baseTileHeight)
+		var items []WidgetItem
+		for i := 1; i <= 12; i++ {
+			items = append(items, WidgetItem{
+				Title:    fmt.Sprintf("%s item %d with a title long enough to be truncated", tile.title, i),
+				Subtitle: "octocat • 2h ago",
+				Status:   "🟢",
+			})
+		}
+		widget.UpdateItems(items)
+		m.widgets = append(m.widgets, widget)
+	}
+	return m
+}
+
+func BenchmarkModelView(b *testing.B) {
+	for _, size := range viewSizes {
+		b.Run(fmt.Sprintf("%dx%d", size.width, size.height), func(b *testing.B) {
+			m := benchmarkModel(size.width, size.height)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				m.View()
+			}
+		})
+	}
+}
+
+func BenchmarkWidgetTileView(b *testing.B) {
+	for _, size := range viewSizes {
+		// The tile sizes renderWidgetGrid picks for each terminal width
+		tile := benchmarkModel(size.width, size.height).widgets[0]
+		tile.width, tile.height = gridTileSize(size.width)
+		b.Run(fmt.Sprintf("%dx%d", size.width, size.height), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				tile.View()
+			}
+		})
+	}
+}
+
+func TestViewAllocationBudget(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocation counts are not meaningful with the race detector")
+	}
+	for _, size := range viewSizes {
+		m := benchmarkModel(size.width, size.height)
+		if allocs := testing.AllocsPerRun(5, func() { m.View() }); allocs > size.viewAllocs {
+			t.Errorf("Expected Model.View at %dx%d to allocate at most %.0f times, got %.0f", size.width, size.height, size.viewAllocs, allocs)
+		}
+
+		tile := m.widgets[0]
+		tile.width, tile.height = gridTileSize(size.width)
+		if allocs := testing.AllocsPerRun(5, func() { tile.View() }); allocs > size.tileAllocs {
+			t.Errorf("Expected WidgetTile.View at %dx%d to allocate at most %.0f times, got %.0f", size.width, size.height, size.tileAllocs, allocs)
+		}
+	}
+}
+
+func TestExpandedTile(t *testing.T) {
+	// One row of tiles, so the expanded tile has room for 8 items
+	m := benchmarkModel(120, 40)
+	m.widgets = m.widgets[:3]
+	m.focusedWidget = 1
+	focused, other := m.widgets[1].title, m.widgets[0].title
+	last := focused + " item 12 with a title long enough to be truncated"
+
+	updated, _ := m.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	m = updated.(Model)
+	view := m.View()
+	if !m.expanded || !strings.Contains(view, focused+" item 1 with a title long enough to be truncated") || strings.Contains(view, other+" item 1") {
+		t.Fatalf("Expected only the focused tile with whole lines, got:\n%s", view)
+	}
+	if strings.Contains(view, last) {
+		t.Errorf("Expected the last item to be out of view before it is selected")
+	}
+
+	m.widgets[1].list.Select(11)
+	if view := m.View(); !strings.Contains(view, last) {
+		t.Errorf("Expected the expanded tile to scroll to the selected item, got:\n%s", view)
+	}
+
+	updated, _ = m.update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if view := m.View(); m.expanded || !strings.Contains(view, other) {
+		t.Errorf("Expected Esc to restore the grid, got:\n%s", view)
+	}
+}
+
+func TestExpandedWindow(t *testing.T) {
+	lines := []string{"one", "two", strings.Repeat("x", 25), "four", "five"}
+	if from, to := expandedWindow(lines, 0, 10, 4); from != 0 || to != 2 {
+		t.Errorf("Expected the first two lines before the wrapped one, got %d-%d", from, to)
+	}
+	if from, to := expandedWindow(lines, 4, 10, 4); from != 3 || to != 5 {
+		t.Errorf("Expected to scroll to the selected line, got %d-%d", from, to)
+	}
+	if from, to := expandedWindow(lines, 2, 10, 2); from != 2 || to != 3 {
+		t.Errorf("Expected a line taller than the tile to show alone, got %d-%d", from, to)
+	}
+}
+
+func TestTileScrolling(t *testing.T) {
+	tile := NewWidgetTile("news", "News", baseTileWidth, baseTileHeight)
+	var items []WidgetItem
+	for i := 1; i <= 24; i++ {
+		items = append(items, WidgetItem{Title: fmt.Sprintf("Story %d", i)})
+	}
+	tile.UpdateItems(items)
+
+	// 6 rows of content: 5 items and the position
+	tile.list.Select(6)
+	view := tile.View()
+	if !strings.Contains(view, "3–7 of 24") || !strings.Contains(view, "Story 7") || strings.Contains(view, "Story 2 ") {
+		t.Errorf("Expected the tile to scroll down to the selection, got:\n%s", view)
+	}
+	tile.list.Select(3)
+	if view := tile.View(); !strings.Contains(view, "3–7 of 24") {
+		t.Errorf("Expected the tile not to scroll while the selection is in view, got:\n%s", view)
+	}
+	tile.list.Select(0)
+	if view := tile.View(); !strings.Contains(view, "1–5 of 24") {
+		t.Errorf("Expected the tile to scroll back up to the selection, got:\n%s", view)
+	}
+	tile.list.Select(23)
+	if view := tile.View(); !strings.Contains(view, "Story 24") || !strings.Contains(view, "20–24 of 24") {
+		t.Errorf("Expected the last item to be reachable, got:\n%s", view)
+	}
+	if item, ok := tile.itemAt(0); !ok || item != 19 {
+		t.Errorf("Expected the first row to show item 19, got %d %v", item, ok)
+	}
+	if _, ok := tile.itemAt(5); ok {
+		t.Error("Expected the position row not to be an item")
+	}
+
+	// Items that fit are all shown, without a position
+	tile.UpdateItems(items[:6])
+	if view := tile.View(); !strings.Contains(view, "Story 6") || strings.Contains(view, " of 6") {
+		t.Errorf("Expected all 6 items without a position, got:\n%s", view)
+	}
+}
+
+func TestZenMode(t *testing.T) {
+	m := benchmarkModel(80, 20)
+	m.widgets[0].UpdateItems([]WidgetItem{
+		{Title: "Standup", Subtitle: "09:30", Details: []string{"Room 4", "Attendees: Ann, Bo"}, URL: "https://meet.example.com/standup"},
+		{Title: "Review", Details: []string{"First line\nSecond line"}},
+	})
+	press := func(msg tea.KeyMsg) {
+		updated, _ := m.update(msg)
+		m = updated.(Model)
+	}
+	z := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")}
+
+	// The first z expands over the grid, without details
+	press(z)
+	if view := m.View(); m.zen || !strings.Contains(view, "Test User") || strings.Contains(view, "Room 4") {
+		t.Fatalf("Expected the tile expanded below the header, got:\n%s", view)
+	}
+
+	press(z)
+	view := m.View()
+	if !m.zen || strings.Contains(view, "Test User") || lipgloss.Height(view) != 20 || lipgloss.Width(view) != 80 {
+		t.Fatalf("Expected the tile alone over the 80x20 terminal, got %dx%d:\n%s", lipgloss.Width(view), lipgloss.Height(view), view)
+	}
+	for _, want := range []string{"Attendees: Ann, Bo", "https://meet.example.com/standup", "  Second line"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q among the item details, got:\n%s", want, view)
+		}
+	}
+	// Details count towards the rows an item takes
+	if item, ok := m.widgets[0].itemAt(4); !ok || item != 1 {
+		t.Errorf("Expected the fifth row to be the second item, got %d %v", item, ok)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.zen || m.expanded || !strings.Contains(m.View(), "Test User") {
+		t.Error("Expected Esc to restore the grid")
+	}
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// vulnScanTimeout bounds a scan of all modules; govulncheck loads and type-checks every
+// package, and downloads the vulnerability database on its first run
+const vulnScanTimeout = 10 * time.Minute
+
+// errScanRunning is returned when a scan is asked for while the last one still runs
+var errScanRunning = errors.New("a vulnerability scan is already running")
+
+// Vuln is a known vulnerability in a package a module imports
+type Vuln struct {
+	ID      string // Go vulnerability ID, e.g. GO-2024-2687
+	Summary string
+	Module  string // module the vulnerable package is in
+	Version string // version of it the scanned module uses
+	Package string
+	Symbol  string // vulnerable function the code calls, if it calls one
+	Fixed   string // first version without the vulnerability; empty when none is fixed yet
+}
+
+// Called reports whether the code calls the vulnerable function, rather than only
+// importing its package
+func (v Vuln) Called() bool {
+	return v.Symbol != ""
+}
+
+// VulnReport is what a scan found in one configured module
+type VulnReport struct {
+	Path     string // as configured, e.g. ~/src/app
+	Vulns    []Vuln // called first
+	Required int    // vulnerabilities in required modules whose packages are not imported
+	Err      string // why the module could not be scanned
+}
+
+// vulnsMsg carries the scan results back to the TUI
+type vulnsMsg struct {
+	reports []VulnReport
+	err     error
+}
+
+// VulnsPlugin runs govulncheck over local Go modules and reports the known
+// vulnerabilities in the packages they import
+type VulnsPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	modules     []string
+	run         func(ctx context.Context, dir string) ([]byte, error) // govulncheck -json in dir
+	mu          sync.Mutex                                            // held for the length of a scan
+	lastData    []VulnReport
+}
+
+// NewVulnsPlugin creates a new Go vulnerability plugin
+func NewVulnsPlugin() *VulnsPlugin {
+	return &VulnsPlugin{
+		id:          "vulns",
+		pluginType:  "security",
+		name:        "Go Vulnerabilities",
+		version:     "1.0.0",
+		description: "Reports known vulnerabilities in the packages your Go modules use",
+		author:      "GoDay Team",
+		run:         runGovulncheck,
+	}
+}
+
+// GetID returns the plugin ID
+func (vp *VulnsPlugin) GetID() string {
+	return vp.id
+}
+
+// GetType returns the plugin type
+func (vp *VulnsPlugin) GetType() string {
+	return vp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (vp *VulnsPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        vp.name,
+		Version:     vp.version,
+		Description: vp.description,
+		Author:      vp.author,
+		Type:        vp.pluginType,
+		Config: map[string]string{
+			"modules": strings.Join(vp.modules, ","),
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (vp *VulnsPlugin) Initialize(config map[string]interface{}) error {
+	if modules := configStringList(config["modules"]); len(modules) > 0 {
+		vp.modules = modules
+	}
+	return nil
+}
+
+// Fetch scans each module in turn, as govulncheck already uses every CPU. Modules that
+// fail to scan carry their error.
+func (vp *VulnsPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	if len(vp.modules) == 0 {
+		return vp.lastData, fmt.Errorf("no modules configured (widgets.vulns.modules)")
+	}
+	if !vp.mu.TryLock() {
+		return vp.lastData, errScanRunning
+	}
+	defer vp.mu.Unlock()
+
+	var reports []VulnReport
+	for _, path := range vp.modules {
+		dir := path
+		if strings.HasPrefix(dir, "~/") {
+			home, _ := os.UserHomeDir()
+			dir = filepath.Join(home, dir[2:])
+		}
+		report := VulnReport{Path: path}
+		out, err := vp.run(ctx, dir)
+		if err == nil {
+			report.Vulns, report.Required, err = parseGovulncheck(bytes.NewReader(out))
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return vp.lastData, ctx.Err()
+			}
+			report.Err = err.Error()
+		}
+		reports = append(reports, report)
+	}
+
+	vp.lastData = reports
+	return reports, nil
+}
+
+// runGovulncheck scans every package of the module in dir and returns the JSON stream
+func runGovulncheck(ctx context.Context, dir string) ([]byte, error) {
+	if _, err := exec.LookPath("govulncheck"); err != nil {
+		return nil, missingBinaryError("govulncheck")
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "govulncheck", "-json", "./...")
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// With -json govulncheck only fails when it cannot scan, saying why last
+		lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+		if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+			return nil, fmt.Errorf("govulncheck: %s", last)
+		}
+		return nil, fmt.Errorf("govulncheck: %w", err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// govulncheckMessage is one message of govulncheck's JSON stream; only vulnerability
+// entries and findings are read
+type govulncheckMessage struct {
+	OSV *struct {
+		ID      string `json:"id"`
+		Summary string `json:"summary"`
+	} `json:"osv"`
+	Finding *struct {
+		OSV          string `json:"osv"`
+		FixedVersion string `json:"fixed_version"`
+		Trace        []struct {
+			Module   string `json:"module"`
+			Version  string `json:"version"`
+			Package  string `json:"package"`
+			Function string `json:"function"`
+			Receiver string `json:"receiver"`
+		} `json:"trace"`
+	} `json:"finding"`
+}
+
+// parseGovulncheck reads a govulncheck JSON stream into the vulnerabilities in imported
+// packages, called first, and how many more are only in required modules. A finding's
+// first trace frame is the vulnerable module, package or function.
+func parseGovulncheck(r io.Reader) ([]Vuln, int, error) {
+	summaries := make(map[string]string)
+	found := make(map[string]*Vuln)
+	required := make(map[string]bool)
+	decoder := json.NewDecoder(r)
+	for {
+		var msg govulncheckMessage
+		if err := decoder.Decode(&msg); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, 0, fmt.Errorf("reading govulncheck output: %w", err)
+		}
+		if msg.OSV != nil {
+			summaries[msg.OSV.ID] = msg.OSV.Summary
+		}
+		if msg.Finding == nil || len(msg.Finding.Trace) == 0 {
+			continue
+		}
+		frame := msg.Finding.Trace[0]
+		if frame.Package == "" {
+			required[msg.Finding.OSV] = true
+			continue
+		}
+		vuln := found[msg.Finding.OSV]
+		if vuln == nil {
+			vuln = &Vuln{ID: msg.Finding.OSV, Module: frame.Module, Version: frame.Version, Package: frame.Package, Fixed: msg.Finding.FixedVersion}
+			found[msg.Finding.OSV] = vuln
+		}
+		if frame.Function != "" && vuln.Symbol == "" {
+			vuln.Symbol = frame.Function
+			if frame.Receiver != "" {
+				vuln.Symbol = strings.TrimPrefix(frame.Receiver, "*") + "." + frame.Function
+			}
+		}
+	}
+
+	var vulns []Vuln
+	for id, vuln := range found {
+		vuln.Summary = summaries[id]
+		vulns = append(vulns, *vuln)
+		delete(required, id)
+	}
+	sort.Slice(vulns, func(i, j int) bool {
+		if vulns[i].Called() != vulns[j].Called() {
+			return vulns[i].Called()
+		}
+		return vulns[i].ID < vulns[j].ID
+	})
+	return vulns, len(required), nil
+}
+
+// scanVulnsCmd runs a scan in the background, as it can take minutes
+func scanVulnsCmd(plugin Plugin) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), vulnScanTimeout)
+		defer cancel()
+
+		data, err := plugin.Fetch(ctx)
+		reports, _ := data.([]VulnReport)
+		return vulnsMsg{reports: reports, err: err}
+	}
+}
+
+// Cleanup performs cleanup
+func (vp *VulnsPlugin) Cleanup() error {
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// govulncheckOutput has a called vulnerability, one only imported and one only in a
+// required module, as govulncheck -json reports them
+const govulncheckOutput = `{"config":{"protocol_version":"v1.0.0","scanner_name":"govulncheck"}}
+{"progress":{"message":"Scanning your code and 52 packages across 8 dependent modules for known vulnerabilities..."}}
+{"osv":{"id":"GO-2024-2687","summary":"HTTP/2 CONTINUATION flood in net/http"}}
+{"osv":{"id":"GO-2023-1988","summary":"Improper rendering of text nodes in golang.org/x/net/html"}}
+{"osv":{"id":"GO-2022-0646","summary":"CBC padding oracle issue in AWS S3 Crypto SDK for golang"}}
+{"finding":{"osv":"GO-2022-0646","trace":[{"module":"github.com/aws/aws-sdk-go","version":"v1.44.0"}]}}
+{"finding":{"osv":"GO-2023-1988","fixed_version":"v0.13.0","trace":[{"module":"golang.org/x/net","version":"v0.7.0"}]}}
+{"finding":{"osv":"GO-2023-1988","fixed_version":"v0.13.0","trace":[{"module":"golang.org/x/net","version":"v0.7.0","package":"golang.org/x/net/html"}]}}
+{"finding":{"osv":"GO-2024-2687","fixed_version":"v0.23.0","trace":[{"module":"golang.org/x/net","version":"v0.7.0","package":"golang.org/x/net/http2"}]}}
+{"finding":{"osv":"GO-2024-2687","fixed_version":"v0.23.0","trace":[{"module":"golang.org/x/net","version":"v0.7.0","package":"golang.org/x/net/http2","function":"ServeConn","receiver":"*Server"},{"module":"example.com/app","package":"example.com/app","function":"main"}]}}
+`
+
+func TestParseGovulncheck(t *testing.T) {
+	vulns, required, err := parseGovulncheck(strings.NewReader(govulncheckOutput))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(vulns) != 2 || required != 1 {
+		t.Fatalf("Expected 2 vulnerabilities in imported packages and 1 more required, got %+v and %d", vulns, required)
+	}
+	if vulns[0].ID != "GO-2024-2687" || vulns[0].Symbol != "Server.ServeConn" || vulns[0].Fixed != "v0.23.0" {
+		t.Errorf("Expected the called HTTP/2 vulnerability first, got %+v", vulns[0])
+	}
+	if vulns[1].Called() || vulns[1].Package != "golang.org/x/net/html" {
+		t.Errorf("Expected the html vulnerability to be imported but not called, got %+v", vulns[1])
+	}
+
+	if _, _, err := parseGovulncheck(strings.NewReader("Scanning your code...")); err == nil {
+		t.Error("Expected text output to be rejected")
+	}
+}
+
+func TestVulnsPluginFetch(t *testing.T) {
+	plugin := NewVulnsPlugin()
+	plugin.run = func(ctx context.Context, dir string) ([]byte, error) {
+		if dir == "broken" {
+			return nil, errors.New("govulncheck: no go.mod file")
+		}
+		return []byte(govulncheckOutput), nil
+	}
+	if err := plugin.Initialize(map[string]interface{}{"modules": []string{"app", "broken"}}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	reports := data.([]VulnReport)
+	if len(reports) != 2 || len(reports[0].Vulns) != 2 || reports[1].Err != "govulncheck: no go.mod file" {
+		t.Fatalf("Expected one scanned module and one failing, got %+v", reports)
+	}
+
+	wm := NewWidgetManager()
+	wm.UpdateVulnsWidget(append(reports, VulnReport{Path: "clean"}))
+	widget := wm.Widgets["vulns"]
+	if widget.Count != 1 || len(widget.Items) != 4 {
+		t.Fatalf("Expected 1 called vulnerability in 4 lines, got %d in %+v", widget.Count, widget.Items)
+	}
+	if got := widget.Items[0].Subtitle; got != "app • calls Server.ServeConn • v0.7.0 → v0.23.0 • HTTP/2 CONTINUATION flood in net/http" {
+		t.Errorf("Expected the call and the fix, got %q", got)
+	}
+	if widget.Items[0].URL != "https://pkg.go.dev/vuln/GO-2024-2687" || widget.Items[3].Status != "✅" {
+		t.Errorf("Expected a link to the report and a clean module, got %+v", widget.Items)
+	}
+
+	// A scan asked for during another leaves it be
+	plugin.mu.Lock()
+	if _, err := plugin.Fetch(context.Background()); !errors.Is(err, errScanRunning) {
+		t.Errorf("Expected a second scan to be refused, got %v", err)
+	}
+	plugin.mu.Unlock()
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"time"
 )
@@ -21,6 +22,11 @@ type WeatherPlugin struct {
 	city        string
 	client      *http.Client
 	lastData    *WeatherData
+	dailyQuota  int    // calls allowed per UTC day; OWM sends no rate-limit headers
+	quotaDay    string // UTC day callsToday counts
+	callsToday  int
+	forecast    bool // also fetch the 5-day forecast, at one more call per refresh
+	apiURL      string
 }
 
 // NewWeatherPlugin creates a new weather plugin
@@ -35,6 +41,8 @@ func NewWeatherPlugin(apiKey, city string) *WeatherPlugin {
 		apiKey:      apiKey,
 		city:        city,
 		client:      &http.Client{Timeout: 10 * time.Second},
+		dailyQuota:  1000, // free plan
+		apiURL:      "http://api.openweathermap.org",
 	}
 }
 
@@ -56,6 +64,12 @@ func (wp *WeatherPlugin) Initialize(config map[string]interface{}) error {
 	if city, ok := config["city"].(string); ok {
 		wp.city = city
 	}
+	if quota, ok := config["daily_quota"].(int); ok && quota > 0 {
+		wp.dailyQuota = quota
+	}
+	if forecast, ok := config["show_forecast"].(bool); ok {
+		wp.forecast = forecast
+	}
 	return nil
 }
 
@@ -70,13 +84,14 @@ func (wp *WeatherPlugin) Fetch(ctx context.Context) (interface{}, error) {
 		}, nil
 	}
 
-	url := fmt.Sprintf("http://api.openweathermap.org/data/2.5/weather?q=%s&units=metric&appid=%s", wp.city, wp.apiKey)
+	url := fmt.Sprintf("%s/data/2.5/weather?q=%s&units=metric&appid=%s", wp.apiURL, wp.city, wp.apiKey)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return wp.lastData, err
 	}
 
+	wp.countCall(time.Now())
 	resp, err := wp.client.Do(req)
 	if err != nil {
 		return wp.lastData, err
@@ -87,6 +102,10 @@ func (wp *WeatherPlugin) Fetch(ctx context.Context) (interface{}, error) {
 	if err != nil {
 		return wp.lastData, err
 	}
+	// Errors such as a revoked key come back as JSON too, which would read as 0°C
+	if resp.StatusCode != http.StatusOK {
+		return wp.lastData, fmt.Errorf("OpenWeatherMap returned status %d", resp.StatusCode)
+	}
 
 	var weatherResp WeatherResponse
 	if err := json.Unmarshal(body, &weatherResp); err != nil {
@@ -114,10 +133,91 @@ func (wp *WeatherPlugin) Fetch(ctx context.Context) (interface{}, error) {
 		Condition:   condition,
 		Icon:        icon,
 	}
+	if wp.forecast {
+		// A failed forecast keeps the last one rather than failing the current weather
+		if days, err := wp.fetchForecast(ctx); err == nil {
+			data.Daily = days
+		} else if wp.lastData != nil {
+			data.Daily = wp.lastData.Daily
+		}
+	}
 	wp.lastData = data
 	return data, nil
 }
 
+// fetchForecast retrieves the 5-day forecast in three-hour steps and sums it up per day:
+// the highest and lowest temperature, and the conditions closest to midday
+func (wp *WeatherPlugin) fetchForecast(ctx context.Context) ([]WeatherDay, error) {
+	url := fmt.Sprintf("%s/data/2.5/forecast?q=%s&units=metric&appid=%s", wp.apiURL, wp.city, wp.apiKey)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	wp.countCall(time.Now())
+	resp, err := wp.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OpenWeatherMap forecast returned status %d", resp.StatusCode)
+	}
+
+	var forecast struct {
+		List []struct {
+			Dt   int64 `json:"dt"`
+			Main struct {
+				TempMin float64 `json:"temp_min"`
+				TempMax float64 `json:"temp_max"`
+			} `json:"main"`
+			Weather []struct {
+				ID   int    `json:"id"`
+				Main string `json:"main"`
+			} `json:"weather"`
+		} `json:"list"`
+		City struct {
+			Timezone int `json:"timezone"` // offset from UTC in seconds
+		} `json:"city"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&forecast); err != nil {
+		return nil, err
+	}
+
+	zone := time.FixedZone("", forecast.City.Timezone)
+	var days []WeatherDay
+	middays := make(map[int]int) // day index -> hours from midday of its conditions
+	for _, step := range forecast.List {
+		at := time.Unix(step.Dt, 0).In(zone)
+		date := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, zone)
+		high, low := int(math.Round(step.Main.TempMax)), int(math.Round(step.Main.TempMin))
+		if len(days) == 0 || !days[len(days)-1].Date.Equal(date) {
+			if len(days) == weatherForecastDays {
+				break
+			}
+			days = append(days, WeatherDay{Date: date, High: high, Low: low})
+			middays[len(days)-1] = 24
+		}
+		day := &days[len(days)-1]
+		if high > day.High {
+			day.High = high
+		}
+		if low < day.Low {
+			day.Low = low
+		}
+		fromMidday := at.Hour() - 12
+		if fromMidday < 0 {
+			fromMidday = -fromMidday
+		}
+		if fromMidday < middays[len(days)-1] && len(step.Weather) > 0 {
+			middays[len(days)-1] = fromMidday
+			day.Icon = getWeatherIcon(step.Weather[0].ID)
+			day.Condition = step.Weather[0].Main
+		}
+	}
+	return days, nil
+}
+
 // GetMetadata returns plugin metadata
 func (wp *WeatherPlugin) GetMetadata() PluginMetadata {
 	return PluginMetadata{
@@ -137,3 +237,31 @@ func (wp *WeatherPlugin) GetMetadata() PluginMetadata {
 func (wp *WeatherPlugin) Cleanup() error {
 	return nil
 }
+
+// countCall records an API call against today's quota
+func (wp *WeatherPlugin) countCall(now time.Time) {
+	day := now.UTC().Format("2006-01-02")
+	if day != wp.quotaDay {
+		wp.quotaDay = day
+		wp.callsToday = 0
+	}
+	wp.callsToday++
+}
+
+// RateLimit returns the calls left in today's quota, which resets at midnight UTC
+func (wp *WeatherPlugin) RateLimit() (RateLimit, bool) {
+	if wp.quotaDay == "" {
+		return RateLimit{}, false
+	}
+	now := time.Now().UTC()
+	used := wp.callsToday
+	if now.Format("2006-01-02") != wp.quotaDay {
+		used = 0
+	}
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return RateLimit{
+		Limit:     wp.dailyQuota,
+		Remaining: wp.dailyQuota - used,
+		Reset:     midnight.AddDate(0, 0, 1),
+	}, true
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWeatherPluginForecast(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data/2.5/weather":
+			fmt.Fprint(w, `{"main":{"temp":21.4},"weather":[{"id":800,"main":"Clear"}]}`)
+		case "/data/2.5/forecast":
+			// UTC+2: 2026-10-16 09:00, 14:00 and 2026-10-17 03:00, 12:00 local
+			fmt.Fprint(w, `{"city":{"timezone":7200},"list":[
+				{"dt":1792134000,"main":{"temp_min":11.2,"temp_max":13.0},"weather":[{"id":500,"main":"Rain"}]},
+				{"dt":1792152000,"main":{"temp_min":14.0,"temp_max":18.6},"weather":[{"id":803,"main":"Clouds"}]},
+				{"dt":1792198800,"main":{"temp_min":7.5,"temp_max":8.0},"weather":[{"id":800,"main":"Clear"}]},
+				{"dt":1792231200,"main":{"temp_min":15.0,"temp_max":16.4},"weather":[{"id":600,"main":"Snow"}]}]}`)
+		}
+	}))
+	defer server.Close()
+
+	plugin := NewWeatherPlugin("", "")
+	plugin.apiURL = server.URL
+	plugin.Initialize(map[string]interface{}{"api_key": "key", "city": "Berlin,DE", "show_forecast": true})
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	weather := data.(*WeatherData)
+	if len(weather.Daily) != 2 {
+		t.Fatalf("Expected two days, got %+v", weather.Daily)
+	}
+	today, tomorrow := weather.Daily[0], weather.Daily[1]
+	if today.Date.Format("2006-01-02") != "2026-10-16" || today.High != 19 || today.Low != 11 || today.Condition != "Clouds" {
+		t.Errorf("Expected today's range with its afternoon conditions, got %+v", today)
+	}
+	if tomorrow.High != 16 || tomorrow.Low != 8 || tomorrow.Condition != "Snow" {
+		t.Errorf("Expected tomorrow's range with its midday conditions, got %+v", tomorrow)
+	}
+	if rl, _ := plugin.RateLimit(); rl.Remaining != 998 {
+		t.Errorf("Expected the forecast to count against the quota, got %d left", rl.Remaining)
+	}
+}
+
+func TestUpdateWeatherWidget(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+	day := func(offset, high, low int, icon string) WeatherDay {
+		return WeatherDay{Date: time.Date(2026, 10, 16+offset, 0, 0, 0, 0, time.Local), High: high, Low: low, Icon: icon, Condition: "Clouds"}
+	}
+
+	wm := NewWidgetManager()
+	wm.UpdateWeatherWidget(&WeatherData{Daily: []WeatherDay{
+		day(-1, 10, 5, "☀"), day(0, 17, 9, "🌧"), day(1, 19, 11, "⛅"), day(2, 20, 12, "☀"),
+		day(3, 18, 10, "☁"), day(4, 16, 8, "🌧"), day(5, 15, 7, "❄"),
+	}}, now)
+	items := wm.Widgets["weather"].Items
+	if len(items) != weatherForecastDays {
+		t.Fatalf("Expected %d days from today, got %+v", weatherForecastDays, items)
+	}
+	if items[0].Title != "Today   🌧  17° / 9°" || items[1].Title != "Sat 17  ⛅  19° / 11°" || items[0].Subtitle != "Clouds" {
+		t.Errorf("Expected day, icon and high/low, got '%s', '%s'", items[0].Title, items[1].Title)
+	}
+
+	wm.UpdateWeatherWidget(&WeatherData{Temperature: 14, Icon: "🌧"}, now)
+	if items := wm.Widgets["weather"].Items; len(items) != 1 || items[0].Title != "🌧 14°C now" {
+		t.Errorf("Expected the current weather without a forecast, got %+v", items)
+	}
+}
@@ -0,0 +1,761 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// WidgetProvider describes one backend that can power a widget
+type WidgetProvider struct {
+	// New constructs the plugin for this provider
+	New func() Plugin
+	// Config builds the plugin configuration; cfg is nil when no config file was loaded
+	Config func(cfg *Config, location string) map[string]interface{}
+}
+
+// ProviderRegistry maps widget keys to the named providers that can back them,
+// e.g. calendar: google|ics. The provider is picked with `provider:` under the widget in config.yaml.
+type ProviderRegistry struct {
+	providers map[string]map[string]WidgetProvider
+	defaults  map[string]string
+}
+
+// NewProviderRegistry creates an empty provider registry
+func NewProviderRegistry() *ProviderRegistry {
+	return &ProviderRegistry{
+		providers: make(map[string]map[string]WidgetProvider),
+		defaults:  make(map[string]string),
+	}
+}
+
+// Register adds a provider for a widget. The first provider registered for a widget is its default.
+func (pr *ProviderRegistry) Register(widget, name string, provider WidgetProvider) {
+	if pr.providers[widget] == nil {
+		pr.providers[widget] = make(map[string]WidgetProvider)
+		pr.defaults[widget] = name
+	}
+	pr.providers[widget][name] = provider
+}
+
+// Widgets returns the widget keys that have providers, sorted
+func (pr *ProviderRegistry) Widgets() []string {
+	widgets := make([]string, 0, len(pr.providers))
+	for widget := range pr.providers {
+		widgets = append(widgets, widget)
+	}
+	sort.Strings(widgets)
+	return widgets
+}
+
+// Providers returns the provider names registered for a widget, sorted
+func (pr *ProviderRegistry) Providers(widget string) []string {
+	names := make([]string, 0, len(pr.providers[widget]))
+	for name := range pr.providers[widget] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultProvider returns the provider used when a widget has no `provider:` set
+func (pr *ProviderRegistry) DefaultProvider(widget string) string {
+	return pr.defaults[widget]
+}
+
+// Create builds the plugin and plugin configuration for a widget's provider.
+// An empty name selects the widget's default provider.
+func (pr *ProviderRegistry) Create(widget, name string, cfg *Config, location string) (Plugin, map[string]interface{}, error) {
+	providers, ok := pr.providers[widget]
+	if !ok {
+		return nil, nil, fmt.Errorf("no providers registered for widget %q", widget)
+	}
+	if name == "" {
+		name = pr.defaults[widget]
+	}
+
+	provider, ok := providers[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown %s provider %q (available: %v)", widget, name, pr.Providers(widget))
+	}
+
+	plugin := provider.New()
+	var pluginConfig map[string]interface{}
+	if provider.Config != nil {
+		pluginConfig = provider.Config(cfg, location)
+	}
+	return plugin, pluginConfig, nil
+}
+
+// WidgetProviderName returns the provider configured for a widget, or "" for the default
+func (c *Config) WidgetProviderName(widget string) string {
+	if c == nil {
+		return ""
+	}
+	switch widget {
+	case "weather":
+		// Without a provider, an OpenWeatherMap key keeps OWM; otherwise wttr.in needs no key
+		if c.Widgets.Weather.Provider == "" && c.Widgets.Weather.APIKey != "" && c.Widgets.Weather.APIKey != "YOUR_OWM_API_KEY" {
+			return "openweathermap"
+		}
+		return c.Widgets.Weather.Provider
+	case "news":
+		return c.Widgets.News.Provider
+	case "traffic":
+		return c.Widgets.Traffic.Provider
+	case "calendar":
+		return c.Widgets.Calendar.Provider
+	case "teams":
+		return c.Widgets.Teams.Provider
+	case "discord":
+		return c.Widgets.Discord.Provider
+	case "discussions":
+		return c.Widgets.Discussions.Provider
+	case "mentions":
+		return c.Widgets.Mentions.Provider
+	case "stocks":
+		return c.Widgets.Stocks.Provider
+	case "crypto":
+		return c.Widgets.Crypto.Provider
+	case "fx":
+		return c.Widgets.FX.Provider
+	case "aqi":
+		return c.Widgets.AQI.Provider
+	case "daylight":
+		return c.Widgets.Daylight.Provider
+	case "pagerduty":
+		return c.Widgets.PagerDuty.Provider
+	case "alerts":
+		return c.Widgets.Alerts.Provider
+	case "status":
+		return c.Widgets.Status.Provider
+	case "uptime":
+		return c.Widgets.Uptime.Provider
+	case "certs":
+		return c.Widgets.Certs.Provider
+	case "domains":
+		return c.Widgets.Domains.Provider
+	case "trends":
+		return c.Widgets.Trends.Provider
+	case "releases":
+		return c.Widgets.Releases.Provider
+	case "vulns":
+		return c.Widgets.Vulns.Provider
+	case "system":
+		return c.Widgets.System.Provider
+	case "network":
+		return c.Widgets.Network.Provider
+	case "toggl":
+		return c.Widgets.Toggl.Provider
+	case "reminders":
+		return c.Widgets.Reminders.Provider
+	}
+	return ""
+}
+
+// defaultNewsTags are used for the news providers when no config file is found
+var defaultNewsTags = []string{"golang", "security", "ai"}
+
+// DefaultProviderRegistry returns a registry with every built-in widget provider
+func DefaultProviderRegistry() *ProviderRegistry {
+	registry := NewProviderRegistry()
+
+	// wttr.in is the default as it needs no key; an OWM key selects openweathermap
+	registry.Register("weather", "wttr", WidgetProvider{
+		New: func() Plugin { return NewWttrWeatherPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			return map[string]interface{}{"city": location}
+		},
+	})
+
+	registry.Register("weather", "open-meteo", WidgetProvider{
+		New: func() Plugin { return NewOpenMeteoWeatherPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			return map[string]interface{}{"city": location}
+		},
+	})
+
+	owm := WidgetProvider{
+		New: func() Plugin { return NewWeatherPlugin("", "") },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			apiKey := "YOUR_OWM_API_KEY"
+			if cfg != nil {
+				apiKey = cfg.Widgets.Weather.APIKey
+			}
+			weatherConfig := map[string]interface{}{
+				"api_key": apiKey,
+				"city":    location,
+			}
+			if cfg != nil && cfg.Widgets.Weather.DailyQuota > 0 {
+				weatherConfig["daily_quota"] = cfg.Widgets.Weather.DailyQuota
+			}
+			if cfg != nil && cfg.Widgets.Weather.ShowForecast {
+				weatherConfig["show_forecast"] = true
+			}
+			return weatherConfig
+		},
+	}
+	registry.Register("weather", "openweathermap", owm)
+	registry.Register("weather", "owm", owm)
+
+	newsConfig := func(cfg *Config, location string) map[string]interface{} {
+		tags := defaultNewsTags
+		var feeds []NewsFeed
+		var sources []string
+		var productHuntToken string
+		var arxivCategories []string
+		var securityKeywords []string
+		var nvdAPIKey string
+		if cfg != nil {
+			tags = cfg.Widgets.News.Tags
+			feeds = cfg.Widgets.News.Feeds
+			sources = cfg.Widgets.News.Sources
+			productHuntToken = cfg.Widgets.News.ProductHunt.Token
+			arxivCategories = cfg.Widgets.News.Arxiv.Categories
+			securityKeywords = cfg.Widgets.News.Security.Keywords
+			nvdAPIKey = cfg.Widgets.News.Security.NVDAPIKey
+		}
+		return map[string]interface{}{
+			"tags":              tags,
+			"current_tag":       "all",
+			"feeds":             feeds,
+			"sources":           sources,
+			"producthunt_token": productHuntToken,
+			"arxiv_categories":  arxivCategories,
+			"security_keywords": securityKeywords,
+			"nvd_api_key":       nvdAPIKey,
+		}
+	}
+	// Aggregate only tech-focused sources by default; Hacker News includes general news
+	// articles. Configured feeds are added, and contribute nothing when there are none.
+	// widgets.news.sources replaces the defaults, e.g. to add producthunt.
+	registry.Register("news", "aggregate", WidgetProvider{
+		New: func() Plugin {
+			return NewAggregateNewsPlugin([]NewsPlugin{NewHackernoonPlugin(), NewDevToPlugin(), NewRSSPlugin()})
+		},
+		Config: newsConfig,
+	})
+	registry.Register("news", "hn", WidgetProvider{
+		New:    func() Plugin { return NewHackerNewsPlugin() },
+		Config: newsConfig,
+	})
+	registry.Register("news", "devto", WidgetProvider{
+		New:    func() Plugin { return NewDevToPlugin() },
+		Config: newsConfig,
+	})
+	registry.Register("news", "hackernoon", WidgetProvider{
+		New:    func() Plugin { return NewHackernoonPlugin() },
+		Config: newsConfig,
+	})
+	registry.Register("news", "rss", WidgetProvider{
+		New:    func() Plugin { return NewRSSPlugin() },
+		Config: newsConfig,
+	})
+	registry.Register("news", "producthunt", WidgetProvider{
+		New:    func() Plugin { return NewProductHuntPlugin() },
+		Config: newsConfig,
+	})
+	registry.Register("news", "arxiv", WidgetProvider{
+		New:    func() Plugin { return NewArxivPlugin() },
+		Config: newsConfig,
+	})
+	registry.Register("news", "security", WidgetProvider{
+		New:    func() Plugin { return NewSecurityNewsPlugin() },
+		Config: newsConfig,
+	})
+	registry.Register("news", "mastodon", WidgetProvider{
+		New: func() Plugin { return NewMastodonPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			mastodonConfig := newsConfig(cfg, location)
+			if cfg != nil {
+				mastodonConfig["instance"] = cfg.Widgets.News.Mastodon.Instance
+				mastodonConfig["hashtags"] = cfg.Widgets.News.Mastodon.Hashtags
+				if cfg.Widgets.News.Mastodon.AccessToken != "" {
+					mastodonConfig["access_token"] = cfg.Widgets.News.Mastodon.AccessToken
+				}
+			}
+			return mastodonConfig
+		},
+	})
+
+	registry.Register("news", "stackoverflow", WidgetProvider{
+		New: func() Plugin { return NewStackOverflowPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			soConfig := newsConfig(cfg, location)
+			if cfg != nil {
+				so := cfg.Widgets.News.StackOverflow
+				soConfig["site"] = so.Site
+				soConfig["question_tags"] = so.Tags
+				soConfig["sort"] = so.Sort
+				soConfig["key"] = so.Key
+				if so.AccessToken != "" {
+					soConfig["access_token"] = so.AccessToken
+				}
+			}
+			return soConfig
+		},
+	})
+
+	// OSRM needs no API key
+	registry.Register("traffic", "osrm", WidgetProvider{
+		New: func() Plugin { return NewOSRMTrafficPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{
+					"origin":      "Electronic City, Bengaluru, Karnataka, India",
+					"destination": "Whitefield, Bengaluru, Karnataka, India",
+				}
+			}
+			return map[string]interface{}{
+				"origin":      cfg.Widgets.Traffic.Origin,
+				"destination": cfg.Widgets.Traffic.Destination,
+			}
+		},
+	})
+
+	// Google Maps durations account for live traffic, but need a Maps API key
+	registry.Register("traffic", "googlemaps", WidgetProvider{
+		New: func() Plugin { return NewGoogleMapsTrafficPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			return map[string]interface{}{
+				"api_key":     cfg.Widgets.Traffic.APIKey,
+				"origin":      cfg.Widgets.Traffic.Origin,
+				"destination": cfg.Widgets.Traffic.Destination,
+			}
+		},
+	})
+
+	registry.Register("calendar", "google", WidgetProvider{
+		New: func() Plugin { return NewGoogleCalendarPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{
+					"max_events": 10,
+					"days_ahead": 7,
+				}
+			}
+			calendarConfig := map[string]interface{}{
+				"max_events": cfg.Widgets.Calendar.MaxEvents,
+				"days_ahead": cfg.Widgets.Calendar.DaysAhead,
+			}
+			if len(cfg.Widgets.Calendar.Calendars) > 0 {
+				calendarConfig["calendars"] = cfg.Widgets.Calendar.Calendars
+			}
+			// Add credentials_file and token_file if provided in config
+			if cfg.Widgets.Calendar.CredentialsFile != "" {
+				calendarConfig["credentials_file"] = cfg.Widgets.Calendar.CredentialsFile
+			}
+			if cfg.Widgets.Calendar.TokenFile != "" {
+				calendarConfig["token_file"] = cfg.Widgets.Calendar.TokenFile
+			}
+			return calendarConfig
+		},
+	})
+	registry.Register("calendar", "ics", WidgetProvider{
+		New: func() Plugin { return NewICSCalendarPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			return map[string]interface{}{
+				"sources":    cfg.Widgets.Calendar.ICS,
+				"max_events": cfg.Widgets.Calendar.MaxEvents,
+				"days_ahead": cfg.Widgets.Calendar.DaysAhead,
+			}
+		},
+	})
+
+	registry.Register("teams", "graph", WidgetProvider{
+		New: func() Plugin { return NewTeamsPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			teamsConfig := map[string]interface{}{
+				"channels": cfg.Widgets.Teams.Channels,
+				"lookback": cfg.Widgets.Teams.Lookback,
+			}
+			// Leave the token unset so the plugin falls back to MS_GRAPH_TOKEN
+			if cfg.Widgets.Teams.AccessToken != "" {
+				teamsConfig["access_token"] = cfg.Widgets.Teams.AccessToken
+			}
+			return teamsConfig
+		},
+	})
+
+	registry.Register("discord", "bot", WidgetProvider{
+		New: func() Plugin { return NewDiscordPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			discordConfig := map[string]interface{}{
+				"channels": cfg.Widgets.Discord.Channels,
+				"user_id":  cfg.Widgets.Discord.UserID,
+				"lookback": cfg.Widgets.Discord.Lookback,
+			}
+			// Leave the token unset so the plugin falls back to DISCORD_BOT_TOKEN
+			if cfg.Widgets.Discord.BotToken != "" {
+				discordConfig["bot_token"] = cfg.Widgets.Discord.BotToken
+			}
+			return discordConfig
+		},
+	})
+
+	registry.Register("discussions", "github", WidgetProvider{
+		New: func() Plugin { return NewGitHubDiscussionsPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			// Shares the token and orgs of the PR widget
+			discussionsConfig := map[string]interface{}{
+				"repos": cfg.Widgets.Discussions.Repos,
+				"orgs":  cfg.Plugins["github-prs"]["orgs"],
+			}
+			if token, ok := cfg.Plugins["github-prs"]["github_token"].(string); ok && token != "" {
+				discussionsConfig["github_token"] = token
+			}
+			return discussionsConfig
+		},
+	})
+
+	registry.Register("stocks", "finnhub", WidgetProvider{
+		New: func() Plugin { return NewStocksPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			stocksConfig := map[string]interface{}{
+				"symbols": cfg.Widgets.Stocks.Symbols,
+			}
+			// Leave the key unset so the plugin falls back to FINNHUB_API_KEY
+			if cfg.Widgets.Stocks.APIKey != "" {
+				stocksConfig["api_key"] = cfg.Widgets.Stocks.APIKey
+			}
+			return stocksConfig
+		},
+	})
+
+	registry.Register("pagerduty", "opsgenie", WidgetProvider{
+		New: func() Plugin { return NewOpsgeniePlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			opsgenieConfig := map[string]interface{}{
+				"region":    cfg.Widgets.PagerDuty.Region,
+				"schedules": cfg.Widgets.PagerDuty.Schedules,
+			}
+			// Leave the key unset so the plugin falls back to OPSGENIE_API_KEY
+			if cfg.Widgets.PagerDuty.APIKey != "" {
+				opsgenieConfig["api_key"] = cfg.Widgets.PagerDuty.APIKey
+			}
+			return opsgenieConfig
+		},
+	})
+
+	registry.Register("pagerduty", "victorops", WidgetProvider{
+		New: func() Plugin { return NewVictorOpsPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			// Unset credentials fall back to VICTOROPS_API_ID and VICTOROPS_API_KEY
+			return map[string]interface{}{
+				"api_id":       cfg.Widgets.PagerDuty.APIID,
+				"api_key":      cfg.Widgets.PagerDuty.APIKey,
+				"organization": cfg.Widgets.PagerDuty.Organization,
+				"teams":        cfg.Widgets.PagerDuty.Schedules,
+			}
+		},
+	})
+
+	registry.Register("alerts", "alertmanager", WidgetProvider{
+		New: func() Plugin { return NewAlertmanagerPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			alertsConfig := map[string]interface{}{
+				"url":     cfg.Widgets.Alerts.URL,
+				"filters": cfg.Widgets.Alerts.Filters,
+			}
+			// Leave the token unset so the plugin falls back to ALERTMANAGER_TOKEN
+			if cfg.Widgets.Alerts.Token != "" {
+				alertsConfig["token"] = cfg.Widgets.Alerts.Token
+			}
+			return alertsConfig
+		},
+	})
+
+	registry.Register("status", "statuspage", WidgetProvider{
+		New: func() Plugin { return NewStatusPagePlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			return map[string]interface{}{
+				"services": cfg.Widgets.Status.Services,
+				"pages":    cfg.Widgets.Status.Pages,
+			}
+		},
+	})
+
+	registry.Register("uptime", "http", WidgetProvider{
+		New: func() Plugin { return NewUptimePlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			return map[string]interface{}{
+				"checks":  cfg.Widgets.Uptime.Checks,
+				"history": cfg.Widgets.Uptime.History,
+			}
+		},
+	})
+
+	registry.Register("certs", "tls", WidgetProvider{
+		New: func() Plugin { return NewCertPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			return map[string]interface{}{
+				"hosts":         cfg.Widgets.Certs.Hosts,
+				"warn_days":     cfg.Widgets.Certs.WarnDays,
+				"critical_days": cfg.Widgets.Certs.CriticalDays,
+			}
+		},
+	})
+
+	registry.Register("domains", "rdap", WidgetProvider{
+		New: func() Plugin { return NewDomainPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			return map[string]interface{}{
+				"domains":       cfg.Widgets.Domains.Domains,
+				"warn_days":     cfg.Widgets.Domains.WarnDays,
+				"critical_days": cfg.Widgets.Domains.CriticalDays,
+				"whois":         cfg.FeatureEnabled(FeatureWHOIS),
+			}
+		},
+	})
+
+	registry.Register("trends", "github", WidgetProvider{
+		New: func() Plugin { return NewTrendsPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			trendsConfig := map[string]interface{}{
+				"weeks": cfg.Widgets.Trends.Weeks,
+			}
+			if token, ok := cfg.Plugins["github-prs"]["github_token"].(string); ok && token != "" {
+				trendsConfig["github_token"] = token
+			}
+			return trendsConfig
+		},
+	})
+
+	registry.Register("releases", "github", WidgetProvider{
+		New: func() Plugin { return NewReleasesPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			releasesConfig := map[string]interface{}{
+				"repos":       cfg.Widgets.Releases.Repos,
+				"prereleases": cfg.Widgets.Releases.Prereleases,
+			}
+			if token, ok := cfg.Plugins["github-prs"]["github_token"].(string); ok && token != "" {
+				releasesConfig["github_token"] = token
+			}
+			return releasesConfig
+		},
+	})
+
+	registry.Register("vulns", "govulncheck", WidgetProvider{
+		New: func() Plugin { return NewVulnsPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			return map[string]interface{}{
+				"modules": cfg.Widgets.Vulns.Modules,
+			}
+		},
+	})
+
+	registry.Register("system", "local", WidgetProvider{
+		New: func() Plugin { return NewSystemPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			return map[string]interface{}{
+				"disks":            cfg.Widgets.System.Disks,
+				"top":              cfg.Widgets.System.Top,
+				"warn_percent":     cfg.Widgets.System.WarnPercent,
+				"critical_percent": cfg.Widgets.System.CriticalPercent,
+			}
+		},
+	})
+
+	registry.Register("network", "tcp", WidgetProvider{
+		New: func() Plugin { return NewNetworkPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			return map[string]interface{}{
+				"anchors":        cfg.Widgets.Network.Anchors,
+				"warn_ms":        cfg.Widgets.Network.WarnMS,
+				"critical_ms":    cfg.Widgets.Network.CriticalMS,
+				"speed_test":     cfg.Widgets.Network.SpeedTest,
+				"speed_test_url": cfg.Widgets.Network.SpeedTestURL,
+			}
+		},
+	})
+
+	registry.Register("toggl", "toggl", WidgetProvider{
+		New: func() Plugin { return NewTogglPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			togglConfig := map[string]interface{}{
+				"workspace_id":  cfg.Widgets.Toggl.WorkspaceID,
+				"project":       cfg.Widgets.Toggl.Project,
+				"description":   cfg.Widgets.Toggl.Description,
+				"dry_run":       cfg.Safety.DryRun,
+				"write_actions": cfg.FeatureEnabled(FeatureWriteActions),
+			}
+			// Leave the token unset so the plugin falls back to TOGGL_API_TOKEN
+			if cfg.Widgets.Toggl.APIToken != "" {
+				togglConfig["api_token"] = cfg.Widgets.Toggl.APIToken
+			}
+			return togglConfig
+		},
+	})
+
+	registry.Register("toggl", "harvest", WidgetProvider{
+		New: func() Plugin { return NewHarvestPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			// Unset credentials fall back to HARVEST_ACCESS_TOKEN and HARVEST_ACCOUNT_ID
+			return map[string]interface{}{
+				"api_token":     cfg.Widgets.Toggl.APIToken,
+				"account_id":    cfg.Widgets.Toggl.AccountID,
+				"project":       cfg.Widgets.Toggl.Project,
+				"task":          cfg.Widgets.Toggl.Task,
+				"description":   cfg.Widgets.Toggl.Description,
+				"target":        cfg.Widgets.Toggl.Target,
+				"dry_run":       cfg.Safety.DryRun,
+				"write_actions": cfg.FeatureEnabled(FeatureWriteActions),
+			}
+		},
+	})
+
+	registry.Register("reminders", "file", WidgetProvider{
+		New: func() Plugin { return NewRemindersPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			return map[string]interface{}{
+				"path": RemindersPath(cfg),
+				"days": cfg.Widgets.Reminders.Days,
+			}
+		},
+	})
+
+	registry.Register("crypto", "coingecko", WidgetProvider{
+		New: func() Plugin { return NewCryptoPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			return map[string]interface{}{
+				"coins":    cfg.Widgets.Crypto.Coins,
+				"currency": cfg.Widgets.Crypto.Currency,
+			}
+		},
+	})
+
+	registry.Register("fx", "frankfurter", WidgetProvider{
+		New: func() Plugin { return NewFXPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			return map[string]interface{}{"pairs": cfg.Widgets.FX.Pairs}
+		},
+	})
+
+	registry.Register("aqi", "waqi", WidgetProvider{
+		New: func() Plugin { return NewWAQIAirQualityPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			aqiConfig := map[string]interface{}{"city": location}
+			// Leave the token unset so the plugin falls back to WAQI_TOKEN
+			if cfg != nil && cfg.Widgets.AQI.Token != "" {
+				aqiConfig["token"] = cfg.Widgets.AQI.Token
+			}
+			if cfg != nil && cfg.Widgets.AQI.Station != "" {
+				aqiConfig["station"] = cfg.Widgets.AQI.Station
+			}
+			return aqiConfig
+		},
+	})
+
+	registry.Register("aqi", "openweathermap", WidgetProvider{
+		New: func() Plugin { return NewOWMAirQualityPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			// Shares the key of the weather widget
+			apiKey := "YOUR_OWM_API_KEY"
+			if cfg != nil {
+				apiKey = cfg.Widgets.Weather.APIKey
+			}
+			return map[string]interface{}{"api_key": apiKey, "city": location}
+		},
+	})
+
+	registry.Register("daylight", "sunrise-sunset", WidgetProvider{
+		New: func() Plugin { return NewSunriseSunsetPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			return map[string]interface{}{"city": location}
+		},
+	})
+
+	registry.Register("mentions", "unified", WidgetProvider{
+		New: func() Plugin { return NewMentionsPlugin() },
+		Config: func(cfg *Config, location string) map[string]interface{} {
+			if cfg == nil {
+				return map[string]interface{}{}
+			}
+			// Empty tokens leave the plugin's environment fallbacks in place
+			mentionsConfig := map[string]interface{}{
+				"sources":     cfg.Widgets.Mentions.Sources,
+				"slack_token": cfg.Widgets.Slack.Token,
+				"gmail_token": cfg.Widgets.Mentions.GmailToken,
+				"email_query": cfg.Widgets.Mentions.EmailQuery,
+				"jira_url":    cfg.Widgets.Jira.BaseURL,
+				"jira_email":  cfg.Widgets.Jira.Email,
+				"jira_token":  cfg.Widgets.Jira.APIToken,
+				"jira_jql":    cfg.Widgets.Mentions.JiraJQL,
+			}
+			if token, ok := cfg.Plugins["github-prs"]["github_token"].(string); ok && token != "" {
+				mentionsConfig["github_token"] = token
+			}
+			return mentionsConfig
+		},
+	})
+
+	return registry
+}
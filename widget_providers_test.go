@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestProviderRegistryCreate(t *testing.T) {
+	registry := DefaultProviderRegistry()
+
+	plugin, _, err := registry.Create("calendar", "", nil, "Bengaluru,IN")
+	if err != nil {
+		t.Fatalf("Create with default provider failed: %v", err)
+	}
+	if plugin.GetID() != "google-calendar" {
+		t.Errorf("Expected default calendar plugin 'google-calendar', got '%s'", plugin.GetID())
+	}
+
+	cfg := &Config{}
+	cfg.Widgets.Calendar.Provider = "ics"
+	cfg.Widgets.Calendar.ICS = []string{"~/calendar.ics"}
+	plugin, config, err := registry.Create("calendar", cfg.WidgetProviderName("calendar"), cfg, "Bengaluru,IN")
+	if err != nil {
+		t.Fatalf("Create with ics provider failed: %v", err)
+	}
+	if plugin.GetID() != "ics-calendar" {
+		t.Errorf("Expected 'ics-calendar' plugin, got '%s'", plugin.GetID())
+	}
+	if sources := configStringList(config["sources"]); len(sources) != 1 {
+		t.Errorf("Expected ICS sources to be passed through, got %v", config["sources"])
+	}
+
+	if _, _, err := registry.Create("calendar", "outlook", cfg, ""); err == nil {
+		t.Error("Expected an unknown provider to fail")
+	}
+	if _, _, err := registry.Create("unknown", "", cfg, ""); err == nil {
+		t.Error("Expected a widget without providers to fail")
+	}
+}
+
+func TestProviderEnumsMatchRegistry(t *testing.T) {
+	registry := DefaultProviderRegistry()
+	widgets := GenerateConfigSchema().Properties["widgets"]
+
+	for _, widget := range registry.Widgets() {
+		section := widgets.Properties[widget]
+		if section == nil || section.Properties["provider"] == nil {
+			t.Errorf("Expected widgets.%s.provider in config", widget)
+			continue
+		}
+
+		enum := append([]string(nil), section.Properties["provider"].Enum...)
+		sort.Strings(enum)
+		if !reflect.DeepEqual(enum, registry.Providers(widget)) {
+			t.Errorf("Expected widgets.%s.provider enum %v to match registered providers %v", widget, enum, registry.Providers(widget))
+		}
+	}
+}
@@ -21,6 +21,9 @@ type WidgetItem struct {
 	Status     string
 	URL        string
 	HasWorkLog bool
+	Details    []string     // more about the item for the detail view; any after the first blank one, such as a description, are markdown
+	Since      time.Time    // when the item happened
+	Age        *SubtitleAge // where Subtitle shows Since, so the relative time keeps up; set by withAge
 }
 
 // WidgetManager manages all widgets
@@ -28,8 +31,15 @@ type WidgetManager struct {
 	Widgets      map[string]*Widget
 	NewsTagIndex int
 	NewsTags     []string
+	// GroupBotPRs collects bot-authored PRs under a collapsible header in the PR widget
+	GroupBotPRs    bool
+	botPRsExpanded bool
+	lastPRs        []GitPullRequest
 }
 
+// botPRsGroupTitle starts the header item of the bots section in the PR widget
+const botPRsGroupTitle = "🤖 Bots"
+
 func NewWidgetManager() *WidgetManager {
 	return &WidgetManager{
 		Widgets:      make(map[string]*Widget),
@@ -38,6 +48,8 @@ func NewWidgetManager() *WidgetManager {
 }
 
 func (wm *WidgetManager) InitializeWidgets(cfg *Config) {
+	wm.GroupBotPRs = cfg != nil && cfg.Widgets.PRs.Bots == "group"
+
 	// Initialize all widgets with placeholder data exactly as per design
 	wm.Widgets["jira"] = &Widget{
 		Title: "JIRA",
@@ -97,6 +109,78 @@ func (wm *WidgetManager) InitializeWidgets(cfg *Config) {
 		},
 	}
 
+	wm.Widgets["teams"] = &Widget{
+		Title: "Teams",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Teams...", Subtitle: "Checking chats for mentions", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["discord"] = &Widget{
+		Title: "Discord",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Discord...", Subtitle: "Reading configured channels", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["discussions"] = &Widget{
+		Title: "Discussions",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Discussions...", Subtitle: "Looking for unanswered questions", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["mentions"] = &Widget{
+		Title: "Mentions",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Mentions...", Subtitle: "Checking GitHub, Jira, Slack and email", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["stocks"] = &Widget{
+		Title: "Stocks",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Stocks...", Subtitle: "Fetching quotes", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["crypto"] = &Widget{
+		Title: "Crypto",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Crypto...", Subtitle: "Fetching prices", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["fx"] = &Widget{
+		Title: "Exchange Rates",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Exchange Rates...", Subtitle: "Fetching rates", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["aqi"] = &Widget{
+		Title: "Air Quality",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Air Quality...", Subtitle: "Fetching AQI", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["weather"] = &Widget{
+		Title: "Weather",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Forecast...", Subtitle: "Fetching weather", Status: "", URL: ""},
+		},
+	}
+
 	wm.Widgets["todos"] = &Widget{
 		Title: "Todos",
 		Count: 5,
@@ -122,6 +206,118 @@ func (wm *WidgetManager) InitializeWidgets(cfg *Config) {
 		Items: []WidgetItem{},
 	}
 
+	wm.Widgets["alerts"] = &Widget{
+		Title: "Alerts",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Alerts...", Subtitle: "Fetching firing alerts", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["status"] = &Widget{
+		Title: "Service Status",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Service Status...", Subtitle: "Reading status pages", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["uptime"] = &Widget{
+		Title: "Uptime",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Uptime...", Subtitle: "Checking endpoints", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["certs"] = &Widget{
+		Title: "Certificates",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Certificates...", Subtitle: "Checking expiry dates", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["domains"] = &Widget{
+		Title: "Domains",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Domains...", Subtitle: "Looking up renewal dates", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["trends"] = &Widget{
+		Title: "Trends",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Trends...", Subtitle: "Counting reviews and issues", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["releases"] = &Widget{
+		Title: "Releases",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Releases...", Subtitle: "Checking watched repositories", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["vulns"] = &Widget{
+		Title: "Go Vulnerabilities",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Go Vulnerabilities...", Subtitle: "Scanning modules with govulncheck", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["system"] = &Widget{
+		Title: "System",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading System...", Subtitle: "Reading CPU, memory and disks", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["network"] = &Widget{
+		Title: "Network",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Network...", Subtitle: "Measuring latency", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["toggl"] = &Widget{
+		Title: "Time Tracking",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Time Tracking...", Subtitle: "Fetching the running timer", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["notes"] = &Widget{
+		Title: "Notes",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "No notes yet", Subtitle: "Press n to jot one down", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["reminders"] = &Widget{
+		Title: "Reminders",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "Loading Reminders...", Subtitle: "Reading upcoming dates", Status: "", URL: ""},
+		},
+	}
+
+	wm.Widgets["pomodoro"] = &Widget{
+		Title: "Pomodoro",
+		Count: 0,
+		Items: []WidgetItem{
+			{Title: "No pomodoro running", Subtitle: "Press f to start one", Status: "", URL: ""},
+		},
+	}
+
 	// Initialize Tech News widget
 	if cfg != nil && len(cfg.Widgets.News.Tags) > 0 {
 		wm.NewsTags = cfg.Widgets.News.Tags
@@ -291,15 +487,20 @@ func (wm *WidgetManager) UpdateGitCommitsWidget(commits []GitCommit) {
 	var items []WidgetItem
 
 	for _, commit := range commits {
-		// Format the time as relative time
-		timeAgo := formatTimeAgo(commit.Date)
+		details := []string{fmt.Sprintf("%s by %s", commit.Hash, commit.Author), commit.Date.Local().Format("Mon 2 Jan 2006 15:04")}
+		if commit.Body != "" {
+			details = append(details, "", commit.Body)
+		}
 
-		items = append(items, WidgetItem{
-			Title:    commit.Message,
-			Subtitle: fmt.Sprintf("%s • %s", timeAgo, commit.Repository),
-			Status:   "",
-			URL:      "", // Could be enhanced with GitHub URL if available
-		})
+		item := WidgetItem{
+			Title:   commit.Message,
+			Status:  "",
+			URL:     "", // Could be enhanced with GitHub URL if available
+			Details: details,
+			Since:   commit.Date,
+		}
+		// Relative time first, then the repository
+		items = append(items, item.withAge(time.Now(), "", false, " • "+commit.Repository))
 	}
 
 	if wm.Widgets["commits"] != nil {
@@ -310,36 +511,83 @@ func (wm *WidgetManager) UpdateGitCommitsWidget(commits []GitCommit) {
 
 // UpdateGitHubPRsWidget updates the PRs widget with data from GitHub API
 func (wm *WidgetManager) UpdateGitHubPRsWidget(prs []GitPullRequest) {
-	var items []WidgetItem
+	wm.lastPRs = prs
 
+	var items []WidgetItem
+	var bots []GitPullRequest
 	for _, pr := range prs {
-		// Format status based on PR state and draft status
-		status := "🟢" // open
-		if pr.IsDraft {
-			status = "🟡" // draft
-		}
-		if pr.State == "closed" {
-			status = "🔴" // closed
+		if wm.GroupBotPRs && pr.IsBot {
+			bots = append(bots, pr)
+			continue
 		}
+		items = append(items, formatPRItem(pr))
+	}
 
-		// Format subtitle with repository and update time
-		timeAgo := formatTimeAgo(pr.UpdatedAt)
-		subtitle := fmt.Sprintf("%s • %s", pr.Repository, timeAgo)
-
-		items = append(items, WidgetItem{
-			Title:    pr.Title,
-			Subtitle: subtitle,
-			Status:   status,
-			URL:      pr.URL,
-		})
+	if len(bots) > 0 {
+		header := WidgetItem{
+			Title:    fmt.Sprintf("%s (%d)", botPRsGroupTitle, len(bots)),
+			Subtitle: "Enter to expand",
+			Status:   "▸",
+		}
+		if wm.botPRsExpanded {
+			header.Subtitle = "Enter to collapse"
+			header.Status = "▾"
+		}
+		items = append(items, header)
+		if wm.botPRsExpanded {
+			for _, pr := range bots {
+				item := formatPRItem(pr)
+				item.Title = "  " + item.Title
+				items = append(items, item)
+			}
+		}
 	}
 
 	if wm.Widgets["prs"] != nil {
 		wm.Widgets["prs"].Items = items
-		wm.Widgets["prs"].Count = len(items)
+		wm.Widgets["prs"].Count = len(prs)
 	}
 }
 
+// ToggleBotPRs expands or collapses the bots section of the PR widget
+func (wm *WidgetManager) ToggleBotPRs() {
+	wm.botPRsExpanded = !wm.botPRsExpanded
+	wm.UpdateGitHubPRsWidget(wm.lastPRs)
+}
+
+// formatPRItem formats a pull request for the PR widget
+func formatPRItem(pr GitPullRequest) WidgetItem {
+	// Format status based on PR state and draft status
+	status := "🟢" // open
+	if pr.IsDraft {
+		status = "🟡" // draft
+	}
+	if pr.State == "closed" {
+		status = "🔴" // closed
+	}
+
+	details := []string{
+		fmt.Sprintf("%s#%d by %s", pr.Repository, pr.Number, pr.Author),
+		fmt.Sprintf("Opened %s • updated %s", pr.CreatedAt.Local().Format("Mon 2 Jan 2006 15:04"), pr.UpdatedAt.Local().Format("Mon 2 Jan 2006 15:04")),
+	}
+	if len(pr.Labels) > 0 {
+		details = append(details, "Labels: "+strings.Join(pr.Labels, ", "))
+	}
+	if body := strings.TrimSpace(pr.Body); body != "" {
+		details = append(details, "", body)
+	}
+
+	// Subtitle with repository and update time
+	item := WidgetItem{
+		Title:   pr.Title,
+		Status:  status,
+		URL:     pr.URL,
+		Details: details,
+		Since:   pr.UpdatedAt,
+	}
+	return item.withAge(time.Now(), pr.Repository+" • ", false, "")
+}
+
 // UpdateTrafficWidget updates the traffic widget with route information
 func (wm *WidgetManager) UpdateTrafficWidget(traffic *TrafficData) {
 	if wm.Widgets["traffic"] == nil {
@@ -446,8 +694,1003 @@ func (wm *WidgetManager) UpdateBiDirectionalTrafficWidget(biTraffic *BiDirection
 	wm.Widgets["traffic"].HasError = false
 }
 
-// UpdateCalendarWidget updates the calendar widget with Google Calendar data
-func (wm *WidgetManager) UpdateCalendarWidget(calendarPlugin *GoogleCalendarPlugin) {
+// ChatActivity summarizes unread mentions in one chat, channel or conversation
+type ChatActivity struct {
+	Channel  string
+	From     string // sender of the most recent mention
+	Preview  string // latest message, for chat-style tiles
+	Mentions int
+	Unread   int
+	URL      string
+	Updated  time.Time
+}
+
+// UpdateChatWidget updates a chat widget such as Teams or Discord with channels that mention me
+func (wm *WidgetManager) UpdateChatWidget(key string, activity []ChatActivity) {
+	var items []WidgetItem
+	mentions := 0
+
+	for _, entry := range activity {
+		mentions += entry.Mentions
+
+		var parts []string
+		if entry.Mentions == 1 {
+			parts = append(parts, "1 mention")
+		} else if entry.Mentions > 1 {
+			parts = append(parts, fmt.Sprintf("%d mentions", entry.Mentions))
+		}
+		if entry.Preview != "" {
+			parts = append(parts, entry.Preview)
+		} else if entry.From != "" {
+			parts = append(parts, entry.From)
+		}
+		subtitle := strings.Join(parts, " • ")
+
+		status := "💬"
+		if entry.Mentions > 0 {
+			status = "🔴"
+		}
+
+		item := WidgetItem{
+			Title:    entry.Channel,
+			Subtitle: subtitle,
+			Status:   status,
+			URL:      entry.URL,
+			Since:    entry.Updated,
+		}
+		if !entry.Updated.IsZero() {
+			if subtitle != "" {
+				subtitle += " • "
+			}
+			item = item.withAge(time.Now(), subtitle, false, "")
+		}
+		items = append(items, item)
+	}
+
+	if len(items) == 0 {
+		items = []WidgetItem{{Title: "No new messages", Subtitle: "You're all caught up", Status: "✅"}}
+	}
+
+	if wm.Widgets[key] == nil {
+		wm.Widgets[key] = &Widget{Title: key}
+	}
+	wm.Widgets[key].Items = items
+	wm.Widgets[key].Count = mentions
+	wm.Widgets[key].HasError = false
+}
+
+// UpdateDiscussionsWidget updates the discussions widget with unanswered questions, oldest first
+func (wm *WidgetManager) UpdateDiscussionsWidget(discussions []Discussion) {
+	var items []WidgetItem
+	for _, d := range discussions {
+		comments := ""
+		if d.Comments == 1 {
+			comments = " • 1 comment"
+		} else if d.Comments > 1 {
+			comments = fmt.Sprintf(" • %d comments", d.Comments)
+		}
+
+		// Questions nobody has replied to at all are the easiest to miss
+		status := "❓"
+		if d.Comments == 0 {
+			status = "🔴"
+		}
+
+		item := WidgetItem{
+			Title:  d.Title,
+			Status: status,
+			URL:    d.URL,
+			Since:  d.CreatedAt,
+		}
+		items = append(items, item.withAge(time.Now(), d.Repository+" • waiting ", true, comments))
+	}
+
+	if len(items) == 0 {
+		items = []WidgetItem{{Title: "No unanswered questions", Subtitle: "Every Q&A discussion has an answer", Status: "✅"}}
+	}
+
+	if wm.Widgets["discussions"] == nil {
+		wm.Widgets["discussions"] = &Widget{Title: "Discussions"}
+	}
+	wm.Widgets["discussions"].Items = items
+	wm.Widgets["discussions"].Count = len(discussions)
+	wm.Widgets["discussions"].HasError = false
+}
+
+// mentionIcons mark which system a mention came from
+var mentionIcons = map[string]string{
+	"github": "🐙",
+	"jira":   "🎫",
+	"slack":  "💬",
+	"email":  "✉️",
+}
+
+// UpdateMentionsWidget updates the mentions widget with mentions from every source, newest first
+func (wm *WidgetManager) UpdateMentionsWidget(mentions []Mention) {
+	var items []WidgetItem
+	for _, mention := range mentions {
+		item := WidgetItem{
+			Title:  mention.Title,
+			Status: mentionIcons[mention.Source],
+			URL:    mention.URL,
+			Since:  mention.Updated,
+		}
+		items = append(items, item.withAge(time.Now(), mention.From+" • ", true, " ago"))
+	}
+
+	if len(items) == 0 {
+		items = []WidgetItem{{Title: "No mentions", Subtitle: "Nobody needs you right now", Status: "✅"}}
+	}
+
+	if wm.Widgets["mentions"] == nil {
+		wm.Widgets["mentions"] = &Widget{Title: "Mentions"}
+	}
+	wm.Widgets["mentions"].Items = items
+	wm.Widgets["mentions"].Count = len(mentions)
+	wm.Widgets["mentions"].HasError = false
+}
+
+// UpdateStocksWidget updates the stocks widget with a quote per symbol
+func (wm *WidgetManager) UpdateStocksWidget(quotes []StockQuote) {
+	var items []WidgetItem
+	for _, quote := range quotes {
+		subtitle := fmt.Sprintf("%+.2f (%+.2f%%)", quote.Change, quote.ChangePercent)
+		if !quote.MarketOpen {
+			subtitle += " • market closed"
+		}
+
+		status := "⚪"
+		if quote.Change > 0 {
+			status = "🟢"
+		} else if quote.Change < 0 {
+			status = "🔴"
+		}
+
+		items = append(items, WidgetItem{
+			Title:    fmt.Sprintf("%s  %.2f", quote.Symbol, quote.Price),
+			Subtitle: subtitle,
+			Status:   status,
+			URL:      "https://finance.yahoo.com/quote/" + quote.Symbol,
+		})
+	}
+
+	if wm.Widgets["stocks"] == nil {
+		wm.Widgets["stocks"] = &Widget{Title: "Stocks"}
+	}
+	wm.Widgets["stocks"].Items = items
+	wm.Widgets["stocks"].Count = len(quotes)
+	wm.Widgets["stocks"].HasError = false
+}
+
+// UpdateOnCallWidget updates the PagerDuty tile with who is on call per schedule, then
+// the alerts or incidents, most urgent first; the count leaves out resolved ones
+func (wm *WidgetManager) UpdateOnCallWidget(status *OnCallStatus) {
+	var items []WidgetItem
+	for _, shift := range status.OnCall {
+		onCall := "nobody"
+		if len(shift.Recipients) > 0 {
+			onCall = strings.Join(shift.Recipients, ", ")
+		}
+		items = append(items, WidgetItem{
+			Title:    shift.Schedule,
+			Subtitle: "On call: " + onCall,
+			Status:   "📟",
+		})
+	}
+
+	openAlerts := 0
+	for _, alert := range status.Alerts {
+		if !alert.Resolved {
+			openAlerts++
+		}
+		state, owner := "", ""
+		if alert.Resolved {
+			state = "resolved • "
+		} else if alert.Acknowledged {
+			state = "acked • "
+		}
+		if alert.Owner != "" {
+			owner = " • " + alert.Owner
+		}
+
+		statusIcon := "⚪"
+		switch {
+		case alert.Resolved:
+			statusIcon = "✅"
+		case alert.Priority == "P1", alert.Priority == "P2":
+			statusIcon = "🔴"
+		case alert.Priority == "P3":
+			statusIcon = "🟠"
+		case alert.Priority == "" && !alert.Acknowledged:
+			// Without priorities, anything nobody has acknowledged yet is urgent
+			statusIcon = "🔴"
+		}
+
+		title := fmt.Sprintf("#%s %s", alert.TinyID, alert.Message)
+		if alert.Priority != "" {
+			title = alert.Priority + " " + title
+		}
+		item := WidgetItem{
+			Title:  title,
+			Status: statusIcon,
+			URL:    alert.URL,
+			Since:  alert.CreatedAt,
+		}
+		items = append(items, item.withAge(time.Now(), state, true, " ago"+owner))
+	}
+
+	if wm.Widgets["pagerduty"] == nil {
+		wm.Widgets["pagerduty"] = &Widget{Title: "PagerDuty"}
+	}
+	wm.Widgets["pagerduty"].Items = items
+	wm.Widgets["pagerduty"].Count = openAlerts
+	wm.Widgets["pagerduty"].HasError = false
+}
+
+// UpdateAlertsWidget updates the alerts widget with a line per alertname, most severe
+// first; Enter opens the group in the Alertmanager UI. The count is of firing alerts
+func (wm *WidgetManager) UpdateAlertsWidget(groups []AlertGroup) {
+	items := []WidgetItem{}
+	firing := 0
+	for _, group := range groups {
+		firing += group.Count
+
+		statusIcon := "⚪"
+		switch severityRank(group.Severity) {
+		case 0:
+			statusIcon = "🔴"
+		case 1:
+			statusIcon = "🟠"
+		case 2:
+			statusIcon = "🟡"
+		}
+
+		before, after := "for ", ""
+		if group.Severity != "" {
+			before = group.Severity + " • " + before
+		}
+		if group.Summary != "" {
+			after = " • " + group.Summary
+		}
+		item := WidgetItem{
+			Title:  fmt.Sprintf("%s ×%d", group.Name, group.Count),
+			Status: statusIcon,
+			URL:    group.URL,
+			Since:  group.Since,
+		}
+		items = append(items, item.withAge(time.Now(), before, true, after))
+	}
+	if len(items) == 0 {
+		items = append(items, WidgetItem{Title: "No alerts firing", Subtitle: "All quiet", Status: "✅"})
+	}
+
+	if wm.Widgets["alerts"] == nil {
+		wm.Widgets["alerts"] = &Widget{Title: "Alerts"}
+	}
+	wm.Widgets["alerts"].Items = items
+	wm.Widgets["alerts"].Count = firing
+	wm.Widgets["alerts"].HasError = false
+}
+
+// UpdateStatusPagesWidget updates the service status widget with a line per status page,
+// problems first; Enter opens the incident or the page. The count is of services with
+// problems
+func (wm *WidgetManager) UpdateStatusPagesWidget(services []ServiceStatus) {
+	items := []WidgetItem{}
+	affected := 0
+	for _, service := range services {
+		if service.Err != nil {
+			items = append(items, WidgetItem{Title: service.Name, Subtitle: "Status page unreachable: " + service.Err.Error(), Status: "❓", URL: service.URL})
+			continue
+		}
+		if service.Level == serviceOperational {
+			items = append(items, WidgetItem{Title: service.Name, Subtitle: "All systems operational", Status: "✅", URL: service.URL})
+			continue
+		}
+		affected++
+
+		statusIcon := "🟡"
+		switch service.Level {
+		case serviceMaintenance:
+			statusIcon = "🔧"
+		case servicePartialOutage:
+			statusIcon = "🟠"
+		case serviceMajorOutage:
+			statusIcon = "🔴"
+		}
+
+		title := service.Name
+		if len(service.Degraded) > 0 {
+			title += ": " + strings.Join(service.Degraded, ", ")
+		}
+		subtitle := serviceLevelNames[service.Level]
+		if service.Incident != "" {
+			subtitle += " • " + service.Incident
+		}
+		item := WidgetItem{Title: title, Subtitle: subtitle, Status: statusIcon, URL: service.URL, Since: service.Since}
+		if !service.Since.IsZero() {
+			item = item.withAge(time.Now(), subtitle+" • for ", true, "")
+		}
+		items = append(items, item)
+	}
+
+	if wm.Widgets["status"] == nil {
+		wm.Widgets["status"] = &Widget{Title: "Service Status"}
+	}
+	wm.Widgets["status"].Items = items
+	wm.Widgets["status"].Count = affected
+	wm.Widgets["status"].HasError = false
+}
+
+// UpdateUptimeWidget updates the uptime widget with a line per endpoint, down ones first,
+// showing the latency, the share of recent checks that were up and their history. The
+// count is of endpoints that are down
+func (wm *WidgetManager) UpdateUptimeWidget(endpoints []EndpointStatus) {
+	items := []WidgetItem{}
+	down := 0
+	for _, endpoint := range endpoints {
+		statusIcon := "🟢"
+		detail := formatLatency(endpoint.Latency)
+		if !endpoint.Up {
+			statusIcon = "🔴"
+			detail = endpoint.Reason
+			down++
+		}
+		subtitle := fmt.Sprintf("%s • %.0f%% up • %s", detail, endpoint.Uptime()*100, uptimeHistoryBar(endpoint.History))
+		items = append(items, WidgetItem{Title: endpoint.Name, Subtitle: subtitle, Status: statusIcon, URL: endpoint.URL})
+	}
+
+	if wm.Widgets["uptime"] == nil {
+		wm.Widgets["uptime"] = &Widget{Title: "Uptime"}
+	}
+	wm.Widgets["uptime"].Items = items
+	wm.Widgets["uptime"].Count = down
+	wm.Widgets["uptime"].HasError = false
+}
+
+// UpdateCertsWidget updates the certificates widget with a line per host, soonest expiry
+// first, showing when the certificate expires and who issued it. The count is of hosts
+// that need attention
+func (wm *WidgetManager) UpdateCertsWidget(certs []CertStatus, now time.Time) {
+	items := []WidgetItem{}
+	attention := 0
+	for _, cert := range certs {
+		if cert.Level != "🟢" {
+			attention++
+		}
+		if cert.Err != "" {
+			items = append(items, WidgetItem{Title: cert.Host, Subtitle: cert.Err, Status: cert.Level})
+			continue
+		}
+		parts := []string{formatDaysLeft(cert.DaysLeft(now)), cert.Expires.Local().Format("02 Jan 2006")}
+		if cert.Problem != "" && cert.Problem != "expired" {
+			parts = append(parts, cert.Problem)
+		}
+		if cert.Intermediate != "" {
+			parts = append(parts, "intermediate "+cert.Intermediate)
+		} else if cert.Issuer != "" {
+			parts = append(parts, cert.Issuer)
+		}
+		items = append(items, WidgetItem{
+			Title:    cert.Host,
+			Subtitle: strings.Join(parts, " • "),
+			Status:   cert.Level,
+			URL:      "https://" + cert.Host,
+		})
+	}
+
+	if wm.Widgets["certs"] == nil {
+		wm.Widgets["certs"] = &Widget{Title: "Certificates"}
+	}
+	wm.Widgets["certs"].Items = items
+	wm.Widgets["certs"].Count = attention
+	wm.Widgets["certs"].HasError = false
+}
+
+// UpdateDomainsWidget updates the domains widget with a line per domain, soonest expiry
+// first, showing when it expires and its registrar. The count is of domains that need
+// renewing soon or could not be looked up
+func (wm *WidgetManager) UpdateDomainsWidget(domains []DomainStatus, now time.Time) {
+	items := []WidgetItem{}
+	attention := 0
+	for _, domain := range domains {
+		if domain.Level != "🟢" {
+			attention++
+		}
+		if domain.Err != "" {
+			items = append(items, WidgetItem{Title: domain.Domain, Subtitle: domain.Err, Status: domain.Level})
+			continue
+		}
+		parts := []string{formatDaysLeft(daysUntil(domain.Expires, now)), domain.Expires.Local().Format("02 Jan 2006")}
+		if domain.Problem != "" {
+			parts = append(parts, domain.Problem)
+		}
+		if domain.Registrar != "" {
+			parts = append(parts, domain.Registrar)
+		}
+		items = append(items, WidgetItem{
+			Title:    domain.Domain,
+			Subtitle: strings.Join(parts, " • "),
+			Status:   domain.Level,
+			URL:      "https://" + domain.Domain,
+		})
+	}
+
+	if wm.Widgets["domains"] == nil {
+		wm.Widgets["domains"] = &Widget{Title: "Domains"}
+	}
+	wm.Widgets["domains"].Items = items
+	wm.Widgets["domains"].Count = attention
+	wm.Widgets["domains"].HasError = false
+}
+
+// UpdateTrendsWidget updates the trends widget with the PRs awaiting your review and the
+// issues assigned to you, each charted over the past weeks. The count is both together.
+func (wm *WidgetManager) UpdateTrendsWidget(trend WorkloadTrend) {
+	days := trend.Days
+	if len(days) == 0 {
+		days = []WorkloadSample{trend.Current}
+	}
+	items := []WidgetItem{
+		trendLine("Reviews awaiting me", days, func(s WorkloadSample) int { return s.Reviews }, "https://github.com/pulls/review-requested"),
+		trendLine("Issues assigned to me", days, func(s WorkloadSample) int { return s.Issues }, "https://github.com/issues/assigned"),
+	}
+
+	if wm.Widgets["trends"] == nil {
+		wm.Widgets["trends"] = &Widget{Title: "Trends"}
+	}
+	wm.Widgets["trends"].Items = items
+	wm.Widgets["trends"].Count = trend.Current.Reviews + trend.Current.Issues
+	wm.Widgets["trends"].HasError = false
+}
+
+// UpdateReleasesWidget updates the releases widget with the latest release of each
+// watched repo, new ones first, linking to its release notes. The count is of new releases.
+func (wm *WidgetManager) UpdateReleasesWidget(releases []Release, now time.Time) {
+	items := []WidgetItem{}
+	fresh := 0
+	for _, release := range releases {
+		if release.Err != "" {
+			items = append(items, WidgetItem{Title: release.Repo, Subtitle: release.Err, Status: "❌"})
+			continue
+		}
+		var parts []string
+		if release.Name != "" && release.Name != release.Tag {
+			parts = append(parts, release.Name)
+		}
+		if release.New {
+			fresh++
+			if release.Newer > 1 {
+				parts = append(parts, fmt.Sprintf("%d releases since %s", release.Newer, release.Previous))
+			} else {
+				parts = append(parts, "new since "+release.Previous)
+			}
+		}
+		subtitle := strings.Join(parts, " • ")
+		status := "📦"
+		if release.New {
+			status = "🆕"
+		}
+		item := WidgetItem{
+			Title:    release.Repo + " " + release.Tag,
+			Subtitle: subtitle,
+			Status:   status,
+			URL:      release.URL,
+			Since:    release.Published,
+		}
+		if !release.Published.IsZero() {
+			if subtitle != "" {
+				subtitle += " • "
+			}
+			item = item.withAge(now, subtitle, true, " ago")
+		}
+		items = append(items, item)
+	}
+
+	if wm.Widgets["releases"] == nil {
+		wm.Widgets["releases"] = &Widget{Title: "Releases"}
+	}
+	wm.Widgets["releases"].Items = items
+	wm.Widgets["releases"].Count = fresh
+	wm.Widgets["releases"].HasError = false
+}
+
+// UpdateVulnsWidget updates the vulnerabilities widget with a line per vulnerable package
+// of each module, those whose vulnerable code is called first, or one line for a module
+// with none. The count is of vulnerabilities whose code is called.
+func (wm *WidgetManager) UpdateVulnsWidget(reports []VulnReport) {
+	items := []WidgetItem{}
+	called := 0
+	for _, report := range reports {
+		if report.Err != "" {
+			items = append(items, WidgetItem{Title: report.Path, Subtitle: report.Err, Status: "❌"})
+			continue
+		}
+		if len(report.Vulns) == 0 {
+			subtitle := "No known vulnerabilities in imported packages"
+			if report.Required > 0 {
+				subtitle += fmt.Sprintf(" • %d in required modules", report.Required)
+			}
+			items = append(items, WidgetItem{Title: report.Path, Subtitle: subtitle, Status: "✅"})
+			continue
+		}
+		for _, vuln := range report.Vulns {
+			parts := []string{report.Path}
+			status := "🟡"
+			if vuln.Called() {
+				called++
+				status = "🔴"
+				parts = append(parts, "calls "+vuln.Symbol)
+			} else {
+				parts = append(parts, "imported, not called")
+			}
+			if vuln.Fixed != "" {
+				parts = append(parts, fmt.Sprintf("%s → %s", vuln.Version, vuln.Fixed))
+			} else {
+				parts = append(parts, "no fix yet")
+			}
+			if vuln.Summary != "" {
+				parts = append(parts, vuln.Summary)
+			}
+			items = append(items, WidgetItem{
+				Title:    vuln.ID + " " + vuln.Package,
+				Subtitle: strings.Join(parts, " • "),
+				Status:   status,
+				URL:      "https://pkg.go.dev/vuln/" + vuln.ID,
+			})
+		}
+	}
+
+	if wm.Widgets["vulns"] == nil {
+		wm.Widgets["vulns"] = &Widget{Title: "Go Vulnerabilities"}
+	}
+	wm.Widgets["vulns"].Items = items
+	wm.Widgets["vulns"].Count = called
+	wm.Widgets["vulns"].HasError = false
+}
+
+// UpdateSystemWidget updates the system widget with CPU, load, memory and each disk,
+// colored by the thresholds, then the busiest processes. The count is of resources at
+// or above the warning threshold.
+func (wm *WidgetManager) UpdateSystemWidget(snapshot SystemSnapshot) {
+	level := func(used float64) string {
+		return resourceLevel(used, snapshot.WarnPercent, snapshot.CriticalPercent)
+	}
+
+	items := []WidgetItem{{
+		Title:    fmt.Sprintf("CPU %.0f%%", snapshot.CPUPercent),
+		Subtitle: fmt.Sprintf("%d cores", snapshot.Cores),
+		Status:   level(snapshot.CPUPercent),
+	}}
+	if snapshot.Load != [3]float64{} {
+		// A load of one per core keeps every core busy
+		items = append(items, WidgetItem{
+			Title:    fmt.Sprintf("Load %.2f %.2f %.2f", snapshot.Load[0], snapshot.Load[1], snapshot.Load[2]),
+			Subtitle: "1, 5 and 15 minute averages",
+			Status:   level(snapshot.Load[0] / float64(snapshot.Cores) * 100),
+		})
+	}
+	memory := percent(snapshot.MemoryUsed, snapshot.MemoryTotal)
+	items = append(items, WidgetItem{
+		Title:    fmt.Sprintf("Memory %.0f%%", memory),
+		Subtitle: fmt.Sprintf("%s of %s", formatBytes(snapshot.MemoryUsed), formatBytes(snapshot.MemoryTotal)),
+		Status:   level(memory),
+	})
+	for _, disk := range snapshot.Disks {
+		if disk.Err != "" {
+			items = append(items, WidgetItem{Title: "Disk " + disk.Path, Subtitle: disk.Err, Status: "❌"})
+			continue
+		}
+		used := percent(disk.Used, disk.Total)
+		items = append(items, WidgetItem{
+			Title:    fmt.Sprintf("Disk %s %.0f%%", disk.Path, used),
+			Subtitle: fmt.Sprintf("%s of %s", formatBytes(disk.Used), formatBytes(disk.Total)),
+			Status:   level(used),
+		})
+	}
+
+	attention := 0
+	for _, item := range items {
+		if item.Status != "🟢" {
+			attention++
+		}
+	}
+	for _, process := range snapshot.Top {
+		items = append(items, WidgetItem{
+			Title:    process.Name,
+			Subtitle: fmt.Sprintf("%.1f%% CPU • %s • pid %d", process.CPUPercent, formatBytes(process.Memory), process.PID),
+			Status:   "⚙️",
+		})
+	}
+
+	if wm.Widgets["system"] == nil {
+		wm.Widgets["system"] = &Widget{Title: "System"}
+	}
+	wm.Widgets["system"].Items = items
+	wm.Widgets["system"].Count = attention
+	wm.Widgets["system"].HasError = false
+}
+
+// UpdateNetworkWidget updates the network widget with a verdict on the connection, the
+// latency to each anchor and the last speed test. The count is of anchors not answering
+// quickly.
+func (wm *WidgetManager) UpdateNetworkWidget(status NetworkStatus) {
+	title, subtitle, level := networkVerdict(status.Anchors)
+	items := []WidgetItem{{Title: title, Subtitle: subtitle, Status: level}}
+
+	attention := 0
+	for _, anchor := range status.Anchors {
+		if anchor.Level != "🟢" {
+			attention++
+		}
+		if anchor.Err != "" {
+			items = append(items, WidgetItem{Title: anchor.Name, Subtitle: anchor.Err, Status: anchor.Level})
+			continue
+		}
+		detail := anchor.Address
+		if anchor.Lost > 0 {
+			detail += fmt.Sprintf(" • %d of %d probes lost", anchor.Lost, networkProbes)
+		}
+		items = append(items, WidgetItem{
+			Title:    fmt.Sprintf("%s %s", anchor.Name, formatLatency(anchor.Latency)),
+			Subtitle: detail,
+			Status:   anchor.Level,
+		})
+	}
+
+	if speed := status.Speed; speed != nil {
+		if speed.Err != "" {
+			items = append(items, WidgetItem{Title: "Speed test failed", Subtitle: speed.Err, Status: "❌"})
+		} else {
+			items = append(items, WidgetItem{
+				Title:    fmt.Sprintf("Download %.0f Mbps", speed.Mbps),
+				Subtitle: "Measured at " + speed.At.Format("15:04"),
+				Status:   "⬇️",
+			})
+		}
+	}
+
+	if wm.Widgets["network"] == nil {
+		wm.Widgets["network"] = &Widget{Title: "Network"}
+	}
+	wm.Widgets["network"].Items = items
+	wm.Widgets["network"].Count = attention
+	wm.Widgets["network"].HasError = false
+}
+
+// UpdateTimeTrackingWidget shows the running timer, the time tracked today against the
+// daily target, and the latest entries
+func (wm *WidgetManager) UpdateTimeTrackingWidget(status TimeTrackingStatus) {
+	var items []WidgetItem
+	if entry := status.Current; entry != nil {
+		subtitle := fmt.Sprintf("Running %s since %s • G stops", formatTracked(entry.Duration), entry.Start.Local().Format("15:04"))
+		if project := timeEntryProject(*entry); project != "" {
+			subtitle = project + " • " + subtitle
+		}
+		items = append(items, WidgetItem{Title: timeEntryTitle(*entry), Subtitle: subtitle, Status: "⏱️", URL: status.URL})
+	} else {
+		items = append(items, WidgetItem{Title: "No timer running", Subtitle: "Press g to start one", Status: "⏸️", URL: status.URL})
+	}
+
+	today := WidgetItem{
+		Title:    fmt.Sprintf("%s tracked today", formatTracked(status.Today)),
+		Subtitle: fmt.Sprintf("%d entries since midnight", status.Entries),
+		Status:   "📊",
+		URL:      status.URL,
+	}
+	if status.Target > 0 {
+		today.Title = fmt.Sprintf("%s of %s today", formatTracked(status.Today), formatTracked(status.Target))
+		if left := status.Target - status.Today; left > 0 {
+			today.Subtitle += fmt.Sprintf(" • %s to go", formatTracked(left))
+		} else {
+			today.Status = "✅"
+		}
+	}
+	items = append(items, today)
+
+	for _, entry := range status.Recent {
+		if entry.Running {
+			continue
+		}
+		subtitle := formatTracked(entry.Duration) + " • " + entry.Start.Local().Format("Mon 15:04")
+		if project := timeEntryProject(entry); project != "" {
+			subtitle = project + " • " + subtitle
+		}
+		items = append(items, WidgetItem{Title: timeEntryTitle(entry), Subtitle: subtitle, Status: "🕒", URL: status.URL})
+	}
+
+	if wm.Widgets["toggl"] == nil {
+		wm.Widgets["toggl"] = &Widget{Title: "Time Tracking"}
+	}
+	wm.Widgets["toggl"].Items = items
+	wm.Widgets["toggl"].Count = status.Entries
+	wm.Widgets["toggl"].HasError = false
+}
+
+// timeEntryTitle is a time entry's description, or says it has none
+func timeEntryTitle(entry TimeEntry) string {
+	if entry.Description == "" {
+		return "(no description)"
+	}
+	return entry.Description
+}
+
+// timeEntryProject names a time entry's project and, on Harvest, its task
+func timeEntryProject(entry TimeEntry) string {
+	if entry.Task != "" && entry.Project != "" {
+		return entry.Project + " / " + entry.Task
+	}
+	return entry.Project
+}
+
+// formatTracked shows tracked time in whole minutes, e.g. "2h5m" or "0m"
+func formatTracked(d time.Duration) string {
+	if d < time.Minute {
+		return "0m"
+	}
+	return formatInterval(d.Truncate(time.Minute))
+}
+
+// UpdateNotesWidget shows the notes, latest first, with when each was captured
+func (wm *WidgetManager) UpdateNotesWidget(notes []Note) {
+	items := []WidgetItem{{Title: "No notes yet", Subtitle: "Press n to jot one down, N to open the file"}}
+	if len(notes) > 0 {
+		items = nil
+	}
+	for _, note := range notes {
+		subtitle := ""
+		if !note.At.IsZero() {
+			subtitle = note.At.Format("Mon 02 Jan 15:04")
+		}
+		items = append(items, WidgetItem{Title: note.Text, Subtitle: subtitle, Status: "📝"})
+	}
+
+	if wm.Widgets["notes"] == nil {
+		wm.Widgets["notes"] = &Widget{Title: "Notes"}
+	}
+	wm.Widgets["notes"].Items = items
+	wm.Widgets["notes"].Count = len(notes)
+	wm.Widgets["notes"].HasError = false
+}
+
+// UpdateRemindersWidget updates the reminders widget with a countdown to each upcoming
+// date, soonest first. The count is of reminders due today.
+func (wm *WidgetManager) UpdateRemindersWidget(reminders []UpcomingReminder) {
+	items := []WidgetItem{}
+	today := 0
+	for _, reminder := range reminders {
+		parts := []string{formatReminderCountdown(reminder.Days), reminder.On.Format("Mon 2 Jan")}
+		if reminder.Years > 0 {
+			parts = append(parts, fmt.Sprintf("%d years", reminder.Years))
+		}
+		status := "📅"
+		switch {
+		case reminder.Days == 0:
+			today++
+			status = "🔔"
+		case reminder.Days <= 7:
+			status = "🟡"
+		}
+		items = append(items, WidgetItem{
+			Title:    reminder.Name,
+			Subtitle: strings.Join(parts, " • "),
+			Status:   status,
+			URL:      reminder.URL,
+		})
+	}
+	if len(items) == 0 {
+		items = append(items, WidgetItem{Title: "Nothing coming up", Subtitle: "No reminders in the days ahead"})
+	}
+
+	if wm.Widgets["reminders"] == nil {
+		wm.Widgets["reminders"] = &Widget{Title: "Reminders"}
+	}
+	wm.Widgets["reminders"].Items = items
+	wm.Widgets["reminders"].Count = today
+	wm.Widgets["reminders"].HasError = false
+}
+
+// UpdatePomodoroWidget shows the countdown of the running phase and the sessions
+// completed today, latest first
+func (wm *WidgetManager) UpdatePomodoroWidget(status PomodoroStatus) {
+	var items []WidgetItem
+	switch status.Phase {
+	case pomodoroWork:
+		items = append(items, WidgetItem{Title: "Focus " + formatCountdown(status.Remaining), Subtitle: "f pauses • F skips to the break", Status: "🍅"})
+	case pomodoroBreak:
+		items = append(items, WidgetItem{Title: "Break " + formatCountdown(status.Remaining), Subtitle: "f pauses • F ends the break", Status: "☕"})
+	default:
+		items = append(items, WidgetItem{Title: "No pomodoro running", Subtitle: fmt.Sprintf("Press f to focus for %s", formatMinutes(status.Work))})
+	}
+	if status.Paused {
+		items[0].Title += " (paused)"
+		items[0].Subtitle = "f resumes • F skips"
+		items[0].Status = "⏸️"
+	}
+
+	var focused time.Duration
+	for _, session := range status.Today {
+		focused += session.End.Sub(session.Start)
+	}
+	if len(status.Today) > 0 {
+		items = append(items, WidgetItem{
+			Title:    fmt.Sprintf("%d completed today", len(status.Today)),
+			Subtitle: formatMinutes(focused) + " focused",
+			Status:   "✅",
+		})
+	}
+	for i := len(status.Today) - 1; i >= 0; i-- {
+		session := status.Today[i]
+		items = append(items, WidgetItem{
+			Title:    session.Start.Format("15:04") + "–" + session.End.Format("15:04"),
+			Subtitle: formatMinutes(session.End.Sub(session.Start)),
+		})
+	}
+
+	if wm.Widgets["pomodoro"] == nil {
+		wm.Widgets["pomodoro"] = &Widget{Title: "Pomodoro"}
+	}
+	wm.Widgets["pomodoro"].Items = items
+	wm.Widgets["pomodoro"].Count = len(status.Today)
+	wm.Widgets["pomodoro"].HasError = false
+}
+
+// UpdateCryptoWidget updates the crypto widget with a quote and last-day sparkline per coin
+func (wm *WidgetManager) UpdateCryptoWidget(quotes []CryptoQuote) {
+	var items []WidgetItem
+	for _, quote := range quotes {
+		status := "⚪"
+		if quote.ChangePercent > 0 {
+			status = "🟢"
+		} else if quote.ChangePercent < 0 {
+			status = "🔴"
+		}
+
+		items = append(items, WidgetItem{
+			Title:    fmt.Sprintf("%s  %s %s", quote.Symbol, formatCryptoPrice(quote.Price), quote.Currency),
+			Subtitle: strings.TrimSpace(fmt.Sprintf("%s %+.2f%% 24h", sparkline(quote.History, cryptoSparklineWidth), quote.ChangePercent)),
+			Status:   status,
+			URL:      "https://www.coingecko.com/en/coins/" + quote.ID,
+		})
+	}
+
+	if wm.Widgets["crypto"] == nil {
+		wm.Widgets["crypto"] = &Widget{Title: "Crypto"}
+	}
+	wm.Widgets["crypto"].Items = items
+	wm.Widgets["crypto"].Count = len(quotes)
+	wm.Widgets["crypto"].HasError = false
+}
+
+// UpdateFXWidget updates the exchange rates widget with a rate per currency pair
+func (wm *WidgetManager) UpdateFXWidget(rates []FXRate) {
+	var items []WidgetItem
+	for _, rate := range rates {
+		status := "⚪"
+		if rate.Change > 0 {
+			status = "🟢"
+		} else if rate.Change < 0 {
+			status = "🔴"
+		}
+
+		items = append(items, WidgetItem{
+			Title:    fmt.Sprintf("%s  %.4f", rate.Pair(), rate.Rate),
+			Subtitle: fmt.Sprintf("%+.4f (%+.2f%%) • %s", rate.Change, rate.ChangePercent, rate.Date.Format("2 Jan")),
+			Status:   status,
+			URL:      "https://finance.yahoo.com/quote/" + rate.Base + rate.Quote + "=X",
+		})
+	}
+
+	if wm.Widgets["fx"] == nil {
+		wm.Widgets["fx"] = &Widget{Title: "Exchange Rates"}
+	}
+	wm.Widgets["fx"].Items = items
+	wm.Widgets["fx"].Count = len(rates)
+	wm.Widgets["fx"].HasError = false
+}
+
+// UpdateAQIWidget updates the air quality tile with the AQI, coloured by health level, and
+// what the level means for outdoor activity
+func (wm *WidgetManager) UpdateAQIWidget(quality *AirQuality) {
+	level := levelForAQI(quality.AQI)
+	details := []string{}
+	if quality.PM25 > 0 {
+		details = append(details, fmt.Sprintf("PM2.5 %.1f µg/m³", quality.PM25))
+	} else if quality.Dominant != "" {
+		details = append(details, "mostly "+pollutantName(quality.Dominant))
+	}
+	if quality.Location != "" {
+		details = append(details, quality.Location)
+	}
+	if !quality.Updated.IsZero() {
+		details = append(details, quality.Updated.Local().Format("15:04"))
+	}
+
+	items := []WidgetItem{
+		{
+			Title:    fmt.Sprintf("AQI %d  %s", quality.AQI, level.name),
+			Subtitle: strings.Join(details, " • "),
+			Status:   level.status,
+			URL:      quality.URL,
+		},
+		{Title: level.advice, Status: "ℹ️", URL: quality.URL},
+	}
+
+	if wm.Widgets["aqi"] == nil {
+		wm.Widgets["aqi"] = &Widget{Title: "Air Quality"}
+	}
+	wm.Widgets["aqi"].Items = items
+	// The tile header shows the index rather than an item count
+	wm.Widgets["aqi"].Count = quality.AQI
+	wm.Widgets["aqi"].HasError = false
+}
+
+// pollutantName spells out a WAQI pollutant code such as pm25
+func pollutantName(code string) string {
+	switch code {
+	case "pm25":
+		return "PM2.5"
+	case "pm10":
+		return "PM10"
+	case "o3":
+		return "ozone"
+	case "no2":
+		return "NO₂"
+	case "so2":
+		return "SO₂"
+	case "co":
+		return "CO"
+	}
+	return code
+}
+
+// UpdateWeatherWidget updates the weather tile with the daily forecast, one day per item
+func (wm *WidgetManager) UpdateWeatherWidget(data *WeatherData, now time.Time) {
+	var items []WidgetItem
+	today := now.Format("2006-01-02")
+	for _, day := range data.Daily {
+		if len(items) == weatherForecastDays {
+			break
+		}
+		// Forecasts start today; a stale first day is skipped
+		if day.Date.Format("2006-01-02") < today {
+			continue
+		}
+		name := day.Date.Format("Mon 2")
+		if day.Date.Format("2006-01-02") == today {
+			name = "Today"
+		}
+		items = append(items, WidgetItem{
+			Title:    fmt.Sprintf("%-7s %s  %d° / %d°", name, day.Icon, day.High, day.Low),
+			Subtitle: day.Condition,
+		})
+	}
+	if len(items) == 0 {
+		items = append(items, WidgetItem{
+			Title:    fmt.Sprintf("%s %d°C now", data.Icon, data.Temperature),
+			Subtitle: "No forecast from this weather provider",
+			Status:   "ℹ️",
+		})
+	}
+
+	if wm.Widgets["weather"] == nil {
+		wm.Widgets["weather"] = &Widget{Title: "Weather"}
+	}
+	wm.Widgets["weather"].Items = items
+	wm.Widgets["weather"].Count = len(items)
+	wm.Widgets["weather"].HasError = false
+}
+
+// formatCryptoPrice shows cents for larger prices and four significant digits below one
+func formatCryptoPrice(price float64) string {
+	if price >= 1 {
+		return fmt.Sprintf("%.2f", price)
+	}
+	return fmt.Sprintf("%.4g", price)
+}
+
+// UpdateCalendarWidget updates the calendar widget with events from a calendar plugin
+func (wm *WidgetManager) UpdateCalendarWidget(calendarPlugin CalendarSource) {
 	if wm.Widgets["calendar"] == nil {
 		wm.Widgets["calendar"] = &Widget{
 			Title: "Calendar",
@@ -483,9 +1726,121 @@ func (wm *WidgetManager) UpdateCalendarWidget(calendarPlugin *GoogleCalendarPlug
 	}
 }
 
+// calendarEventDetails lists when and where an event is, who attends and its description
+func calendarEventDetails(event CalendarEvent) []string {
+	when := event.StartTime.Format("Mon 2 Jan 2006 15:04")
+	if !event.EndTime.IsZero() {
+		when += " - " + event.EndTime.Format("15:04")
+	}
+	details := []string{when}
+	if event.Location != "" {
+		details = append(details, "Location: "+event.Location)
+	}
+	if event.Calendar != "" {
+		details = append(details, "Calendar: "+event.Calendar)
+	}
+	if len(event.Attendees) > 0 {
+		details = append(details, "Attendees: "+strings.Join(event.Attendees, ", "))
+	}
+	if description := strings.TrimSpace(event.Description); description != "" {
+		details = append(details, "", description)
+	}
+	return details
+}
+
+// formatCalendarItems formats upcoming calendar events for display in the widget
+func formatCalendarItems(events []CalendarEvent, now time.Time) []WidgetItem {
+	var items []WidgetItem
+	today := now.Format("2006-01-02")
+
+	for _, event := range events {
+		// Skip past events (except for current ongoing events)
+		if event.EndTime.Before(now) {
+			continue
+		}
+
+		// Format time display
+		var timeStr string
+		eventDate := event.StartTime.Format("2006-01-02")
+
+		if eventDate == today {
+			// Today's events - show time only
+			if event.StartTime.Format("15:04") == event.EndTime.Format("15:04") {
+				// All-day event
+				timeStr = "All day"
+			} else {
+				timeStr = event.StartTime.Format("15:04")
+				if !event.EndTime.IsZero() {
+					timeStr += "-" + event.EndTime.Format("15:04")
+				}
+			}
+		} else {
+			// Future events - show date and time
+			timeStr = event.StartTime.Format("Jan 2")
+			if event.StartTime.Format("15:04") != "00:00" {
+				timeStr += " " + event.StartTime.Format("15:04")
+			}
+		}
+
+		if event.Calendar != "" {
+			timeStr += " • " + event.Calendar
+		}
+
+		// Create status indicator
+		var status string
+		if event.StartTime.Before(now) && event.EndTime.After(now) {
+			status = "🔴" // Currently happening
+		} else if event.StartTime.Sub(now) < 30*time.Minute {
+			status = "🟡" // Starting soon
+		} else {
+			status = "🟢" // Future event
+		}
+
+		items = append(items, WidgetItem{
+			Title:    event.Title,
+			Subtitle: timeStr,
+			Status:   status,
+			URL:      event.URL,
+			Details:  calendarEventDetails(event),
+		})
+
+		// Limit to reasonable number for display
+		if len(items) >= 5 {
+			break
+		}
+	}
+
+	if len(items) == 0 {
+		items = append(items, WidgetItem{
+			Title:    "No upcoming events",
+			Subtitle: "Your calendar is clear",
+			Status:   "📅",
+		})
+	}
+
+	return items
+}
+
+// formatAge formats how long ago t was compactly, e.g. "45m", "5h" or "12d"
+func formatAge(t, now time.Time) string {
+	age := now.Sub(t)
+	switch {
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	}
+}
+
 // formatTimeAgo formats a time as a relative time string
 func formatTimeAgo(t time.Time) string {
-	now := time.Now()
+	return timeAgo(t, time.Now())
+}
+
+// timeAgo formats how long before now t was, e.g. "5 minutes ago", or its date after a week
+func timeAgo(t, now time.Time) string {
 	diff := now.Sub(t)
 
 	if diff < time.Minute {
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// workloadHistoryDays is how long daily workload counts are kept
+const workloadHistoryDays = 90
+
+// WorkloadSample is how many PRs awaited your review and issues were assigned to you
+type WorkloadSample struct {
+	At      time.Time `json:"at"`
+	Reviews int       `json:"reviews"`
+	Issues  int       `json:"issues"`
+}
+
+// WorkloadHistory keeps the last workload count of each day, for the Trends tile
+type WorkloadHistory struct {
+	path string                    // file the history is kept in; empty keeps it in memory
+	Days map[string]WorkloadSample `json:"days"` // "2006-01-02" -> last count that day
+}
+
+// WorkloadHistoryPath returns where the workload history is kept: ~/.goday/workload_history.json
+func WorkloadHistoryPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".goday", "workload_history.json")
+}
+
+// LoadWorkloadHistory reads the history kept in path. A missing or unreadable file
+// starts an empty history, which the next recording overwrites.
+func LoadWorkloadHistory(path string) *WorkloadHistory {
+	history := &WorkloadHistory{path: path, Days: make(map[string]WorkloadSample)}
+	if path == "" {
+		return history
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return history
+	}
+	var saved WorkloadHistory
+	if json.Unmarshal(data, &saved) == nil && saved.Days != nil {
+		history.Days = saved.Days
+	}
+	return history
+}
+
+// workloadDayKey is the local day a sample taken at t counts for
+func workloadDayKey(t time.Time) string {
+	return t.Local().Format("2006-01-02")
+}
+
+// Record keeps a sample as the count of its day, drops days past the kept window and saves
+func (h *WorkloadHistory) Record(sample WorkloadSample) error {
+	if h.path == "" {
+		h.add(sample)
+		return nil
+	}
+	// Another dashboard or goday sync may have changed the file since it was loaded
+	return withFileLock(h.path, func() error {
+		if saved := LoadWorkloadHistory(h.path); len(saved.Days) > 0 {
+			h.Days = saved.Days
+		}
+		h.add(sample)
+		return h.save()
+	})
+}
+
+// add sets the count of the sample's day and forgets days older than the window
+func (h *WorkloadHistory) add(sample WorkloadSample) {
+	h.Days[workloadDayKey(sample.At)] = sample
+	oldest := workloadDayKey(sample.At.AddDate(0, 0, -workloadHistoryDays))
+	for day := range h.Days {
+		if day < oldest {
+			delete(h.Days, day)
+		}
+	}
+}
+
+// Series returns one sample per day over the last days up to today, oldest first,
+// starting later when the history does. Days GoDay did not run repeat the count before them.
+func (h *WorkloadHistory) Series(now time.Time, days int) []WorkloadSample {
+	var keys []string
+	for day := range h.Days {
+		keys = append(keys, day)
+	}
+	sort.Strings(keys)
+
+	var series []WorkloadSample
+	var last *WorkloadSample
+	next := 0
+	start := now.Local().AddDate(0, 0, -days+1)
+	for day := start; !day.After(now.Local()); day = day.AddDate(0, 0, 1) {
+		key := workloadDayKey(day)
+		for next < len(keys) && keys[next] <= key {
+			sample := h.Days[keys[next]]
+			last = &sample
+			next++
+		}
+		if last != nil {
+			series = append(series, *last)
+		}
+	}
+	return series
+}
+
+// save writes the history to its file atomically
+func (h *WorkloadHistory) save() error {
+	if h.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(h.path, data, 0600)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWorkloadHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workload_history.json")
+	day := func(n int) time.Time { return time.Date(2026, 10, n, 10, 0, 0, 0, time.Local) }
+
+	history := LoadWorkloadHistory(path)
+	history.Record(WorkloadSample{At: time.Date(2026, 6, 1, 10, 0, 0, 0, time.Local), Reviews: 9})
+	history.Record(WorkloadSample{At: day(10), Reviews: 2, Issues: 5})
+	history.Record(WorkloadSample{At: day(12), Reviews: 4, Issues: 5})
+	history.Record(WorkloadSample{At: day(12).Add(6 * time.Hour), Reviews: 3, Issues: 4})
+
+	saved := LoadWorkloadHistory(path)
+	if len(saved.Days) != 2 {
+		t.Errorf("Expected the June count to be dropped and one count per day, got %v", saved.Days)
+	}
+
+	series := saved.Series(day(13), 3)
+	if len(series) != 3 {
+		t.Fatalf("Expected a sample for each of 3 days, got %v", series)
+	}
+	if series[0].Reviews != 2 || series[1].Reviews != 3 || series[2].Reviews != 3 {
+		t.Errorf("Expected the 11th to repeat the 10th and the 13th the 12th's last count, got %v", series)
+	}
+	if series := saved.Series(day(13), 30); len(series) != 4 || series[0].Reviews != 2 {
+		t.Errorf("Expected the chart to start with the history, got %v", series)
+	}
+}
+
+func TestMergeWorkloadHistories(t *testing.T) {
+	laptop := []byte(`{"days":{"2026-10-12":{"at":"2026-10-12T09:00:00Z","reviews":4,"issues":5},"2026-10-13":{"at":"2026-10-13T09:00:00Z","reviews":1,"issues":1}}}`)
+	desktop := []byte(`{"days":{"2026-10-12":{"at":"2026-10-12T17:00:00Z","reviews":3,"issues":4}}}`)
+	ab, _ := mergeWorkloadHistories(laptop, desktop)
+	ba, _ := mergeWorkloadHistories(desktop, laptop)
+	if !bytes.Equal(ab, ba) {
+		t.Errorf("Expected the merge not to depend on order:\n%s\n%s", ab, ba)
+	}
+	history := LoadWorkloadHistory(writeTemp(t, ab))
+	if len(history.Days) != 2 || history.Days["2026-10-12"].Reviews != 3 {
+		t.Errorf("Expected the later count of each day, got %s", ab)
+	}
+}
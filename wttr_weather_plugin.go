@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WttrWeatherPlugin fetches current weather from wttr.in, which needs no API key
+type WttrWeatherPlugin struct {
+	id          string
+	pluginType  string
+	name        string
+	version     string
+	description string
+	author      string
+	city        string
+	apiURL      string
+	client      *http.Client
+	lastData    *WeatherData
+}
+
+// NewWttrWeatherPlugin creates a new wttr.in weather plugin
+func NewWttrWeatherPlugin() *WttrWeatherPlugin {
+	return &WttrWeatherPlugin{
+		id:          "wttr",
+		pluginType:  "weather",
+		name:        "wttr.in",
+		version:     "1.0.0",
+		description: "Fetches weather data from wttr.in without an API key",
+		author:      "GoDay Team",
+		apiURL:      "https://wttr.in",
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// GetID returns the plugin ID
+func (wp *WttrWeatherPlugin) GetID() string {
+	return wp.id
+}
+
+// GetType returns the plugin type
+func (wp *WttrWeatherPlugin) GetType() string {
+	return wp.pluginType
+}
+
+// GetMetadata returns plugin metadata
+func (wp *WttrWeatherPlugin) GetMetadata() PluginMetadata {
+	return PluginMetadata{
+		Name:        wp.name,
+		Version:     wp.version,
+		Description: wp.description,
+		Author:      wp.author,
+		Type:        wp.pluginType,
+		Config: map[string]string{
+			"city": wp.city,
+		},
+	}
+}
+
+// Initialize sets up the plugin with configuration
+func (wp *WttrWeatherPlugin) Initialize(config map[string]interface{}) error {
+	if city, ok := config["city"].(string); ok {
+		wp.city = city
+	}
+	return nil
+}
+
+// Fetch retrieves the current conditions and the daily forecast for the city
+func (wp *WttrWeatherPlugin) Fetch(ctx context.Context) (interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", wp.apiURL+"/"+url.PathEscape(wp.city)+"?format=j1", nil)
+	if err != nil {
+		return wp.lastData, err
+	}
+
+	resp, err := wp.client.Do(req)
+	if err != nil {
+		return wp.lastData, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return wp.lastData, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return wp.lastData, fmt.Errorf("wttr.in returned status %d", resp.StatusCode)
+	}
+
+	var report struct {
+		CurrentCondition []struct {
+			TempC       string `json:"temp_C"`
+			WeatherCode string `json:"weatherCode"`
+			WeatherDesc []struct {
+				Value string `json:"value"`
+			} `json:"weatherDesc"`
+		} `json:"current_condition"`
+		Weather []struct {
+			Date     string `json:"date"`
+			MaxTempC string `json:"maxtempC"`
+			MinTempC string `json:"mintempC"`
+			Hourly   []struct {
+				Time        string `json:"time"`
+				WeatherCode string `json:"weatherCode"`
+				WeatherDesc []struct {
+					Value string `json:"value"`
+				} `json:"weatherDesc"`
+			} `json:"hourly"`
+		} `json:"weather"`
+	}
+	if err := json.Unmarshal(body, &report); err != nil {
+		return wp.lastData, err
+	}
+	if len(report.CurrentCondition) == 0 {
+		return wp.lastData, fmt.Errorf("wttr.in has no current conditions for %q", wp.city)
+	}
+
+	current := report.CurrentCondition[0]
+	temperature, err := strconv.Atoi(current.TempC)
+	if err != nil {
+		return wp.lastData, fmt.Errorf("invalid temperature %q from wttr.in", current.TempC)
+	}
+	code, _ := strconv.Atoi(current.WeatherCode)
+	condition := ""
+	if len(current.WeatherDesc) > 0 {
+		condition = current.WeatherDesc[0].Value
+	}
+
+	data := &WeatherData{
+		Temperature: temperature,
+		Condition:   condition,
+		Icon:        wwoWeatherIcon(code),
+	}
+
+	// wttr.in forecasts three days in three-hour steps; midday stands for the day
+	for _, day := range report.Weather {
+		date, err := time.ParseInLocation("2006-01-02", day.Date, time.Local)
+		if err != nil {
+			continue
+		}
+		high, _ := strconv.Atoi(day.MaxTempC)
+		low, _ := strconv.Atoi(day.MinTempC)
+		forecast := WeatherDay{Date: date, High: high, Low: low, Icon: "☁"}
+		for _, hour := range day.Hourly {
+			if hour.Time != "1200" {
+				continue
+			}
+			code, _ := strconv.Atoi(hour.WeatherCode)
+			forecast.Icon = wwoWeatherIcon(code)
+			if len(hour.WeatherDesc) > 0 {
+				forecast.Condition = strings.TrimSpace(hour.WeatherDesc[0].Value)
+			}
+		}
+		data.Daily = append(data.Daily, forecast)
+	}
+	wp.lastData = data
+	return data, nil
+}
+
+// Cleanup performs cleanup
+func (wp *WttrWeatherPlugin) Cleanup() error {
+	return nil
+}
+
+// wwoWeatherIcon maps the World Weather Online condition codes used by wttr.in to an icon
+func wwoWeatherIcon(code int) string {
+	switch code {
+	case 113:
+		return "☀"
+	case 116:
+		return "⛅"
+	case 119, 122:
+		return "☁"
+	case 143, 248, 260:
+		return "🌫"
+	case 200, 386, 389, 392, 395:
+		return "⛈"
+	case 179, 182, 185, 227, 230, 281, 284, 311, 314, 317, 320, 323, 326, 329, 332, 335, 338, 350, 362, 365, 368, 371, 374, 377:
+		return "❄"
+	case 176, 263, 266, 293, 296, 299, 302, 305, 308, 353, 356, 359:
+		return "🌧"
+	default:
+		return "☁"
+	}
+}
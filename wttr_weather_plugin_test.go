@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWttrWeatherPluginFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/Berlin,DE" || r.URL.Query().Get("format") != "j1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"current_condition":[{"temp_C":"14","weatherCode":"296","weatherDesc":[{"value":"Light rain"}]}],
+			"weather":[{"date":"2026-10-16","maxtempC":"17","mintempC":"9","hourly":[
+				{"time":"900","weatherCode":"296","weatherDesc":[{"value":"Light rain"}]},
+				{"time":"1200","weatherCode":"116","weatherDesc":[{"value":"Partly cloudy "}]}]}]}`)
+	}))
+	defer server.Close()
+
+	plugin := NewWttrWeatherPlugin()
+	plugin.apiURL = server.URL
+	plugin.Initialize(map[string]interface{}{"city": "Berlin,DE"})
+
+	data, err := plugin.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	weather := data.(*WeatherData)
+	if weather.Temperature != 14 || weather.Icon != "🌧" || weather.Condition != "Light rain" {
+		t.Errorf("Expected light rain at 14°C, got %+v", weather)
+	}
+	if len(weather.Daily) != 1 || weather.Daily[0].High != 17 || weather.Daily[0].Low != 9 || weather.Daily[0].Icon != "⛅" || weather.Daily[0].Condition != "Partly cloudy" {
+		t.Errorf("Expected the day's range with its midday conditions, got %+v", weather.Daily)
+	}
+
+	// Failures keep the last data instead of inventing weather
+	plugin.Initialize(map[string]interface{}{"city": "Nowhere"})
+	data, err = plugin.Fetch(context.Background())
+	if err == nil || data.(*WeatherData).Temperature != 14 {
+		t.Errorf("Expected an error with the last data, got %+v, %v", data, err)
+	}
+}
+
+func TestWeatherProviderSelection(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.WidgetProviderName("weather"); got != "" {
+		t.Errorf("Expected the default provider without a key, got '%s'", got)
+	}
+	if got := DefaultProviderRegistry().DefaultProvider("weather"); got != "wttr" {
+		t.Errorf("Expected wttr as the default weather provider, got '%s'", got)
+	}
+
+	cfg.Widgets.Weather.APIKey = "YOUR_OWM_API_KEY"
+	if got := cfg.WidgetProviderName("weather"); got != "" {
+		t.Errorf("Expected the placeholder key not to select OpenWeatherMap, got '%s'", got)
+	}
+	cfg.Widgets.Weather.APIKey = "real-key"
+	if got := cfg.WidgetProviderName("weather"); got != "openweathermap" {
+		t.Errorf("Expected a key to keep OpenWeatherMap, got '%s'", got)
+	}
+	cfg.Widgets.Weather.Provider = "wttr"
+	if got := cfg.WidgetProviderName("weather"); got != "wttr" {
+		t.Errorf("Expected the configured provider to win, got '%s'", got)
+	}
+
+	plugin, _, err := DefaultProviderRegistry().Create("weather", "owm", cfg, "Berlin,DE")
+	if err != nil || plugin.GetID() != "openweathermap" {
+		t.Errorf("Expected owm to be an alias for OpenWeatherMap, got %v", err)
+	}
+}